@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"net"
 	"reflect"
+	"slices"
 
 	corev1 "k8s.io/api/core/v1"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
@@ -954,6 +955,10 @@ func restore_v1alpha3_VirtualMachineReadinessProbeSpec(
 			dst.Spec.ReadinessProbe = &vmopv1.VirtualMachineReadinessProbeSpec{}
 		}
 		dst.Spec.ReadinessProbe.GuestInfo = src.Spec.ReadinessProbe.GuestInfo
+		dst.Spec.ReadinessProbe.HTTPGet = src.Spec.ReadinessProbe.HTTPGet
+		dst.Spec.ReadinessProbe.InitialDelaySeconds = src.Spec.ReadinessProbe.InitialDelaySeconds
+		dst.Spec.ReadinessProbe.SuccessThreshold = src.Spec.ReadinessProbe.SuccessThreshold
+		dst.Spec.ReadinessProbe.FailureThreshold = src.Spec.ReadinessProbe.FailureThreshold
 	}
 }
 
@@ -988,6 +993,22 @@ func restore_v1alpha3_VirtualMachineBootstrapCloudInitInstanceID(
 	dst.Spec.Bootstrap.CloudInit.InstanceID = iid
 }
 
+func restore_v1alpha3_VirtualMachineBootstrapIgnition(dst, src *vmopv1.VirtualMachine) {
+	var ignition *vmopv1.VirtualMachineBootstrapIgnitionSpec
+	if bs := src.Spec.Bootstrap; bs != nil {
+		ignition = bs.Ignition
+	}
+
+	if ignition == nil {
+		return
+	}
+
+	if dst.Spec.Bootstrap == nil {
+		dst.Spec.Bootstrap = &vmopv1.VirtualMachineBootstrapSpec{}
+	}
+	dst.Spec.Bootstrap.Ignition = ignition
+}
+
 func restore_v1alpha3_VirtualMachineGuestID(dst, src *vmopv1.VirtualMachine) {
 	dst.Spec.GuestID = src.Spec.GuestID
 }
@@ -996,6 +1017,93 @@ func restore_v1alpha3_VirtualMachineCdrom(dst, src *vmopv1.VirtualMachine) {
 	dst.Spec.Cdrom = src.Spec.Cdrom
 }
 
+func restore_v1alpha3_VirtualMachineVolumes(dst, src *vmopv1.VirtualMachine) {
+	// The ephemeral, ConfigMap, and Secret volume sources do not exist in this
+	// version. If the volumes backed by PVCs are unchanged, then restore all
+	// of the volumes. Otherwise, restore the volumes not backed by PVCs after
+	// the ones that are.
+	pvcVolumes := func(in []vmopv1.VirtualMachineVolume) []vmopv1.VirtualMachineVolume {
+		var out []vmopv1.VirtualMachineVolume
+		for _, v := range in {
+			if v.PersistentVolumeClaim != nil {
+				out = append(out, vmopv1.VirtualMachineVolume{
+					Name: v.Name,
+					VirtualMachineVolumeSource: vmopv1.VirtualMachineVolumeSource{
+						PersistentVolumeClaim: v.PersistentVolumeClaim,
+					},
+				})
+			}
+		}
+		return out
+	}
+
+	if apiequality.Semantic.DeepEqual(pvcVolumes(dst.Spec.Volumes), pvcVolumes(src.Spec.Volumes)) {
+		dst.Spec.Volumes = src.Spec.Volumes
+		return
+	}
+
+	dst.Spec.Volumes = slices.DeleteFunc(dst.Spec.Volumes, func(v vmopv1.VirtualMachineVolume) bool {
+		return v.PersistentVolumeClaim == nil
+	})
+	for _, v := range src.Spec.Volumes {
+		if v.PersistentVolumeClaim == nil {
+			dst.Spec.Volumes = append(dst.Spec.Volumes, v)
+		}
+	}
+}
+
+func restore_v1alpha3_VirtualMachineAdvancedSpecHotAdd(dst, src *vmopv1.VirtualMachine) {
+	srcAdv := src.Spec.Advanced
+	if srcAdv == nil || (srcAdv.CPUHotAddEnabled == nil && srcAdv.MemoryHotAddEnabled == nil) {
+		return
+	}
+	if dst.Spec.Advanced == nil {
+		dst.Spec.Advanced = &vmopv1.VirtualMachineAdvancedSpec{}
+	}
+	dst.Spec.Advanced.CPUHotAddEnabled = srcAdv.CPUHotAddEnabled
+	dst.Spec.Advanced.MemoryHotAddEnabled = srcAdv.MemoryHotAddEnabled
+}
+
+func restore_v1alpha3_VirtualMachineAdvancedSpecSerialConsole(dst, src *vmopv1.VirtualMachine) {
+	srcAdv := src.Spec.Advanced
+	if srcAdv == nil || srcAdv.SerialConsoleEnabled == nil {
+		return
+	}
+	if dst.Spec.Advanced == nil {
+		dst.Spec.Advanced = &vmopv1.VirtualMachineAdvancedSpec{}
+	}
+	dst.Spec.Advanced.SerialConsoleEnabled = srcAdv.SerialConsoleEnabled
+}
+
+func restore_v1alpha3_VirtualMachinePowerOffGracePeriod(dst, src *vmopv1.VirtualMachine) {
+	dst.Spec.PowerOffTimeoutSeconds = src.Spec.PowerOffTimeoutSeconds
+	dst.Spec.PreStop = src.Spec.PreStop
+}
+
+func restore_v1alpha3_VirtualMachinePowerSchedule(dst, src *vmopv1.VirtualMachine) {
+	dst.Spec.PowerSchedule = src.Spec.PowerSchedule
+}
+
+func restore_v1alpha3_VirtualMachineClone(dst, src *vmopv1.VirtualMachine) {
+	dst.Spec.Clone = src.Spec.Clone
+}
+
+func restore_v1alpha3_VirtualMachineCurrentSnapshot(dst, src *vmopv1.VirtualMachine) {
+	dst.Spec.CurrentSnapshot = src.Spec.CurrentSnapshot
+}
+
+func restore_v1alpha3_VirtualMachinePlacement(dst, src *vmopv1.VirtualMachine) {
+	dst.Spec.Placement = src.Spec.Placement
+}
+
+func restore_v1alpha3_VirtualMachineDriftPolicy(dst, src *vmopv1.VirtualMachine) {
+	dst.Spec.DriftPolicy = src.Spec.DriftPolicy
+}
+
+func restore_v1alpha3_VirtualMachineLivenessProbe(dst, src *vmopv1.VirtualMachine) {
+	dst.Spec.LivenessProbe = src.Spec.LivenessProbe
+}
+
 func convert_v1alpha1_PreReqsReadyCondition_to_v1alpha3_Conditions(
 	dst *vmopv1.VirtualMachine) []metav1.Condition {
 
@@ -1244,10 +1352,21 @@ func (src *VirtualMachine) ConvertTo(dstRaw ctrlconversion.Hub) error {
 	restore_v1alpha3_VirtualMachineReadinessProbeSpec(dst, restored)
 	restore_v1alpha3_VirtualMachineBiosUUID(dst, restored)
 	restore_v1alpha3_VirtualMachineBootstrapCloudInitInstanceID(dst, restored)
+	restore_v1alpha3_VirtualMachineBootstrapIgnition(dst, restored)
 	restore_v1alpha3_VirtualMachineInstanceUUID(dst, restored)
 	restore_v1alpha3_VirtualMachineGuestID(dst, restored)
 	restore_v1alpha3_VirtualMachineCdrom(dst, restored)
+	restore_v1alpha3_VirtualMachineVolumes(dst, restored)
 	restore_v1alpha3_VirtualMachineCryptoSpec(dst, restored)
+	restore_v1alpha3_VirtualMachineAdvancedSpecHotAdd(dst, restored)
+	restore_v1alpha3_VirtualMachineAdvancedSpecSerialConsole(dst, restored)
+	restore_v1alpha3_VirtualMachinePowerOffGracePeriod(dst, restored)
+	restore_v1alpha3_VirtualMachinePowerSchedule(dst, restored)
+	restore_v1alpha3_VirtualMachineClone(dst, restored)
+	restore_v1alpha3_VirtualMachineCurrentSnapshot(dst, restored)
+	restore_v1alpha3_VirtualMachinePlacement(dst, restored)
+	restore_v1alpha3_VirtualMachineDriftPolicy(dst, restored)
+	restore_v1alpha3_VirtualMachineLivenessProbe(dst, restored)
 
 	// END RESTORE
 
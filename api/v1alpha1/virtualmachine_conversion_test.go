@@ -173,11 +173,26 @@ func TestVirtualMachineConversion(t *testing.T) {
 						},
 					},
 				},
-				PowerState:      vmopv1.VirtualMachinePowerStateOff,
-				PowerOffMode:    vmopv1.VirtualMachinePowerOpModeHard,
+				PowerState:             vmopv1.VirtualMachinePowerStateOff,
+				PowerOffMode:           vmopv1.VirtualMachinePowerOpModeHard,
+				PowerOffTimeoutSeconds: ptrOf[int32](600),
+				PreStop: &vmopv1.VirtualMachinePreStopHookSpec{
+					Command:        "/usr/local/bin/flush-db",
+					TimeoutSeconds: ptrOf[int32](120),
+				},
 				SuspendMode:     vmopv1.VirtualMachinePowerOpModeTrySoft,
 				NextRestartTime: "tomorrow",
 				RestartMode:     vmopv1.VirtualMachinePowerOpModeSoft,
+				PowerSchedule: &vmopv1.VirtualMachinePowerScheduleSpec{
+					TimeZone: "America/Los_Angeles",
+					PowerOn:  "0 7 * * 1-5",
+					PowerOff: "0 19 * * 1-5",
+				},
+				Clone: &vmopv1.VirtualMachineCloneSpec{
+					Mode:         vmopv1.VirtualMachineCloneModeLinked,
+					CloneVolumes: true,
+				},
+				DriftPolicy: vmopv1.VirtualMachineDriftPolicyRevert,
 				Volumes: []vmopv1.VirtualMachineVolume{
 					{
 						Name: "my-volume",
@@ -209,6 +224,11 @@ func TestVirtualMachineConversion(t *testing.T) {
 						Host: "some-host",
 						Port: intstr.FromString("https"),
 					},
+					HTTPGet: &vmopv1.HTTPGetAction{
+						Path:   "/healthz",
+						Port:   intstr.FromInt32(8443),
+						Scheme: vmopv1.URISchemeHTTPS,
+					},
 					GuestHeartbeat: &vmopv1.GuestHeartbeatAction{
 						ThresholdStatus: vmopv1.RedHeartbeatStatus,
 					},
@@ -218,13 +238,28 @@ func TestVirtualMachineConversion(t *testing.T) {
 							Value: "guest-value",
 						},
 					},
-					TimeoutSeconds: 100,
-					PeriodSeconds:  200,
+					TimeoutSeconds:      100,
+					PeriodSeconds:       200,
+					InitialDelaySeconds: 300,
+					SuccessThreshold:    2,
+					FailureThreshold:    3,
+				},
+				LivenessProbe: &vmopv1.VirtualMachineLivenessProbeSpec{
+					GuestHeartbeat: &vmopv1.GuestHeartbeatAction{
+						ThresholdStatus: vmopv1.YellowHeartbeatStatus,
+					},
+					TimeoutSeconds:      10,
+					PeriodSeconds:       20,
+					InitialDelaySeconds: 30,
+					FailureThreshold:    4,
 				},
 				Advanced: &vmopv1.VirtualMachineAdvancedSpec{
 					BootDiskCapacity:              ptrOf(resource.MustParse("1024k")),
 					DefaultVolumeProvisioningMode: vmopv1.VirtualMachineVolumeProvisioningModeThickEagerZero,
 					ChangeBlockTracking:           ptrOf(true),
+					CPUHotAddEnabled:              ptrOf(true),
+					MemoryHotAddEnabled:           ptrOf(false),
+					SerialConsoleEnabled:          ptrOf(true),
 				},
 				Reserved: &vmopv1.VirtualMachineReservedSpec{
 					ResourcePolicyName: "my-resource-policy",
@@ -236,7 +271,7 @@ func TestVirtualMachineConversion(t *testing.T) {
 				Cdrom: []vmopv1.VirtualMachineCdromSpec{
 					{
 						Name: "cdrom1",
-						Image: vmopv1.VirtualMachineImageRef{
+						Image: &vmopv1.VirtualMachineImageRef{
 							Name: "my-cdrom-image",
 							Kind: "VirtualMachineImage",
 						},
@@ -512,6 +547,42 @@ func TestVirtualMachineConversion(t *testing.T) {
 		hubSpokeHub(g, &hub, &vmopv1a1.VirtualMachine{})
 	})
 
+	t.Run("VirtualMachine hub-spoke-hub with Ignition", func(t *testing.T) {
+		g := NewWithT(t)
+
+		hub := vmopv1.VirtualMachine{
+			Spec: vmopv1.VirtualMachineSpec{
+				Bootstrap: &vmopv1.VirtualMachineBootstrapSpec{
+					Ignition: &vmopv1.VirtualMachineBootstrapIgnitionSpec{
+						Config: &vmopv1.VirtualMachineBootstrapIgnitionConfig{
+							Users: []vmopv1.VirtualMachineBootstrapIgnitionUser{
+								{
+									Name:              "core",
+									SSHAuthorizedKeys: []string{"ssh-rsa AAAA"},
+								},
+							},
+							Files: []vmopv1.VirtualMachineBootstrapIgnitionFile{
+								{
+									Path:     "/etc/motd",
+									Contents: "hello",
+									Mode:     ptrOf[int32](420),
+								},
+							},
+							SystemdUnits: []vmopv1.VirtualMachineBootstrapIgnitionSystemdUnit{
+								{
+									Name:    "hello.service",
+									Enabled: ptrOf(true),
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+
+		hubSpokeHub(g, &hub, &vmopv1a1.VirtualMachine{})
+	})
+
 	t.Run("VirtualMachine hub-spoke Status", func(t *testing.T) {
 		g := NewWithT(t)
 
@@ -1601,6 +1672,60 @@ func TestVirtualMachineConversion(t *testing.T) {
 		g.Expect(anno).Should(HaveKeyWithValue(vmopv1.V1alpha1ConfigMapTransportAnnotation, "true"))
 	})
 
+	t.Run("VirtualMachine hub-spoke-hub with spec.volumes not backed by PVCs", func(t *testing.T) {
+		g := NewWithT(t)
+		hub := vmopv1.VirtualMachine{
+			Spec: vmopv1.VirtualMachineSpec{
+				Volumes: []vmopv1.VirtualMachineVolume{
+					{
+						Name: "scratch",
+						VirtualMachineVolumeSource: vmopv1.VirtualMachineVolumeSource{
+							Ephemeral: &vmopv1.EphemeralVolumeSource{
+								Size:             resource.MustParse("10Gi"),
+								ProvisioningMode: vmopv1.VirtualMachineVolumeProvisioningModeThin,
+							},
+						},
+					},
+					{
+						Name: "my-pvc",
+						VirtualMachineVolumeSource: vmopv1.VirtualMachineVolumeSource{
+							PersistentVolumeClaim: ptrOf(vmopv1.PersistentVolumeClaimVolumeSource{
+								PersistentVolumeClaimVolumeSource: corev1.PersistentVolumeClaimVolumeSource{
+									ClaimName: "my-claim",
+								},
+							}),
+						},
+					},
+					{
+						Name: "scratch2",
+						VirtualMachineVolumeSource: vmopv1.VirtualMachineVolumeSource{
+							Ephemeral: &vmopv1.EphemeralVolumeSource{
+								Size: resource.MustParse("1Gi"),
+							},
+						},
+					},
+					{
+						Name: "config",
+						VirtualMachineVolumeSource: vmopv1.VirtualMachineVolumeSource{
+							ConfigMap: &corev1.ConfigMapVolumeSource{
+								LocalObjectReference: corev1.LocalObjectReference{Name: "my-config-map"},
+							},
+						},
+					},
+					{
+						Name: "secret",
+						VirtualMachineVolumeSource: vmopv1.VirtualMachineVolumeSource{
+							Secret: &corev1.SecretVolumeSource{
+								SecretName: "my-secret",
+							},
+						},
+					},
+				},
+			},
+		}
+		hubSpokeHub(g, &hub, &vmopv1a1.VirtualMachine{})
+	})
+
 	t.Run("VirtualMachine hub-spoke-hub with spec.image", func(t *testing.T) {
 		g := NewWithT(t)
 		hub := vmopv1.VirtualMachine{
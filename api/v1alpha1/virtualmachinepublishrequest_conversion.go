@@ -5,21 +5,62 @@
 package v1alpha1
 
 import (
+	apiconversion "k8s.io/apimachinery/pkg/conversion"
 	ctrlconversion "sigs.k8s.io/controller-runtime/pkg/conversion"
 
+	"github.com/vmware-tanzu/vm-operator/api/utilconversion"
 	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
 )
 
+func Convert_v1alpha3_VirtualMachinePublishRequestTargetLocation_To_v1alpha1_VirtualMachinePublishRequestTargetLocation(
+	in *vmopv1.VirtualMachinePublishRequestTargetLocation, out *VirtualMachinePublishRequestTargetLocation, s apiconversion.Scope) error {
+
+	return autoConvert_v1alpha3_VirtualMachinePublishRequestTargetLocation_To_v1alpha1_VirtualMachinePublishRequestTargetLocation(in, out, s)
+}
+
+func Convert_v1alpha3_VirtualMachinePublishRequestStatus_To_v1alpha1_VirtualMachinePublishRequestStatus(
+	in *vmopv1.VirtualMachinePublishRequestStatus, out *VirtualMachinePublishRequestStatus, s apiconversion.Scope) error {
+
+	return autoConvert_v1alpha3_VirtualMachinePublishRequestStatus_To_v1alpha1_VirtualMachinePublishRequestStatus(in, out, s)
+}
+
+func restore_v1alpha3_VirtualMachinePublishRequestTargetOCIRegistry(dst, src *vmopv1.VirtualMachinePublishRequest) {
+	dst.Spec.Target.Location.OCIRegistry = src.Spec.Target.Location.OCIRegistry
+
+	if dst.Status.TargetRef != nil && src.Status.TargetRef != nil {
+		dst.Status.TargetRef.Location.OCIRegistry = src.Status.TargetRef.Location.OCIRegistry
+	}
+
+	dst.Status.ImageDigest = src.Status.ImageDigest
+}
+
 // ConvertTo converts this VirtualMachinePublishRequest to the Hub version.
 func (src *VirtualMachinePublishRequest) ConvertTo(dstRaw ctrlconversion.Hub) error {
 	dst := dstRaw.(*vmopv1.VirtualMachinePublishRequest)
-	return Convert_v1alpha1_VirtualMachinePublishRequest_To_v1alpha3_VirtualMachinePublishRequest(src, dst, nil)
+	if err := Convert_v1alpha1_VirtualMachinePublishRequest_To_v1alpha3_VirtualMachinePublishRequest(src, dst, nil); err != nil {
+		return err
+	}
+
+	// Manually restore data.
+	restored := &vmopv1.VirtualMachinePublishRequest{}
+	if ok, err := utilconversion.UnmarshalData(src, restored); err != nil || !ok {
+		return err
+	}
+
+	restore_v1alpha3_VirtualMachinePublishRequestTargetOCIRegistry(dst, restored)
+
+	return nil
 }
 
 // ConvertFrom converts the hub version to this VirtualMachinePublishRequest.
 func (dst *VirtualMachinePublishRequest) ConvertFrom(srcRaw ctrlconversion.Hub) error {
 	src := srcRaw.(*vmopv1.VirtualMachinePublishRequest)
-	return Convert_v1alpha3_VirtualMachinePublishRequest_To_v1alpha1_VirtualMachinePublishRequest(src, dst, nil)
+	if err := Convert_v1alpha3_VirtualMachinePublishRequest_To_v1alpha1_VirtualMachinePublishRequest(src, dst, nil); err != nil {
+		return err
+	}
+
+	// Preserve Hub data on down-conversion except for metadata
+	return utilconversion.MarshalData(src, dst)
 }
 
 // ConvertTo converts this VirtualMachinePublishRequestList to the Hub version.
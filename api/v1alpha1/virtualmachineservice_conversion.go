@@ -5,21 +5,80 @@
 package v1alpha1
 
 import (
+	apiconversion "k8s.io/apimachinery/pkg/conversion"
 	ctrlconversion "sigs.k8s.io/controller-runtime/pkg/conversion"
 
+	"github.com/vmware-tanzu/vm-operator/api/utilconversion"
 	"github.com/vmware-tanzu/vm-operator/api/v1alpha3"
 )
 
+func Convert_v1alpha3_VirtualMachineServicePort_To_v1alpha1_VirtualMachineServicePort(
+	in *v1alpha3.VirtualMachineServicePort, out *VirtualMachineServicePort, s apiconversion.Scope) error {
+
+	return autoConvert_v1alpha3_VirtualMachineServicePort_To_v1alpha1_VirtualMachineServicePort(in, out, s)
+}
+
+func Convert_v1alpha3_VirtualMachineServiceSpec_To_v1alpha1_VirtualMachineServiceSpec(
+	in *v1alpha3.VirtualMachineServiceSpec, out *VirtualMachineServiceSpec, s apiconversion.Scope) error {
+
+	return autoConvert_v1alpha3_VirtualMachineServiceSpec_To_v1alpha1_VirtualMachineServiceSpec(in, out, s)
+}
+
+func restore_v1alpha3_VirtualMachineServiceSessionAffinity(dst, src *v1alpha3.VirtualMachineService) {
+	dst.Spec.SessionAffinity = src.Spec.SessionAffinity
+	dst.Spec.SessionAffinityConfig = src.Spec.SessionAffinityConfig
+}
+
+func restore_v1alpha3_VirtualMachineServiceExternalTrafficPolicy(dst, src *v1alpha3.VirtualMachineService) {
+	dst.Spec.ExternalTrafficPolicy = src.Spec.ExternalTrafficPolicy
+	dst.Spec.HealthCheckNodePort = src.Spec.HealthCheckNodePort
+}
+
+func restore_v1alpha3_VirtualMachineServicePortTargetPortName(dst, src *v1alpha3.VirtualMachineService) {
+	if len(dst.Spec.Ports) != len(src.Spec.Ports) {
+		return
+	}
+
+	for i := range dst.Spec.Ports {
+		dstPort, srcPort := &dst.Spec.Ports[i], src.Spec.Ports[i]
+
+		// Only restore a target port name if the port was not changed
+		// while in this version.
+		if dstPort.Name == srcPort.Name && dstPort.TargetPort == srcPort.TargetPort {
+			dstPort.TargetPortName = srcPort.TargetPortName
+		}
+	}
+}
+
 // ConvertTo converts this VirtualMachineService to the Hub version.
 func (src *VirtualMachineService) ConvertTo(dstRaw ctrlconversion.Hub) error {
 	dst := dstRaw.(*v1alpha3.VirtualMachineService)
-	return Convert_v1alpha1_VirtualMachineService_To_v1alpha3_VirtualMachineService(src, dst, nil)
+	if err := Convert_v1alpha1_VirtualMachineService_To_v1alpha3_VirtualMachineService(src, dst, nil); err != nil {
+		return err
+	}
+
+	// Manually restore data.
+	restored := &v1alpha3.VirtualMachineService{}
+	if ok, err := utilconversion.UnmarshalData(src, restored); err != nil || !ok {
+		return err
+	}
+
+	restore_v1alpha3_VirtualMachineServiceSessionAffinity(dst, restored)
+	restore_v1alpha3_VirtualMachineServiceExternalTrafficPolicy(dst, restored)
+	restore_v1alpha3_VirtualMachineServicePortTargetPortName(dst, restored)
+
+	return nil
 }
 
 // ConvertFrom converts the hub version to this VirtualMachineService.
 func (dst *VirtualMachineService) ConvertFrom(srcRaw ctrlconversion.Hub) error {
 	src := srcRaw.(*v1alpha3.VirtualMachineService)
-	return Convert_v1alpha3_VirtualMachineService_To_v1alpha1_VirtualMachineService(src, dst, nil)
+	if err := Convert_v1alpha3_VirtualMachineService_To_v1alpha1_VirtualMachineService(src, dst, nil); err != nil {
+		return err
+	}
+
+	// Preserve Hub data on down-conversion except for metadata
+	return utilconversion.MarshalData(src, dst)
 }
 
 // ConvertTo converts this VirtualMachineServiceList to the Hub version.
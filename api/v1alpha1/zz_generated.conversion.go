@@ -347,11 +347,6 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1alpha3.VirtualMachinePublishRequestStatus)(nil), (*VirtualMachinePublishRequestStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1alpha3_VirtualMachinePublishRequestStatus_To_v1alpha1_VirtualMachinePublishRequestStatus(a.(*v1alpha3.VirtualMachinePublishRequestStatus), b.(*VirtualMachinePublishRequestStatus), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*VirtualMachinePublishRequestTarget)(nil), (*v1alpha3.VirtualMachinePublishRequestTarget)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_VirtualMachinePublishRequestTarget_To_v1alpha3_VirtualMachinePublishRequestTarget(a.(*VirtualMachinePublishRequestTarget), b.(*v1alpha3.VirtualMachinePublishRequestTarget), scope)
 	}); err != nil {
@@ -377,11 +372,6 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1alpha3.VirtualMachinePublishRequestTargetLocation)(nil), (*VirtualMachinePublishRequestTargetLocation)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1alpha3_VirtualMachinePublishRequestTargetLocation_To_v1alpha1_VirtualMachinePublishRequestTargetLocation(a.(*v1alpha3.VirtualMachinePublishRequestTargetLocation), b.(*VirtualMachinePublishRequestTargetLocation), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*VirtualMachineResourceSpec)(nil), (*v1alpha3.VirtualMachineResourceSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_VirtualMachineResourceSpec_To_v1alpha3_VirtualMachineResourceSpec(a.(*VirtualMachineResourceSpec), b.(*v1alpha3.VirtualMachineResourceSpec), scope)
 	}); err != nil {
@@ -417,21 +407,11 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1alpha3.VirtualMachineServicePort)(nil), (*VirtualMachineServicePort)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1alpha3_VirtualMachineServicePort_To_v1alpha1_VirtualMachineServicePort(a.(*v1alpha3.VirtualMachineServicePort), b.(*VirtualMachineServicePort), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*VirtualMachineServiceSpec)(nil), (*v1alpha3.VirtualMachineServiceSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_VirtualMachineServiceSpec_To_v1alpha3_VirtualMachineServiceSpec(a.(*VirtualMachineServiceSpec), b.(*v1alpha3.VirtualMachineServiceSpec), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1alpha3.VirtualMachineServiceSpec)(nil), (*VirtualMachineServiceSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1alpha3_VirtualMachineServiceSpec_To_v1alpha1_VirtualMachineServiceSpec(a.(*v1alpha3.VirtualMachineServiceSpec), b.(*VirtualMachineServiceSpec), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*VirtualMachineServiceStatus)(nil), (*v1alpha3.VirtualMachineServiceStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_VirtualMachineServiceStatus_To_v1alpha3_VirtualMachineServiceStatus(a.(*VirtualMachineServiceStatus), b.(*v1alpha3.VirtualMachineServiceStatus), scope)
 	}); err != nil {
@@ -552,11 +532,31 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddConversionFunc((*v1alpha3.VirtualMachinePublishRequestStatus)(nil), (*VirtualMachinePublishRequestStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_VirtualMachinePublishRequestStatus_To_v1alpha1_VirtualMachinePublishRequestStatus(a.(*v1alpha3.VirtualMachinePublishRequestStatus), b.(*VirtualMachinePublishRequestStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*v1alpha3.VirtualMachinePublishRequestTargetLocation)(nil), (*VirtualMachinePublishRequestTargetLocation)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_VirtualMachinePublishRequestTargetLocation_To_v1alpha1_VirtualMachinePublishRequestTargetLocation(a.(*v1alpha3.VirtualMachinePublishRequestTargetLocation), b.(*VirtualMachinePublishRequestTargetLocation), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddConversionFunc((*v1alpha3.VirtualMachineReadinessProbeSpec)(nil), (*Probe)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_VirtualMachineReadinessProbeSpec_To_v1alpha1_Probe(a.(*v1alpha3.VirtualMachineReadinessProbeSpec), b.(*Probe), scope)
 	}); err != nil {
 		return err
 	}
+	if err := s.AddConversionFunc((*v1alpha3.VirtualMachineServicePort)(nil), (*VirtualMachineServicePort)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_VirtualMachineServicePort_To_v1alpha1_VirtualMachineServicePort(a.(*v1alpha3.VirtualMachineServicePort), b.(*VirtualMachineServicePort), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*v1alpha3.VirtualMachineServiceSpec)(nil), (*VirtualMachineServiceSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_VirtualMachineServiceSpec_To_v1alpha1_VirtualMachineServiceSpec(a.(*v1alpha3.VirtualMachineServiceSpec), b.(*VirtualMachineServiceSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddConversionFunc((*v1alpha3.VirtualMachineSetResourcePolicySpec)(nil), (*VirtualMachineSetResourcePolicySpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_VirtualMachineSetResourcePolicySpec_To_v1alpha1_VirtualMachineSetResourcePolicySpec(a.(*v1alpha3.VirtualMachineSetResourcePolicySpec), b.(*VirtualMachineSetResourcePolicySpec), scope)
 	}); err != nil {
@@ -1619,7 +1619,15 @@ func Convert_v1alpha3_VirtualMachinePublishRequestSpec_To_v1alpha1_VirtualMachin
 
 func autoConvert_v1alpha1_VirtualMachinePublishRequestStatus_To_v1alpha3_VirtualMachinePublishRequestStatus(in *VirtualMachinePublishRequestStatus, out *v1alpha3.VirtualMachinePublishRequestStatus, s conversion.Scope) error {
 	out.SourceRef = (*v1alpha3.VirtualMachinePublishRequestSource)(unsafe.Pointer(in.SourceRef))
-	out.TargetRef = (*v1alpha3.VirtualMachinePublishRequestTarget)(unsafe.Pointer(in.TargetRef))
+	if in.TargetRef != nil {
+		in, out := &in.TargetRef, &out.TargetRef
+		*out = new(v1alpha3.VirtualMachinePublishRequestTarget)
+		if err := Convert_v1alpha1_VirtualMachinePublishRequestTarget_To_v1alpha3_VirtualMachinePublishRequestTarget(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.TargetRef = nil
+	}
 	out.CompletionTime = in.CompletionTime
 	out.StartTime = in.StartTime
 	out.Attempts = in.Attempts
@@ -1647,12 +1655,21 @@ func Convert_v1alpha1_VirtualMachinePublishRequestStatus_To_v1alpha3_VirtualMach
 
 func autoConvert_v1alpha3_VirtualMachinePublishRequestStatus_To_v1alpha1_VirtualMachinePublishRequestStatus(in *v1alpha3.VirtualMachinePublishRequestStatus, out *VirtualMachinePublishRequestStatus, s conversion.Scope) error {
 	out.SourceRef = (*VirtualMachinePublishRequestSource)(unsafe.Pointer(in.SourceRef))
-	out.TargetRef = (*VirtualMachinePublishRequestTarget)(unsafe.Pointer(in.TargetRef))
+	if in.TargetRef != nil {
+		in, out := &in.TargetRef, &out.TargetRef
+		*out = new(VirtualMachinePublishRequestTarget)
+		if err := Convert_v1alpha3_VirtualMachinePublishRequestTarget_To_v1alpha1_VirtualMachinePublishRequestTarget(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.TargetRef = nil
+	}
 	out.CompletionTime = in.CompletionTime
 	out.StartTime = in.StartTime
 	out.Attempts = in.Attempts
 	out.LastAttemptTime = in.LastAttemptTime
 	out.ImageName = in.ImageName
+	// WARNING: in.ImageDigest requires manual conversion: does not exist in peer-type
 	out.Ready = in.Ready
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
@@ -1668,11 +1685,6 @@ func autoConvert_v1alpha3_VirtualMachinePublishRequestStatus_To_v1alpha1_Virtual
 	return nil
 }
 
-// Convert_v1alpha3_VirtualMachinePublishRequestStatus_To_v1alpha1_VirtualMachinePublishRequestStatus is an autogenerated conversion function.
-func Convert_v1alpha3_VirtualMachinePublishRequestStatus_To_v1alpha1_VirtualMachinePublishRequestStatus(in *v1alpha3.VirtualMachinePublishRequestStatus, out *VirtualMachinePublishRequestStatus, s conversion.Scope) error {
-	return autoConvert_v1alpha3_VirtualMachinePublishRequestStatus_To_v1alpha1_VirtualMachinePublishRequestStatus(in, out, s)
-}
-
 func autoConvert_v1alpha1_VirtualMachinePublishRequestTarget_To_v1alpha3_VirtualMachinePublishRequestTarget(in *VirtualMachinePublishRequestTarget, out *v1alpha3.VirtualMachinePublishRequestTarget, s conversion.Scope) error {
 	if err := Convert_v1alpha1_VirtualMachinePublishRequestTargetItem_To_v1alpha3_VirtualMachinePublishRequestTargetItem(&in.Item, &out.Item, s); err != nil {
 		return err
@@ -1741,14 +1753,10 @@ func autoConvert_v1alpha3_VirtualMachinePublishRequestTargetLocation_To_v1alpha1
 	out.Name = in.Name
 	out.APIVersion = in.APIVersion
 	out.Kind = in.Kind
+	// WARNING: in.OCIRegistry requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_v1alpha3_VirtualMachinePublishRequestTargetLocation_To_v1alpha1_VirtualMachinePublishRequestTargetLocation is an autogenerated conversion function.
-func Convert_v1alpha3_VirtualMachinePublishRequestTargetLocation_To_v1alpha1_VirtualMachinePublishRequestTargetLocation(in *v1alpha3.VirtualMachinePublishRequestTargetLocation, out *VirtualMachinePublishRequestTargetLocation, s conversion.Scope) error {
-	return autoConvert_v1alpha3_VirtualMachinePublishRequestTargetLocation_To_v1alpha1_VirtualMachinePublishRequestTargetLocation(in, out, s)
-}
-
 func autoConvert_v1alpha1_VirtualMachineResourceSpec_To_v1alpha3_VirtualMachineResourceSpec(in *VirtualMachineResourceSpec, out *v1alpha3.VirtualMachineResourceSpec, s conversion.Scope) error {
 	out.Cpu = in.Cpu
 	out.Memory = in.Memory
@@ -1805,7 +1813,17 @@ func Convert_v1alpha3_VirtualMachineService_To_v1alpha1_VirtualMachineService(in
 
 func autoConvert_v1alpha1_VirtualMachineServiceList_To_v1alpha3_VirtualMachineServiceList(in *VirtualMachineServiceList, out *v1alpha3.VirtualMachineServiceList, s conversion.Scope) error {
 	out.ListMeta = in.ListMeta
-	out.Items = *(*[]v1alpha3.VirtualMachineService)(unsafe.Pointer(&in.Items))
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]v1alpha3.VirtualMachineService, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha1_VirtualMachineService_To_v1alpha3_VirtualMachineService(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
 	return nil
 }
 
@@ -1816,7 +1834,17 @@ func Convert_v1alpha1_VirtualMachineServiceList_To_v1alpha3_VirtualMachineServic
 
 func autoConvert_v1alpha3_VirtualMachineServiceList_To_v1alpha1_VirtualMachineServiceList(in *v1alpha3.VirtualMachineServiceList, out *VirtualMachineServiceList, s conversion.Scope) error {
 	out.ListMeta = in.ListMeta
-	out.Items = *(*[]VirtualMachineService)(unsafe.Pointer(&in.Items))
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VirtualMachineService, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha3_VirtualMachineService_To_v1alpha1_VirtualMachineService(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
 	return nil
 }
 
@@ -1843,17 +1871,23 @@ func autoConvert_v1alpha3_VirtualMachineServicePort_To_v1alpha1_VirtualMachineSe
 	out.Protocol = in.Protocol
 	out.Port = in.Port
 	out.TargetPort = in.TargetPort
+	// WARNING: in.TargetPortName requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_v1alpha3_VirtualMachineServicePort_To_v1alpha1_VirtualMachineServicePort is an autogenerated conversion function.
-func Convert_v1alpha3_VirtualMachineServicePort_To_v1alpha1_VirtualMachineServicePort(in *v1alpha3.VirtualMachineServicePort, out *VirtualMachineServicePort, s conversion.Scope) error {
-	return autoConvert_v1alpha3_VirtualMachineServicePort_To_v1alpha1_VirtualMachineServicePort(in, out, s)
-}
-
 func autoConvert_v1alpha1_VirtualMachineServiceSpec_To_v1alpha3_VirtualMachineServiceSpec(in *VirtualMachineServiceSpec, out *v1alpha3.VirtualMachineServiceSpec, s conversion.Scope) error {
 	out.Type = v1alpha3.VirtualMachineServiceType(in.Type)
-	out.Ports = *(*[]v1alpha3.VirtualMachineServicePort)(unsafe.Pointer(&in.Ports))
+	if in.Ports != nil {
+		in, out := &in.Ports, &out.Ports
+		*out = make([]v1alpha3.VirtualMachineServicePort, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha1_VirtualMachineServicePort_To_v1alpha3_VirtualMachineServicePort(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Ports = nil
+	}
 	out.Selector = *(*map[string]string)(unsafe.Pointer(&in.Selector))
 	out.LoadBalancerIP = in.LoadBalancerIP
 	out.LoadBalancerSourceRanges = *(*[]string)(unsafe.Pointer(&in.LoadBalancerSourceRanges))
@@ -1869,20 +1903,29 @@ func Convert_v1alpha1_VirtualMachineServiceSpec_To_v1alpha3_VirtualMachineServic
 
 func autoConvert_v1alpha3_VirtualMachineServiceSpec_To_v1alpha1_VirtualMachineServiceSpec(in *v1alpha3.VirtualMachineServiceSpec, out *VirtualMachineServiceSpec, s conversion.Scope) error {
 	out.Type = VirtualMachineServiceType(in.Type)
-	out.Ports = *(*[]VirtualMachineServicePort)(unsafe.Pointer(&in.Ports))
+	if in.Ports != nil {
+		in, out := &in.Ports, &out.Ports
+		*out = make([]VirtualMachineServicePort, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha3_VirtualMachineServicePort_To_v1alpha1_VirtualMachineServicePort(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Ports = nil
+	}
 	out.Selector = *(*map[string]string)(unsafe.Pointer(&in.Selector))
 	out.LoadBalancerIP = in.LoadBalancerIP
 	out.LoadBalancerSourceRanges = *(*[]string)(unsafe.Pointer(&in.LoadBalancerSourceRanges))
 	out.ClusterIP = in.ClusterIP
 	out.ExternalName = in.ExternalName
+	// WARNING: in.SessionAffinity requires manual conversion: does not exist in peer-type
+	// WARNING: in.SessionAffinityConfig requires manual conversion: does not exist in peer-type
+	// WARNING: in.ExternalTrafficPolicy requires manual conversion: does not exist in peer-type
+	// WARNING: in.HealthCheckNodePort requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_v1alpha3_VirtualMachineServiceSpec_To_v1alpha1_VirtualMachineServiceSpec is an autogenerated conversion function.
-func Convert_v1alpha3_VirtualMachineServiceSpec_To_v1alpha1_VirtualMachineServiceSpec(in *v1alpha3.VirtualMachineServiceSpec, out *VirtualMachineServiceSpec, s conversion.Scope) error {
-	return autoConvert_v1alpha3_VirtualMachineServiceSpec_To_v1alpha1_VirtualMachineServiceSpec(in, out, s)
-}
-
 func autoConvert_v1alpha1_VirtualMachineServiceStatus_To_v1alpha3_VirtualMachineServiceStatus(in *VirtualMachineServiceStatus, out *v1alpha3.VirtualMachineServiceStatus, s conversion.Scope) error {
 	if err := Convert_v1alpha1_LoadBalancerStatus_To_v1alpha3_LoadBalancerStatus(&in.LoadBalancer, &out.LoadBalancer, s); err != nil {
 		return err
@@ -2060,6 +2103,7 @@ func autoConvert_v1alpha1_VirtualMachineSpec_To_v1alpha3_VirtualMachineSpec(in *
 func autoConvert_v1alpha3_VirtualMachineSpec_To_v1alpha1_VirtualMachineSpec(in *v1alpha3.VirtualMachineSpec, out *VirtualMachineSpec, s conversion.Scope) error {
 	// WARNING: in.Cdrom requires manual conversion: does not exist in peer-type
 	// WARNING: in.Image requires manual conversion: does not exist in peer-type
+	// WARNING: in.Clone requires manual conversion: does not exist in peer-type
 	out.ImageName = in.ImageName
 	out.ClassName = in.ClassName
 	// WARNING: in.Crypto requires manual conversion: does not exist in peer-type
@@ -2068,9 +2112,12 @@ func autoConvert_v1alpha3_VirtualMachineSpec_To_v1alpha1_VirtualMachineSpec(in *
 	// WARNING: in.Network requires manual conversion: does not exist in peer-type
 	out.PowerState = VirtualMachinePowerState(in.PowerState)
 	out.PowerOffMode = VirtualMachinePowerOpMode(in.PowerOffMode)
+	// WARNING: in.PowerOffTimeoutSeconds requires manual conversion: does not exist in peer-type
+	// WARNING: in.PreStop requires manual conversion: does not exist in peer-type
 	out.SuspendMode = VirtualMachinePowerOpMode(in.SuspendMode)
 	out.NextRestartTime = in.NextRestartTime
 	out.RestartMode = VirtualMachinePowerOpMode(in.RestartMode)
+	// WARNING: in.PowerSchedule requires manual conversion: does not exist in peer-type
 	if in.Volumes != nil {
 		in, out := &in.Volumes, &out.Volumes
 		*out = make([]VirtualMachineVolume, len(*in))
@@ -2091,12 +2138,16 @@ func autoConvert_v1alpha3_VirtualMachineSpec_To_v1alpha1_VirtualMachineSpec(in *
 	} else {
 		out.ReadinessProbe = nil
 	}
+	// WARNING: in.LivenessProbe requires manual conversion: does not exist in peer-type
 	// WARNING: in.Advanced requires manual conversion: does not exist in peer-type
 	// WARNING: in.Reserved requires manual conversion: does not exist in peer-type
 	out.MinHardwareVersion = in.MinHardwareVersion
 	// WARNING: in.InstanceUUID requires manual conversion: does not exist in peer-type
 	// WARNING: in.BiosUUID requires manual conversion: does not exist in peer-type
 	// WARNING: in.GuestID requires manual conversion: does not exist in peer-type
+	// WARNING: in.CurrentSnapshot requires manual conversion: does not exist in peer-type
+	// WARNING: in.Placement requires manual conversion: does not exist in peer-type
+	// WARNING: in.DriftPolicy requires manual conversion: does not exist in peer-type
 	return nil
 }
 
@@ -2172,8 +2223,14 @@ func autoConvert_v1alpha3_VirtualMachineStatus_To_v1alpha1_VirtualMachineStatus(
 	out.ChangeBlockTracking = (*bool)(unsafe.Pointer(in.ChangeBlockTracking))
 	out.Zone = in.Zone
 	out.LastRestartTime = (*v1.Time)(unsafe.Pointer(in.LastRestartTime))
+	// WARNING: in.RestartCount requires manual conversion: does not exist in peer-type
+	// WARNING: in.PowerSchedule requires manual conversion: does not exist in peer-type
 	out.HardwareVersion = in.HardwareVersion
 	// WARNING: in.Storage requires manual conversion: does not exist in peer-type
+	// WARNING: in.CurrentSnapshot requires manual conversion: does not exist in peer-type
+	// WARNING: in.RootSnapshots requires manual conversion: does not exist in peer-type
+	// WARNING: in.Resize requires manual conversion: does not exist in peer-type
+	// WARNING: in.Drift requires manual conversion: does not exist in peer-type
 	return nil
 }
 
@@ -2246,6 +2303,7 @@ func autoConvert_v1alpha3_VirtualMachineVolumeStatus_To_v1alpha1_VirtualMachineV
 	// WARNING: in.Crypto requires manual conversion: does not exist in peer-type
 	// WARNING: in.Limit requires manual conversion: does not exist in peer-type
 	// WARNING: in.Used requires manual conversion: does not exist in peer-type
+	// WARNING: in.ResizeStatus requires manual conversion: does not exist in peer-type
 	out.Attached = in.Attached
 	// WARNING: in.DiskUUID requires manual conversion: does not exist in peer-type
 	out.Error = in.Error
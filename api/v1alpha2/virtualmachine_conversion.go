@@ -5,6 +5,9 @@
 package v1alpha2
 
 import (
+	"slices"
+
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	apiconversion "k8s.io/apimachinery/pkg/conversion"
 	ctrlconversion "sigs.k8s.io/controller-runtime/pkg/conversion"
 
@@ -13,12 +16,24 @@ import (
 	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
 )
 
+func Convert_v1alpha3_VirtualMachineAdvancedSpec_To_v1alpha2_VirtualMachineAdvancedSpec(
+	in *vmopv1.VirtualMachineAdvancedSpec, out *VirtualMachineAdvancedSpec, s apiconversion.Scope) error {
+
+	return autoConvert_v1alpha3_VirtualMachineAdvancedSpec_To_v1alpha2_VirtualMachineAdvancedSpec(in, out, s)
+}
+
 func Convert_v1alpha3_VirtualMachineBootstrapCloudInitSpec_To_v1alpha2_VirtualMachineBootstrapCloudInitSpec(
 	in *vmopv1.VirtualMachineBootstrapCloudInitSpec, out *VirtualMachineBootstrapCloudInitSpec, s apiconversion.Scope) error {
 
 	return autoConvert_v1alpha3_VirtualMachineBootstrapCloudInitSpec_To_v1alpha2_VirtualMachineBootstrapCloudInitSpec(in, out, s)
 }
 
+func Convert_v1alpha3_VirtualMachineBootstrapSpec_To_v1alpha2_VirtualMachineBootstrapSpec(
+	in *vmopv1.VirtualMachineBootstrapSpec, out *VirtualMachineBootstrapSpec, s apiconversion.Scope) error {
+
+	return autoConvert_v1alpha3_VirtualMachineBootstrapSpec_To_v1alpha2_VirtualMachineBootstrapSpec(in, out, s)
+}
+
 func Convert_v1alpha3_VirtualMachineNetworkConfigDNSStatus_To_v1alpha2_VirtualMachineNetworkConfigDNSStatus(
 	in *vmopv1.VirtualMachineNetworkConfigDNSStatus, out *VirtualMachineNetworkConfigDNSStatus, s apiconversion.Scope) error {
 
@@ -31,6 +46,12 @@ func Convert_v1alpha3_VirtualMachineNetworkSpec_To_v1alpha2_VirtualMachineNetwor
 	return autoConvert_v1alpha3_VirtualMachineNetworkSpec_To_v1alpha2_VirtualMachineNetworkSpec(in, out, s)
 }
 
+func Convert_v1alpha3_VirtualMachineReadinessProbeSpec_To_v1alpha2_VirtualMachineReadinessProbeSpec(
+	in *vmopv1.VirtualMachineReadinessProbeSpec, out *VirtualMachineReadinessProbeSpec, s apiconversion.Scope) error {
+
+	return autoConvert_v1alpha3_VirtualMachineReadinessProbeSpec_To_v1alpha2_VirtualMachineReadinessProbeSpec(in, out, s)
+}
+
 func Convert_v1alpha3_VirtualMachineSpec_To_v1alpha2_VirtualMachineSpec(
 	in *vmopv1.VirtualMachineSpec, out *VirtualMachineSpec, s apiconversion.Scope) error {
 
@@ -47,6 +68,12 @@ func Convert_v1alpha3_VirtualMachineSpec_To_v1alpha2_VirtualMachineSpec(
 	return nil
 }
 
+func Convert_v1alpha3_VirtualMachineVolumeSource_To_v1alpha2_VirtualMachineVolumeSource(
+	in *vmopv1.VirtualMachineVolumeSource, out *VirtualMachineVolumeSource, s apiconversion.Scope) error {
+
+	return autoConvert_v1alpha3_VirtualMachineVolumeSource_To_v1alpha2_VirtualMachineVolumeSource(in, out, s)
+}
+
 func Convert_v1alpha2_VirtualMachineVolumeStatus_To_v1alpha3_VirtualMachineVolumeStatus(
 	in *VirtualMachineVolumeStatus, out *vmopv1.VirtualMachineVolumeStatus, s apiconversion.Scope) error {
 
@@ -269,6 +296,22 @@ func restore_v1alpha3_VirtualMachineBootstrapCloudInitInstanceID(
 	dst.Spec.Bootstrap.CloudInit.InstanceID = iid
 }
 
+func restore_v1alpha3_VirtualMachineBootstrapIgnition(dst, src *vmopv1.VirtualMachine) {
+	var ignition *vmopv1.VirtualMachineBootstrapIgnitionSpec
+	if bs := src.Spec.Bootstrap; bs != nil {
+		ignition = bs.Ignition
+	}
+
+	if ignition == nil {
+		return
+	}
+
+	if dst.Spec.Bootstrap == nil {
+		dst.Spec.Bootstrap = &vmopv1.VirtualMachineBootstrapSpec{}
+	}
+	dst.Spec.Bootstrap.Ignition = ignition
+}
+
 func restore_v1alpha3_VirtualMachineGuestID(dst, src *vmopv1.VirtualMachine) {
 	dst.Spec.GuestID = src.Spec.GuestID
 }
@@ -277,6 +320,105 @@ func restore_v1alpha3_VirtualMachineCdrom(dst, src *vmopv1.VirtualMachine) {
 	dst.Spec.Cdrom = src.Spec.Cdrom
 }
 
+func restore_v1alpha3_VirtualMachineVolumes(dst, src *vmopv1.VirtualMachine) {
+	// The ephemeral, ConfigMap, and Secret volume sources do not exist in this
+	// version. If the volumes backed by PVCs are unchanged, then restore all
+	// of the volumes. Otherwise, restore the volumes not backed by PVCs after
+	// the ones that are.
+	pvcVolumes := func(in []vmopv1.VirtualMachineVolume) []vmopv1.VirtualMachineVolume {
+		var out []vmopv1.VirtualMachineVolume
+		for _, v := range in {
+			if v.PersistentVolumeClaim != nil {
+				out = append(out, vmopv1.VirtualMachineVolume{
+					Name: v.Name,
+					VirtualMachineVolumeSource: vmopv1.VirtualMachineVolumeSource{
+						PersistentVolumeClaim: v.PersistentVolumeClaim,
+					},
+				})
+			}
+		}
+		return out
+	}
+
+	if apiequality.Semantic.DeepEqual(pvcVolumes(dst.Spec.Volumes), pvcVolumes(src.Spec.Volumes)) {
+		dst.Spec.Volumes = src.Spec.Volumes
+		return
+	}
+
+	dst.Spec.Volumes = slices.DeleteFunc(dst.Spec.Volumes, func(v vmopv1.VirtualMachineVolume) bool {
+		return v.PersistentVolumeClaim == nil
+	})
+	for _, v := range src.Spec.Volumes {
+		if v.PersistentVolumeClaim == nil {
+			dst.Spec.Volumes = append(dst.Spec.Volumes, v)
+		}
+	}
+}
+
+func restore_v1alpha3_VirtualMachineAdvancedSpecHotAdd(dst, src *vmopv1.VirtualMachine) {
+	srcAdv := src.Spec.Advanced
+	if srcAdv == nil || (srcAdv.CPUHotAddEnabled == nil && srcAdv.MemoryHotAddEnabled == nil) {
+		return
+	}
+	if dst.Spec.Advanced == nil {
+		dst.Spec.Advanced = &vmopv1.VirtualMachineAdvancedSpec{}
+	}
+	dst.Spec.Advanced.CPUHotAddEnabled = srcAdv.CPUHotAddEnabled
+	dst.Spec.Advanced.MemoryHotAddEnabled = srcAdv.MemoryHotAddEnabled
+}
+
+func restore_v1alpha3_VirtualMachineAdvancedSpecSerialConsole(dst, src *vmopv1.VirtualMachine) {
+	srcAdv := src.Spec.Advanced
+	if srcAdv == nil || srcAdv.SerialConsoleEnabled == nil {
+		return
+	}
+	if dst.Spec.Advanced == nil {
+		dst.Spec.Advanced = &vmopv1.VirtualMachineAdvancedSpec{}
+	}
+	dst.Spec.Advanced.SerialConsoleEnabled = srcAdv.SerialConsoleEnabled
+}
+
+func restore_v1alpha3_VirtualMachinePowerOffGracePeriod(dst, src *vmopv1.VirtualMachine) {
+	dst.Spec.PowerOffTimeoutSeconds = src.Spec.PowerOffTimeoutSeconds
+	dst.Spec.PreStop = src.Spec.PreStop
+}
+
+func restore_v1alpha3_VirtualMachinePowerSchedule(dst, src *vmopv1.VirtualMachine) {
+	dst.Spec.PowerSchedule = src.Spec.PowerSchedule
+}
+
+func restore_v1alpha3_VirtualMachineClone(dst, src *vmopv1.VirtualMachine) {
+	dst.Spec.Clone = src.Spec.Clone
+}
+
+func restore_v1alpha3_VirtualMachineCurrentSnapshot(dst, src *vmopv1.VirtualMachine) {
+	dst.Spec.CurrentSnapshot = src.Spec.CurrentSnapshot
+}
+
+func restore_v1alpha3_VirtualMachinePlacement(dst, src *vmopv1.VirtualMachine) {
+	dst.Spec.Placement = src.Spec.Placement
+}
+
+func restore_v1alpha3_VirtualMachineDriftPolicy(dst, src *vmopv1.VirtualMachine) {
+	dst.Spec.DriftPolicy = src.Spec.DriftPolicy
+}
+
+func restore_v1alpha3_VirtualMachineReadinessProbe(dst, src *vmopv1.VirtualMachine) {
+	if src.Spec.ReadinessProbe != nil {
+		if dst.Spec.ReadinessProbe == nil {
+			dst.Spec.ReadinessProbe = &vmopv1.VirtualMachineReadinessProbeSpec{}
+		}
+		dst.Spec.ReadinessProbe.HTTPGet = src.Spec.ReadinessProbe.HTTPGet
+		dst.Spec.ReadinessProbe.InitialDelaySeconds = src.Spec.ReadinessProbe.InitialDelaySeconds
+		dst.Spec.ReadinessProbe.SuccessThreshold = src.Spec.ReadinessProbe.SuccessThreshold
+		dst.Spec.ReadinessProbe.FailureThreshold = src.Spec.ReadinessProbe.FailureThreshold
+	}
+}
+
+func restore_v1alpha3_VirtualMachineLivenessProbe(dst, src *vmopv1.VirtualMachine) {
+	dst.Spec.LivenessProbe = src.Spec.LivenessProbe
+}
+
 // ConvertTo converts this VirtualMachine to the Hub version.
 func (src *VirtualMachine) ConvertTo(dstRaw ctrlconversion.Hub) error {
 	dst := dstRaw.(*vmopv1.VirtualMachine)
@@ -296,10 +438,22 @@ func (src *VirtualMachine) ConvertTo(dstRaw ctrlconversion.Hub) error {
 	restore_v1alpha3_VirtualMachineInstanceUUID(dst, restored)
 	restore_v1alpha3_VirtualMachineBiosUUID(dst, restored)
 	restore_v1alpha3_VirtualMachineBootstrapCloudInitInstanceID(dst, restored)
+	restore_v1alpha3_VirtualMachineBootstrapIgnition(dst, restored)
 	restore_v1alpha3_VirtualMachineSpecNetworkDomainName(dst, restored)
 	restore_v1alpha3_VirtualMachineGuestID(dst, restored)
 	restore_v1alpha3_VirtualMachineCdrom(dst, restored)
+	restore_v1alpha3_VirtualMachineVolumes(dst, restored)
 	restore_v1alpha3_VirtualMachineCryptoSpec(dst, restored)
+	restore_v1alpha3_VirtualMachineAdvancedSpecHotAdd(dst, restored)
+	restore_v1alpha3_VirtualMachineAdvancedSpecSerialConsole(dst, restored)
+	restore_v1alpha3_VirtualMachinePowerOffGracePeriod(dst, restored)
+	restore_v1alpha3_VirtualMachinePowerSchedule(dst, restored)
+	restore_v1alpha3_VirtualMachineClone(dst, restored)
+	restore_v1alpha3_VirtualMachineCurrentSnapshot(dst, restored)
+	restore_v1alpha3_VirtualMachinePlacement(dst, restored)
+	restore_v1alpha3_VirtualMachineDriftPolicy(dst, restored)
+	restore_v1alpha3_VirtualMachineReadinessProbe(dst, restored)
+	restore_v1alpha3_VirtualMachineLivenessProbe(dst, restored)
 
 	// END RESTORE
 
@@ -5,21 +5,50 @@
 package v1alpha2
 
 import (
+	apiconversion "k8s.io/apimachinery/pkg/conversion"
 	ctrlconversion "sigs.k8s.io/controller-runtime/pkg/conversion"
 
+	"github.com/vmware-tanzu/vm-operator/api/utilconversion"
 	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
 )
 
+func Convert_v1alpha3_VirtualMachineWebConsoleRequestSpec_To_v1alpha2_VirtualMachineWebConsoleRequestSpec(
+	in *vmopv1.VirtualMachineWebConsoleRequestSpec, out *VirtualMachineWebConsoleRequestSpec, s apiconversion.Scope) error {
+
+	return autoConvert_v1alpha3_VirtualMachineWebConsoleRequestSpec_To_v1alpha2_VirtualMachineWebConsoleRequestSpec(in, out, s)
+}
+
+func restore_v1alpha3_VirtualMachineWebConsoleRequestConsoleType(dst, src *vmopv1.VirtualMachineWebConsoleRequest) {
+	dst.Spec.ConsoleType = src.Spec.ConsoleType
+}
+
 // ConvertTo converts this VirtualMachineWebConsoleRequest to the Hub version.
 func (src *VirtualMachineWebConsoleRequest) ConvertTo(dstRaw ctrlconversion.Hub) error {
 	dst := dstRaw.(*vmopv1.VirtualMachineWebConsoleRequest)
-	return Convert_v1alpha2_VirtualMachineWebConsoleRequest_To_v1alpha3_VirtualMachineWebConsoleRequest(src, dst, nil)
+	if err := Convert_v1alpha2_VirtualMachineWebConsoleRequest_To_v1alpha3_VirtualMachineWebConsoleRequest(src, dst, nil); err != nil {
+		return err
+	}
+
+	// Manually restore data.
+	restored := &vmopv1.VirtualMachineWebConsoleRequest{}
+	if ok, err := utilconversion.UnmarshalData(src, restored); err != nil || !ok {
+		return err
+	}
+
+	restore_v1alpha3_VirtualMachineWebConsoleRequestConsoleType(dst, restored)
+
+	return nil
 }
 
 // ConvertFrom converts the hub version to this VirtualMachineWebConsoleRequest.
 func (dst *VirtualMachineWebConsoleRequest) ConvertFrom(srcRaw ctrlconversion.Hub) error {
 	src := srcRaw.(*vmopv1.VirtualMachineWebConsoleRequest)
-	return Convert_v1alpha3_VirtualMachineWebConsoleRequest_To_v1alpha2_VirtualMachineWebConsoleRequest(src, dst, nil)
+	if err := Convert_v1alpha3_VirtualMachineWebConsoleRequest_To_v1alpha2_VirtualMachineWebConsoleRequest(src, dst, nil); err != nil {
+		return err
+	}
+
+	// Preserve Hub data on down-conversion except for metadata
+	return utilconversion.MarshalData(src, dst)
 }
 
 // ConvertTo converts this VirtualMachineWebConsoleRequestList to the Hub version.
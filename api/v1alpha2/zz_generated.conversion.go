@@ -230,11 +230,6 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1alpha3.VirtualMachineAdvancedSpec)(nil), (*VirtualMachineAdvancedSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1alpha3_VirtualMachineAdvancedSpec_To_v1alpha2_VirtualMachineAdvancedSpec(a.(*v1alpha3.VirtualMachineAdvancedSpec), b.(*VirtualMachineAdvancedSpec), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*VirtualMachineBootstrapCloudInitSpec)(nil), (*v1alpha3.VirtualMachineBootstrapCloudInitSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_VirtualMachineBootstrapCloudInitSpec_To_v1alpha3_VirtualMachineBootstrapCloudInitSpec(a.(*VirtualMachineBootstrapCloudInitSpec), b.(*v1alpha3.VirtualMachineBootstrapCloudInitSpec), scope)
 	}); err != nil {
@@ -255,11 +250,6 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1alpha3.VirtualMachineBootstrapSpec)(nil), (*VirtualMachineBootstrapSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1alpha3_VirtualMachineBootstrapSpec_To_v1alpha2_VirtualMachineBootstrapSpec(a.(*v1alpha3.VirtualMachineBootstrapSpec), b.(*VirtualMachineBootstrapSpec), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*VirtualMachineBootstrapSysprepSpec)(nil), (*v1alpha3.VirtualMachineBootstrapSysprepSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_VirtualMachineBootstrapSysprepSpec_To_v1alpha3_VirtualMachineBootstrapSysprepSpec(a.(*VirtualMachineBootstrapSysprepSpec), b.(*v1alpha3.VirtualMachineBootstrapSysprepSpec), scope)
 	}); err != nil {
@@ -650,11 +640,6 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1alpha3.VirtualMachinePublishRequestStatus)(nil), (*VirtualMachinePublishRequestStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1alpha3_VirtualMachinePublishRequestStatus_To_v1alpha2_VirtualMachinePublishRequestStatus(a.(*v1alpha3.VirtualMachinePublishRequestStatus), b.(*VirtualMachinePublishRequestStatus), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*VirtualMachinePublishRequestTarget)(nil), (*v1alpha3.VirtualMachinePublishRequestTarget)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_VirtualMachinePublishRequestTarget_To_v1alpha3_VirtualMachinePublishRequestTarget(a.(*VirtualMachinePublishRequestTarget), b.(*v1alpha3.VirtualMachinePublishRequestTarget), scope)
 	}); err != nil {
@@ -680,21 +665,11 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1alpha3.VirtualMachinePublishRequestTargetLocation)(nil), (*VirtualMachinePublishRequestTargetLocation)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1alpha3_VirtualMachinePublishRequestTargetLocation_To_v1alpha2_VirtualMachinePublishRequestTargetLocation(a.(*v1alpha3.VirtualMachinePublishRequestTargetLocation), b.(*VirtualMachinePublishRequestTargetLocation), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*VirtualMachineReadinessProbeSpec)(nil), (*v1alpha3.VirtualMachineReadinessProbeSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_VirtualMachineReadinessProbeSpec_To_v1alpha3_VirtualMachineReadinessProbeSpec(a.(*VirtualMachineReadinessProbeSpec), b.(*v1alpha3.VirtualMachineReadinessProbeSpec), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1alpha3.VirtualMachineReadinessProbeSpec)(nil), (*VirtualMachineReadinessProbeSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1alpha3_VirtualMachineReadinessProbeSpec_To_v1alpha2_VirtualMachineReadinessProbeSpec(a.(*v1alpha3.VirtualMachineReadinessProbeSpec), b.(*VirtualMachineReadinessProbeSpec), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*VirtualMachineReservedSpec)(nil), (*v1alpha3.VirtualMachineReservedSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_VirtualMachineReservedSpec_To_v1alpha3_VirtualMachineReservedSpec(a.(*VirtualMachineReservedSpec), b.(*v1alpha3.VirtualMachineReservedSpec), scope)
 	}); err != nil {
@@ -740,21 +715,11 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1alpha3.VirtualMachineServicePort)(nil), (*VirtualMachineServicePort)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1alpha3_VirtualMachineServicePort_To_v1alpha2_VirtualMachineServicePort(a.(*v1alpha3.VirtualMachineServicePort), b.(*VirtualMachineServicePort), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*VirtualMachineServiceSpec)(nil), (*v1alpha3.VirtualMachineServiceSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_VirtualMachineServiceSpec_To_v1alpha3_VirtualMachineServiceSpec(a.(*VirtualMachineServiceSpec), b.(*v1alpha3.VirtualMachineServiceSpec), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1alpha3.VirtualMachineServiceSpec)(nil), (*VirtualMachineServiceSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1alpha3_VirtualMachineServiceSpec_To_v1alpha2_VirtualMachineServiceSpec(a.(*v1alpha3.VirtualMachineServiceSpec), b.(*VirtualMachineServiceSpec), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*VirtualMachineServiceStatus)(nil), (*v1alpha3.VirtualMachineServiceStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_VirtualMachineServiceStatus_To_v1alpha3_VirtualMachineServiceStatus(a.(*VirtualMachineServiceStatus), b.(*v1alpha3.VirtualMachineServiceStatus), scope)
 	}); err != nil {
@@ -835,11 +800,6 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1alpha3.VirtualMachineVolumeSource)(nil), (*VirtualMachineVolumeSource)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1alpha3_VirtualMachineVolumeSource_To_v1alpha2_VirtualMachineVolumeSource(a.(*v1alpha3.VirtualMachineVolumeSource), b.(*VirtualMachineVolumeSource), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*VirtualMachineWebConsoleRequest)(nil), (*v1alpha3.VirtualMachineWebConsoleRequest)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_VirtualMachineWebConsoleRequest_To_v1alpha3_VirtualMachineWebConsoleRequest(a.(*VirtualMachineWebConsoleRequest), b.(*v1alpha3.VirtualMachineWebConsoleRequest), scope)
 	}); err != nil {
@@ -865,11 +825,6 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1alpha3.VirtualMachineWebConsoleRequestSpec)(nil), (*VirtualMachineWebConsoleRequestSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1alpha3_VirtualMachineWebConsoleRequestSpec_To_v1alpha2_VirtualMachineWebConsoleRequestSpec(a.(*v1alpha3.VirtualMachineWebConsoleRequestSpec), b.(*VirtualMachineWebConsoleRequestSpec), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*VirtualMachineWebConsoleRequestStatus)(nil), (*v1alpha3.VirtualMachineWebConsoleRequestStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_VirtualMachineWebConsoleRequestStatus_To_v1alpha3_VirtualMachineWebConsoleRequestStatus(a.(*VirtualMachineWebConsoleRequestStatus), b.(*v1alpha3.VirtualMachineWebConsoleRequestStatus), scope)
 	}); err != nil {
@@ -895,11 +850,21 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddConversionFunc((*v1alpha3.VirtualMachineAdvancedSpec)(nil), (*VirtualMachineAdvancedSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_VirtualMachineAdvancedSpec_To_v1alpha2_VirtualMachineAdvancedSpec(a.(*v1alpha3.VirtualMachineAdvancedSpec), b.(*VirtualMachineAdvancedSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddConversionFunc((*v1alpha3.VirtualMachineBootstrapCloudInitSpec)(nil), (*VirtualMachineBootstrapCloudInitSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_VirtualMachineBootstrapCloudInitSpec_To_v1alpha2_VirtualMachineBootstrapCloudInitSpec(a.(*v1alpha3.VirtualMachineBootstrapCloudInitSpec), b.(*VirtualMachineBootstrapCloudInitSpec), scope)
 	}); err != nil {
 		return err
 	}
+	if err := s.AddConversionFunc((*v1alpha3.VirtualMachineBootstrapSpec)(nil), (*VirtualMachineBootstrapSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_VirtualMachineBootstrapSpec_To_v1alpha2_VirtualMachineBootstrapSpec(a.(*v1alpha3.VirtualMachineBootstrapSpec), b.(*VirtualMachineBootstrapSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddConversionFunc((*v1alpha3.VirtualMachineImageStatus)(nil), (*VirtualMachineImageStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_VirtualMachineImageStatus_To_v1alpha2_VirtualMachineImageStatus(a.(*v1alpha3.VirtualMachineImageStatus), b.(*VirtualMachineImageStatus), scope)
 	}); err != nil {
@@ -915,6 +880,31 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddConversionFunc((*v1alpha3.VirtualMachinePublishRequestStatus)(nil), (*VirtualMachinePublishRequestStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_VirtualMachinePublishRequestStatus_To_v1alpha2_VirtualMachinePublishRequestStatus(a.(*v1alpha3.VirtualMachinePublishRequestStatus), b.(*VirtualMachinePublishRequestStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*v1alpha3.VirtualMachinePublishRequestTargetLocation)(nil), (*VirtualMachinePublishRequestTargetLocation)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_VirtualMachinePublishRequestTargetLocation_To_v1alpha2_VirtualMachinePublishRequestTargetLocation(a.(*v1alpha3.VirtualMachinePublishRequestTargetLocation), b.(*VirtualMachinePublishRequestTargetLocation), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*v1alpha3.VirtualMachineReadinessProbeSpec)(nil), (*VirtualMachineReadinessProbeSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_VirtualMachineReadinessProbeSpec_To_v1alpha2_VirtualMachineReadinessProbeSpec(a.(*v1alpha3.VirtualMachineReadinessProbeSpec), b.(*VirtualMachineReadinessProbeSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*v1alpha3.VirtualMachineServicePort)(nil), (*VirtualMachineServicePort)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_VirtualMachineServicePort_To_v1alpha2_VirtualMachineServicePort(a.(*v1alpha3.VirtualMachineServicePort), b.(*VirtualMachineServicePort), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*v1alpha3.VirtualMachineServiceSpec)(nil), (*VirtualMachineServiceSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_VirtualMachineServiceSpec_To_v1alpha2_VirtualMachineServiceSpec(a.(*v1alpha3.VirtualMachineServiceSpec), b.(*VirtualMachineServiceSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddConversionFunc((*v1alpha3.VirtualMachineSpec)(nil), (*VirtualMachineSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_VirtualMachineSpec_To_v1alpha2_VirtualMachineSpec(a.(*v1alpha3.VirtualMachineSpec), b.(*VirtualMachineSpec), scope)
 	}); err != nil {
@@ -925,11 +915,21 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddConversionFunc((*v1alpha3.VirtualMachineVolumeSource)(nil), (*VirtualMachineVolumeSource)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_VirtualMachineVolumeSource_To_v1alpha2_VirtualMachineVolumeSource(a.(*v1alpha3.VirtualMachineVolumeSource), b.(*VirtualMachineVolumeSource), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddConversionFunc((*v1alpha3.VirtualMachineVolumeStatus)(nil), (*VirtualMachineVolumeStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_VirtualMachineVolumeStatus_To_v1alpha2_VirtualMachineVolumeStatus(a.(*v1alpha3.VirtualMachineVolumeStatus), b.(*VirtualMachineVolumeStatus), scope)
 	}); err != nil {
 		return err
 	}
+	if err := s.AddConversionFunc((*v1alpha3.VirtualMachineWebConsoleRequestSpec)(nil), (*VirtualMachineWebConsoleRequestSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_VirtualMachineWebConsoleRequestSpec_To_v1alpha2_VirtualMachineWebConsoleRequestSpec(a.(*v1alpha3.VirtualMachineWebConsoleRequestSpec), b.(*VirtualMachineWebConsoleRequestSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddConversionFunc((*v1alpha3.VirtualMachine)(nil), (*VirtualMachine)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_VirtualMachine_To_v1alpha2_VirtualMachine(a.(*v1alpha3.VirtualMachine), b.(*VirtualMachine), scope)
 	}); err != nil {
@@ -1434,14 +1434,12 @@ func autoConvert_v1alpha3_VirtualMachineAdvancedSpec_To_v1alpha2_VirtualMachineA
 	out.BootDiskCapacity = (*resource.Quantity)(unsafe.Pointer(in.BootDiskCapacity))
 	out.DefaultVolumeProvisioningMode = VirtualMachineVolumeProvisioningMode(in.DefaultVolumeProvisioningMode)
 	out.ChangeBlockTracking = (*bool)(unsafe.Pointer(in.ChangeBlockTracking))
+	// WARNING: in.CPUHotAddEnabled requires manual conversion: does not exist in peer-type
+	// WARNING: in.MemoryHotAddEnabled requires manual conversion: does not exist in peer-type
+	// WARNING: in.SerialConsoleEnabled requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_v1alpha3_VirtualMachineAdvancedSpec_To_v1alpha2_VirtualMachineAdvancedSpec is an autogenerated conversion function.
-func Convert_v1alpha3_VirtualMachineAdvancedSpec_To_v1alpha2_VirtualMachineAdvancedSpec(in *v1alpha3.VirtualMachineAdvancedSpec, out *VirtualMachineAdvancedSpec, s conversion.Scope) error {
-	return autoConvert_v1alpha3_VirtualMachineAdvancedSpec_To_v1alpha2_VirtualMachineAdvancedSpec(in, out, s)
-}
-
 func autoConvert_v1alpha2_VirtualMachineBootstrapCloudInitSpec_To_v1alpha3_VirtualMachineBootstrapCloudInitSpec(in *VirtualMachineBootstrapCloudInitSpec, out *v1alpha3.VirtualMachineBootstrapCloudInitSpec, s conversion.Scope) error {
 	out.CloudConfig = (*cloudinit.CloudConfig)(unsafe.Pointer(in.CloudConfig))
 	out.RawCloudConfig = (*common.SecretKeySelector)(unsafe.Pointer(in.RawCloudConfig))
@@ -1538,14 +1536,10 @@ func autoConvert_v1alpha3_VirtualMachineBootstrapSpec_To_v1alpha2_VirtualMachine
 		out.Sysprep = nil
 	}
 	out.VAppConfig = (*VirtualMachineBootstrapVAppConfigSpec)(unsafe.Pointer(in.VAppConfig))
+	// WARNING: in.Ignition requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_v1alpha3_VirtualMachineBootstrapSpec_To_v1alpha2_VirtualMachineBootstrapSpec is an autogenerated conversion function.
-func Convert_v1alpha3_VirtualMachineBootstrapSpec_To_v1alpha2_VirtualMachineBootstrapSpec(in *v1alpha3.VirtualMachineBootstrapSpec, out *VirtualMachineBootstrapSpec, s conversion.Scope) error {
-	return autoConvert_v1alpha3_VirtualMachineBootstrapSpec_To_v1alpha2_VirtualMachineBootstrapSpec(in, out, s)
-}
-
 func autoConvert_v1alpha2_VirtualMachineBootstrapSysprepSpec_To_v1alpha3_VirtualMachineBootstrapSysprepSpec(in *VirtualMachineBootstrapSysprepSpec, out *v1alpha3.VirtualMachineBootstrapSysprepSpec, s conversion.Scope) error {
 	if in.Sysprep != nil {
 		in, out := &in.Sysprep, &out.Sysprep
@@ -2643,7 +2637,17 @@ func Convert_v1alpha3_VirtualMachinePublishRequest_To_v1alpha2_VirtualMachinePub
 
 func autoConvert_v1alpha2_VirtualMachinePublishRequestList_To_v1alpha3_VirtualMachinePublishRequestList(in *VirtualMachinePublishRequestList, out *v1alpha3.VirtualMachinePublishRequestList, s conversion.Scope) error {
 	out.ListMeta = in.ListMeta
-	out.Items = *(*[]v1alpha3.VirtualMachinePublishRequest)(unsafe.Pointer(&in.Items))
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]v1alpha3.VirtualMachinePublishRequest, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha2_VirtualMachinePublishRequest_To_v1alpha3_VirtualMachinePublishRequest(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
 	return nil
 }
 
@@ -2654,7 +2658,17 @@ func Convert_v1alpha2_VirtualMachinePublishRequestList_To_v1alpha3_VirtualMachin
 
 func autoConvert_v1alpha3_VirtualMachinePublishRequestList_To_v1alpha2_VirtualMachinePublishRequestList(in *v1alpha3.VirtualMachinePublishRequestList, out *VirtualMachinePublishRequestList, s conversion.Scope) error {
 	out.ListMeta = in.ListMeta
-	out.Items = *(*[]VirtualMachinePublishRequest)(unsafe.Pointer(&in.Items))
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VirtualMachinePublishRequest, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha3_VirtualMachinePublishRequest_To_v1alpha2_VirtualMachinePublishRequest(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
 	return nil
 }
 
@@ -2721,7 +2735,15 @@ func Convert_v1alpha3_VirtualMachinePublishRequestSpec_To_v1alpha2_VirtualMachin
 
 func autoConvert_v1alpha2_VirtualMachinePublishRequestStatus_To_v1alpha3_VirtualMachinePublishRequestStatus(in *VirtualMachinePublishRequestStatus, out *v1alpha3.VirtualMachinePublishRequestStatus, s conversion.Scope) error {
 	out.SourceRef = (*v1alpha3.VirtualMachinePublishRequestSource)(unsafe.Pointer(in.SourceRef))
-	out.TargetRef = (*v1alpha3.VirtualMachinePublishRequestTarget)(unsafe.Pointer(in.TargetRef))
+	if in.TargetRef != nil {
+		in, out := &in.TargetRef, &out.TargetRef
+		*out = new(v1alpha3.VirtualMachinePublishRequestTarget)
+		if err := Convert_v1alpha2_VirtualMachinePublishRequestTarget_To_v1alpha3_VirtualMachinePublishRequestTarget(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.TargetRef = nil
+	}
 	out.CompletionTime = in.CompletionTime
 	out.StartTime = in.StartTime
 	out.Attempts = in.Attempts
@@ -2739,22 +2761,26 @@ func Convert_v1alpha2_VirtualMachinePublishRequestStatus_To_v1alpha3_VirtualMach
 
 func autoConvert_v1alpha3_VirtualMachinePublishRequestStatus_To_v1alpha2_VirtualMachinePublishRequestStatus(in *v1alpha3.VirtualMachinePublishRequestStatus, out *VirtualMachinePublishRequestStatus, s conversion.Scope) error {
 	out.SourceRef = (*VirtualMachinePublishRequestSource)(unsafe.Pointer(in.SourceRef))
-	out.TargetRef = (*VirtualMachinePublishRequestTarget)(unsafe.Pointer(in.TargetRef))
+	if in.TargetRef != nil {
+		in, out := &in.TargetRef, &out.TargetRef
+		*out = new(VirtualMachinePublishRequestTarget)
+		if err := Convert_v1alpha3_VirtualMachinePublishRequestTarget_To_v1alpha2_VirtualMachinePublishRequestTarget(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.TargetRef = nil
+	}
 	out.CompletionTime = in.CompletionTime
 	out.StartTime = in.StartTime
 	out.Attempts = in.Attempts
 	out.LastAttemptTime = in.LastAttemptTime
 	out.ImageName = in.ImageName
+	// WARNING: in.ImageDigest requires manual conversion: does not exist in peer-type
 	out.Ready = in.Ready
 	out.Conditions = *(*[]v1.Condition)(unsafe.Pointer(&in.Conditions))
 	return nil
 }
 
-// Convert_v1alpha3_VirtualMachinePublishRequestStatus_To_v1alpha2_VirtualMachinePublishRequestStatus is an autogenerated conversion function.
-func Convert_v1alpha3_VirtualMachinePublishRequestStatus_To_v1alpha2_VirtualMachinePublishRequestStatus(in *v1alpha3.VirtualMachinePublishRequestStatus, out *VirtualMachinePublishRequestStatus, s conversion.Scope) error {
-	return autoConvert_v1alpha3_VirtualMachinePublishRequestStatus_To_v1alpha2_VirtualMachinePublishRequestStatus(in, out, s)
-}
-
 func autoConvert_v1alpha2_VirtualMachinePublishRequestTarget_To_v1alpha3_VirtualMachinePublishRequestTarget(in *VirtualMachinePublishRequestTarget, out *v1alpha3.VirtualMachinePublishRequestTarget, s conversion.Scope) error {
 	if err := Convert_v1alpha2_VirtualMachinePublishRequestTargetItem_To_v1alpha3_VirtualMachinePublishRequestTargetItem(&in.Item, &out.Item, s); err != nil {
 		return err
@@ -2823,14 +2849,10 @@ func autoConvert_v1alpha3_VirtualMachinePublishRequestTargetLocation_To_v1alpha2
 	out.Name = in.Name
 	out.APIVersion = in.APIVersion
 	out.Kind = in.Kind
+	// WARNING: in.OCIRegistry requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_v1alpha3_VirtualMachinePublishRequestTargetLocation_To_v1alpha2_VirtualMachinePublishRequestTargetLocation is an autogenerated conversion function.
-func Convert_v1alpha3_VirtualMachinePublishRequestTargetLocation_To_v1alpha2_VirtualMachinePublishRequestTargetLocation(in *v1alpha3.VirtualMachinePublishRequestTargetLocation, out *VirtualMachinePublishRequestTargetLocation, s conversion.Scope) error {
-	return autoConvert_v1alpha3_VirtualMachinePublishRequestTargetLocation_To_v1alpha2_VirtualMachinePublishRequestTargetLocation(in, out, s)
-}
-
 func autoConvert_v1alpha2_VirtualMachineReadinessProbeSpec_To_v1alpha3_VirtualMachineReadinessProbeSpec(in *VirtualMachineReadinessProbeSpec, out *v1alpha3.VirtualMachineReadinessProbeSpec, s conversion.Scope) error {
 	out.TCPSocket = (*v1alpha3.TCPSocketAction)(unsafe.Pointer(in.TCPSocket))
 	out.GuestHeartbeat = (*v1alpha3.GuestHeartbeatAction)(unsafe.Pointer(in.GuestHeartbeat))
@@ -2847,18 +2869,17 @@ func Convert_v1alpha2_VirtualMachineReadinessProbeSpec_To_v1alpha3_VirtualMachin
 
 func autoConvert_v1alpha3_VirtualMachineReadinessProbeSpec_To_v1alpha2_VirtualMachineReadinessProbeSpec(in *v1alpha3.VirtualMachineReadinessProbeSpec, out *VirtualMachineReadinessProbeSpec, s conversion.Scope) error {
 	out.TCPSocket = (*TCPSocketAction)(unsafe.Pointer(in.TCPSocket))
+	// WARNING: in.HTTPGet requires manual conversion: does not exist in peer-type
 	out.GuestHeartbeat = (*GuestHeartbeatAction)(unsafe.Pointer(in.GuestHeartbeat))
 	out.GuestInfo = *(*[]GuestInfoAction)(unsafe.Pointer(&in.GuestInfo))
 	out.TimeoutSeconds = in.TimeoutSeconds
 	out.PeriodSeconds = in.PeriodSeconds
+	// WARNING: in.InitialDelaySeconds requires manual conversion: does not exist in peer-type
+	// WARNING: in.SuccessThreshold requires manual conversion: does not exist in peer-type
+	// WARNING: in.FailureThreshold requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_v1alpha3_VirtualMachineReadinessProbeSpec_To_v1alpha2_VirtualMachineReadinessProbeSpec is an autogenerated conversion function.
-func Convert_v1alpha3_VirtualMachineReadinessProbeSpec_To_v1alpha2_VirtualMachineReadinessProbeSpec(in *v1alpha3.VirtualMachineReadinessProbeSpec, out *VirtualMachineReadinessProbeSpec, s conversion.Scope) error {
-	return autoConvert_v1alpha3_VirtualMachineReadinessProbeSpec_To_v1alpha2_VirtualMachineReadinessProbeSpec(in, out, s)
-}
-
 func autoConvert_v1alpha2_VirtualMachineReservedSpec_To_v1alpha3_VirtualMachineReservedSpec(in *VirtualMachineReservedSpec, out *v1alpha3.VirtualMachineReservedSpec, s conversion.Scope) error {
 	out.ResourcePolicyName = in.ResourcePolicyName
 	return nil
@@ -2935,7 +2956,17 @@ func Convert_v1alpha3_VirtualMachineService_To_v1alpha2_VirtualMachineService(in
 
 func autoConvert_v1alpha2_VirtualMachineServiceList_To_v1alpha3_VirtualMachineServiceList(in *VirtualMachineServiceList, out *v1alpha3.VirtualMachineServiceList, s conversion.Scope) error {
 	out.ListMeta = in.ListMeta
-	out.Items = *(*[]v1alpha3.VirtualMachineService)(unsafe.Pointer(&in.Items))
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]v1alpha3.VirtualMachineService, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha2_VirtualMachineService_To_v1alpha3_VirtualMachineService(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
 	return nil
 }
 
@@ -2946,7 +2977,17 @@ func Convert_v1alpha2_VirtualMachineServiceList_To_v1alpha3_VirtualMachineServic
 
 func autoConvert_v1alpha3_VirtualMachineServiceList_To_v1alpha2_VirtualMachineServiceList(in *v1alpha3.VirtualMachineServiceList, out *VirtualMachineServiceList, s conversion.Scope) error {
 	out.ListMeta = in.ListMeta
-	out.Items = *(*[]VirtualMachineService)(unsafe.Pointer(&in.Items))
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VirtualMachineService, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha3_VirtualMachineService_To_v1alpha2_VirtualMachineService(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
 	return nil
 }
 
@@ -2973,17 +3014,23 @@ func autoConvert_v1alpha3_VirtualMachineServicePort_To_v1alpha2_VirtualMachineSe
 	out.Protocol = in.Protocol
 	out.Port = in.Port
 	out.TargetPort = in.TargetPort
+	// WARNING: in.TargetPortName requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_v1alpha3_VirtualMachineServicePort_To_v1alpha2_VirtualMachineServicePort is an autogenerated conversion function.
-func Convert_v1alpha3_VirtualMachineServicePort_To_v1alpha2_VirtualMachineServicePort(in *v1alpha3.VirtualMachineServicePort, out *VirtualMachineServicePort, s conversion.Scope) error {
-	return autoConvert_v1alpha3_VirtualMachineServicePort_To_v1alpha2_VirtualMachineServicePort(in, out, s)
-}
-
 func autoConvert_v1alpha2_VirtualMachineServiceSpec_To_v1alpha3_VirtualMachineServiceSpec(in *VirtualMachineServiceSpec, out *v1alpha3.VirtualMachineServiceSpec, s conversion.Scope) error {
 	out.Type = v1alpha3.VirtualMachineServiceType(in.Type)
-	out.Ports = *(*[]v1alpha3.VirtualMachineServicePort)(unsafe.Pointer(&in.Ports))
+	if in.Ports != nil {
+		in, out := &in.Ports, &out.Ports
+		*out = make([]v1alpha3.VirtualMachineServicePort, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha2_VirtualMachineServicePort_To_v1alpha3_VirtualMachineServicePort(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Ports = nil
+	}
 	out.Selector = *(*map[string]string)(unsafe.Pointer(&in.Selector))
 	out.LoadBalancerIP = in.LoadBalancerIP
 	out.LoadBalancerSourceRanges = *(*[]string)(unsafe.Pointer(&in.LoadBalancerSourceRanges))
@@ -2999,20 +3046,29 @@ func Convert_v1alpha2_VirtualMachineServiceSpec_To_v1alpha3_VirtualMachineServic
 
 func autoConvert_v1alpha3_VirtualMachineServiceSpec_To_v1alpha2_VirtualMachineServiceSpec(in *v1alpha3.VirtualMachineServiceSpec, out *VirtualMachineServiceSpec, s conversion.Scope) error {
 	out.Type = VirtualMachineServiceType(in.Type)
-	out.Ports = *(*[]VirtualMachineServicePort)(unsafe.Pointer(&in.Ports))
+	if in.Ports != nil {
+		in, out := &in.Ports, &out.Ports
+		*out = make([]VirtualMachineServicePort, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha3_VirtualMachineServicePort_To_v1alpha2_VirtualMachineServicePort(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Ports = nil
+	}
 	out.Selector = *(*map[string]string)(unsafe.Pointer(&in.Selector))
 	out.LoadBalancerIP = in.LoadBalancerIP
 	out.LoadBalancerSourceRanges = *(*[]string)(unsafe.Pointer(&in.LoadBalancerSourceRanges))
 	out.ClusterIP = in.ClusterIP
 	out.ExternalName = in.ExternalName
+	// WARNING: in.SessionAffinity requires manual conversion: does not exist in peer-type
+	// WARNING: in.SessionAffinityConfig requires manual conversion: does not exist in peer-type
+	// WARNING: in.ExternalTrafficPolicy requires manual conversion: does not exist in peer-type
+	// WARNING: in.HealthCheckNodePort requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_v1alpha3_VirtualMachineServiceSpec_To_v1alpha2_VirtualMachineServiceSpec is an autogenerated conversion function.
-func Convert_v1alpha3_VirtualMachineServiceSpec_To_v1alpha2_VirtualMachineServiceSpec(in *v1alpha3.VirtualMachineServiceSpec, out *VirtualMachineServiceSpec, s conversion.Scope) error {
-	return autoConvert_v1alpha3_VirtualMachineServiceSpec_To_v1alpha2_VirtualMachineServiceSpec(in, out, s)
-}
-
 func autoConvert_v1alpha2_VirtualMachineServiceStatus_To_v1alpha3_VirtualMachineServiceStatus(in *VirtualMachineServiceStatus, out *v1alpha3.VirtualMachineServiceStatus, s conversion.Scope) error {
 	if err := Convert_v1alpha2_LoadBalancerStatus_To_v1alpha3_LoadBalancerStatus(&in.LoadBalancer, &out.LoadBalancer, s); err != nil {
 		return err
@@ -3166,9 +3222,35 @@ func autoConvert_v1alpha2_VirtualMachineSpec_To_v1alpha3_VirtualMachineSpec(in *
 	out.SuspendMode = v1alpha3.VirtualMachinePowerOpMode(in.SuspendMode)
 	out.NextRestartTime = in.NextRestartTime
 	out.RestartMode = v1alpha3.VirtualMachinePowerOpMode(in.RestartMode)
-	out.Volumes = *(*[]v1alpha3.VirtualMachineVolume)(unsafe.Pointer(&in.Volumes))
-	out.ReadinessProbe = (*v1alpha3.VirtualMachineReadinessProbeSpec)(unsafe.Pointer(in.ReadinessProbe))
-	out.Advanced = (*v1alpha3.VirtualMachineAdvancedSpec)(unsafe.Pointer(in.Advanced))
+	if in.Volumes != nil {
+		in, out := &in.Volumes, &out.Volumes
+		*out = make([]v1alpha3.VirtualMachineVolume, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha2_VirtualMachineVolume_To_v1alpha3_VirtualMachineVolume(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Volumes = nil
+	}
+	if in.ReadinessProbe != nil {
+		in, out := &in.ReadinessProbe, &out.ReadinessProbe
+		*out = new(v1alpha3.VirtualMachineReadinessProbeSpec)
+		if err := Convert_v1alpha2_VirtualMachineReadinessProbeSpec_To_v1alpha3_VirtualMachineReadinessProbeSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.ReadinessProbe = nil
+	}
+	if in.Advanced != nil {
+		in, out := &in.Advanced, &out.Advanced
+		*out = new(v1alpha3.VirtualMachineAdvancedSpec)
+		if err := Convert_v1alpha2_VirtualMachineAdvancedSpec_To_v1alpha3_VirtualMachineAdvancedSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Advanced = nil
+	}
 	out.Reserved = (*v1alpha3.VirtualMachineReservedSpec)(unsafe.Pointer(in.Reserved))
 	out.MinHardwareVersion = in.MinHardwareVersion
 	return nil
@@ -3182,6 +3264,7 @@ func Convert_v1alpha2_VirtualMachineSpec_To_v1alpha3_VirtualMachineSpec(in *Virt
 func autoConvert_v1alpha3_VirtualMachineSpec_To_v1alpha2_VirtualMachineSpec(in *v1alpha3.VirtualMachineSpec, out *VirtualMachineSpec, s conversion.Scope) error {
 	// WARNING: in.Cdrom requires manual conversion: does not exist in peer-type
 	// WARNING: in.Image requires manual conversion: does not exist in peer-type
+	// WARNING: in.Clone requires manual conversion: does not exist in peer-type
 	out.ImageName = in.ImageName
 	out.ClassName = in.ClassName
 	// WARNING: in.Crypto requires manual conversion: does not exist in peer-type
@@ -3206,17 +3289,50 @@ func autoConvert_v1alpha3_VirtualMachineSpec_To_v1alpha2_VirtualMachineSpec(in *
 	}
 	out.PowerState = VirtualMachinePowerState(in.PowerState)
 	out.PowerOffMode = VirtualMachinePowerOpMode(in.PowerOffMode)
+	// WARNING: in.PowerOffTimeoutSeconds requires manual conversion: does not exist in peer-type
+	// WARNING: in.PreStop requires manual conversion: does not exist in peer-type
 	out.SuspendMode = VirtualMachinePowerOpMode(in.SuspendMode)
 	out.NextRestartTime = in.NextRestartTime
 	out.RestartMode = VirtualMachinePowerOpMode(in.RestartMode)
-	out.Volumes = *(*[]VirtualMachineVolume)(unsafe.Pointer(&in.Volumes))
-	out.ReadinessProbe = (*VirtualMachineReadinessProbeSpec)(unsafe.Pointer(in.ReadinessProbe))
-	out.Advanced = (*VirtualMachineAdvancedSpec)(unsafe.Pointer(in.Advanced))
+	// WARNING: in.PowerSchedule requires manual conversion: does not exist in peer-type
+	if in.Volumes != nil {
+		in, out := &in.Volumes, &out.Volumes
+		*out = make([]VirtualMachineVolume, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha3_VirtualMachineVolume_To_v1alpha2_VirtualMachineVolume(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Volumes = nil
+	}
+	if in.ReadinessProbe != nil {
+		in, out := &in.ReadinessProbe, &out.ReadinessProbe
+		*out = new(VirtualMachineReadinessProbeSpec)
+		if err := Convert_v1alpha3_VirtualMachineReadinessProbeSpec_To_v1alpha2_VirtualMachineReadinessProbeSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.ReadinessProbe = nil
+	}
+	// WARNING: in.LivenessProbe requires manual conversion: does not exist in peer-type
+	if in.Advanced != nil {
+		in, out := &in.Advanced, &out.Advanced
+		*out = new(VirtualMachineAdvancedSpec)
+		if err := Convert_v1alpha3_VirtualMachineAdvancedSpec_To_v1alpha2_VirtualMachineAdvancedSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Advanced = nil
+	}
 	out.Reserved = (*VirtualMachineReservedSpec)(unsafe.Pointer(in.Reserved))
 	out.MinHardwareVersion = in.MinHardwareVersion
 	// WARNING: in.InstanceUUID requires manual conversion: does not exist in peer-type
 	// WARNING: in.BiosUUID requires manual conversion: does not exist in peer-type
 	// WARNING: in.GuestID requires manual conversion: does not exist in peer-type
+	// WARNING: in.CurrentSnapshot requires manual conversion: does not exist in peer-type
+	// WARNING: in.Placement requires manual conversion: does not exist in peer-type
+	// WARNING: in.DriftPolicy requires manual conversion: does not exist in peer-type
 	return nil
 }
 
@@ -3288,8 +3404,14 @@ func autoConvert_v1alpha3_VirtualMachineStatus_To_v1alpha2_VirtualMachineStatus(
 	out.ChangeBlockTracking = (*bool)(unsafe.Pointer(in.ChangeBlockTracking))
 	out.Zone = in.Zone
 	out.LastRestartTime = (*v1.Time)(unsafe.Pointer(in.LastRestartTime))
+	// WARNING: in.RestartCount requires manual conversion: does not exist in peer-type
+	// WARNING: in.PowerSchedule requires manual conversion: does not exist in peer-type
 	out.HardwareVersion = in.HardwareVersion
 	// WARNING: in.Storage requires manual conversion: does not exist in peer-type
+	// WARNING: in.CurrentSnapshot requires manual conversion: does not exist in peer-type
+	// WARNING: in.RootSnapshots requires manual conversion: does not exist in peer-type
+	// WARNING: in.Resize requires manual conversion: does not exist in peer-type
+	// WARNING: in.Drift requires manual conversion: does not exist in peer-type
 	return nil
 }
 
@@ -3373,14 +3495,12 @@ func Convert_v1alpha2_VirtualMachineVolumeSource_To_v1alpha3_VirtualMachineVolum
 
 func autoConvert_v1alpha3_VirtualMachineVolumeSource_To_v1alpha2_VirtualMachineVolumeSource(in *v1alpha3.VirtualMachineVolumeSource, out *VirtualMachineVolumeSource, s conversion.Scope) error {
 	out.PersistentVolumeClaim = (*PersistentVolumeClaimVolumeSource)(unsafe.Pointer(in.PersistentVolumeClaim))
+	// WARNING: in.Ephemeral requires manual conversion: does not exist in peer-type
+	// WARNING: in.ConfigMap requires manual conversion: does not exist in peer-type
+	// WARNING: in.Secret requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_v1alpha3_VirtualMachineVolumeSource_To_v1alpha2_VirtualMachineVolumeSource is an autogenerated conversion function.
-func Convert_v1alpha3_VirtualMachineVolumeSource_To_v1alpha2_VirtualMachineVolumeSource(in *v1alpha3.VirtualMachineVolumeSource, out *VirtualMachineVolumeSource, s conversion.Scope) error {
-	return autoConvert_v1alpha3_VirtualMachineVolumeSource_To_v1alpha2_VirtualMachineVolumeSource(in, out, s)
-}
-
 func autoConvert_v1alpha2_VirtualMachineVolumeStatus_To_v1alpha3_VirtualMachineVolumeStatus(in *VirtualMachineVolumeStatus, out *v1alpha3.VirtualMachineVolumeStatus, s conversion.Scope) error {
 	out.Name = in.Name
 	out.Attached = in.Attached
@@ -3395,6 +3515,7 @@ func autoConvert_v1alpha3_VirtualMachineVolumeStatus_To_v1alpha2_VirtualMachineV
 	// WARNING: in.Crypto requires manual conversion: does not exist in peer-type
 	// WARNING: in.Limit requires manual conversion: does not exist in peer-type
 	// WARNING: in.Used requires manual conversion: does not exist in peer-type
+	// WARNING: in.ResizeStatus requires manual conversion: does not exist in peer-type
 	out.Attached = in.Attached
 	out.DiskUUID = in.DiskUUID
 	out.Error = in.Error
@@ -3435,7 +3556,17 @@ func Convert_v1alpha3_VirtualMachineWebConsoleRequest_To_v1alpha2_VirtualMachine
 
 func autoConvert_v1alpha2_VirtualMachineWebConsoleRequestList_To_v1alpha3_VirtualMachineWebConsoleRequestList(in *VirtualMachineWebConsoleRequestList, out *v1alpha3.VirtualMachineWebConsoleRequestList, s conversion.Scope) error {
 	out.ListMeta = in.ListMeta
-	out.Items = *(*[]v1alpha3.VirtualMachineWebConsoleRequest)(unsafe.Pointer(&in.Items))
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]v1alpha3.VirtualMachineWebConsoleRequest, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha2_VirtualMachineWebConsoleRequest_To_v1alpha3_VirtualMachineWebConsoleRequest(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
 	return nil
 }
 
@@ -3446,7 +3577,17 @@ func Convert_v1alpha2_VirtualMachineWebConsoleRequestList_To_v1alpha3_VirtualMac
 
 func autoConvert_v1alpha3_VirtualMachineWebConsoleRequestList_To_v1alpha2_VirtualMachineWebConsoleRequestList(in *v1alpha3.VirtualMachineWebConsoleRequestList, out *VirtualMachineWebConsoleRequestList, s conversion.Scope) error {
 	out.ListMeta = in.ListMeta
-	out.Items = *(*[]VirtualMachineWebConsoleRequest)(unsafe.Pointer(&in.Items))
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VirtualMachineWebConsoleRequest, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha3_VirtualMachineWebConsoleRequest_To_v1alpha2_VirtualMachineWebConsoleRequest(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
 	return nil
 }
 
@@ -3469,14 +3610,10 @@ func Convert_v1alpha2_VirtualMachineWebConsoleRequestSpec_To_v1alpha3_VirtualMac
 func autoConvert_v1alpha3_VirtualMachineWebConsoleRequestSpec_To_v1alpha2_VirtualMachineWebConsoleRequestSpec(in *v1alpha3.VirtualMachineWebConsoleRequestSpec, out *VirtualMachineWebConsoleRequestSpec, s conversion.Scope) error {
 	out.Name = in.Name
 	out.PublicKey = in.PublicKey
+	// WARNING: in.ConsoleType requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_v1alpha3_VirtualMachineWebConsoleRequestSpec_To_v1alpha2_VirtualMachineWebConsoleRequestSpec is an autogenerated conversion function.
-func Convert_v1alpha3_VirtualMachineWebConsoleRequestSpec_To_v1alpha2_VirtualMachineWebConsoleRequestSpec(in *v1alpha3.VirtualMachineWebConsoleRequestSpec, out *VirtualMachineWebConsoleRequestSpec, s conversion.Scope) error {
-	return autoConvert_v1alpha3_VirtualMachineWebConsoleRequestSpec_To_v1alpha2_VirtualMachineWebConsoleRequestSpec(in, out, s)
-}
-
 func autoConvert_v1alpha2_VirtualMachineWebConsoleRequestStatus_To_v1alpha3_VirtualMachineWebConsoleRequestStatus(in *VirtualMachineWebConsoleRequestStatus, out *v1alpha3.VirtualMachineWebConsoleRequestStatus, s conversion.Scope) error {
 	out.Response = in.Response
 	out.ExpiryTime = in.ExpiryTime
@@ -81,6 +81,19 @@ type VirtualMachineBootstrapSpec struct {
 	// This bootstrap provider may not be used in conjunction with the CloudInit
 	// bootstrap provider.
 	VAppConfig *VirtualMachineBootstrapVAppConfigSpec `json:"vAppConfig,omitempty"`
+
+	// +optional
+
+	// Ignition may be used to bootstrap Linux guests that rely on Ignition,
+	// such as Flatcar Container Linux, Fedora CoreOS, and Red Hat Enterprise
+	// Linux CoreOS.
+	//
+	// The guest's networking stack is configured by Ignition writing
+	// systemd-networkd units into the guest.
+	//
+	// Please note this bootstrap provider may not be used in conjunction with
+	// the other bootstrap providers.
+	Ignition *VirtualMachineBootstrapIgnitionSpec `json:"ignition,omitempty"`
 }
 
 // VirtualMachineBootstrapCloudInitSpec describes the CloudInit configuration
@@ -209,3 +222,110 @@ type VirtualMachineBootstrapVAppConfigSpec struct {
 	// Please note this field and Properties are mutually exclusive.
 	RawProperties string `json:"rawProperties,omitempty"`
 }
+
+// VirtualMachineBootstrapIgnitionSpec describes the Ignition configuration
+// used to bootstrap the VM.
+type VirtualMachineBootstrapIgnitionSpec struct {
+	// +optional
+
+	// Config describes a subset of a Butane config, used to bootstrap the VM.
+	//
+	// Please note this field and RawConfig are mutually exclusive.
+	Config *VirtualMachineBootstrapIgnitionConfig `json:"config,omitempty"`
+
+	// +optional
+
+	// RawConfig describes a key in a Secret resource that contains the
+	// Ignition config data used to bootstrap the VM.
+	//
+	// The Ignition config data specified by the key must be JSON and may be
+	// plain-text, base64-encoded, or gzipped and base64-encoded.
+	//
+	// Please note this field and Config are mutually exclusive.
+	RawConfig *vmopv1common.SecretKeySelector `json:"rawConfig,omitempty"`
+}
+
+// VirtualMachineBootstrapIgnitionConfig describes a subset of a Butane config.
+type VirtualMachineBootstrapIgnitionConfig struct {
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+
+	// Users is a list of users to add to the guest.
+	Users []VirtualMachineBootstrapIgnitionUser `json:"users,omitempty"`
+
+	// +optional
+	// +listType=map
+	// +listMapKey=path
+
+	// Files is a list of files to write to the guest.
+	Files []VirtualMachineBootstrapIgnitionFile `json:"files,omitempty"`
+
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+
+	// SystemdUnits is a list of systemd units to add to the guest.
+	SystemdUnits []VirtualMachineBootstrapIgnitionSystemdUnit `json:"systemdUnits,omitempty"`
+}
+
+// VirtualMachineBootstrapIgnitionUser describes a user to add to the guest.
+type VirtualMachineBootstrapIgnitionUser struct {
+	// Name is the user's login name.
+	Name string `json:"name"`
+
+	// +optional
+
+	// Groups is a list of supplementary groups for the user.
+	Groups []string `json:"groups,omitempty"`
+
+	// +optional
+
+	// SSHAuthorizedKeys is a list of public keys to add to the user's
+	// authorized keys.
+	SSHAuthorizedKeys []string `json:"sshAuthorizedKeys,omitempty"`
+}
+
+// VirtualMachineBootstrapIgnitionFile describes a file to write to the guest.
+type VirtualMachineBootstrapIgnitionFile struct {
+	// Path is the absolute path of the file.
+	Path string `json:"path"`
+
+	// +optional
+
+	// Contents is the plain-text contents of the file.
+	Contents string `json:"contents,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=4095
+
+	// Mode is the file's permission mode, expressed as a decimal number, ex.
+	// 420 for 0644.
+	//
+	// Defaults to 420 (0644) if omitted.
+	Mode *int32 `json:"mode,omitempty"`
+
+	// +optional
+
+	// Overwrite specifies whether to replace a file that already exists at
+	// the path.
+	Overwrite *bool `json:"overwrite,omitempty"`
+}
+
+// VirtualMachineBootstrapIgnitionSystemdUnit describes a systemd unit to add
+// to the guest.
+type VirtualMachineBootstrapIgnitionSystemdUnit struct {
+	// Name is the name of the unit, ex. "example.service".
+	Name string `json:"name"`
+
+	// +optional
+
+	// Enabled specifies whether the unit is started on boot.
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// +optional
+
+	// Contents is the contents of the unit file.
+	Contents string `json:"contents,omitempty"`
+}
@@ -0,0 +1,65 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha3
+
+// VirtualMachineLivenessProbeSpec describes a probe used to determine if a VM
+// is alive. When the probe fails FailureThreshold consecutive times, the VM
+// is restarted in accordance with spec.restartMode. All probe actions are
+// mutually exclusive.
+type VirtualMachineLivenessProbeSpec struct {
+	// +optional
+
+	// TCPSocket specifies an action involving a TCP port.
+	TCPSocket *TCPSocketAction `json:"tcpSocket,omitempty"`
+
+	// +optional
+
+	// HTTPGet specifies an action involving an HTTP GET request to the VM.
+	HTTPGet *HTTPGetAction `json:"httpGet,omitempty"`
+
+	// +optional
+
+	// GuestHeartbeat specifies an action involving the guest heartbeat status.
+	GuestHeartbeat *GuestHeartbeatAction `json:"guestHeartbeat,omitempty"`
+
+	// +optional
+
+	// GuestInfo specifies an action involving key/value pairs from GuestInfo.
+	//
+	// The elements are evaluated with the logical AND operator, meaning
+	// all expressions must evaluate as true for the probe to succeed.
+	GuestInfo []GuestInfoAction `json:"guestInfo,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Minimum:=1
+	// +kubebuilder:validation:Maximum:=60
+
+	// TimeoutSeconds specifies a number of seconds after which the probe times out.
+	// Defaults to 10 seconds. Minimum value is 1.
+	TimeoutSeconds int32 `json:"timeoutSeconds,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Minimum:=1
+
+	// PeriodSeconds specifics how often (in seconds) to perform the probe.
+	// Defaults to 10 seconds. Minimum value is 1.
+	PeriodSeconds int32 `json:"periodSeconds,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Minimum:=0
+
+	// InitialDelaySeconds specifies the number of seconds after the VM has
+	// been powered on or restarted before the probe is run. Defaults to 0
+	// seconds.
+	InitialDelaySeconds int32 `json:"initialDelaySeconds,omitempty"`
+
+	// +optional
+	// +kubebuilder:default=3
+	// +kubebuilder:validation:Minimum:=1
+
+	// FailureThreshold specifies the number of consecutive failures after
+	// which the VM is restarted. Defaults to 3. Minimum value is 1.
+	FailureThreshold int32 `json:"failureThreshold,omitempty"`
+}
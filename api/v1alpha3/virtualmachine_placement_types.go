@@ -0,0 +1,93 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha3
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +kubebuilder:validation:Enum=DoNotSchedule;ScheduleAnyway
+
+// VirtualMachineUnsatisfiableConstraintAction describes how a placement
+// constraint is handled when no zone satisfies it.
+type VirtualMachineUnsatisfiableConstraintAction string
+
+const (
+	// DoNotScheduleUnsatisfiableConstraintAction prevents the VM from being
+	// placed in a zone that does not satisfy the constraint.
+	DoNotScheduleUnsatisfiableConstraintAction VirtualMachineUnsatisfiableConstraintAction = "DoNotSchedule"
+
+	// ScheduleAnywayUnsatisfiableConstraintAction allows the VM to be placed in
+	// any zone, but prefers the zones that best satisfy the constraint.
+	ScheduleAnywayUnsatisfiableConstraintAction VirtualMachineUnsatisfiableConstraintAction = "ScheduleAnyway"
+)
+
+// VirtualMachinePlacementSpec describes the policy used to select the zone in
+// which a VM is placed.
+//
+// The policy is only used when the VM does not already have a zone, and it is
+// computed from the zones, as reported by status.zone, of the existing VMs in
+// the same namespace.
+type VirtualMachinePlacementSpec struct {
+	// +optional
+	// +listType=atomic
+
+	// TopologySpreadConstraints describes how VMs that match a label selector
+	// are spread across zones.
+	TopologySpreadConstraints []VirtualMachineTopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+
+	// +optional
+
+	// ZoneAntiAffinity describes the VMs this VM should not share a zone
+	// with.
+	ZoneAntiAffinity *VirtualMachineZoneAntiAffinity `json:"zoneAntiAffinity,omitempty"`
+
+	// +optional
+	// +listType=set
+
+	// PreferredZones is a list of zones in which the VM should be placed
+	// when possible. Zones that appear earlier in the list are preferred over
+	// zones that appear later.
+	//
+	// Preferred zones are only considered after the topology spread
+	// constraints and zone anti-affinity.
+	PreferredZones []string `json:"preferredZones,omitempty"`
+}
+
+// VirtualMachineTopologySpreadConstraint describes how VMs that match a label
+// selector are spread across zones.
+type VirtualMachineTopologySpreadConstraint struct {
+	// +optional
+	// +kubebuilder:default=1
+	// +kubebuilder:validation:Minimum=1
+
+	// MaxSkew describes the maximum permitted difference between the number of
+	// matching VMs in any two zones. Defaults to 1.
+	MaxSkew int32 `json:"maxSkew,omitempty"`
+
+	// LabelSelector is used to find the matching VMs in the same namespace.
+	LabelSelector *metav1.LabelSelector `json:"labelSelector"`
+
+	// +optional
+	// +kubebuilder:default=DoNotSchedule
+
+	// WhenUnsatisfiable describes how to handle a VM that cannot be placed
+	// without exceeding MaxSkew. Defaults to DoNotSchedule.
+	WhenUnsatisfiable VirtualMachineUnsatisfiableConstraintAction `json:"whenUnsatisfiable,omitempty"`
+}
+
+// VirtualMachineZoneAntiAffinity describes the VMs that a VM should not share
+// a zone with.
+type VirtualMachineZoneAntiAffinity struct {
+	// LabelSelector is used to find the matching VMs in the same namespace.
+	LabelSelector *metav1.LabelSelector `json:"labelSelector"`
+
+	// +optional
+	// +kubebuilder:default=DoNotSchedule
+
+	// WhenUnsatisfiable describes how to handle a VM when every zone already
+	// has a matching VM. Defaults to DoNotSchedule.
+	WhenUnsatisfiable VirtualMachineUnsatisfiableConstraintAction `json:"whenUnsatisfiable,omitempty"`
+}
@@ -21,6 +21,11 @@ type VirtualMachineReadinessProbeSpec struct {
 
 	// +optional
 
+	// HTTPGet specifies an action involving an HTTP GET request to the VM.
+	HTTPGet *HTTPGetAction `json:"httpGet,omitempty"`
+
+	// +optional
+
 	// GuestHeartbeat specifies an action involving the guest heartbeat status.
 	GuestHeartbeat *GuestHeartbeatAction `json:"guestHeartbeat,omitempty"`
 
@@ -66,13 +71,38 @@ type VirtualMachineReadinessProbeSpec struct {
 	// PeriodSeconds specifics how often (in seconds) to perform the probe.
 	// Defaults to 10 seconds. Minimum value is 1.
 	PeriodSeconds int32 `json:"periodSeconds,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Minimum:=0
+
+	// InitialDelaySeconds specifies the number of seconds after the VM has
+	// been powered on before the probe is run. Defaults to 0 seconds.
+	InitialDelaySeconds int32 `json:"initialDelaySeconds,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Minimum:=1
+
+	// SuccessThreshold specifies the number of consecutive successes after
+	// which the VM is considered ready when it was not previously ready.
+	// Defaults to 1. Minimum value is 1.
+	SuccessThreshold int32 `json:"successThreshold,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Minimum:=1
+
+	// FailureThreshold specifies the number of consecutive failures after
+	// which the VM is considered not ready when it was previously ready.
+	// Defaults to 1. Minimum value is 1.
+	FailureThreshold int32 `json:"failureThreshold,omitempty"`
 }
 
 // TCPSocketAction describes an action based on opening a socket.
 type TCPSocketAction struct {
 	// Port specifies a number or name of the port to access on the VM.
 	// If the format of port is a number, it must be in the range 1 to 65535.
-	// If the format of name is a string, it must be an IANA_SVC_NAME.
+	// If the format of name is a string, it must be an IANA_SVC_NAME and is
+	// resolved by the VM's "port.vmservice.vmoperator.vmware.com/<name>"
+	// annotation.
 	Port intstr.IntOrString `json:"port"`
 
 	// +optional
@@ -81,6 +111,81 @@ type TCPSocketAction struct {
 	Host string `json:"host,omitempty"`
 }
 
+// +kubebuilder:validation:Enum=HTTP;HTTPS
+
+// URIScheme identifies the scheme used for connection to a host.
+type URIScheme string
+
+const (
+	// URISchemeHTTP means that the scheme used will be http://.
+	URISchemeHTTP URIScheme = "HTTP"
+
+	// URISchemeHTTPS means that the scheme used will be https://.
+	URISchemeHTTPS URIScheme = "HTTPS"
+)
+
+// HTTPHeader describes a custom header to be used in HTTP probes.
+type HTTPHeader struct {
+	// Name is the header field name.
+	Name string `json:"name"`
+
+	// Value is the header field value.
+	Value string `json:"value"`
+}
+
+// HTTPGetAction describes an action based on HTTP GET requests.
+type HTTPGetAction struct {
+	// +optional
+
+	// Path is the path to access on the HTTP server. Defaults to "/".
+	Path string `json:"path,omitempty"`
+
+	// Port specifies a number or name of the port to access on the VM.
+	// If the format of port is a number, it must be in the range 1 to 65535.
+	// If the format of name is a string, it must be an IANA_SVC_NAME and is
+	// resolved by the VM's "port.vmservice.vmoperator.vmware.com/<name>"
+	// annotation.
+	Port intstr.IntOrString `json:"port"`
+
+	// +optional
+
+	// Host is an optional host name to connect to. Host defaults to the VM IP.
+	// To set the HTTP Host header, use HTTPHeaders instead.
+	Host string `json:"host,omitempty"`
+
+	// +optional
+	// +kubebuilder:default=HTTP
+
+	// Scheme to use for connecting to the host. Defaults to HTTP.
+	//
+	// Please note that when HTTPS is used, the server's certificate is not
+	// verified.
+	Scheme URIScheme `json:"scheme,omitempty"`
+
+	// +optional
+	// +listType=atomic
+
+	// HTTPHeaders are custom headers to set in the request. HTTP allows
+	// repeated headers.
+	HTTPHeaders []HTTPHeader `json:"httpHeaders,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Minimum:=100
+	// +kubebuilder:validation:Maximum:=599
+
+	// MinStatusCode is the lowest HTTP status code that is considered a
+	// success. Defaults to 200.
+	MinStatusCode int32 `json:"minStatusCode,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Minimum:=100
+	// +kubebuilder:validation:Maximum:=599
+
+	// MaxStatusCode is the highest HTTP status code that is considered a
+	// success. Defaults to 399.
+	MaxStatusCode int32 `json:"maxStatusCode,omitempty"`
+}
+
 // GuestHeartbeatStatus is the guest heartbeat status.
 type GuestHeartbeatStatus string
 
@@ -35,7 +35,7 @@ type VirtualMachineVolume struct {
 }
 
 // VirtualMachineVolumeSource represents the source location of a volume to
-// mount. Only one of its members may be specified.
+// mount. Exactly one of its members must be specified.
 type VirtualMachineVolumeSource struct {
 	// +optional
 
@@ -45,6 +45,49 @@ type VirtualMachineVolumeSource struct {
 	// More information is available at
 	// https://kubernetes.io/docs/concepts/storage/persistent-volumes#persistentvolumeclaims.
 	PersistentVolumeClaim *PersistentVolumeClaimVolumeSource `json:"persistentVolumeClaim,omitempty"`
+
+	// +optional
+
+	// Ephemeral represents a disk that is created in the VM's directory when
+	// the volume is added to the VM and deleted when the volume is removed
+	// from the VM or the VM is deleted.
+	Ephemeral *EphemeralVolumeSource `json:"ephemeral,omitempty"`
+
+	// +optional
+
+	// ConfigMap represents a ConfigMap in the same namespace whose data is
+	// written to a small, read-only disk attached to the VM. The disk contains
+	// an ISO 9660 file system the guest may mount, and the volume's name is
+	// used as the file system's label.
+	//
+	// Like a ConfigMap volume for a pod, each key is written to a file of the
+	// same name unless items map the key to another path. The mode of a file
+	// is ignored as the disk is read-only.
+	//
+	// The disk is regenerated each time the VM is powered on, so changes to
+	// the data of the ConfigMap are applied the next time the VM is powered
+	// on.
+	ConfigMap *corev1.ConfigMapVolumeSource `json:"configMap,omitempty"`
+
+	// +optional
+
+	// Secret represents a Secret in the same namespace whose data is written
+	// to a small, read-only disk attached to the VM. Please refer to ConfigMap
+	// for more information about the disk.
+	Secret *corev1.SecretVolumeSource `json:"secret,omitempty"`
+}
+
+// EphemeralVolumeSource describes a disk that lives and dies with the VM.
+type EphemeralVolumeSource struct {
+	// Size is the capacity of the disk.
+	Size resource.Quantity `json:"size"`
+
+	// +optional
+
+	// ProvisioningMode describes the provisioning mode of the disk. Defaults to
+	// the VM's spec.advanced.defaultVolumeProvisioningMode, or Thin if that is
+	// not set.
+	ProvisioningMode VirtualMachineVolumeProvisioningMode `json:"provisioningMode,omitempty"`
 }
 
 // PersistentVolumeClaimVolumeSource is a composite for the Kubernetes
@@ -85,6 +128,28 @@ const (
 	VirtualMachineStorageDiskTypeManaged VirtualMachineVolumeType = "Managed"
 )
 
+// +kubebuilder:validation:Enum=ControllerResizeInProgress;ControllerResizeFailed;FileSystemResizePending
+
+// VirtualMachineVolumeResizeStatus describes the state of an in-progress
+// expansion of a volume.
+type VirtualMachineVolumeResizeStatus string
+
+const (
+	// VirtualMachineVolumeResizeStatusControllerResizeInProgress indicates
+	// the volume's underlying disk is being expanded.
+	VirtualMachineVolumeResizeStatusControllerResizeInProgress VirtualMachineVolumeResizeStatus = "ControllerResizeInProgress"
+
+	// VirtualMachineVolumeResizeStatusControllerResizeFailed indicates the
+	// expansion of the volume's underlying disk failed.
+	VirtualMachineVolumeResizeStatusControllerResizeFailed VirtualMachineVolumeResizeStatus = "ControllerResizeFailed"
+
+	// VirtualMachineVolumeResizeStatusFileSystemResizePending indicates the
+	// volume's underlying disk has been expanded, but the file system on the
+	// disk must be grown by the guest before the additional capacity may be
+	// used.
+	VirtualMachineVolumeResizeStatusFileSystemResizePending VirtualMachineVolumeResizeStatus = "FileSystemResizePending"
+)
+
 type VirtualMachineVolumeCryptoStatus struct {
 	// +optional
 
@@ -131,6 +196,12 @@ type VirtualMachineVolumeStatus struct {
 
 	// +optional
 
+	// ResizeStatus describes the state of an in-progress expansion of the
+	// volume. This field is empty when the volume is not being expanded.
+	ResizeStatus VirtualMachineVolumeResizeStatus `json:"resizeStatus,omitempty"`
+
+	// +optional
+
 	// Attached represents whether a volume has been successfully attached to
 	// the VirtualMachine or not.
 	Attached bool `json:"attached,omitempty"`
@@ -168,6 +239,15 @@ func SortVirtualMachineVolumeStatuses(s []VirtualMachineVolumeStatus) {
 type VirtualMachineStorageStatus struct {
 	// +optional
 
+	// StorageClass describes the name of the StorageClass whose storage
+	// policy was last applied to the VM's home and classic disks.
+	//
+	// When this differs from spec.storageClass, the VM is migrated to a
+	// datastore compatible with the storage policy of spec.storageClass.
+	StorageClass string `json:"storageClass,omitempty"`
+
+	// +optional
+
 	// Usage describes the observed amount of storage used by a VirtualMachine.
 	Usage *VirtualMachineStorageStatusUsage `json:"usage,omitempty"`
 }
@@ -7,6 +7,7 @@
 package v1alpha3
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -54,6 +55,23 @@ const (
 	VirtualMachineClassConfigurationSynced = "VirtualMachineClassConfigurationSynced"
 )
 
+const (
+	// VirtualMachineStorageClassSynced indicates that the VM's home and classic
+	// disks are placed on a datastore compatible with the storage policy of
+	// the VM's StorageClass.
+	VirtualMachineStorageClassSynced = "VirtualMachineStorageClassSynced"
+
+	// VirtualMachineStorageClassMigratingReason documents that the VM is being
+	// migrated to a datastore compatible with the storage policy of the VM's
+	// new StorageClass.
+	VirtualMachineStorageClassMigratingReason = "StorageClassMigrating"
+
+	// VirtualMachineStorageClassMigrationFailedReason documents that the VM
+	// could not be migrated to a datastore compatible with the storage policy
+	// of the VM's new StorageClass. The migration is retried.
+	VirtualMachineStorageClassMigrationFailedReason = "StorageClassMigrationFailed"
+)
+
 const (
 	// GuestBootstrapCondition exposes the status of guest bootstrap from within
 	// the guest OS, when available.
@@ -90,6 +108,26 @@ const (
 	GuestCustomizationFailedReason = "GuestCustomizationFailed"
 )
 
+const (
+	// GuestShutdownCondition exposes the result of the last attempt to
+	// gracefully shut down the guest when the VM was powered off with the Soft
+	// or TrySoft power off mode.
+	GuestShutdownCondition = "GuestShutdown"
+
+	// GuestShutdownFailedReason documents that the guest could not be shut
+	// down, and the VM was not powered off.
+	GuestShutdownFailedReason = "GuestShutdownFailed"
+
+	// GuestShutdownHardPowerOffReason documents that the guest could not be
+	// shut down in time, and the VM was halted instead.
+	GuestShutdownHardPowerOffReason = "GuestShutdownHardPowerOff"
+
+	// GuestShutdownPreStopHookRunningReason documents that the guest was
+	// signaled with the pre-stop hook, and the VM is not powered off until
+	// the hook's timeout elapses or the guest powers itself off.
+	GuestShutdownPreStopHookRunningReason = "GuestShutdownPreStopHookRunning"
+)
+
 const (
 	// VirtualMachineToolsCondition exposes the status of VMware Tools running
 	// in the guest OS, when available.
@@ -149,6 +187,16 @@ const (
 	// VirtualMachineSameVMClassResizeAnnotation is an annotation that indicates the VM
 	// should be resized as the class it points to changes.
 	VirtualMachineSameVMClassResizeAnnotation = GroupName + "/same-vm-class-resize"
+
+	// GuestFileSystemResizeAnnotation is an annotation that indicates the
+	// guest should be notified when the file system on one of the VM's
+	// volumes needs to be grown after the volume has been expanded.
+	//
+	// When set to "true", the volumes whose status.volumes[].resizeStatus is
+	// FileSystemResizePending are published to the guest via the guestinfo
+	// key "guestinfo.vmservice.volumes.resize", which may be consumed by a
+	// cloud-init or other in-guest hook to grow the file systems.
+	GuestFileSystemResizeAnnotation = GroupName + "/guest-filesystem-resize"
 )
 
 const (
@@ -159,6 +207,29 @@ const (
 	ManagedByExtensionType = "VirtualMachine"
 )
 
+const (
+	// VirtualMachineConfigurationDriftCondition exposes whether the VM's
+	// configuration in vSphere has drifted from the configuration implied by
+	// the VM's spec and class.
+	VirtualMachineConfigurationDriftCondition = "ConfigurationDrift"
+
+	// VirtualMachineConfigurationDriftDetectedReason documents that drift was
+	// detected and is reported in the VM's status.
+	VirtualMachineConfigurationDriftDetectedReason = "DriftDetected"
+
+	// VirtualMachineConfigurationNoDriftReason documents that no drift was
+	// detected.
+	VirtualMachineConfigurationNoDriftReason = "NoDrift"
+
+	// VirtualMachineConfigurationDriftRevertedReason documents that the
+	// detected drift was reverted.
+	VirtualMachineConfigurationDriftRevertedReason = "DriftReverted"
+
+	// VirtualMachineConfigurationDriftAdoptedReason documents that the
+	// detected drift was adopted.
+	VirtualMachineConfigurationDriftAdoptedReason = "DriftAdopted"
+)
+
 const (
 	// VirtualMachineBackupUpToDateCondition exposes the status of the latest VirtualMachine Backup, when available.
 	VirtualMachineBackupUpToDateCondition = "VirtualMachineBackupUpToDate"
@@ -286,13 +357,61 @@ const (
 type VirtualMachineImageRef struct {
 	// Kind describes the type of image, either a namespace-scoped
 	// VirtualMachineImage or cluster-scoped ClusterVirtualMachineImage.
+	//
+	// The kind may also be VirtualMachine, in which case the VM is cloned from
+	// another VirtualMachine in the same namespace.
 	Kind string `json:"kind"`
 
 	// Name refers to the name of a VirtualMachineImage resource in the same
 	// namespace as this VM or a cluster-scoped ClusterVirtualMachineImage.
+	//
+	// If the kind is VirtualMachine, the name refers to a VirtualMachine in
+	// the same namespace as this VM.
 	Name string `json:"name"`
 }
 
+// +kubebuilder:validation:Enum=Full;Linked
+
+// VirtualMachineCloneMode describes how a VM's disks are cloned from the
+// source VM.
+type VirtualMachineCloneMode string
+
+const (
+	// VirtualMachineCloneModeFull indicates the clone receives a full,
+	// independent copy of the source VM's disks.
+	VirtualMachineCloneModeFull VirtualMachineCloneMode = "Full"
+
+	// VirtualMachineCloneModeLinked indicates the clone's disks are delta
+	// disks backed by the source VM's current snapshot. The source VM must
+	// have a current snapshot.
+	VirtualMachineCloneModeLinked VirtualMachineCloneMode = "Linked"
+)
+
+// VirtualMachineCloneSpec describes how a VM is cloned from another VM.
+type VirtualMachineCloneSpec struct {
+	// +optional
+	// +kubebuilder:default=Full
+
+	// Mode describes how the source VM's disks are cloned.
+	//
+	// Defaults to Full.
+	Mode VirtualMachineCloneMode `json:"mode,omitempty"`
+
+	// +optional
+
+	// CloneVolumes describes whether the data in the source VM's
+	// PersistentVolumeClaim volumes is cloned as well.
+	//
+	// When true, a new PersistentVolumeClaim is created for each of the source
+	// VM's PersistentVolumeClaim volumes, using the source claim as its data
+	// source, and is added to this VM's list of volumes. Instance storage
+	// volumes are never cloned.
+	//
+	// When false, the source VM's PersistentVolumeClaim volumes are not
+	// included in the clone.
+	CloneVolumes bool `json:"cloneVolumes,omitempty"`
+}
+
 // VirtualMachineCdromSpec describes the desired state of a CD-ROM device.
 type VirtualMachineCdromSpec struct {
 	// +kubebuilder:validation:Pattern="^[a-z0-9]{2,}$"
@@ -303,15 +422,42 @@ type VirtualMachineCdromSpec struct {
 	// This field is immutable when the VM is powered on.
 	Name string `json:"name"`
 
+	// +optional
+
 	// Image describes the reference to an ISO type VirtualMachineImage or
 	// ClusterVirtualMachineImage resource used as the backing for the CD-ROM.
 	// If the image kind is omitted, it defaults to VirtualMachineImage.
 	//
+	// Exactly one of image, persistentVolumeClaim, or configDrive must be
+	// specified.
+	//
 	// This field is immutable when the VM is powered on.
 	//
 	// Please note, unlike the spec.imageName field, the value of this
 	// spec.cdrom.image.name MUST be a Kubernetes object name.
-	Image VirtualMachineImageRef `json:"image"`
+	Image *VirtualMachineImageRef `json:"image,omitempty"`
+
+	// +optional
+
+	// PersistentVolumeClaim describes a PersistentVolumeClaim whose volume
+	// contains an ISO image used as the backing for the CD-ROM.
+	//
+	// Exactly one of image, persistentVolumeClaim, or configDrive must be
+	// specified.
+	//
+	// This field is immutable when the VM is powered on.
+	PersistentVolumeClaim *VirtualMachineCdromPersistentVolumeClaimSource `json:"persistentVolumeClaim,omitempty"`
+
+	// +optional
+
+	// ConfigDrive describes the ConfigMap and Secret resources from which an
+	// ISO image is generated and used as the backing for the CD-ROM.
+	//
+	// Exactly one of image, persistentVolumeClaim, or configDrive must be
+	// specified.
+	//
+	// This field is immutable when the VM is powered on.
+	ConfigDrive *VirtualMachineCdromConfigDriveSource `json:"configDrive,omitempty"`
 
 	// +optional
 	// +kubebuilder:default=true
@@ -342,6 +488,75 @@ type VirtualMachineCdromSpec struct {
 	AllowGuestControl *bool `json:"allowGuestControl,omitempty"`
 }
 
+// VirtualMachineCdromPersistentVolumeClaimSource describes a
+// PersistentVolumeClaim used as the backing for a CD-ROM.
+type VirtualMachineCdromPersistentVolumeClaimSource struct {
+	// ClaimName is the name of a PersistentVolumeClaim in the same namespace
+	// as the VM.
+	//
+	// The claim must use the Block volume mode, and the volume must contain an
+	// ISO image written to it as a raw block device.
+	//
+	// The CD-ROM is backed by the flat extent of the volume's disk, so the
+	// volume must reside on a datastore that stores disks as files, such as
+	// VMFS or NFS. Volumes on vSAN or vVols datastores are not supported.
+	//
+	// The claim may not also be specified in spec.volumes.
+	ClaimName string `json:"claimName"`
+}
+
+// VirtualMachineCdromConfigDriveSource describes the resources from which the
+// ISO image used as the backing for a CD-ROM is generated.
+type VirtualMachineCdromConfigDriveSource struct {
+	// +optional
+	// +kubebuilder:default=config-2
+	// +kubebuilder:validation:MaxLength=16
+
+	// VolumeLabel describes the label of the generated ISO image's volume.
+	// Guest tools use the label to discover the config drive, ex.
+	// cloud-init's NoCloud data source uses the label "cidata".
+	//
+	// Defaults to "config-2", the label of an OpenStack config drive.
+	VolumeLabel string `json:"volumeLabel,omitempty"`
+
+	// +kubebuilder:validation:MinItems=1
+
+	// Sources describe the ConfigMap and Secret resources whose data are
+	// written as files to the generated ISO image.
+	//
+	// Like a projected volume, each key is written to a file at the root of
+	// the image unless the items field maps the key to another path. A path
+	// may contain directories, ex. openstack/latest/meta_data.json. The mode
+	// of an item is ignored as the image is read-only.
+	//
+	// The image is generated each time the VM is powered on, so changes to
+	// the data of the resources are applied the next time the VM is powered
+	// on.
+	//
+	// The image is stored unencrypted in the VM's directory on its datastore,
+	// and is deleted when the CD-ROM is removed or the VM is deleted. Only the
+	// keys of a Secret that are listed in its items are written to the image.
+	Sources []VirtualMachineCdromConfigDriveProjection `json:"sources"`
+}
+
+// VirtualMachineCdromConfigDriveProjection describes a ConfigMap or Secret
+// resource whose data are written to a config drive. Exactly one of configMap
+// or secret must be specified.
+type VirtualMachineCdromConfigDriveProjection struct {
+	// +optional
+
+	// ConfigMap describes a ConfigMap in the same namespace as the VM.
+	ConfigMap *corev1.ConfigMapProjection `json:"configMap,omitempty"`
+
+	// +optional
+
+	// Secret describes a Secret in the same namespace as the VM.
+	//
+	// The items field is required, so the keys of the Secret that are written
+	// to the config drive must be listed explicitly.
+	Secret *corev1.SecretProjection `json:"secret,omitempty"`
+}
+
 // VirtualMachineCryptoSpec defines the desired state of a VirtualMachine's
 // encryption state.
 type VirtualMachineCryptoSpec struct {
@@ -446,6 +661,20 @@ type VirtualMachineSpec struct {
 
 	// +optional
 
+	// Clone describes how this VM is cloned from the VirtualMachine referenced
+	// by spec.image. This field may only be set when spec.image.kind is
+	// VirtualMachine.
+	//
+	// When a VM is cloned, its virtual hardware, other than its network
+	// interfaces, is copied from the source VM. The clone has a new BIOS UUID,
+	// instance UUID, and network identity, and the bootstrap provider is run
+	// again with a new instance ID.
+	//
+	// This field is immutable.
+	Clone *VirtualMachineCloneSpec `json:"clone,omitempty"`
+
+	// +optional
+
 	// ImageName describes the name of the image resource used to deploy this
 	// VM.
 	//
@@ -543,11 +772,31 @@ type VirtualMachineSpec struct {
 	// requires the VM's guest to have VM Tools installed and attempts to
 	// gracefully shutdown the VM. Its variant, TrySoft, first attempts
 	// a graceful shutdown, and if that fails or the VM is not in a powered off
-	// state after five minutes, the VM is halted.
+	// state after PowerOffTimeoutSeconds, the VM is halted.
 	//
 	// If omitted, the mode defaults to TrySoft.
 	PowerOffMode VirtualMachinePowerOpMode `json:"powerOffMode,omitempty"`
 
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=3600
+
+	// PowerOffTimeoutSeconds is the amount of time, in seconds, to wait for
+	// the guest to shut down when the VM is powered off with the Soft or
+	// TrySoft power off mode. If the guest is not shut down in time, a Soft
+	// power off fails, and a TrySoft power off falls back to halting the VM.
+	//
+	// If omitted, the timeout defaults to 300 seconds.
+	PowerOffTimeoutSeconds *int32 `json:"powerOffTimeoutSeconds,omitempty"`
+
+	// +optional
+
+	// PreStop describes a hook used to signal the guest before the VM is
+	// powered off with the Soft or TrySoft power off mode, giving applications
+	// such as databases time to flush their data before the guest is shut
+	// down.
+	PreStop *VirtualMachinePreStopHookSpec `json:"preStop,omitempty"`
+
 	// +optional
 	// +kubebuilder:default=TrySoft
 
@@ -596,6 +845,18 @@ type VirtualMachineSpec struct {
 	// If omitted, the mode defaults to TrySoft.
 	RestartMode VirtualMachinePowerOpMode `json:"restartMode,omitempty"`
 
+	// +optional
+
+	// PowerSchedule may be used to change the VM's power state on a recurring
+	// schedule, ex. to power off the VM every evening and power it back on
+	// every morning.
+	//
+	// At each scheduled time, the VM controller sets spec.powerState to the
+	// scheduled power state. The VM is then powered on, powered off, or
+	// suspended in accordance with spec.powerOffMode and spec.suspendMode.
+	// The power state may still be changed manually between scheduled times.
+	PowerSchedule *VirtualMachinePowerScheduleSpec `json:"powerSchedule,omitempty"`
+
 	// +optional
 	// +listType=map
 	// +listMapKey=name
@@ -610,6 +871,13 @@ type VirtualMachineSpec struct {
 
 	// +optional
 
+	// LivenessProbe describes a probe used to determine if the VM is alive.
+	// A VM that fails its liveness probe is restarted in accordance with
+	// RestartMode.
+	LivenessProbe *VirtualMachineLivenessProbeSpec `json:"livenessProbe,omitempty"`
+
+	// +optional
+
 	// Advanced describes a set of optional, advanced VM configuration options.
 	Advanced *VirtualMachineAdvancedSpec `json:"advanced,omitempty"`
 
@@ -705,6 +973,43 @@ type VirtualMachineSpec struct {
 	//
 	// This field is required when the VM has any CD-ROM devices attached.
 	GuestID string `json:"guestID,omitempty"`
+
+	// +optional
+
+	// CurrentSnapshot describes the VirtualMachineSnapshot that should be the
+	// VM's current snapshot.
+	//
+	// Setting this field to a snapshot other than the VM's current snapshot
+	// reverts the VM to that snapshot. The VM is not reverted if the snapshot
+	// does not exist.
+	//
+	// When this field is set, taking a new snapshot of the VM updates this
+	// field to refer to the new snapshot, since it becomes the VM's current
+	// snapshot.
+	CurrentSnapshot *vmopv1common.LocalObjectRef `json:"currentSnapshot,omitempty"`
+
+	// +optional
+
+	// Placement describes the policy used to select the zone in which the VM
+	// is placed.
+	//
+	// When this field is omitted and the VM is owned by a
+	// VirtualMachineReplicaSet, the VM is placed so the replicas are spread
+	// across zones on a best effort basis.
+	//
+	// This field has no effect once the VM has been placed in a zone.
+	Placement *VirtualMachinePlacementSpec `json:"placement,omitempty"`
+
+	// +optional
+
+	// DriftPolicy describes how VM Operator handles changes made to the VM
+	// directly in vSphere that cause its configuration to differ from the
+	// configuration implied by the VM's spec and class, ex. the number of
+	// CPUs, the amount of memory, the network interfaces, the disks, the
+	// ExtraConfig, and the encryption key.
+	//
+	// Please note, drift is only detected when this field is set.
+	DriftPolicy VirtualMachineDriftPolicy `json:"driftPolicy,omitempty"`
 }
 
 // VirtualMachineReservedSpec describes a set of VM configuration options
@@ -749,6 +1054,243 @@ type VirtualMachineAdvancedSpec struct {
 	// for this VM, a feature utilized by external backup systems such as
 	// VMware Data Recovery.
 	ChangeBlockTracking *bool `json:"changeBlockTracking,omitempty"`
+
+	// +optional
+
+	// CPUHotAddEnabled is a flag that enables adding CPUs to this VM while it
+	// is powered on. When enabled, increasing the number of CPUs via the VM's
+	// class is applied without a power cycle.
+	//
+	// If omitted, the value from the VM class's ConfigSpec is used.
+	//
+	// Please note this setting may only be changed while the VM is powered
+	// off. Also, the guest must support hot-added CPUs.
+	CPUHotAddEnabled *bool `json:"cpuHotAddEnabled,omitempty"`
+
+	// +optional
+
+	// MemoryHotAddEnabled is a flag that enables adding memory to this VM
+	// while it is powered on. When enabled, increasing the VM's memory via the
+	// VM's class is applied without a power cycle.
+	//
+	// If omitted, the value from the VM class's ConfigSpec is used.
+	//
+	// Please note this setting may only be changed while the VM is powered
+	// off. Also, the guest must support hot-added memory.
+	MemoryHotAddEnabled *bool `json:"memoryHotAddEnabled,omitempty"`
+
+	// +optional
+
+	// SerialConsoleEnabled is a flag that adds a network-backed serial port to
+	// this VM, connected to the virtual serial port concentrator, so the VM's
+	// serial console may be accessed with a VirtualMachineWebConsoleRequest
+	// whose console type is Serial. When this flag is false or omitted, the
+	// serial port is removed from the VM.
+	//
+	// Please note this setting may only be changed while the VM is powered
+	// off, and may only be enabled when the virtual serial port concentrator
+	// is configured.
+	SerialConsoleEnabled *bool `json:"serialConsoleEnabled,omitempty"`
+}
+
+const (
+	// VirtualMachineResizePendingReasonHotAddDisabled indicates a change
+	// cannot be applied while the VM is powered on because hot-add is not
+	// enabled for the VM.
+	VirtualMachineResizePendingReasonHotAddDisabled = "HotAddDisabled"
+
+	// VirtualMachineResizePendingReasonDecrease indicates a change cannot be
+	// applied while the VM is powered on because it decreases a resource.
+	VirtualMachineResizePendingReasonDecrease = "Decrease"
+
+	// VirtualMachineResizePendingReasonPowerOffRequired indicates a change
+	// cannot be applied while the VM is powered on.
+	VirtualMachineResizePendingReasonPowerOffRequired = "PowerOffRequired"
+)
+
+// VirtualMachineResizePendingChange describes a change to the VM's
+// configuration that cannot be applied while the VM is powered on.
+type VirtualMachineResizePendingChange struct {
+	// Field is the name of the VM's configuration field, ex. numCPUs.
+	Field string `json:"field"`
+
+	// +optional
+
+	// Current is the current value of the field.
+	Current string `json:"current,omitempty"`
+
+	// +optional
+
+	// Desired is the desired value of the field.
+	Desired string `json:"desired,omitempty"`
+
+	// Reason describes why the change cannot be applied while the VM is
+	// powered on.
+	Reason string `json:"reason"`
+}
+
+// VirtualMachineResizeStatus describes the observed state of resizing a VM
+// while it is powered on.
+type VirtualMachineResizeStatus struct {
+	// +optional
+	// +listType=map
+	// +listMapKey=field
+
+	// PendingChanges describes the changes that could not be applied while
+	// the VM is powered on. These changes are applied the next time the VM is
+	// powered off or powered on.
+	PendingChanges []VirtualMachineResizePendingChange `json:"pendingChanges,omitempty"`
+}
+
+// VirtualMachineDriftPolicy describes how VM Operator handles the drift
+// between the VM's configuration in vSphere and the configuration implied by
+// the VM's spec and class.
+//
+// +kubebuilder:validation:Enum=Report;Revert;Adopt
+type VirtualMachineDriftPolicy string
+
+const (
+	// VirtualMachineDriftPolicyReport indicates the drift is reported in the
+	// VM's status but is not otherwise acted upon.
+	VirtualMachineDriftPolicyReport VirtualMachineDriftPolicy = "Report"
+
+	// VirtualMachineDriftPolicyRevert indicates the drifted fields are
+	// reverted to the values implied by the VM's spec and class.
+	VirtualMachineDriftPolicyRevert VirtualMachineDriftPolicy = "Revert"
+
+	// VirtualMachineDriftPolicyAdopt indicates the current values of the
+	// drifted fields are adopted and are no longer reported as drift.
+	VirtualMachineDriftPolicyAdopt VirtualMachineDriftPolicy = "Adopt"
+)
+
+// VirtualMachineDriftedField describes a field of the VM's configuration in
+// vSphere whose value differs from the one implied by the VM's spec and
+// class.
+type VirtualMachineDriftedField struct {
+	// Field is the name of the field, ex. numCPUs or extraConfig[key].
+	Field string `json:"field"`
+
+	// +optional
+
+	// Current is the value of the field in vSphere.
+	Current string `json:"current,omitempty"`
+
+	// +optional
+
+	// Desired is the value of the field implied by the VM's spec and class.
+	Desired string `json:"desired,omitempty"`
+}
+
+// VirtualMachineDriftStatus describes the observed drift between the VM's
+// configuration in vSphere and the configuration implied by the VM's spec and
+// class.
+type VirtualMachineDriftStatus struct {
+	// +optional
+	// +listType=map
+	// +listMapKey=field
+
+	// Fields describes the fields that have drifted.
+	Fields []VirtualMachineDriftedField `json:"fields,omitempty"`
+
+	// +optional
+	// +listType=map
+	// +listMapKey=field
+
+	// Adopted describes the drifted fields whose current values were adopted
+	// because the VM's drift policy is Adopt. An adopted field is reported as
+	// drift again if either its current or desired value changes.
+	Adopted []VirtualMachineDriftedField `json:"adopted,omitempty"`
+}
+
+// VirtualMachinePreStopHookSpec describes a hook used to signal the guest
+// before the VM is powered off.
+type VirtualMachinePreStopHookSpec struct {
+	// +kubebuilder:validation:MinLength=1
+
+	// Command is the command the guest is asked to run before it is shut
+	// down.
+	//
+	// The guest is signaled by setting the guestinfo key
+	// guestinfo.vmservice.prestop.command to the command, and the key
+	// guestinfo.vmservice.prestop.time to the RFC3339 formatted time at which
+	// the guest was signaled. An agent in the guest is responsible for
+	// watching these keys, for example with
+	// "vmware-rpctool 'info-get guestinfo.vmservice.prestop.time'", and
+	// running the command when the time changes. The guest is signaled once
+	// per power off, and the keys are removed after the VM is powered off or
+	// the power off is cancelled.
+	Command string `json:"command"`
+
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=3600
+
+	// TimeoutSeconds is the amount of time, in seconds, the guest is given to
+	// run the command before it is shut down. If the guest powers itself off
+	// within this time, it is not shut down.
+	//
+	// If omitted, the timeout defaults to 60 seconds.
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+}
+
+// VirtualMachinePowerScheduleSpec describes a recurring schedule for changing
+// a VM's power state.
+//
+// Each schedule is a standard, five field cron expression, ex. "0 19 * * 1-5"
+// for 7pm every weekday. The fields are the minute, hour, day of the month,
+// month, and day of the week. The descriptors @yearly, @monthly, @weekly,
+// @daily, and @hourly are also supported.
+type VirtualMachinePowerScheduleSpec struct {
+	// +optional
+
+	// TimeZone is the name of the time zone from the IANA Time Zone database,
+	// ex. America/Los_Angeles, in which the schedules are evaluated.
+	//
+	// If omitted, the schedules are evaluated in UTC.
+	TimeZone string `json:"timeZone,omitempty"`
+
+	// +optional
+
+	// PowerOn is the cron expression for when to power on the VM.
+	PowerOn string `json:"powerOn,omitempty"`
+
+	// +optional
+
+	// PowerOff is the cron expression for when to power off the VM.
+	PowerOff string `json:"powerOff,omitempty"`
+
+	// +optional
+
+	// Suspend is the cron expression for when to suspend the VM.
+	Suspend string `json:"suspend,omitempty"`
+}
+
+// VirtualMachinePowerScheduleStatus describes the observed state of a VM's
+// power schedule.
+type VirtualMachinePowerScheduleStatus struct {
+	// +optional
+
+	// NextPowerState is the power state to which the VM is next scheduled to
+	// be changed.
+	NextPowerState VirtualMachinePowerState `json:"nextPowerState,omitempty"`
+
+	// +optional
+
+	// NextTime is the time at which the VM's power state is next scheduled to
+	// be changed.
+	NextTime *metav1.Time `json:"nextTime,omitempty"`
+
+	// +optional
+
+	// LastPowerState is the power state to which the VM was last changed by
+	// the schedule.
+	LastPowerState VirtualMachinePowerState `json:"lastPowerState,omitempty"`
+
+	// +optional
+
+	// LastTime is the time at which the VM's power state was last changed by
+	// the schedule.
+	LastTime *metav1.Time `json:"lastTime,omitempty"`
 }
 
 type VirtualMachineEncryptionType string
@@ -872,6 +1414,17 @@ type VirtualMachineStatus struct {
 
 	// +optional
 
+	// RestartCount describes the number of times the VM has been restarted
+	// because its liveness probe failed.
+	RestartCount int32 `json:"restartCount,omitempty"`
+
+	// +optional
+
+	// PowerSchedule describes the observed state of the VM's power schedule.
+	PowerSchedule *VirtualMachinePowerScheduleStatus `json:"powerSchedule,omitempty"`
+
+	// +optional
+
 	// HardwareVersion describes the VirtualMachine resource's observed
 	// hardware version.
 	//
@@ -883,6 +1436,31 @@ type VirtualMachineStatus struct {
 
 	// Storage describes the observed state of the VirtualMachine's storage.
 	Storage *VirtualMachineStorageStatus `json:"storage,omitempty"`
+
+	// +optional
+
+	// CurrentSnapshot describes the observed current snapshot of the VM.
+	CurrentSnapshot *vmopv1common.LocalObjectRef `json:"currentSnapshot,omitempty"`
+
+	// +optional
+
+	// RootSnapshots describes the snapshots at the root of the VM's snapshot
+	// tree. The children of each snapshot are described by the status of the
+	// corresponding VirtualMachineSnapshot resource.
+	RootSnapshots []vmopv1common.LocalObjectRef `json:"rootSnapshots,omitempty"`
+
+	// +optional
+
+	// Resize describes the observed state of resizing the VM while it is
+	// powered on, including any changes that require the VM to be powered
+	// off.
+	Resize *VirtualMachineResizeStatus `json:"resize,omitempty"`
+
+	// +optional
+
+	// Drift describes the observed drift between the VM's configuration in
+	// vSphere and the configuration implied by the VM's spec and class.
+	Drift *VirtualMachineDriftStatus `json:"drift,omitempty"`
 }
 
 // +kubebuilder:object:root=true
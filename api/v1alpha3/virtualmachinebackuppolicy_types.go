@@ -0,0 +1,113 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha3
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// VirtualMachineBackupPolicyResourceKind is the kind of a resource that may be
+// included in a VM's backup.
+//
+// +kubebuilder:validation:Enum=ConfigMap;NetworkPolicy;VirtualMachineService
+type VirtualMachineBackupPolicyResourceKind string
+
+const (
+	// VirtualMachineBackupPolicyResourceKindConfigMap includes ConfigMap
+	// resources in the backup.
+	VirtualMachineBackupPolicyResourceKindConfigMap VirtualMachineBackupPolicyResourceKind = "ConfigMap"
+
+	// VirtualMachineBackupPolicyResourceKindNetworkPolicy includes
+	// NetworkPolicy resources in the backup.
+	VirtualMachineBackupPolicyResourceKindNetworkPolicy VirtualMachineBackupPolicyResourceKind = "NetworkPolicy"
+
+	// VirtualMachineBackupPolicyResourceKindVirtualMachineService includes
+	// VirtualMachineService resources in the backup.
+	VirtualMachineBackupPolicyResourceKindVirtualMachineService VirtualMachineBackupPolicyResourceKind = "VirtualMachineService"
+)
+
+// VirtualMachineBackupPolicyResource describes the resources of a kind that
+// are included in a VM's backup.
+type VirtualMachineBackupPolicyResource struct {
+	// Kind describes the kind of the resources.
+	Kind VirtualMachineBackupPolicyResourceKind `json:"kind"`
+
+	// +optional
+
+	// Selector selects the resources, in the same namespace as the VM, by
+	// their labels.
+	//
+	// When omitted, the VirtualMachineServices whose spec.selector matches
+	// the VM's labels, or the NetworkPolicies whose spec.podSelector matches
+	// the VM's labels, are selected. This field is required for ConfigMaps.
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+}
+
+// VirtualMachineBackupPolicySpec defines the desired state of a
+// VirtualMachineBackupPolicy.
+type VirtualMachineBackupPolicySpec struct {
+	// +optional
+
+	// VMSelector selects the VMs, in the same namespace as the policy, to
+	// which the policy applies. When omitted, the policy applies to all of
+	// the VMs in the namespace.
+	VMSelector *metav1.LabelSelector `json:"vmSelector,omitempty"`
+
+	// +optional
+	// +listType=atomic
+
+	// Resources describes the resources, in addition to the ones referenced
+	// by the VM such as its bootstrap Secrets, that are included in the
+	// backup.
+	Resources []VirtualMachineBackupPolicyResource `json:"resources,omitempty"`
+
+	// +optional
+	// +listType=set
+
+	// ExcludedFieldPaths describes the fields that are removed from the VM and
+	// the additional resources before they are backed up.
+	//
+	// Each path is a JSON pointer as defined in RFC 6901, ex.
+	// /metadata/labels/example.com~1my-label. Paths that do not exist in a
+	// resource are ignored, and paths may not refer to the items of a list.
+	// The fields that are required to restore a resource, such as its name,
+	// may not be excluded.
+	ExcludedFieldPaths []string `json:"excludedFieldPaths,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Namespaced,shortName=vmbackuppolicy
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// VirtualMachineBackupPolicy is the schema for the virtualmachinebackuppolicies
+// API and describes the scope of the backup of the VMs in a namespace.
+//
+// When more than one policy applies to a VM, the union of their resources and
+// excluded field paths is used.
+type VirtualMachineBackupPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec VirtualMachineBackupPolicySpec `json:"spec,omitempty"`
+}
+
+func (p *VirtualMachineBackupPolicy) NamespacedName() string {
+	return p.Namespace + "/" + p.Name
+}
+
+// +kubebuilder:object:root=true
+
+// VirtualMachineBackupPolicyList contains a list of
+// VirtualMachineBackupPolicy.
+type VirtualMachineBackupPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VirtualMachineBackupPolicy `json:"items"`
+}
+
+func init() {
+	objectTypes = append(objectTypes, &VirtualMachineBackupPolicy{}, &VirtualMachineBackupPolicyList{})
+}
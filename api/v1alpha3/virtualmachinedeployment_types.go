@@ -0,0 +1,292 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha3
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// VirtualMachineDeploymentStrategyType defines the type of strategy used to
+// replace the virtual machines of a VirtualMachineDeployment when its template
+// changes.
+// +kubebuilder:validation:Enum=RollingUpdate;Recreate
+type VirtualMachineDeploymentStrategyType string
+
+const (
+	// RollingUpdateVirtualMachineDeploymentStrategyType replaces the old
+	// VirtualMachineReplicaSet by the new one using a rolling update, i.e.
+	// gradually scales down the old VirtualMachineReplicaSets and scales up the
+	// new one.
+	RollingUpdateVirtualMachineDeploymentStrategyType VirtualMachineDeploymentStrategyType = "RollingUpdate"
+
+	// RecreateVirtualMachineDeploymentStrategyType deletes all the virtual
+	// machines of the old VirtualMachineReplicaSets before any virtual machines
+	// are created by the new one.
+	RecreateVirtualMachineDeploymentStrategyType VirtualMachineDeploymentStrategyType = "Recreate"
+)
+
+const (
+	// VirtualMachineDeploymentNameLabel is the key of the label applied to the
+	// VirtualMachineReplicaSets and VirtualMachines owned by a
+	// VirtualMachineDeployment. The value of this label is the name of the
+	// VirtualMachineDeployment.
+	VirtualMachineDeploymentNameLabel = "vmoperator.vmware.com/deployment-name"
+
+	// VirtualMachineDeploymentUniqueLabel is the key of the label applied to
+	// the VirtualMachineReplicaSets and VirtualMachines owned by a
+	// VirtualMachineDeployment. The value of this label is the hash of the
+	// template used to create the VirtualMachineReplicaSet, and is used to
+	// ensure VirtualMachineReplicaSets of different revisions do not select
+	// the same VirtualMachines.
+	VirtualMachineDeploymentUniqueLabel = "vmoperator.vmware.com/vm-template-hash"
+
+	// VirtualMachineDeploymentRevisionAnnotation is the annotation on a
+	// VirtualMachineDeployment and its VirtualMachineReplicaSets that records
+	// the revision of the template.
+	VirtualMachineDeploymentRevisionAnnotation = "vmoperator.vmware.com/deployment-revision"
+)
+
+const (
+	// VirtualMachineDeploymentAvailableCondition documents that the
+	// VirtualMachineDeployment has at least the minimum number of ready
+	// replicas required by its strategy.
+	VirtualMachineDeploymentAvailableCondition = "Available"
+
+	// VirtualMachineDeploymentWaitingForAvailableReplicasReason documents that
+	// the VirtualMachineDeployment does not yet have the minimum number of
+	// ready replicas.
+	VirtualMachineDeploymentWaitingForAvailableReplicasReason = "WaitingForAvailableReplicas"
+
+	// VirtualMachineDeploymentProgressingCondition documents whether the
+	// VirtualMachineDeployment has finished rolling out its current template.
+	VirtualMachineDeploymentProgressingCondition = "Progressing"
+
+	// VirtualMachineDeploymentRollingOutReason documents that the
+	// VirtualMachineDeployment is replacing the replicas of an old template.
+	VirtualMachineDeploymentRollingOutReason = "RollingOut"
+
+	// VirtualMachineDeploymentPausedReason documents that the rollout of the
+	// VirtualMachineDeployment is paused.
+	VirtualMachineDeploymentPausedReason = "Paused"
+
+	// VirtualMachineDeploymentRollbackFailedReason documents that the
+	// VirtualMachineDeployment could not be rolled back to the requested
+	// revision.
+	VirtualMachineDeploymentRollbackFailedReason = "RollbackFailed"
+)
+
+// VirtualMachineDeploymentStrategy describes how to replace the existing
+// virtual machines with new ones.
+type VirtualMachineDeploymentStrategy struct {
+	// +optional
+	// +kubebuilder:default=RollingUpdate
+	//
+	// Type of deployment. Allowed values are RollingUpdate and Recreate.
+	// Defaults to RollingUpdate.
+	Type VirtualMachineDeploymentStrategyType `json:"type,omitempty"`
+
+	// +optional
+	//
+	// RollingUpdate contains the rolling update config params. Present only if
+	// Type is RollingUpdate.
+	RollingUpdate *VirtualMachineRollingUpdateDeployment `json:"rollingUpdate,omitempty"`
+}
+
+// VirtualMachineRollingUpdateDeployment is used to control the desired
+// behavior of a rolling update.
+type VirtualMachineRollingUpdateDeployment struct {
+	// +optional
+	// +kubebuilder:validation:XIntOrString
+	//
+	// MaxUnavailable is the maximum number of virtual machines that can be
+	// unavailable during the update. The value can be an absolute number
+	// (ex: 5) or a percentage of the desired replicas (ex: 10%). The absolute
+	// number is calculated from the percentage by rounding down. This cannot
+	// be 0 if MaxSurge is 0. A virtual machine is available when it is ready.
+	// Defaults to 0.
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:XIntOrString
+	//
+	// MaxSurge is the maximum number of virtual machines that can be created
+	// over the desired number of replicas. The value can be an absolute number
+	// (ex: 5) or a percentage of the desired replicas (ex: 10%). The absolute
+	// number is calculated from the percentage by rounding up. This cannot be
+	// 0 if MaxUnavailable is 0. Defaults to 1.
+	MaxSurge *intstr.IntOrString `json:"maxSurge,omitempty"`
+}
+
+// VirtualMachineDeploymentRollback describes the revision to which a
+// VirtualMachineDeployment is rolled back.
+type VirtualMachineDeploymentRollback struct {
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	//
+	// Revision is the revision to roll back to. If set to 0, the
+	// VirtualMachineDeployment is rolled back to the previous revision.
+	Revision int64 `json:"revision,omitempty"`
+}
+
+// VirtualMachineDeploymentSpec is the specification of a
+// VirtualMachineDeployment.
+type VirtualMachineDeploymentSpec struct {
+	// +optional
+	// +kubebuilder:default=1
+	//
+	// Replicas is the number of desired replicas.
+	// This is a pointer to distinguish between explicit zero and unspecified.
+	// Defaults to 1.
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// Selector is a label query over the virtual machines that should match
+	// the replica count. It must match the VirtualMachine template's labels.
+	// More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/labels/#label-selectors
+	Selector *metav1.LabelSelector `json:"selector"`
+
+	// Template is the object that describes the virtual machines that will be
+	// created. Changing the template results in a new revision of the
+	// VirtualMachineDeployment being rolled out according to Strategy.
+	Template VirtualMachineTemplateSpec `json:"template"`
+
+	// +optional
+	//
+	// Strategy is the deployment strategy used to replace existing virtual
+	// machines with new ones.
+	Strategy *VirtualMachineDeploymentStrategy `json:"strategy,omitempty"`
+
+	// +optional
+	// +kubebuilder:default=1
+	// +kubebuilder:validation:Minimum=0
+	//
+	// RevisionHistoryLimit is the number of old VirtualMachineReplicaSets to
+	// retain to allow rollback. Defaults to 1.
+	RevisionHistoryLimit *int32 `json:"revisionHistoryLimit,omitempty"`
+
+	// +optional
+	//
+	// Paused indicates the rollout of the VirtualMachineDeployment is paused.
+	// Changes to the template are not rolled out while the
+	// VirtualMachineDeployment is paused, but changes to Replicas are still
+	// honored.
+	Paused bool `json:"paused,omitempty"`
+
+	// +optional
+	//
+	// RollbackTo is the config this VirtualMachineDeployment is rolling back
+	// to. The field is cleared once the rollback is complete.
+	RollbackTo *VirtualMachineDeploymentRollback `json:"rollbackTo,omitempty"`
+}
+
+// VirtualMachineDeploymentStatus represents the observed state of a
+// VirtualMachineDeployment resource.
+type VirtualMachineDeploymentStatus struct {
+	// +optional
+	//
+	// ObservedGeneration reflects the generation of the most recently observed
+	// VirtualMachineDeployment.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// +optional
+	//
+	// Selector is the same as the label selector but in the string format to
+	// avoid introspection by clients. The string will be in the same format as
+	// the query-param syntax.
+	// More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/labels/#label-selectors
+	Selector string `json:"selector,omitempty"`
+
+	// +optional
+	//
+	// Replicas is the total number of non-terminated virtual machines targeted
+	// by this VirtualMachineDeployment.
+	Replicas int32 `json:"replicas"`
+
+	// +optional
+	//
+	// UpdatedReplicas is the total number of non-terminated virtual machines
+	// targeted by this VirtualMachineDeployment that have the desired template
+	// spec.
+	UpdatedReplicas int32 `json:"updatedReplicas,omitempty"`
+
+	// +optional
+	//
+	// ReadyReplicas is the total number of ready virtual machines targeted by
+	// this VirtualMachineDeployment.
+	ReadyReplicas int32 `json:"readyReplicas,omitempty"`
+
+	// +optional
+	//
+	// UnavailableReplicas is the total number of unavailable virtual machines
+	// targeted by this VirtualMachineDeployment. This is the total number of
+	// virtual machines that are still required for the
+	// VirtualMachineDeployment to have 100% available capacity. They may
+	// either be virtual machines that are not ready, or virtual machines that
+	// have not yet been created.
+	UnavailableReplicas int32 `json:"unavailableReplicas,omitempty"`
+
+	// +optional
+	//
+	// Revision is the revision of the VirtualMachineDeployment's current
+	// template.
+	Revision int64 `json:"revision,omitempty"`
+
+	// +optional
+	//
+	// CollisionCount is the count of hash collisions for the
+	// VirtualMachineDeployment. The controller uses this field as a collision
+	// avoidance mechanism when it needs to create the name for the newest
+	// VirtualMachineReplicaSet.
+	CollisionCount *int32 `json:"collisionCount,omitempty"`
+
+	// +optional
+	//
+	// Conditions represents the latest available observations of a
+	// VirtualMachineDeployment's current state.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+func (d *VirtualMachineDeployment) GetConditions() []metav1.Condition {
+	return d.Status.Conditions
+}
+
+func (d *VirtualMachineDeployment) SetConditions(conditions []metav1.Condition) {
+	d.Status.Conditions = conditions
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Namespaced,shortName=vmd;vmdeployment
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:subresource:scale:specpath=.spec.replicas,statuspath=.status.replicas,selectorpath=.status.selector
+// +kubebuilder:printcolumn:name="Replicas",type="integer",JSONPath=".status.replicas",description="Total number of non-terminated virtual machines targeted by this VirtualMachineDeployment"
+// +kubebuilder:printcolumn:name="Ready",type="integer",JSONPath=".status.readyReplicas",description="Total number of ready virtual machines targeted by this VirtualMachineDeployment"
+// +kubebuilder:printcolumn:name="Updated",type="integer",JSONPath=".status.updatedReplicas",description="Total number of non-terminated virtual machines targeted by this VirtualMachineDeployment that have the desired template spec"
+// +kubebuilder:printcolumn:name="Unavailable",type="integer",JSONPath=".status.unavailableReplicas",description="Total number of unavailable virtual machines targeted by this VirtualMachineDeployment"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="Time duration since creation of VirtualMachineDeployment"
+
+// VirtualMachineDeployment is the schema for the virtualmachinedeployments API
+// and manages the revisions of a set of replica virtual machines by way of
+// VirtualMachineReplicaSets.
+type VirtualMachineDeployment struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VirtualMachineDeploymentSpec   `json:"spec,omitempty"`
+	Status VirtualMachineDeploymentStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// VirtualMachineDeploymentList contains a list of VirtualMachineDeployment.
+type VirtualMachineDeploymentList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VirtualMachineDeployment `json:"items"`
+}
+
+func init() {
+	objectTypes = append(objectTypes, &VirtualMachineDeployment{}, &VirtualMachineDeploymentList{})
+}
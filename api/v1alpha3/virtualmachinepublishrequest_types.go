@@ -47,6 +47,19 @@ const (
 	VirtualMachinePublishRequestConditionComplete = "Complete"
 )
 
+const (
+	// VirtualMachinePublishRequestTargetLocationKindOCIRegistry is the kind
+	// of a publication request's target location when the VM is published
+	// as an OCI artifact to the registry described by
+	// spec.target.location.ociRegistry.
+	VirtualMachinePublishRequestTargetLocationKindOCIRegistry = "OCIRegistry"
+
+	// VirtualMachinePublishRequestUIDAnnotation is the annotation on the
+	// manifest of an OCI artifact published by a VirtualMachinePublishRequest
+	// that contains the UID of the request.
+	VirtualMachinePublishRequestUIDAnnotation = "virtualmachinepublishrequest.vmoperator.vmware.com/uid"
+)
+
 // Condition.Reason for Conditions related to VirtualMachinePublishRequest.
 const (
 	// SourceVirtualMachineNotExistReason documents that the source VM of
@@ -69,6 +82,15 @@ const (
 	// library of the VirtualMachinePublishRequest isn't ready.
 	TargetContentLibraryNotReadyReason = "TargetContentLibraryNotReady"
 
+	// TargetOCIRegistrySecretNotExistReason documents that the Secret with the
+	// credentials for the target OCI registry of the
+	// VirtualMachinePublishRequest doesn't exist.
+	TargetOCIRegistrySecretNotExistReason = "TargetOCIRegistrySecretNotExist"
+
+	// TargetOCIRegistryNotReadyReason documents that the target OCI registry
+	// of the VirtualMachinePublishRequest cannot be accessed.
+	TargetOCIRegistryNotReadyReason = "TargetOCIRegistryNotReady"
+
 	// TargetItemAlreadyExistsReason documents that an item with the same name
 	// as the VirtualMachinePublishRequest's target item name exists in
 	// the target content library.
@@ -79,6 +101,11 @@ const (
 	// target item is not found in the namespace.
 	TargetVirtualMachineImageNotFoundReason = "VirtualMachineImageNotFound"
 
+	// TargetOCIArtifactNotFoundReason documents that the OCI artifact
+	// published by the VirtualMachinePublishRequest is not found in the
+	// target OCI registry.
+	TargetOCIArtifactNotFoundReason = "OCIArtifactNotFound"
+
 	// UploadTaskNotStartedReason documents that the VM publish task hasn't started.
 	UploadTaskNotStartedReason = "NotStarted"
 
@@ -145,6 +172,9 @@ type VirtualMachinePublishRequestTargetItem struct {
 	// show up in vCenter Content Library, not the custom resource name
 	// in the namespace.
 	//
+	// If the spec.target.location.kind equals OCIRegistry, then this is the
+	// tag of the published OCI artifact.
+	//
 	// If omitted then the controller will use spec.source.name + "-image".
 	Name string `json:"name,omitempty"`
 
@@ -154,6 +184,31 @@ type VirtualMachinePublishRequestTargetItem struct {
 	Description string `json:"description,omitempty"`
 }
 
+// VirtualMachinePublishRequestTargetOCIRegistry describes the OCI registry to
+// which a VM is published as an OCI artifact.
+type VirtualMachinePublishRequestTargetOCIRegistry struct {
+	// Repository is the repository to which the VM is pushed, including the
+	// registry host, ex. registry.example.com/golden-images/ubuntu.
+	Repository string `json:"repository"`
+
+	// +optional
+
+	// SecretName is the name of the Secret in the same namespace as the
+	// VirtualMachinePublishRequest that contains the credentials used to
+	// push to the registry.
+	//
+	// The Secret must be of type kubernetes.io/dockerconfigjson or
+	// kubernetes.io/basic-auth. If omitted, the registry is accessed
+	// anonymously.
+	SecretName string `json:"secretName,omitempty"`
+
+	// +optional
+
+	// Insecure indicates the registry is accessed over plain HTTP instead of
+	// HTTPS.
+	Insecure bool `json:"insecure,omitempty"`
+}
+
 // VirtualMachinePublishRequestTargetLocation is the location part of a
 // publication request's target.
 type VirtualMachinePublishRequestTargetLocation struct {
@@ -168,6 +223,8 @@ type VirtualMachinePublishRequestTargetLocation struct {
 	// equal to spec.target.location.apiVersion, a kind equal to
 	// spec.target.location.kind, and has the label
 	// "imageregistry.vmware.com/default".
+	//
+	// This field is ignored when spec.target.location.kind is OCIRegistry.
 	Name string `json:"name,omitempty"`
 
 	// +optional
@@ -180,7 +237,20 @@ type VirtualMachinePublishRequestTargetLocation struct {
 	// +kubebuilder:default=ContentLibrary
 
 	// Kind is the kind of referenced object.
+	//
+	// When the kind is OCIRegistry, the VM is exported as an OVF and pushed
+	// as an OCI artifact to the registry described by
+	// spec.target.location.ociRegistry, and the API version must be a
+	// version of the vmoperator.vmware.com group, ex.
+	// vmoperator.vmware.com/v1alpha3.
 	Kind string `json:"kind,omitempty"`
+
+	// +optional
+
+	// OCIRegistry describes the OCI registry to which the VM is published.
+	//
+	// This field is required when spec.target.location.kind is OCIRegistry.
+	OCIRegistry *VirtualMachinePublishRequestTargetOCIRegistry `json:"ociRegistry,omitempty"`
 }
 
 // VirtualMachinePublishRequestTarget is the target of a publication request,
@@ -311,6 +381,14 @@ type VirtualMachinePublishRequestStatus struct {
 
 	// +optional
 
+	// ImageDigest is the digest of the manifest of the OCI artifact that is
+	// pushed when the target location is an OCI registry.
+	//
+	// This field will not be set until the OCI artifact is uploaded.
+	ImageDigest string `json:"imageDigest,omitempty"`
+
+	// +optional
+
 	// Ready is set to true only when the VM has been published successfully
 	// and the new VirtualMachineImage resource is ready.
 	//
@@ -48,6 +48,30 @@ const (
 	VirtualMachineReplicaSetNameLabel = "vmoperator.vmware.com/replicaset-name"
 )
 
+const (
+	// RandomVirtualMachineReplicaSetDeletePolicy prioritizes VirtualMachines
+	// that are being deleted, and otherwise deletes VirtualMachines in an
+	// arbitrary, but stable, order.
+	RandomVirtualMachineReplicaSetDeletePolicy = "Random"
+
+	// OldestVirtualMachineReplicaSetDeletePolicy prioritizes the deletion of
+	// the oldest VirtualMachines.
+	OldestVirtualMachineReplicaSetDeletePolicy = "Oldest"
+
+	// NewestVirtualMachineReplicaSetDeletePolicy prioritizes the deletion of
+	// the newest VirtualMachines.
+	NewestVirtualMachineReplicaSetDeletePolicy = "Newest"
+
+	// NotReadyFirstVirtualMachineReplicaSetDeletePolicy prioritizes the
+	// deletion of VirtualMachines that are not ready.
+	NotReadyFirstVirtualMachineReplicaSetDeletePolicy = "NotReadyFirst"
+
+	// ZoneBalancedVirtualMachineReplicaSetDeletePolicy deletes VirtualMachines
+	// from the zones with the most replicas so the remaining replicas stay
+	// evenly spread across zones.
+	ZoneBalancedVirtualMachineReplicaSetDeletePolicy = "ZoneBalanced"
+)
+
 // VirtualMachineTemplateSpec describes the data needed to create a VirtualMachine
 // from a template.
 type VirtualMachineTemplateSpec struct {
@@ -74,10 +98,17 @@ type VirtualMachineReplicaSetSpec struct {
 	Replicas *int32 `json:"replicas,omitempty"`
 
 	// +optional
-	// +kubebuilder:validation:Enum=Random
+	// +kubebuilder:validation:Enum=Random;Oldest;Newest;NotReadyFirst;ZoneBalanced
 	//
 	// DeletePolicy defines the policy used to identify nodes to delete when downscaling.
-	// Only supported deletion policy is "Random".
+	// Valid values are "Random", "Oldest", "Newest", "NotReadyFirst", and
+	// "ZoneBalanced". Defaults to "Random".
+	//
+	// VirtualMachines that are already being deleted are always selected first.
+	// The "ZoneBalanced" policy removes VirtualMachines from the zones, as
+	// reported by status.zone, with the most replicas so the remaining replicas
+	// stay evenly spread. Within a zone, VirtualMachines that are not ready are
+	// preferred.
 	DeletePolicy string `json:"deletePolicy,omitempty"`
 
 	// +optional
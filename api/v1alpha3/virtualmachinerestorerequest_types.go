@@ -0,0 +1,194 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha3
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// VirtualMachineRestoreRequestCompleteCondition exposes whether the
+	// restore request has been processed. When the request is a dry run, the
+	// condition is true once the resources that would be restored are
+	// reported in the status.
+	VirtualMachineRestoreRequestCompleteCondition = "VirtualMachineRestoreRequestComplete"
+
+	// VirtualMachineRestoreRequestBackupNotFoundReason documents that the
+	// source vSphere VM does not exist or does not have any backup data.
+	VirtualMachineRestoreRequestBackupNotFoundReason = "BackupNotFound"
+
+	// VirtualMachineRestoreRequestConflictReason documents that one or more
+	// of the resources to restore already exist and the conflict policy is
+	// Fail.
+	VirtualMachineRestoreRequestConflictReason = "Conflict"
+
+	// VirtualMachineRestoreRequestFailedReason documents that the resources
+	// could not be restored due to an error.
+	VirtualMachineRestoreRequestFailedReason = "Failed"
+)
+
+// VirtualMachineRestoreRequestConflictPolicy describes how to handle a
+// resource to restore that already exists.
+//
+// +kubebuilder:validation:Enum=Fail;Skip
+type VirtualMachineRestoreRequestConflictPolicy string
+
+const (
+	// VirtualMachineRestoreRequestConflictPolicyFail fails the request, and
+	// no resources are restored, if any of the resources to restore already
+	// exist.
+	VirtualMachineRestoreRequestConflictPolicyFail VirtualMachineRestoreRequestConflictPolicy = "Fail"
+
+	// VirtualMachineRestoreRequestConflictPolicySkip skips the resources to
+	// restore that already exist, and restores the others.
+	VirtualMachineRestoreRequestConflictPolicySkip VirtualMachineRestoreRequestConflictPolicy = "Skip"
+)
+
+// VirtualMachineRestoreRequestResourceAction describes the action taken, or
+// that would be taken for a dry run, to restore a resource.
+type VirtualMachineRestoreRequestResourceAction string
+
+const (
+	// VirtualMachineRestoreRequestResourceActionCreate indicates the resource
+	// is created from the backup.
+	VirtualMachineRestoreRequestResourceActionCreate VirtualMachineRestoreRequestResourceAction = "Create"
+
+	// VirtualMachineRestoreRequestResourceActionSkip indicates the resource
+	// already exists and is not restored.
+	VirtualMachineRestoreRequestResourceActionSkip VirtualMachineRestoreRequestResourceAction = "Skip"
+
+	// VirtualMachineRestoreRequestResourceActionConflict indicates the
+	// resource already exists and caused the request to fail.
+	VirtualMachineRestoreRequestResourceActionConflict VirtualMachineRestoreRequestResourceAction = "Conflict"
+)
+
+// VirtualMachineRestoreRequestSource describes the vSphere VM that contains
+// the backup data.
+type VirtualMachineRestoreRequestSource struct {
+	// ManagedObjectID describes the vSphere managed object ID of the VM whose
+	// backup data is restored, ex. vm-42.
+	//
+	// The backup data is written into the VM's ExtraConfig while the VM is
+	// managed by VM Operator, and the backed up VirtualMachine must belong to
+	// the same namespace as the restore request.
+	ManagedObjectID string `json:"managedObjectID"`
+}
+
+// VirtualMachineRestoreRequestSpec defines the desired state of a
+// VirtualMachineRestoreRequest.
+type VirtualMachineRestoreRequestSpec struct {
+	// Source describes the vSphere VM that contains the backup data.
+	//
+	// Please note this field is immutable once the request is created.
+	Source VirtualMachineRestoreRequestSource `json:"source"`
+
+	// +optional
+	// +kubebuilder:default=Fail
+
+	// ConflictPolicy describes how to handle a resource to restore that
+	// already exists. Defaults to Fail.
+	//
+	// Please note this field is immutable once the request is created.
+	ConflictPolicy VirtualMachineRestoreRequestConflictPolicy `json:"conflictPolicy,omitempty"`
+
+	// +optional
+
+	// DryRun describes whether the request only reports the resources that
+	// would be restored, without restoring them.
+	//
+	// Please note this field is immutable once the request is created.
+	DryRun bool `json:"dryRun,omitempty"`
+}
+
+// VirtualMachineRestoreRequestResourceStatus describes a resource restored by
+// the request.
+type VirtualMachineRestoreRequestResourceStatus struct {
+	// APIVersion is the API version of the resource.
+	APIVersion string `json:"apiVersion"`
+
+	// Kind is the kind of the resource.
+	Kind string `json:"kind"`
+
+	// Name is the name of the resource.
+	Name string `json:"name"`
+
+	// Action describes the action taken, or that would be taken for a dry
+	// run, to restore the resource.
+	Action VirtualMachineRestoreRequestResourceAction `json:"action"`
+
+	// +optional
+
+	// Restored describes whether the resource has been created from the
+	// backup. It is always false for a dry run.
+	Restored bool `json:"restored,omitempty"`
+}
+
+// VirtualMachineRestoreRequestStatus defines the observed state of a
+// VirtualMachineRestoreRequest.
+type VirtualMachineRestoreRequestStatus struct {
+	// +optional
+	// +listType=atomic
+
+	// Resources describes the resources found in the backup data, in the
+	// order in which they are restored.
+	Resources []VirtualMachineRestoreRequestResourceStatus `json:"resources,omitempty"`
+
+	// +optional
+
+	// CompletionTime describes when the request was completed.
+	CompletionTime metav1.Time `json:"completionTime,omitempty"`
+
+	// +optional
+
+	// Conditions describes the observed conditions of the request.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+func (r *VirtualMachineRestoreRequest) GetConditions() []metav1.Condition {
+	return r.Status.Conditions
+}
+
+func (r *VirtualMachineRestoreRequest) SetConditions(conditions []metav1.Condition) {
+	r.Status.Conditions = conditions
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Namespaced,shortName=vmrestore
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Source",type="string",JSONPath=".spec.source.managedObjectID"
+// +kubebuilder:printcolumn:name="Dry-Run",type="boolean",JSONPath=".spec.dryRun"
+// +kubebuilder:printcolumn:name="Complete",type="string",JSONPath=".status.conditions[?(@.type=='VirtualMachineRestoreRequestComplete')].status"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// VirtualMachineRestoreRequest is the schema for the
+// virtualmachinerestorerequests API and represents a request to recreate a
+// VirtualMachine, and the PersistentVolumeClaims and bootstrap resources it
+// references, from the backup data of a vSphere VM.
+type VirtualMachineRestoreRequest struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VirtualMachineRestoreRequestSpec   `json:"spec,omitempty"`
+	Status VirtualMachineRestoreRequestStatus `json:"status,omitempty"`
+}
+
+func (r *VirtualMachineRestoreRequest) NamespacedName() string {
+	return r.Namespace + "/" + r.Name
+}
+
+// +kubebuilder:object:root=true
+
+// VirtualMachineRestoreRequestList contains a list of
+// VirtualMachineRestoreRequest.
+type VirtualMachineRestoreRequestList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VirtualMachineRestoreRequest `json:"items"`
+}
+
+func init() {
+	objectTypes = append(objectTypes, &VirtualMachineRestoreRequest{}, &VirtualMachineRestoreRequestList{})
+}
@@ -8,6 +8,16 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+const (
+	// VirtualMachineServicePortAnnotationPrefix is the prefix of the
+	// annotations on a VirtualMachine that name the ports open on the VM.
+	// For example, the annotation
+	// "port.vmservice.vmoperator.vmware.com/http: 8080" names port 8080 as
+	// "http", allowing a VirtualMachineServicePort to specify "http" as its
+	// TargetPortName.
+	VirtualMachineServicePortAnnotationPrefix = "port.vmservice.vmoperator.vmware.com/"
+)
+
 // VirtualMachineServiceType string describes ingress methods for a service.
 type VirtualMachineServiceType string
 
@@ -45,11 +55,78 @@ type VirtualMachineServicePort struct {
 	// Port describes the external port that will be exposed by the service.
 	Port int32 `json:"port"`
 
+	// +optional
+
 	// TargetPort describes the internal port open on a VirtualMachine that
 	// should be mapped to the external Port.
+	// Must be specified unless TargetPortName is specified.
 	TargetPort int32 `json:"targetPort"`
+
+	// +optional
+
+	// TargetPortName is the name of the internal port open on a
+	// VirtualMachine that should be mapped to the external Port. It must be an
+	// IANA_SVC_NAME and is resolved for each selected VirtualMachine by the
+	// VM's "port.vmservice.vmoperator.vmware.com/<name>" annotation. A VM that
+	// does not name the port is not included in the endpoints for this port.
+	// May not be specified with TargetPort.
+	TargetPortName string `json:"targetPortName,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=ClientIP;None
+
+// VirtualMachineServiceAffinity describes the session affinity of a
+// VirtualMachineService.
+type VirtualMachineServiceAffinity string
+
+const (
+	// VirtualMachineServiceAffinityClientIP means that connections from the
+	// same client IP are routed to the same VirtualMachine.
+	VirtualMachineServiceAffinityClientIP VirtualMachineServiceAffinity = "ClientIP"
+
+	// VirtualMachineServiceAffinityNone means that there is no session
+	// affinity.
+	VirtualMachineServiceAffinityNone VirtualMachineServiceAffinity = "None"
+)
+
+// ClientIPConfig describes the configuration of ClientIP based session
+// affinity.
+type ClientIPConfig struct {
+	// +optional
+	// +kubebuilder:validation:Minimum:=1
+	// +kubebuilder:validation:Maximum:=86400
+
+	// TimeoutSeconds specifies the seconds of ClientIP type session sticky
+	// time. The value must be greater than 0 and less than or equal to 86400
+	// (1 day). Defaults to 10800 (3 hours).
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+}
+
+// SessionAffinityConfig describes the configuration of session affinity.
+type SessionAffinityConfig struct {
+	// +optional
+
+	// ClientIP contains the configuration of ClientIP based session affinity.
+	ClientIP *ClientIPConfig `json:"clientIP,omitempty"`
 }
 
+// +kubebuilder:validation:Enum=Cluster;Local
+
+// VirtualMachineServiceExternalTrafficPolicy describes how nodes distribute
+// the external traffic they receive for a VirtualMachineService.
+type VirtualMachineServiceExternalTrafficPolicy string
+
+const (
+	// VirtualMachineServiceExternalTrafficPolicyCluster routes traffic to all
+	// of the VirtualMachines that back the service.
+	VirtualMachineServiceExternalTrafficPolicyCluster VirtualMachineServiceExternalTrafficPolicy = "Cluster"
+
+	// VirtualMachineServiceExternalTrafficPolicyLocal preserves the client
+	// source IP and only routes traffic to VirtualMachines local to the
+	// node or load balancer that received the traffic.
+	VirtualMachineServiceExternalTrafficPolicyLocal VirtualMachineServiceExternalTrafficPolicy = "Local"
+)
+
 // LoadBalancerStatus represents the status of a load balancer.
 type LoadBalancerStatus struct {
 	// +optional
@@ -138,6 +215,45 @@ type VirtualMachineServiceSpec struct {
 	// Must be a valid RFC-1123 hostname (https://tools.ietf.org/html/rfc1123)
 	// and requires Type to be ExternalName.
 	ExternalName string `json:"externalName,omitempty"`
+
+	// +optional
+
+	// SessionAffinity specifies whether connections from the same client
+	// are routed to the same VirtualMachine. Supports "ClientIP" and "None".
+	// Defaults to "None".
+	// Ignored if type is ExternalName.
+	SessionAffinity VirtualMachineServiceAffinity `json:"sessionAffinity,omitempty"`
+
+	// +optional
+
+	// SessionAffinityConfig contains the configuration of session affinity.
+	// Only applies when SessionAffinity is ClientIP.
+	SessionAffinityConfig *SessionAffinityConfig `json:"sessionAffinityConfig,omitempty"`
+
+	// +optional
+
+	// ExternalTrafficPolicy describes how the load balancer distributes the
+	// external traffic it receives for this service. Supports "Cluster" and
+	// "Local". Defaults to "Cluster".
+	// Only applies to VirtualMachineService Type: LoadBalancer.
+	// When not specified, the deprecated
+	// "virtualmachineservice.vmoperator.vmware.com/service.externalTrafficPolicy"
+	// annotation is used, if present.
+	ExternalTrafficPolicy VirtualMachineServiceExternalTrafficPolicy `json:"externalTrafficPolicy,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Minimum:=1
+	// +kubebuilder:validation:Maximum:=65535
+
+	// HealthCheckNodePort specifies the port used by the load balancer to
+	// check the health of the nodes that host the VirtualMachines backing
+	// this service.
+	// Only applies to VirtualMachineService Type: LoadBalancer with an
+	// ExternalTrafficPolicy of Local.
+	// When not specified, the deprecated
+	// "virtualmachineservice.vmoperator.vmware.com/service.healthCheckNodePort"
+	// annotation is used, if present.
+	HealthCheckNodePort int32 `json:"healthCheckNodePort,omitempty"`
 }
 
 // VirtualMachineServiceStatus defines the observed state of
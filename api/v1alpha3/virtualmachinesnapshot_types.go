@@ -0,0 +1,142 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha3
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	vmopv1common "github.com/vmware-tanzu/vm-operator/api/v1alpha3/common"
+)
+
+const (
+	// VirtualMachineSnapshotReadyCondition exposes whether the snapshot has
+	// been taken on the underlying infrastructure.
+	VirtualMachineSnapshotReadyCondition = "VirtualMachineSnapshotReady"
+
+	// VirtualMachineSnapshotCreatingReason documents that the snapshot is
+	// still being taken.
+	VirtualMachineSnapshotCreatingReason = "VirtualMachineSnapshotCreating"
+
+	// VirtualMachineSnapshotFailedReason documents that the snapshot could not
+	// be taken due to an error.
+	VirtualMachineSnapshotFailedReason = "VirtualMachineSnapshotFailed"
+
+	// VirtualMachineSnapshotVMNotFoundReason documents that the snapshot
+	// cannot be taken because the referenced VirtualMachine does not exist.
+	VirtualMachineSnapshotVMNotFoundReason = "VirtualMachineNotFound"
+)
+
+// VirtualMachineSnapshotSpec defines the desired state of a
+// VirtualMachineSnapshot.
+type VirtualMachineSnapshotSpec struct {
+	// VMRef describes the VirtualMachine, in the same namespace as the
+	// snapshot, from which the snapshot is taken.
+	//
+	// Please note this field is immutable once the snapshot is created.
+	VMRef *vmopv1common.LocalObjectRef `json:"vmRef,omitempty"`
+
+	// +optional
+
+	// Memory describes whether the VM's memory should be included in the
+	// snapshot. This field is ignored if the VM is not powered on.
+	//
+	// Reverting to a snapshot that includes memory returns the VM to the
+	// powered on state it was in when the snapshot was taken.
+	Memory bool `json:"memory,omitempty"`
+
+	// +optional
+
+	// Quiesce describes whether the guest file system should be quiesced
+	// before the snapshot is taken. Quiescing requires VMware Tools to be
+	// running in the guest and is ignored if the VM is not powered on.
+	Quiesce bool `json:"quiesce,omitempty"`
+
+	// +optional
+
+	// Description is a user-friendly description of the snapshot.
+	Description string `json:"description,omitempty"`
+}
+
+// VirtualMachineSnapshotStatus defines the observed state of a
+// VirtualMachineSnapshot.
+type VirtualMachineSnapshotStatus struct {
+	// +optional
+
+	// PowerState describes the power state of the VM when the snapshot was
+	// taken.
+	PowerState VirtualMachinePowerState `json:"powerState,omitempty"`
+
+	// +optional
+
+	// Quiesced describes whether the guest file system was quiesced when the
+	// snapshot was taken.
+	Quiesced bool `json:"quiesced,omitempty"`
+
+	// +optional
+
+	// UniqueID describes the unique identifier of the snapshot on the
+	// underlying infrastructure.
+	UniqueID string `json:"uniqueID,omitempty"`
+
+	// +optional
+
+	// CreationTime describes when the snapshot was taken on the underlying
+	// infrastructure.
+	CreationTime *metav1.Time `json:"creationTime,omitempty"`
+
+	// +optional
+
+	// Children describes the snapshots that were taken with this snapshot as
+	// their parent.
+	Children []vmopv1common.LocalObjectRef `json:"children,omitempty"`
+
+	// +optional
+
+	// Conditions describes the observed conditions of the snapshot.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+func (s *VirtualMachineSnapshot) GetConditions() []metav1.Condition {
+	return s.Status.Conditions
+}
+
+func (s *VirtualMachineSnapshot) SetConditions(conditions []metav1.Condition) {
+	s.Status.Conditions = conditions
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Namespaced,shortName=vmsnapshot
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="VM",type="string",JSONPath=".spec.vmRef.name"
+// +kubebuilder:printcolumn:name="Power-State",type="string",JSONPath=".status.powerState"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// VirtualMachineSnapshot is the schema for the virtualmachinesnapshots API
+// and represents a point-in-time snapshot of a VirtualMachine.
+type VirtualMachineSnapshot struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VirtualMachineSnapshotSpec   `json:"spec,omitempty"`
+	Status VirtualMachineSnapshotStatus `json:"status,omitempty"`
+}
+
+func (s *VirtualMachineSnapshot) NamespacedName() string {
+	return s.Namespace + "/" + s.Name
+}
+
+// +kubebuilder:object:root=true
+
+// VirtualMachineSnapshotList contains a list of VirtualMachineSnapshot.
+type VirtualMachineSnapshotList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VirtualMachineSnapshot `json:"items"`
+}
+
+func init() {
+	objectTypes = append(objectTypes, &VirtualMachineSnapshot{}, &VirtualMachineSnapshotList{})
+}
@@ -8,6 +8,21 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// VirtualMachineWebConsoleType describes the type of a VM's web console.
+//
+// +kubebuilder:validation:Enum=WebMKS;Serial
+type VirtualMachineWebConsoleType string
+
+const (
+	// VirtualMachineWebConsoleTypeWebMKS describes a web console that
+	// provides access to the VM's keyboard, video, and mouse.
+	VirtualMachineWebConsoleTypeWebMKS VirtualMachineWebConsoleType = "WebMKS"
+
+	// VirtualMachineWebConsoleTypeSerial describes a web console that provides
+	// access to the VM's serial port.
+	VirtualMachineWebConsoleTypeSerial VirtualMachineWebConsoleType = "Serial"
+)
+
 // VirtualMachineWebConsoleRequestSpec describes the desired state for a web
 // console request to a VM.
 type VirtualMachineWebConsoleRequestSpec struct {
@@ -16,6 +31,18 @@ type VirtualMachineWebConsoleRequestSpec struct {
 	Name string `json:"name"`
 	// PublicKey is used to encrypt the status.response. This is expected to be a RSA OAEP public key in X.509 PEM format.
 	PublicKey string `json:"publicKey"`
+
+	// +optional
+	// +kubebuilder:default=WebMKS
+
+	// ConsoleType describes the type of the web console.
+	//
+	// When set to Serial, status.response contains the encrypted URI and token
+	// used to connect to the VM's serial port via the proxy. Please note, the
+	// VM must have spec.advanced.serialConsoleEnabled set to true.
+	//
+	// Defaults to WebMKS.
+	ConsoleType VirtualMachineWebConsoleType `json:"consoleType,omitempty"`
 }
 
 // VirtualMachineWebConsoleRequestStatus describes the observed state of the
@@ -13,10 +13,32 @@ import (
 	"github.com/vmware-tanzu/vm-operator/api/v1alpha3/cloudinit"
 	"github.com/vmware-tanzu/vm-operator/api/v1alpha3/common"
 	"github.com/vmware-tanzu/vm-operator/api/v1alpha3/sysprep"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClientIPConfig) DeepCopyInto(out *ClientIPConfig) {
+	*out = *in
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClientIPConfig.
+func (in *ClientIPConfig) DeepCopy() *ClientIPConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ClientIPConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterVirtualMachineImage) DeepCopyInto(out *ClusterVirtualMachineImage) {
 	*out = *in
@@ -91,6 +113,22 @@ func (in *DynamicDirectPathIODevice) DeepCopy() *DynamicDirectPathIODevice {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EphemeralVolumeSource) DeepCopyInto(out *EphemeralVolumeSource) {
+	*out = *in
+	out.Size = in.Size.DeepCopy()
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EphemeralVolumeSource.
+func (in *EphemeralVolumeSource) DeepCopy() *EphemeralVolumeSource {
+	if in == nil {
+		return nil
+	}
+	out := new(EphemeralVolumeSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GuestHeartbeatAction) DeepCopyInto(out *GuestHeartbeatAction) {
 	*out = *in
@@ -121,6 +159,42 @@ func (in *GuestInfoAction) DeepCopy() *GuestInfoAction {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPGetAction) DeepCopyInto(out *HTTPGetAction) {
+	*out = *in
+	out.Port = in.Port
+	if in.HTTPHeaders != nil {
+		in, out := &in.HTTPHeaders, &out.HTTPHeaders
+		*out = make([]HTTPHeader, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPGetAction.
+func (in *HTTPGetAction) DeepCopy() *HTTPGetAction {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPGetAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPHeader) DeepCopyInto(out *HTTPHeader) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPHeader.
+func (in *HTTPHeader) DeepCopy() *HTTPHeader {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPHeader)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InstanceStorage) DeepCopyInto(out *InstanceStorage) {
 	*out = *in
@@ -315,6 +389,26 @@ func (in *ResourcePoolSpec) DeepCopy() *ResourcePoolSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SessionAffinityConfig) DeepCopyInto(out *SessionAffinityConfig) {
+	*out = *in
+	if in.ClientIP != nil {
+		in, out := &in.ClientIP, &out.ClientIP
+		*out = new(ClientIPConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SessionAffinityConfig.
+func (in *SessionAffinityConfig) DeepCopy() *SessionAffinityConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(SessionAffinityConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TCPSocketAction) DeepCopyInto(out *TCPSocketAction) {
 	*out = *in
@@ -426,6 +520,21 @@ func (in *VirtualMachineAdvancedSpec) DeepCopyInto(out *VirtualMachineAdvancedSp
 		*out = new(bool)
 		**out = **in
 	}
+	if in.CPUHotAddEnabled != nil {
+		in, out := &in.CPUHotAddEnabled, &out.CPUHotAddEnabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MemoryHotAddEnabled != nil {
+		in, out := &in.MemoryHotAddEnabled, &out.MemoryHotAddEnabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.SerialConsoleEnabled != nil {
+		in, out := &in.SerialConsoleEnabled, &out.SerialConsoleEnabled
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineAdvancedSpec.
@@ -438,6 +547,116 @@ func (in *VirtualMachineAdvancedSpec) DeepCopy() *VirtualMachineAdvancedSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineBackupPolicy) DeepCopyInto(out *VirtualMachineBackupPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineBackupPolicy.
+func (in *VirtualMachineBackupPolicy) DeepCopy() *VirtualMachineBackupPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineBackupPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VirtualMachineBackupPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineBackupPolicyList) DeepCopyInto(out *VirtualMachineBackupPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VirtualMachineBackupPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineBackupPolicyList.
+func (in *VirtualMachineBackupPolicyList) DeepCopy() *VirtualMachineBackupPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineBackupPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VirtualMachineBackupPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineBackupPolicyResource) DeepCopyInto(out *VirtualMachineBackupPolicyResource) {
+	*out = *in
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineBackupPolicyResource.
+func (in *VirtualMachineBackupPolicyResource) DeepCopy() *VirtualMachineBackupPolicyResource {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineBackupPolicyResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineBackupPolicySpec) DeepCopyInto(out *VirtualMachineBackupPolicySpec) {
+	*out = *in
+	if in.VMSelector != nil {
+		in, out := &in.VMSelector, &out.VMSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]VirtualMachineBackupPolicyResource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ExcludedFieldPaths != nil {
+		in, out := &in.ExcludedFieldPaths, &out.ExcludedFieldPaths
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineBackupPolicySpec.
+func (in *VirtualMachineBackupPolicySpec) DeepCopy() *VirtualMachineBackupPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineBackupPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VirtualMachineBootstrapCloudInitSpec) DeepCopyInto(out *VirtualMachineBootstrapCloudInitSpec) {
 	*out = *in
@@ -478,6 +697,137 @@ func (in *VirtualMachineBootstrapCloudInitSpec) DeepCopy() *VirtualMachineBootst
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineBootstrapIgnitionConfig) DeepCopyInto(out *VirtualMachineBootstrapIgnitionConfig) {
+	*out = *in
+	if in.Users != nil {
+		in, out := &in.Users, &out.Users
+		*out = make([]VirtualMachineBootstrapIgnitionUser, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Files != nil {
+		in, out := &in.Files, &out.Files
+		*out = make([]VirtualMachineBootstrapIgnitionFile, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SystemdUnits != nil {
+		in, out := &in.SystemdUnits, &out.SystemdUnits
+		*out = make([]VirtualMachineBootstrapIgnitionSystemdUnit, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineBootstrapIgnitionConfig.
+func (in *VirtualMachineBootstrapIgnitionConfig) DeepCopy() *VirtualMachineBootstrapIgnitionConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineBootstrapIgnitionConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineBootstrapIgnitionFile) DeepCopyInto(out *VirtualMachineBootstrapIgnitionFile) {
+	*out = *in
+	if in.Mode != nil {
+		in, out := &in.Mode, &out.Mode
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Overwrite != nil {
+		in, out := &in.Overwrite, &out.Overwrite
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineBootstrapIgnitionFile.
+func (in *VirtualMachineBootstrapIgnitionFile) DeepCopy() *VirtualMachineBootstrapIgnitionFile {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineBootstrapIgnitionFile)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineBootstrapIgnitionSpec) DeepCopyInto(out *VirtualMachineBootstrapIgnitionSpec) {
+	*out = *in
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = new(VirtualMachineBootstrapIgnitionConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RawConfig != nil {
+		in, out := &in.RawConfig, &out.RawConfig
+		*out = new(common.SecretKeySelector)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineBootstrapIgnitionSpec.
+func (in *VirtualMachineBootstrapIgnitionSpec) DeepCopy() *VirtualMachineBootstrapIgnitionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineBootstrapIgnitionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineBootstrapIgnitionSystemdUnit) DeepCopyInto(out *VirtualMachineBootstrapIgnitionSystemdUnit) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineBootstrapIgnitionSystemdUnit.
+func (in *VirtualMachineBootstrapIgnitionSystemdUnit) DeepCopy() *VirtualMachineBootstrapIgnitionSystemdUnit {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineBootstrapIgnitionSystemdUnit)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineBootstrapIgnitionUser) DeepCopyInto(out *VirtualMachineBootstrapIgnitionUser) {
+	*out = *in
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SSHAuthorizedKeys != nil {
+		in, out := &in.SSHAuthorizedKeys, &out.SSHAuthorizedKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineBootstrapIgnitionUser.
+func (in *VirtualMachineBootstrapIgnitionUser) DeepCopy() *VirtualMachineBootstrapIgnitionUser {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineBootstrapIgnitionUser)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VirtualMachineBootstrapLinuxPrepSpec) DeepCopyInto(out *VirtualMachineBootstrapLinuxPrepSpec) {
 	*out = *in
@@ -521,6 +871,11 @@ func (in *VirtualMachineBootstrapSpec) DeepCopyInto(out *VirtualMachineBootstrap
 		*out = new(VirtualMachineBootstrapVAppConfigSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Ignition != nil {
+		in, out := &in.Ignition, &out.Ignition
+		*out = new(VirtualMachineBootstrapIgnitionSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineBootstrapSpec.
@@ -581,13 +936,89 @@ func (in *VirtualMachineBootstrapVAppConfigSpec) DeepCopy() *VirtualMachineBoots
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VirtualMachineCdromSpec) DeepCopyInto(out *VirtualMachineCdromSpec) {
+func (in *VirtualMachineCdromConfigDriveProjection) DeepCopyInto(out *VirtualMachineCdromConfigDriveProjection) {
 	*out = *in
-	out.Image = in.Image
-	if in.Connected != nil {
-		in, out := &in.Connected, &out.Connected
-		*out = new(bool)
-		**out = **in
+	if in.ConfigMap != nil {
+		in, out := &in.ConfigMap, &out.ConfigMap
+		*out = new(corev1.ConfigMapProjection)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Secret != nil {
+		in, out := &in.Secret, &out.Secret
+		*out = new(corev1.SecretProjection)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineCdromConfigDriveProjection.
+func (in *VirtualMachineCdromConfigDriveProjection) DeepCopy() *VirtualMachineCdromConfigDriveProjection {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineCdromConfigDriveProjection)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineCdromConfigDriveSource) DeepCopyInto(out *VirtualMachineCdromConfigDriveSource) {
+	*out = *in
+	if in.Sources != nil {
+		in, out := &in.Sources, &out.Sources
+		*out = make([]VirtualMachineCdromConfigDriveProjection, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineCdromConfigDriveSource.
+func (in *VirtualMachineCdromConfigDriveSource) DeepCopy() *VirtualMachineCdromConfigDriveSource {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineCdromConfigDriveSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineCdromPersistentVolumeClaimSource) DeepCopyInto(out *VirtualMachineCdromPersistentVolumeClaimSource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineCdromPersistentVolumeClaimSource.
+func (in *VirtualMachineCdromPersistentVolumeClaimSource) DeepCopy() *VirtualMachineCdromPersistentVolumeClaimSource {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineCdromPersistentVolumeClaimSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineCdromSpec) DeepCopyInto(out *VirtualMachineCdromSpec) {
+	*out = *in
+	if in.Image != nil {
+		in, out := &in.Image, &out.Image
+		*out = new(VirtualMachineImageRef)
+		**out = **in
+	}
+	if in.PersistentVolumeClaim != nil {
+		in, out := &in.PersistentVolumeClaim, &out.PersistentVolumeClaim
+		*out = new(VirtualMachineCdromPersistentVolumeClaimSource)
+		**out = **in
+	}
+	if in.ConfigDrive != nil {
+		in, out := &in.ConfigDrive, &out.ConfigDrive
+		*out = new(VirtualMachineCdromConfigDriveSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Connected != nil {
+		in, out := &in.Connected, &out.Connected
+		*out = new(bool)
+		**out = **in
 	}
 	if in.AllowGuestControl != nil {
 		in, out := &in.AllowGuestControl, &out.AllowGuestControl
@@ -753,6 +1184,21 @@ func (in *VirtualMachineClassStatus) DeepCopy() *VirtualMachineClassStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineCloneSpec) DeepCopyInto(out *VirtualMachineCloneSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineCloneSpec.
+func (in *VirtualMachineCloneSpec) DeepCopy() *VirtualMachineCloneSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineCloneSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VirtualMachineCryptoSpec) DeepCopyInto(out *VirtualMachineCryptoSpec) {
 	*out = *in
@@ -793,6 +1239,208 @@ func (in *VirtualMachineCryptoStatus) DeepCopy() *VirtualMachineCryptoStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineDeployment) DeepCopyInto(out *VirtualMachineDeployment) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineDeployment.
+func (in *VirtualMachineDeployment) DeepCopy() *VirtualMachineDeployment {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineDeployment)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VirtualMachineDeployment) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineDeploymentList) DeepCopyInto(out *VirtualMachineDeploymentList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VirtualMachineDeployment, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineDeploymentList.
+func (in *VirtualMachineDeploymentList) DeepCopy() *VirtualMachineDeploymentList {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineDeploymentList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VirtualMachineDeploymentList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineDeploymentRollback) DeepCopyInto(out *VirtualMachineDeploymentRollback) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineDeploymentRollback.
+func (in *VirtualMachineDeploymentRollback) DeepCopy() *VirtualMachineDeploymentRollback {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineDeploymentRollback)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineDeploymentSpec) DeepCopyInto(out *VirtualMachineDeploymentSpec) {
+	*out = *in
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Template.DeepCopyInto(&out.Template)
+	if in.Strategy != nil {
+		in, out := &in.Strategy, &out.Strategy
+		*out = new(VirtualMachineDeploymentStrategy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RevisionHistoryLimit != nil {
+		in, out := &in.RevisionHistoryLimit, &out.RevisionHistoryLimit
+		*out = new(int32)
+		**out = **in
+	}
+	if in.RollbackTo != nil {
+		in, out := &in.RollbackTo, &out.RollbackTo
+		*out = new(VirtualMachineDeploymentRollback)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineDeploymentSpec.
+func (in *VirtualMachineDeploymentSpec) DeepCopy() *VirtualMachineDeploymentSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineDeploymentSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineDeploymentStatus) DeepCopyInto(out *VirtualMachineDeploymentStatus) {
+	*out = *in
+	if in.CollisionCount != nil {
+		in, out := &in.CollisionCount, &out.CollisionCount
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineDeploymentStatus.
+func (in *VirtualMachineDeploymentStatus) DeepCopy() *VirtualMachineDeploymentStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineDeploymentStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineDeploymentStrategy) DeepCopyInto(out *VirtualMachineDeploymentStrategy) {
+	*out = *in
+	if in.RollingUpdate != nil {
+		in, out := &in.RollingUpdate, &out.RollingUpdate
+		*out = new(VirtualMachineRollingUpdateDeployment)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineDeploymentStrategy.
+func (in *VirtualMachineDeploymentStrategy) DeepCopy() *VirtualMachineDeploymentStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineDeploymentStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineDriftStatus) DeepCopyInto(out *VirtualMachineDriftStatus) {
+	*out = *in
+	if in.Fields != nil {
+		in, out := &in.Fields, &out.Fields
+		*out = make([]VirtualMachineDriftedField, len(*in))
+		copy(*out, *in)
+	}
+	if in.Adopted != nil {
+		in, out := &in.Adopted, &out.Adopted
+		*out = make([]VirtualMachineDriftedField, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineDriftStatus.
+func (in *VirtualMachineDriftStatus) DeepCopy() *VirtualMachineDriftStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineDriftStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineDriftedField) DeepCopyInto(out *VirtualMachineDriftedField) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineDriftedField.
+func (in *VirtualMachineDriftedField) DeepCopy() *VirtualMachineDriftedField {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineDriftedField)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VirtualMachineImage) DeepCopyInto(out *VirtualMachineImage) {
 	*out = *in
@@ -1212,6 +1860,41 @@ func (in *VirtualMachineList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineLivenessProbeSpec) DeepCopyInto(out *VirtualMachineLivenessProbeSpec) {
+	*out = *in
+	if in.TCPSocket != nil {
+		in, out := &in.TCPSocket, &out.TCPSocket
+		*out = new(TCPSocketAction)
+		**out = **in
+	}
+	if in.HTTPGet != nil {
+		in, out := &in.HTTPGet, &out.HTTPGet
+		*out = new(HTTPGetAction)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GuestHeartbeat != nil {
+		in, out := &in.GuestHeartbeat, &out.GuestHeartbeat
+		*out = new(GuestHeartbeatAction)
+		**out = **in
+	}
+	if in.GuestInfo != nil {
+		in, out := &in.GuestInfo, &out.GuestInfo
+		*out = make([]GuestInfoAction, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineLivenessProbeSpec.
+func (in *VirtualMachineLivenessProbeSpec) DeepCopy() *VirtualMachineLivenessProbeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineLivenessProbeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VirtualMachineNetworkConfigDHCPOptionsStatus) DeepCopyInto(out *VirtualMachineNetworkConfigDHCPOptionsStatus) {
 	*out = *in
@@ -1701,6 +2384,96 @@ func (in *VirtualMachineNetworkStatus) DeepCopy() *VirtualMachineNetworkStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachinePlacementSpec) DeepCopyInto(out *VirtualMachinePlacementSpec) {
+	*out = *in
+	if in.TopologySpreadConstraints != nil {
+		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
+		*out = make([]VirtualMachineTopologySpreadConstraint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ZoneAntiAffinity != nil {
+		in, out := &in.ZoneAntiAffinity, &out.ZoneAntiAffinity
+		*out = new(VirtualMachineZoneAntiAffinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PreferredZones != nil {
+		in, out := &in.PreferredZones, &out.PreferredZones
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachinePlacementSpec.
+func (in *VirtualMachinePlacementSpec) DeepCopy() *VirtualMachinePlacementSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachinePlacementSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachinePowerScheduleSpec) DeepCopyInto(out *VirtualMachinePowerScheduleSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachinePowerScheduleSpec.
+func (in *VirtualMachinePowerScheduleSpec) DeepCopy() *VirtualMachinePowerScheduleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachinePowerScheduleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachinePowerScheduleStatus) DeepCopyInto(out *VirtualMachinePowerScheduleStatus) {
+	*out = *in
+	if in.NextTime != nil {
+		in, out := &in.NextTime, &out.NextTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastTime != nil {
+		in, out := &in.LastTime, &out.LastTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachinePowerScheduleStatus.
+func (in *VirtualMachinePowerScheduleStatus) DeepCopy() *VirtualMachinePowerScheduleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachinePowerScheduleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachinePreStopHookSpec) DeepCopyInto(out *VirtualMachinePreStopHookSpec) {
+	*out = *in
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachinePreStopHookSpec.
+func (in *VirtualMachinePreStopHookSpec) DeepCopy() *VirtualMachinePreStopHookSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachinePreStopHookSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VirtualMachinePublishRequest) DeepCopyInto(out *VirtualMachinePublishRequest) {
 	*out = *in
@@ -1779,7 +2552,7 @@ func (in *VirtualMachinePublishRequestSource) DeepCopy() *VirtualMachinePublishR
 func (in *VirtualMachinePublishRequestSpec) DeepCopyInto(out *VirtualMachinePublishRequestSpec) {
 	*out = *in
 	out.Source = in.Source
-	out.Target = in.Target
+	in.Target.DeepCopyInto(&out.Target)
 	if in.TTLSecondsAfterFinished != nil {
 		in, out := &in.TTLSecondsAfterFinished, &out.TTLSecondsAfterFinished
 		*out = new(int64)
@@ -1808,7 +2581,7 @@ func (in *VirtualMachinePublishRequestStatus) DeepCopyInto(out *VirtualMachinePu
 	if in.TargetRef != nil {
 		in, out := &in.TargetRef, &out.TargetRef
 		*out = new(VirtualMachinePublishRequestTarget)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	in.CompletionTime.DeepCopyInto(&out.CompletionTime)
 	in.StartTime.DeepCopyInto(&out.StartTime)
@@ -1836,7 +2609,7 @@ func (in *VirtualMachinePublishRequestStatus) DeepCopy() *VirtualMachinePublishR
 func (in *VirtualMachinePublishRequestTarget) DeepCopyInto(out *VirtualMachinePublishRequestTarget) {
 	*out = *in
 	out.Item = in.Item
-	out.Location = in.Location
+	in.Location.DeepCopyInto(&out.Location)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachinePublishRequestTarget.
@@ -1850,86 +2623,285 @@ func (in *VirtualMachinePublishRequestTarget) DeepCopy() *VirtualMachinePublishR
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VirtualMachinePublishRequestTargetItem) DeepCopyInto(out *VirtualMachinePublishRequestTargetItem) {
+func (in *VirtualMachinePublishRequestTargetItem) DeepCopyInto(out *VirtualMachinePublishRequestTargetItem) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachinePublishRequestTargetItem.
+func (in *VirtualMachinePublishRequestTargetItem) DeepCopy() *VirtualMachinePublishRequestTargetItem {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachinePublishRequestTargetItem)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachinePublishRequestTargetLocation) DeepCopyInto(out *VirtualMachinePublishRequestTargetLocation) {
+	*out = *in
+	if in.OCIRegistry != nil {
+		in, out := &in.OCIRegistry, &out.OCIRegistry
+		*out = new(VirtualMachinePublishRequestTargetOCIRegistry)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachinePublishRequestTargetLocation.
+func (in *VirtualMachinePublishRequestTargetLocation) DeepCopy() *VirtualMachinePublishRequestTargetLocation {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachinePublishRequestTargetLocation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachinePublishRequestTargetOCIRegistry) DeepCopyInto(out *VirtualMachinePublishRequestTargetOCIRegistry) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachinePublishRequestTargetOCIRegistry.
+func (in *VirtualMachinePublishRequestTargetOCIRegistry) DeepCopy() *VirtualMachinePublishRequestTargetOCIRegistry {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachinePublishRequestTargetOCIRegistry)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineReadinessProbeSpec) DeepCopyInto(out *VirtualMachineReadinessProbeSpec) {
+	*out = *in
+	if in.TCPSocket != nil {
+		in, out := &in.TCPSocket, &out.TCPSocket
+		*out = new(TCPSocketAction)
+		**out = **in
+	}
+	if in.HTTPGet != nil {
+		in, out := &in.HTTPGet, &out.HTTPGet
+		*out = new(HTTPGetAction)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GuestHeartbeat != nil {
+		in, out := &in.GuestHeartbeat, &out.GuestHeartbeat
+		*out = new(GuestHeartbeatAction)
+		**out = **in
+	}
+	if in.GuestInfo != nil {
+		in, out := &in.GuestInfo, &out.GuestInfo
+		*out = make([]GuestInfoAction, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineReadinessProbeSpec.
+func (in *VirtualMachineReadinessProbeSpec) DeepCopy() *VirtualMachineReadinessProbeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineReadinessProbeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineReplicaSet) DeepCopyInto(out *VirtualMachineReplicaSet) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineReplicaSet.
+func (in *VirtualMachineReplicaSet) DeepCopy() *VirtualMachineReplicaSet {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineReplicaSet)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VirtualMachineReplicaSet) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineReplicaSetList) DeepCopyInto(out *VirtualMachineReplicaSetList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VirtualMachineReplicaSet, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineReplicaSetList.
+func (in *VirtualMachineReplicaSetList) DeepCopy() *VirtualMachineReplicaSetList {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineReplicaSetList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VirtualMachineReplicaSetList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineReplicaSetSpec) DeepCopyInto(out *VirtualMachineReplicaSetSpec) {
+	*out = *in
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Template.DeepCopyInto(&out.Template)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineReplicaSetSpec.
+func (in *VirtualMachineReplicaSetSpec) DeepCopy() *VirtualMachineReplicaSetSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineReplicaSetSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineReplicaSetStatus) DeepCopyInto(out *VirtualMachineReplicaSetStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineReplicaSetStatus.
+func (in *VirtualMachineReplicaSetStatus) DeepCopy() *VirtualMachineReplicaSetStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineReplicaSetStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineReservedSpec) DeepCopyInto(out *VirtualMachineReservedSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineReservedSpec.
+func (in *VirtualMachineReservedSpec) DeepCopy() *VirtualMachineReservedSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineReservedSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineResizePendingChange) DeepCopyInto(out *VirtualMachineResizePendingChange) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachinePublishRequestTargetItem.
-func (in *VirtualMachinePublishRequestTargetItem) DeepCopy() *VirtualMachinePublishRequestTargetItem {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineResizePendingChange.
+func (in *VirtualMachineResizePendingChange) DeepCopy() *VirtualMachineResizePendingChange {
 	if in == nil {
 		return nil
 	}
-	out := new(VirtualMachinePublishRequestTargetItem)
+	out := new(VirtualMachineResizePendingChange)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VirtualMachinePublishRequestTargetLocation) DeepCopyInto(out *VirtualMachinePublishRequestTargetLocation) {
+func (in *VirtualMachineResizeStatus) DeepCopyInto(out *VirtualMachineResizeStatus) {
 	*out = *in
+	if in.PendingChanges != nil {
+		in, out := &in.PendingChanges, &out.PendingChanges
+		*out = make([]VirtualMachineResizePendingChange, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachinePublishRequestTargetLocation.
-func (in *VirtualMachinePublishRequestTargetLocation) DeepCopy() *VirtualMachinePublishRequestTargetLocation {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineResizeStatus.
+func (in *VirtualMachineResizeStatus) DeepCopy() *VirtualMachineResizeStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(VirtualMachinePublishRequestTargetLocation)
+	out := new(VirtualMachineResizeStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VirtualMachineReadinessProbeSpec) DeepCopyInto(out *VirtualMachineReadinessProbeSpec) {
+func (in *VirtualMachineResourceSpec) DeepCopyInto(out *VirtualMachineResourceSpec) {
 	*out = *in
-	if in.TCPSocket != nil {
-		in, out := &in.TCPSocket, &out.TCPSocket
-		*out = new(TCPSocketAction)
-		**out = **in
-	}
-	if in.GuestHeartbeat != nil {
-		in, out := &in.GuestHeartbeat, &out.GuestHeartbeat
-		*out = new(GuestHeartbeatAction)
-		**out = **in
-	}
-	if in.GuestInfo != nil {
-		in, out := &in.GuestInfo, &out.GuestInfo
-		*out = make([]GuestInfoAction, len(*in))
-		copy(*out, *in)
-	}
+	out.Cpu = in.Cpu.DeepCopy()
+	out.Memory = in.Memory.DeepCopy()
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineReadinessProbeSpec.
-func (in *VirtualMachineReadinessProbeSpec) DeepCopy() *VirtualMachineReadinessProbeSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineResourceSpec.
+func (in *VirtualMachineResourceSpec) DeepCopy() *VirtualMachineResourceSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(VirtualMachineReadinessProbeSpec)
+	out := new(VirtualMachineResourceSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VirtualMachineReplicaSet) DeepCopyInto(out *VirtualMachineReplicaSet) {
+func (in *VirtualMachineRestoreRequest) DeepCopyInto(out *VirtualMachineRestoreRequest) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
+	out.Spec = in.Spec
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineReplicaSet.
-func (in *VirtualMachineReplicaSet) DeepCopy() *VirtualMachineReplicaSet {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineRestoreRequest.
+func (in *VirtualMachineRestoreRequest) DeepCopy() *VirtualMachineRestoreRequest {
 	if in == nil {
 		return nil
 	}
-	out := new(VirtualMachineReplicaSet)
+	out := new(VirtualMachineRestoreRequest)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *VirtualMachineReplicaSet) DeepCopyObject() runtime.Object {
+func (in *VirtualMachineRestoreRequest) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1937,31 +2909,31 @@ func (in *VirtualMachineReplicaSet) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VirtualMachineReplicaSetList) DeepCopyInto(out *VirtualMachineReplicaSetList) {
+func (in *VirtualMachineRestoreRequestList) DeepCopyInto(out *VirtualMachineRestoreRequestList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]VirtualMachineReplicaSet, len(*in))
+		*out = make([]VirtualMachineRestoreRequest, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineReplicaSetList.
-func (in *VirtualMachineReplicaSetList) DeepCopy() *VirtualMachineReplicaSetList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineRestoreRequestList.
+func (in *VirtualMachineRestoreRequestList) DeepCopy() *VirtualMachineRestoreRequestList {
 	if in == nil {
 		return nil
 	}
-	out := new(VirtualMachineReplicaSetList)
+	out := new(VirtualMachineRestoreRequestList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *VirtualMachineReplicaSetList) DeepCopyObject() runtime.Object {
+func (in *VirtualMachineRestoreRequestList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1969,81 +2941,100 @@ func (in *VirtualMachineReplicaSetList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VirtualMachineReplicaSetSpec) DeepCopyInto(out *VirtualMachineReplicaSetSpec) {
+func (in *VirtualMachineRestoreRequestResourceStatus) DeepCopyInto(out *VirtualMachineRestoreRequestResourceStatus) {
 	*out = *in
-	if in.Replicas != nil {
-		in, out := &in.Replicas, &out.Replicas
-		*out = new(int32)
-		**out = **in
-	}
-	if in.Selector != nil {
-		in, out := &in.Selector, &out.Selector
-		*out = new(v1.LabelSelector)
-		(*in).DeepCopyInto(*out)
-	}
-	in.Template.DeepCopyInto(&out.Template)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineReplicaSetSpec.
-func (in *VirtualMachineReplicaSetSpec) DeepCopy() *VirtualMachineReplicaSetSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineRestoreRequestResourceStatus.
+func (in *VirtualMachineRestoreRequestResourceStatus) DeepCopy() *VirtualMachineRestoreRequestResourceStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(VirtualMachineReplicaSetSpec)
+	out := new(VirtualMachineRestoreRequestResourceStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VirtualMachineReplicaSetStatus) DeepCopyInto(out *VirtualMachineReplicaSetStatus) {
+func (in *VirtualMachineRestoreRequestSource) DeepCopyInto(out *VirtualMachineRestoreRequestSource) {
 	*out = *in
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]v1.Condition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineRestoreRequestSource.
+func (in *VirtualMachineRestoreRequestSource) DeepCopy() *VirtualMachineRestoreRequestSource {
+	if in == nil {
+		return nil
 	}
+	out := new(VirtualMachineRestoreRequestSource)
+	in.DeepCopyInto(out)
+	return out
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineReplicaSetStatus.
-func (in *VirtualMachineReplicaSetStatus) DeepCopy() *VirtualMachineReplicaSetStatus {
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineRestoreRequestSpec) DeepCopyInto(out *VirtualMachineRestoreRequestSpec) {
+	*out = *in
+	out.Source = in.Source
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineRestoreRequestSpec.
+func (in *VirtualMachineRestoreRequestSpec) DeepCopy() *VirtualMachineRestoreRequestSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(VirtualMachineReplicaSetStatus)
+	out := new(VirtualMachineRestoreRequestSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VirtualMachineReservedSpec) DeepCopyInto(out *VirtualMachineReservedSpec) {
+func (in *VirtualMachineRestoreRequestStatus) DeepCopyInto(out *VirtualMachineRestoreRequestStatus) {
 	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]VirtualMachineRestoreRequestResourceStatus, len(*in))
+		copy(*out, *in)
+	}
+	in.CompletionTime.DeepCopyInto(&out.CompletionTime)
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineReservedSpec.
-func (in *VirtualMachineReservedSpec) DeepCopy() *VirtualMachineReservedSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineRestoreRequestStatus.
+func (in *VirtualMachineRestoreRequestStatus) DeepCopy() *VirtualMachineRestoreRequestStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(VirtualMachineReservedSpec)
+	out := new(VirtualMachineRestoreRequestStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VirtualMachineResourceSpec) DeepCopyInto(out *VirtualMachineResourceSpec) {
+func (in *VirtualMachineRollingUpdateDeployment) DeepCopyInto(out *VirtualMachineRollingUpdateDeployment) {
 	*out = *in
-	out.Cpu = in.Cpu.DeepCopy()
-	out.Memory = in.Memory.DeepCopy()
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.MaxSurge != nil {
+		in, out := &in.MaxSurge, &out.MaxSurge
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineResourceSpec.
-func (in *VirtualMachineResourceSpec) DeepCopy() *VirtualMachineResourceSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineRollingUpdateDeployment.
+func (in *VirtualMachineRollingUpdateDeployment) DeepCopy() *VirtualMachineRollingUpdateDeployment {
 	if in == nil {
 		return nil
 	}
-	out := new(VirtualMachineResourceSpec)
+	out := new(VirtualMachineRollingUpdateDeployment)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -2142,6 +3133,11 @@ func (in *VirtualMachineServiceSpec) DeepCopyInto(out *VirtualMachineServiceSpec
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.SessionAffinityConfig != nil {
+		in, out := &in.SessionAffinityConfig, &out.SessionAffinityConfig
+		*out = new(SessionAffinityConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineServiceSpec.
@@ -2270,6 +3266,116 @@ func (in *VirtualMachineSetResourcePolicyStatus) DeepCopy() *VirtualMachineSetRe
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineSnapshot) DeepCopyInto(out *VirtualMachineSnapshot) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineSnapshot.
+func (in *VirtualMachineSnapshot) DeepCopy() *VirtualMachineSnapshot {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineSnapshot)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VirtualMachineSnapshot) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineSnapshotList) DeepCopyInto(out *VirtualMachineSnapshotList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VirtualMachineSnapshot, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineSnapshotList.
+func (in *VirtualMachineSnapshotList) DeepCopy() *VirtualMachineSnapshotList {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineSnapshotList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VirtualMachineSnapshotList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineSnapshotSpec) DeepCopyInto(out *VirtualMachineSnapshotSpec) {
+	*out = *in
+	if in.VMRef != nil {
+		in, out := &in.VMRef, &out.VMRef
+		*out = new(common.LocalObjectRef)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineSnapshotSpec.
+func (in *VirtualMachineSnapshotSpec) DeepCopy() *VirtualMachineSnapshotSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineSnapshotSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineSnapshotStatus) DeepCopyInto(out *VirtualMachineSnapshotStatus) {
+	*out = *in
+	if in.CreationTime != nil {
+		in, out := &in.CreationTime, &out.CreationTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Children != nil {
+		in, out := &in.Children, &out.Children
+		*out = make([]common.LocalObjectRef, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineSnapshotStatus.
+func (in *VirtualMachineSnapshotStatus) DeepCopy() *VirtualMachineSnapshotStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineSnapshotStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VirtualMachineSpec) DeepCopyInto(out *VirtualMachineSpec) {
 	*out = *in
@@ -2285,6 +3391,11 @@ func (in *VirtualMachineSpec) DeepCopyInto(out *VirtualMachineSpec) {
 		*out = new(VirtualMachineImageRef)
 		**out = **in
 	}
+	if in.Clone != nil {
+		in, out := &in.Clone, &out.Clone
+		*out = new(VirtualMachineCloneSpec)
+		**out = **in
+	}
 	if in.Crypto != nil {
 		in, out := &in.Crypto, &out.Crypto
 		*out = new(VirtualMachineCryptoSpec)
@@ -2300,6 +3411,21 @@ func (in *VirtualMachineSpec) DeepCopyInto(out *VirtualMachineSpec) {
 		*out = new(VirtualMachineNetworkSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.PowerOffTimeoutSeconds != nil {
+		in, out := &in.PowerOffTimeoutSeconds, &out.PowerOffTimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.PreStop != nil {
+		in, out := &in.PreStop, &out.PreStop
+		*out = new(VirtualMachinePreStopHookSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PowerSchedule != nil {
+		in, out := &in.PowerSchedule, &out.PowerSchedule
+		*out = new(VirtualMachinePowerScheduleSpec)
+		**out = **in
+	}
 	if in.Volumes != nil {
 		in, out := &in.Volumes, &out.Volumes
 		*out = make([]VirtualMachineVolume, len(*in))
@@ -2312,6 +3438,11 @@ func (in *VirtualMachineSpec) DeepCopyInto(out *VirtualMachineSpec) {
 		*out = new(VirtualMachineReadinessProbeSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.LivenessProbe != nil {
+		in, out := &in.LivenessProbe, &out.LivenessProbe
+		*out = new(VirtualMachineLivenessProbeSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Advanced != nil {
 		in, out := &in.Advanced, &out.Advanced
 		*out = new(VirtualMachineAdvancedSpec)
@@ -2322,6 +3453,16 @@ func (in *VirtualMachineSpec) DeepCopyInto(out *VirtualMachineSpec) {
 		*out = new(VirtualMachineReservedSpec)
 		**out = **in
 	}
+	if in.CurrentSnapshot != nil {
+		in, out := &in.CurrentSnapshot, &out.CurrentSnapshot
+		*out = new(common.LocalObjectRef)
+		**out = **in
+	}
+	if in.Placement != nil {
+		in, out := &in.Placement, &out.Placement
+		*out = new(VirtualMachinePlacementSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineSpec.
@@ -2375,11 +3516,36 @@ func (in *VirtualMachineStatus) DeepCopyInto(out *VirtualMachineStatus) {
 		in, out := &in.LastRestartTime, &out.LastRestartTime
 		*out = (*in).DeepCopy()
 	}
+	if in.PowerSchedule != nil {
+		in, out := &in.PowerSchedule, &out.PowerSchedule
+		*out = new(VirtualMachinePowerScheduleStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Storage != nil {
 		in, out := &in.Storage, &out.Storage
 		*out = new(VirtualMachineStorageStatus)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.CurrentSnapshot != nil {
+		in, out := &in.CurrentSnapshot, &out.CurrentSnapshot
+		*out = new(common.LocalObjectRef)
+		**out = **in
+	}
+	if in.RootSnapshots != nil {
+		in, out := &in.RootSnapshots, &out.RootSnapshots
+		*out = make([]common.LocalObjectRef, len(*in))
+		copy(*out, *in)
+	}
+	if in.Resize != nil {
+		in, out := &in.Resize, &out.Resize
+		*out = new(VirtualMachineResizeStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Drift != nil {
+		in, out := &in.Drift, &out.Drift
+		*out = new(VirtualMachineDriftStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineStatus.
@@ -2480,6 +3646,26 @@ func (in *VirtualMachineTemplateSpec) DeepCopy() *VirtualMachineTemplateSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineTopologySpreadConstraint) DeepCopyInto(out *VirtualMachineTopologySpreadConstraint) {
+	*out = *in
+	if in.LabelSelector != nil {
+		in, out := &in.LabelSelector, &out.LabelSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineTopologySpreadConstraint.
+func (in *VirtualMachineTopologySpreadConstraint) DeepCopy() *VirtualMachineTopologySpreadConstraint {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineTopologySpreadConstraint)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VirtualMachineVolume) DeepCopyInto(out *VirtualMachineVolume) {
 	*out = *in
@@ -2519,6 +3705,21 @@ func (in *VirtualMachineVolumeSource) DeepCopyInto(out *VirtualMachineVolumeSour
 		*out = new(PersistentVolumeClaimVolumeSource)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Ephemeral != nil {
+		in, out := &in.Ephemeral, &out.Ephemeral
+		*out = new(EphemeralVolumeSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ConfigMap != nil {
+		in, out := &in.ConfigMap, &out.ConfigMap
+		*out = new(corev1.ConfigMapVolumeSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Secret != nil {
+		in, out := &in.Secret, &out.Secret
+		*out = new(corev1.SecretVolumeSource)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineVolumeSource.
@@ -2650,3 +3851,23 @@ func (in *VirtualMachineWebConsoleRequestStatus) DeepCopy() *VirtualMachineWebCo
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineZoneAntiAffinity) DeepCopyInto(out *VirtualMachineZoneAntiAffinity) {
+	*out = *in
+	if in.LabelSelector != nil {
+		in, out := &in.LabelSelector, &out.LabelSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineZoneAntiAffinity.
+func (in *VirtualMachineZoneAntiAffinity) DeepCopy() *VirtualMachineZoneAntiAffinity {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineZoneAntiAffinity)
+	in.DeepCopyInto(out)
+	return out
+}
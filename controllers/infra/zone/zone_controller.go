@@ -13,11 +13,16 @@ import (
 
 	"github.com/go-logr/logr"
 	vimtypes "github.com/vmware/govmomi/vim25/types"
+	corev1 "k8s.io/api/core/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
+	ctrlbuilder "sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	topologyv1 "github.com/vmware-tanzu/vm-operator/external/tanzu-topology/api/v1alpha1"
 	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
@@ -50,12 +55,42 @@ func AddToManager(ctx *pkgctx.ControllerManagerContext, mgr manager.Manager) err
 		record.New(mgr.GetEventRecorderFor(controllerNameLong)),
 	)
 
-	return ctrl.NewControllerManagedBy(mgr).
+	builder := ctrl.NewControllerManagedBy(mgr).
 		For(controlledType).
 		WithOptions(controller.Options{
 			SkipNameValidation: SkipNameValidation,
-		}).
-		Complete(r)
+		})
+
+	if pkgcfg.FromContext(ctx).AsyncSignalNamespaceSelector != "" {
+		// Reconcile a namespace's zones when its labels change so the zones
+		// are added to or removed from the scope of the watcher.
+		builder = builder.Watches(
+			&corev1.Namespace{},
+			handler.EnqueueRequestsFromMapFunc(
+				zonesForNamespace(mgr.GetClient())),
+			ctrlbuilder.WithPredicates(predicate.LabelChangedPredicate{}))
+	}
+
+	return builder.Complete(r)
+}
+
+// zonesForNamespace returns a mapper function that enqueues requests for the
+// zones in a namespace.
+func zonesForNamespace(k8sClient client.Client) handler.MapFunc {
+	return func(ctx context.Context, o client.Object) []reconcile.Request {
+		var list topologyv1.ZoneList
+		if err := k8sClient.List(ctx, &list, client.InNamespace(o.GetName())); err != nil {
+			return nil
+		}
+
+		requests := make([]reconcile.Request, 0, len(list.Items))
+		for i := range list.Items {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: client.ObjectKeyFromObject(&list.Items[i]),
+			})
+		}
+		return requests
+	}
 }
 
 func NewReconciler(
@@ -156,7 +191,19 @@ func (r *Reconciler) ReconcileNormal(
 	}
 
 	if val := obj.Spec.ManagedVMs.FolderMoID; val != "" {
-		if err := watcher.Add(
+		watched, err := watcher.IsNamespaceWatched(ctx, r.Client, obj.Namespace)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+
+		// Stop watching the folder if the namespace is no longer in the
+		// scope of the watcher, ex. its labels were changed.
+		watchFn := watcher.Add
+		if !watched {
+			watchFn = watcher.Remove
+		}
+
+		if err := watchFn(
 			ctx,
 			vimtypes.ManagedObjectReference{
 				Type:  "Folder",
@@ -136,7 +136,14 @@ func (r *Reconciler) ReconcileNormal(
 			continue
 		}
 
-		if vm.Status.Storage == nil ||
+		if vm.Spec.StorageClass != obj.Spec.StorageClassName {
+			// Ignore VMs that use a different storage class. A VM that is
+			// migrated to a new storage class is accounted against the new
+			// storage class as soon as the migration is requested.
+			continue
+		}
+
+		if vm.Status.Storage == nil || vm.Status.Storage.Usage == nil ||
 			!conditions.IsTrue(&vm, vmopv1.VirtualMachineConditionCreated) {
 
 			// The VM is not yet fully created or is not yet reporting its
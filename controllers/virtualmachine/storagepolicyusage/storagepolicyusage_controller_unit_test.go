@@ -283,6 +283,7 @@ func unitTestsReconcile() {
 						Name:      "vm-1",
 					},
 					Spec: vmopv1.VirtualMachineSpec{
+						StorageClass: name,
 						Image: &vmopv1.VirtualMachineImageRef{
 							Kind: "VirtualMachineImage",
 							Name: "my-vmi",
@@ -309,6 +310,7 @@ func unitTestsReconcile() {
 						Name:      "vm-2",
 					},
 					Spec: vmopv1.VirtualMachineSpec{
+						StorageClass: name,
 						Image: &vmopv1.VirtualMachineImageRef{
 							Kind: "VirtualMachineImage",
 							Name: "my-vmi",
@@ -382,6 +384,14 @@ func unitTestsReconcile() {
 							inNamespace, inName, fake)))
 				})
 			})
+			Context("that use a different storage class", func() {
+				BeforeEach(func() {
+					vm1.Spec.StorageClass = name + "-other"
+				})
+				Specify("the reported information should only include VMs that use the storage class", func() {
+					assertReportedTotals(spu, err, nil, zeroQuantity, resource.MustParse("10Gi"))
+				})
+			})
 			Context("that are being deleted", func() {
 				BeforeEach(func() {
 					vm1.DeletionTimestamp = ptr.To(metav1.Now())
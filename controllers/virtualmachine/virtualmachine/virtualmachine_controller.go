@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -22,6 +23,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
@@ -45,6 +47,7 @@ import (
 	"github.com/vmware-tanzu/vm-operator/pkg/util/kube/cource"
 	"github.com/vmware-tanzu/vm-operator/pkg/util/ovfcache"
 	vmopv1util "github.com/vmware-tanzu/vm-operator/pkg/util/vmopv1"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/vsphere/watcher"
 	"github.com/vmware-tanzu/vm-operator/pkg/vmconfig"
 	"github.com/vmware-tanzu/vm-operator/pkg/vmconfig/crypto"
 )
@@ -135,6 +138,16 @@ func AddToManager(ctx *pkgctx.ControllerManagerContext, mgr manager.Manager) err
 			&handler.EnqueueRequestForObject{}))
 	}
 
+	if pkgcfg.FromContext(ctx).AsyncSignalNamespaceSelector != "" {
+		// Reconcile a namespace's VMs when its labels change so the VMs are
+		// picked up by the instance of VM Operator whose selector the
+		// namespace now matches.
+		builder = builder.Watches(
+			&corev1.Namespace{},
+			handler.EnqueueRequestsFromMapFunc(namespaceToVMMapperFn(ctx, r.Client)),
+			ctrlbuilder.WithPredicates(predicate.LabelChangedPredicate{}))
+	}
+
 	if pkgcfg.FromContext(ctx).Features.FastDeploy {
 		builder = builder.Watches(
 			&vmopv1.VirtualMachineImageCache{},
@@ -150,9 +163,70 @@ func AddToManager(ctx *pkgctx.ControllerManagerContext, mgr manager.Manager) err
 		)
 	}
 
+	if pkgcfg.FromContext(ctx).Features.VMBackupPolicy {
+		builder = builder.Watches(
+			&vmopv1.VirtualMachineBackupPolicy{},
+			handler.EnqueueRequestsFromMapFunc(backupPolicyToVMMapperFn(ctx, r.Client)))
+	}
+
 	return builder.Complete(r)
 }
 
+// namespaceToVMMapperFn returns a mapper function that can be used to queue
+// reconcile requests for the VirtualMachines in a namespace in response to an
+// event on the Namespace resource.
+func namespaceToVMMapperFn(
+	ctx *pkgctx.ControllerManagerContext,
+	c client.Client) func(_ context.Context, o client.Object) []reconcile.Request {
+
+	return func(_ context.Context, o client.Object) []reconcile.Request {
+		vmList := &vmopv1.VirtualMachineList{}
+		if err := c.List(ctx, vmList, client.InNamespace(o.GetName())); err != nil {
+			ctx.Logger.Error(err, "Failed to list VirtualMachines for namespace", "namespace", o.GetName())
+			return nil
+		}
+
+		requests := make([]reconcile.Request, 0, len(vmList.Items))
+		for i := range vmList.Items {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: client.ObjectKeyFromObject(&vmList.Items[i]),
+			})
+		}
+		return requests
+	}
+}
+
+// backupPolicyToVMMapperFn returns a mapper function that can be used to queue
+// reconcile requests for the VirtualMachines in response to an event on a
+// VirtualMachineBackupPolicy resource, so the VMs are backed up with the
+// latest policy. All VMs in the namespace are queued, since a change to the
+// policy's VM selector may affect VMs the policy no longer selects.
+func backupPolicyToVMMapperFn(
+	ctx *pkgctx.ControllerManagerContext,
+	c client.Client) func(_ context.Context, o client.Object) []reconcile.Request {
+
+	return func(_ context.Context, o client.Object) []reconcile.Request {
+		logger := ctx.Logger.WithValues("name", o.GetName(), "namespace", o.GetNamespace())
+
+		vmList := &vmopv1.VirtualMachineList{}
+		if err := c.List(ctx, vmList, client.InNamespace(o.GetNamespace())); err != nil {
+			logger.Error(err, "Failed to list VirtualMachines for reconciliation due to VirtualMachineBackupPolicy watch")
+			return nil
+		}
+
+		reconcileRequests := make([]reconcile.Request, 0, len(vmList.Items))
+		for _, vm := range vmList.Items {
+			key := client.ObjectKey{Namespace: vm.Namespace, Name: vm.Name}
+			reconcileRequests = append(reconcileRequests, reconcile.Request{NamespacedName: key})
+		}
+
+		if len(reconcileRequests) > 0 {
+			logger.V(4).Info("Returning VM reconcile requests due to VirtualMachineBackupPolicy watch", "requests", reconcileRequests)
+		}
+		return reconcileRequests
+	}
+}
+
 // classToVMMapperFn returns a mapper function that can be used to queue reconcile request
 // for the VirtualMachines in response to an event on the VirtualMachineClass resource when
 // WCP_Namespaced_VM_Class FSS is enabled.
@@ -275,6 +349,8 @@ type Reconciler struct {
 // +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachines,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachines/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachineclasses,verbs=get;list
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachinebackuppolicies,verbs=get;list;watch
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=networkpolicies,verbs=get;list;watch
 // +kubebuilder:rbac:groups=vmware.com,resources=virtualnetworkinterfaces;virtualnetworkinterfaces/status,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=netoperator.vmware.com,resources=networkinterfaces,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=storage.k8s.io,resources=storageclasses,verbs=get;list;watch
@@ -299,6 +375,15 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Re
 	ctx = ovfcache.JoinContext(ctx, r.Context)
 	ctx = record.WithContext(ctx, r.Recorder)
 
+	// When the namespaces are split across multiple instances of VM Operator
+	// with AsyncSignalNamespaceSelector, only the VMs in the namespaces
+	// watched by this instance are reconciled.
+	if ok, err := watcher.IsNamespaceWatched(ctx, r.Client, req.Namespace); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	} else if !ok {
+		return ctrl.Result{}, nil
+	}
+
 	vm := &vmopv1.VirtualMachine{}
 	if err := r.Get(ctx, req.NamespacedName, vm); err != nil {
 		return ctrl.Result{}, client.IgnoreNotFound(err)
@@ -349,12 +434,27 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Re
 		return ctrl.Result{}, fmt.Errorf("failed to init patch helper for %s: %w", vmCtx, err)
 	}
 
+	// The storage class the VM was last placed on. This differs from
+	// spec.storageClass while the VM is migrated to a new storage class.
+	var observedStorageClass string
+	if s := vm.Status.Storage; s != nil {
+		observedStorageClass = s.StorageClass
+	}
+
 	defer func() {
 		if pkgcfg.FromContext(ctx).Features.UnifiedStorageQuota {
 			vmopv1util.SyncStorageUsageForNamespace(
 				ctx,
 				vm.Namespace,
 				vm.Spec.StorageClass)
+			if observedStorageClass != vm.Spec.StorageClass {
+				// Re-account the usage of the storage class the VM is
+				// migrated from.
+				vmopv1util.SyncStorageUsageForNamespace(
+					ctx,
+					vm.Namespace,
+					observedStorageClass)
+			}
 		}
 		if err := patchHelper.Patch(ctx, vm); err != nil {
 			if reterr == nil {
@@ -376,8 +476,13 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Re
 		return ctrl.Result{}, err
 	}
 
-	// Requeue after N amount of time according to the state of the VM.
-	return ctrl.Result{RequeueAfter: requeueDelay(vmCtx, err)}, nil
+	// Requeue after N amount of time according to the state of the VM, or
+	// when the VM's power schedule is next due, whichever is sooner.
+	delay := requeueDelay(vmCtx, err)
+	if d := powerScheduleRequeueDelay(vmCtx); d > 0 && (delay == 0 || d < delay) {
+		delay = d
+	}
+	return ctrl.Result{RequeueAfter: delay}, nil
 }
 
 // Determine if we should request a non-zero requeue delay in order to trigger a
@@ -430,6 +535,16 @@ func requeueDelay(
 	return 0
 }
 
+// powerScheduleRequeueDelay returns the duration until the VM's power schedule
+// is next due, or zero if the VM does not have a power schedule.
+func powerScheduleRequeueDelay(ctx *pkgctx.VirtualMachineContext) time.Duration {
+	status := ctx.VM.Status.PowerSchedule
+	if ctx.VM.Spec.PowerSchedule == nil || status == nil || status.NextTime == nil {
+		return 0
+	}
+	return max(time.Until(status.NextTime.Time), time.Second)
+}
+
 func (r *Reconciler) ReconcileDelete(ctx *pkgctx.VirtualMachineContext) (reterr error) {
 	ctx.Logger.Info("Reconciling VirtualMachine Deletion")
 
@@ -518,6 +633,9 @@ func (r *Reconciler) ReconcileNormal(ctx *pkgctx.VirtualMachineContext) (reterr
 	// Upgrade schema fields where needed
 	upgradeSchema(ctx)
 
+	// Change the desired power state if the VM's power schedule is due.
+	r.reconcilePowerSchedule(ctx)
+
 	if pkgcfg.FromContext(ctx).Features.FastDeploy {
 		// Do not proceed unless the VMI cache this VM needs is ready.
 		if !r.isVMICacheReady(ctx) {
@@ -591,18 +709,95 @@ func (r *Reconciler) ReconcileNormal(ctx *pkgctx.VirtualMachineContext) (reterr
 		// Add the VM to the probe manager. This is idempotent.
 		r.Prober.AddToProberManager(ctx.VM)
 
-	} else if p := ctx.VM.Spec.ReadinessProbe; p != nil && p.TCPSocket != nil {
-		// TCP probes still use the probe manager.
+	} else if vmopv1util.IsReadinessProbePeriodic(*ctx.VM) || ctx.VM.Spec.LivenessProbe != nil {
+		// TCP and HTTP GET readiness probes, readiness probes with thresholds
+		// or an initial delay, and liveness probes still use the probe
+		// manager.
 		r.Prober.AddToProberManager(ctx.VM)
 	} else {
-		// Remove the probe in case it *was* a TCP probe but switched to one
-		// of the other types.
+		// Remove the probe in case it *was* run by the probe manager but
+		// switched to one of the other types.
 		r.Prober.RemoveFromProberManager(ctx.VM)
 	}
 
 	return err
 }
 
+// reconcilePowerSchedule sets the VM's desired power state when the VM's power
+// schedule is due, and updates the status with when it is next due. Errors are
+// logged rather than returned since an invalid schedule should not prevent the
+// rest of the VM from being reconciled.
+func (r *Reconciler) reconcilePowerSchedule(ctx *pkgctx.VirtualMachineContext) {
+	schedule := ctx.VM.Spec.PowerSchedule
+	if schedule == nil {
+		ctx.VM.Status.PowerSchedule = nil
+		return
+	}
+
+	if ctx.VM.Status.PowerSchedule == nil {
+		ctx.VM.Status.PowerSchedule = &vmopv1.VirtualMachinePowerScheduleStatus{}
+	}
+	status := ctx.VM.Status.PowerSchedule
+
+	now := time.Now()
+
+	if status.NextPowerState != "" && status.NextTime != nil &&
+		!now.Before(status.NextTime.Time) {
+
+		powerState := status.NextPowerState
+
+		// The schedule may have been changed since the status was updated, so
+		// only act on the due power state if it is still scheduled.
+		p, t, _ := vmopv1util.NextScheduledPowerState(
+			*schedule, status.NextTime.Add(-time.Minute))
+		stillScheduled := p == powerState && t.Equal(status.NextTime.Time)
+
+		switch {
+		case !stillScheduled:
+			ctx.Logger.Info("Skipping power state no longer scheduled",
+				"powerState", powerState, "scheduledTime", status.NextTime)
+		case ctx.VM.Spec.PowerState == powerState:
+			ctx.Logger.V(4).Info("VM already has scheduled power state",
+				"powerState", powerState)
+		case powerState == vmopv1.VirtualMachinePowerStateSuspended &&
+			ctx.VM.Spec.PowerState == vmopv1.VirtualMachinePowerStateOff:
+			// A powered off VM cannot be suspended.
+			ctx.Logger.Info("Skipping scheduled suspend of powered off VM")
+		default:
+			ctx.Logger.Info("Changing power state as scheduled",
+				"currentPowerState", ctx.VM.Spec.PowerState,
+				"powerState", powerState,
+				"scheduledTime", status.NextTime)
+			r.Recorder.Eventf(ctx.VM, "PowerSchedule",
+				"Changing power state from %s to %s as scheduled at %s",
+				ctx.VM.Spec.PowerState, powerState,
+				status.NextTime.UTC().Format(time.RFC3339))
+			ctx.VM.Spec.PowerState = powerState
+		}
+
+		if stillScheduled {
+			status.LastPowerState = powerState
+			status.LastTime = status.NextTime
+		}
+	}
+
+	nextPowerState, nextTime, err := vmopv1util.NextScheduledPowerState(
+		*schedule, now)
+	if err != nil {
+		ctx.Logger.Error(err, "Failed to get next scheduled power state")
+		status.NextPowerState = ""
+		status.NextTime = nil
+		return
+	}
+
+	status.NextPowerState = nextPowerState
+	if nextTime.IsZero() {
+		status.NextTime = nil
+	} else {
+		status.NextTime = &metav1.Time{Time: nextTime}
+	}
+}
+
 func getIsDefaultVMClassController(ctx context.Context) bool {
 	if v := pkgcfg.FromContext(ctx).DefaultVMClassControllerName; v == "" || v == vmClassControllerName {
 		return true
@@ -8,11 +8,14 @@ import (
 	"context"
 	"errors"
 	"strings"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
@@ -119,6 +122,42 @@ func unitTestsReconcile() {
 		fakeVMProvider = nil
 	})
 
+	Context("Reconcile", func() {
+		BeforeEach(func() {
+			vm.Finalizers = nil
+			initObjects = append(initObjects, vm, &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   vm.Namespace,
+					Labels: map[string]string{"shard": "b"},
+				},
+			})
+		})
+
+		When("the namespace is not selected by the async signal namespace selector", func() {
+			JustBeforeEach(func() {
+				pkgcfg.SetContext(reconciler.Context, func(config *pkgcfg.Config) {
+					config.AsyncSignalNamespaceSelector = "shard=a"
+				})
+			})
+
+			It("does not reconcile the VM", func() {
+				var called bool
+				fakeVMProvider.CreateOrUpdateVirtualMachineFn = func(_ context.Context, _ *vmopv1.VirtualMachine) error {
+					called = true
+					return nil
+				}
+
+				_, err := reconciler.Reconcile(context.Background(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(vm)})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(called).To(BeFalse())
+
+				obj := &vmopv1.VirtualMachine{}
+				Expect(ctx.Client.Get(ctx, client.ObjectKeyFromObject(vm), obj)).To(Succeed())
+				Expect(obj.Finalizers).To(BeEmpty())
+			})
+		})
+	})
+
 	Context("ReconcileNormal", func() {
 		BeforeEach(func() {
 			initObjects = append(initObjects, vm)
@@ -310,6 +349,97 @@ func unitTestsReconcile() {
 			Expect(reconciler.ReconcileNormal(vmCtx)).ShouldNot(Succeed())
 			expectEvents(ctx, "ReconcileNormalFailure")
 		})
+
+		Context("PowerSchedule", func() {
+
+			It("Should clear the status when there is no power schedule", func() {
+				vm.Status.PowerSchedule = &vmopv1.VirtualMachinePowerScheduleStatus{
+					NextPowerState: vmopv1.VirtualMachinePowerStateOff,
+				}
+
+				Expect(reconciler.ReconcileNormal(vmCtx)).To(Succeed())
+				Expect(vm.Status.PowerSchedule).To(BeNil())
+			})
+
+			When("the VM has a power schedule", func() {
+				BeforeEach(func() {
+					vm.Spec.PowerState = vmopv1.VirtualMachinePowerStateOn
+					vm.Spec.PowerSchedule = &vmopv1.VirtualMachinePowerScheduleSpec{
+						PowerOff: "* * * * *",
+					}
+				})
+
+				It("Should set the next scheduled power state", func() {
+					Expect(reconciler.ReconcileNormal(vmCtx)).To(Succeed())
+					Expect(vm.Spec.PowerState).To(Equal(vmopv1.VirtualMachinePowerStateOn))
+
+					status := vm.Status.PowerSchedule
+					Expect(status).ToNot(BeNil())
+					Expect(status.NextPowerState).To(Equal(vmopv1.VirtualMachinePowerStateOff))
+					Expect(status.NextTime).ToNot(BeNil())
+					Expect(status.NextTime.Time).To(BeTemporally(">", time.Now()))
+					Expect(status.NextTime.Time).To(BeTemporally("<=", time.Now().Add(time.Minute)))
+					Expect(status.LastTime).To(BeNil())
+				})
+
+				It("Should change the power state when the schedule is due", func() {
+					due := metav1.NewTime(time.Now().Truncate(time.Minute))
+					vm.Status.PowerSchedule = &vmopv1.VirtualMachinePowerScheduleStatus{
+						NextPowerState: vmopv1.VirtualMachinePowerStateOff,
+						NextTime:       &due,
+					}
+
+					Expect(reconciler.ReconcileNormal(vmCtx)).To(Succeed())
+					Expect(vm.Spec.PowerState).To(Equal(vmopv1.VirtualMachinePowerStateOff))
+
+					status := vm.Status.PowerSchedule
+					Expect(status.LastPowerState).To(Equal(vmopv1.VirtualMachinePowerStateOff))
+					Expect(status.LastTime).To(Equal(&due))
+					Expect(status.NextTime.Time).To(BeTemporally(">", due.Time))
+					expectEvents(ctx, "PowerSchedule")
+				})
+
+				It("Should not change the power state when the due power state is no longer scheduled", func() {
+					due := metav1.NewTime(time.Now().Truncate(time.Minute))
+					vm.Status.PowerSchedule = &vmopv1.VirtualMachinePowerScheduleStatus{
+						NextPowerState: vmopv1.VirtualMachinePowerStateSuspended,
+						NextTime:       &due,
+					}
+
+					Expect(reconciler.ReconcileNormal(vmCtx)).To(Succeed())
+					Expect(vm.Spec.PowerState).To(Equal(vmopv1.VirtualMachinePowerStateOn))
+					Expect(vm.Status.PowerSchedule.LastTime).To(BeNil())
+					Expect(vm.Status.PowerSchedule.NextPowerState).To(Equal(vmopv1.VirtualMachinePowerStateOff))
+					expectEvents(ctx)
+				})
+
+				It("Should not suspend a powered off VM", func() {
+					vm.Spec.PowerState = vmopv1.VirtualMachinePowerStateOff
+					vm.Spec.PowerSchedule = &vmopv1.VirtualMachinePowerScheduleSpec{
+						Suspend: "* * * * *",
+					}
+					due := metav1.NewTime(time.Now().Truncate(time.Minute))
+					vm.Status.PowerSchedule = &vmopv1.VirtualMachinePowerScheduleStatus{
+						NextPowerState: vmopv1.VirtualMachinePowerStateSuspended,
+						NextTime:       &due,
+					}
+
+					Expect(reconciler.ReconcileNormal(vmCtx)).To(Succeed())
+					Expect(vm.Spec.PowerState).To(Equal(vmopv1.VirtualMachinePowerStateOff))
+					Expect(vm.Status.PowerSchedule.LastPowerState).To(Equal(vmopv1.VirtualMachinePowerStateSuspended))
+					expectEvents(ctx)
+				})
+
+				It("Should clear the next scheduled power state when the schedule is invalid", func() {
+					vm.Spec.PowerSchedule.TimeZone = "Local"
+
+					Expect(reconciler.ReconcileNormal(vmCtx)).To(Succeed())
+					Expect(vm.Status.PowerSchedule).ToNot(BeNil())
+					Expect(vm.Status.PowerSchedule.NextPowerState).To(BeEmpty())
+					Expect(vm.Status.PowerSchedule.NextTime).To(BeNil())
+				})
+			})
+		})
 	})
 
 	Context("ReconcileDelete", func() {
@@ -23,8 +23,10 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
@@ -108,6 +110,33 @@ func AddToManager(ctx *pkgctx.ControllerManagerContext, mgr manager.Manager) err
 		return err
 	}
 
+	// Watch for the expansion of PersistentVolumeClaims, and enqueue the
+	// VirtualMachines that reference them so the resize status of the
+	// volumes is updated. Please note, the PVCs are already cached since
+	// the limits of the VM's volumes are read from them.
+	if err := c.Watch(source.Kind(
+		mgr.GetCache(),
+		&corev1.PersistentVolumeClaim{},
+		handler.TypedEnqueueRequestsFromMapFunc(pvcToVMMapperFn(ctx, r.Client)),
+		predicate.TypedFuncs[*corev1.PersistentVolumeClaim]{
+			CreateFunc: func(event.TypedCreateEvent[*corev1.PersistentVolumeClaim]) bool {
+				return false
+			},
+			UpdateFunc: func(e event.TypedUpdateEvent[*corev1.PersistentVolumeClaim]) bool {
+				return pvcResizeChanged(e.ObjectOld, e.ObjectNew)
+			},
+			DeleteFunc: func(event.TypedDeleteEvent[*corev1.PersistentVolumeClaim]) bool {
+				return false
+			},
+			GenericFunc: func(event.TypedGenericEvent[*corev1.PersistentVolumeClaim]) bool {
+				return false
+			},
+		},
+	)); err != nil {
+
+		return err
+	}
+
 	if pkgcfg.FromContext(ctx).Features.InstanceStorage {
 		// Instance storage isn't enabled in all envs and is not that commonly used. Avoid the
 		// memory and CPU cost of watching PVCs until we encounter a VM with instance storage.
@@ -172,6 +201,50 @@ func AddToManager(ctx *pkgctx.ControllerManagerContext, mgr manager.Manager) err
 	return nil
 }
 
+// pvcToVMMapperFn returns a mapper function that can be used to queue
+// reconcile requests for the VirtualMachines that reference a
+// PersistentVolumeClaim.
+func pvcToVMMapperFn(
+	ctx context.Context,
+	k8sClient client.Client) handler.TypedMapFunc[*corev1.PersistentVolumeClaim, reconcile.Request] {
+
+	return func(_ context.Context, pvc *corev1.PersistentVolumeClaim) []reconcile.Request {
+		logger := logr.FromContextOrDiscard(ctx).
+			WithValues("name", pvc.Name, "namespace", pvc.Namespace)
+		logger.V(4).Info("Reconciling all VMs referencing a PersistentVolumeClaim")
+
+		vmList := &vmopv1.VirtualMachineList{}
+		if err := k8sClient.List(ctx, vmList, client.InNamespace(pvc.Namespace)); err != nil {
+			logger.Error(err, "Failed to list VirtualMachines for reconciliation due to PersistentVolumeClaim watch")
+			return nil
+		}
+
+		var requests []reconcile.Request
+		for i := range vmList.Items {
+			vm := &vmList.Items[i]
+			for _, vol := range vm.Spec.Volumes {
+				if vol.PersistentVolumeClaim != nil && vol.PersistentVolumeClaim.ClaimName == pvc.Name {
+					requests = append(requests, reconcile.Request{
+						NamespacedName: client.ObjectKey{Namespace: vm.Namespace, Name: vm.Name},
+					})
+					break
+				}
+			}
+		}
+
+		return requests
+	}
+}
+
+// pvcResizeChanged returns true if the requested or actual capacity, or the
+// state of an expansion, differs between the two PVCs.
+func pvcResizeChanged(oldPVC, newPVC *corev1.PersistentVolumeClaim) bool {
+	return !oldPVC.Spec.Resources.Requests.Storage().Equal(*newPVC.Spec.Resources.Requests.Storage()) ||
+		!oldPVC.Spec.Resources.Limits.Storage().Equal(*newPVC.Spec.Resources.Limits.Storage()) ||
+		!oldPVC.Status.Capacity.Storage().Equal(*newPVC.Status.Capacity.Storage()) ||
+		pvcResizeStatus(*oldPVC) != pvcResizeStatus(*newPVC)
+}
+
 func NewReconciler(
 	ctx context.Context,
 	client client.Client,
@@ -666,8 +739,8 @@ func (r *Reconciler) processAttachments(
 				volumeStatus := attachmentToVolumeStatus(volume.Name, attachment)
 				volumeStatus.Used = existingManagedVols[volume.Name].Used
 				volumeStatus.Crypto = existingManagedVols[volume.Name].Crypto
-				if err := updateVolumeStatusWithLimit(ctx, r.Client, *volume.PersistentVolumeClaim, &volumeStatus); err != nil {
-					ctx.Logger.Error(err, "failed to get volume status limit")
+				if err := updateVolumeStatusWithPVC(ctx, r.Client, *volume.PersistentVolumeClaim, &volumeStatus); err != nil {
+					ctx.Logger.Error(err, "failed to get volume status limit and resize status")
 				}
 				volumeStatuses = append(volumeStatuses, volumeStatus)
 				hasPendingAttachment = hasPendingAttachment || !attachment.Status.Attached
@@ -944,7 +1017,9 @@ func attachmentToVolumeStatus(
 	}
 }
 
-func updateVolumeStatusWithLimit(
+// updateVolumeStatusWithPVC updates the limit and resize status of the given
+// volume status from the volume's PVC.
+func updateVolumeStatusWithPVC(
 	ctx *pkgctx.VolumeContext,
 	c client.Reader,
 	pvcSpec vmopv1.PersistentVolumeClaimVolumeSource,
@@ -953,8 +1028,8 @@ func updateVolumeStatusWithLimit(
 	// See if the volume is an instance storage volume.
 	if pvcSpec.InstanceVolumeClaim != nil {
 		// Short-cut the rest of the function since instance storage
-		// volumes already have the requested size and the PVC does
-		// not need to be fetched.
+		// volumes already have the requested size, cannot be expanded,
+		// and the PVC does not need to be fetched.
 		status.Limit = &pvcSpec.InstanceVolumeClaim.Size
 		return nil
 	}
@@ -979,5 +1054,45 @@ func updateVolumeStatusWithLimit(
 		status.Limit = &v
 	}
 
+	status.ResizeStatus = pvcResizeStatus(pvc)
+
 	return nil
 }
+
+// pvcResizeStatus returns the state of an in-progress expansion of the given
+// PVC. The allocated resource status is preferred since it is more precise,
+// but it is only set when the RecoverVolumeExpansionFailure feature is
+// enabled in Kubernetes, so the PVC's conditions are used as a fallback.
+func pvcResizeStatus(pvc corev1.PersistentVolumeClaim) vmopv1.VirtualMachineVolumeResizeStatus {
+	if rs, ok := pvc.Status.AllocatedResourceStatuses[corev1.ResourceStorage]; ok {
+		switch rs {
+		case corev1.PersistentVolumeClaimControllerResizeInProgress:
+			return vmopv1.VirtualMachineVolumeResizeStatusControllerResizeInProgress
+		case corev1.PersistentVolumeClaimControllerResizeInfeasible:
+			return vmopv1.VirtualMachineVolumeResizeStatusControllerResizeFailed
+		case corev1.PersistentVolumeClaimNodeResizePending,
+			corev1.PersistentVolumeClaimNodeResizeInProgress,
+			corev1.PersistentVolumeClaimNodeResizeInfeasible:
+			// There is no kubelet to grow the file system on a VM's volume,
+			// so the guest is responsible for doing so.
+			return vmopv1.VirtualMachineVolumeResizeStatusFileSystemResizePending
+		}
+	}
+
+	var status vmopv1.VirtualMachineVolumeResizeStatus
+	for _, c := range pvc.Status.Conditions {
+		if c.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch c.Type {
+		case corev1.PersistentVolumeClaimResizing:
+			status = vmopv1.VirtualMachineVolumeResizeStatusControllerResizeInProgress
+		case corev1.PersistentVolumeClaimControllerResizeError:
+			return vmopv1.VirtualMachineVolumeResizeStatusControllerResizeFailed
+		case corev1.PersistentVolumeClaimFileSystemResizePending:
+			return vmopv1.VirtualMachineVolumeResizeStatusFileSystemResizePending
+		}
+	}
+
+	return status
+}
@@ -1148,6 +1148,83 @@ FaultMessage: ([]vimtypes.LocalizableMessage) \u003cnil\u003e\\n }\\n },\\n Type
 									assertPVCHasLimit()
 								})
 							})
+
+							DescribeTable("PVC is being expanded",
+								func(status corev1.PersistentVolumeClaimStatus, expected vmopv1.VirtualMachineVolumeResizeStatus) {
+									pvc := &corev1.PersistentVolumeClaim{
+										ObjectMeta: metav1.ObjectMeta{
+											Namespace: vm.Namespace,
+											Name:      vm.Spec.Volumes[0].PersistentVolumeClaim.ClaimName,
+										},
+										Spec: corev1.PersistentVolumeClaimSpec{
+											Resources: corev1.VolumeResourceRequirements{
+												Requests: corev1.ResourceList{
+													corev1.ResourceStorage: resource.MustParse("20Gi"),
+												},
+											},
+										},
+										Status: status,
+									}
+									Expect(reconciler.Client.Create(ctx, pvc)).To(Succeed())
+
+									assertBaselineVolStatus()
+									assertPVCHasLimit()
+									Expect(vm.Status.Volumes[3].ResizeStatus).To(Equal(expected))
+								},
+								Entry("not resizing",
+									corev1.PersistentVolumeClaimStatus{},
+									vmopv1.VirtualMachineVolumeResizeStatus("")),
+								Entry("resizing condition",
+									corev1.PersistentVolumeClaimStatus{
+										Conditions: []corev1.PersistentVolumeClaimCondition{
+											{Type: corev1.PersistentVolumeClaimResizing, Status: corev1.ConditionTrue},
+										},
+									},
+									vmopv1.VirtualMachineVolumeResizeStatusControllerResizeInProgress),
+								Entry("controller resize error condition",
+									corev1.PersistentVolumeClaimStatus{
+										Conditions: []corev1.PersistentVolumeClaimCondition{
+											{Type: corev1.PersistentVolumeClaimResizing, Status: corev1.ConditionTrue},
+											{Type: corev1.PersistentVolumeClaimControllerResizeError, Status: corev1.ConditionTrue},
+										},
+									},
+									vmopv1.VirtualMachineVolumeResizeStatusControllerResizeFailed),
+								Entry("file system resize pending condition",
+									corev1.PersistentVolumeClaimStatus{
+										Conditions: []corev1.PersistentVolumeClaimCondition{
+											{Type: corev1.PersistentVolumeClaimFileSystemResizePending, Status: corev1.ConditionTrue},
+										},
+									},
+									vmopv1.VirtualMachineVolumeResizeStatusFileSystemResizePending),
+								Entry("false condition",
+									corev1.PersistentVolumeClaimStatus{
+										Conditions: []corev1.PersistentVolumeClaimCondition{
+											{Type: corev1.PersistentVolumeClaimFileSystemResizePending, Status: corev1.ConditionFalse},
+										},
+									},
+									vmopv1.VirtualMachineVolumeResizeStatus("")),
+								Entry("controller resize in progress allocated resource status",
+									corev1.PersistentVolumeClaimStatus{
+										AllocatedResourceStatuses: map[corev1.ResourceName]corev1.ClaimResourceStatus{
+											corev1.ResourceStorage: corev1.PersistentVolumeClaimControllerResizeInProgress,
+										},
+									},
+									vmopv1.VirtualMachineVolumeResizeStatusControllerResizeInProgress),
+								Entry("controller resize infeasible allocated resource status",
+									corev1.PersistentVolumeClaimStatus{
+										AllocatedResourceStatuses: map[corev1.ResourceName]corev1.ClaimResourceStatus{
+											corev1.ResourceStorage: corev1.PersistentVolumeClaimControllerResizeInfeasible,
+										},
+									},
+									vmopv1.VirtualMachineVolumeResizeStatusControllerResizeFailed),
+								Entry("node resize pending allocated resource status",
+									corev1.PersistentVolumeClaimStatus{
+										AllocatedResourceStatuses: map[corev1.ResourceName]corev1.ClaimResourceStatus{
+											corev1.ResourceStorage: corev1.PersistentVolumeClaimNodeResizePending,
+										},
+									},
+									vmopv1.VirtualMachineVolumeResizeStatusFileSystemResizePending),
+							)
 						})
 					})
 
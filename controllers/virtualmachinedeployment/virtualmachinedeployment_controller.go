@@ -0,0 +1,586 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachinedeployment
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/pkg/patch"
+	"github.com/vmware-tanzu/vm-operator/pkg/record"
+	"github.com/vmware-tanzu/vm-operator/pkg/util"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/ptr"
+	vmopv1util "github.com/vmware-tanzu/vm-operator/pkg/util/vmopv1"
+)
+
+const (
+	finalizerName = "virtualmachinedeployment.vmoperator.vmware.com"
+)
+
+var (
+	// deploymentKind contains the schema.GroupVersionKind for the
+	// VirtualMachineDeployment type.
+	deploymentKind = vmopv1.GroupVersion.WithKind("VirtualMachineDeployment")
+
+	// replicaSetKind contains the schema.GroupVersionKind for the
+	// VirtualMachineReplicaSet type.
+	replicaSetKind = vmopv1.GroupVersion.WithKind("VirtualMachineReplicaSet")
+)
+
+// AddToManager adds this package's controller to the provided manager.
+func AddToManager(ctx *pkgctx.ControllerManagerContext, mgr manager.Manager) error {
+	var (
+		controlledType     = &vmopv1.VirtualMachineDeployment{}
+		controlledTypeName = reflect.TypeOf(controlledType).Elem().Name()
+
+		controllerNameShort = fmt.Sprintf("%s-controller", strings.ToLower(controlledTypeName))
+		controllerNameLong  = fmt.Sprintf("%s/%s/%s", ctx.Namespace, ctx.Name, controllerNameShort)
+	)
+
+	r := NewReconciler(
+		ctx,
+		mgr.GetClient(),
+		ctrl.Log.WithName("controllers").WithName(controlledTypeName),
+		record.New(mgr.GetEventRecorderFor(controllerNameLong)))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(controlledType).
+		Owns(&vmopv1.VirtualMachineReplicaSet{}).
+		Watches(&vmopv1.VirtualMachine{},
+			handler.EnqueueRequestsFromMapFunc(r.VMToDeployment(ctx)),
+		).
+		WithOptions(controller.Options{MaxConcurrentReconciles: ctx.MaxConcurrentReconciles}).
+		Complete(r)
+}
+
+func NewReconciler(
+	ctx context.Context,
+	client client.Client,
+	logger logr.Logger,
+	recorder record.Recorder) *Reconciler {
+
+	return &Reconciler{
+		Context:  ctx,
+		Client:   client,
+		Logger:   logger,
+		Recorder: recorder,
+	}
+}
+
+// Reconciler reconciles a VirtualMachineDeployment object.
+type Reconciler struct {
+	client.Client
+	Context  context.Context
+	Logger   logr.Logger
+	Recorder record.Recorder
+}
+
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachinedeployments,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachinedeployments/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachinereplicasets,verbs=create;delete;get;list;watch;update;patch
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachines,verbs=get;list;watch
+
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
+	ctx = pkgcfg.JoinContext(ctx, r.Context)
+
+	d := &vmopv1.VirtualMachineDeployment{}
+	if err := r.Get(ctx, req.NamespacedName, d); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	dCtx := &pkgctx.VirtualMachineDeploymentContext{
+		Context:    ctx,
+		Logger:     ctrl.Log.WithName("VirtualMachineDeployment").WithValues("namespace", d.Namespace, "name", d.Name),
+		Deployment: d,
+	}
+
+	patchHelper, err := patch.NewHelper(d, r.Client)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to init patch helper for %s: %w", dCtx.String(), err)
+	}
+
+	defer func() {
+		if err := patchHelper.Patch(ctx, d); err != nil {
+			if reterr == nil {
+				reterr = err
+			}
+			dCtx.Logger.Error(err, "patch failed")
+		}
+	}()
+
+	if !d.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, r.ReconcileDelete(dCtx)
+	}
+
+	result, err := r.ReconcileNormal(dCtx)
+	if err != nil {
+		dCtx.Logger.Error(err, "Failed to reconcile VirtualMachineDeployment")
+		return ctrl.Result{}, err
+	}
+
+	return result, nil
+}
+
+func (r *Reconciler) ReconcileDelete(ctx *pkgctx.VirtualMachineDeploymentContext) error {
+	ctx.Logger.Info("Reconciling VirtualMachineDeployment Deletion")
+
+	// The VirtualMachineReplicaSets are garbage collected by way of their
+	// owner references.
+	if controllerutil.ContainsFinalizer(ctx.Deployment, finalizerName) {
+		defer func() {
+			r.Recorder.EmitEvent(ctx.Deployment, "Delete", nil, false)
+		}()
+
+		controllerutil.RemoveFinalizer(ctx.Deployment, finalizerName)
+	}
+
+	return nil
+}
+
+func (r *Reconciler) ReconcileNormal(ctx *pkgctx.VirtualMachineDeploymentContext) (ctrl.Result, error) {
+	if !controllerutil.ContainsFinalizer(ctx.Deployment, finalizerName) {
+		// Set the finalizer and return so the object is patched immediately.
+		controllerutil.AddFinalizer(ctx.Deployment, finalizerName)
+		return ctrl.Result{}, nil
+	}
+
+	ctx.Logger.Info("Reconciling VirtualMachineDeployment")
+
+	d := ctx.Deployment
+	if d.Spec.Selector == nil {
+		return ctrl.Result{}, fmt.Errorf(
+			"the Selector field in Spec for VirtualMachineDeployment %q is nil, this should not be allowed", d.Name)
+	}
+
+	rsList, err := r.getReplicaSets(ctx)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// A rollback only updates the template. The new template is rolled out
+	// by the next reconcile that is triggered by the update.
+	if d.Spec.RollbackTo != nil {
+		return ctrl.Result{}, r.rollback(ctx, rsList)
+	}
+
+	if ctx.ReadyReplicas, err = r.getReadyReplicas(ctx, rsList); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	hash, err := util.ComputeHash(&d.Spec.Template, d.Status.CollisionCount)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to compute template hash: %w", err)
+	}
+
+	newRS, oldRSs := splitReplicaSets(rsList, hash, &d.Spec.Template)
+
+	maxSurge, maxUnavailable, err := resolveFenceposts(d)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if d.Spec.Paused {
+		// Changes to the template are not rolled out while paused, but changes
+		// to the number of replicas are.
+		syncErr := r.syncPaused(ctx, newRS, oldRSs)
+		r.updateStatus(ctx, newRS, rsList, maxUnavailable)
+		return ctrl.Result{}, syncErr
+	}
+
+	if newRS == nil {
+		if newRS, err = r.createReplicaSet(ctx, hash, maxRevision(oldRSs)+1); err != nil {
+			return ctrl.Result{}, err
+		}
+		rsList = append(rsList, newRS)
+	} else if err := r.syncRevision(ctx, newRS, oldRSs); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	var rolloutErr error
+	switch strategyType(d) {
+	case vmopv1.RecreateVirtualMachineDeploymentStrategyType:
+		rolloutErr = r.rolloutRecreate(ctx, newRS, oldRSs)
+	default:
+		rolloutErr = r.rolloutRolling(ctx, newRS, oldRSs, maxSurge, maxUnavailable)
+	}
+
+	// Update the status of the VirtualMachineDeployment even in case of error
+	// since the rollout might have resulted in replica sets being scaled.
+	r.updateStatus(ctx, newRS, rsList, maxUnavailable)
+
+	if rolloutErr != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to roll out VirtualMachineDeployment: %w", rolloutErr)
+	}
+
+	if err := r.cleanupReplicaSets(ctx, oldRSs); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// Queue faster reconciles until the rollout is complete.
+	if !conditions.IsTrue(d, vmopv1.VirtualMachineDeploymentProgressingCondition) {
+		return ctrl.Result{RequeueAfter: 15 * time.Second}, nil
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// getReplicaSets returns the VirtualMachineReplicaSets controlled by the
+// VirtualMachineDeployment.
+// Note that we do not support adopting VirtualMachineReplicaSets since they
+// would not have the template hash label.
+func (r *Reconciler) getReplicaSets(
+	ctx *pkgctx.VirtualMachineDeploymentContext) ([]*vmopv1.VirtualMachineReplicaSet, error) {
+
+	rsList := &vmopv1.VirtualMachineReplicaSetList{}
+	if err := r.Client.List(ctx, rsList, client.InNamespace(ctx.Deployment.Namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list VirtualMachineReplicaSets: %w", err)
+	}
+
+	var rss []*vmopv1.VirtualMachineReplicaSet
+	for i := range rsList.Items {
+		rs := &rsList.Items[i]
+		if metav1.IsControlledBy(rs, ctx.Deployment) {
+			rss = append(rss, rs)
+		}
+	}
+
+	return rss, nil
+}
+
+// getReadyReplicas returns the number of ready VMs of each of the provided
+// VirtualMachineReplicaSets, keyed by the name of the replica set. The
+// readiness of the VMs is determined here since the ready replicas reported by
+// a VirtualMachineReplicaSet include VMs that are not yet ready.
+func (r *Reconciler) getReadyReplicas(
+	ctx *pkgctx.VirtualMachineDeploymentContext,
+	rsList []*vmopv1.VirtualMachineReplicaSet) (map[string]int32, error) {
+
+	vmList := &vmopv1.VirtualMachineList{}
+	if err := r.Client.List(
+		ctx,
+		vmList,
+		client.InNamespace(ctx.Deployment.Namespace),
+		client.MatchingLabels{
+			vmopv1.VirtualMachineDeploymentNameLabel: util.MustFormatValue(ctx.Deployment.Name),
+		}); err != nil {
+
+		return nil, fmt.Errorf("failed to list VirtualMachines: %w", err)
+	}
+
+	rsByName := make(map[string]*vmopv1.VirtualMachineReplicaSet, len(rsList))
+	for _, rs := range rsList {
+		rsByName[rs.Name] = rs
+	}
+
+	readyReplicas := make(map[string]int32, len(rsList))
+	for i := range vmList.Items {
+		vm := &vmList.Items[i]
+		ref := metav1.GetControllerOfNoCopy(vm)
+		if ref == nil || ref.Kind != replicaSetKind.Kind {
+			continue
+		}
+		if rs, ok := rsByName[ref.Name]; ok && ref.UID == rs.UID && vmopv1util.IsVirtualMachineReady(*vm) {
+			readyReplicas[rs.Name]++
+		}
+	}
+
+	return readyReplicas, nil
+}
+
+// VMToDeployment is a mapper function to be used to enqueue requests for
+// reconciliation for the VirtualMachineDeployment that controls the
+// VirtualMachineReplicaSet of a VM, so the readiness of the VM is observed.
+func (r *Reconciler) VMToDeployment(
+	ctx *pkgctx.ControllerManagerContext) func(_ context.Context, o client.Object) []reconcile.Request {
+
+	return func(_ context.Context, o client.Object) []reconcile.Request {
+		vm, ok := o.(*vmopv1.VirtualMachine)
+		if !ok {
+			panic(fmt.Sprintf("Expected a VirtualMachine, but got a %T", o))
+		}
+
+		ref := metav1.GetControllerOfNoCopy(vm)
+		if ref == nil || ref.Kind != replicaSetKind.Kind {
+			return nil
+		}
+
+		rs := &vmopv1.VirtualMachineReplicaSet{}
+		if err := r.Client.Get(ctx, client.ObjectKey{Namespace: vm.Namespace, Name: ref.Name}, rs); err != nil {
+			if !apierrors.IsNotFound(err) {
+				ctx.Logger.Error(err, "Failed getting VirtualMachineReplicaSet for VM")
+			}
+			return nil
+		}
+
+		ref = metav1.GetControllerOfNoCopy(rs)
+		if ref == nil || ref.Kind != deploymentKind.Kind {
+			return nil
+		}
+
+		return []reconcile.Request{
+			{NamespacedName: client.ObjectKey{Namespace: rs.Namespace, Name: ref.Name}},
+		}
+	}
+}
+
+// createReplicaSet creates the VirtualMachineReplicaSet for the current
+// template of the VirtualMachineDeployment.
+func (r *Reconciler) createReplicaSet(
+	ctx *pkgctx.VirtualMachineDeploymentContext,
+	hash string,
+	revision int64) (*vmopv1.VirtualMachineReplicaSet, error) {
+
+	d := ctx.Deployment
+
+	selector := d.Spec.Selector.DeepCopy()
+	if selector.MatchLabels == nil {
+		selector.MatchLabels = map[string]string{}
+	}
+	selector.MatchLabels[vmopv1.VirtualMachineDeploymentUniqueLabel] = hash
+
+	template := d.Spec.Template.DeepCopy()
+	template.Labels = getReplicaSetLabels(d, hash)
+
+	rs := &vmopv1.VirtualMachineReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-%s", d.Name, hash),
+			Namespace: d.Namespace,
+			Labels:    getReplicaSetLabels(d, hash),
+			Annotations: map[string]string{
+				vmopv1.VirtualMachineDeploymentRevisionAnnotation: strconv.FormatInt(revision, 10),
+			},
+			OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(d, deploymentKind)},
+		},
+		Spec: vmopv1.VirtualMachineReplicaSetSpec{
+			// The replica set is scaled up by the rollout.
+			Replicas: ptr.To(int32(0)),
+			Selector: selector,
+			Template: *template,
+		},
+	}
+
+	if err := r.Client.Create(ctx, rs); err != nil {
+		if apierrors.IsAlreadyExists(err) {
+			existingRS := &vmopv1.VirtualMachineReplicaSet{}
+			if err := r.Client.Get(ctx, client.ObjectKeyFromObject(rs), existingRS); err != nil {
+				return nil, fmt.Errorf("failed to get VirtualMachineReplicaSet %q: %w", rs.Name, err)
+			}
+
+			// The replica set may have been created by an earlier reconcile
+			// that did not observe it yet.
+			if metav1.IsControlledBy(existingRS, d) &&
+				equalIgnoreHash(&d.Spec.Template, &existingRS.Spec.Template) {
+				return existingRS, nil
+			}
+
+			// The hash of the template collides with the hash of the template
+			// of another replica set. Bump the collision count so the next
+			// reconcile computes a different hash.
+			if d.Status.CollisionCount == nil {
+				d.Status.CollisionCount = ptr.To(int32(0))
+			}
+			*d.Status.CollisionCount++
+			ctx.Logger.Info("Found a hash collision for VirtualMachineDeployment",
+				"replicaSet", rs.Name, "collisionCount", *d.Status.CollisionCount)
+		}
+
+		r.Recorder.Warnf(d, "FailedCreate", "Failed to create VirtualMachineReplicaSet %q: %v", rs.Name, err)
+		return nil, fmt.Errorf("failed to create VirtualMachineReplicaSet %q: %w", rs.Name, err)
+	}
+
+	ctx.Logger.Info("Created VirtualMachineReplicaSet", "replicaSet", rs.Name, "revision", revision)
+	r.Recorder.Eventf(d, "SuccessfulCreate", "Created VirtualMachineReplicaSet %q", rs.Name)
+
+	return rs, nil
+}
+
+// syncRevision ensures the VirtualMachineReplicaSet for the current template
+// has the latest revision. This is not the case after a rollback to the
+// template of an old VirtualMachineReplicaSet.
+func (r *Reconciler) syncRevision(
+	ctx *pkgctx.VirtualMachineDeploymentContext,
+	newRS *vmopv1.VirtualMachineReplicaSet,
+	oldRSs []*vmopv1.VirtualMachineReplicaSet) error {
+
+	revision := maxRevision(oldRSs) + 1
+	if getRevision(newRS) >= revision {
+		return nil
+	}
+
+	rsPatch := client.MergeFrom(newRS.DeepCopy())
+	if newRS.Annotations == nil {
+		newRS.Annotations = map[string]string{}
+	}
+	newRS.Annotations[vmopv1.VirtualMachineDeploymentRevisionAnnotation] = strconv.FormatInt(revision, 10)
+	if err := r.Client.Patch(ctx, newRS, rsPatch); err != nil {
+		return fmt.Errorf("failed to update revision of VirtualMachineReplicaSet %q: %w", newRS.Name, err)
+	}
+
+	return nil
+}
+
+// rollback updates the template of the VirtualMachineDeployment to the
+// template of the VirtualMachineReplicaSet with the requested revision. The
+// change is made to a copy of the VirtualMachineDeployment that is updated
+// directly rather than by patching the VirtualMachineDeployment when the
+// reconcile completes.
+func (r *Reconciler) rollback(
+	ctx *pkgctx.VirtualMachineDeploymentContext,
+	rsList []*vmopv1.VirtualMachineReplicaSet) error {
+
+	d := ctx.Deployment
+
+	revision := d.Spec.RollbackTo.Revision
+	if revision == 0 {
+		revision = previousRevision(rsList)
+	}
+
+	newD := d.DeepCopy()
+
+	// The rollback is only attempted once.
+	newD.Spec.RollbackTo = nil
+
+	var found bool
+	for _, rs := range rsList {
+		if revision == 0 || getRevision(rs) != revision {
+			continue
+		}
+
+		template := rs.Spec.Template.DeepCopy()
+		delete(template.Labels, vmopv1.VirtualMachineDeploymentUniqueLabel)
+		delete(template.Labels, vmopv1.VirtualMachineDeploymentNameLabel)
+		newD.Spec.Template = *template
+		found = true
+		break
+	}
+
+	if err := r.Client.Update(ctx, newD); err != nil {
+		return fmt.Errorf("failed to roll back VirtualMachineDeployment: %w", err)
+	}
+
+	if !found {
+		conditions.MarkFalse(
+			d,
+			vmopv1.VirtualMachineDeploymentProgressingCondition,
+			vmopv1.VirtualMachineDeploymentRollbackFailedReason,
+			"Unable to find revision %d to roll back to",
+			revision)
+		r.Recorder.Warnf(d, "RollbackRevisionNotFound", "Unable to find revision %d to roll back to", revision)
+		return nil
+	}
+
+	ctx.Logger.Info("Rolled back VirtualMachineDeployment", "revision", revision)
+	r.Recorder.Eventf(d, "DeploymentRollback", "Rolled back to revision %d", revision)
+
+	return nil
+}
+
+// updateStatus updates the Status field of the VirtualMachineDeployment.
+func (r *Reconciler) updateStatus(
+	ctx *pkgctx.VirtualMachineDeploymentContext,
+	newRS *vmopv1.VirtualMachineReplicaSet,
+	rsList []*vmopv1.VirtualMachineReplicaSet,
+	maxUnavailable int32) {
+
+	d := ctx.Deployment
+	desired := desiredReplicas(d)
+
+	var totalReplicas, readyReplicas int32
+	for _, rs := range rsList {
+		totalReplicas += rs.Status.Replicas
+		readyReplicas += ctx.ReadyReplicas[rs.Name]
+	}
+
+	d.Status.Replicas = totalReplicas
+	d.Status.ReadyReplicas = readyReplicas
+	d.Status.UnavailableReplicas = max(desired-readyReplicas, 0)
+	d.Status.UpdatedReplicas = 0
+	if newRS != nil {
+		d.Status.UpdatedReplicas = newRS.Status.Replicas
+		d.Status.Revision = getRevision(newRS)
+
+		if d.Annotations == nil {
+			d.Annotations = map[string]string{}
+		}
+		d.Annotations[vmopv1.VirtualMachineDeploymentRevisionAnnotation] = strconv.FormatInt(d.Status.Revision, 10)
+	}
+
+	if selector, err := metav1.LabelSelectorAsSelector(d.Spec.Selector); err == nil {
+		d.Status.Selector = selector.String()
+	}
+
+	// Save the generation number we acted on, otherwise we might wrongfully
+	// indicate that we've seen a spec update when we retry.
+	d.Status.ObservedGeneration = d.Generation
+
+	if readyReplicas >= desired-maxUnavailable {
+		conditions.MarkTrue(d, vmopv1.VirtualMachineDeploymentAvailableCondition)
+	} else {
+		conditions.MarkFalse(
+			d,
+			vmopv1.VirtualMachineDeploymentAvailableCondition,
+			vmopv1.VirtualMachineDeploymentWaitingForAvailableReplicasReason,
+			"%d of %d replicas are ready",
+			readyReplicas,
+			desired)
+	}
+
+	switch {
+	case d.Spec.Paused:
+		conditions.MarkFalse(
+			d,
+			vmopv1.VirtualMachineDeploymentProgressingCondition,
+			vmopv1.VirtualMachineDeploymentPausedReason,
+			"Rollout of VirtualMachineDeployment is paused")
+	case d.Status.UpdatedReplicas == desired &&
+		totalReplicas == desired &&
+		readyReplicas == desired:
+
+		if !conditions.IsTrue(d, vmopv1.VirtualMachineDeploymentProgressingCondition) {
+			ctx.Logger.Info("Rollout is complete", "revision", d.Status.Revision)
+		}
+		conditions.MarkTrue(d, vmopv1.VirtualMachineDeploymentProgressingCondition)
+	default:
+		conditions.MarkFalse(
+			d,
+			vmopv1.VirtualMachineDeploymentProgressingCondition,
+			vmopv1.VirtualMachineDeploymentRollingOutReason,
+			"%d of %d replicas are updated",
+			d.Status.UpdatedReplicas,
+			desired)
+	}
+}
+
+func getReplicaSetLabels(d *vmopv1.VirtualMachineDeployment, hash string) map[string]string {
+	labels := make(map[string]string, len(d.Spec.Template.Labels)+2)
+
+	// Copy over the labels from the template.
+	maps.Copy(labels, d.Spec.Template.Labels)
+
+	labels[vmopv1.VirtualMachineDeploymentNameLabel] = util.MustFormatValue(d.Name)
+	labels[vmopv1.VirtualMachineDeploymentUniqueLabel] = hash
+
+	return labels
+}
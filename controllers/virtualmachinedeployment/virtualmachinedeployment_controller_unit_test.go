@@ -0,0 +1,411 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachinedeployment_test
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachinedeployment"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/pkg/util"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/ptr"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+const finalizerName = "virtualmachinedeployment.vmoperator.vmware.com"
+
+var (
+	deploymentKind = vmopv1.GroupVersion.WithKind("VirtualMachineDeployment")
+	replicaSetKind = vmopv1.GroupVersion.WithKind("VirtualMachineReplicaSet")
+)
+
+func unitTests() {
+	Describe(
+		"Reconcile",
+		Label(
+			testlabels.Controller,
+		),
+		unitTestsReconcile,
+	)
+}
+
+func unitTestsReconcile() {
+	var (
+		ctx *builder.UnitTestContextForController
+
+		reconciler *virtualmachinedeployment.Reconciler
+		d          *vmopv1.VirtualMachineDeployment
+	)
+
+	BeforeEach(func() {
+		d = builder.DummyVirtualMachineDeployment()
+		d.GenerateName = ""
+		d.Name = "dummy-deployment"
+		d.Namespace = "dummy-ns"
+		d.Spec.Replicas = ptr.To(int32(3))
+		d.Finalizers = []string{finalizerName}
+	})
+
+	JustBeforeEach(func() {
+		ctx = suite.NewUnitTestContextForController(d)
+		reconciler = virtualmachinedeployment.NewReconciler(
+			ctx,
+			ctx.Client,
+			ctx.Logger,
+			ctx.Recorder,
+		)
+	})
+
+	AfterEach(func() {
+		ctx.AfterEach()
+		ctx = nil
+		reconciler = nil
+	})
+
+	reconcileNormal := func() {
+		GinkgoHelper()
+		_, err := reconciler.ReconcileNormal(&pkgctx.VirtualMachineDeploymentContext{
+			Context:    ctx,
+			Logger:     ctx.Logger.WithName(d.Name),
+			Deployment: d,
+		})
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	// getReplicaSets returns the deployment's replica sets keyed by revision.
+	getReplicaSets := func() map[string]*vmopv1.VirtualMachineReplicaSet {
+		GinkgoHelper()
+		rsList := &vmopv1.VirtualMachineReplicaSetList{}
+		Expect(ctx.Client.List(ctx, rsList, client.InNamespace(d.Namespace))).To(Succeed())
+		rss := map[string]*vmopv1.VirtualMachineReplicaSet{}
+		for i := range rsList.Items {
+			rs := &rsList.Items[i]
+			rss[rs.Annotations[vmopv1.VirtualMachineDeploymentRevisionAnnotation]] = rs
+		}
+		return rss
+	}
+
+	// getDeployment returns the deployment as stored by the client.
+	getDeployment := func() *vmopv1.VirtualMachineDeployment {
+		GinkgoHelper()
+		obj := &vmopv1.VirtualMachineDeployment{}
+		Expect(ctx.Client.Get(ctx, client.ObjectKeyFromObject(d), obj)).To(Succeed())
+		return obj
+	}
+
+	// setReplicaSetStatus sets the status of the replica set as if its
+	// replicas had been created and the provided number were ready.
+	setReplicaSetStatus := func(rs *vmopv1.VirtualMachineReplicaSet, ready int32) {
+		GinkgoHelper()
+		rs.Status.Replicas = *rs.Spec.Replicas
+		rs.Status.ReadyReplicas = rs.Status.Replicas
+		Expect(ctx.Client.Status().Update(ctx, rs)).To(Succeed())
+
+		Expect(ctx.Client.DeleteAllOf(
+			ctx,
+			&vmopv1.VirtualMachine{},
+			client.InNamespace(rs.Namespace),
+			client.MatchingLabels{vmopv1.VirtualMachineDeploymentUniqueLabel: rs.Labels[vmopv1.VirtualMachineDeploymentUniqueLabel]},
+		)).To(Succeed())
+
+		for i := range rs.Status.Replicas {
+			vm := &vmopv1.VirtualMachine{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            fmt.Sprintf("%s-%d", rs.Name, i),
+					Namespace:       rs.Namespace,
+					Labels:          rs.Spec.Template.Labels,
+					OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(rs, replicaSetKind)},
+				},
+				Spec: rs.Spec.Template.Spec,
+			}
+			Expect(ctx.Client.Create(ctx, vm)).To(Succeed())
+			if i < ready {
+				conditions.MarkTrue(vm, vmopv1.VirtualMachineConditionCreated)
+				vm.Status.PowerState = vm.Spec.PowerState
+				Expect(ctx.Client.Status().Update(ctx, vm)).To(Succeed())
+			}
+		}
+	}
+
+	Context("ReconcileNormal", func() {
+
+		When("the deployment does not have the finalizer", func() {
+			BeforeEach(func() {
+				d.Finalizers = nil
+			})
+
+			It("adds the finalizer", func() {
+				reconcileNormal()
+				Expect(d.Finalizers).To(ContainElement(finalizerName))
+				Expect(getReplicaSets()).To(BeEmpty())
+			})
+		})
+
+		It("creates a replica set for the template", func() {
+			reconcileNormal()
+
+			rss := getReplicaSets()
+			Expect(rss).To(HaveLen(1))
+			rs := rss["1"]
+			Expect(rs).ToNot(BeNil())
+			Expect(*rs.Spec.Replicas).To(Equal(int32(3)))
+
+			hash := rs.Labels[vmopv1.VirtualMachineDeploymentUniqueLabel]
+			Expect(hash).ToNot(BeEmpty())
+			Expect(rs.Name).To(Equal(d.Name + "-" + hash))
+			Expect(rs.Spec.Selector.MatchLabels).To(HaveKeyWithValue(vmopv1.VirtualMachineDeploymentUniqueLabel, hash))
+			Expect(rs.Spec.Template.Labels).To(HaveKeyWithValue(vmopv1.VirtualMachineDeploymentUniqueLabel, hash))
+			Expect(rs.Spec.Template.Labels).To(HaveKeyWithValue(vmopv1.VirtualMachineDeploymentNameLabel, d.Name))
+			Expect(rs.Spec.Template.Labels).To(HaveKeyWithValue("app", "dummy"))
+			Expect(rs.OwnerReferences).To(HaveLen(1))
+			Expect(rs.OwnerReferences[0].Name).To(Equal(d.Name))
+
+			Expect(d.Status.Revision).To(Equal(int64(1)))
+			Expect(d.Status.Selector).To(Equal("app=dummy"))
+			Expect(conditions.IsFalse(d, vmopv1.VirtualMachineDeploymentAvailableCondition)).To(BeTrue())
+			Expect(conditions.IsFalse(d, vmopv1.VirtualMachineDeploymentProgressingCondition)).To(BeTrue())
+
+			By("replicas become ready", func() {
+				setReplicaSetStatus(rs, 3)
+				reconcileNormal()
+				Expect(d.Status.Replicas).To(Equal(int32(3)))
+				Expect(d.Status.ReadyReplicas).To(Equal(int32(3)))
+				Expect(d.Status.UpdatedReplicas).To(Equal(int32(3)))
+				Expect(d.Status.UnavailableReplicas).To(BeZero())
+				Expect(conditions.IsTrue(d, vmopv1.VirtualMachineDeploymentAvailableCondition)).To(BeTrue())
+				Expect(conditions.IsTrue(d, vmopv1.VirtualMachineDeploymentProgressingCondition)).To(BeTrue())
+			})
+		})
+
+		When("the hash of the template collides with another replica set", func() {
+			var hash string
+
+			JustBeforeEach(func() {
+				var err error
+				hash, err = util.ComputeHash(&d.Spec.Template, nil)
+				Expect(err).ToNot(HaveOccurred())
+
+				rs := &vmopv1.VirtualMachineReplicaSet{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:            d.Name + "-" + hash,
+						Namespace:       d.Namespace,
+						Labels:          map[string]string{vmopv1.VirtualMachineDeploymentUniqueLabel: hash},
+						OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(d, deploymentKind)},
+					},
+					Spec: vmopv1.VirtualMachineReplicaSetSpec{
+						Replicas: ptr.To(int32(0)),
+						Selector: d.Spec.Selector,
+						Template: *d.Spec.Template.DeepCopy(),
+					},
+				}
+				rs.Spec.Template.Spec.ImageName = "other-image"
+				Expect(ctx.Client.Create(ctx, rs)).To(Succeed())
+			})
+
+			It("creates the replica set with a different hash", func() {
+				_, err := reconciler.ReconcileNormal(&pkgctx.VirtualMachineDeploymentContext{
+					Context:    ctx,
+					Logger:     ctx.Logger.WithName(d.Name),
+					Deployment: d,
+				})
+				Expect(err).To(HaveOccurred())
+				Expect(d.Status.CollisionCount).To(HaveValue(Equal(int32(1))))
+
+				reconcileNormal()
+
+				rs := getReplicaSets()["1"]
+				Expect(rs).ToNot(BeNil())
+				Expect(rs.Labels[vmopv1.VirtualMachineDeploymentUniqueLabel]).ToNot(Equal(hash))
+				Expect(rs.Spec.Template.Spec.ImageName).To(Equal(d.Spec.Template.Spec.ImageName))
+			})
+		})
+
+		When("the template is changed", func() {
+			var oldRS *vmopv1.VirtualMachineReplicaSet
+
+			JustBeforeEach(func() {
+				reconcileNormal()
+				oldRS = getReplicaSets()["1"]
+				setReplicaSetStatus(oldRS, 3)
+
+				d.Spec.Template.Spec.ImageName = "new-image"
+			})
+
+			It("performs a rolling update", func() {
+				reconcileNormal()
+
+				rss := getReplicaSets()
+				Expect(rss).To(HaveLen(2))
+				Expect(*rss["1"].Spec.Replicas).To(Equal(int32(3)))
+				Expect(*rss["2"].Spec.Replicas).To(Equal(int32(1)), "maxSurge defaults to 1")
+				Expect(rss["2"].Spec.Template.Spec.ImageName).To(Equal("new-image"))
+				Expect(d.Status.Revision).To(Equal(int64(2)))
+
+				By("old replicas are scaled down as new replicas become ready", func() {
+					setReplicaSetStatus(rss["2"], 1)
+					reconcileNormal()
+
+					rss = getReplicaSets()
+					Expect(*rss["1"].Spec.Replicas).To(Equal(int32(2)))
+					Expect(*rss["2"].Spec.Replicas).To(Equal(int32(1)))
+
+					setReplicaSetStatus(rss["1"], 2)
+					reconcileNormal()
+
+					rss = getReplicaSets()
+					Expect(*rss["1"].Spec.Replicas).To(Equal(int32(2)))
+					Expect(*rss["2"].Spec.Replicas).To(Equal(int32(2)))
+				})
+			})
+
+			When("maxUnavailable allows replicas to be unavailable", func() {
+				BeforeEach(func() {
+					d.Spec.Strategy = &vmopv1.VirtualMachineDeploymentStrategy{
+						Type: vmopv1.RollingUpdateVirtualMachineDeploymentStrategyType,
+						RollingUpdate: &vmopv1.VirtualMachineRollingUpdateDeployment{
+							MaxSurge:       ptr.To(intstr.FromInt32(0)),
+							MaxUnavailable: ptr.To(intstr.FromInt32(2)),
+						},
+					}
+				})
+
+				It("scales down old replicas before new replicas are ready", func() {
+					reconcileNormal()
+
+					rss := getReplicaSets()
+					Expect(*rss["1"].Spec.Replicas).To(Equal(int32(1)))
+					Expect(*rss["2"].Spec.Replicas).To(BeZero())
+
+					setReplicaSetStatus(rss["1"], 1)
+					reconcileNormal()
+
+					rss = getReplicaSets()
+					Expect(*rss["2"].Spec.Replicas).To(Equal(int32(2)))
+				})
+			})
+
+			When("the strategy is Recreate", func() {
+				BeforeEach(func() {
+					d.Spec.Strategy = &vmopv1.VirtualMachineDeploymentStrategy{
+						Type: vmopv1.RecreateVirtualMachineDeploymentStrategyType,
+					}
+				})
+
+				It("scales down the old replicas before scaling up the new ones", func() {
+					reconcileNormal()
+
+					rss := getReplicaSets()
+					Expect(*rss["1"].Spec.Replicas).To(BeZero())
+					Expect(*rss["2"].Spec.Replicas).To(BeZero())
+
+					setReplicaSetStatus(rss["1"], 0)
+					reconcileNormal()
+
+					rss = getReplicaSets()
+					Expect(*rss["2"].Spec.Replicas).To(Equal(int32(3)))
+				})
+			})
+
+			When("the deployment is paused", func() {
+				JustBeforeEach(func() {
+					d.Spec.Paused = true
+					d.Spec.Replicas = ptr.To(int32(5))
+				})
+
+				It("does not roll out the template but does scale", func() {
+					reconcileNormal()
+
+					rss := getReplicaSets()
+					Expect(rss).To(HaveLen(1))
+					Expect(*rss["1"].Spec.Replicas).To(Equal(int32(5)))
+					Expect(conditions.GetReason(d, vmopv1.VirtualMachineDeploymentProgressingCondition)).
+						To(Equal(vmopv1.VirtualMachineDeploymentPausedReason))
+				})
+			})
+
+			When("the old replica set has been scaled down", func() {
+				JustBeforeEach(func() {
+					// Complete the rollout with all replicas becoming ready.
+					for i := 0; i < 6; i++ {
+						reconcileNormal()
+						for _, rs := range getReplicaSets() {
+							setReplicaSetStatus(rs, *rs.Spec.Replicas)
+						}
+					}
+					rss := getReplicaSets()
+					Expect(*rss["1"].Spec.Replicas).To(BeZero())
+					Expect(*rss["2"].Spec.Replicas).To(Equal(int32(3)))
+				})
+
+				It("rolls back to the previous revision", func() {
+					d.Spec.RollbackTo = &vmopv1.VirtualMachineDeploymentRollback{}
+					reconcileNormal()
+
+					Expect(d.Spec.RollbackTo).ToNot(BeNil(), "the rollback is made to a copy")
+					Expect(d.Spec.Template.Spec.ImageName).To(Equal("new-image"))
+
+					d = getDeployment()
+					Expect(d.Spec.RollbackTo).To(BeNil())
+					Expect(d.Spec.Template.Spec.ImageName).To(Equal(oldRS.Spec.Template.Spec.ImageName))
+					Expect(d.Spec.Template.Labels).ToNot(HaveKey(vmopv1.VirtualMachineDeploymentUniqueLabel))
+					Expect(d.Spec.Template.Labels).ToNot(HaveKey(vmopv1.VirtualMachineDeploymentNameLabel))
+
+					By("reusing the replica set of the previous revision", func() {
+						reconcileNormal()
+
+						rss := getReplicaSets()
+						Expect(rss).To(HaveLen(2))
+						Expect(rss["3"]).ToNot(BeNil())
+						Expect(rss["3"].Name).To(Equal(oldRS.Name))
+						Expect(*rss["3"].Spec.Replicas).To(Equal(int32(1)))
+						Expect(d.Status.Revision).To(Equal(int64(3)))
+					})
+				})
+
+				It("does not roll back to a revision that does not exist", func() {
+					d.Spec.RollbackTo = &vmopv1.VirtualMachineDeploymentRollback{Revision: 42}
+					reconcileNormal()
+
+					Expect(conditions.GetReason(d, vmopv1.VirtualMachineDeploymentProgressingCondition)).
+						To(Equal(vmopv1.VirtualMachineDeploymentRollbackFailedReason))
+
+					d = getDeployment()
+					Expect(d.Spec.RollbackTo).To(BeNil())
+					Expect(d.Spec.Template.Spec.ImageName).To(Equal("new-image"))
+				})
+
+				It("deletes replica sets beyond the revision history limit", func() {
+					d.Spec.RevisionHistoryLimit = ptr.To(int32(0))
+					reconcileNormal()
+
+					rss := getReplicaSets()
+					Expect(rss).To(HaveLen(1))
+					Expect(rss).To(HaveKey("2"))
+				})
+			})
+		})
+	})
+
+	Context("ReconcileDelete", func() {
+		It("removes the finalizer", func() {
+			Expect(reconciler.ReconcileDelete(&pkgctx.VirtualMachineDeploymentContext{
+				Context:    ctx,
+				Logger:     ctx.Logger.WithName(d.Name),
+				Deployment: d,
+			})).To(Succeed())
+			Expect(d.Finalizers).ToNot(ContainElement(finalizerName))
+		})
+	})
+}
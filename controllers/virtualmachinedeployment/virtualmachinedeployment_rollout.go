@@ -0,0 +1,402 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachinedeployment
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/ptr"
+)
+
+// rolloutRolling gradually scales up the new VirtualMachineReplicaSet and
+// scales down the old ones while honoring maxSurge and maxUnavailable.
+func (r *Reconciler) rolloutRolling(
+	ctx *pkgctx.VirtualMachineDeploymentContext,
+	newRS *vmopv1.VirtualMachineReplicaSet,
+	oldRSs []*vmopv1.VirtualMachineReplicaSet,
+	maxSurge, maxUnavailable int32) error {
+
+	allRSs := append([]*vmopv1.VirtualMachineReplicaSet{newRS}, oldRSs...)
+
+	newReplicas := newReplicaSetReplicas(desiredReplicas(ctx.Deployment), maxSurge, newRS, allRSs)
+	if err := r.scaleReplicaSet(ctx, newRS, newReplicas); err != nil {
+		return err
+	}
+
+	return r.scaleDownOldReplicaSets(ctx, newRS, oldRSs, maxUnavailable)
+}
+
+// rolloutRecreate scales down all of the old VirtualMachineReplicaSets and
+// only scales up the new one once all of the old VirtualMachines are gone.
+func (r *Reconciler) rolloutRecreate(
+	ctx *pkgctx.VirtualMachineDeploymentContext,
+	newRS *vmopv1.VirtualMachineReplicaSet,
+	oldRSs []*vmopv1.VirtualMachineReplicaSet) error {
+
+	for _, rs := range oldRSs {
+		if err := r.scaleReplicaSet(ctx, rs, 0); err != nil {
+			return err
+		}
+	}
+
+	for _, rs := range oldRSs {
+		if rs.Status.Replicas > 0 {
+			// The VirtualMachineDeployment is reconciled again when the status
+			// of the old VirtualMachineReplicaSet changes.
+			ctx.Logger.V(4).Info("Waiting for old VirtualMachines to be deleted",
+				"replicaSet", rs.Name, "replicas", rs.Status.Replicas)
+			return nil
+		}
+	}
+
+	return r.scaleReplicaSet(ctx, newRS, desiredReplicas(ctx.Deployment))
+}
+
+// syncPaused scales the VirtualMachineDeployment while its rollout is paused.
+// The replica set that is receiving replicas is scaled to the desired number
+// of replicas. Nothing is scaled if a rollout was in progress when the
+// VirtualMachineDeployment was paused.
+func (r *Reconciler) syncPaused(
+	ctx *pkgctx.VirtualMachineDeploymentContext,
+	newRS *vmopv1.VirtualMachineReplicaSet,
+	oldRSs []*vmopv1.VirtualMachineReplicaSet) error {
+
+	var active []*vmopv1.VirtualMachineReplicaSet
+	for _, rs := range oldRSs {
+		if replicas(rs) > 0 {
+			active = append(active, rs)
+		}
+	}
+	if newRS != nil && (replicas(newRS) > 0 || len(active) == 0) {
+		active = append(active, newRS)
+	}
+
+	if len(active) != 1 {
+		return nil
+	}
+
+	return r.scaleReplicaSet(ctx, active[0], desiredReplicas(ctx.Deployment))
+}
+
+// scaleDownOldReplicaSets scales down the old VirtualMachineReplicaSets
+// without the number of ready replicas falling below the minimum available.
+func (r *Reconciler) scaleDownOldReplicaSets(
+	ctx *pkgctx.VirtualMachineDeploymentContext,
+	newRS *vmopv1.VirtualMachineReplicaSet,
+	oldRSs []*vmopv1.VirtualMachineReplicaSet,
+	maxUnavailable int32) error {
+
+	if totalReplicas(oldRSs) == 0 {
+		return nil
+	}
+
+	allRSs := append([]*vmopv1.VirtualMachineReplicaSet{newRS}, oldRSs...)
+	minAvailable := desiredReplicas(ctx.Deployment) - maxUnavailable
+	newRSUnavailable := max(replicas(newRS)-ctx.ReadyReplicas[newRS.Name], 0)
+
+	// The replicas of the old replica sets that are not ready can be scaled
+	// down first since doing so does not reduce availability. Do not scale
+	// down more replicas than can be tolerated while the new replica set's
+	// replicas are not yet ready.
+	maxCleanup := totalReplicas(allRSs) - minAvailable - newRSUnavailable
+	if maxCleanup <= 0 {
+		return nil
+	}
+
+	sortReplicaSetsOldestFirst(oldRSs)
+
+	var cleanedUp int32
+	for _, rs := range oldRSs {
+		if cleanedUp >= maxCleanup {
+			break
+		}
+		unhealthy := replicas(rs) - ctx.ReadyReplicas[rs.Name]
+		if unhealthy <= 0 {
+			continue
+		}
+		scaleDown := min(maxCleanup-cleanedUp, unhealthy)
+		if err := r.scaleReplicaSet(ctx, rs, replicas(rs)-scaleDown); err != nil {
+			return err
+		}
+		cleanedUp += scaleDown
+	}
+
+	// Scale down the ready replicas of the old replica sets as long as the
+	// number of ready replicas remains at or above the minimum available.
+	maxScaleDown := totalReadyReplicas(ctx, allRSs) - minAvailable
+	var scaledDown int32
+	for _, rs := range oldRSs {
+		if scaledDown >= maxScaleDown {
+			break
+		}
+		if replicas(rs) == 0 {
+			continue
+		}
+		scaleDown := min(maxScaleDown-scaledDown, replicas(rs))
+		if err := r.scaleReplicaSet(ctx, rs, replicas(rs)-scaleDown); err != nil {
+			return err
+		}
+		scaledDown += scaleDown
+	}
+
+	return nil
+}
+
+// cleanupReplicaSets deletes the old VirtualMachineReplicaSets that have been
+// scaled down beyond the revision history limit, oldest revisions first.
+func (r *Reconciler) cleanupReplicaSets(
+	ctx *pkgctx.VirtualMachineDeploymentContext,
+	oldRSs []*vmopv1.VirtualMachineReplicaSet) error {
+
+	limit := int32(1)
+	if ctx.Deployment.Spec.RevisionHistoryLimit != nil {
+		limit = *ctx.Deployment.Spec.RevisionHistoryLimit
+	}
+
+	var candidates []*vmopv1.VirtualMachineReplicaSet
+	for _, rs := range oldRSs {
+		if replicas(rs) == 0 && rs.Status.Replicas == 0 && rs.DeletionTimestamp.IsZero() {
+			candidates = append(candidates, rs)
+		}
+	}
+
+	diff := len(candidates) - int(limit)
+	if diff <= 0 {
+		return nil
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return getRevision(candidates[i]) < getRevision(candidates[j])
+	})
+
+	for _, rs := range candidates[:diff] {
+		ctx.Logger.Info("Deleting old VirtualMachineReplicaSet", "replicaSet", rs.Name, "revision", getRevision(rs))
+		if err := r.Client.Delete(ctx, rs); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete VirtualMachineReplicaSet %q: %w", rs.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// scaleReplicaSet updates the number of replicas of the provided
+// VirtualMachineReplicaSet.
+func (r *Reconciler) scaleReplicaSet(
+	ctx *pkgctx.VirtualMachineDeploymentContext,
+	rs *vmopv1.VirtualMachineReplicaSet,
+	newReplicas int32) error {
+
+	oldReplicas := replicas(rs)
+	if oldReplicas == newReplicas {
+		return nil
+	}
+
+	rsPatch := client.MergeFrom(rs.DeepCopy())
+	rs.Spec.Replicas = ptr.To(newReplicas)
+	if err := r.Client.Patch(ctx, rs, rsPatch); err != nil {
+		r.Recorder.Warnf(ctx.Deployment, "FailedScale",
+			"Failed to scale VirtualMachineReplicaSet %q to %d: %v", rs.Name, newReplicas, err)
+		return fmt.Errorf("failed to scale VirtualMachineReplicaSet %q to %d: %w", rs.Name, newReplicas, err)
+	}
+
+	direction := "up"
+	if newReplicas < oldReplicas {
+		direction = "down"
+	}
+	ctx.Logger.Info("Scaled VirtualMachineReplicaSet",
+		"replicaSet", rs.Name, "oldReplicas", oldReplicas, "newReplicas", newReplicas)
+	r.Recorder.Eventf(ctx.Deployment, "ScalingReplicaSet",
+		"Scaled %s VirtualMachineReplicaSet %q from %d to %d", direction, rs.Name, oldReplicas, newReplicas)
+
+	return nil
+}
+
+// newReplicaSetReplicas returns the number of replicas the new
+// VirtualMachineReplicaSet should have without exceeding maxSurge.
+func newReplicaSetReplicas(
+	desired, maxSurge int32,
+	newRS *vmopv1.VirtualMachineReplicaSet,
+	allRSs []*vmopv1.VirtualMachineReplicaSet) int32 {
+
+	current := replicas(newRS)
+	if current >= desired {
+		return desired
+	}
+
+	scaleUp := min(desired+maxSurge-totalReplicas(allRSs), desired-current)
+	if scaleUp <= 0 {
+		return current
+	}
+
+	return current + scaleUp
+}
+
+// resolveFenceposts returns the absolute values of maxSurge and
+// maxUnavailable for the VirtualMachineDeployment. Both values are zero for
+// the Recreate strategy.
+func resolveFenceposts(d *vmopv1.VirtualMachineDeployment) (int32, int32, error) {
+	if strategyType(d) == vmopv1.RecreateVirtualMachineDeploymentStrategyType {
+		return 0, 0, nil
+	}
+
+	maxSurge := intstr.FromInt32(1)
+	maxUnavailable := intstr.FromInt32(0)
+	if s := d.Spec.Strategy; s != nil && s.RollingUpdate != nil {
+		if s.RollingUpdate.MaxSurge != nil {
+			maxSurge = *s.RollingUpdate.MaxSurge
+		}
+		if s.RollingUpdate.MaxUnavailable != nil {
+			maxUnavailable = *s.RollingUpdate.MaxUnavailable
+		}
+	}
+
+	desired := int(desiredReplicas(d))
+
+	surge, err := intstr.GetScaledValueFromIntOrPercent(&maxSurge, desired, true)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid maxSurge: %w", err)
+	}
+	unavailable, err := intstr.GetScaledValueFromIntOrPercent(&maxUnavailable, desired, false)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid maxUnavailable: %w", err)
+	}
+
+	// The rollout cannot make progress if both values are zero.
+	if surge == 0 && unavailable == 0 {
+		unavailable = 1
+	}
+
+	return int32(surge), int32(unavailable), nil //nolint:gosec
+}
+
+// splitReplicaSets returns the VirtualMachineReplicaSet for the provided
+// template and its hash, if any, and the remaining old
+// VirtualMachineReplicaSets. A replica set with the hash of a different
+// template is an old replica set.
+func splitReplicaSets(
+	rsList []*vmopv1.VirtualMachineReplicaSet,
+	hash string,
+	template *vmopv1.VirtualMachineTemplateSpec) (*vmopv1.VirtualMachineReplicaSet, []*vmopv1.VirtualMachineReplicaSet) {
+
+	var (
+		newRS  *vmopv1.VirtualMachineReplicaSet
+		oldRSs []*vmopv1.VirtualMachineReplicaSet
+	)
+
+	for _, rs := range rsList {
+		if newRS == nil &&
+			rs.Labels[vmopv1.VirtualMachineDeploymentUniqueLabel] == hash &&
+			equalIgnoreHash(template, &rs.Spec.Template) {
+
+			newRS = rs
+			continue
+		}
+		oldRSs = append(oldRSs, rs)
+	}
+
+	return newRS, oldRSs
+}
+
+// equalIgnoreHash returns true if the provided templates are equal, ignoring
+// the labels added to the template of a VirtualMachineReplicaSet by the
+// VirtualMachineDeployment.
+func equalIgnoreHash(template1, template2 *vmopv1.VirtualMachineTemplateSpec) bool {
+	t1, t2 := template1.DeepCopy(), template2.DeepCopy()
+	for _, t := range []*vmopv1.VirtualMachineTemplateSpec{t1, t2} {
+		delete(t.Labels, vmopv1.VirtualMachineDeploymentUniqueLabel)
+		delete(t.Labels, vmopv1.VirtualMachineDeploymentNameLabel)
+		if len(t.Labels) == 0 {
+			t.Labels = nil
+		}
+	}
+	return apiequality.Semantic.DeepEqual(t1, t2)
+}
+
+func sortReplicaSetsOldestFirst(rsList []*vmopv1.VirtualMachineReplicaSet) {
+	sort.SliceStable(rsList, func(i, j int) bool {
+		ri, rj := getRevision(rsList[i]), getRevision(rsList[j])
+		if ri == rj {
+			return rsList[i].Name < rsList[j].Name
+		}
+		return ri < rj
+	})
+}
+
+// getRevision returns the revision of the VirtualMachineReplicaSet, or zero
+// if the revision is unknown.
+func getRevision(rs *vmopv1.VirtualMachineReplicaSet) int64 {
+	v, err := strconv.ParseInt(rs.Annotations[vmopv1.VirtualMachineDeploymentRevisionAnnotation], 10, 64)
+	if err != nil {
+		return 0
+	}
+	return v
+}
+
+func maxRevision(rsList []*vmopv1.VirtualMachineReplicaSet) int64 {
+	var m int64
+	for _, rs := range rsList {
+		m = max(m, getRevision(rs))
+	}
+	return m
+}
+
+// previousRevision returns the second highest revision of the provided
+// VirtualMachineReplicaSets, or zero if there is no previous revision.
+func previousRevision(rsList []*vmopv1.VirtualMachineReplicaSet) int64 {
+	var cur, prev int64
+	for _, rs := range rsList {
+		switch v := getRevision(rs); {
+		case v > cur:
+			prev, cur = cur, v
+		case v < cur && v > prev:
+			prev = v
+		}
+	}
+	return prev
+}
+
+func strategyType(d *vmopv1.VirtualMachineDeployment) vmopv1.VirtualMachineDeploymentStrategyType {
+	if d.Spec.Strategy == nil || d.Spec.Strategy.Type == "" {
+		return vmopv1.RollingUpdateVirtualMachineDeploymentStrategyType
+	}
+	return d.Spec.Strategy.Type
+}
+
+// desiredReplicas returns the desired number of replicas of the
+// VirtualMachineDeployment.
+func desiredReplicas(d *vmopv1.VirtualMachineDeployment) int32 {
+	return ptr.Deref(d.Spec.Replicas)
+}
+
+func replicas(rs *vmopv1.VirtualMachineReplicaSet) int32 {
+	return ptr.Deref(rs.Spec.Replicas)
+}
+
+func totalReplicas(rsList []*vmopv1.VirtualMachineReplicaSet) int32 {
+	var total int32
+	for _, rs := range rsList {
+		total += replicas(rs)
+	}
+	return total
+}
+
+func totalReadyReplicas(
+	ctx *pkgctx.VirtualMachineDeploymentContext,
+	rsList []*vmopv1.VirtualMachineReplicaSet) int32 {
+
+	var total int32
+	for _, rs := range rsList {
+		total += ctx.ReadyReplicas[rs.Name]
+	}
+	return total
+}
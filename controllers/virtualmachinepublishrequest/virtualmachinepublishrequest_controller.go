@@ -146,6 +146,8 @@ func NewReconciler(
 		Recorder:   recorder,
 		VMProvider: vmProvider,
 		Metrics:    metrics.NewVMPublishMetrics(),
+
+		ociPublishes: newOCIPublishTracker(),
 	}
 }
 
@@ -158,6 +160,8 @@ type Reconciler struct {
 	Recorder   record.Recorder
 	VMProvider providers.VirtualMachineProviderInterface
 	Metrics    *metrics.VMPublishMetrics
+
+	ociPublishes *ociPublishTracker
 }
 
 func requeueResult(ctx *pkgctx.VirtualMachinePublishRequestContext) ctrl.Result {
@@ -196,6 +200,7 @@ func requeueResult(ctx *pkgctx.VirtualMachinePublishRequestContext) ctrl.Result
 // +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachines,verbs=get;list
 // +kubebuilder:rbac:groups=imageregistry.vmware.com,resources=contentlibraries,verbs=get;list;watch
 // +kubebuilder:rbac:groups=imageregistry.vmware.com,resources=contentlibraries/status,verbs=get;
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get
 
 func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
 	ctx = pkgcfg.JoinContext(ctx, r.Context)
@@ -313,6 +318,25 @@ func (r *Reconciler) publishVirtualMachine(ctx *pkgctx.VirtualMachinePublishRequ
 			return err
 		}
 
+		if isOCIRegistryTarget(vmPublishReq) {
+			// Track the push before starting it so the next reconcile does
+			// not publish the VM again.
+			vmPub, actID := vmPublishReq.DeepCopy(), getPublishRequestActID(vmPublishReq)
+			r.ociPublishes.start(vmPub.UID, actID)
+
+			go func() {
+				digest, pubErr := r.publishVirtualMachineToOCI(ctx, vmPub, actID)
+				r.ociPublishes.finish(vmPub.UID, actID, digest, pubErr)
+				if pubErr != nil {
+					ctx.Logger.Error(pubErr, "failed to push VM to OCI registry")
+				} else {
+					ctx.Logger.Info("pushed VM to OCI registry", "digest", digest)
+				}
+				r.Recorder.EmitEvent(vmPub, "Publish", pubErr, false)
+			}()
+			return nil
+		}
+
 		go func() {
 			actID := getPublishRequestActID(vmPublishReq)
 			itemID, pubErr := r.VMProvider.PublishVirtualMachine(ctx, ctx.VM, vmPublishReq, ctx.ContentLibrary, actID)
@@ -394,6 +418,10 @@ func (r *Reconciler) checkIsSourceValid(ctx *pkgctx.VirtualMachinePublishRequest
 // It is invalid if the content library doesn't exist, an item with the same name in the CL exists.
 func (r *Reconciler) checkIsTargetValid(ctx *pkgctx.VirtualMachinePublishRequestContext) error {
 	vmPubReq := ctx.VMPublishRequest
+	if isOCIRegistryTarget(vmPubReq) {
+		return r.checkIsOCITargetValid(ctx)
+	}
+
 	contentLibrary := &imgregv1a1.ContentLibrary{}
 	targetLocationName := vmPubReq.Spec.Target.Location.Name
 	targetItemName := vmPubReq.Status.TargetRef.Item.Name
@@ -485,6 +513,10 @@ func (r *Reconciler) checkIsImageAvailable(ctx *pkgctx.VirtualMachinePublishRequ
 		return nil
 	}
 
+	if isOCIRegistryTarget(ctx.VMPublishRequest) {
+		return r.checkIsOCIArtifactAvailable(ctx)
+	}
+
 	if ctx.ItemID == "" {
 		id, err := r.getUploadedItemID(ctx)
 		if err != nil {
@@ -600,6 +632,10 @@ func (r *Reconciler) checkPubReqStatusAndShouldRepublish(ctx *pkgctx.VirtualMach
 		return false, nil
 	}
 
+	if isOCIRegistryTarget(ctx.VMPublishRequest) {
+		return r.checkOCIPublishStatusAndShouldRepublish(ctx)
+	}
+
 	actID := getPublishRequestActID(ctx.VMPublishRequest)
 	logger := ctx.Logger.WithValues("actID", actID, "descriptionID", TaskDescriptionID)
 
@@ -778,11 +814,17 @@ func (r *Reconciler) findCorrelatedItemIDByName(ctx *pkgctx.VirtualMachinePublis
 }
 
 // updatePublishedItemDescription updates item description, which removes vmPub UUID from it.
+// The annotations of an OCI artifact cannot be updated without changing its digest, so the
+// vmPub UID remains on the artifact.
 func (r *Reconciler) updatePublishedItemDescription(ctx *pkgctx.VirtualMachinePublishRequestContext) error {
 	if !conditions.IsTrue(ctx.VMPublishRequest, vmopv1.VirtualMachinePublishRequestConditionImageAvailable) {
 		return nil
 	}
 
+	if isOCIRegistryTarget(ctx.VMPublishRequest) {
+		return nil
+	}
+
 	if ctx.ItemID == "" {
 		id, err := r.getUploadedItemID(ctx)
 		if err != nil {
@@ -922,6 +964,7 @@ func (r *Reconciler) ReconcileDelete(ctx *pkgctx.VirtualMachinePublishRequestCon
 	if controllerutil.ContainsFinalizer(ctx.VMPublishRequest, finalizerName) ||
 		controllerutil.ContainsFinalizer(ctx.VMPublishRequest, deprecatedFinalizerName) {
 		r.Metrics.DeleteMetrics(ctx.Logger, ctx.VMPublishRequest.Name, ctx.VMPublishRequest.Namespace)
+		r.ociPublishes.delete(ctx.VMPublishRequest.UID)
 		controllerutil.RemoveFinalizer(ctx.VMPublishRequest, finalizerName)
 		controllerutil.RemoveFinalizer(ctx.VMPublishRequest, deprecatedFinalizerName)
 	}
@@ -5,8 +5,12 @@
 package virtualmachinepublishrequest_test
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"strings"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -28,7 +32,10 @@ import (
 	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
 	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
 	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers"
 	providerfake "github.com/vmware-tanzu/vm-operator/pkg/providers/fake"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/oci"
+	ocifake "github.com/vmware-tanzu/vm-operator/pkg/util/oci/fake"
 	"github.com/vmware-tanzu/vm-operator/test/builder"
 )
 
@@ -678,5 +685,240 @@ func unitTestsReconcile() {
 				})
 			})
 		})
+
+		Context("Target is an OCI registry", func() {
+			const (
+				repositoryName = "golden/dummy-vm"
+				itemName       = "dummy-item"
+			)
+
+			var (
+				registry *ocifake.Registry
+				secret   *corev1.Secret
+			)
+
+			BeforeEach(func() {
+				registry = ocifake.NewRegistryWithBasicAuth("user", "pass")
+				secret = &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "registry-creds",
+						Namespace: vm.Namespace,
+					},
+					Type: corev1.SecretTypeBasicAuth,
+					Data: map[string][]byte{
+						corev1.BasicAuthUsernameKey: []byte("user"),
+						corev1.BasicAuthPasswordKey: []byte("pass"),
+					},
+				}
+
+				vmpub.UID = "dummy-vmpub-uid"
+				vmpub.Spec.Target.Location = vmopv1.VirtualMachinePublishRequestTargetLocation{
+					APIVersion: vmopv1.GroupVersion.String(),
+					Kind:       vmopv1.VirtualMachinePublishRequestTargetLocationKindOCIRegistry,
+					OCIRegistry: &vmopv1.VirtualMachinePublishRequestTargetOCIRegistry{
+						Repository: registry.Host() + "/" + repositoryName,
+						SecretName: secret.Name,
+						Insecure:   true,
+					},
+				}
+				initObjects = append(initObjects, secret)
+			})
+
+			AfterEach(func() {
+				registry.Close()
+			})
+
+			pushManifest := func(annotations map[string]string) string {
+				ociClient := oci.NewClient(registry.Host(), oci.ClientOptions{
+					Username: "user",
+					Password: "pass",
+					Insecure: true,
+				})
+				_, _, err := ociClient.PushBlob(ctx, repositoryName, bytes.NewReader(oci.EmptyJSON))
+				Expect(err).ToNot(HaveOccurred())
+
+				digest, err := ociClient.PushManifest(ctx, repositoryName, itemName, &oci.Manifest{
+					SchemaVersion: 2,
+					MediaType:     oci.MediaTypeImageManifest,
+					Config:        oci.EmptyJSONDescriptor,
+					Layers:        []oci.Descriptor{},
+					Annotations:   annotations,
+				})
+				Expect(err).ToNot(HaveOccurred())
+				return digest
+			}
+
+			reconcileUntilComplete := func() {
+				Eventually(func(g Gomega) {
+					_, err := reconciler.ReconcileNormal(vmpubCtx)
+					g.Expect(err).ToNot(HaveOccurred())
+					g.Expect(conditions.IsTrue(vmpub,
+						vmopv1.VirtualMachinePublishRequestConditionComplete)).To(BeTrue())
+				}).Should(Succeed())
+			}
+
+			It("pushes the VM as an OCI artifact and completes once the artifact is available", func() {
+				_, err := reconciler.ReconcileNormal(vmpubCtx)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(conditions.IsTrue(vmpub,
+					vmopv1.VirtualMachinePublishRequestConditionTargetValid)).To(BeTrue())
+				Expect(vmpub.Status.Attempts).To(BeEquivalentTo(1))
+
+				reconcileUntilComplete()
+				Expect(fakeVMProvider.IsExportVMCalled()).To(BeTrue())
+				Expect(fakeVMProvider.IsPublishVMCalled()).To(BeFalse())
+				Expect(conditions.IsTrue(vmpub,
+					vmopv1.VirtualMachinePublishRequestConditionUploaded)).To(BeTrue())
+				Expect(conditions.IsTrue(vmpub,
+					vmopv1.VirtualMachinePublishRequestConditionImageAvailable)).To(BeTrue())
+				Expect(vmpub.Status.Ready).To(BeTrue())
+
+				data, ok := registry.Manifest(repositoryName, itemName)
+				Expect(ok).To(BeTrue())
+				Expect(vmpub.Status.ImageDigest).To(Equal(oci.Digest(data)))
+
+				manifest := &oci.Manifest{}
+				Expect(json.Unmarshal(data, manifest)).To(Succeed())
+				Expect(manifest.ArtifactType).To(Equal(virtualmachinepublishrequest.OCIArtifactType))
+				Expect(manifest.Annotations).To(HaveKeyWithValue(
+					vmopv1.VirtualMachinePublishRequestUIDAnnotation, string(vmpub.UID)))
+				Expect(manifest.Layers).To(HaveLen(2))
+				Expect(manifest.Layers[0].MediaType).To(Equal(virtualmachinepublishrequest.OCIDiskMediaType))
+				Expect(manifest.Layers[0].Annotations).To(HaveKeyWithValue(oci.AnnotationTitle, itemName+"-disk-0.vmdk"))
+				Expect(manifest.Layers[1].MediaType).To(Equal(virtualmachinepublishrequest.OCIDescriptorMediaType))
+				Expect(manifest.Layers[1].Annotations).To(HaveKeyWithValue(oci.AnnotationTitle, itemName+".ovf"))
+
+				disk, ok := registry.Blob(manifest.Layers[0].Digest)
+				Expect(ok).To(BeTrue())
+				Expect(string(disk)).To(Equal("dummy-disk"))
+			})
+
+			When("the push is in progress", func() {
+				var release chan struct{}
+
+				JustBeforeEach(func() {
+					release = make(chan struct{})
+					fakeVMProvider.Lock()
+					fakeVMProvider.ExportVirtualMachineFn = func(ctx context.Context, vm *vmopv1.VirtualMachine,
+						name string, writeFileFn providers.ExportFileFn) error {
+						if err := writeFileFn(name+"-disk-0.vmdk", strings.NewReader("dummy-disk")); err != nil {
+							return err
+						}
+						<-release
+						return writeFileFn(name+".ovf", strings.NewReader("<Envelope/>"))
+					}
+					fakeVMProvider.Unlock()
+				})
+
+				It("reports the upload progress and does not publish again", func() {
+					_, err := reconciler.ReconcileNormal(vmpubCtx)
+					Expect(err).ToNot(HaveOccurred())
+
+					Eventually(func(g Gomega) {
+						_, err := reconciler.ReconcileNormal(vmpubCtx)
+						g.Expect(err).ToNot(HaveOccurred())
+
+						uploadCondition := conditions.Get(vmpub, vmopv1.VirtualMachinePublishRequestConditionUploaded)
+						g.Expect(uploadCondition).ToNot(BeNil())
+						g.Expect(uploadCondition.Reason).To(Equal(vmopv1.UploadingReason))
+						g.Expect(uploadCondition.Message).To(Equal("Uploaded 10 to the OCI registry."))
+					}).Should(Succeed())
+					Expect(vmpub.Status.Attempts).To(BeEquivalentTo(1))
+
+					close(release)
+					reconcileUntilComplete()
+					Expect(vmpub.Status.Attempts).To(BeEquivalentTo(1))
+				})
+			})
+
+			When("the push fails", func() {
+				JustBeforeEach(func() {
+					fakeVMProvider.Lock()
+					fakeVMProvider.ExportVirtualMachineFn = func(ctx context.Context, vm *vmopv1.VirtualMachine,
+						name string, writeFileFn providers.ExportFileFn) error {
+						return writeFileFn(name+".ovf", io.MultiReader(strings.NewReader("<Env"), iotestErrReader{}))
+					}
+					fakeVMProvider.Unlock()
+				})
+
+				It("marks Uploaded false and publishes again", func() {
+					_, err := reconciler.ReconcileNormal(vmpubCtx)
+					Expect(err).ToNot(HaveOccurred())
+
+					Eventually(func(g Gomega) {
+						_, err := reconciler.ReconcileNormal(vmpubCtx)
+						g.Expect(err).ToNot(HaveOccurred())
+						g.Expect(conditions.GetReason(vmpub,
+							vmopv1.VirtualMachinePublishRequestConditionUploaded)).To(Equal(vmopv1.UploadFailureReason))
+					}).Should(Succeed())
+					Expect(conditions.GetMessage(vmpub,
+						vmopv1.VirtualMachinePublishRequestConditionUploaded)).To(ContainSubstring("dummy read error"))
+					Expect(vmpub.Status.Attempts).To(BeEquivalentTo(2))
+				})
+			})
+
+			When("an artifact with the same tag already exists", func() {
+				JustBeforeEach(func() {
+					pushManifest(nil)
+				})
+
+				It("marks TargetValid false and does not publish", func() {
+					_, err := reconciler.ReconcileNormal(vmpubCtx)
+					Expect(err).ToNot(HaveOccurred())
+
+					Expect(conditions.GetReason(vmpub,
+						vmopv1.VirtualMachinePublishRequestConditionTargetValid)).To(Equal(vmopv1.TargetItemAlreadyExistsReason))
+					Expect(fakeVMProvider.IsExportVMCalled()).To(BeFalse())
+				})
+			})
+
+			When("a prior push succeeded but lost track of it", func() {
+				var digest string
+
+				BeforeEach(func() {
+					vmpub.Status.Attempts = 1
+					vmpub.Status.LastAttemptTime = metav1.NewTime(time.Now().Add(-time.Minute))
+				})
+
+				JustBeforeEach(func() {
+					digest = pushManifest(map[string]string{
+						vmopv1.VirtualMachinePublishRequestUIDAnnotation: string(vmpub.UID),
+					})
+				})
+
+				It("marks Uploaded true without publishing again", func() {
+					_, err := reconciler.ReconcileNormal(vmpubCtx)
+					Expect(err).ToNot(HaveOccurred())
+
+					Expect(fakeVMProvider.IsExportVMCalled()).To(BeFalse())
+					Expect(conditions.IsTrue(vmpub,
+						vmopv1.VirtualMachinePublishRequestConditionUploaded)).To(BeTrue())
+					Expect(conditions.IsTrue(vmpub,
+						vmopv1.VirtualMachinePublishRequestConditionComplete)).To(BeTrue())
+					Expect(vmpub.Status.ImageDigest).To(Equal(digest))
+				})
+			})
+
+			When("the registry secret does not exist", func() {
+				BeforeEach(func() {
+					initObjects = []client.Object{vm, vmpub}
+				})
+
+				It("returns error and marks TargetValid false", func() {
+					_, err := reconciler.ReconcileNormal(vmpubCtx)
+					Expect(err).To(HaveOccurred())
+
+					Expect(conditions.GetReason(vmpub,
+						vmopv1.VirtualMachinePublishRequestConditionTargetValid)).To(Equal(vmopv1.TargetOCIRegistrySecretNotExistReason))
+				})
+			})
+		})
 	})
 }
+
+// iotestErrReader is a reader that always fails.
+type iotestErrReader struct{}
+
+func (iotestErrReader) Read([]byte) (int, error) {
+	return 0, fmt.Errorf("dummy read error")
+}
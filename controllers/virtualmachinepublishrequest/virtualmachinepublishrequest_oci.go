@@ -0,0 +1,385 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachinepublishrequest
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/oci"
+)
+
+const (
+	// OCIArtifactType is the artifact type of a VM published to an OCI
+	// registry. Each file of the VM's OVF is a layer of the artifact, with
+	// the file name in the layer's org.opencontainers.image.title annotation.
+	OCIArtifactType = "application/vnd.vmware.vmoperator.ovf.v1"
+
+	// OCIDescriptorMediaType is the media type of the layer that contains the
+	// OVF descriptor of a VM published to an OCI registry.
+	OCIDescriptorMediaType = "application/vnd.vmware.vmoperator.ovf.descriptor.v1+xml"
+
+	// OCIDiskMediaType is the media type of the layers that contain the disks
+	// of a VM published to an OCI registry.
+	OCIDiskMediaType = "application/vnd.vmware.vmoperator.ovf.disk.v1.vmdk"
+)
+
+// isOCIRegistryTarget returns true if the VM is published to an OCI registry
+// instead of a content library.
+func isOCIRegistryTarget(vmPub *vmopv1.VirtualMachinePublishRequest) bool {
+	return vmPub.Spec.Target.Location.Kind == vmopv1.VirtualMachinePublishRequestTargetLocationKindOCIRegistry
+}
+
+// ociPublish is the state of a VM being pushed to an OCI registry.
+type ociPublish struct {
+	actID    string
+	done     bool
+	uploaded int64
+	digest   string
+	err      error
+}
+
+// ociPublishTracker tracks the VMs being pushed to an OCI registry. Unlike
+// publishing to a content library, there is no vCenter task to query for
+// the progress of a push, so it is tracked in memory by the goroutine that
+// pushes the VM.
+type ociPublishTracker struct {
+	mu        sync.Mutex
+	publishes map[types.UID]*ociPublish
+}
+
+func newOCIPublishTracker() *ociPublishTracker {
+	return &ociPublishTracker{
+		publishes: map[types.UID]*ociPublish{},
+	}
+}
+
+func (t *ociPublishTracker) start(uid types.UID, actID string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.publishes[uid] = &ociPublish{actID: actID}
+}
+
+// get returns the state of the push with the activation ID.
+func (t *ociPublishTracker) get(uid types.UID, actID string) (ociPublish, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if p, ok := t.publishes[uid]; ok && p.actID == actID {
+		return *p, true
+	}
+	return ociPublish{}, false
+}
+
+func (t *ociPublishTracker) addUploaded(uid types.UID, actID string, n int64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if p, ok := t.publishes[uid]; ok && p.actID == actID {
+		p.uploaded += n
+	}
+}
+
+func (t *ociPublishTracker) finish(uid types.UID, actID, digest string, err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if p, ok := t.publishes[uid]; ok && p.actID == actID {
+		p.done, p.digest, p.err = true, digest, err
+	}
+}
+
+func (t *ociPublishTracker) delete(uid types.UID) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	delete(t.publishes, uid)
+}
+
+// uploadProgressReader reports the number of bytes read from the underlying
+// reader to the tracker.
+type uploadProgressReader struct {
+	r       io.Reader
+	tracker *ociPublishTracker
+	uid     types.UID
+	actID   string
+}
+
+func (r *uploadProgressReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.tracker.addUploaded(r.uid, r.actID, int64(n))
+	return n, err
+}
+
+// newOCIClient returns a client for the registry with the credentials from
+// the registry's Secret, and the name of the target repository.
+func (r *Reconciler) newOCIClient(
+	ctx *pkgctx.VirtualMachinePublishRequestContext,
+	registry *vmopv1.VirtualMachinePublishRequestTargetOCIRegistry) (*oci.Client, string, error) {
+
+	host, name, err := oci.ParseRepository(registry.Repository)
+	if err != nil {
+		return nil, "", err
+	}
+
+	opts := oci.ClientOptions{
+		Insecure: registry.Insecure,
+	}
+
+	if registry.SecretName != "" {
+		secret := &corev1.Secret{}
+		objKey := client.ObjectKey{Name: registry.SecretName, Namespace: ctx.VMPublishRequest.Namespace}
+		if err := r.Get(ctx, objKey, secret); err != nil {
+			return nil, "", err
+		}
+
+		if opts.Username, opts.Password, err = oci.CredentialsFromSecret(secret, host); err != nil {
+			return nil, "", err
+		}
+	}
+
+	return oci.NewClient(host, opts), name, nil
+}
+
+// checkIsOCITargetValid checks if the target OCI registry is valid. It is
+// invalid if the registry's Secret doesn't exist, the registry cannot be
+// accessed, or an artifact with the target tag already exists.
+func (r *Reconciler) checkIsOCITargetValid(ctx *pkgctx.VirtualMachinePublishRequestContext) error {
+	vmPubReq := ctx.VMPublishRequest
+	registry := vmPubReq.Spec.Target.Location.OCIRegistry
+	if registry == nil {
+		err := errors.New("target location has no OCI registry")
+		conditions.MarkFalse(vmPubReq,
+			vmopv1.VirtualMachinePublishRequestConditionTargetValid,
+			vmopv1.TargetOCIRegistryNotReadyReason,
+			err.Error())
+		return err
+	}
+
+	ociClient, name, err := r.newOCIClient(ctx, registry)
+	if err != nil {
+		ctx.Logger.Error(err, "failed to create OCI registry client", "repository", registry.Repository)
+		reason := vmopv1.TargetOCIRegistryNotReadyReason
+		if apierrors.IsNotFound(err) {
+			reason = vmopv1.TargetOCIRegistrySecretNotExistReason
+		}
+		conditions.MarkFalse(vmPubReq,
+			vmopv1.VirtualMachinePublishRequestConditionTargetValid,
+			reason,
+			err.Error())
+		return err
+	}
+
+	targetItemName := vmPubReq.Status.TargetRef.Item.Name
+	manifest, digest, err := ociClient.GetManifest(ctx, name, targetItemName)
+	if err != nil && !errors.Is(err, oci.ErrNotFound) {
+		ctx.Logger.Error(err, "failed to get OCI artifact", "repository", registry.Repository, "tag", targetItemName)
+		conditions.MarkFalse(vmPubReq,
+			vmopv1.VirtualMachinePublishRequestConditionTargetValid,
+			vmopv1.TargetOCIRegistryNotReadyReason,
+			err.Error())
+		return err
+	}
+
+	if manifest != nil {
+		ctx.Logger.Info("target artifact already exists in the OCI registry",
+			"repository", registry.Repository, "tag", targetItemName)
+		// If the artifact was pushed by a prior attempt of this
+		// VirtualMachinePublishRequest, ex. the controller restarted before
+		// the result of the push was recorded, then the VM is uploaded.
+		if vmPubReq.Status.Attempts > 0 &&
+			manifest.Annotations[vmopv1.VirtualMachinePublishRequestUIDAnnotation] == string(vmPubReq.UID) {
+
+			ctx.Logger.Info("existing target artifact is published by this VMPubReq")
+			conditions.MarkTrue(vmPubReq, vmopv1.VirtualMachinePublishRequestConditionTargetValid)
+			conditions.MarkTrue(vmPubReq, vmopv1.VirtualMachinePublishRequestConditionUploaded)
+			vmPubReq.Status.ImageDigest = digest
+			return nil
+		}
+
+		conditions.MarkFalse(vmPubReq,
+			vmopv1.VirtualMachinePublishRequestConditionTargetValid,
+			vmopv1.TargetItemAlreadyExistsReason,
+			fmt.Sprintf("artifact with tag %s already exists in the OCI repository %s", targetItemName,
+				registry.Repository))
+		return nil
+	}
+
+	conditions.MarkTrue(vmPubReq, vmopv1.VirtualMachinePublishRequestConditionTargetValid)
+	return nil
+}
+
+// publishVirtualMachineToOCI exports the VM as an OVF and pushes each of its
+// files as a layer of an OCI artifact. The manifest is pushed last, so the
+// tag only exists once all of the layers have been uploaded.
+func (r *Reconciler) publishVirtualMachineToOCI(
+	ctx *pkgctx.VirtualMachinePublishRequestContext,
+	vmPubReq *vmopv1.VirtualMachinePublishRequest,
+	actID string) (string, error) {
+
+	ociClient, name, err := r.newOCIClient(ctx, vmPubReq.Spec.Target.Location.OCIRegistry)
+	if err != nil {
+		return "", err
+	}
+
+	targetItemName := vmPubReq.Status.TargetRef.Item.Name
+
+	var layers []oci.Descriptor
+	err = r.VMProvider.ExportVirtualMachine(ctx, ctx.VM, targetItemName, func(fileName string, f io.Reader) error {
+		digest, size, err := ociClient.PushBlob(ctx, name, &uploadProgressReader{
+			r:       f,
+			tracker: r.ociPublishes,
+			uid:     vmPubReq.UID,
+			actID:   actID,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to push %s: %w", fileName, err)
+		}
+
+		mediaType := OCIDiskMediaType
+		if path.Ext(fileName) == ".ovf" {
+			mediaType = OCIDescriptorMediaType
+		}
+
+		layers = append(layers, oci.Descriptor{
+			MediaType: mediaType,
+			Digest:    digest,
+			Size:      size,
+			Annotations: map[string]string{
+				oci.AnnotationTitle: fileName,
+			},
+		})
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if _, _, err := ociClient.PushBlob(ctx, name, bytes.NewReader(oci.EmptyJSON)); err != nil {
+		return "", fmt.Errorf("failed to push config: %w", err)
+	}
+
+	annotations := map[string]string{
+		oci.AnnotationCreated:                            time.Now().UTC().Format(time.RFC3339),
+		vmopv1.VirtualMachinePublishRequestUIDAnnotation: string(vmPubReq.UID),
+	}
+	if description := vmPubReq.Status.TargetRef.Item.Description; description != "" {
+		annotations[oci.AnnotationDescription] = description
+	}
+
+	return ociClient.PushManifest(ctx, name, targetItemName, &oci.Manifest{
+		SchemaVersion: 2,
+		MediaType:     oci.MediaTypeImageManifest,
+		ArtifactType:  OCIArtifactType,
+		Config:        oci.EmptyJSONDescriptor,
+		Layers:        layers,
+		Annotations:   annotations,
+	})
+}
+
+// checkOCIPublishStatusAndShouldRepublish checks the status of the push to
+// the OCI registry, marks the Uploaded condition, and returns true if the VM
+// should be published again.
+func (r *Reconciler) checkOCIPublishStatusAndShouldRepublish(ctx *pkgctx.VirtualMachinePublishRequestContext) (bool, error) {
+	vmPubReq := ctx.VMPublishRequest
+	actID := getPublishRequestActID(vmPubReq)
+
+	publish, ok := r.ociPublishes.get(vmPubReq.UID, actID)
+	if !ok {
+		// The push is not tracked, ex. the controller restarted while
+		// pushing. Check if the push completed before retrying it.
+		ociClient, name, err := r.newOCIClient(ctx, vmPubReq.Spec.Target.Location.OCIRegistry)
+		if err != nil {
+			return false, err
+		}
+
+		manifest, digest, err := ociClient.GetManifest(ctx, name, vmPubReq.Status.TargetRef.Item.Name)
+		if err != nil && !errors.Is(err, oci.ErrNotFound) {
+			return false, err
+		}
+
+		if manifest != nil &&
+			manifest.Annotations[vmopv1.VirtualMachinePublishRequestUIDAnnotation] == string(vmPubReq.UID) {
+
+			vmPubReq.Status.ImageDigest = digest
+			conditions.MarkTrue(vmPubReq, vmopv1.VirtualMachinePublishRequestConditionUploaded)
+			return false, nil
+		}
+
+		ctx.Logger.Info("OCI push is not in progress, retry publishing this VM", "actID", actID)
+		return true, nil
+	}
+
+	switch {
+	case !publish.done:
+		ctx.Logger.V(5).Info("OCI push is still in progress", "actID", actID, "uploaded", publish.uploaded)
+		conditions.MarkFalse(vmPubReq,
+			vmopv1.VirtualMachinePublishRequestConditionUploaded,
+			vmopv1.UploadingReason,
+			fmt.Sprintf("Uploaded %s to the OCI registry.",
+				resource.NewQuantity(publish.uploaded, resource.BinarySI)))
+		return false, nil
+
+	case publish.err != nil:
+		ctx.Logger.Error(publish.err, "OCI push failed, will retry this operation", "actID", actID)
+		r.ociPublishes.delete(vmPubReq.UID)
+		conditions.MarkFalse(vmPubReq,
+			vmopv1.VirtualMachinePublishRequestConditionUploaded,
+			vmopv1.UploadFailureReason,
+			publish.err.Error())
+		return true, nil
+
+	default:
+		ctx.Logger.Info("OCI push succeeded", "actID", actID, "digest", publish.digest)
+		r.ociPublishes.delete(vmPubReq.UID)
+		vmPubReq.Status.ImageDigest = publish.digest
+		conditions.MarkTrue(vmPubReq, vmopv1.VirtualMachinePublishRequestConditionUploaded)
+		return false, nil
+	}
+}
+
+// checkIsOCIArtifactAvailable checks if the pushed artifact is available in
+// the OCI registry with the target tag.
+func (r *Reconciler) checkIsOCIArtifactAvailable(ctx *pkgctx.VirtualMachinePublishRequestContext) error {
+	vmPubReq := ctx.VMPublishRequest
+
+	ociClient, name, err := r.newOCIClient(ctx, vmPubReq.Spec.Target.Location.OCIRegistry)
+	if err != nil {
+		return err
+	}
+
+	_, digest, err := ociClient.GetManifest(ctx, name, vmPubReq.Status.TargetRef.Item.Name)
+	if err != nil && !errors.Is(err, oci.ErrNotFound) {
+		ctx.Logger.Error(err, "failed to get OCI artifact")
+		return err
+	}
+
+	if err != nil || digest != vmPubReq.Status.ImageDigest {
+		conditions.MarkFalse(vmPubReq,
+			vmopv1.VirtualMachinePublishRequestConditionImageAvailable,
+			vmopv1.TargetOCIArtifactNotFoundReason,
+			"OCI artifact not found")
+		return nil
+	}
+
+	conditions.MarkTrue(vmPubReq, vmopv1.VirtualMachinePublishRequestConditionImageAvailable)
+	ctx.Logger.Info("OCI artifact is available", "digest", digest)
+	return nil
+}
@@ -513,16 +513,15 @@ func (r *Reconciler) syncReplicas(
 			"currentReplicas", len(vms),
 			"desiredReplicas", *(rs.Spec.Replicas),
 			"vmsToBeCreated", diff,
-			"deletePolicy", "oldestFirst",
+			"deletePolicy", rs.Spec.DeletePolicy,
 		)
 
-		deletePriorityFunc, err := getDeletePriorityFunc(rs)
+		vmsToDelete, err := getMachinesToDelete(rs, vms, diff)
 		if err != nil {
 			return err
 		}
 
 		var errs []error
-		vmsToDelete := getMachinesToDeletePrioritized(vms, diff, deletePriorityFunc)
 		for i, vm := range vmsToDelete {
 			log := ctx.Logger.WithValues("vm", vm.Name)
 			if vm.GetDeletionTimestamp().IsZero() {
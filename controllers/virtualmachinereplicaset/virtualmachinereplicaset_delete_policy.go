@@ -21,9 +21,13 @@ limitations under the License.
 package virtualmachinereplicaset
 
 import (
+	"fmt"
+	"math"
 	"sort"
+	"time"
 
 	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	vmopv1util "github.com/vmware-tanzu/vm-operator/pkg/util/vmopv1"
 )
 
 type (
@@ -33,8 +37,11 @@ type (
 
 const (
 	mustDelete    deletePriority = 100.0
+	betterDelete  deletePriority = 75.0
 	couldDelete   deletePriority = 50.0
 	mustNotDelete deletePriority = 0.0
+
+	secondsPerTenDays float64 = 864000
 )
 
 func randomDeletePolicy(vm *vmopv1.VirtualMachine) deletePriority {
@@ -42,9 +49,44 @@ func randomDeletePolicy(vm *vmopv1.VirtualMachine) deletePriority {
 		return mustDelete
 	}
 
-	// TODO: when we support a Ready/healthy condition, that should have a
-	// higher priority for deletion.  For now, all VMs that are not marked
-	// for deletion get the same priority.
+	// All VMs that are not marked for deletion get the same priority. Use the
+	// NotReadyFirst policy to prefer deleting VMs that are not ready.
+	return couldDelete
+}
+
+// oldestDeletePolicy prioritizes the deletion of older VMs. The priority
+// approaches betterDelete as the age of the VM grows.
+func oldestDeletePolicy(vm *vmopv1.VirtualMachine) deletePriority {
+	if !vm.DeletionTimestamp.IsZero() {
+		return mustDelete
+	}
+	if vm.CreationTimestamp.Time.IsZero() {
+		return mustNotDelete
+	}
+	d := time.Since(vm.CreationTimestamp.Time)
+	if d.Seconds() < 0 {
+		return mustNotDelete
+	}
+	return deletePriority(float64(betterDelete) * (1.0 - math.Exp(-d.Seconds()/secondsPerTenDays)))
+}
+
+// newestDeletePolicy prioritizes the deletion of newer VMs.
+func newestDeletePolicy(vm *vmopv1.VirtualMachine) deletePriority {
+	if !vm.DeletionTimestamp.IsZero() {
+		return mustDelete
+	}
+	return betterDelete - oldestDeletePolicy(vm)
+}
+
+// notReadyFirstDeletePolicy prioritizes the deletion of VMs that are not
+// ready.
+func notReadyFirstDeletePolicy(vm *vmopv1.VirtualMachine) deletePriority {
+	if !vm.DeletionTimestamp.IsZero() {
+		return mustDelete
+	}
+	if !vmopv1util.IsVirtualMachineReady(*vm) {
+		return betterDelete
+	}
 	return couldDelete
 }
 
@@ -82,8 +124,90 @@ func getMachinesToDeletePrioritized(filteredMachines []*vmopv1.VirtualMachine, d
 	return sortable.machines[:diff]
 }
 
-// TODO(muchhals): Needed when we add delete priority field.
-func getDeletePriorityFunc(_ *vmopv1.VirtualMachineReplicaSet) (deletePriorityFunc, error) {
-	// TODO: Expand when we start supporting other delete policies.
-	return randomDeletePolicy, nil
+// getMachinesToDeleteZoneBalanced returns the VMs to delete such that the
+// remaining VMs are spread as evenly as possible across zones. VMs that are
+// already being deleted are always selected first. Otherwise, VMs are taken
+// one at a time from the zone with the most VMs, preferring the VMs in that
+// zone that are not ready.
+func getMachinesToDeleteZoneBalanced(filteredMachines []*vmopv1.VirtualMachine, diff int) []*vmopv1.VirtualMachine {
+	if diff >= len(filteredMachines) {
+		return filteredMachines
+	} else if diff <= 0 {
+		return []*vmopv1.VirtualMachine{}
+	}
+
+	var (
+		machinesToDelete []*vmopv1.VirtualMachine
+		machinesByZone   = map[string][]*vmopv1.VirtualMachine{}
+	)
+
+	for _, vm := range filteredMachines {
+		if !vm.DeletionTimestamp.IsZero() {
+			machinesToDelete = append(machinesToDelete, vm)
+			continue
+		}
+		machinesByZone[vm.Status.Zone] = append(machinesByZone[vm.Status.Zone], vm)
+	}
+
+	sort.Slice(machinesToDelete, func(i, j int) bool {
+		return machinesToDelete[i].Name < machinesToDelete[j].Name
+	})
+
+	for _, machines := range machinesByZone {
+		sort.Sort(sortableMachines{
+			machines: machines,
+			priority: notReadyFirstDeletePolicy,
+		})
+	}
+
+	for len(machinesToDelete) < diff {
+		// Pick the zone with the most VMs, using the zone name to break ties
+		// so the same zone is chosen each time.
+		var (
+			zone  string
+			count int
+		)
+		for z, machines := range machinesByZone {
+			if n := len(machines); n > count || (n == count && n > 0 && z < zone) {
+				zone, count = z, n
+			}
+		}
+
+		machinesToDelete = append(machinesToDelete, machinesByZone[zone][0])
+		machinesByZone[zone] = machinesByZone[zone][1:]
+	}
+
+	return machinesToDelete[:diff]
+}
+
+func getMachinesToDelete(
+	rs *vmopv1.VirtualMachineReplicaSet,
+	filteredMachines []*vmopv1.VirtualMachine,
+	diff int) ([]*vmopv1.VirtualMachine, error) {
+
+	if rs.Spec.DeletePolicy == vmopv1.ZoneBalancedVirtualMachineReplicaSetDeletePolicy {
+		return getMachinesToDeleteZoneBalanced(filteredMachines, diff), nil
+	}
+
+	fun, err := getDeletePriorityFunc(rs)
+	if err != nil {
+		return nil, err
+	}
+
+	return getMachinesToDeletePrioritized(filteredMachines, diff, fun), nil
+}
+
+func getDeletePriorityFunc(rs *vmopv1.VirtualMachineReplicaSet) (deletePriorityFunc, error) {
+	switch rs.Spec.DeletePolicy {
+	case "", vmopv1.RandomVirtualMachineReplicaSetDeletePolicy:
+		return randomDeletePolicy, nil
+	case vmopv1.OldestVirtualMachineReplicaSetDeletePolicy:
+		return oldestDeletePolicy, nil
+	case vmopv1.NewestVirtualMachineReplicaSetDeletePolicy:
+		return newestDeletePolicy, nil
+	case vmopv1.NotReadyFirstVirtualMachineReplicaSetDeletePolicy:
+		return notReadyFirstDeletePolicy, nil
+	default:
+		return nil, fmt.Errorf("unsupported delete policy %q", rs.Spec.DeletePolicy)
+	}
 }
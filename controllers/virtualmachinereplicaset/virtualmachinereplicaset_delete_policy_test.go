@@ -0,0 +1,225 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachinereplicaset
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+)
+
+var _ = Describe(
+	"Delete policy",
+	Label(testlabels.Controller, testlabels.V1Alpha3),
+	func() {
+		var (
+			now = time.Now()
+			rs  *vmopv1.VirtualMachineReplicaSet
+		)
+
+		newVM := func(name, zone string, age time.Duration, ready bool) *vmopv1.VirtualMachine {
+			vm := &vmopv1.VirtualMachine{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              name,
+					CreationTimestamp: metav1.NewTime(now.Add(-age)),
+				},
+				Spec: vmopv1.VirtualMachineSpec{
+					PowerState: vmopv1.VirtualMachinePowerStateOn,
+				},
+				Status: vmopv1.VirtualMachineStatus{
+					Zone: zone,
+				},
+			}
+			if ready {
+				vm.Status.PowerState = vmopv1.VirtualMachinePowerStateOn
+				conditions.MarkTrue(vm, vmopv1.VirtualMachineConditionCreated)
+			}
+			return vm
+		}
+
+		markDeleting := func(vm *vmopv1.VirtualMachine) *vmopv1.VirtualMachine {
+			t := metav1.NewTime(now)
+			vm.DeletionTimestamp = &t
+			return vm
+		}
+
+		names := func(vms []*vmopv1.VirtualMachine) []string {
+			var n []string
+			for _, vm := range vms {
+				n = append(n, vm.Name)
+			}
+			return n
+		}
+
+		getVMsToDelete := func(vms []*vmopv1.VirtualMachine, diff int) []string {
+			vmsToDelete, err := getMachinesToDelete(rs, vms, diff)
+			Expect(err).ToNot(HaveOccurred())
+			return names(vmsToDelete)
+		}
+
+		BeforeEach(func() {
+			rs = &vmopv1.VirtualMachineReplicaSet{}
+		})
+
+		Context("getDeletePriorityFunc", func() {
+			It("should default to the Random policy", func() {
+				fun, err := getDeletePriorityFunc(rs)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(fun).ToNot(BeNil())
+			})
+
+			It("should return an error for an unsupported policy", func() {
+				rs.Spec.DeletePolicy = "LeastRecentlyUsed"
+				_, err := getDeletePriorityFunc(rs)
+				Expect(err).To(MatchError(`unsupported delete policy "LeastRecentlyUsed"`))
+
+				_, err = getMachinesToDelete(rs, nil, 1)
+				Expect(err).To(HaveOccurred())
+			})
+		})
+
+		Context("Random", func() {
+			It("should delete VMs being deleted first, then by name", func() {
+				vms := []*vmopv1.VirtualMachine{
+					newVM("vm-c", "", time.Hour, true),
+					newVM("vm-b", "", time.Hour, false),
+					markDeleting(newVM("vm-d", "", time.Hour, true)),
+					newVM("vm-a", "", time.Hour, true),
+				}
+				Expect(getVMsToDelete(vms, 2)).To(Equal([]string{"vm-d", "vm-a"}))
+			})
+
+			It("should return all the VMs when the diff exceeds the number of VMs", func() {
+				vms := []*vmopv1.VirtualMachine{
+					newVM("vm-a", "", time.Hour, true),
+				}
+				Expect(getVMsToDelete(vms, 3)).To(Equal([]string{"vm-a"}))
+				Expect(getVMsToDelete(vms, 0)).To(BeEmpty())
+			})
+		})
+
+		Context("Oldest", func() {
+			BeforeEach(func() {
+				rs.Spec.DeletePolicy = vmopv1.OldestVirtualMachineReplicaSetDeletePolicy
+			})
+
+			It("should delete the oldest VMs", func() {
+				vms := []*vmopv1.VirtualMachine{
+					newVM("vm-a", "", time.Minute, true),
+					newVM("vm-b", "", 48*time.Hour, true),
+					newVM("vm-c", "", time.Hour, true),
+					newVM("vm-d", "", 30*24*time.Hour, true),
+				}
+				Expect(getVMsToDelete(vms, 2)).To(Equal([]string{"vm-d", "vm-b"}))
+			})
+
+			It("should delete VMs being deleted first", func() {
+				vms := []*vmopv1.VirtualMachine{
+					newVM("vm-a", "", 30*24*time.Hour, true),
+					markDeleting(newVM("vm-b", "", time.Minute, true)),
+				}
+				Expect(getVMsToDelete(vms, 1)).To(Equal([]string{"vm-b"}))
+			})
+		})
+
+		Context("Newest", func() {
+			BeforeEach(func() {
+				rs.Spec.DeletePolicy = vmopv1.NewestVirtualMachineReplicaSetDeletePolicy
+			})
+
+			It("should delete the newest VMs", func() {
+				vms := []*vmopv1.VirtualMachine{
+					newVM("vm-a", "", time.Minute, true),
+					newVM("vm-b", "", 48*time.Hour, true),
+					newVM("vm-c", "", time.Hour, true),
+					newVM("vm-d", "", 30*24*time.Hour, true),
+				}
+				Expect(getVMsToDelete(vms, 2)).To(Equal([]string{"vm-a", "vm-c"}))
+			})
+
+			It("should delete VMs being deleted first", func() {
+				vms := []*vmopv1.VirtualMachine{
+					newVM("vm-a", "", time.Minute, true),
+					markDeleting(newVM("vm-b", "", 30*24*time.Hour, true)),
+				}
+				Expect(getVMsToDelete(vms, 1)).To(Equal([]string{"vm-b"}))
+			})
+		})
+
+		Context("NotReadyFirst", func() {
+			BeforeEach(func() {
+				rs.Spec.DeletePolicy = vmopv1.NotReadyFirstVirtualMachineReplicaSetDeletePolicy
+			})
+
+			It("should delete the VMs that are not ready first", func() {
+				vms := []*vmopv1.VirtualMachine{
+					newVM("vm-a", "", time.Hour, true),
+					newVM("vm-b", "", time.Hour, false),
+					newVM("vm-c", "", time.Hour, true),
+					markDeleting(newVM("vm-d", "", time.Hour, true)),
+					newVM("vm-e", "", time.Hour, false),
+				}
+				Expect(getVMsToDelete(vms, 3)).To(Equal([]string{"vm-d", "vm-b", "vm-e"}))
+				Expect(getVMsToDelete(vms, 4)).To(Equal([]string{"vm-d", "vm-b", "vm-e", "vm-a"}))
+			})
+		})
+
+		Context("ZoneBalanced", func() {
+			BeforeEach(func() {
+				rs.Spec.DeletePolicy = vmopv1.ZoneBalancedVirtualMachineReplicaSetDeletePolicy
+			})
+
+			It("should delete VMs from the zones with the most VMs", func() {
+				vms := []*vmopv1.VirtualMachine{
+					newVM("vm-a1", "zone-a", time.Hour, true),
+					newVM("vm-a2", "zone-a", time.Hour, true),
+					newVM("vm-a3", "zone-a", time.Hour, true),
+					newVM("vm-b1", "zone-b", time.Hour, true),
+					newVM("vm-b2", "zone-b", time.Hour, true),
+					newVM("vm-c1", "zone-c", time.Hour, true),
+				}
+				Expect(getVMsToDelete(vms, 1)).To(Equal([]string{"vm-a1"}))
+				Expect(getVMsToDelete(vms, 2)).To(Equal([]string{"vm-a1", "vm-a2"}))
+				Expect(getVMsToDelete(vms, 3)).To(Equal([]string{"vm-a1", "vm-a2", "vm-b1"}))
+				Expect(getVMsToDelete(vms, 4)).To(Equal([]string{"vm-a1", "vm-a2", "vm-b1", "vm-a3"}))
+			})
+
+			It("should prefer VMs that are not ready within a zone", func() {
+				vms := []*vmopv1.VirtualMachine{
+					newVM("vm-a1", "zone-a", time.Hour, true),
+					newVM("vm-a2", "zone-a", time.Hour, false),
+					newVM("vm-b1", "zone-b", time.Hour, false),
+				}
+				Expect(getVMsToDelete(vms, 1)).To(Equal([]string{"vm-a2"}))
+			})
+
+			It("should count VMs being deleted towards the diff", func() {
+				vms := []*vmopv1.VirtualMachine{
+					newVM("vm-a1", "zone-a", time.Hour, true),
+					newVM("vm-a2", "zone-a", time.Hour, true),
+					markDeleting(newVM("vm-b1", "zone-b", time.Hour, true)),
+					newVM("vm-b2", "zone-b", time.Hour, true),
+				}
+				Expect(getVMsToDelete(vms, 1)).To(Equal([]string{"vm-b1"}))
+				Expect(getVMsToDelete(vms, 2)).To(Equal([]string{"vm-b1", "vm-a1"}))
+			})
+
+			It("should treat VMs without a zone as their own zone", func() {
+				vms := []*vmopv1.VirtualMachine{
+					newVM("vm-1", "", time.Hour, true),
+					newVM("vm-2", "", time.Hour, true),
+					newVM("vm-a1", "zone-a", time.Hour, true),
+				}
+				Expect(getVMsToDelete(vms, 1)).To(Equal([]string{"vm-1"}))
+			})
+		})
+	})
@@ -0,0 +1,494 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachinerestorerequest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	cnsv1alpha1apis "github.com/vmware-tanzu/vm-operator/external/vsphere-csi-driver/pkg/syncer/cnsoperator/apis"
+	cnsv1alpha1 "github.com/vmware-tanzu/vm-operator/external/vsphere-csi-driver/pkg/syncer/cnsoperator/apis/cnsregistervolume/v1alpha1"
+	backupapi "github.com/vmware-tanzu/vm-operator/pkg/backup/api"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	pkgerr "github.com/vmware-tanzu/vm-operator/pkg/errors"
+	"github.com/vmware-tanzu/vm-operator/pkg/patch"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers"
+	"github.com/vmware-tanzu/vm-operator/pkg/record"
+)
+
+// AddToManager adds this package's controller to the provided manager.
+func AddToManager(ctx *pkgctx.ControllerManagerContext, mgr manager.Manager) error {
+	var (
+		controlledType     = &vmopv1.VirtualMachineRestoreRequest{}
+		controlledTypeName = reflect.TypeOf(controlledType).Elem().Name()
+
+		controllerNameShort = fmt.Sprintf("%s-controller", strings.ToLower(controlledTypeName))
+		controllerNameLong  = fmt.Sprintf("%s/%s/%s", ctx.Namespace, ctx.Name, controllerNameShort)
+	)
+
+	r := NewReconciler(
+		ctx,
+		mgr.GetClient(),
+		ctrl.Log.WithName("controllers").WithName(controlledTypeName),
+		record.New(mgr.GetEventRecorderFor(controllerNameLong)),
+		ctx.VMProvider,
+	)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(controlledType).
+		WithOptions(controller.Options{MaxConcurrentReconciles: ctx.MaxConcurrentReconciles}).
+		Complete(r)
+}
+
+func NewReconciler(
+	ctx context.Context,
+	client client.Client,
+	logger logr.Logger,
+	recorder record.Recorder,
+	vmProvider providers.VirtualMachineProviderInterface) *Reconciler {
+
+	return &Reconciler{
+		Context:    ctx,
+		Client:     client,
+		Logger:     logger,
+		Recorder:   recorder,
+		VMProvider: vmProvider,
+	}
+}
+
+// Reconciler reconciles a VirtualMachineRestoreRequest object.
+type Reconciler struct {
+	client.Client
+	Context    context.Context
+	Logger     logr.Logger
+	Recorder   record.Recorder
+	VMProvider providers.VirtualMachineProviderInterface
+}
+
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachinerestorerequests,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachinerestorerequests/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachines,verbs=get;list;watch;create
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachineservices,verbs=get;list;watch;create
+// +kubebuilder:rbac:groups="",resources=persistentvolumeclaims,verbs=get;list;watch
+// +kubebuilder:rbac:groups=cns.vmware.com,resources=cnsregistervolumes,verbs=get;list;watch;create
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=networkpolicies,verbs=get;list;watch;create
+
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
+	ctx = pkgcfg.JoinContext(ctx, r.Context)
+
+	restoreReq := &vmopv1.VirtualMachineRestoreRequest{}
+	if err := r.Get(ctx, req.NamespacedName, restoreReq); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	restoreCtx := &pkgctx.VirtualMachineRestoreRequestContext{
+		Context:                      ctx,
+		Logger:                       ctrl.Log.WithName("VirtualMachineRestoreRequest").WithValues("name", req.NamespacedName),
+		VirtualMachineRestoreRequest: restoreReq,
+	}
+
+	patchHelper, err := patch.NewHelper(restoreReq, r.Client)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to init patch helper for %s: %w", restoreCtx, err)
+	}
+	defer func() {
+		if err := patchHelper.Patch(ctx, restoreReq); err != nil {
+			if reterr == nil {
+				reterr = err
+			}
+			restoreCtx.Logger.Error(err, "patch failed")
+		}
+	}()
+
+	if !restoreReq.DeletionTimestamp.IsZero() {
+		// The restored resources are not owned by the request, so there is
+		// nothing to clean up.
+		return ctrl.Result{}, nil
+	}
+
+	return pkgerr.ResultFromError(r.ReconcileNormal(restoreCtx))
+}
+
+// volumeRegistrationRequeueDelay is how long to wait before checking again
+// whether the backed up disks have been registered as volumes.
+const volumeRegistrationRequeueDelay = 10 * time.Second
+
+// errVolumeRegistration is returned when a backed up disk cannot be
+// registered as a volume. Retrying will not change the outcome.
+var errVolumeRegistration = errors.New("failed to register volume")
+
+func (r *Reconciler) ReconcileNormal(ctx *pkgctx.VirtualMachineRestoreRequestContext) error {
+	restoreReq := ctx.VirtualMachineRestoreRequest
+
+	if !restoreReq.Status.CompletionTime.IsZero() {
+		// The request is only processed once.
+		return nil
+	}
+
+	ctx.Logger.Info("Reconciling VirtualMachineRestoreRequest")
+
+	backup, err := r.VMProvider.GetVirtualMachineBackup(ctx, restoreReq.Spec.Source.ManagedObjectID)
+	if err != nil {
+		r.markFailed(ctx, err)
+		return fmt.Errorf("failed to get VM backup: %w", err)
+	}
+
+	// Do not reveal whether a VM exists if it was backed up from a different
+	// namespace than the one of the request.
+	if backup == nil || backup.VM == nil || backup.VM.Namespace != restoreReq.Namespace {
+		conditions.MarkFalse(
+			restoreReq,
+			vmopv1.VirtualMachineRestoreRequestCompleteCondition,
+			vmopv1.VirtualMachineRestoreRequestBackupNotFoundReason,
+			"VM %s does not exist or has no backup data for this namespace",
+			restoreReq.Spec.Source.ManagedObjectID)
+		r.markComplete(ctx)
+		return nil
+	}
+
+	objs, err := getRestoreObjects(restoreReq.Namespace, backup)
+	if err != nil {
+		r.markFailed(ctx, err)
+		r.markComplete(ctx)
+		return nil
+	}
+
+	if err := r.reconcileResourceStatus(ctx, objs); err != nil {
+		r.markFailed(ctx, err)
+		return err
+	}
+
+	var numConflicts int
+	for _, res := range restoreReq.Status.Resources {
+		if res.Action == vmopv1.VirtualMachineRestoreRequestResourceActionConflict {
+			numConflicts++
+		}
+	}
+	if numConflicts > 0 {
+		conditions.MarkFalse(
+			restoreReq,
+			vmopv1.VirtualMachineRestoreRequestCompleteCondition,
+			vmopv1.VirtualMachineRestoreRequestConflictReason,
+			"%d resource(s) to restore already exist", numConflicts)
+		r.markComplete(ctx)
+		return nil
+	}
+
+	if err := r.createObjects(ctx, objs); err != nil {
+		if errors.As(err, &pkgerr.RequeueError{}) {
+			return err
+		}
+		r.markFailed(ctx, err)
+		if apierrors.IsInvalid(err) || apierrors.IsForbidden(err) || errors.Is(err, errVolumeRegistration) {
+			// Retrying will not change the outcome.
+			r.markComplete(ctx)
+			return nil
+		}
+		return err
+	}
+
+	conditions.MarkTrue(restoreReq, vmopv1.VirtualMachineRestoreRequestCompleteCondition)
+	r.markComplete(ctx)
+
+	if !restoreReq.Spec.DryRun {
+		r.Recorder.EmitEvent(restoreReq, "Restore", nil, false)
+	}
+
+	return nil
+}
+
+// reconcileResourceStatus updates the status with the action to take for each
+// of the objects to restore.
+func (r *Reconciler) reconcileResourceStatus(
+	ctx *pkgctx.VirtualMachineRestoreRequestContext,
+	objs []client.Object) error {
+
+	restoreReq := ctx.VirtualMachineRestoreRequest
+
+	// A resource is not a conflict if it was created by a previous reconcile
+	// that failed before all of the resources were created.
+	restored := map[string]struct{}{}
+	for _, res := range restoreReq.Status.Resources {
+		if res.Restored {
+			restored[resourceKey(res.APIVersion, res.Kind, res.Name)] = struct{}{}
+		}
+	}
+
+	resources := make([]vmopv1.VirtualMachineRestoreRequestResourceStatus, 0, len(objs))
+	for _, obj := range objs {
+		gvk := obj.GetObjectKind().GroupVersionKind()
+		_, isRegisterVolume := obj.(*cnsv1alpha1.CnsRegisterVolume)
+		if isRegisterVolume {
+			// The PVC is created when the disk is registered as a volume.
+			gvk = corev1.SchemeGroupVersion.WithKind("PersistentVolumeClaim")
+		}
+		res := vmopv1.VirtualMachineRestoreRequestResourceStatus{
+			APIVersion: gvk.GroupVersion().String(),
+			Kind:       gvk.Kind,
+			Name:       obj.GetName(),
+			Action:     vmopv1.VirtualMachineRestoreRequestResourceActionCreate,
+		}
+		_, wasRestored := restored[resourceKey(res.APIVersion, res.Kind, res.Name)]
+
+		// Use an unstructured object so the existence check is not served
+		// from the cache.
+		existing := &unstructured.Unstructured{}
+		existing.SetGroupVersionKind(gvk)
+		err := r.Get(ctx, client.ObjectKeyFromObject(obj), existing)
+		switch {
+		case err == nil:
+			if wasRestored {
+				res.Restored = true
+			} else if restoreReq.Spec.ConflictPolicy == vmopv1.VirtualMachineRestoreRequestConflictPolicySkip {
+				res.Action = vmopv1.VirtualMachineRestoreRequestResourceActionSkip
+			} else {
+				res.Action = vmopv1.VirtualMachineRestoreRequestResourceActionConflict
+			}
+		case !apierrors.IsNotFound(err):
+			return fmt.Errorf("failed to get %s %s: %w", res.Kind, res.Name, err)
+		case isRegisterVolume && wasRestored:
+			// The CnsRegisterVolume was created but the disk has not been
+			// registered yet.
+			res.Restored = true
+		}
+
+		resources = append(resources, res)
+	}
+
+	restoreReq.Status.Resources = resources
+	return nil
+}
+
+// createObjects creates the objects whose action is Create. All of the objects
+// are first submitted to the API server for validation so an object that is
+// rejected does not leave a partial restore behind. For a dry run, the objects
+// are only validated.
+func (r *Reconciler) createObjects(
+	ctx *pkgctx.VirtualMachineRestoreRequestContext,
+	objs []client.Object) error {
+
+	if err := r.doCreateObjects(ctx, objs, true); err != nil {
+		return err
+	}
+	if ctx.VirtualMachineRestoreRequest.Spec.DryRun {
+		return nil
+	}
+	return r.doCreateObjects(ctx, objs, false)
+}
+
+func (r *Reconciler) doCreateObjects(
+	ctx *pkgctx.VirtualMachineRestoreRequestContext,
+	objs []client.Object,
+	dryRun bool) error {
+
+	restoreReq := ctx.VirtualMachineRestoreRequest
+
+	var opts []client.CreateOption
+	if dryRun {
+		opts = append(opts, client.DryRunAll)
+	}
+
+	for i, obj := range objs {
+		res := &restoreReq.Status.Resources[i]
+		if res.Action != vmopv1.VirtualMachineRestoreRequestResourceActionCreate || res.Restored {
+			continue
+		}
+
+		if _, ok := obj.(*vmopv1.VirtualMachine); ok && !dryRun {
+			// Do not create the VM until all of its PVCs exist.
+			if err := r.checkVolumesRegistered(ctx, objs); err != nil {
+				return err
+			}
+		}
+
+		if dryRun {
+			// The API server populates the object, ex. its resourceVersion,
+			// even for a dry run, which would fail the actual create.
+			obj = obj.DeepCopyObject().(client.Object)
+		}
+
+		if err := r.Create(ctx, obj, opts...); err != nil {
+			return fmt.Errorf("failed to create %s %s: %w", res.Kind, res.Name, err)
+		}
+
+		if !dryRun {
+			ctx.Logger.Info("Restored resource", "kind", res.Kind, "name", res.Name)
+			res.Restored = true
+		}
+	}
+
+	return nil
+}
+
+// checkVolumesRegistered returns nil if the disks restored by this request
+// have been registered as volumes, a RequeueError if the registration is still
+// in progress, or an errVolumeRegistration error if the registration failed.
+func (r *Reconciler) checkVolumesRegistered(
+	ctx *pkgctx.VirtualMachineRestoreRequestContext,
+	objs []client.Object) error {
+
+	restoreReq := ctx.VirtualMachineRestoreRequest
+
+	for i, obj := range objs {
+		registerVolume, ok := obj.(*cnsv1alpha1.CnsRegisterVolume)
+		if !ok || !restoreReq.Status.Resources[i].Restored {
+			continue
+		}
+		pvcName := registerVolume.Spec.PvcName
+
+		obj := &cnsv1alpha1.CnsRegisterVolume{}
+		if err := r.Get(ctx, client.ObjectKeyFromObject(registerVolume), obj); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return fmt.Errorf("failed to get CnsRegisterVolume %s: %w", registerVolume.Name, err)
+			}
+
+			// The CnsRegisterVolume is deleted once the volume is registered.
+			pvc := &corev1.PersistentVolumeClaim{}
+			if err := r.Get(ctx, client.ObjectKey{Namespace: restoreReq.Namespace, Name: pvcName}, pvc); err != nil {
+				if !apierrors.IsNotFound(err) {
+					return fmt.Errorf("failed to get PersistentVolumeClaim %s: %w", pvcName, err)
+				}
+				return fmt.Errorf("%w for PersistentVolumeClaim %s: CnsRegisterVolume %s no longer exists",
+					errVolumeRegistration, pvcName, registerVolume.Name)
+			}
+			continue
+		}
+
+		if obj.Status.Error != "" {
+			return fmt.Errorf("%w for PersistentVolumeClaim %s: %s",
+				errVolumeRegistration, pvcName, obj.Status.Error)
+		}
+		if !obj.Status.Registered {
+			ctx.Logger.Info("Waiting for disk to be registered", "pvcName", pvcName)
+			return pkgerr.RequeueError{After: volumeRegistrationRequeueDelay}
+		}
+	}
+
+	return nil
+}
+
+func (r *Reconciler) markFailed(ctx *pkgctx.VirtualMachineRestoreRequestContext, err error) {
+	conditions.MarkFalse(
+		ctx.VirtualMachineRestoreRequest,
+		vmopv1.VirtualMachineRestoreRequestCompleteCondition,
+		vmopv1.VirtualMachineRestoreRequestFailedReason,
+		"%s", err)
+	r.Recorder.EmitEvent(ctx.VirtualMachineRestoreRequest, "Restore", err, false)
+}
+
+func (r *Reconciler) markComplete(ctx *pkgctx.VirtualMachineRestoreRequestContext) {
+	ctx.VirtualMachineRestoreRequest.Status.CompletionTime = metav1.Now()
+}
+
+func resourceKey(apiVersion, kind, name string) string {
+	return apiVersion + "/" + kind + "/" + name
+}
+
+// getRestoreObjects returns the objects to restore from the backup, in the
+// order in which they are created: the additional resources, ex. the bootstrap
+// Secrets, then the CnsRegisterVolumes that create the PVCs from the backed up
+// disks, and finally the VM that references them.
+func getRestoreObjects(
+	namespace string,
+	backup *providers.VirtualMachineBackup) ([]client.Object, error) {
+
+	var objs []client.Object
+
+	for _, res := range backup.AdditionalResources {
+		obj := res.DeepCopy()
+		unstructured.RemoveNestedField(obj.Object, "status")
+		resetObjectMeta(obj, namespace)
+		objs = append(objs, obj)
+	}
+
+	for _, diskData := range backup.PVCDiskData {
+		registerVolume, err := getRestoreRegisterVolume(namespace, backup, diskData)
+		if err != nil {
+			return nil, err
+		}
+		objs = append(objs, registerVolume)
+	}
+
+	vm := &vmopv1.VirtualMachine{
+		ObjectMeta: *backup.VM.ObjectMeta.DeepCopy(),
+		Spec:       *backup.VM.Spec.DeepCopy(),
+	}
+	vm.SetGroupVersionKind(vmopv1.GroupVersion.WithKind("VirtualMachine"))
+	resetObjectMeta(vm, namespace)
+	if vm.Annotations == nil {
+		vm.Annotations = map[string]string{}
+	}
+	vm.Annotations[vmopv1.RestoredVMAnnotation] = ""
+	objs = append(objs, vm)
+
+	return objs, nil
+}
+
+// getRestoreRegisterVolume returns the CnsRegisterVolume that statically
+// registers the backed up disk as a volume and creates the PVC bound to it.
+// The disk is not copied, and the PVC gets the capacity and storage class of
+// the disk.
+func getRestoreRegisterVolume(
+	namespace string,
+	backup *providers.VirtualMachineBackup,
+	diskData backupapi.PVCDiskData) (*cnsv1alpha1.CnsRegisterVolume, error) {
+
+	diskURLPath := backup.PVCDiskURLPaths[diskData.PVCName]
+	if diskURLPath == "" {
+		return nil, fmt.Errorf("%w for PersistentVolumeClaim %s: backup does not include the disk %q",
+			errVolumeRegistration, diskData.PVCName, diskData.FileName)
+	}
+
+	registerVolume := &cnsv1alpha1.CnsRegisterVolume{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      diskData.PVCName,
+			Namespace: namespace,
+		},
+		Spec: cnsv1alpha1.CnsRegisterVolumeSpec{
+			PvcName:     diskData.PVCName,
+			DiskURLPath: diskURLPath,
+		},
+	}
+	registerVolume.SetGroupVersionKind(cnsv1alpha1apis.GroupVersion.WithKind("CnsRegisterVolume"))
+	if accessModes := diskData.AccessModes; len(accessModes) > 0 {
+		registerVolume.Spec.AccessMode = corev1.PersistentVolumeAccessMode(accessModes[0])
+	}
+
+	return registerVolume, nil
+}
+
+// resetObjectMeta clears the fields of the object's metadata that are set by
+// the API server or that refer to other objects that may no longer exist.
+func resetObjectMeta(obj client.Object, namespace string) {
+	obj.SetNamespace(namespace)
+	obj.SetUID("")
+	obj.SetResourceVersion("")
+	obj.SetGeneration(0)
+	obj.SetCreationTimestamp(metav1.Time{})
+	obj.SetDeletionTimestamp(nil)
+	obj.SetDeletionGracePeriodSeconds(nil)
+	obj.SetOwnerReferences(nil)
+	obj.SetFinalizers(nil)
+	obj.SetManagedFields(nil)
+}
@@ -0,0 +1,564 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachinerestorerequest_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachinerestorerequest"
+	cnsv1alpha1 "github.com/vmware-tanzu/vm-operator/external/vsphere-csi-driver/pkg/syncer/cnsoperator/apis/cnsregistervolume/v1alpha1"
+	backupapi "github.com/vmware-tanzu/vm-operator/pkg/backup/api"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	pkgerr "github.com/vmware-tanzu/vm-operator/pkg/errors"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers"
+	providerfake "github.com/vmware-tanzu/vm-operator/pkg/providers/fake"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func unitTests() {
+	Describe(
+		"Reconcile",
+		Label(
+			testlabels.Controller,
+		),
+		unitTestsReconcile,
+	)
+}
+
+func unitTestsReconcile() {
+	const (
+		ns          = "dummy-ns"
+		moID        = "vm-42"
+		pvcName     = "my-pvc"
+		diskURLPath = "https://my-vc.local/folder/my-vm/my-disk.vmdk?dcPath=my-dc&dsName=datastore1"
+	)
+
+	var (
+		initObjects    []client.Object
+		funcs          interceptor.Funcs
+		ctx            *builder.UnitTestContextForController
+		fakeVMProvider *providerfake.VMProvider
+
+		reconciler *virtualmachinerestorerequest.Reconciler
+		restoreCtx *pkgctx.VirtualMachineRestoreRequestContext
+		restoreReq *vmopv1.VirtualMachineRestoreRequest
+		backup     *providers.VirtualMachineBackup
+		backupErr  error
+		secret     *corev1.Secret
+	)
+
+	BeforeEach(func() {
+		restoreReq = builder.DummyVirtualMachineRestoreRequest(ns, "dummy-restore", moID)
+
+		backupVM := builder.DummyBasicVirtualMachine("dummy-vm", ns)
+		backupVM.UID = "old-uid"
+		backupVM.ResourceVersion = "42"
+		backupVM.Finalizers = []string{"virtualmachine.vmoperator.vmware.com"}
+		backupVM.Spec.StorageClass = "my-storage-class"
+		backupVM.Spec.Volumes = []vmopv1.VirtualMachineVolume{
+			{
+				Name: "my-disk",
+				VirtualMachineVolumeSource: vmopv1.VirtualMachineVolumeSource{
+					PersistentVolumeClaim: &vmopv1.PersistentVolumeClaimVolumeSource{
+						PersistentVolumeClaimVolumeSource: corev1.PersistentVolumeClaimVolumeSource{
+							ClaimName: pvcName,
+						},
+					},
+				},
+			},
+		}
+		backupVM.Status.UniqueID = moID
+		backupVM.Status.Volumes = []vmopv1.VirtualMachineVolumeStatus{
+			{
+				Name:  "my-disk",
+				Limit: resource.NewQuantity(10*1024*1024*1024, resource.BinarySI),
+			},
+		}
+
+		secret = &corev1.Secret{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: "v1",
+				Kind:       "Secret",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            "my-bootstrap-secret",
+				Namespace:       ns,
+				UID:             "old-secret-uid",
+				ResourceVersion: "7",
+			},
+			StringData: map[string]string{
+				"user-data": "#cloud-config",
+			},
+		}
+		secretContent, err := runtime.DefaultUnstructuredConverter.ToUnstructured(secret)
+		Expect(err).ToNot(HaveOccurred())
+
+		backup = &providers.VirtualMachineBackup{
+			VM: backupVM,
+			AdditionalResources: []*unstructured.Unstructured{
+				{Object: secretContent},
+			},
+			PVCDiskData: []backupapi.PVCDiskData{
+				{
+					FileName:    "[datastore1] my-vm/my-disk.vmdk",
+					PVCName:     pvcName,
+					AccessModes: []string{string(corev1.ReadWriteOnce)},
+				},
+			},
+			PVCDiskURLPaths: map[string]string{
+				pvcName: diskURLPath,
+			},
+		}
+		backupErr = nil
+	})
+
+	JustBeforeEach(func() {
+		ctx = suite.NewUnitTestContextForControllerWithFuncs(funcs, initObjects...)
+		reconciler = virtualmachinerestorerequest.NewReconciler(
+			ctx,
+			ctx.Client,
+			ctx.Logger,
+			ctx.Recorder,
+			ctx.VMProvider,
+		)
+		fakeVMProvider = ctx.VMProvider.(*providerfake.VMProvider)
+		fakeVMProvider.GetVirtualMachineBackupFn = func(_ context.Context, id string) (*providers.VirtualMachineBackup, error) {
+			Expect(id).To(Equal(moID))
+			return backup, backupErr
+		}
+
+		restoreCtx = &pkgctx.VirtualMachineRestoreRequestContext{
+			Context:                      ctx,
+			Logger:                       ctx.Logger.WithName(restoreReq.Name),
+			VirtualMachineRestoreRequest: restoreReq,
+		}
+	})
+
+	AfterEach(func() {
+		ctx.AfterEach()
+		ctx = nil
+		initObjects = nil
+		funcs = interceptor.Funcs{}
+		reconciler = nil
+		fakeVMProvider.Reset()
+	})
+
+	getVM := func() (*vmopv1.VirtualMachine, error) {
+		vm := &vmopv1.VirtualMachine{}
+		return vm, ctx.Client.Get(ctx, client.ObjectKey{Namespace: ns, Name: "dummy-vm"}, vm)
+	}
+
+	getRegisterVolume := func() (*cnsv1alpha1.CnsRegisterVolume, error) {
+		obj := &cnsv1alpha1.CnsRegisterVolume{}
+		return obj, ctx.Client.Get(ctx, client.ObjectKey{Namespace: ns, Name: pvcName}, obj)
+	}
+
+	// registerVolume simulates CNS registering the disk as a volume.
+	registerVolume := func() {
+		obj, err := getRegisterVolume()
+		ExpectWithOffset(1, err).ToNot(HaveOccurred())
+		obj.Status.Registered = true
+		ExpectWithOffset(1, ctx.Client.Update(ctx, obj)).To(Succeed())
+
+		pvc := &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      pvcName,
+				Namespace: ns,
+			},
+		}
+		ExpectWithOffset(1, ctx.Client.Create(ctx, pvc)).To(Succeed())
+	}
+
+	expectRequeue := func(err error) {
+		ExpectWithOffset(1, err).To(MatchError(pkgerr.RequeueError{After: 10 * time.Second}))
+	}
+
+	expectCondition := func(status metav1.ConditionStatus, reason string) {
+		c := conditions.Get(restoreReq, vmopv1.VirtualMachineRestoreRequestCompleteCondition)
+		ExpectWithOffset(1, c).ToNot(BeNil())
+		ExpectWithOffset(1, c.Status).To(Equal(status))
+		if reason != "" {
+			ExpectWithOffset(1, c.Reason).To(Equal(reason))
+		}
+	}
+
+	Context("ReconcileNormal", func() {
+
+		When("the backup does not exist", func() {
+			BeforeEach(func() {
+				backup = nil
+			})
+
+			It("completes the request with BackupNotFound", func() {
+				Expect(reconciler.ReconcileNormal(restoreCtx)).To(Succeed())
+				expectCondition(metav1.ConditionFalse, vmopv1.VirtualMachineRestoreRequestBackupNotFoundReason)
+				Expect(restoreReq.Status.CompletionTime.IsZero()).To(BeFalse())
+			})
+		})
+
+		When("the backup belongs to a different namespace", func() {
+			BeforeEach(func() {
+				backup.VM.Namespace = "other-ns"
+			})
+
+			It("completes the request with BackupNotFound", func() {
+				Expect(reconciler.ReconcileNormal(restoreCtx)).To(Succeed())
+				expectCondition(metav1.ConditionFalse, vmopv1.VirtualMachineRestoreRequestBackupNotFoundReason)
+
+				_, err := getVM()
+				Expect(apierrors.IsNotFound(err)).To(BeTrue())
+			})
+		})
+
+		When("getting the backup fails", func() {
+			BeforeEach(func() {
+				backupErr = errors.New("fubar")
+			})
+
+			It("returns the error and does not complete the request", func() {
+				Expect(reconciler.ReconcileNormal(restoreCtx)).To(MatchError(ContainSubstring("fubar")))
+				expectCondition(metav1.ConditionFalse, vmopv1.VirtualMachineRestoreRequestFailedReason)
+				Expect(restoreReq.Status.CompletionTime.IsZero()).To(BeTrue())
+			})
+		})
+
+		When("the backup does not include the disk of a PVC", func() {
+			BeforeEach(func() {
+				backup.PVCDiskURLPaths = nil
+			})
+
+			It("completes the request with Failed", func() {
+				Expect(reconciler.ReconcileNormal(restoreCtx)).To(Succeed())
+				expectCondition(metav1.ConditionFalse, vmopv1.VirtualMachineRestoreRequestFailedReason)
+				Expect(restoreReq.Status.CompletionTime.IsZero()).To(BeFalse())
+
+				_, err := getRegisterVolume()
+				Expect(apierrors.IsNotFound(err)).To(BeTrue())
+			})
+		})
+
+		When("none of the resources exist", func() {
+			It("registers the disks and waits for the PVCs before restoring the VM", func() {
+				expectRequeue(reconciler.ReconcileNormal(restoreCtx))
+				Expect(conditions.Get(restoreReq, vmopv1.VirtualMachineRestoreRequestCompleteCondition)).To(BeNil())
+				Expect(restoreReq.Status.CompletionTime.IsZero()).To(BeTrue())
+
+				obj, err := getRegisterVolume()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(obj.Spec.PvcName).To(Equal(pvcName))
+				Expect(obj.Spec.DiskURLPath).To(Equal(diskURLPath))
+				Expect(obj.Spec.AccessMode).To(Equal(corev1.ReadWriteOnce))
+
+				Expect(restoreReq.Status.Resources).To(HaveLen(3))
+				Expect(restoreReq.Status.Resources[1].Kind).To(Equal("PersistentVolumeClaim"))
+				Expect(restoreReq.Status.Resources[1].Restored).To(BeTrue())
+				Expect(restoreReq.Status.Resources[2].Restored).To(BeFalse())
+
+				_, err = getVM()
+				Expect(apierrors.IsNotFound(err)).To(BeTrue())
+
+				By("reconciling again before the disk is registered", func() {
+					expectRequeue(reconciler.ReconcileNormal(restoreCtx))
+					Expect(restoreReq.Status.Resources[1].Restored).To(BeTrue())
+				})
+
+				registerVolume()
+
+				Expect(reconciler.ReconcileNormal(restoreCtx)).To(Succeed())
+				expectCondition(metav1.ConditionTrue, "")
+				Expect(restoreReq.Status.CompletionTime.IsZero()).To(BeFalse())
+
+				Expect(restoreReq.Status.Resources).To(Equal([]vmopv1.VirtualMachineRestoreRequestResourceStatus{
+					{
+						APIVersion: "v1",
+						Kind:       "Secret",
+						Name:       secret.Name,
+						Action:     vmopv1.VirtualMachineRestoreRequestResourceActionCreate,
+						Restored:   true,
+					},
+					{
+						APIVersion: "v1",
+						Kind:       "PersistentVolumeClaim",
+						Name:       pvcName,
+						Action:     vmopv1.VirtualMachineRestoreRequestResourceActionCreate,
+						Restored:   true,
+					},
+					{
+						APIVersion: vmopv1.GroupVersion.String(),
+						Kind:       "VirtualMachine",
+						Name:       "dummy-vm",
+						Action:     vmopv1.VirtualMachineRestoreRequestResourceActionCreate,
+						Restored:   true,
+					},
+				}))
+
+				restoredSecret := &corev1.Secret{}
+				Expect(ctx.Client.Get(ctx, client.ObjectKeyFromObject(secret), restoredSecret)).To(Succeed())
+				Expect(restoredSecret.UID).ToNot(Equal(secret.UID))
+
+				vm, err := getVM()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(vm.UID).ToNot(Equal(backup.VM.UID))
+				Expect(vm.Finalizers).To(BeEmpty())
+				Expect(vm.Annotations).To(HaveKey(vmopv1.RestoredVMAnnotation))
+				Expect(vm.Spec.Volumes).To(Equal(backup.VM.Spec.Volumes))
+				Expect(vm.Status.UniqueID).To(BeEmpty())
+			})
+		})
+
+		When("the disk has been registered and the CnsRegisterVolume deleted", func() {
+			BeforeEach(func() {
+				restoreReq.Status.Resources = []vmopv1.VirtualMachineRestoreRequestResourceStatus{
+					{
+						APIVersion: "v1",
+						Kind:       "PersistentVolumeClaim",
+						Name:       pvcName,
+						Action:     vmopv1.VirtualMachineRestoreRequestResourceActionCreate,
+						Restored:   true,
+					},
+				}
+				initObjects = append(initObjects, &corev1.PersistentVolumeClaim{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      pvcName,
+						Namespace: ns,
+					},
+				})
+			})
+
+			It("restores the VM", func() {
+				Expect(reconciler.ReconcileNormal(restoreCtx)).To(Succeed())
+				expectCondition(metav1.ConditionTrue, "")
+
+				_, err := getVM()
+				Expect(err).ToNot(HaveOccurred())
+			})
+		})
+
+		When("the disk cannot be registered", func() {
+			It("completes the request with Failed and does not restore the VM", func() {
+				expectRequeue(reconciler.ReconcileNormal(restoreCtx))
+
+				obj, err := getRegisterVolume()
+				Expect(err).ToNot(HaveOccurred())
+				obj.Status.Error = "disk not found"
+				Expect(ctx.Client.Update(ctx, obj)).To(Succeed())
+
+				Expect(reconciler.ReconcileNormal(restoreCtx)).To(Succeed())
+				expectCondition(metav1.ConditionFalse, vmopv1.VirtualMachineRestoreRequestFailedReason)
+				c := conditions.Get(restoreReq, vmopv1.VirtualMachineRestoreRequestCompleteCondition)
+				Expect(c.Message).To(ContainSubstring("disk not found"))
+				Expect(restoreReq.Status.CompletionTime.IsZero()).To(BeFalse())
+
+				_, err = getVM()
+				Expect(apierrors.IsNotFound(err)).To(BeTrue())
+			})
+		})
+
+		When("the request is a dry run", func() {
+			BeforeEach(func() {
+				restoreReq.Spec.DryRun = true
+			})
+
+			It("reports the resources without restoring them", func() {
+				Expect(reconciler.ReconcileNormal(restoreCtx)).To(Succeed())
+				expectCondition(metav1.ConditionTrue, "")
+
+				Expect(restoreReq.Status.Resources).To(HaveLen(3))
+				for _, res := range restoreReq.Status.Resources {
+					Expect(res.Action).To(Equal(vmopv1.VirtualMachineRestoreRequestResourceActionCreate))
+					Expect(res.Restored).To(BeFalse())
+				}
+
+				_, err := getVM()
+				Expect(apierrors.IsNotFound(err)).To(BeTrue())
+				Expect(apierrors.IsNotFound(
+					ctx.Client.Get(ctx, client.ObjectKeyFromObject(secret), &corev1.Secret{}))).To(BeTrue())
+				_, err = getRegisterVolume()
+				Expect(apierrors.IsNotFound(err)).To(BeTrue())
+			})
+		})
+
+		When("a resource already exists", func() {
+			BeforeEach(func() {
+				existing := secret.DeepCopy()
+				existing.UID = ""
+				existing.ResourceVersion = ""
+				initObjects = append(initObjects, existing)
+			})
+
+			It("completes the request with Conflict and does not restore any resources", func() {
+				Expect(reconciler.ReconcileNormal(restoreCtx)).To(Succeed())
+				expectCondition(metav1.ConditionFalse, vmopv1.VirtualMachineRestoreRequestConflictReason)
+				Expect(restoreReq.Status.CompletionTime.IsZero()).To(BeFalse())
+
+				Expect(restoreReq.Status.Resources).To(HaveLen(3))
+				Expect(restoreReq.Status.Resources[0].Action).To(Equal(vmopv1.VirtualMachineRestoreRequestResourceActionConflict))
+
+				_, err := getVM()
+				Expect(apierrors.IsNotFound(err)).To(BeTrue())
+			})
+
+			When("the conflict policy is Skip", func() {
+				BeforeEach(func() {
+					restoreReq.Spec.ConflictPolicy = vmopv1.VirtualMachineRestoreRequestConflictPolicySkip
+				})
+
+				It("restores the other resources", func() {
+					expectRequeue(reconciler.ReconcileNormal(restoreCtx))
+					registerVolume()
+					Expect(reconciler.ReconcileNormal(restoreCtx)).To(Succeed())
+					expectCondition(metav1.ConditionTrue, "")
+
+					Expect(restoreReq.Status.Resources).To(HaveLen(3))
+					Expect(restoreReq.Status.Resources[0].Action).To(Equal(vmopv1.VirtualMachineRestoreRequestResourceActionSkip))
+					Expect(restoreReq.Status.Resources[0].Restored).To(BeFalse())
+
+					_, err := getVM()
+					Expect(err).ToNot(HaveOccurred())
+				})
+			})
+
+			When("the resource was restored by a previous reconcile", func() {
+				BeforeEach(func() {
+					restoreReq.Status.Resources = []vmopv1.VirtualMachineRestoreRequestResourceStatus{
+						{
+							APIVersion: "v1",
+							Kind:       "Secret",
+							Name:       secret.Name,
+							Action:     vmopv1.VirtualMachineRestoreRequestResourceActionCreate,
+							Restored:   true,
+						},
+					}
+				})
+
+				It("restores the remaining resources", func() {
+					expectRequeue(reconciler.ReconcileNormal(restoreCtx))
+					registerVolume()
+					Expect(reconciler.ReconcileNormal(restoreCtx)).To(Succeed())
+					expectCondition(metav1.ConditionTrue, "")
+
+					Expect(restoreReq.Status.Resources).To(HaveLen(3))
+					for _, res := range restoreReq.Status.Resources {
+						Expect(res.Action).To(Equal(vmopv1.VirtualMachineRestoreRequestResourceActionCreate))
+						Expect(res.Restored).To(BeTrue())
+					}
+				})
+			})
+		})
+
+		When("the backup includes a NetworkPolicy", func() {
+			var networkPolicy *networkingv1.NetworkPolicy
+
+			BeforeEach(func() {
+				networkPolicy = &networkingv1.NetworkPolicy{
+					TypeMeta: metav1.TypeMeta{
+						APIVersion: "networking.k8s.io/v1",
+						Kind:       "NetworkPolicy",
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Name:            "my-network-policy",
+						Namespace:       ns,
+						UID:             "old-network-policy-uid",
+						ResourceVersion: "9",
+					},
+					Spec: networkingv1.NetworkPolicySpec{
+						PodSelector: metav1.LabelSelector{
+							MatchLabels: map[string]string{"app": "dummy"},
+						},
+					},
+				}
+				content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(networkPolicy)
+				Expect(err).ToNot(HaveOccurred())
+				backup.AdditionalResources = append(backup.AdditionalResources, &unstructured.Unstructured{Object: content})
+			})
+
+			It("restores the NetworkPolicy", func() {
+				expectRequeue(reconciler.ReconcileNormal(restoreCtx))
+				registerVolume()
+				Expect(reconciler.ReconcileNormal(restoreCtx)).To(Succeed())
+				expectCondition(metav1.ConditionTrue, "")
+
+				Expect(restoreReq.Status.Resources).To(HaveLen(4))
+				res := restoreReq.Status.Resources[1]
+				Expect(res.APIVersion).To(Equal("networking.k8s.io/v1"))
+				Expect(res.Kind).To(Equal("NetworkPolicy"))
+				Expect(res.Name).To(Equal(networkPolicy.Name))
+				Expect(res.Restored).To(BeTrue())
+
+				obj := &networkingv1.NetworkPolicy{}
+				Expect(ctx.Client.Get(ctx, client.ObjectKeyFromObject(networkPolicy), obj)).To(Succeed())
+				Expect(obj.UID).ToNot(Equal(networkPolicy.UID))
+				Expect(obj.Spec).To(Equal(networkPolicy.Spec))
+			})
+
+			When("the NetworkPolicy is rejected by the API server", func() {
+				BeforeEach(func() {
+					funcs.Create = func(
+						ctx context.Context,
+						c client.WithWatch,
+						obj client.Object,
+						opts ...client.CreateOption) error {
+
+						if obj.GetObjectKind().GroupVersionKind().Kind == "NetworkPolicy" {
+							return apierrors.NewInvalid(
+								schema.GroupKind{Group: "networking.k8s.io", Kind: "NetworkPolicy"},
+								obj.GetName(),
+								field.ErrorList{field.Invalid(field.NewPath("spec"), "", "invalid")})
+						}
+						return c.Create(ctx, obj, opts...)
+					}
+				})
+
+				It("completes the request with Failed and does not restore any resources", func() {
+					Expect(reconciler.ReconcileNormal(restoreCtx)).To(Succeed())
+					expectCondition(metav1.ConditionFalse, vmopv1.VirtualMachineRestoreRequestFailedReason)
+					Expect(restoreReq.Status.CompletionTime.IsZero()).To(BeFalse())
+
+					for _, res := range restoreReq.Status.Resources {
+						Expect(res.Restored).To(BeFalse())
+					}
+					Expect(apierrors.IsNotFound(
+						ctx.Client.Get(ctx, client.ObjectKeyFromObject(secret), &corev1.Secret{}))).To(BeTrue())
+					_, err := getRegisterVolume()
+					Expect(apierrors.IsNotFound(err)).To(BeTrue())
+				})
+			})
+		})
+
+		When("the request is complete", func() {
+			BeforeEach(func() {
+				restoreReq.Status.CompletionTime = metav1.Now()
+			})
+
+			It("does not get the backup", func() {
+				fakeVMProvider.GetVirtualMachineBackupFn = func(_ context.Context, _ string) (*providers.VirtualMachineBackup, error) {
+					Fail("unexpected call to GetVirtualMachineBackup")
+					return nil, nil
+				}
+				Expect(reconciler.ReconcileNormal(restoreCtx)).To(Succeed())
+			})
+		})
+	})
+}
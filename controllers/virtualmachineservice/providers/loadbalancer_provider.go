@@ -27,6 +27,12 @@ const (
 )
 
 // LoadbalancerProvider sets up Loadbalancer for different type of Loadbalancer.
+//
+// Providers should use utils.GetExternalTrafficPolicy and
+// utils.GetHealthCheckNodePort to honor a VirtualMachineService's
+// externalTrafficPolicy and healthCheckNodePort, since these may be specified
+// either in the spec or by the legacy annotations. The session affinity and
+// target ports are set directly on the Service.
 type LoadbalancerProvider interface {
 	EnsureLoadBalancer(ctx context.Context, vmService *vmopv1.VirtualMachineService) error
 
@@ -113,7 +119,7 @@ func (nl *NsxtLoadbalancerProvider) GetServiceLabels(ctx context.Context, vmServ
 
 	// When externalTrafficPolicy is set to Local, skip kube-proxy for the
 	// target Service
-	if etp := vmService.Annotations[utils.AnnotationServiceExternalTrafficPolicyKey]; corev1.ServiceExternalTrafficPolicyType(etp) == corev1.ServiceExternalTrafficPolicyTypeLocal {
+	if utils.GetExternalTrafficPolicy(vmService) == corev1.ServiceExternalTrafficPolicyTypeLocal {
 		res[LabelServiceProxyName] = NSXTServiceProxy
 	}
 
@@ -127,7 +133,7 @@ func (nl *NsxtLoadbalancerProvider) GetToBeRemovedServiceLabels(ctx context.Cont
 
 	// When there is no externalTrafficPolicy configured or it's not Local,
 	// remove the service-proxy label
-	if etp := vmService.Annotations[utils.AnnotationServiceExternalTrafficPolicyKey]; corev1.ServiceExternalTrafficPolicyType(etp) != corev1.ServiceExternalTrafficPolicyTypeLocal {
+	if utils.GetExternalTrafficPolicy(vmService) != corev1.ServiceExternalTrafficPolicyTypeLocal {
 		res[LabelServiceProxyName] = NSXTServiceProxy
 	}
 
@@ -139,8 +145,8 @@ func (nl *NsxtLoadbalancerProvider) GetToBeRemovedServiceLabels(ctx context.Cont
 func (nl *NsxtLoadbalancerProvider) GetServiceAnnotations(ctx context.Context, vmService *vmopv1.VirtualMachineService) (map[string]string, error) {
 	res := make(map[string]string)
 
-	if healthCheckNodePortString, ok := vmService.Annotations[utils.AnnotationServiceHealthCheckNodePortKey]; ok {
-		res[ServiceLoadBalancerHealthCheckNodePortTagKey] = healthCheckNodePortString
+	if healthCheckNodePort := utils.GetHealthCheckNodePort(vmService); healthCheckNodePort != "" {
+		res[ServiceLoadBalancerHealthCheckNodePortTagKey] = healthCheckNodePort
 	}
 
 	return res, nil
@@ -153,7 +159,7 @@ func (nl *NsxtLoadbalancerProvider) GetToBeRemovedServiceAnnotations(ctx context
 
 	// When healthCheckNodePort is NOT present, the corresponding NSX-T
 	// annotation should be cleared as well
-	if _, ok := vmService.Annotations[utils.AnnotationServiceHealthCheckNodePortKey]; !ok {
+	if utils.GetHealthCheckNodePort(vmService) == "" {
 		res[ServiceLoadBalancerHealthCheckNodePortTagKey] = ""
 	}
 
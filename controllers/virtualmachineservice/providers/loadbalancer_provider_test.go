@@ -104,6 +104,22 @@ var _ = Describe(
 				port := vmServiceAnnotations[ServiceLoadBalancerHealthCheckNodePortTagKey]
 				Expect(port).To(Equal("30012"))
 			})
+
+			When("VMService spec also has healthCheckNodePort defined", func() {
+				BeforeEach(func() {
+					vmService.Spec.HealthCheckNodePort = 30013
+				})
+
+				It("should prefer the health check node port in the spec", func() {
+					vmServiceAnnotations, err := lbProvider.GetServiceAnnotations(ctx, vmService)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(vmServiceAnnotations).To(HaveKeyWithValue(ServiceLoadBalancerHealthCheckNodePortTagKey, "30013"))
+
+					vmServiceAnnotations, err = lbProvider.GetToBeRemovedServiceAnnotations(ctx, vmService)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(vmServiceAnnotations).To(BeEmpty())
+				})
+			})
 		})
 
 		Context("GetToBeRemovedServiceAnnotations when VMService does not have healthCheckNodePort defined", func() {
@@ -176,6 +192,23 @@ var _ = Describe(
 					Expect(labels[LabelServiceProxyName]).To(Equal(NSXTServiceProxy))
 				})
 			})
+
+			Context("etp is Local in the spec", func() {
+				BeforeEach(func() {
+					vmService.Spec.ExternalTrafficPolicy = vmopv1.VirtualMachineServiceExternalTrafficPolicyLocal
+				})
+
+				It("should create one label for ServiceProxyName", func() {
+					labels, err := lbProvider.GetServiceLabels(ctx, vmService)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(labels).To(HaveLen(1))
+					Expect(labels[LabelServiceProxyName]).To(Equal(NSXTServiceProxy))
+
+					labels, err = lbProvider.GetToBeRemovedServiceLabels(ctx, vmService)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(labels).To(BeEmpty())
+				})
+			})
 		})
 
 		Context("GetToBeRemovedServiceLabels", func() {
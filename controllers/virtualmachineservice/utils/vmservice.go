@@ -0,0 +1,53 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+)
+
+// GetExternalTrafficPolicy returns the external traffic policy for the
+// VirtualMachineService. The spec field takes precedence over the
+// AnnotationServiceExternalTrafficPolicyKey annotation. An empty string is
+// returned when neither specify a known policy.
+func GetExternalTrafficPolicy(vmService *vmopv1.VirtualMachineService) corev1.ServiceExternalTrafficPolicyType {
+	etp := corev1.ServiceExternalTrafficPolicyType(vmService.Spec.ExternalTrafficPolicy)
+	if etp == "" {
+		etp = corev1.ServiceExternalTrafficPolicyType(vmService.Annotations[AnnotationServiceExternalTrafficPolicyKey])
+	}
+
+	switch etp {
+	case corev1.ServiceExternalTrafficPolicyTypeLocal, corev1.ServiceExternalTrafficPolicyTypeCluster:
+		return etp
+	default:
+		return ""
+	}
+}
+
+// GetHealthCheckNodePort returns the health check node port for the
+// VirtualMachineService. The spec field takes precedence over the
+// AnnotationServiceHealthCheckNodePortKey annotation. An empty string is
+// returned when neither specify a port.
+func GetHealthCheckNodePort(vmService *vmopv1.VirtualMachineService) string {
+	if port := vmService.Spec.HealthCheckNodePort; port != 0 {
+		return strconv.Itoa(int(port))
+	}
+	return vmService.Annotations[AnnotationServiceHealthCheckNodePortKey]
+}
+
+// GetTargetPort returns the target port of the Service port for the
+// VirtualMachineServicePort, which is the TargetPortName when specified.
+// Otherwise it is the TargetPort.
+func GetTargetPort(vmPort vmopv1.VirtualMachineServicePort) intstr.IntOrString {
+	if vmPort.TargetPortName != "" {
+		return intstr.FromString(vmPort.TargetPortName)
+	}
+	return intstr.FromInt32(vmPort.TargetPort)
+}
@@ -7,11 +7,14 @@ package virtualmachineservice
 import (
 	"context"
 	"fmt"
+	"maps"
 	"reflect"
+	"strconv"
 	"strings"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
@@ -80,6 +83,8 @@ func AddToManager(ctx *pkgctx.ControllerManagerContext, mgr manager.Manager) err
 			handler.EnqueueRequestForOwner(mgr.GetScheme(), mgr.GetRESTMapper(), &vmopv1.VirtualMachineService{})).
 		Watches(&corev1.Endpoints{},
 			handler.EnqueueRequestForOwner(mgr.GetScheme(), mgr.GetRESTMapper(), &vmopv1.VirtualMachineService{})).
+		Watches(&discoveryv1.EndpointSlice{},
+			handler.EnqueueRequestForOwner(mgr.GetScheme(), mgr.GetRESTMapper(), &vmopv1.VirtualMachineService{})).
 		Watches(&vmopv1.VirtualMachine{},
 			handler.EnqueueRequestsFromMapFunc(r.virtualMachineToVirtualMachineServiceMapper())).
 		Complete(r)
@@ -119,6 +124,7 @@ type ReconcileVirtualMachineService struct {
 // +kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="",resources=services/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups="",resources=endpoints,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=discovery.k8s.io,resources=endpointslices,verbs=get;list;watch;create;update;patch;delete;deletecollection
 
 func (r *ReconcileVirtualMachineService) Reconcile(ctx context.Context, request reconcile.Request) (_ reconcile.Result, reterr error) {
 	ctx = pkgcfg.JoinContext(ctx, r.Context)
@@ -168,6 +174,11 @@ func (r *ReconcileVirtualMachineService) ReconcileDelete(ctx *pkgctx.VirtualMach
 			return err
 		}
 
+		if err := r.deleteEndpointSlices(ctx); err != nil {
+			ctx.Logger.Error(err, "Failed to delete EndpointSlices")
+			return err
+		}
+
 		service := &corev1.Service{ObjectMeta: objectMeta}
 		if err := r.Client.Delete(ctx, service); client.IgnoreNotFound(err) != nil {
 			ctx.Logger.Error(err, "Failed to delete Service")
@@ -431,7 +442,7 @@ func (r *ReconcileVirtualMachineService) createOrUpdateService(ctx *pkgctx.Virtu
 				Name:       vmPort.Name,
 				Protocol:   corev1.Protocol(vmPort.Protocol),
 				Port:       vmPort.Port,
-				TargetPort: intstr.FromInt(int(vmPort.TargetPort)),
+				TargetPort: utils.GetTargetPort(vmPort),
 				NodePort:   nodePortMap[vmPort.Name],
 			}
 			servicePorts = append(servicePorts, servicePort)
@@ -439,24 +450,27 @@ func (r *ReconcileVirtualMachineService) createOrUpdateService(ctx *pkgctx.Virtu
 		service.Spec.Ports = servicePorts
 
 		// This is the default that k8s would otherwise set (note that we don't really support NodePort).
-		// The only real purpose of this is if the AnnotationServiceExternalTrafficPolicyKey annotation
-		// below is removed, so that we switch the Service back to the default.
+		// The only real purpose of this is if the ExternalTrafficPolicy field or the
+		// AnnotationServiceExternalTrafficPolicyKey annotation is removed, so that we switch the
+		// Service back to the default.
 		if service.Spec.Type == corev1.ServiceTypeNodePort || service.Spec.Type == corev1.ServiceTypeLoadBalancer {
 			service.Spec.ExternalTrafficPolicy = corev1.ServiceExternalTrafficPolicyTypeCluster
-		}
 
-		if externalTrafficPolicy, ok := service.Annotations[utils.AnnotationServiceExternalTrafficPolicyKey]; ok {
-			// Note that this annotation is only set (and makes sense) from the GC cloud provider.
-			trafficPolicy := corev1.ServiceExternalTrafficPolicyType(externalTrafficPolicy)
-			switch trafficPolicy {
-			case corev1.ServiceExternalTrafficPolicyTypeLocal, corev1.ServiceExternalTrafficPolicyTypeCluster:
-				service.Spec.ExternalTrafficPolicy = trafficPolicy
-			default:
+			// Note that the annotation is only set (and makes sense) from the GC cloud provider.
+			if externalTrafficPolicy := utils.GetExternalTrafficPolicy(vmService); externalTrafficPolicy != "" {
+				service.Spec.ExternalTrafficPolicy = externalTrafficPolicy
+			} else if v, ok := service.Annotations[utils.AnnotationServiceExternalTrafficPolicyKey]; ok {
 				ctx.Logger.V(5).Info("Unknown externalTrafficPolicy VirtualMachineService annotation",
-					"externalTrafficPolicy", externalTrafficPolicy)
+					"externalTrafficPolicy", v)
 			}
 		}
 
+		// The health check node port is the port on the nodes of the cluster the VirtualMachineService
+		// was created for, not of this cluster, so it is left to the LB provider via the Service
+		// annotations instead of being set in the Service spec.
+
+		setServiceSessionAffinity(vmService, service)
+
 		return nil
 	})
 
@@ -538,7 +552,7 @@ func (r *ReconcileVirtualMachineService) getVirtualMachineServicesSelectingVirtu
 	return matchingVMServices, nil
 }
 
-// createOrUpdateEndpoints updates the Endpoints for VirtualMachineService.
+// createOrUpdateEndpoints updates the Endpoints and EndpointSlices for VirtualMachineService.
 func (r *ReconcileVirtualMachineService) createOrUpdateEndpoints(ctx *pkgctx.VirtualMachineServiceContext, service *corev1.Service) error {
 	ctx.Logger.V(5).Info("Updating VirtualMachineService Endpoints")
 	defer ctx.Logger.V(5).Info("Finished updating VirtualMachineService Endpoints")
@@ -548,11 +562,11 @@ func (r *ReconcileVirtualMachineService) createOrUpdateEndpoints(ctx *pkgctx.Vir
 		return nil
 	}
 
-	unpackedSubsets, err := r.generateSubsetsForService(ctx, service)
+	vmEndpoints, err := r.getVMEndpointsForService(ctx, service)
 	if err != nil {
 		return err
 	}
-	subsets := utils.RepackSubsets(unpackedSubsets)
+	subsets := utils.RepackSubsets(generateSubsetsForService(ctx, vmEndpoints))
 
 	endpoints := &corev1.Endpoints{
 		ObjectMeta: metav1.ObjectMeta{
@@ -568,7 +582,12 @@ func (r *ReconcileVirtualMachineService) createOrUpdateEndpoints(ctx *pkgctx.Vir
 
 		// NCP apparently needs the same Labels as what is present on the Service, and I'm not aware
 		// of anything else setting Labels, so just sync the Labels (and Annotations) with the Service.
-		endpoints.Labels = service.Labels
+		// Since we publish the EndpointSlices ourselves, the Endpoints are not mirrored.
+		endpoints.Labels = maps.Clone(service.Labels)
+		if endpoints.Labels == nil {
+			endpoints.Labels = map[string]string{}
+		}
+		endpoints.Labels[discoveryv1.LabelSkipMirror] = "true"
 		endpoints.Annotations = service.Annotations
 		endpoints.Subsets = subsets
 		return nil
@@ -585,13 +604,46 @@ func (r *ReconcileVirtualMachineService) createOrUpdateEndpoints(ctx *pkgctx.Vir
 		ctx.Logger.Info("Updating Service Endpoints", "endpoints", endpoints)
 	}
 
-	return nil
+	return r.createOrUpdateEndpointSlices(ctx, service, vmEndpoints)
+}
+
+// setServiceSessionAffinity sets the Service's session affinity from the VirtualMachineService.
+// The defaults that k8s would otherwise set are set explicitly so the Service is not needlessly
+// patched on every reconcile.
+func setServiceSessionAffinity(vmService *vmopv1.VirtualMachineService, service *corev1.Service) {
+	if vmService.Spec.SessionAffinity != vmopv1.VirtualMachineServiceAffinityClientIP ||
+		service.Spec.Type == corev1.ServiceTypeExternalName {
+
+		service.Spec.SessionAffinity = corev1.ServiceAffinityNone
+		service.Spec.SessionAffinityConfig = nil
+		return
+	}
+
+	timeoutSeconds := ptr.To[int32](corev1.DefaultClientIPServiceAffinitySeconds)
+	if config := vmService.Spec.SessionAffinityConfig; config != nil && config.ClientIP != nil && config.ClientIP.TimeoutSeconds != nil {
+		timeoutSeconds = ptr.To(*config.ClientIP.TimeoutSeconds)
+	}
+
+	service.Spec.SessionAffinity = corev1.ServiceAffinityClientIP
+	service.Spec.SessionAffinityConfig = &corev1.SessionAffinityConfig{
+		ClientIP: &corev1.ClientIPConfig{
+			TimeoutSeconds: timeoutSeconds,
+		},
+	}
 }
 
-func findVMPortNum(_ *vmopv1.VirtualMachine, port intstr.IntOrString, _ corev1.Protocol) (int, error) {
+// findVMPortNum returns the port number on the VM for the Service's target port. A named
+// target port is resolved by the VM's VirtualMachineServicePortAnnotationPrefix annotation.
+func findVMPortNum(vm *vmopv1.VirtualMachine, port intstr.IntOrString, _ corev1.Protocol) (int, error) {
 	switch port.Type {
 	case intstr.String:
-		// Not supported.
+		if v, ok := vm.Annotations[vmopv1.VirtualMachineServicePortAnnotationPrefix+port.StrVal]; ok {
+			portNum, err := strconv.Atoi(v)
+			if err != nil || portNum < 1 || portNum > 65535 {
+				return 0, fmt.Errorf("invalid port %q for port name %q on VM", v, port.StrVal)
+			}
+			return portNum, nil
+		}
 	case intstr.Int:
 		return port.IntValue(), nil
 	}
@@ -599,28 +651,43 @@ func findVMPortNum(_ *vmopv1.VirtualMachine, port intstr.IntOrString, _ corev1.P
 	return 0, fmt.Errorf("no matching port on VM")
 }
 
-// generateSubsetsForService generates Endpoints subsets for a given Service.
-func (r *ReconcileVirtualMachineService) generateSubsetsForService(
+// vmEndpoint is a VM selected by a VirtualMachineService, and how it is published in the
+// Service's Endpoints and EndpointSlices.
+type vmEndpoint struct {
+	vm    *vmopv1.VirtualMachine
+	ip    string
+	ports []corev1.EndpointPort
+
+	// ready is true when the VM passes its readiness probe, if any. This is
+	// what determines whether the VM is a ready address in the Endpoints.
+	ready bool
+	// serving is true when the VM is able to receive traffic, regardless of whether it is
+	// terminating.
+	serving bool
+	// terminating is true when the VM is being deleted or powered off. A terminating VM is not
+	// ready in the EndpointSlices, but is still published so that load balancers can drain its
+	// connections.
+	terminating bool
+}
+
+// getVMEndpointsForService returns the VMs, selected by the VirtualMachineService, that have
+// an IP address.
+func (r *ReconcileVirtualMachineService) getVMEndpointsForService(
 	ctx *pkgctx.VirtualMachineServiceContext,
-	service *corev1.Service) ([]corev1.EndpointSubset, error) {
+	service *corev1.Service) ([]vmEndpoint, error) {
 
 	vmList, err := r.getVirtualMachinesSelectedByVMService(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	var subsets = make([]corev1.EndpointSubset, 0, len(vmList.Items))
+	var vmEndpoints = make([]vmEndpoint, 0, len(vmList.Items))
 	var vmInSubsetsMap map[types.UID]struct{}
 
 	for i := range vmList.Items {
-		vm := vmList.Items[i]
+		vm := &vmList.Items[i]
 		logger := ctx.Logger.WithValues("virtualMachine", vm.NamespacedName())
 
-		if !vm.DeletionTimestamp.IsZero() {
-			logger.Info("Skipping VM marked for deletion")
-			continue
-		}
-
 		var vmIP string
 		if vm.Status.Network != nil {
 			vmIP = vm.Status.Network.PrimaryIP4
@@ -644,8 +711,8 @@ func (r *ReconcileVirtualMachineService) generateSubsetsForService(
 		// Otherwise, a VM that does not have a ReadinessProbe is implicitly ready.
 		ready := true
 
-		if probe := vm.Spec.ReadinessProbe; probe != nil && (probe.TCPSocket != nil || probe.GuestHeartbeat != nil || len(probe.GuestInfo) != 0) {
-			if condition := conditions.Get(&vm, vmopv1.ReadyConditionType); condition == nil {
+		if probe := vm.Spec.ReadinessProbe; probe != nil && (probe.TCPSocket != nil || probe.HTTPGet != nil || probe.GuestHeartbeat != nil || len(probe.GuestInfo) != 0) {
+			if condition := conditions.Get(vm, vmopv1.ReadyConditionType); condition == nil {
 				if vmInSubsetsMap == nil {
 					vmInSubsetsMap = r.getVMsReferencedByServiceEndpoints(ctx, service)
 				}
@@ -659,8 +726,61 @@ func (r *ReconcileVirtualMachineService) generateSubsetsForService(
 			}
 		}
 
+		// A VM that is not powered on cannot serve traffic, and a VM that is being deleted or
+		// powered off should be drained.
+		poweredOff := vm.Status.PowerState != "" && vm.Status.PowerState != vmopv1.VirtualMachinePowerStateOn
+		poweringOff := vm.Spec.PowerState != "" && vm.Spec.PowerState != vmopv1.VirtualMachinePowerStateOn
+
+		endpoint := vmEndpoint{
+			vm:          vm,
+			ip:          vmIP,
+			ready:       ready,
+			serving:     ready && !poweredOff,
+			terminating: !vm.DeletionTimestamp.IsZero() || poweringOff,
+		}
+
+		// TODO: Headless support
+		for _, servicePort := range service.Spec.Ports {
+			portName := servicePort.Name
+			portProto := servicePort.Protocol
+
+			logger.V(5).Info("ServicePort for VirtualMachine",
+				"port name", portName, "port proto", portProto)
+
+			portNum, err := findVMPortNum(vm, servicePort.TargetPort, portProto)
+			if err != nil {
+				logger.Info("Failed to find port for service",
+					"name", portName, "protocol", portProto, "error", err)
+				continue
+			}
+
+			endpoint.ports = append(endpoint.ports,
+				corev1.EndpointPort{Name: portName, Port: int32(portNum), Protocol: portProto})
+		}
+
+		vmEndpoints = append(vmEndpoints, endpoint)
+	}
+
+	return vmEndpoints, nil
+}
+
+// generateSubsetsForService generates Endpoints subsets for a given Service.
+func generateSubsetsForService(
+	ctx *pkgctx.VirtualMachineServiceContext,
+	vmEndpoints []vmEndpoint) []corev1.EndpointSubset {
+
+	var subsets = make([]corev1.EndpointSubset, 0, len(vmEndpoints))
+
+	for _, endpoint := range vmEndpoints {
+		vm := endpoint.vm
+
+		if !vm.DeletionTimestamp.IsZero() {
+			ctx.Logger.Info("Skipping VM marked for deletion", "virtualMachine", vm.NamespacedName())
+			continue
+		}
+
 		epa := corev1.EndpointAddress{
-			IP: vmIP,
+			IP: endpoint.ip,
 			TargetRef: &corev1.ObjectReference{
 				APIVersion: vm.APIVersion,
 				Kind:       vm.Kind,
@@ -676,36 +796,19 @@ func (r *ReconcileVirtualMachineService) generateSubsetsForService(
 
 		// Populate the EP subset for this VM. We create one subset for each VM, and then our
 		// caller will repack the subsets that have identical ports.
-		subset := corev1.EndpointSubset{}
-		if ready {
+		subset := corev1.EndpointSubset{
+			Ports: endpoint.ports,
+		}
+		if endpoint.ready {
 			subset.Addresses = []corev1.EndpointAddress{epa}
 		} else {
 			subset.NotReadyAddresses = []corev1.EndpointAddress{epa}
 		}
 
-		// TODO: Headless support
-		for _, servicePort := range service.Spec.Ports {
-			portName := servicePort.Name
-			portProto := servicePort.Protocol
-
-			logger.V(5).Info("ServicePort for VirtualMachine",
-				"port name", portName, "port proto", portProto)
-
-			portNum, err := findVMPortNum(&vm, servicePort.TargetPort, portProto)
-			if err != nil {
-				logger.Info("Failed to find port for service",
-					"name", portName, "protocol", portProto, "error", err)
-				continue
-			}
-
-			subset.Ports = append(subset.Ports,
-				corev1.EndpointPort{Name: portName, Port: int32(portNum), Protocol: portProto})
-		}
-
 		subsets = append(subsets, subset)
 	}
 
-	return subsets, nil
+	return subsets
 }
 
 // updateVMService syncs the VirtualMachineService Status from the Service status.
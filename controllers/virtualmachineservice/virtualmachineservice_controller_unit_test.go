@@ -5,15 +5,19 @@
 package virtualmachineservice_test
 
 import (
+	"fmt"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
 	"github.com/onsi/gomega/types"
 
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	apiEquality "k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
@@ -192,6 +196,54 @@ func unitTestsReconcile() {
 				Expect(service.Spec.LoadBalancerSourceRanges).To(ContainElements(lbSourceRanges))
 				Expect(service.Spec.AllocateLoadBalancerNodePorts).ToNot(BeNil())
 				Expect(*service.Spec.AllocateLoadBalancerNodePorts).To(BeFalse())
+				Expect(service.Spec.ExternalTrafficPolicy).To(Equal(corev1.ServiceExternalTrafficPolicyTypeCluster))
+				Expect(service.Spec.SessionAffinity).To(Equal(corev1.ServiceAffinityNone))
+				Expect(service.Spec.SessionAffinityConfig).To(BeNil())
+			})
+
+			Context("With SessionAffinity", func() {
+				BeforeEach(func() {
+					vmService.Spec.SessionAffinity = vmopv1.VirtualMachineServiceAffinityClientIP
+				})
+
+				It("Defaults the ClientIP timeout", func() {
+					Expect(service.Spec.SessionAffinity).To(Equal(corev1.ServiceAffinityClientIP))
+					Expect(service.Spec.SessionAffinityConfig).ToNot(BeNil())
+					Expect(service.Spec.SessionAffinityConfig.ClientIP).ToNot(BeNil())
+					Expect(service.Spec.SessionAffinityConfig.ClientIP.TimeoutSeconds).To(HaveValue(BeEquivalentTo(corev1.DefaultClientIPServiceAffinitySeconds)))
+				})
+
+				When("SessionAffinityConfig is specified", func() {
+					BeforeEach(func() {
+						vmService.Spec.SessionAffinityConfig = &vmopv1.SessionAffinityConfig{
+							ClientIP: &vmopv1.ClientIPConfig{
+								TimeoutSeconds: ptr.To[int32](600),
+							},
+						}
+					})
+
+					It("Expected SessionAffinityConfig", func() {
+						Expect(service.Spec.SessionAffinity).To(Equal(corev1.ServiceAffinityClientIP))
+						Expect(service.Spec.SessionAffinityConfig).ToNot(BeNil())
+						Expect(service.Spec.SessionAffinityConfig.ClientIP).ToNot(BeNil())
+						Expect(service.Spec.SessionAffinityConfig.ClientIP.TimeoutSeconds).To(HaveValue(BeEquivalentTo(600)))
+					})
+				})
+			})
+
+			Context("With Spec.Ports with a TargetPortName", func() {
+				BeforeEach(func() {
+					vmServicePort1.TargetPort = 0
+					vmServicePort1.TargetPortName = "http"
+					vmService.Spec.Ports = []vmopv1.VirtualMachineServicePort{
+						vmServicePort1,
+					}
+				})
+
+				It("Service ports", func() {
+					Expect(service.Spec.Ports).To(HaveLen(1))
+					Expect(service.Spec.Ports[0].TargetPort).To(Equal(intstr.FromString("http")))
+				})
 			})
 
 			Context("With Expected Spec.Ports", func() {
@@ -254,6 +306,26 @@ func unitTestsReconcile() {
 					Expect(service.Spec.ExternalTrafficPolicy).To(Equal(corev1.ServiceExternalTrafficPolicyTypeLocal))
 					Expect(service.Annotations).To(HaveKeyWithValue(utils.AnnotationServiceHealthCheckNodePortKey, "99"))
 				})
+
+				When("Spec has ExternalTrafficPolicy", func() {
+					BeforeEach(func() {
+						vmService.Spec.ExternalTrafficPolicy = vmopv1.VirtualMachineServiceExternalTrafficPolicyCluster
+					})
+
+					It("Spec takes precedence over the annotation", func() {
+						Expect(service.Spec.ExternalTrafficPolicy).To(Equal(corev1.ServiceExternalTrafficPolicyTypeCluster))
+					})
+				})
+			})
+
+			Context("ExternalTrafficPolicy Spec", func() {
+				BeforeEach(func() {
+					vmService.Spec.ExternalTrafficPolicy = vmopv1.VirtualMachineServiceExternalTrafficPolicyLocal
+				})
+
+				It("Expected values", func() {
+					Expect(service.Spec.ExternalTrafficPolicy).To(Equal(corev1.ServiceExternalTrafficPolicyTypeLocal))
+				})
 			})
 		})
 
@@ -575,6 +647,45 @@ func unitTestsReconcile() {
 				})
 			})
 
+			Context("When Service has a TargetPortName", func() {
+				BeforeEach(func() {
+					vmServicePort1.TargetPort = 0
+					vmServicePort1.TargetPortName = "http"
+					vmService.Spec.Ports = []vmopv1.VirtualMachineServicePort{
+						vmServicePort1,
+					}
+
+					vm1.Annotations = map[string]string{
+						vmopv1.VirtualMachineServicePortAnnotationPrefix + "http": "8080",
+					}
+					initObjects = append(initObjects, vm1, vm3)
+				})
+
+				It("Resolves the port from the VM annotation", func() {
+					Expect(endpoints.Subsets).To(HaveLen(1))
+					subset := endpoints.Subsets[0]
+
+					Expect(subset.Ports).To(HaveLen(1))
+					Expect(subset.Ports[0].Name).To(Equal(vmServicePort1.Name))
+					Expect(subset.Ports[0].Port).To(BeEquivalentTo(8080))
+
+					Expect(subset.Addresses).To(HaveLen(1))
+					assertEPAddrFromVM(subset.Addresses[0], vm1)
+				})
+
+				When("VM does not name the port", func() {
+					BeforeEach(func() {
+						vm1.Annotations = nil
+					})
+
+					It("Port is not included in Subsets", func() {
+						for _, subset := range endpoints.Subsets {
+							Expect(subset.Ports).To(BeEmpty())
+						}
+					})
+				})
+			})
+
 			Context("When VMs have Readiness Probe", func() {
 				BeforeEach(func() {
 					vm1.Spec.ReadinessProbe = &vmopv1.VirtualMachineReadinessProbeSpec{
@@ -688,6 +799,229 @@ func unitTestsReconcile() {
 			})
 		})
 
+		Context("Creates expected EndpointSlices", func() {
+			var vm1, vm2, vm3 *vmopv1.VirtualMachine
+
+			getEndpointSlices := func() []discoveryv1.EndpointSlice {
+				endpointSliceList := &discoveryv1.EndpointSliceList{}
+				ExpectWithOffset(1, ctx.Client.List(ctx, endpointSliceList,
+					client.InNamespace(vmService.Namespace),
+					client.MatchingLabels{discoveryv1.LabelServiceName: vmService.Name})).To(Succeed())
+				return endpointSliceList.Items
+			}
+
+			getEndpoint := func(endpointSlice discoveryv1.EndpointSlice, vm *vmopv1.VirtualMachine) discoveryv1.Endpoint {
+				for _, endpoint := range endpointSlice.Endpoints {
+					if endpoint.TargetRef != nil && endpoint.TargetRef.Name == vm.Name {
+						return endpoint
+					}
+				}
+				Fail("no endpoint for VM " + vm.Name)
+				return discoveryv1.Endpoint{}
+			}
+
+			BeforeEach(func() {
+				labelSelector := map[string]string{"my-app": "dummy-label"}
+				vmService.Spec.Selector = labelSelector
+				vmService.Spec.Ports = []vmopv1.VirtualMachineServicePort{
+					vmServicePort1,
+				}
+
+				vm1 = &vmopv1.VirtualMachine{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "dummy-vm1",
+						Namespace: vmService.Namespace,
+						Labels:    labelSelector,
+					},
+					Spec: vmopv1.VirtualMachineSpec{
+						PowerState: vmopv1.VirtualMachinePowerStateOn,
+					},
+					Status: vmopv1.VirtualMachineStatus{
+						PowerState: vmopv1.VirtualMachinePowerStateOn,
+						Zone:       "zone-a",
+						Network: &vmopv1.VirtualMachineNetworkStatus{
+							PrimaryIP4: "1.1.1.1",
+						},
+					},
+				}
+
+				// Being powered off.
+				vm2 = &vmopv1.VirtualMachine{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "dummy-vm2",
+						Namespace: vmService.Namespace,
+						Labels:    labelSelector,
+					},
+					Spec: vmopv1.VirtualMachineSpec{
+						PowerState: vmopv1.VirtualMachinePowerStateOff,
+					},
+					Status: vmopv1.VirtualMachineStatus{
+						PowerState: vmopv1.VirtualMachinePowerStateOn,
+						Network: &vmopv1.VirtualMachineNetworkStatus{
+							PrimaryIP4: "2.2.2.2",
+						},
+					},
+				}
+
+				// Being deleted.
+				vm3 = &vmopv1.VirtualMachine{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:              "dummy-vm3",
+						Namespace:         vmService.Namespace,
+						Labels:            labelSelector,
+						DeletionTimestamp: ptr.To(metav1.Now()),
+						Finalizers:        []string{"dummy.finalizer"},
+					},
+					Status: vmopv1.VirtualMachineStatus{
+						Network: &vmopv1.VirtualMachineNetworkStatus{
+							PrimaryIP6: "fd00::3",
+						},
+					},
+				}
+
+				initObjects = append(initObjects, vm1, vm2, vm3)
+			})
+
+			JustBeforeEach(func() {
+				err := reconciler.ReconcileNormal(vmServiceCtx)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("Publishes EndpointSlices with the expected conditions", func() {
+				endpointSlices := getEndpointSlices()
+				Expect(endpointSlices).To(HaveLen(2))
+
+				var ipv4Slice, ipv6Slice discoveryv1.EndpointSlice
+				for _, endpointSlice := range endpointSlices {
+					Expect(endpointSlice.Labels).To(HaveKey(discoveryv1.LabelManagedBy))
+					Expect(endpointSlice.OwnerReferences).To(HaveLen(1))
+					Expect(endpointSlice.Ports).To(HaveLen(1))
+					Expect(endpointSlice.Ports[0].Port).To(HaveValue(Equal(vmServicePort1.TargetPort)))
+
+					switch endpointSlice.AddressType {
+					case discoveryv1.AddressTypeIPv4:
+						ipv4Slice = endpointSlice
+					case discoveryv1.AddressTypeIPv6:
+						ipv6Slice = endpointSlice
+					}
+				}
+
+				Expect(ipv4Slice.Endpoints).To(HaveLen(2))
+				endpoint := getEndpoint(ipv4Slice, vm1)
+				Expect(endpoint.Addresses).To(Equal([]string{"1.1.1.1"}))
+				Expect(endpoint.Conditions.Ready).To(HaveValue(BeTrue()))
+				Expect(endpoint.Conditions.Serving).To(HaveValue(BeTrue()))
+				Expect(endpoint.Conditions.Terminating).To(HaveValue(BeFalse()))
+				Expect(endpoint.Zone).To(HaveValue(Equal("zone-a")))
+				Expect(endpoint.Hints).ToNot(BeNil())
+				Expect(endpoint.Hints.ForZones).To(Equal([]discoveryv1.ForZone{{Name: "zone-a"}}))
+
+				endpoint = getEndpoint(ipv4Slice, vm2)
+				Expect(endpoint.Conditions.Ready).To(HaveValue(BeFalse()))
+				Expect(endpoint.Conditions.Serving).To(HaveValue(BeTrue()))
+				Expect(endpoint.Conditions.Terminating).To(HaveValue(BeTrue()))
+				Expect(endpoint.Zone).To(BeNil())
+				Expect(endpoint.Hints).To(BeNil())
+
+				Expect(ipv6Slice.Endpoints).To(HaveLen(1))
+				endpoint = getEndpoint(ipv6Slice, vm3)
+				Expect(endpoint.Addresses).To(Equal([]string{"fd00::3"}))
+				Expect(endpoint.Conditions.Ready).To(HaveValue(BeFalse()))
+				Expect(endpoint.Conditions.Serving).To(HaveValue(BeTrue()))
+				Expect(endpoint.Conditions.Terminating).To(HaveValue(BeTrue()))
+			})
+
+			It("Endpoints are not mirrored and only exclude VMs being deleted", func() {
+				endpoints := &corev1.Endpoints{}
+				Expect(ctx.Client.Get(ctx, objKey, endpoints)).To(Succeed())
+				Expect(endpoints.Labels).To(HaveKeyWithValue(discoveryv1.LabelSkipMirror, "true"))
+
+				Expect(endpoints.Subsets).To(HaveLen(1))
+				subset := endpoints.Subsets[0]
+				Expect(subset.Addresses).To(HaveLen(2))
+				assertEPAddrFromVM(subset.Addresses[0], vm1)
+				assertEPAddrFromVM(subset.Addresses[1], vm2)
+				Expect(subset.NotReadyAddresses).To(BeEmpty())
+			})
+
+			When("VM is powered off", func() {
+				BeforeEach(func() {
+					vm2.Status.PowerState = vmopv1.VirtualMachinePowerStateOff
+				})
+
+				It("Endpoint is not serving", func() {
+					for _, endpointSlice := range getEndpointSlices() {
+						if endpointSlice.AddressType != discoveryv1.AddressTypeIPv4 {
+							continue
+						}
+						endpoint := getEndpoint(endpointSlice, vm2)
+						Expect(endpoint.Conditions.Ready).To(HaveValue(BeFalse()))
+						Expect(endpoint.Conditions.Serving).To(HaveValue(BeFalse()))
+					}
+				})
+			})
+
+			When("there are more endpoints than fit in one EndpointSlice", func() {
+				const numVMs = 1001
+
+				BeforeEach(func() {
+					for i := range numVMs {
+						vm := vm1.DeepCopy()
+						vm.Name = fmt.Sprintf("dummy-vm-%04d", i)
+						vm.Status.Network.PrimaryIP4 = fmt.Sprintf("10.0.%d.%d", i/256, i%256)
+						initObjects = append(initObjects, vm)
+					}
+				})
+
+				It("Splits the endpoints across EndpointSlices", func() {
+					var ipv4Slices []discoveryv1.EndpointSlice
+					for _, endpointSlice := range getEndpointSlices() {
+						if endpointSlice.AddressType == discoveryv1.AddressTypeIPv4 {
+							ipv4Slices = append(ipv4Slices, endpointSlice)
+						}
+					}
+					Expect(ipv4Slices).To(HaveLen(2))
+
+					// Includes vm1 and vm2.
+					total := 0
+					for _, endpointSlice := range ipv4Slices {
+						Expect(len(endpointSlice.Endpoints)).To(BeNumerically("<=", 1000))
+						total += len(endpointSlice.Endpoints)
+					}
+					Expect(total).To(Equal(numVMs + 2))
+				})
+
+				It("Removes the EndpointSlices that are no longer needed", func() {
+					for i := range numVMs {
+						vm := &vmopv1.VirtualMachine{}
+						vm.Namespace = vmService.Namespace
+						vm.Name = fmt.Sprintf("dummy-vm-%04d", i)
+						Expect(ctx.Client.Delete(ctx, vm)).To(Succeed())
+					}
+
+					err := reconciler.ReconcileNormal(vmServiceCtx)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(getEndpointSlices()).To(HaveLen(2))
+				})
+			})
+
+			When("VMs no longer have an IPv6 address", func() {
+				It("Removes the stale EndpointSlice", func() {
+					Expect(getEndpointSlices()).To(HaveLen(2))
+
+					vm3.Status.Network.PrimaryIP6 = ""
+					Expect(ctx.Client.Status().Update(ctx, vm3)).To(Succeed())
+
+					err := reconciler.ReconcileNormal(vmServiceCtx)
+					Expect(err).NotTo(HaveOccurred())
+
+					endpointSlices := getEndpointSlices()
+					Expect(endpointSlices).To(HaveLen(1))
+					Expect(endpointSlices[0].AddressType).To(Equal(discoveryv1.AddressTypeIPv4))
+				})
+			})
+		})
+
 		Context("Selectorless VirtualMachineService", func() {
 			var vm1 *vmopv1.VirtualMachine
 			var labelSelector, vmLabels map[string]string
@@ -774,10 +1108,21 @@ func unitTestsReconcile() {
 				}
 				endpoint := &corev1.Endpoints{ObjectMeta: objectMeta}
 				service := &corev1.Service{ObjectMeta: objectMeta}
-				initObjects = append(initObjects, endpoint, service)
+				endpointSlice := &discoveryv1.EndpointSlice{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      vmService.Name + "-abcde",
+						Namespace: vmService.Namespace,
+						Labels: map[string]string{
+							discoveryv1.LabelServiceName: vmService.Name,
+							discoveryv1.LabelManagedBy:   "vmoperator.vmware.com/virtualmachineservice-controller",
+						},
+					},
+					AddressType: discoveryv1.AddressTypeIPv4,
+				}
+				initObjects = append(initObjects, endpoint, service, endpointSlice)
 			})
 
-			It("Deletes Endpoint and Service", func() {
+			It("Deletes Endpoint, EndpointSlices and Service", func() {
 				err := reconciler.ReconcileDelete(vmServiceCtx)
 				Expect(err).ToNot(HaveOccurred())
 
@@ -785,6 +1130,10 @@ func unitTestsReconcile() {
 				err = ctx.Client.Get(ctx, objKey, endpoint)
 				Expect(errors.IsNotFound(err)).To(BeTrue())
 
+				endpointSliceList := &discoveryv1.EndpointSliceList{}
+				Expect(ctx.Client.List(ctx, endpointSliceList, client.InNamespace(vmService.Namespace))).To(Succeed())
+				Expect(endpointSliceList.Items).To(BeEmpty())
+
 				service := &corev1.Service{}
 				err = ctx.Client.Get(ctx, objKey, service)
 				Expect(errors.IsNotFound(err)).To(BeTrue())
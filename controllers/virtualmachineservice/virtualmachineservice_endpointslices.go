@@ -0,0 +1,238 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachineservice
+
+import (
+	"fmt"
+	"hash/fnv"
+	"net"
+	"sort"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/ptr"
+)
+
+const (
+	// endpointSliceManagedBy is the value of the discoveryv1.LabelManagedBy
+	// label on the EndpointSlices created for a VirtualMachineService.
+	endpointSliceManagedBy = "vmoperator.vmware.com/virtualmachineservice-controller"
+
+	// maxEndpointsPerSlice is the maximum number of endpoints in an
+	// EndpointSlice, which is the most the API server allows.
+	maxEndpointsPerSlice = 1000
+)
+
+// endpointSliceKey identifies the EndpointSlice that an endpoint belongs to,
+// since all the endpoints in an EndpointSlice have the same address type and
+// ports.
+type endpointSliceKey struct {
+	addressType discoveryv1.AddressType
+	ports       string
+}
+
+// createOrUpdateEndpointSlices publishes the EndpointSlices for the Service.
+// Unlike the Endpoints, VMs that are being deleted or powered off are included
+// in the EndpointSlices as terminating so load balancers may drain them.
+func (r *ReconcileVirtualMachineService) createOrUpdateEndpointSlices(
+	ctx *pkgctx.VirtualMachineServiceContext,
+	service *corev1.Service,
+	vmEndpoints []vmEndpoint) error {
+
+	vmEndpointsByKey := map[endpointSliceKey][]vmEndpoint{}
+	var keys []endpointSliceKey
+
+	for _, vmEndpoint := range vmEndpoints {
+		key := endpointSliceKey{
+			addressType: getEndpointAddressType(vmEndpoint.ip),
+			ports:       fmt.Sprintf("%v", vmEndpoint.ports),
+		}
+
+		if _, ok := vmEndpointsByKey[key]; !ok {
+			keys = append(keys, key)
+		}
+		vmEndpointsByKey[key] = append(vmEndpointsByKey[key], vmEndpoint)
+	}
+
+	// Each address type and ports combination is split into as many EndpointSlices as needed
+	// to stay within maxEndpointsPerSlice. The endpoints are sorted by VM name so that a VM
+	// stays in the same EndpointSlice across reconciles.
+	var desiredSlices []*discoveryv1.EndpointSlice
+	for _, key := range keys {
+		keyEndpoints := vmEndpointsByKey[key]
+		sort.SliceStable(keyEndpoints, func(i, j int) bool {
+			return keyEndpoints[i].vm.Name < keyEndpoints[j].vm.Name
+		})
+
+		for index := 0; index*maxEndpointsPerSlice < len(keyEndpoints); index++ {
+			chunk := keyEndpoints[index*maxEndpointsPerSlice : min((index+1)*maxEndpointsPerSlice, len(keyEndpoints))]
+
+			endpointSlice := &discoveryv1.EndpointSlice{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      getEndpointSliceName(service, key, index),
+					Namespace: service.Namespace,
+				},
+				AddressType: key.addressType,
+				Ports:       toEndpointSlicePorts(chunk[0].ports),
+				Endpoints:   make([]discoveryv1.Endpoint, 0, len(chunk)),
+			}
+			for _, vmEndpoint := range chunk {
+				endpointSlice.Endpoints = append(endpointSlice.Endpoints, toEndpointSliceEndpoint(vmEndpoint))
+			}
+
+			desiredSlices = append(desiredSlices, endpointSlice)
+		}
+	}
+
+	desired := sets.New[string]()
+	for _, desiredSlice := range desiredSlices {
+		desired.Insert(desiredSlice.Name)
+
+		endpointSlice := &discoveryv1.EndpointSlice{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      desiredSlice.Name,
+				Namespace: desiredSlice.Namespace,
+			},
+		}
+
+		result, err := controllerutil.CreateOrPatch(ctx, r.Client, endpointSlice, func() error {
+			if err := controllerutil.SetControllerReference(ctx.VMService, endpointSlice, r.Client.Scheme()); err != nil {
+				return err
+			}
+
+			if endpointSlice.Labels == nil {
+				endpointSlice.Labels = map[string]string{}
+			}
+			for k, v := range endpointSliceLabels(ctx) {
+				endpointSlice.Labels[k] = v
+			}
+			endpointSlice.AddressType = desiredSlice.AddressType
+			endpointSlice.Endpoints = desiredSlice.Endpoints
+			endpointSlice.Ports = desiredSlice.Ports
+			return nil
+		})
+
+		if err != nil {
+			return err
+		}
+
+		switch result {
+		case controllerutil.OperationResultCreated:
+			ctx.Logger.Info("Creating Service EndpointSlice", "endpointSlice", endpointSlice.Name)
+		case controllerutil.OperationResultUpdated:
+			ctx.Logger.Info("Updating Service EndpointSlice", "endpointSlice", endpointSlice.Name)
+		}
+	}
+
+	// Remove the EndpointSlices whose address type or ports no longer have any endpoints, or
+	// that are no longer needed because there are fewer endpoints.
+	endpointSliceList := &discoveryv1.EndpointSliceList{}
+	if err := r.List(ctx, endpointSliceList,
+		client.InNamespace(ctx.VMService.Namespace), client.MatchingLabels(endpointSliceLabels(ctx))); err != nil {
+		return err
+	}
+
+	for i := range endpointSliceList.Items {
+		endpointSlice := &endpointSliceList.Items[i]
+		if desired.Has(endpointSlice.Name) {
+			continue
+		}
+
+		ctx.Logger.Info("Deleting Service EndpointSlice", "endpointSlice", endpointSlice.Name)
+		if err := r.Delete(ctx, endpointSlice); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// deleteEndpointSlices deletes the EndpointSlices for the VirtualMachineService.
+func (r *ReconcileVirtualMachineService) deleteEndpointSlices(ctx *pkgctx.VirtualMachineServiceContext) error {
+	return client.IgnoreNotFound(r.DeleteAllOf(ctx, &discoveryv1.EndpointSlice{},
+		client.InNamespace(ctx.VMService.Namespace), client.MatchingLabels(endpointSliceLabels(ctx))))
+}
+
+// endpointSliceLabels returns the labels that identify the EndpointSlices for
+// the VirtualMachineService.
+func endpointSliceLabels(ctx *pkgctx.VirtualMachineServiceContext) map[string]string {
+	return map[string]string{
+		discoveryv1.LabelServiceName: ctx.VMService.Name,
+		discoveryv1.LabelManagedBy:   endpointSliceManagedBy,
+	}
+}
+
+// getEndpointSliceName returns a stable name for the Service's EndpointSlice
+// with the given key and index.
+func getEndpointSliceName(service *corev1.Service, key endpointSliceKey, index int) string {
+	hasher := fnv.New32a()
+	_, _ = hasher.Write([]byte(string(key.addressType) + "/" + key.ports))
+	if index > 0 {
+		_, _ = hasher.Write([]byte("/" + strconv.Itoa(index)))
+	}
+	return fmt.Sprintf("%s-%s", service.Name, utilrand.SafeEncodeString(fmt.Sprint(hasher.Sum32())))
+}
+
+func getEndpointAddressType(ip string) discoveryv1.AddressType {
+	if parsed := net.ParseIP(ip); parsed != nil && parsed.To4() == nil {
+		return discoveryv1.AddressTypeIPv6
+	}
+	return discoveryv1.AddressTypeIPv4
+}
+
+func toEndpointSlicePorts(ports []corev1.EndpointPort) []discoveryv1.EndpointPort {
+	slicePorts := make([]discoveryv1.EndpointPort, 0, len(ports))
+	for _, port := range ports {
+		slicePorts = append(slicePorts, discoveryv1.EndpointPort{
+			Name:     ptr.To(port.Name),
+			Protocol: ptr.To(port.Protocol),
+			Port:     ptr.To(port.Port),
+		})
+	}
+	sort.Slice(slicePorts, func(i, j int) bool {
+		return *slicePorts[i].Name < *slicePorts[j].Name
+	})
+	return slicePorts
+}
+
+// toEndpointSliceEndpoint returns the EndpointSlice endpoint for the VM. When
+// the VM's zone is known, the endpoint is hinted to be consumed from its zone
+// so that topology aware routing keeps traffic within the zone.
+func toEndpointSliceEndpoint(vmEndpoint vmEndpoint) discoveryv1.Endpoint {
+	vm := vmEndpoint.vm
+
+	endpoint := discoveryv1.Endpoint{
+		Addresses: []string{vmEndpoint.ip},
+		Conditions: discoveryv1.EndpointConditions{
+			Ready:       ptr.To(vmEndpoint.serving && !vmEndpoint.terminating),
+			Serving:     ptr.To(vmEndpoint.serving),
+			Terminating: ptr.To(vmEndpoint.terminating),
+		},
+		TargetRef: &corev1.ObjectReference{
+			APIVersion: vm.APIVersion,
+			Kind:       vm.Kind,
+			Namespace:  vm.Namespace,
+			Name:       vm.Name,
+			UID:        vm.UID,
+		},
+	}
+
+	if zone := vm.Status.Zone; zone != "" {
+		endpoint.Zone = ptr.To(zone)
+		endpoint.Hints = &discoveryv1.EndpointHints{
+			ForZones: []discoveryv1.ForZone{{Name: zone}},
+		}
+	}
+
+	return endpoint
+}
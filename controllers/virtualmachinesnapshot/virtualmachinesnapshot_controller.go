@@ -0,0 +1,282 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachinesnapshot
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	vmopv1common "github.com/vmware-tanzu/vm-operator/api/v1alpha3/common"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/pkg/patch"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers"
+	"github.com/vmware-tanzu/vm-operator/pkg/record"
+)
+
+const (
+	finalizerName = "virtualmachinesnapshot.vmoperator.vmware.com"
+)
+
+// AddToManager adds this package's controller to the provided manager.
+func AddToManager(ctx *pkgctx.ControllerManagerContext, mgr manager.Manager) error {
+	var (
+		controlledType     = &vmopv1.VirtualMachineSnapshot{}
+		controlledTypeName = reflect.TypeOf(controlledType).Elem().Name()
+
+		controllerNameShort = fmt.Sprintf("%s-controller", strings.ToLower(controlledTypeName))
+		controllerNameLong  = fmt.Sprintf("%s/%s/%s", ctx.Namespace, ctx.Name, controllerNameShort)
+	)
+
+	r := NewReconciler(
+		ctx,
+		mgr.GetClient(),
+		ctrl.Log.WithName("controllers").WithName(controlledTypeName),
+		record.New(mgr.GetEventRecorderFor(controllerNameLong)),
+		ctx.VMProvider,
+	)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(controlledType).
+		Watches(&vmopv1.VirtualMachine{},
+			handler.EnqueueRequestsFromMapFunc(r.VMToSnapshots(ctx)),
+		).
+		WithOptions(controller.Options{MaxConcurrentReconciles: ctx.MaxConcurrentReconciles}).
+		Complete(r)
+}
+
+// VMToSnapshots is a mapper function to be used to enqueue requests for
+// reconciliation for the VirtualMachineSnapshots of a VM. This ensures the
+// status of each snapshot reflects changes to the VM's snapshot tree.
+func (r *Reconciler) VMToSnapshots(
+	ctx *pkgctx.ControllerManagerContext) func(_ context.Context, o client.Object) []reconcile.Request {
+
+	return func(_ context.Context, o client.Object) []reconcile.Request {
+		vm, ok := o.(*vmopv1.VirtualMachine)
+		if !ok {
+			panic(fmt.Sprintf("Expected a VirtualMachine, but got a %T", o))
+		}
+
+		snapshotList := &vmopv1.VirtualMachineSnapshotList{}
+		if err := r.Client.List(ctx, snapshotList, client.InNamespace(vm.Namespace)); err != nil {
+			ctx.Logger.Error(err, "Failed to list VirtualMachineSnapshots for VM")
+			return nil
+		}
+
+		var result []reconcile.Request
+		for _, s := range snapshotList.Items {
+			if s.Spec.VMRef != nil && s.Spec.VMRef.Name == vm.Name {
+				name := client.ObjectKey{Name: s.Name, Namespace: s.Namespace}
+				result = append(result, reconcile.Request{NamespacedName: name})
+			}
+		}
+
+		return result
+	}
+}
+
+func NewReconciler(
+	ctx context.Context,
+	client client.Client,
+	logger logr.Logger,
+	recorder record.Recorder,
+	vmProvider providers.VirtualMachineProviderInterface) *Reconciler {
+
+	return &Reconciler{
+		Context:    ctx,
+		Client:     client,
+		Logger:     logger,
+		Recorder:   recorder,
+		VMProvider: vmProvider,
+	}
+}
+
+// Reconciler reconciles a VirtualMachineSnapshot object.
+type Reconciler struct {
+	client.Client
+	Context    context.Context
+	Logger     logr.Logger
+	Recorder   record.Recorder
+	VMProvider providers.VirtualMachineProviderInterface
+}
+
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachinesnapshots,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachinesnapshots/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachines,verbs=get;list;watch;update;patch
+
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
+	ctx = pkgcfg.JoinContext(ctx, r.Context)
+
+	vmSnapshot := &vmopv1.VirtualMachineSnapshot{}
+	if err := r.Get(ctx, req.NamespacedName, vmSnapshot); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	snapshotCtx := &pkgctx.VirtualMachineSnapshotContext{
+		Context:                ctx,
+		Logger:                 ctrl.Log.WithName("VirtualMachineSnapshot").WithValues("name", req.NamespacedName),
+		VirtualMachineSnapshot: vmSnapshot,
+	}
+
+	patchHelper, err := patch.NewHelper(vmSnapshot, r.Client)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to init patch helper for %s: %w", snapshotCtx, err)
+	}
+	defer func() {
+		if err := patchHelper.Patch(ctx, vmSnapshot); err != nil {
+			if reterr == nil {
+				reterr = err
+			}
+			snapshotCtx.Logger.Error(err, "patch failed")
+		}
+	}()
+
+	if !vmSnapshot.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, r.ReconcileDelete(snapshotCtx)
+	}
+
+	return ctrl.Result{}, r.ReconcileNormal(snapshotCtx)
+}
+
+// getVM returns the VM referenced by the snapshot, or nil if the VM does not
+// exist.
+func (r *Reconciler) getVM(ctx *pkgctx.VirtualMachineSnapshotContext) (*vmopv1.VirtualMachine, error) {
+	vmRef := ctx.VirtualMachineSnapshot.Spec.VMRef
+	if vmRef == nil || vmRef.Name == "" {
+		return nil, nil
+	}
+
+	vm := &vmopv1.VirtualMachine{}
+	key := client.ObjectKey{Namespace: ctx.VirtualMachineSnapshot.Namespace, Name: vmRef.Name}
+	if err := r.Get(ctx, key, vm); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get VirtualMachine %s: %w", key, err)
+	}
+
+	return vm, nil
+}
+
+func (r *Reconciler) ReconcileDelete(ctx *pkgctx.VirtualMachineSnapshotContext) error {
+	if !controllerutil.ContainsFinalizer(ctx.VirtualMachineSnapshot, finalizerName) {
+		return nil
+	}
+
+	ctx.Logger.Info("Reconciling VirtualMachineSnapshot Deletion")
+
+	vm, err := r.getVM(ctx)
+	if err != nil {
+		return err
+	}
+
+	if vm != nil && vm.Status.UniqueID != "" {
+		ctx.VM = vm
+		if err := r.VMProvider.DeleteVirtualMachineSnapshot(ctx, vm, ctx.VirtualMachineSnapshot); err != nil {
+			r.Recorder.EmitEvent(ctx.VirtualMachineSnapshot, "Delete", err, false)
+			return fmt.Errorf("failed to delete snapshot: %w", err)
+		}
+	}
+
+	r.Recorder.EmitEvent(ctx.VirtualMachineSnapshot, "Delete", nil, false)
+	controllerutil.RemoveFinalizer(ctx.VirtualMachineSnapshot, finalizerName)
+
+	return nil
+}
+
+func (r *Reconciler) ReconcileNormal(ctx *pkgctx.VirtualMachineSnapshotContext) error {
+	if !controllerutil.ContainsFinalizer(ctx.VirtualMachineSnapshot, finalizerName) {
+		// Set the finalizer and return so the object is patched immediately.
+		controllerutil.AddFinalizer(ctx.VirtualMachineSnapshot, finalizerName)
+		return nil
+	}
+
+	ctx.Logger.Info("Reconciling VirtualMachineSnapshot")
+
+	vm, err := r.getVM(ctx)
+	if err != nil {
+		return err
+	}
+
+	if vm == nil {
+		conditions.MarkFalse(
+			ctx.VirtualMachineSnapshot,
+			vmopv1.VirtualMachineSnapshotReadyCondition,
+			vmopv1.VirtualMachineSnapshotVMNotFoundReason,
+			"VirtualMachine not found")
+		return nil
+	}
+	ctx.VM = vm
+
+	if vm.Status.UniqueID == "" {
+		// The snapshot will be reconciled again when the VM's status changes.
+		conditions.MarkFalse(
+			ctx.VirtualMachineSnapshot,
+			vmopv1.VirtualMachineSnapshotReadyCondition,
+			vmopv1.VirtualMachineSnapshotCreatingReason,
+			"Waiting for VirtualMachine to be created")
+		return nil
+	}
+
+	if err := controllerutil.SetOwnerReference(vm, ctx.VirtualMachineSnapshot, r.Scheme()); err != nil {
+		return err
+	}
+
+	if ctx.VirtualMachineSnapshot.Status.UniqueID == "" {
+		if err := r.reconcileVMCurrentSnapshot(ctx); err != nil {
+			return err
+		}
+	}
+
+	if err := r.VMProvider.CreateOrUpdateVirtualMachineSnapshot(ctx, vm, ctx.VirtualMachineSnapshot); err != nil {
+		conditions.MarkFalse(
+			ctx.VirtualMachineSnapshot,
+			vmopv1.VirtualMachineSnapshotReadyCondition,
+			vmopv1.VirtualMachineSnapshotFailedReason,
+			"%s", err)
+		return fmt.Errorf("failed to create snapshot: %w", err)
+	}
+
+	conditions.MarkTrue(ctx.VirtualMachineSnapshot, vmopv1.VirtualMachineSnapshotReadyCondition)
+
+	return nil
+}
+
+// reconcileVMCurrentSnapshot updates the VM's spec.currentSnapshot to refer to
+// the snapshot that is about to be taken, since that snapshot becomes the VM's
+// current snapshot. Otherwise the VM would be reverted to the snapshot named by
+// the field. The field is left alone if it is not set.
+func (r *Reconciler) reconcileVMCurrentSnapshot(ctx *pkgctx.VirtualMachineSnapshotContext) error {
+	vm := ctx.VM
+	if vm.Spec.CurrentSnapshot == nil || vm.Spec.CurrentSnapshot.Name == ctx.VirtualMachineSnapshot.Name {
+		return nil
+	}
+
+	vmPatch := client.MergeFrom(vm.DeepCopy())
+	vm.Spec.CurrentSnapshot = &vmopv1common.LocalObjectRef{
+		APIVersion: vmopv1.GroupVersion.String(),
+		Kind:       "VirtualMachineSnapshot",
+		Name:       ctx.VirtualMachineSnapshot.Name,
+	}
+	if err := r.Client.Patch(ctx, vm, vmPatch); err != nil {
+		return fmt.Errorf("failed to update VirtualMachine current snapshot: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,255 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachinesnapshot_test
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	vmopv1common "github.com/vmware-tanzu/vm-operator/api/v1alpha3/common"
+	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachinesnapshot"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	providerfake "github.com/vmware-tanzu/vm-operator/pkg/providers/fake"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+const finalizerName = "virtualmachinesnapshot.vmoperator.vmware.com"
+
+func unitTests() {
+	Describe(
+		"Reconcile",
+		Label(
+			testlabels.Controller,
+		),
+		unitTestsReconcile,
+	)
+}
+
+func unitTestsReconcile() {
+	var (
+		initObjects    []client.Object
+		ctx            *builder.UnitTestContextForController
+		fakeVMProvider *providerfake.VMProvider
+
+		reconciler  *virtualmachinesnapshot.Reconciler
+		snapshotCtx *pkgctx.VirtualMachineSnapshotContext
+		vm          *vmopv1.VirtualMachine
+		vmSnapshot  *vmopv1.VirtualMachineSnapshot
+	)
+
+	BeforeEach(func() {
+		vm = builder.DummyBasicVirtualMachine("dummy-vm", "dummy-ns")
+		vm.Status.UniqueID = "vm-42"
+
+		vmSnapshot = builder.DummyVirtualMachineSnapshot(vm.Namespace, "dummy-snapshot", vm.Name)
+		vmSnapshot.Finalizers = []string{finalizerName}
+	})
+
+	JustBeforeEach(func() {
+		ctx = suite.NewUnitTestContextForController(initObjects...)
+		reconciler = virtualmachinesnapshot.NewReconciler(
+			ctx,
+			ctx.Client,
+			ctx.Logger,
+			ctx.Recorder,
+			ctx.VMProvider,
+		)
+		fakeVMProvider = ctx.VMProvider.(*providerfake.VMProvider)
+
+		snapshotCtx = &pkgctx.VirtualMachineSnapshotContext{
+			Context:                ctx,
+			Logger:                 ctx.Logger.WithName(vmSnapshot.Name),
+			VirtualMachineSnapshot: vmSnapshot,
+		}
+	})
+
+	AfterEach(func() {
+		ctx.AfterEach()
+		ctx = nil
+		initObjects = nil
+		reconciler = nil
+		fakeVMProvider.Reset()
+	})
+
+	Context("ReconcileNormal", func() {
+
+		When("the snapshot does not have the finalizer", func() {
+			BeforeEach(func() {
+				vmSnapshot.Finalizers = nil
+				initObjects = append(initObjects, vm, vmSnapshot)
+			})
+
+			It("adds the finalizer", func() {
+				Expect(reconciler.ReconcileNormal(snapshotCtx)).To(Succeed())
+				Expect(controllerutil.ContainsFinalizer(vmSnapshot, finalizerName)).To(BeTrue())
+			})
+		})
+
+		When("the VM does not exist", func() {
+			BeforeEach(func() {
+				initObjects = append(initObjects, vmSnapshot)
+			})
+
+			It("marks the snapshot not ready", func() {
+				Expect(reconciler.ReconcileNormal(snapshotCtx)).To(Succeed())
+				c := conditions.Get(vmSnapshot, vmopv1.VirtualMachineSnapshotReadyCondition)
+				Expect(c).ToNot(BeNil())
+				Expect(c.Status).To(Equal(metav1.ConditionFalse))
+				Expect(c.Reason).To(Equal(vmopv1.VirtualMachineSnapshotVMNotFoundReason))
+			})
+		})
+
+		When("the VM has not been created", func() {
+			BeforeEach(func() {
+				vm.Status.UniqueID = ""
+				initObjects = append(initObjects, vm, vmSnapshot)
+			})
+
+			It("does not take the snapshot", func() {
+				called := false
+				fakeVMProvider.CreateOrUpdateVirtualMachineSnapshotFn = func(
+					_ context.Context, _ *vmopv1.VirtualMachine, _ *vmopv1.VirtualMachineSnapshot) error {
+					called = true
+					return nil
+				}
+
+				Expect(reconciler.ReconcileNormal(snapshotCtx)).To(Succeed())
+				Expect(called).To(BeFalse())
+				Expect(conditions.GetReason(vmSnapshot, vmopv1.VirtualMachineSnapshotReadyCondition)).To(
+					Equal(vmopv1.VirtualMachineSnapshotCreatingReason))
+			})
+		})
+
+		When("the VM exists", func() {
+			BeforeEach(func() {
+				initObjects = append(initObjects, vm, vmSnapshot)
+			})
+
+			When("the provider takes the snapshot", func() {
+				JustBeforeEach(func() {
+					fakeVMProvider.CreateOrUpdateVirtualMachineSnapshotFn = func(
+						_ context.Context, _ *vmopv1.VirtualMachine, s *vmopv1.VirtualMachineSnapshot) error {
+						s.Status.UniqueID = "snapshot-1"
+						s.Status.PowerState = vmopv1.VirtualMachinePowerStateOn
+						return nil
+					}
+				})
+
+				It("updates the status and sets the VM as an owner", func() {
+					Expect(reconciler.ReconcileNormal(snapshotCtx)).To(Succeed())
+					Expect(vmSnapshot.Status.UniqueID).To(Equal("snapshot-1"))
+					Expect(vmSnapshot.Status.PowerState).To(Equal(vmopv1.VirtualMachinePowerStateOn))
+					Expect(conditions.IsTrue(vmSnapshot, vmopv1.VirtualMachineSnapshotReadyCondition)).To(BeTrue())
+					Expect(vmSnapshot.OwnerReferences).To(HaveLen(1))
+					Expect(vmSnapshot.OwnerReferences[0].Name).To(Equal(vm.Name))
+				})
+
+				When("the VM's current snapshot is set", func() {
+					BeforeEach(func() {
+						vm.Spec.CurrentSnapshot = &vmopv1common.LocalObjectRef{
+							APIVersion: vmopv1.GroupVersion.String(),
+							Kind:       "VirtualMachineSnapshot",
+							Name:       "older-snapshot",
+						}
+					})
+
+					It("updates the VM's current snapshot to the new snapshot", func() {
+						Expect(reconciler.ReconcileNormal(snapshotCtx)).To(Succeed())
+
+						obj := &vmopv1.VirtualMachine{}
+						Expect(ctx.Client.Get(ctx, client.ObjectKeyFromObject(vm), obj)).To(Succeed())
+						Expect(obj.Spec.CurrentSnapshot).ToNot(BeNil())
+						Expect(obj.Spec.CurrentSnapshot.Name).To(Equal(vmSnapshot.Name))
+					})
+				})
+
+				When("the VM's current snapshot is not set", func() {
+					It("does not update the VM's current snapshot", func() {
+						Expect(reconciler.ReconcileNormal(snapshotCtx)).To(Succeed())
+
+						obj := &vmopv1.VirtualMachine{}
+						Expect(ctx.Client.Get(ctx, client.ObjectKeyFromObject(vm), obj)).To(Succeed())
+						Expect(obj.Spec.CurrentSnapshot).To(BeNil())
+					})
+				})
+			})
+
+			When("the provider returns an error", func() {
+				JustBeforeEach(func() {
+					fakeVMProvider.CreateOrUpdateVirtualMachineSnapshotFn = func(
+						_ context.Context, _ *vmopv1.VirtualMachine, _ *vmopv1.VirtualMachineSnapshot) error {
+						return errors.New("fake")
+					}
+				})
+
+				It("returns the error and marks the snapshot not ready", func() {
+					err := reconciler.ReconcileNormal(snapshotCtx)
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("fake"))
+					Expect(conditions.GetReason(vmSnapshot, vmopv1.VirtualMachineSnapshotReadyCondition)).To(
+						Equal(vmopv1.VirtualMachineSnapshotFailedReason))
+				})
+			})
+		})
+	})
+
+	Context("ReconcileDelete", func() {
+
+		When("the VM exists", func() {
+			BeforeEach(func() {
+				initObjects = append(initObjects, vm, vmSnapshot)
+			})
+
+			It("deletes the snapshot and removes the finalizer", func() {
+				called := false
+				fakeVMProvider.DeleteVirtualMachineSnapshotFn = func(
+					_ context.Context, _ *vmopv1.VirtualMachine, _ *vmopv1.VirtualMachineSnapshot) error {
+					called = true
+					return nil
+				}
+
+				Expect(reconciler.ReconcileDelete(snapshotCtx)).To(Succeed())
+				Expect(called).To(BeTrue())
+				Expect(controllerutil.ContainsFinalizer(vmSnapshot, finalizerName)).To(BeFalse())
+			})
+
+			It("keeps the finalizer when the provider returns an error", func() {
+				fakeVMProvider.DeleteVirtualMachineSnapshotFn = func(
+					_ context.Context, _ *vmopv1.VirtualMachine, _ *vmopv1.VirtualMachineSnapshot) error {
+					return errors.New("fake")
+				}
+
+				Expect(reconciler.ReconcileDelete(snapshotCtx)).ToNot(Succeed())
+				Expect(controllerutil.ContainsFinalizer(vmSnapshot, finalizerName)).To(BeTrue())
+			})
+		})
+
+		When("the VM does not exist", func() {
+			BeforeEach(func() {
+				initObjects = append(initObjects, vmSnapshot)
+			})
+
+			It("removes the finalizer", func() {
+				fakeVMProvider.DeleteVirtualMachineSnapshotFn = func(
+					_ context.Context, _ *vmopv1.VirtualMachine, _ *vmopv1.VirtualMachineSnapshot) error {
+					return errors.New("should not be called")
+				}
+
+				Expect(reconciler.ReconcileDelete(snapshotCtx)).To(Succeed())
+				Expect(controllerutil.ContainsFinalizer(vmSnapshot, finalizerName)).To(BeFalse())
+			})
+		})
+	})
+}
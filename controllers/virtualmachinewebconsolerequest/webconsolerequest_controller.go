@@ -6,7 +6,8 @@ package virtualmachinewebconsolerequest
 
 import (
 	"context"
-
+	"crypto/rand"
+	"encoding/base64"
 	"fmt"
 	"reflect"
 	"strings"
@@ -26,6 +27,7 @@ import (
 	"github.com/vmware-tanzu/vm-operator/pkg/providers"
 	"github.com/vmware-tanzu/vm-operator/pkg/record"
 	"github.com/vmware-tanzu/vm-operator/pkg/util/kube/proxyaddr"
+	"github.com/vmware-tanzu/vm-operator/pkg/webconsolevalidation"
 )
 
 const (
@@ -168,9 +170,30 @@ func (r *Reconciler) ReconcileNormal(ctx *pkgctx.WebConsoleRequestContextV1) err
 		ctx.Logger.Info("Finished reconciling WebConsoleRequest")
 	}()
 
-	ticket, err := r.VMProvider.GetVirtualMachineWebMKSTicket(ctx, ctx.VM, ctx.WebConsoleRequest.Spec.PublicKey)
-	if err != nil {
-		return fmt.Errorf("failed to get webmksticket: %w", err)
+	var ticket string
+	switch ctx.WebConsoleRequest.Spec.ConsoleType {
+	case vmopv1.VirtualMachineWebConsoleTypeSerial:
+		token, err := newSerialToken()
+		if err != nil {
+			return fmt.Errorf("failed to generate serial console token: %w", err)
+		}
+		ticket, err = r.VMProvider.GetVirtualMachineSerialConsoleTicket(ctx, ctx.VM, ctx.WebConsoleRequest.Spec.PublicKey, token)
+		if err != nil {
+			return fmt.Errorf("failed to get serial console ticket: %w", err)
+		}
+
+		// Only the hash of the token is stored. The token is used by the
+		// proxy when validating the connection request to the serial console.
+		if ctx.WebConsoleRequest.Annotations == nil {
+			ctx.WebConsoleRequest.Annotations = make(map[string]string)
+		}
+		ctx.WebConsoleRequest.Annotations[webconsolevalidation.SerialTokenHashAnnotationKey] = webconsolevalidation.HashSerialToken(token)
+	default:
+		var err error
+		ticket, err = r.VMProvider.GetVirtualMachineWebMKSTicket(ctx, ctx.VM, ctx.WebConsoleRequest.Spec.PublicKey)
+		if err != nil {
+			return fmt.Errorf("failed to get webmksticket: %w", err)
+		}
 	}
 	r.Recorder.EmitEvent(ctx.WebConsoleRequest, "Acquired Ticket", nil, false)
 
@@ -211,3 +234,13 @@ func (r *Reconciler) ReconcileOwnerReferences(ctx *pkgctx.WebConsoleRequestConte
 	ctx.WebConsoleRequest.SetOwnerReferences([]metav1.OwnerReference{ownerRef})
 	return nil
 }
+
+// newSerialToken returns a random token used to connect to a VM's serial
+// console.
+func newSerialToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
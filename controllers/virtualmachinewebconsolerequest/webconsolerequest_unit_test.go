@@ -6,6 +6,7 @@ package virtualmachinewebconsolerequest_test
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -22,6 +23,7 @@ import (
 	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
 	providerfake "github.com/vmware-tanzu/vm-operator/pkg/providers/fake"
 	proxyaddr "github.com/vmware-tanzu/vm-operator/pkg/util/kube/proxyaddr"
+	"github.com/vmware-tanzu/vm-operator/pkg/webconsolevalidation"
 	"github.com/vmware-tanzu/vm-operator/test/builder"
 )
 
@@ -136,6 +138,38 @@ func unitTestsReconcile() {
 			})
 		})
 
+		When("the console type is Serial", func() {
+			const serialTicket = "my-fake-serial-ticket"
+
+			var token string
+
+			BeforeEach(func() {
+				wcr.Spec.ConsoleType = vmopv1.VirtualMachineWebConsoleTypeSerial
+			})
+
+			JustBeforeEach(func() {
+				fakeVMProvider.GetVirtualMachineWebMKSTicketFn = func(ctx context.Context, vm *vmopv1.VirtualMachine, pubKey string) (string, error) {
+					return "", errors.New("unexpected call")
+				}
+				fakeVMProvider.GetVirtualMachineSerialConsoleTicketFn = func(ctx context.Context, vm *vmopv1.VirtualMachine, pubKey, t string) (string, error) {
+					token = t
+					return serialTicket, nil
+				}
+			})
+
+			It("returns success", func() {
+				err := reconciler.ReconcileNormal(wcrCtx)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(wcrCtx.WebConsoleRequest.Status.ProxyAddr).To(Equal("dummy-proxy-ip"))
+				Expect(wcrCtx.WebConsoleRequest.Status.Response).To(Equal(serialTicket))
+				Expect(token).ToNot(BeEmpty())
+				Expect(wcrCtx.WebConsoleRequest.Annotations).To(HaveKeyWithValue(
+					webconsolevalidation.SerialTokenHashAnnotationKey,
+					webconsolevalidation.HashSerialToken(token)))
+			})
+		})
+
 		When("Web Console returns correct proxy address", func() {
 
 			DescribeTable("DNS Names",
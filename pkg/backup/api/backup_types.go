@@ -94,6 +94,11 @@ const (
 	// on the VM resource in Supervisor indicate whether the backups are in sync.
 	BackupVersionExtraConfigKey = "vmservice.virtualmachine.backupVersion"
 
+	// BackupPolicyHashExtraConfigKey is the ExtraConfig key to persist the
+	// hash of the VirtualMachineBackupPolicy that applied to the VM's last
+	// backup. The VM is backed up again when the hash changes.
+	BackupPolicyHashExtraConfigKey = "vmservice.virtualmachine.backupPolicyHash"
+
 	// DisableAutoRegistrationExtraConfigKey is the ExtraConfig key that can be
 	// set to "true" (case insensitive) on a virtual machine to opt-out of the
 	// automatic registration workflow.
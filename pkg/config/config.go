@@ -113,6 +113,38 @@ type Config struct {
 	// Defaults to true.
 	AsyncSignalEnabled bool
 
+	// AsyncSignalWatchedPropertyPaths is a comma-delimited list of the vSphere
+	// VM property paths watched by the vm-watcher service. When empty, the
+	// watcher's default property paths are used. The property paths the
+	// watcher requires, ex. config.extraConfig, are always watched.
+	//
+	// For information as to why this field is not a []string, please see the
+	// GoDocs for the Config type.
+	AsyncSignalWatchedPropertyPaths string
+
+	// AsyncSignalIgnoredExtraConfigKeys is a comma-delimited list of
+	// ExtraConfig keys whose changes are ignored by the vm-watcher service, in
+	// addition to the watcher's default ignored keys.
+	//
+	// For information as to why this field is not a []string, please see the
+	// GoDocs for the Config type.
+	AsyncSignalIgnoredExtraConfigKeys string
+
+	// AsyncSignalNamespaceSelector is a label selector, ex. "shard=a", that
+	// selects the namespaces whose VMs are watched by the vm-watcher service
+	// and reconciled by the VirtualMachine controller. This allows the
+	// namespaces to be split across multiple instances of VM Operator, each
+	// with its own PropertyCollector.
+	//
+	// Each instance, or shard, is a separate deployment of VM Operator with
+	// its own LeaderElectionID, so every shard has a leader that runs its
+	// vm-watcher service and VirtualMachine controller. The selectors of the
+	// shards must not overlap, and together must select every namespace with
+	// VMs.
+	//
+	// Defaults to "", which selects all namespaces.
+	AsyncSignalNamespaceSelector string
+
 	// AsyncCreateEnabled may be set to false to disable non-blocking create
 	// operations.
 	//
@@ -143,6 +175,13 @@ type Config struct {
 	//
 	// Defaults to "direct".
 	FastDeployMode string
+
+	// SerialConsoleProxyURI is the URI of the virtual serial port
+	// concentrator, ex. telnets://vspc.example.com:13370, used to access the
+	// serial console of VMs via a VirtualMachineWebConsoleRequest.
+	//
+	// Defaults to "", which means serial consoles are not available.
+	SerialConsoleProxyURI string
 }
 
 // GetMaxDeployThreadsOnProvider returns MaxDeployThreadsOnProvider if it is >0
@@ -174,6 +213,11 @@ type FeatureStates struct {
 	BringYourOwnEncryptionKey bool // FSS_WCP_VMSERVICE_BYOK
 	SVAsyncUpgrade            bool // FSS_WCP_SUPERVISOR_ASYNC_UPGRADE
 	FastDeploy                bool // FSS_WCP_VMSERVICE_FAST_DEPLOY
+	VMSnapshots               bool // FSS_WCP_VMSERVICE_VM_SNAPSHOTS
+	VMNetworkHotPlug          bool // FSS_WCP_VMSERVICE_NETWORK_HOTPLUG
+	VMRestoreRequest          bool // FSS_WCP_VMSERVICE_RESTORE_REQUEST
+	VMEphemeralVolumes        bool // FSS_WCP_VMSERVICE_EPHEMERAL_VOLUMES
+	VMBackupPolicy            bool // FSS_WCP_VMSERVICE_BACKUP_POLICY
 }
 
 type InstanceStorage struct {
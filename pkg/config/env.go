@@ -30,9 +30,13 @@ func FromEnv() Config {
 	setStringSlice(env.PrivilegedUsers, &config.PrivilegedUsers)
 	setBool(env.LogSensitiveData, &config.LogSensitiveData)
 	setBool(env.AsyncSignalEnabled, &config.AsyncSignalEnabled)
+	setStringSlice(env.AsyncSignalWatchedPropertyPaths, &config.AsyncSignalWatchedPropertyPaths)
+	setStringSlice(env.AsyncSignalIgnoredExtraConfigKeys, &config.AsyncSignalIgnoredExtraConfigKeys)
+	setString(env.AsyncSignalNamespaceSelector, &config.AsyncSignalNamespaceSelector)
 	setBool(env.AsyncCreateEnabled, &config.AsyncCreateEnabled)
 	setDuration(env.MemStatsPeriod, &config.MemStatsPeriod)
 	setString(env.FastDeployMode, &config.FastDeployMode)
+	setString(env.SerialConsoleProxyURI, &config.SerialConsoleProxyURI)
 
 	setDuration(env.InstanceStoragePVPlacementFailedTTL, &config.InstanceStorage.PVPlacementFailedTTL)
 	setFloat64(env.InstanceStorageJitterMaxFactor, &config.InstanceStorage.JitterMaxFactor)
@@ -68,6 +72,11 @@ func FromEnv() Config {
 	setBool(env.FSSBringYourOwnEncryptionKey, &config.Features.BringYourOwnEncryptionKey)
 	setBool(env.FSSFastDeploy, &config.Features.FastDeploy)
 	setBool(env.FSSSVAsyncUpgrade, &config.Features.SVAsyncUpgrade)
+	setBool(env.FSSVMSnapshots, &config.Features.VMSnapshots)
+	setBool(env.FSSVMNetworkHotPlug, &config.Features.VMNetworkHotPlug)
+	setBool(env.FSSVMRestoreRequest, &config.Features.VMRestoreRequest)
+	setBool(env.FSSVMEphemeralVolumes, &config.Features.VMEphemeralVolumes)
+	setBool(env.FSSVMBackupPolicy, &config.Features.VMBackupPolicy)
 	if !config.Features.SVAsyncUpgrade {
 		// When SVAsyncUpgrade is enabled, we'll later use the capability CM to determine if
 		// FSS's with a capability are enabled. TKGMultipleCL is special in that in predated
@@ -27,8 +27,12 @@ const (
 	JSONExtraConfig
 	LogSensitiveData
 	AsyncSignalEnabled
+	AsyncSignalWatchedPropertyPaths
+	AsyncSignalIgnoredExtraConfigKeys
+	AsyncSignalNamespaceSelector
 	AsyncCreateEnabled
 	FastDeployMode
+	SerialConsoleProxyURI
 	InstanceStoragePVPlacementFailedTTL
 	InstanceStorageJitterMaxFactor
 	InstanceStorageSeedRequeueDuration
@@ -62,6 +66,11 @@ const (
 	FSSBringYourOwnEncryptionKey
 	FSSSVAsyncUpgrade
 	FSSFastDeploy
+	FSSVMSnapshots
+	FSSVMNetworkHotPlug
+	FSSVMRestoreRequest
+	FSSVMEphemeralVolumes
+	FSSVMBackupPolicy
 	_varNameEnd
 )
 
@@ -115,10 +124,18 @@ func (n VarName) String() string {
 		return "LOG_SENSITIVE_DATA"
 	case AsyncSignalEnabled:
 		return "ASYNC_SIGNAL_ENABLED"
+	case AsyncSignalWatchedPropertyPaths:
+		return "ASYNC_SIGNAL_WATCHED_PROPERTY_PATHS"
+	case AsyncSignalIgnoredExtraConfigKeys:
+		return "ASYNC_SIGNAL_IGNORED_EXTRA_CONFIG_KEYS"
+	case AsyncSignalNamespaceSelector:
+		return "ASYNC_SIGNAL_NAMESPACE_SELECTOR"
 	case AsyncCreateEnabled:
 		return "ASYNC_CREATE_ENABLED"
 	case FastDeployMode:
 		return "FAST_DEPLOY_MODE"
+	case SerialConsoleProxyURI:
+		return "SERIAL_CONSOLE_PROXY_URI"
 	case InstanceStoragePVPlacementFailedTTL:
 		return "INSTANCE_STORAGE_PV_PLACEMENT_FAILED_TTL"
 	case InstanceStorageJitterMaxFactor:
@@ -185,6 +202,16 @@ func (n VarName) String() string {
 		return "FSS_WCP_SUPERVISOR_ASYNC_UPGRADE"
 	case FSSFastDeploy:
 		return "FSS_WCP_VMSERVICE_FAST_DEPLOY"
+	case FSSVMSnapshots:
+		return "FSS_WCP_VMSERVICE_VM_SNAPSHOTS"
+	case FSSVMNetworkHotPlug:
+		return "FSS_WCP_VMSERVICE_NETWORK_HOTPLUG"
+	case FSSVMRestoreRequest:
+		return "FSS_WCP_VMSERVICE_RESTORE_REQUEST"
+	case FSSVMEphemeralVolumes:
+		return "FSS_WCP_VMSERVICE_EPHEMERAL_VOLUMES"
+	case FSSVMBackupPolicy:
+		return "FSS_WCP_VMSERVICE_BACKUP_POLICY"
 	}
 	panic("unknown environment variable")
 }
@@ -104,10 +104,19 @@ var _ = Describe(
 					Expect(os.Setenv("FSS_WCP_VMSERVICE_BYOK", "true")).To(Succeed())
 					Expect(os.Setenv("FSS_WCP_SUPERVISOR_ASYNC_UPGRADE", "false")).To(Succeed())
 					Expect(os.Setenv("FSS_WCP_VMSERVICE_FAST_DEPLOY", "true")).To(Succeed())
+					Expect(os.Setenv("FSS_WCP_VMSERVICE_VM_SNAPSHOTS", "true")).To(Succeed())
+					Expect(os.Setenv("FSS_WCP_VMSERVICE_NETWORK_HOTPLUG", "true")).To(Succeed())
+					Expect(os.Setenv("FSS_WCP_VMSERVICE_RESTORE_REQUEST", "true")).To(Succeed())
+					Expect(os.Setenv("FSS_WCP_VMSERVICE_EPHEMERAL_VOLUMES", "true")).To(Succeed())
+					Expect(os.Setenv("FSS_WCP_VMSERVICE_BACKUP_POLICY", "true")).To(Succeed())
 					Expect(os.Setenv("CREATE_VM_REQUEUE_DELAY", "125h")).To(Succeed())
 					Expect(os.Setenv("POWERED_ON_VM_HAS_IP_REQUEUE_DELAY", "126h")).To(Succeed())
 					Expect(os.Setenv("MEM_STATS_PERIOD", "127h")).To(Succeed())
 					Expect(os.Setenv("SYNC_IMAGE_REQUEUE_DELAY", "128h")).To(Succeed())
+					Expect(os.Setenv("ASYNC_SIGNAL_WATCHED_PROPERTY_PATHS", "129, 130")).To(Succeed())
+					Expect(os.Setenv("ASYNC_SIGNAL_IGNORED_EXTRA_CONFIG_KEYS", "131,,132")).To(Succeed())
+					Expect(os.Setenv("ASYNC_SIGNAL_NAMESPACE_SELECTOR", "133")).To(Succeed())
+					Expect(os.Setenv("SERIAL_CONSOLE_PROXY_URI", "134")).To(Succeed())
 				})
 				It("Should return a default config overridden by the environment", func() {
 					Expect(config).To(BeComparableTo(pkgcfg.Config{
@@ -157,11 +166,21 @@ var _ = Describe(
 							SVAsyncUpgrade:            false, // Capability gate so tested below
 							WorkloadDomainIsolation:   true,
 							FastDeploy:                true,
+							VMSnapshots:               true,
+							VMNetworkHotPlug:          true,
+							VMRestoreRequest:          true,
+							VMEphemeralVolumes:        true,
+							VMBackupPolicy:            true,
 						},
 						CreateVMRequeueDelay:         125 * time.Hour,
 						PoweredOnVMHasIPRequeueDelay: 126 * time.Hour,
 						MemStatsPeriod:               127 * time.Hour,
 						SyncImageRequeueDelay:        128 * time.Hour,
+
+						AsyncSignalWatchedPropertyPaths:   "129,130",
+						AsyncSignalIgnoredExtraConfigKeys: "131,132",
+						AsyncSignalNamespaceSelector:      "133",
+						SerialConsoleProxyURI:             "134",
 					}))
 				})
 			})
@@ -73,4 +73,11 @@ const (
 	// FastDeployModeLinked is a fast deploy mode. See FastDeployAnnotationKey
 	// for more information.
 	FastDeployModeLinked = "linked"
+
+	// StorageClassMigrationAnnotationKey is applied to VirtualMachine
+	// resources while their storage is being migrated to a new storage class.
+	// The value of this annotation is a comma-delimited string that specifies
+	// the target storage class and the ID of the relocate task, ex.
+	// gold,task-42.
+	StorageClassMigrationAnnotationKey = "vmoperator.vmware.com/storage-class-migration"
 )
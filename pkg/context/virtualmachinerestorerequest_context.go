@@ -0,0 +1,26 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package context
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+)
+
+// VirtualMachineRestoreRequestContext is the context used for
+// VirtualMachineRestoreRequest reconciliation.
+type VirtualMachineRestoreRequestContext struct {
+	context.Context
+	Logger                       logr.Logger
+	VirtualMachineRestoreRequest *vmopv1.VirtualMachineRestoreRequest
+}
+
+func (v *VirtualMachineRestoreRequestContext) String() string {
+	return fmt.Sprintf("%s %s/%s", v.VirtualMachineRestoreRequest.GroupVersionKind(), v.VirtualMachineRestoreRequest.Namespace, v.VirtualMachineRestoreRequest.Name)
+}
@@ -6,6 +6,7 @@ package context
 
 import (
 	"context"
+	"time"
 
 	"github.com/go-logr/logr"
 
@@ -21,6 +22,41 @@ type ProbeContext struct {
 	VM            *vmopv1.VirtualMachine
 	ProbeType     string
 	PeriodSeconds int32
+	State         *ProbeState
+
+	// ProbeSpec describes the actions of the probe being run. When nil, the
+	// VM's readiness probe is used.
+	ProbeSpec *vmopv1.VirtualMachineReadinessProbeSpec
+}
+
+// GetProbeSpec returns the spec of the probe being run.
+func (p *ProbeContext) GetProbeSpec() *vmopv1.VirtualMachineReadinessProbeSpec {
+	if p.ProbeSpec != nil {
+		return p.ProbeSpec
+	}
+	return p.VM.Spec.ReadinessProbe
+}
+
+// ProbeState is the state of a VM's probe that is retained between runs of
+// the probe.
+type ProbeState struct {
+	// ConsecutiveFailures is the number of consecutive times the probe has
+	// failed.
+	ConsecutiveFailures int32
+
+	// ConsecutiveSuccesses is the number of consecutive times the probe has
+	// succeeded.
+	ConsecutiveSuccesses int32
+
+	// StartTime is the time from which the probe's initial delay is
+	// measured. A zero value indicates the VM has not yet been observed as
+	// powered on.
+	StartTime time.Time
+}
+
+// Reset clears the probe state.
+func (s *ProbeState) Reset() {
+	*s = ProbeState{}
 }
 
 // String returns probe type.
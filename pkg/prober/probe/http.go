@@ -0,0 +1,139 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package probe
+
+import (
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	"github.com/vmware-tanzu/vm-operator/pkg/prober/context"
+)
+
+const (
+	defaultMinStatusCode = http.StatusOK
+	defaultMaxStatusCode = http.StatusBadRequest - 1
+
+	// maxRespBodyLength is the maximum number of bytes read from the response
+	// body. The body is drained so the connection may be reused.
+	maxRespBodyLength = 10 * 1 << 10
+
+	probeUserAgent = "vm-operator-probe"
+)
+
+// httpProber implements the Probe interface.
+type httpProber struct {
+	transport *http.Transport
+}
+
+// NewHTTPProber creates a new http prober which implements the Probe interface
+// to execute HTTP GET probes.
+func NewHTTPProber() Probe {
+	return &httpProber{
+		transport: &http.Transport{
+			// The VM's certificate is usually self-signed, so like Kubernetes
+			// HTTPS probes, the certificate is not verified.
+			TLSClientConfig:   &tls.Config{InsecureSkipVerify: true}, //nolint:gosec
+			DisableKeepAlives: true,
+		},
+	}
+}
+
+func (pr httpProber) Probe(ctx *context.ProbeContext) (Result, error) {
+	vm := ctx.VM
+	p := ctx.GetProbeSpec()
+	action := p.HTTPGet
+
+	portNum, err := findPort(vm, action.Port, corev1.ProtocolTCP)
+	if err != nil {
+		return Failure, err
+	}
+
+	host := action.Host
+	if host == "" {
+		ctx.Logger.V(4).Info("HTTPGet Host not specified, using VM IP", "probe", ctx.String())
+		if host, err = getVMIP(vm); err != nil {
+			return Failure, err
+		}
+	}
+
+	req, err := newHTTPGetRequest(host, portNum, action)
+	if err != nil {
+		return Failure, err
+	}
+
+	client := &http.Client{
+		Transport: pr.transport,
+		Timeout:   getTimeout(p),
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return Failure, err
+	}
+	defer resp.Body.Close()
+
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxRespBodyLength))
+
+	minCode, maxCode := defaultMinStatusCode, defaultMaxStatusCode
+	if action.MinStatusCode > 0 {
+		minCode = int(action.MinStatusCode)
+	}
+	if action.MaxStatusCode > 0 {
+		maxCode = int(action.MaxStatusCode)
+	}
+
+	if resp.StatusCode < minCode || resp.StatusCode > maxCode {
+		return Failure, fmt.Errorf("HTTP probe failed with status code %d, expected %d-%d",
+			resp.StatusCode, minCode, maxCode)
+	}
+
+	return Success, nil
+}
+
+func newHTTPGetRequest(host string, port int, action *vmopv1.HTTPGetAction) (*http.Request, error) {
+	scheme := strings.ToLower(string(action.Scheme))
+	if scheme == "" {
+		scheme = "http"
+	}
+
+	path := action.Path
+	if path == "" {
+		path = "/"
+	} else if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+
+	u, err := url.Parse(path)
+	if err != nil {
+		return nil, fmt.Errorf("invalid HTTP probe path %q: %w", action.Path, err)
+	}
+	u.Scheme = scheme
+	u.Host = net.JoinHostPort(host, strconv.Itoa(port))
+
+	req, err := http.NewRequest(http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("User-Agent", probeUserAgent)
+	for _, h := range action.HTTPHeaders {
+		if strings.EqualFold(h.Name, "Host") {
+			req.Host = h.Value
+			continue
+		}
+		req.Header.Add(h.Name, h.Value)
+	}
+
+	return req, nil
+}
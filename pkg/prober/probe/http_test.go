@@ -0,0 +1,212 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package probe
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+
+	"github.com/vmware-tanzu/vm-operator/pkg/prober/context"
+)
+
+var _ = Describe("HTTP probe", func() {
+	var (
+		vm            *vmopv1.VirtualMachine
+		testHTTPProbe Probe
+		probeCtx      *context.ProbeContext
+
+		testServer *httptest.Server
+		testHost   string
+		testPort   int
+
+		statusCode int
+		lastReq    *http.Request
+	)
+
+	BeforeEach(func() {
+		vm = &vmopv1.VirtualMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "dummy-vm",
+				Namespace: "dummy-ns",
+			},
+			Spec: vmopv1.VirtualMachineSpec{
+				ClassName: "dummy-vmclass",
+			},
+			Status: vmopv1.VirtualMachineStatus{
+				Network: &vmopv1.VirtualMachineNetworkStatus{},
+			},
+		}
+
+		statusCode = http.StatusOK
+		lastReq = nil
+		testServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			lastReq = r
+			w.WriteHeader(statusCode)
+		}))
+
+		host, port, err := net.SplitHostPort(testServer.Listener.Addr().String())
+		Expect(err).NotTo(HaveOccurred())
+		testHost = host
+		testPort, err = strconv.Atoi(port)
+		Expect(err).NotTo(HaveOccurred())
+
+		testHTTPProbe = NewHTTPProber()
+	})
+
+	JustBeforeEach(func() {
+		probeCtx = &context.ProbeContext{
+			VM:     vm,
+			Logger: ctrl.Log.WithName("Probe").WithValues("name", vm.NamespacedName()),
+		}
+	})
+
+	AfterEach(func() {
+		testServer.Close()
+	})
+
+	Context("HTTP GET probe with host set in VM spec", func() {
+		BeforeEach(func() {
+			vm.Spec.ReadinessProbe = getVirtualMachineReadinessHTTPGetProbe(testHost, testPort)
+			vm.Spec.ReadinessProbe.HTTPGet.Path = "healthz?verbose=true"
+			vm.Spec.ReadinessProbe.HTTPGet.HTTPHeaders = []vmopv1.HTTPHeader{
+				{Name: "X-Probe", Value: "ready"},
+				{Name: "Host", Value: "my-app.example.com"},
+			}
+		})
+
+		It("succeeds and sends the expected request", func() {
+			res, err := testHTTPProbe.Probe(probeCtx)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(res).To(Equal(Success))
+
+			Expect(lastReq).ToNot(BeNil())
+			Expect(lastReq.Method).To(Equal(http.MethodGet))
+			Expect(lastReq.URL.Path).To(Equal("/healthz"))
+			Expect(lastReq.URL.RawQuery).To(Equal("verbose=true"))
+			Expect(lastReq.Host).To(Equal("my-app.example.com"))
+			Expect(lastReq.Header.Get("X-Probe")).To(Equal("ready"))
+			Expect(lastReq.Header.Get("User-Agent")).To(Equal(probeUserAgent))
+		})
+	})
+
+	Context("HTTP GET probe with empty host", func() {
+		BeforeEach(func() {
+			vm.Spec.ReadinessProbe = getVirtualMachineReadinessHTTPGetProbe("", testPort)
+		})
+
+		It("succeeds using the VM IP", func() {
+			vm.Status.Network.PrimaryIP4 = testHost
+			res, err := testHTTPProbe.Probe(probeCtx)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(res).To(Equal(Success))
+			Expect(lastReq.URL.Path).To(Equal("/"))
+		})
+
+		It("fails when the VM does not have an IP", func() {
+			res, err := testHTTPProbe.Probe(probeCtx)
+			Expect(err).To(MatchError(ContainSubstring("doesn't have an IP assigned")))
+			Expect(res).To(Equal(Failure))
+		})
+	})
+
+	Context("HTTP GET probe with a named port", func() {
+		BeforeEach(func() {
+			vm.Spec.ReadinessProbe = getVirtualMachineReadinessHTTPGetProbe(testHost, testPort)
+			vm.Spec.ReadinessProbe.HTTPGet.Port = intstr.FromString("http")
+		})
+
+		It("succeeds using the port named by the VM", func() {
+			vm.Annotations = map[string]string{
+				vmopv1.VirtualMachineServicePortAnnotationPrefix + "http": strconv.Itoa(testPort),
+			}
+			res, err := testHTTPProbe.Probe(probeCtx)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(res).To(Equal(Success))
+		})
+
+		It("fails when the VM does not name the port", func() {
+			res, err := testHTTPProbe.Probe(probeCtx)
+			Expect(err).To(HaveOccurred())
+			Expect(res).To(Equal(Failure))
+		})
+	})
+
+	Context("status codes", func() {
+		BeforeEach(func() {
+			vm.Spec.ReadinessProbe = getVirtualMachineReadinessHTTPGetProbe(testHost, testPort)
+		})
+
+		It("fails on a status code outside of the default range", func() {
+			statusCode = http.StatusServiceUnavailable
+			res, err := testHTTPProbe.Probe(probeCtx)
+			Expect(err).To(MatchError("HTTP probe failed with status code 503, expected 200-399"))
+			Expect(res).To(Equal(Failure))
+		})
+
+		It("succeeds on a status code within the configured range", func() {
+			statusCode = http.StatusUnauthorized
+			vm.Spec.ReadinessProbe.HTTPGet.MinStatusCode = 200
+			vm.Spec.ReadinessProbe.HTTPGet.MaxStatusCode = 499
+			res, err := testHTTPProbe.Probe(probeCtx)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(res).To(Equal(Success))
+		})
+
+		It("fails on a status code outside of the configured range", func() {
+			statusCode = http.StatusFound
+			vm.Spec.ReadinessProbe.HTTPGet.MaxStatusCode = 299
+			res, err := testHTTPProbe.Probe(probeCtx)
+			Expect(err).To(HaveOccurred())
+			Expect(res).To(Equal(Failure))
+		})
+	})
+
+	It("fails when the server is not reachable", func() {
+		vm.Spec.ReadinessProbe = getVirtualMachineReadinessHTTPGetProbe(testHost, 10001)
+		res, err := testHTTPProbe.Probe(probeCtx)
+		Expect(err).Should(HaveOccurred())
+		Expect(res).To(Equal(Failure))
+	})
+
+	It("succeeds against an HTTPS server with a self-signed certificate", func() {
+		tlsServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer tlsServer.Close()
+
+		host, port, err := net.SplitHostPort(tlsServer.Listener.Addr().String())
+		Expect(err).NotTo(HaveOccurred())
+		portInt, err := strconv.Atoi(port)
+		Expect(err).NotTo(HaveOccurred())
+
+		vm.Spec.ReadinessProbe = getVirtualMachineReadinessHTTPGetProbe(host, portInt)
+		vm.Spec.ReadinessProbe.HTTPGet.Scheme = vmopv1.URISchemeHTTPS
+
+		res, err := testHTTPProbe.Probe(probeCtx)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(res).To(Equal(Success))
+	})
+})
+
+func getVirtualMachineReadinessHTTPGetProbe(host string, port int) *vmopv1.VirtualMachineReadinessProbeSpec {
+	return &vmopv1.VirtualMachineReadinessProbeSpec{
+		HTTPGet: &vmopv1.HTTPGetAction{
+			Host: host,
+			Port: intstr.FromInt(port),
+		},
+		PeriodSeconds: 1,
+	}
+}
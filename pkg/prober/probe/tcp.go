@@ -27,7 +27,7 @@ func NewTCPProber() Probe {
 
 func (pr tcpProber) Probe(ctx *context.ProbeContext) (Result, error) {
 	vm := ctx.VM
-	p := ctx.VM.Spec.ReadinessProbe
+	p := ctx.GetProbeSpec()
 
 	portProto := corev1.ProtocolTCP
 	portNum, err := findPort(vm, p.TCPSocket.Port, portProto)
@@ -38,35 +38,30 @@ func (pr tcpProber) Probe(ctx *context.ProbeContext) (Result, error) {
 	ip := p.TCPSocket.Host
 	if ip == "" {
 		ctx.Logger.V(4).Info("TCPSocket Host not specified, using VM IP", "probe", ctx.String())
-		if vm.Status.Network != nil {
-			ip = vm.Status.Network.PrimaryIP4
-			if ip == "" {
-				ip = vm.Status.Network.PrimaryIP6
-			}
-		}
-		if ip == "" {
-			return Failure, fmt.Errorf("VM %s doesn't have an IP assigned", vm.NamespacedName())
+		if ip, err = getVMIP(vm); err != nil {
+			return Failure, err
 		}
 	}
 
-	var timeout time.Duration
-	if p.TimeoutSeconds <= 0 {
-		timeout = defaultConnectTimeout
-	} else {
-		timeout = time.Duration(p.TimeoutSeconds) * time.Second
-	}
-
-	if err := checkConnection("tcp", ip, strconv.Itoa(portNum), timeout); err != nil {
+	if err := checkConnection("tcp", ip, strconv.Itoa(portNum), getTimeout(p)); err != nil {
 		return Failure, err
 	}
 
 	return Success, nil
 }
 
+// findPort returns the port number on the VM for the probe's port. A named port
+// is resolved by the VM's VirtualMachineServicePortAnnotationPrefix annotation.
 func findPort(vm *vmopv1.VirtualMachine, portName intstr.IntOrString, _ corev1.Protocol) (int, error) {
 	switch portName.Type {
 	case intstr.String:
-		// Not supported.
+		if v, ok := vm.Annotations[vmopv1.VirtualMachineServicePortAnnotationPrefix+portName.StrVal]; ok {
+			portNum, err := strconv.Atoi(v)
+			if err != nil || portNum < 1 || portNum > 65535 {
+				return 0, fmt.Errorf("invalid port %q for port name %q on VM %s", v, portName.StrVal, vm.NamespacedName())
+			}
+			return portNum, nil
+		}
 	case intstr.Int:
 		return portName.IntValue(), nil
 	}
@@ -74,6 +69,28 @@ func findPort(vm *vmopv1.VirtualMachine, portName intstr.IntOrString, _ corev1.P
 	return 0, fmt.Errorf("no suitable port for manifest: %s", vm.UID)
 }
 
+// getVMIP returns the VM's primary IP address, preferring IPv4.
+func getVMIP(vm *vmopv1.VirtualMachine) (string, error) {
+	var ip string
+	if vm.Status.Network != nil {
+		ip = vm.Status.Network.PrimaryIP4
+		if ip == "" {
+			ip = vm.Status.Network.PrimaryIP6
+		}
+	}
+	if ip == "" {
+		return "", fmt.Errorf("VM %s doesn't have an IP assigned", vm.NamespacedName())
+	}
+	return ip, nil
+}
+
+func getTimeout(p *vmopv1.VirtualMachineReadinessProbeSpec) time.Duration {
+	if p.TimeoutSeconds <= 0 {
+		return defaultConnectTimeout
+	}
+	return time.Duration(p.TimeoutSeconds) * time.Second
+}
+
 func checkConnection(proto, host, port string, timeout time.Duration) error {
 	address := net.JoinHostPort(host, port)
 	conn, err := net.DialTimeout(proto, address, timeout)
@@ -30,6 +30,7 @@ import (
 const (
 	proberManagerName       = "virtualmachine-prober-manager"
 	readinessProbeQueueName = "readinessProbeQueue"
+	livenessProbeQueueName  = "livenessProbeQueue"
 
 	// defaultPeriodSeconds represents the default value for the frequency (in seconds) to perform the probe.
 	// We use the same default value as the kubernetes container probe.
@@ -38,6 +39,9 @@ const (
 	// the number of readiness workers.
 	// TODO: find a way to calibrate it.
 	numberOfReadinessWorkers = 5
+
+	// the number of liveness workers.
+	numberOfLivenessWorkers = 5
 )
 
 // Manager represents a prober manager interface.
@@ -51,6 +55,7 @@ type Manager interface {
 type manager struct {
 	client         client.Client
 	readinessQueue worker.DelayingInterface
+	livenessQueue  worker.DelayingInterface
 	prober         *probe.Prober
 	log            logr.Logger
 	recorder       vmoprecord.Recorder
@@ -62,6 +67,20 @@ type manager struct {
 	// adding VMs to the readiness queue when this VM is already in the heap but not in the queue.
 	readinessMutex       sync.Mutex
 	vmReadinessProbeList map[string]vmopv1.VirtualMachineReadinessProbeSpec
+
+	livenessMutex       sync.Mutex
+	vmLivenessProbeList map[string]vmopv1.VirtualMachineLivenessProbeSpec
+
+	// probeStates is the state of each VM's probes that is retained between
+	// runs of the probes, ex. the number of consecutive failures.
+	probeStatesMutex sync.Mutex
+	probeStates      map[probeStateKey]*proberctx.ProbeState
+}
+
+// probeStateKey identifies the state of a type of probe for a VM.
+type probeStateKey struct {
+	probeType string
+	vmName    string
 }
 
 // NewManager initializes a prober manager.
@@ -73,10 +92,13 @@ func NewManager(
 	probeManager := &manager{
 		client:               client,
 		readinessQueue:       workqueue.NewNamedDelayingQueue(readinessProbeQueueName),
+		livenessQueue:        workqueue.NewNamedDelayingQueue(livenessProbeQueueName),
 		prober:               probe.NewProber(vmProvider),
 		log:                  ctrl.Log.WithName(proberManagerName),
 		recorder:             record,
 		vmReadinessProbeList: make(map[string]vmopv1.VirtualMachineReadinessProbeSpec),
+		vmLivenessProbeList:  make(map[string]vmopv1.VirtualMachineLivenessProbeSpec),
+		probeStates:          make(map[probeStateKey]*proberctx.ProbeState),
 	}
 	return probeManager
 }
@@ -99,11 +121,21 @@ func (m *manager) AddToProberManager(vm *vmopv1.VirtualMachine) {
 	vmName := vm.NamespacedName()
 	m.log.V(4).Info("Add to prober manager", "vm", vmName)
 
+	m.addToReadinessProbeList(vm)
+	m.addToLivenessProbeList(vm)
+}
+
+// addToReadinessProbeList adds a VM to the readiness queue if it specifies a
+// readiness probe.
+func (m *manager) addToReadinessProbeList(vm *vmopv1.VirtualMachine) {
+	vmName := vm.NamespacedName()
+
 	m.readinessMutex.Lock()
 	defer m.readinessMutex.Unlock()
 
 	if vm.Spec.ReadinessProbe != nil &&
-		(vm.Spec.ReadinessProbe.TCPSocket != nil || vm.Spec.ReadinessProbe.GuestHeartbeat != nil || len(vm.Spec.ReadinessProbe.GuestInfo) != 0) {
+		(vm.Spec.ReadinessProbe.TCPSocket != nil || vm.Spec.ReadinessProbe.HTTPGet != nil ||
+			vm.Spec.ReadinessProbe.GuestHeartbeat != nil || len(vm.Spec.ReadinessProbe.GuestInfo) != 0) {
 		// if the VM is not in the list, or its readiness probe spec has been updated, immediately add it to the queue
 		// otherwise, ignore it.
 		if oldProbe, ok := m.vmReadinessProbeList[vmName]; ok && reflect.DeepEqual(oldProbe, vm.Spec.ReadinessProbe) {
@@ -118,14 +150,70 @@ func (m *manager) AddToProberManager(vm *vmopv1.VirtualMachine) {
 	}
 }
 
+// addToLivenessProbeList adds a VM to the liveness queue if it specifies a
+// liveness probe.
+func (m *manager) addToLivenessProbeList(vm *vmopv1.VirtualMachine) {
+	vmName := vm.NamespacedName()
+
+	m.livenessMutex.Lock()
+	defer m.livenessMutex.Unlock()
+
+	if p := vm.Spec.LivenessProbe; p != nil &&
+		(p.TCPSocket != nil || p.HTTPGet != nil || p.GuestHeartbeat != nil || len(p.GuestInfo) != 0) {
+		// if the VM is not in the list, or its liveness probe spec has been updated, immediately add it to the queue
+		// otherwise, ignore it.
+		if oldProbe, ok := m.vmLivenessProbeList[vmName]; ok && reflect.DeepEqual(oldProbe, *p) {
+			m.log.V(4).Info("VM is already in the liveness probe list and its probe spec is not updated, skip it", "vm", vmName)
+			return
+		}
+
+		m.livenessQueue.Add(client.ObjectKey{Name: vm.Name, Namespace: vm.Namespace})
+		m.vmLivenessProbeList[vmName] = *p
+		m.removeProbeState(worker.LivenessProbeType, vmName)
+	} else {
+		delete(m.vmLivenessProbeList, vmName)
+		m.removeProbeState(worker.LivenessProbeType, vmName)
+	}
+}
+
 // RemoveFromProberManager removes a VM from the prober manager.
 func (m *manager) RemoveFromProberManager(vm *vmopv1.VirtualMachine) {
 	vmName := vm.NamespacedName()
 	m.log.V(4).Info("Remove from prober manager", "vm", vmName)
 
 	m.readinessMutex.Lock()
-	defer m.readinessMutex.Unlock()
 	delete(m.vmReadinessProbeList, vmName)
+	m.readinessMutex.Unlock()
+
+	m.livenessMutex.Lock()
+	delete(m.vmLivenessProbeList, vmName)
+	m.livenessMutex.Unlock()
+
+	m.removeProbeState(worker.ReadinessProbeType, vmName)
+	m.removeProbeState(worker.LivenessProbeType, vmName)
+}
+
+// getProbeState returns the state of a VM's probe, creating it if it does not
+// exist.
+func (m *manager) getProbeState(probeType, vmName string) *proberctx.ProbeState {
+	m.probeStatesMutex.Lock()
+	defer m.probeStatesMutex.Unlock()
+
+	key := probeStateKey{probeType: probeType, vmName: vmName}
+	state, ok := m.probeStates[key]
+	if !ok {
+		state = &proberctx.ProbeState{}
+		m.probeStates[key] = state
+	}
+	return state
+}
+
+// removeProbeState removes the state of a VM's probe.
+func (m *manager) removeProbeState(probeType, vmName string) {
+	m.probeStatesMutex.Lock()
+	defer m.probeStatesMutex.Unlock()
+
+	delete(m.probeStates, probeStateKey{probeType: probeType, vmName: vmName})
 }
 
 // Start starts the probe manager.
@@ -140,9 +228,17 @@ func (m *manager) Start(ctx context.Context) error {
 		m.worker(readinessWorker)
 	}
 
+	m.log.Info("Starting liveness workers", "count", numberOfLivenessWorkers)
+	m.workersWG.Add(numberOfLivenessWorkers)
+	for i := 0; i < numberOfLivenessWorkers; i++ {
+		livenessWorker := worker.NewLivenessWorker(m.livenessQueue, m.prober, m.client, m.recorder)
+		m.worker(livenessWorker)
+	}
+
 	<-ctx.Done()
 
 	m.readinessQueue.ShutDown()
+	m.livenessQueue.ShutDown()
 	m.workersWG.Wait()
 	return nil
 }
@@ -177,6 +273,10 @@ func (m *manager) processItemFromQueue(w worker.Worker) bool {
 		if !apierrors.IsNotFound(err) {
 			// Get VM error, immediately re-queue the VM.
 			queue.Add(item)
+		} else {
+			// The VM has been deleted, so forget its probe states.
+			m.removeProbeState(worker.ReadinessProbeType, item.String())
+			m.removeProbeState(worker.LivenessProbeType, item.String())
 		}
 		return false
 	}
@@ -185,6 +285,7 @@ func (m *manager) processItemFromQueue(w worker.Worker) bool {
 	if err != nil || ctx == nil {
 		return false
 	}
+	ctx.State = m.getProbeState(ctx.String(), vm.NamespacedName())
 
 	if !vm.ObjectMeta.DeletionTimestamp.IsZero() {
 		ctx.Logger.V(4).Info("the VirtualMachine is marked for deletion, skip running the probe")
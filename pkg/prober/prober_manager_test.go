@@ -296,6 +296,51 @@ var _ = Describe("VirtualMachine probes", func() {
 				testManager.readinessMutex.Unlock()
 			})
 		})
+
+		When("VM specifies a liveness probe", func() {
+			BeforeEach(func() {
+				vm.Spec.LivenessProbe = &vmopv1.VirtualMachineLivenessProbeSpec{
+					TCPSocket: &vmopv1.TCPSocketAction{
+						Port: intstr.FromInt(10001),
+					},
+					PeriodSeconds: periodSeconds,
+				}
+			})
+
+			It("Should add to the liveness queue and list", func() {
+				testManager.AddToProberManager(vm)
+
+				Expect(testManager.livenessQueue.Len()).To(Equal(1))
+				testManager.livenessMutex.Lock()
+				Expect(testManager.vmLivenessProbeList).Should(HaveKey(vm.NamespacedName()))
+				testManager.livenessMutex.Unlock()
+			})
+
+			It("Should reset the probe state if the VM's liveness probe spec is updated", func() {
+				testManager.AddToProberManager(vm)
+				state := testManager.getProbeState(worker.LivenessProbeType, vm.NamespacedName())
+				state.ConsecutiveFailures = 2
+
+				vm.Spec.LivenessProbe.FailureThreshold = 5
+				testManager.AddToProberManager(vm)
+
+				Expect(testManager.getProbeState(worker.LivenessProbeType, vm.NamespacedName())).ToNot(BeIdenticalTo(state))
+			})
+
+			It("Should remove from the manager when the VM is removed", func() {
+				testManager.AddToProberManager(vm)
+				testManager.getProbeState(worker.LivenessProbeType, vm.NamespacedName())
+
+				testManager.RemoveFromProberManager(vm)
+
+				testManager.livenessMutex.Lock()
+				Expect(testManager.vmLivenessProbeList).ShouldNot(HaveKey(vm.NamespacedName()))
+				testManager.livenessMutex.Unlock()
+				testManager.probeStatesMutex.Lock()
+				Expect(testManager.probeStates).To(BeEmpty())
+				testManager.probeStatesMutex.Unlock()
+			})
+		})
 	})
 })
 
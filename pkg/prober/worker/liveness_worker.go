@@ -0,0 +1,178 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package worker
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	"github.com/vmware-tanzu/vm-operator/pkg/patch"
+	proberctx "github.com/vmware-tanzu/vm-operator/pkg/prober/context"
+	"github.com/vmware-tanzu/vm-operator/pkg/prober/probe"
+	vmoprecord "github.com/vmware-tanzu/vm-operator/pkg/record"
+)
+
+const (
+	// livenessProbeFailedReason is the reason for the event recorded when a
+	// VM is restarted because its liveness probe failed.
+	livenessProbeFailedReason string = "LivenessProbeFailed"
+
+	// defaultLivenessFailureThreshold is the number of consecutive failures after
+	// which a VM is restarted if the liveness probe does not specify a
+	// failure threshold.
+	defaultLivenessFailureThreshold int32 = 3
+
+	// restartNow is the value of spec.nextRestartTime that requests a VM be
+	// restarted immediately.
+	restartNow = "now"
+)
+
+// livenessWorker implements Worker interface.
+type livenessWorker struct {
+	queue    DelayingInterface
+	prober   *probe.Prober
+	client   client.Client
+	recorder vmoprecord.Recorder
+}
+
+// NewLivenessWorker creates a new liveness worker to run liveness probes.
+func NewLivenessWorker(
+	queue DelayingInterface,
+	prober *probe.Prober,
+	client client.Client,
+	recorder vmoprecord.Recorder,
+) Worker {
+	return &livenessWorker{
+		queue:    queue,
+		prober:   prober,
+		client:   client,
+		recorder: recorder,
+	}
+}
+
+func (w *livenessWorker) GetQueue() DelayingInterface {
+	return w.queue
+}
+
+// CreateProbeContext creates a probe context for liveness probe.
+func (w *livenessWorker) CreateProbeContext(vm *vmopv1.VirtualMachine) (*proberctx.ProbeContext, error) {
+	p := vm.Spec.LivenessProbe
+
+	if p == nil || (p.TCPSocket == nil && p.HTTPGet == nil && p.GuestHeartbeat == nil && len(p.GuestInfo) == 0) {
+		return nil, nil
+	}
+
+	patchHelper, err := patch.NewHelper(vm, w.client)
+	if err != nil {
+		return nil, err
+	}
+
+	return &proberctx.ProbeContext{
+		Context:       context.Background(),
+		Logger:        ctrl.Log.WithName("liveness-probe").WithValues("vmName", vm.NamespacedName()),
+		PatchHelper:   patchHelper,
+		VM:            vm,
+		ProbeType:     LivenessProbeType,
+		PeriodSeconds: p.PeriodSeconds,
+		ProbeSpec: &vmopv1.VirtualMachineReadinessProbeSpec{
+			TCPSocket:      p.TCPSocket,
+			HTTPGet:        p.HTTPGet,
+			GuestHeartbeat: p.GuestHeartbeat,
+			GuestInfo:      p.GuestInfo,
+			TimeoutSeconds: p.TimeoutSeconds,
+			PeriodSeconds:  p.PeriodSeconds,
+		},
+	}, nil
+}
+
+// ProcessProbeResult processes probe results and restarts the VM once the
+// probe has failed the configured number of consecutive times.
+func (w *livenessWorker) ProcessProbeResult(ctx *proberctx.ProbeContext, res probe.Result, resErr error) error {
+	vm := ctx.VM
+	state := getProbeState(ctx)
+
+	// A VM that is not powered on cannot be restarted, and its initial delay
+	// starts over once it is powered on again.
+	if vm.Status.PowerState != vmopv1.VirtualMachinePowerStateOn {
+		state.Reset()
+		return nil
+	}
+
+	switch res {
+	case probe.Success:
+		state.ConsecutiveFailures = 0
+		return nil
+	case probe.Failure:
+		state.ConsecutiveFailures++
+	default: // probe.Unknown
+		// The result cannot be used to decide whether the VM is alive, so
+		// leave the failure count as-is.
+		return nil
+	}
+
+	failureThreshold := vm.Spec.LivenessProbe.FailureThreshold
+	if failureThreshold <= 0 {
+		failureThreshold = defaultLivenessFailureThreshold
+	}
+
+	if state.ConsecutiveFailures < failureThreshold {
+		ctx.Logger.V(4).Info("VM resource LIVENESS probe failed",
+			"failures", state.ConsecutiveFailures, "failureThreshold", failureThreshold)
+		return nil
+	}
+
+	msg := ""
+	if resErr != nil {
+		msg = resErr.Error()
+	}
+
+	ctx.Logger.Info("VM resource LIVENESS probe failed, restarting VM",
+		"failures", state.ConsecutiveFailures, "reason", msg)
+	w.recorder.Warnf(vm, livenessProbeFailedReason,
+		"Liveness probe failed %d times, restarting VM: %s", state.ConsecutiveFailures, msg)
+
+	// The mutating webhook replaces "now" with the current time, which the
+	// VM controller then uses to restart the VM in accordance with
+	// spec.restartMode.
+	vm.Spec.NextRestartTime = restartNow
+	vm.Status.RestartCount++
+
+	if err := ctx.PatchHelper.Patch(ctx, vm); err != nil {
+		return fmt.Errorf("patched failed: %w", err)
+	}
+
+	// Give the VM the probe's initial delay to come back up after the
+	// restart before probing it again.
+	state.ConsecutiveFailures = 0
+	state.StartTime = time.Now()
+
+	return nil
+}
+
+func (w *livenessWorker) DoProbe(ctx *proberctx.ProbeContext) error {
+	if !initialDelayElapsed(ctx, ctx.VM.Spec.LivenessProbe.InitialDelaySeconds) {
+		return nil
+	}
+
+	res, err := w.runProbe(ctx)
+	if err != nil {
+		ctx.Logger.Error(err, "liveness probe fails", "result", res)
+	}
+	return w.ProcessProbeResult(ctx, res, err)
+}
+
+// runProbe runs a specific type of probe based on the VM probe spec.
+func (w *livenessWorker) runProbe(ctx *proberctx.ProbeContext) (probe.Result, error) {
+	if p := getProbe(w.prober, ctx.GetProbeSpec()); p != nil {
+		return p.Probe(ctx)
+	}
+
+	return probe.Unknown, fmt.Errorf("unknown action specified for VM %s liveness probe", ctx.VM.NamespacedName())
+}
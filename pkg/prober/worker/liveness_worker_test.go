@@ -0,0 +1,266 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package worker
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	clientgorecord "k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+
+	proberctx "github.com/vmware-tanzu/vm-operator/pkg/prober/context"
+	fakeprobe "github.com/vmware-tanzu/vm-operator/pkg/prober/fake/probe"
+	"github.com/vmware-tanzu/vm-operator/pkg/prober/probe"
+	"github.com/vmware-tanzu/vm-operator/pkg/record"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+var _ = Describe("VirtualMachine liveness probes", func() {
+	var (
+		testWorker Worker
+
+		vm    *vmopv1.VirtualMachine
+		vmKey client.ObjectKey
+		ctx   *proberctx.ProbeContext
+		state *proberctx.ProbeState
+
+		fakeClient         client.Client
+		fakeEvents         chan string
+		fakeTCPProbe       *fakeprobe.FakeProbe
+		fakeHTTPGetProbe   *fakeprobe.FakeProbe
+		fakeHeartbeatProbe *fakeprobe.FakeProbe
+	)
+
+	BeforeEach(func() {
+		vm = &vmopv1.VirtualMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "dummy-vm",
+				Namespace: "dummy-ns",
+			},
+			Spec: vmopv1.VirtualMachineSpec{
+				ClassName: "dummy-vmclass",
+				LivenessProbe: &vmopv1.VirtualMachineLivenessProbeSpec{
+					TCPSocket: &vmopv1.TCPSocketAction{
+						Port: intstr.FromInt(10001),
+					},
+					PeriodSeconds:    1,
+					FailureThreshold: 2,
+				},
+			},
+			Status: vmopv1.VirtualMachineStatus{
+				PowerState: vmopv1.VirtualMachinePowerStateOn,
+			},
+		}
+		vmKey = client.ObjectKey{Name: vm.Name, Namespace: vm.Namespace}
+		state = &proberctx.ProbeState{}
+
+		fakeClient = builder.NewFakeClient()
+		eventRecorder := clientgorecord.NewFakeRecorder(1024)
+		fakeEvents = eventRecorder.Events
+
+		queue := workqueue.NewNamedDelayingQueue("test")
+		fakeTCPProbe = fakeprobe.NewFakeProbe().(*fakeprobe.FakeProbe)
+		fakeHTTPGetProbe = fakeprobe.NewFakeProbe().(*fakeprobe.FakeProbe)
+		fakeHeartbeatProbe = fakeprobe.NewFakeProbe().(*fakeprobe.FakeProbe)
+		prober := &probe.Prober{
+			TCPProbe:       fakeTCPProbe,
+			HTTPGetProbe:   fakeHTTPGetProbe,
+			GuestHeartbeat: fakeHeartbeatProbe,
+		}
+		testWorker = NewLivenessWorker(queue, prober, fakeClient, record.New(eventRecorder))
+	})
+
+	JustBeforeEach(func() {
+		Expect(fakeClient.Create(context.Background(), vm)).Should(Succeed())
+		Expect(fakeClient.Get(context.Background(), vmKey, vm)).Should(Succeed())
+
+		var err error
+		ctx, err = testWorker.CreateProbeContext(vm)
+		Expect(err).ShouldNot(HaveOccurred())
+		if ctx != nil {
+			ctx.State = state
+		}
+	})
+
+	When("VM does not specify a liveness probe action", func() {
+		BeforeEach(func() {
+			vm.Spec.LivenessProbe = &vmopv1.VirtualMachineLivenessProbeSpec{}
+		})
+
+		It("Should not create a probe context", func() {
+			Expect(ctx).To(BeNil())
+		})
+	})
+
+	It("Should run the probe with the liveness probe's actions", func() {
+		fakeTCPProbe.ProbeFn = func(ctx *proberctx.ProbeContext) (probe.Result, error) {
+			Expect(ctx.GetProbeSpec().TCPSocket).To(Equal(vm.Spec.LivenessProbe.TCPSocket))
+			return probe.Success, nil
+		}
+
+		Expect(testWorker.DoProbe(ctx)).Should(Succeed())
+		Expect(state.ConsecutiveFailures).To(BeZero())
+		Expect(state.StartTime.IsZero()).To(BeFalse())
+	})
+
+	It("Should restart the VM once the failure threshold is reached", func() {
+		fakeTCPProbe.ProbeFn = func(ctx *proberctx.ProbeContext) (probe.Result, error) {
+			return probe.Failure, fmt.Errorf("connection refused")
+		}
+
+		By("Should not restart the VM on the first failure", func() {
+			Expect(testWorker.DoProbe(ctx)).Should(Succeed())
+			Expect(state.ConsecutiveFailures).To(Equal(int32(1)))
+
+			Expect(fakeClient.Get(ctx, vmKey, vm)).Should(Succeed())
+			Expect(vm.Spec.NextRestartTime).To(BeEmpty())
+			Expect(vm.Status.RestartCount).To(BeZero())
+			Expect(fakeEvents).ShouldNot(Receive())
+		})
+
+		By("Should restart the VM on the second failure", func() {
+			Expect(testWorker.DoProbe(ctx)).Should(Succeed())
+			Expect(state.ConsecutiveFailures).To(BeZero())
+
+			Expect(fakeClient.Get(ctx, vmKey, vm)).Should(Succeed())
+			Expect(vm.Spec.NextRestartTime).To(Equal("now"))
+			Expect(vm.Status.RestartCount).To(Equal(int32(1)))
+			Expect(fakeEvents).Should(Receive(And(
+				ContainSubstring(livenessProbeFailedReason),
+				ContainSubstring("connection refused"))))
+		})
+	})
+
+	It("Should reset the failure count when the probe succeeds", func() {
+		state.ConsecutiveFailures = 1
+		fakeTCPProbe.ProbeFn = func(ctx *proberctx.ProbeContext) (probe.Result, error) {
+			return probe.Success, nil
+		}
+
+		Expect(testWorker.DoProbe(ctx)).Should(Succeed())
+		Expect(state.ConsecutiveFailures).To(BeZero())
+	})
+
+	It("Should not change the failure count when the probe result is unknown", func() {
+		state.ConsecutiveFailures = 1
+		fakeTCPProbe.ProbeFn = func(ctx *proberctx.ProbeContext) (probe.Result, error) {
+			return probe.Unknown, fmt.Errorf("unknown")
+		}
+
+		Expect(testWorker.DoProbe(ctx)).Should(Succeed())
+		Expect(state.ConsecutiveFailures).To(Equal(int32(1)))
+	})
+
+	When("the VM is not powered on", func() {
+		BeforeEach(func() {
+			vm.Status.PowerState = vmopv1.VirtualMachinePowerStateOff
+		})
+
+		It("Should reset the probe state and not restart the VM", func() {
+			state.ConsecutiveFailures = 1
+			state.StartTime = time.Now()
+
+			Expect(testWorker.ProcessProbeResult(ctx, probe.Failure, fmt.Errorf("not powered on"))).Should(Succeed())
+			Expect(state.ConsecutiveFailures).To(BeZero())
+			Expect(state.StartTime.IsZero()).To(BeTrue())
+
+			Expect(fakeClient.Get(ctx, vmKey, vm)).Should(Succeed())
+			Expect(vm.Spec.NextRestartTime).To(BeEmpty())
+		})
+	})
+
+	When("the probe has an initial delay", func() {
+		BeforeEach(func() {
+			vm.Spec.LivenessProbe.InitialDelaySeconds = 60
+		})
+
+		It("Should not run the probe until the initial delay has elapsed", func() {
+			called := false
+			fakeTCPProbe.ProbeFn = func(ctx *proberctx.ProbeContext) (probe.Result, error) {
+				called = true
+				return probe.Failure, nil
+			}
+
+			Expect(testWorker.DoProbe(ctx)).Should(Succeed())
+			Expect(called).To(BeFalse())
+
+			state.StartTime = time.Now().Add(-time.Minute)
+			Expect(testWorker.DoProbe(ctx)).Should(Succeed())
+			Expect(called).To(BeTrue())
+		})
+
+		It("Should measure the initial delay from the last restart", func() {
+			state.StartTime = time.Now().Add(-time.Hour)
+			lastRestartTime := metav1.Now()
+			ctx.VM.Status.LastRestartTime = &lastRestartTime
+
+			called := false
+			fakeTCPProbe.ProbeFn = func(ctx *proberctx.ProbeContext) (probe.Result, error) {
+				called = true
+				return probe.Failure, nil
+			}
+
+			Expect(testWorker.DoProbe(ctx)).Should(Succeed())
+			Expect(called).To(BeFalse())
+			Expect(state.StartTime).To(Equal(lastRestartTime.Time))
+		})
+	})
+
+	When("VM has an HTTP GET liveness probe", func() {
+		BeforeEach(func() {
+			vm.Spec.LivenessProbe = &vmopv1.VirtualMachineLivenessProbeSpec{
+				HTTPGet: &vmopv1.HTTPGetAction{
+					Port: intstr.FromInt(8080),
+				},
+				FailureThreshold: 1,
+			}
+		})
+
+		// Just need to test for probe selection.
+		It("Should restart the VM when probe fails", func() {
+			fakeHTTPGetProbe.ProbeFn = func(ctx *proberctx.ProbeContext) (probe.Result, error) {
+				return probe.Failure, fmt.Errorf("http get error")
+			}
+
+			Expect(testWorker.DoProbe(ctx)).Should(Succeed())
+			Expect(fakeClient.Get(ctx, vmKey, vm)).Should(Succeed())
+			Expect(vm.Spec.NextRestartTime).To(Equal("now"))
+			Expect(vm.Status.RestartCount).To(Equal(int32(1)))
+		})
+	})
+
+	When("VM has a guest heartbeat liveness probe", func() {
+		BeforeEach(func() {
+			vm.Spec.LivenessProbe = &vmopv1.VirtualMachineLivenessProbeSpec{
+				GuestHeartbeat: &vmopv1.GuestHeartbeatAction{},
+			}
+		})
+
+		// Just need to test for probe selection.
+		It("Should use the default failure threshold", func() {
+			fakeHeartbeatProbe.ProbeFn = func(ctx *proberctx.ProbeContext) (probe.Result, error) {
+				return probe.Failure, fmt.Errorf("heartbeat error")
+			}
+
+			for i := int32(1); i < defaultLivenessFailureThreshold; i++ {
+				Expect(testWorker.DoProbe(ctx)).Should(Succeed())
+				Expect(state.ConsecutiveFailures).To(Equal(i))
+			}
+			Expect(testWorker.DoProbe(ctx)).Should(Succeed())
+			Expect(fakeClient.Get(ctx, vmKey, vm)).Should(Succeed())
+			Expect(vm.Status.RestartCount).To(Equal(int32(1)))
+		})
+	})
+})
@@ -5,6 +5,8 @@
 package worker
 
 import (
+	"time"
+
 	"k8s.io/client-go/util/workqueue"
 
 	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
@@ -15,6 +17,14 @@ import (
 
 type DelayingInterface = workqueue.TypedDelayingInterface[any]
 
+const (
+	// ReadinessProbeType is the type of the probe run by the readiness worker.
+	ReadinessProbeType = "readiness"
+
+	// LivenessProbeType is the type of the probe run by the liveness worker.
+	LivenessProbeType = "liveness"
+)
+
 // Worker represents a prober worker interface.
 type Worker interface {
 	GetQueue() DelayingInterface
@@ -22,3 +32,35 @@ type Worker interface {
 	DoProbe(ctx *context.ProbeContext) error
 	ProcessProbeResult(ctx *context.ProbeContext, res probe.Result, resErr error) error
 }
+
+// getProbeState returns the probe state from the context, initializing it if
+// the context does not have one.
+func getProbeState(ctx *context.ProbeContext) *context.ProbeState {
+	if ctx.State == nil {
+		ctx.State = &context.ProbeState{}
+	}
+	return ctx.State
+}
+
+// initialDelayElapsed returns true if the probe's initial delay has elapsed
+// since the VM was first observed as powered on or was last restarted.
+func initialDelayElapsed(ctx *context.ProbeContext, initialDelaySeconds int32) bool {
+	state := getProbeState(ctx)
+
+	now := time.Now()
+	if state.StartTime.IsZero() {
+		state.StartTime = now
+	}
+	if t := ctx.VM.Status.LastRestartTime; t != nil && t.After(state.StartTime) {
+		state.StartTime = t.Time
+	}
+
+	initialDelay := time.Duration(initialDelaySeconds) * time.Second
+	if now.Before(state.StartTime.Add(initialDelay)) {
+		ctx.Logger.V(4).Info("skip probe during initial delay",
+			"probe", ctx.String(), "startTime", state.StartTime, "initialDelay", initialDelay)
+		return false
+	}
+
+	return true
+}
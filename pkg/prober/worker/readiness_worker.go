@@ -25,6 +25,12 @@ const (
 	readyReason    string = "Ready"
 	notReadyReason string = "NotReady"
 	unknownReason  string = "Unknown"
+
+	// defaultSuccessThreshold and defaultReadinessFailureThreshold are the
+	// number of consecutive successes and failures required to transition
+	// the ReadyCondition if the readiness probe does not specify them.
+	defaultSuccessThreshold          int32 = 1
+	defaultReadinessFailureThreshold int32 = 1
 )
 
 // readinessWorker implements Worker interface.
@@ -58,7 +64,7 @@ func (w *readinessWorker) GetQueue() DelayingInterface {
 func (w *readinessWorker) CreateProbeContext(vm *vmopv1.VirtualMachine) (*proberctx.ProbeContext, error) {
 	p := vm.Spec.ReadinessProbe
 
-	if p.TCPSocket == nil && p.GuestHeartbeat == nil && len(p.GuestInfo) == 0 {
+	if p.TCPSocket == nil && p.HTTPGet == nil && p.GuestHeartbeat == nil && len(p.GuestInfo) == 0 {
 		return nil, nil
 	}
 
@@ -72,7 +78,7 @@ func (w *readinessWorker) CreateProbeContext(vm *vmopv1.VirtualMachine) (*prober
 		Logger:        ctrl.Log.WithName("readiness-probe").WithValues("vmName", vm.NamespacedName()),
 		PatchHelper:   patchHelper,
 		VM:            vm,
-		ProbeType:     "readiness",
+		ProbeType:     ReadinessProbeType,
 		PeriodSeconds: p.PeriodSeconds,
 	}, nil
 }
@@ -83,6 +89,29 @@ func (w *readinessWorker) ProcessProbeResult(ctx *proberctx.ProbeContext, res pr
 	vm := ctx.VM
 	condition := w.getCondition(res, resErr)
 
+	state := getProbeState(ctx)
+	if vm.Status.PowerState != vmopv1.VirtualMachinePowerStateOn {
+		// A VM that is not powered on is not ready regardless of the probe's
+		// thresholds, and its initial delay starts over once it is powered on
+		// again.
+		state.Reset()
+	} else {
+		if res == probe.Success {
+			state.ConsecutiveSuccesses++
+			state.ConsecutiveFailures = 0
+		} else {
+			state.ConsecutiveFailures++
+			state.ConsecutiveSuccesses = 0
+		}
+
+		if !w.thresholdReached(ctx, condition) {
+			ctx.Logger.V(4).Info("VM resource READINESS probe threshold not reached",
+				"condition.status", condition.Status,
+				"successes", state.ConsecutiveSuccesses, "failures", state.ConsecutiveFailures)
+			return nil
+		}
+	}
+
 	// We only send event when either the condition type is added or its status changes, not
 	// if either its reason, severity, or message changes.
 	if c := conditions.Get(vm, condition.Type); c == nil || c.Status != condition.Status {
@@ -110,6 +139,10 @@ func (w *readinessWorker) ProcessProbeResult(ctx *proberctx.ProbeContext, res pr
 }
 
 func (w *readinessWorker) DoProbe(ctx *proberctx.ProbeContext) error {
+	if !initialDelayElapsed(ctx, ctx.VM.Spec.ReadinessProbe.InitialDelaySeconds) {
+		return nil
+	}
+
 	res, err := w.runProbe(ctx)
 	if err != nil {
 		ctx.Logger.Error(err, "readiness probe fails", "result", res)
@@ -118,19 +151,22 @@ func (w *readinessWorker) DoProbe(ctx *proberctx.ProbeContext) error {
 }
 
 // getProbe returns a specific type of probe method.
-func (w *readinessWorker) getProbe(probeSpec *vmopv1.VirtualMachineReadinessProbeSpec) probe.Probe {
+func getProbe(prober *probe.Prober, probeSpec *vmopv1.VirtualMachineReadinessProbeSpec) probe.Probe {
 	if probeSpec == nil {
 		return nil
 	}
 
 	if probeSpec.TCPSocket != nil {
-		return w.prober.TCPProbe
+		return prober.TCPProbe
+	}
+	if probeSpec.HTTPGet != nil {
+		return prober.HTTPGetProbe
 	}
 	if probeSpec.GuestHeartbeat != nil {
-		return w.prober.GuestHeartbeat
+		return prober.GuestHeartbeat
 	}
 	if len(probeSpec.GuestInfo) != 0 {
-		return w.prober.GuestInfo
+		return prober.GuestInfo
 	}
 
 	return nil
@@ -138,13 +174,47 @@ func (w *readinessWorker) getProbe(probeSpec *vmopv1.VirtualMachineReadinessProb
 
 // runProbe runs a specific type of probe based on the VM probe spec.
 func (w *readinessWorker) runProbe(ctx *proberctx.ProbeContext) (probe.Result, error) {
-	if p := w.getProbe(ctx.VM.Spec.ReadinessProbe); p != nil {
+	if p := getProbe(w.prober, ctx.VM.Spec.ReadinessProbe); p != nil {
 		return p.Probe(ctx)
 	}
 
 	return probe.Unknown, fmt.Errorf("unknown action specified for VM %s readiness probe", ctx.VM.NamespacedName())
 }
 
+// thresholdReached returns true if the probe has succeeded or failed enough
+// consecutive times for the VM's ReadyCondition to transition to the status of
+// the given condition.
+func (w *readinessWorker) thresholdReached(ctx *proberctx.ProbeContext, condition *metav1.Condition) bool {
+	p := ctx.VM.Spec.ReadinessProbe
+	state := getProbeState(ctx)
+
+	c := conditions.Get(ctx.VM, condition.Type)
+	if c != nil && c.Status == condition.Status {
+		// Not a transition.
+		return true
+	}
+
+	if condition.Status == metav1.ConditionTrue {
+		successThreshold := p.SuccessThreshold
+		if successThreshold <= 0 {
+			successThreshold = defaultSuccessThreshold
+		}
+		return state.ConsecutiveSuccesses >= successThreshold
+	}
+
+	if c == nil {
+		// A VM is not ready until its probe has succeeded the configured
+		// number of times, so there is no need to wait for failures.
+		return true
+	}
+
+	failureThreshold := p.FailureThreshold
+	if failureThreshold <= 0 {
+		failureThreshold = defaultReadinessFailureThreshold
+	}
+	return state.ConsecutiveFailures >= failureThreshold
+}
+
 // getCondition returns condition based on VM probe results.
 func (w *readinessWorker) getCondition(res probe.Result, err error) *metav1.Condition {
 	msg := ""
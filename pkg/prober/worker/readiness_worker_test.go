@@ -8,6 +8,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -41,6 +42,7 @@ var _ = Describe("VirtualMachine readiness probes", func() {
 		fakeEvents         chan string
 		fakeTCPProbe       *fakeprobe.FakeProbe
 		fakeHeartbeatProbe *fakeprobe.FakeProbe
+		fakeHTTPGetProbe   *fakeprobe.FakeProbe
 	)
 
 	BeforeEach(func() {
@@ -64,8 +66,10 @@ var _ = Describe("VirtualMachine readiness probes", func() {
 		queue := workqueue.NewNamedDelayingQueue("test")
 		fakeTCPProbe = fakeprobe.NewFakeProbe().(*fakeprobe.FakeProbe)
 		fakeHeartbeatProbe = fakeprobe.NewFakeProbe().(*fakeprobe.FakeProbe)
+		fakeHTTPGetProbe = fakeprobe.NewFakeProbe().(*fakeprobe.FakeProbe)
 		prober := &probe.Prober{
 			TCPProbe:       fakeTCPProbe,
+			HTTPGetProbe:   fakeHTTPGetProbe,
 			GuestHeartbeat: fakeHeartbeatProbe,
 		}
 		testWorker = NewReadinessWorker(queue, prober, fakeClient, fakeRecorder)
@@ -161,6 +165,116 @@ var _ = Describe("VirtualMachine readiness probes", func() {
 		})
 	})
 
+	Context("VM has readiness probe thresholds", func() {
+		var (
+			state *proberctx.ProbeState
+		)
+
+		BeforeEach(func() {
+			vm.Spec.ReadinessProbe = getVirtualMachineReadinessTCPProbe(10001)
+			vm.Spec.ReadinessProbe.SuccessThreshold = 2
+			vm.Spec.ReadinessProbe.FailureThreshold = 3
+			vm.Status.PowerState = vmopv1.VirtualMachinePowerStateOn
+			Expect(fakeClient.Create(context.Background(), vm)).Should(Succeed())
+			Expect(fakeClient.Get(context.Background(), vmKey, vm)).Should(Succeed())
+			state = &proberctx.ProbeState{}
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			ctx, err = testWorker.CreateProbeContext(vm)
+			Expect(err).ShouldNot(HaveOccurred())
+			ctx.State = state
+		})
+
+		doProbe := func(res probe.Result) {
+			fakeTCPProbe.ProbeFn = func(ctx *proberctx.ProbeContext) (probe.Result, error) {
+				return res, nil
+			}
+			Expect(testWorker.DoProbe(ctx)).Should(Succeed())
+			Expect(fakeClient.Get(ctx, vmKey, vm)).Should(Succeed())
+			ctx.VM = vm
+		}
+
+		It("Should only mark the VM as ready after the success threshold is reached", func() {
+			doProbe(probe.Success)
+			Expect(conditions.Get(vm, vmopv1.ReadyConditionType)).To(BeNil())
+
+			doProbe(probe.Success)
+			checkReadyCondition(fakeClient, vmKey, metav1.ConditionTrue)
+		})
+
+		It("Should immediately mark the VM as not ready when it has no ReadyCondition", func() {
+			doProbe(probe.Failure)
+			checkReadyCondition(fakeClient, vmKey, metav1.ConditionFalse)
+		})
+
+		When("VM is ready", func() {
+			BeforeEach(func() {
+				vm.Status.Conditions = append(vm.Status.Conditions, *conditions.TrueCondition(vmopv1.ReadyConditionType))
+				Expect(fakeClient.Status().Update(context.Background(), vm)).To(Succeed())
+			})
+
+			It("Should only mark the VM as not ready after the failure threshold is reached", func() {
+				doProbe(probe.Failure)
+				doProbe(probe.Failure)
+				checkReadyCondition(fakeClient, vmKey, metav1.ConditionTrue)
+
+				doProbe(probe.Failure)
+				checkReadyCondition(fakeClient, vmKey, metav1.ConditionFalse)
+			})
+
+			It("Should reset the failure count when the probe succeeds", func() {
+				doProbe(probe.Failure)
+				doProbe(probe.Failure)
+				doProbe(probe.Success)
+				doProbe(probe.Failure)
+				doProbe(probe.Failure)
+				checkReadyCondition(fakeClient, vmKey, metav1.ConditionTrue)
+			})
+		})
+
+		When("VM is not powered on", func() {
+			BeforeEach(func() {
+				vm.Status.PowerState = vmopv1.VirtualMachinePowerStateOff
+				vm.Status.Conditions = append(vm.Status.Conditions, *conditions.TrueCondition(vmopv1.ReadyConditionType))
+				Expect(fakeClient.Status().Update(context.Background(), vm)).To(Succeed())
+			})
+
+			It("Should immediately mark the VM as not ready", func() {
+				state.ConsecutiveSuccesses = 5
+				Expect(testWorker.ProcessProbeResult(ctx, probe.Failure, fmt.Errorf("not powered on"))).To(Succeed())
+				checkReadyCondition(fakeClient, vmKey, metav1.ConditionFalse)
+				Expect(state.ConsecutiveSuccesses).To(BeZero())
+			})
+		})
+
+		When("the probe has an initial delay", func() {
+			BeforeEach(func() {
+				vm.Spec.ReadinessProbe.InitialDelaySeconds = 60
+				Expect(fakeClient.Update(context.Background(), vm)).To(Succeed())
+			})
+
+			It("Should not run the probe until the initial delay has elapsed", func() {
+				called := false
+				fakeTCPProbe.ProbeFn = func(ctx *proberctx.ProbeContext) (probe.Result, error) {
+					called = true
+					return probe.Failure, nil
+				}
+
+				Expect(testWorker.DoProbe(ctx)).Should(Succeed())
+				Expect(called).To(BeFalse())
+				Expect(fakeClient.Get(ctx, vmKey, vm)).Should(Succeed())
+				Expect(conditions.Get(vm, vmopv1.ReadyConditionType)).To(BeNil())
+
+				state.StartTime = time.Now().Add(-time.Minute)
+				Expect(testWorker.DoProbe(ctx)).Should(Succeed())
+				Expect(called).To(BeTrue())
+				checkReadyCondition(fakeClient, vmKey, metav1.ConditionFalse)
+			})
+		})
+	})
+
 	Context("Guest heartbeat Probe", func() {
 
 		BeforeEach(func() {
@@ -185,6 +299,31 @@ var _ = Describe("VirtualMachine readiness probes", func() {
 			Expect(condition.Message).To(ContainSubstring("heartbeat error"))
 		})
 	})
+
+	Context("HTTP GET Probe", func() {
+
+		BeforeEach(func() {
+			vm.Spec.ReadinessProbe = getVirtualMachineHTTPGetProbe(8080)
+			Expect(fakeClient.Create(context.Background(), vm)).Should(Succeed())
+			Expect(fakeClient.Get(context.Background(), vmKey, vm)).Should(Succeed())
+			var err error
+			ctx, err = testWorker.CreateProbeContext(vm)
+			Expect(err).ShouldNot(HaveOccurred())
+		})
+
+		// Just need to test for probe selection.
+		It("Should update ReadyCondition when probe fails", func() {
+			fakeHTTPGetProbe.ProbeFn = func(ctx *proberctx.ProbeContext) (probe.Result, error) {
+				return probe.Failure, fmt.Errorf("http get error")
+			}
+
+			Expect(testWorker.DoProbe(ctx)).Should(Succeed())
+			Expect(fakeClient.Get(ctx, vmKey, vm)).Should(Succeed())
+			condition := conditions.Get(vm, vmopv1.ReadyConditionType)
+			Expect(condition).ToNot(BeNil())
+			Expect(condition.Message).To(ContainSubstring("http get error"))
+		})
+	})
 })
 
 func TestReadinessProbeWorker(t *testing.T) {
@@ -207,3 +346,12 @@ func getVirtualMachineHeartbeatProbe() *vmopv1.VirtualMachineReadinessProbeSpec
 		PeriodSeconds:  1,
 	}
 }
+
+func getVirtualMachineHTTPGetProbe(port int) *vmopv1.VirtualMachineReadinessProbeSpec {
+	return &vmopv1.VirtualMachineReadinessProbeSpec{
+		HTTPGet: &vmopv1.HTTPGetAction{
+			Port: intstr.FromInt(port),
+		},
+		PeriodSeconds: 1,
+	}
+}
@@ -7,6 +7,7 @@ package fake
 import (
 	"context"
 	"fmt"
+	"strings"
 	"sync"
 
 	"github.com/vmware/govmomi/vapi/library"
@@ -35,10 +36,17 @@ type funcs struct {
 	DeleteVirtualMachineFn              func(ctx context.Context, vm *vmopv1.VirtualMachine) error
 	PublishVirtualMachineFn             func(ctx context.Context, vm *vmopv1.VirtualMachine,
 		vmPub *vmopv1.VirtualMachinePublishRequest, cl *imgregv1a1.ContentLibrary, actID string) (string, error)
-	GetVirtualMachineGuestHeartbeatFn  func(ctx context.Context, vm *vmopv1.VirtualMachine) (vmopv1.GuestHeartbeatStatus, error)
-	GetVirtualMachinePropertiesFn      func(ctx context.Context, vm *vmopv1.VirtualMachine, propertyPaths []string) (map[string]any, error)
-	GetVirtualMachineWebMKSTicketFn    func(ctx context.Context, vm *vmopv1.VirtualMachine, pubKey string) (string, error)
-	GetVirtualMachineHardwareVersionFn func(ctx context.Context, vm *vmopv1.VirtualMachine) (vimtypes.HardwareVersion, error)
+	ExportVirtualMachineFn func(ctx context.Context, vm *vmopv1.VirtualMachine, name string,
+		writeFileFn providers.ExportFileFn) error
+	GetVirtualMachineGuestHeartbeatFn      func(ctx context.Context, vm *vmopv1.VirtualMachine) (vmopv1.GuestHeartbeatStatus, error)
+	GetVirtualMachinePropertiesFn          func(ctx context.Context, vm *vmopv1.VirtualMachine, propertyPaths []string) (map[string]any, error)
+	GetVirtualMachineWebMKSTicketFn        func(ctx context.Context, vm *vmopv1.VirtualMachine, pubKey string) (string, error)
+	GetVirtualMachineSerialConsoleTicketFn func(ctx context.Context, vm *vmopv1.VirtualMachine, pubKey, token string) (string, error)
+	GetVirtualMachineHardwareVersionFn     func(ctx context.Context, vm *vmopv1.VirtualMachine) (vimtypes.HardwareVersion, error)
+
+	CreateOrUpdateVirtualMachineSnapshotFn func(ctx context.Context, vm *vmopv1.VirtualMachine, vmSnapshot *vmopv1.VirtualMachineSnapshot) error
+	DeleteVirtualMachineSnapshotFn         func(ctx context.Context, vm *vmopv1.VirtualMachine, vmSnapshot *vmopv1.VirtualMachineSnapshot) error
+	GetVirtualMachineBackupFn              func(ctx context.Context, moID string) (*providers.VirtualMachineBackup, error)
 
 	// ListItemsFromContentLibraryFn              func(ctx context.Context, contentLibrary *vmopv1.ContentLibraryProvider) ([]string, error)
 	// GetVirtualMachineImageFromContentLibraryFn func(ctx context.Context, contentLibrary *vmopv1.ContentLibraryProvider, itemID string,
@@ -70,6 +78,7 @@ type VMProvider struct {
 	vmPubMap          map[string]vimtypes.TaskInfoState
 
 	isPublishVMCalled bool
+	isExportVMCalled  bool
 }
 
 var _ providers.VirtualMachineProviderInterface = &VMProvider{}
@@ -83,6 +92,7 @@ func (s *VMProvider) Reset() {
 	s.resourcePolicyMap = make(map[client.ObjectKey]*vmopv1.VirtualMachineSetResourcePolicy)
 	s.vmPubMap = make(map[string]vimtypes.TaskInfoState)
 	s.isPublishVMCalled = false
+	s.isExportVMCalled = false
 }
 
 func (s *VMProvider) CreateOrUpdateVirtualMachine(ctx context.Context, vm *vmopv1.VirtualMachine) error {
@@ -130,6 +140,25 @@ func (s *VMProvider) PublishVirtualMachine(ctx context.Context, vm *vmopv1.Virtu
 	return "dummy-id", nil
 }
 
+func (s *VMProvider) ExportVirtualMachine(ctx context.Context, vm *vmopv1.VirtualMachine,
+	name string, writeFileFn providers.ExportFileFn) error {
+	// The lock is not held while exporting so a test can block the export
+	// to observe a publish that is in progress.
+	s.Lock()
+	s.isExportVMCalled = true
+	exportFn := s.ExportVirtualMachineFn
+	s.Unlock()
+
+	if exportFn != nil {
+		return exportFn(ctx, vm, name, writeFileFn)
+	}
+
+	if err := writeFileFn(name+"-disk-0.vmdk", strings.NewReader("dummy-disk")); err != nil {
+		return err
+	}
+	return writeFileFn(name+".ovf", strings.NewReader("<Envelope/>"))
+}
+
 func (s *VMProvider) GetVirtualMachineGuestHeartbeat(ctx context.Context, vm *vmopv1.VirtualMachine) (vmopv1.GuestHeartbeatStatus, error) {
 	s.Lock()
 	defer s.Unlock()
@@ -161,6 +190,15 @@ func (s *VMProvider) GetVirtualMachineWebMKSTicket(ctx context.Context, vm *vmop
 	return "", nil
 }
 
+func (s *VMProvider) GetVirtualMachineSerialConsoleTicket(ctx context.Context, vm *vmopv1.VirtualMachine, pubKey, token string) (string, error) {
+	s.Lock()
+	defer s.Unlock()
+	if s.GetVirtualMachineSerialConsoleTicketFn != nil {
+		return s.GetVirtualMachineSerialConsoleTicketFn(ctx, vm, pubKey, token)
+	}
+	return "", nil
+}
+
 func (s *VMProvider) GetVirtualMachineHardwareVersion(ctx context.Context, vm *vmopv1.VirtualMachine) (vimtypes.HardwareVersion, error) {
 	s.Lock()
 	defer s.Unlock()
@@ -170,6 +208,33 @@ func (s *VMProvider) GetVirtualMachineHardwareVersion(ctx context.Context, vm *v
 	return vimtypes.VMX15, nil
 }
 
+func (s *VMProvider) CreateOrUpdateVirtualMachineSnapshot(ctx context.Context, vm *vmopv1.VirtualMachine, vmSnapshot *vmopv1.VirtualMachineSnapshot) error {
+	s.Lock()
+	defer s.Unlock()
+	if s.CreateOrUpdateVirtualMachineSnapshotFn != nil {
+		return s.CreateOrUpdateVirtualMachineSnapshotFn(ctx, vm, vmSnapshot)
+	}
+	return nil
+}
+
+func (s *VMProvider) DeleteVirtualMachineSnapshot(ctx context.Context, vm *vmopv1.VirtualMachine, vmSnapshot *vmopv1.VirtualMachineSnapshot) error {
+	s.Lock()
+	defer s.Unlock()
+	if s.DeleteVirtualMachineSnapshotFn != nil {
+		return s.DeleteVirtualMachineSnapshotFn(ctx, vm, vmSnapshot)
+	}
+	return nil
+}
+
+func (s *VMProvider) GetVirtualMachineBackup(ctx context.Context, moID string) (*providers.VirtualMachineBackup, error) {
+	s.Lock()
+	defer s.Unlock()
+	if s.GetVirtualMachineBackupFn != nil {
+		return s.GetVirtualMachineBackupFn(ctx, moID)
+	}
+	return nil, nil
+}
+
 func (s *VMProvider) CreateOrUpdateVirtualMachineSetResourcePolicy(ctx context.Context, resourcePolicy *vmopv1.VirtualMachineSetResourcePolicy) error {
 	s.Lock()
 	defer s.Unlock()
@@ -376,6 +441,13 @@ func (s *VMProvider) IsPublishVMCalled() bool {
 	return s.isPublishVMCalled
 }
 
+func (s *VMProvider) IsExportVMCalled() bool {
+	s.Lock()
+	defer s.Unlock()
+
+	return s.isExportVMCalled
+}
+
 func (s *VMProvider) DoesProfileSupportEncryption(
 	ctx context.Context,
 	profileID string) (bool, error) {
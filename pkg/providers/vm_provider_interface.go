@@ -7,14 +7,17 @@ package providers
 import (
 	"context"
 	"errors"
+	"io"
 
 	"github.com/vmware/govmomi/vapi/library"
 	vimtypes "github.com/vmware/govmomi/vim25/types"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
 
 	imgregv1a1 "github.com/vmware-tanzu/image-registry-operator-api/api/v1alpha1"
 
 	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	backupapi "github.com/vmware-tanzu/vm-operator/pkg/backup/api"
 	"github.com/vmware-tanzu/vm-operator/pkg/util/vsphere/client"
 )
 
@@ -30,6 +33,29 @@ var (
 	ErrReconcileInProgress = errors.New("reconcile already in progress")
 )
 
+// ExportFileFn is called by ExportVirtualMachine with the name and content of
+// each file of the exported OVF. The disks are exported before the OVF
+// descriptor that references them.
+type ExportFileFn func(fileName string, r io.Reader) error
+
+// VirtualMachineBackup is the backup data of a VM that is read from the VM on
+// the underlying infrastructure.
+type VirtualMachineBackup struct {
+	// VM is the backed up VirtualMachine resource.
+	VM *vmopv1.VirtualMachine
+
+	// AdditionalResources are the backed up resources referenced by the VM,
+	// ex. its bootstrap Secrets.
+	AdditionalResources []*unstructured.Unstructured
+
+	// PVCDiskData describes the PVCs attached to the VM when it was backed up.
+	PVCDiskData []backupapi.PVCDiskData
+
+	// PVCDiskURLPaths are the URLs of the backed up PVCs' disks, keyed by the
+	// name of the PVC, used to statically register the disks as volumes.
+	PVCDiskURLPaths map[string]string
+}
+
 // VirtualMachineProviderInterface is a pluggable interface for VM Providers.
 type VirtualMachineProviderInterface interface {
 	CreateOrUpdateVirtualMachine(ctx context.Context, vm *vmopv1.VirtualMachine) error
@@ -37,11 +63,21 @@ type VirtualMachineProviderInterface interface {
 	DeleteVirtualMachine(ctx context.Context, vm *vmopv1.VirtualMachine) error
 	PublishVirtualMachine(ctx context.Context, vm *vmopv1.VirtualMachine,
 		vmPub *vmopv1.VirtualMachinePublishRequest, cl *imgregv1a1.ContentLibrary, actID string) (string, error)
+	ExportVirtualMachine(ctx context.Context, vm *vmopv1.VirtualMachine, name string, writeFileFn ExportFileFn) error
 	GetVirtualMachineGuestHeartbeat(ctx context.Context, vm *vmopv1.VirtualMachine) (vmopv1.GuestHeartbeatStatus, error)
 	GetVirtualMachineProperties(ctx context.Context, vm *vmopv1.VirtualMachine, propertyPaths []string) (map[string]any, error)
 	GetVirtualMachineWebMKSTicket(ctx context.Context, vm *vmopv1.VirtualMachine, pubKey string) (string, error)
+	GetVirtualMachineSerialConsoleTicket(ctx context.Context, vm *vmopv1.VirtualMachine, pubKey, token string) (string, error)
 	GetVirtualMachineHardwareVersion(ctx context.Context, vm *vmopv1.VirtualMachine) (vimtypes.HardwareVersion, error)
 
+	CreateOrUpdateVirtualMachineSnapshot(ctx context.Context, vm *vmopv1.VirtualMachine, vmSnapshot *vmopv1.VirtualMachineSnapshot) error
+	DeleteVirtualMachineSnapshot(ctx context.Context, vm *vmopv1.VirtualMachine, vmSnapshot *vmopv1.VirtualMachineSnapshot) error
+
+	// GetVirtualMachineBackup returns the backup data of the VM with the
+	// specified managed object ID, or nil if the VM does not exist or has not
+	// been backed up.
+	GetVirtualMachineBackup(ctx context.Context, moID string) (*VirtualMachineBackup, error)
+
 	CreateOrUpdateVirtualMachineSetResourcePolicy(ctx context.Context, resourcePolicy *vmopv1.VirtualMachineSetResourcePolicy) error
 	IsVirtualMachineSetResourcePolicyReady(ctx context.Context, availabilityZoneName string, resourcePolicy *vmopv1.VirtualMachineSetResourcePolicy) (bool, error)
 	DeleteVirtualMachineSetResourcePolicy(ctx context.Context, resourcePolicy *vmopv1.VirtualMachineSetResourcePolicy) error
@@ -48,6 +48,31 @@ const (
 	//             managed by VM Operator.
 	MMPowerOffVMExtraConfigKey = "maintenance.vm.evacuation.poweroff"
 
+	// GuestFileSystemResizeExtraConfigKey is the ExtraConfig key used to
+	// publish the volumes whose file systems need to be grown by the guest
+	// after the volumes have been expanded.
+	GuestFileSystemResizeExtraConfigKey = "guestinfo.vmservice.volumes.resize"
+
+	// EphemeralVolumeFileNameSuffix is appended to the name of an ephemeral
+	// volume to get the name of its disk in the VM's directory.
+	EphemeralVolumeFileNameSuffix = "-ephemeral.vmdk"
+
+	// DataVolumeFileNameSuffix is appended to the name of a volume backed by
+	// a ConfigMap or Secret, and the digest of its data, to get the name of
+	// its disk in the VM's directory.
+	DataVolumeFileNameSuffix = "-data.vmdk"
+
+	// DataVolumeSourceFileNameSuffix is appended to the name of the disk of a
+	// volume backed by a ConfigMap or Secret to get the name of the hosted
+	// disk that is uploaded to the VM's directory and copied to create it.
+	DataVolumeSourceFileNameSuffix = "-source.vmdk"
+
+	// EphemeralVolumesExtraConfigKey is the ExtraConfig key used to record
+	// the UUIDs of the disks created for the volumes that are not backed by a
+	// PVC, keyed by the name of the volume. Only these disks are ever removed
+	// from the VM when their volume is removed from the spec.
+	EphemeralVolumesExtraConfigKey = "vmservice.volumes.ephemeral"
+
 	// NetPlanVersion points to the version used for Network config.
 	// For more information, please see https://cloudinit.readthedocs.io/en/latest/topics/network-config-format-v2.html
 	NetPlanVersion = int64(2)
@@ -69,6 +94,9 @@ const (
 	CloudInitGuestInfoUserdata         = "guestinfo.userdata"
 	CloudInitGuestInfoUserdataEncoding = "guestinfo.userdata.encoding"
 
+	IgnitionGuestInfoConfigData         = "guestinfo.ignition.config.data"
+	IgnitionGuestInfoConfigDataEncoding = "guestinfo.ignition.config.data.encoding"
+
 	// EncryptionClassNameAnnotation specifies the name of an EncryptionClass
 	// resource. This is used by APIs that participate in BYOK but cannot modify
 	// their spec to do so, such as the PersistentVolumeClaim API.
@@ -99,6 +127,11 @@ const (
 	// InstanceStorageVDiskID vDisk ID for instance storage volume.
 	InstanceStorageVDiskID = "cc737f33-2aa3-4594-aa60-df7d6d4cb984"
 
+	// ClonedVolumeVMNameLabelKey is the label key on a PVC cloned from a
+	// volume of the source VM of a linked clone. Its value is the name of the
+	// VM for which the PVC was cloned.
+	ClonedVolumeVMNameLabelKey = "vmoperator.vmware.com/cloned-volume-vm-name"
+
 	// VPCAttachmentRef annotation key is for VPC SubnetPort to get virtual machine name.
 	VPCAttachmentRef = "nsx.vmware.com/attachment_ref"
 
@@ -123,9 +123,15 @@ func CreateAndWaitForNetworkInterfaces(
 	}
 
 	var defaultToGlobalNameservers, defaultToGlobalSearchDomains bool
-	if bootstrap := vmCtx.VM.Spec.Bootstrap; bootstrap != nil && bootstrap.CloudInit != nil {
-		defaultToGlobalNameservers = ptr.DerefWithDefault(bootstrap.CloudInit.UseGlobalNameserversAsDefault, true)
-		defaultToGlobalSearchDomains = ptr.DerefWithDefault(bootstrap.CloudInit.UseGlobalSearchDomainsAsDefault, true)
+	if bootstrap := vmCtx.VM.Spec.Bootstrap; bootstrap != nil {
+		if bootstrap.CloudInit != nil {
+			defaultToGlobalNameservers = ptr.DerefWithDefault(bootstrap.CloudInit.UseGlobalNameserversAsDefault, true)
+			defaultToGlobalSearchDomains = ptr.DerefWithDefault(bootstrap.CloudInit.UseGlobalSearchDomainsAsDefault, true)
+		} else if bootstrap.Ignition != nil {
+			// The systemd-networkd units only have per-interface DNS settings.
+			defaultToGlobalNameservers = true
+			defaultToGlobalSearchDomains = true
+		}
 	}
 
 	results := make([]NetworkInterfaceResult, 0, len(networkSpec.Interfaces))
@@ -164,15 +170,111 @@ func CreateAndWaitForNetworkInterfaces(
 		results = append(results, *result)
 	}
 
-	// TODO: Once we really support network changing on the fly, we need to keep track of now
-	// unused network interface CRDs so they can be deleted after they're removed from the VM
-	// via Reconfigure, instead of delaying that until the VM is deleted via GC.
-
 	return NetworkInterfaceResults{
 		Results: results,
 	}, nil
 }
 
+// DeleteOrphanedNetworkInterfaces deletes the VM's network interface CRs that no
+// longer correspond to an interface in the VM's spec, ex. after an interface was
+// removed from the VM via Reconfigure. Otherwise, these CRs would not be deleted
+// until the VM is deleted via GC.
+func DeleteOrphanedNetworkInterfaces(
+	vmCtx pkgctx.VirtualMachineContext,
+	client ctrlclient.Client) error {
+
+	networkType := pkgcfg.FromContext(vmCtx).NetworkProviderType
+
+	// Both the older (v1a1) and the current CR names are kept since the CR of an
+	// interface may have either name.
+	expectedNames := map[string]struct{}{}
+	if networkSpec := vmCtx.VM.Spec.Network; networkSpec != nil && !networkSpec.Disabled {
+		for _, interfaceSpec := range networkSpec.Interfaces {
+			var networkName string
+			if interfaceSpec.Network != nil {
+				networkName = interfaceSpec.Network.Name
+			}
+
+			switch networkType {
+			case pkgcfg.NetworkProviderTypeVDS:
+				expectedNames[NetOPCRName(vmCtx.VM.Name, networkName, interfaceSpec.Name, true)] = struct{}{}
+				expectedNames[NetOPCRName(vmCtx.VM.Name, networkName, interfaceSpec.Name, false)] = struct{}{}
+			case pkgcfg.NetworkProviderTypeNSXT:
+				expectedNames[NCPCRName(vmCtx.VM.Name, networkName, interfaceSpec.Name, true)] = struct{}{}
+				expectedNames[NCPCRName(vmCtx.VM.Name, networkName, interfaceSpec.Name, false)] = struct{}{}
+			case pkgcfg.NetworkProviderTypeVPC:
+				expectedNames[VPCCRName(vmCtx.VM.Name, networkName, interfaceSpec.Name)] = struct{}{}
+			}
+		}
+	}
+
+	var objs []ctrlclient.Object
+	listOpts := []ctrlclient.ListOption{
+		ctrlclient.InNamespace(vmCtx.VM.Namespace),
+		ctrlclient.MatchingLabels{VMNameLabel: vmCtx.VM.Name},
+	}
+
+	switch networkType {
+	case pkgcfg.NetworkProviderTypeVDS:
+		list := &netopv1alpha1.NetworkInterfaceList{}
+		if err := client.List(vmCtx, list, listOpts...); err != nil {
+			return err
+		}
+		for i := range list.Items {
+			objs = append(objs, &list.Items[i])
+		}
+	case pkgcfg.NetworkProviderTypeNSXT:
+		list := &ncpv1alpha1.VirtualNetworkInterfaceList{}
+		if err := client.List(vmCtx, list, listOpts...); err != nil {
+			return err
+		}
+		for i := range list.Items {
+			objs = append(objs, &list.Items[i])
+		}
+	case pkgcfg.NetworkProviderTypeVPC:
+		list := &vpcv1alpha1.SubnetPortList{}
+		if err := client.List(vmCtx, list, listOpts...); err != nil {
+			return err
+		}
+		for i := range list.Items {
+			objs = append(objs, &list.Items[i])
+		}
+	default:
+		// Named networks do not have any CRs.
+		return nil
+	}
+
+	for _, obj := range objs {
+		if _, ok := expectedNames[obj.GetName()]; ok {
+			continue
+		}
+
+		// Only delete the CRs that are owned by this VM, in case the label was
+		// added to the CR by something else.
+		if !isOwnedByVM(vmCtx.VM, obj) {
+			continue
+		}
+
+		vmCtx.Logger.Info("Deleting orphaned network interface",
+			"kind", fmt.Sprintf("%T", obj), "name", obj.GetName())
+
+		if err := client.Delete(vmCtx, obj); ctrlclient.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete network interface %q: %w", obj.GetName(), err)
+		}
+	}
+
+	return nil
+}
+
+func isOwnedByVM(vm *vmopv1.VirtualMachine, obj ctrlclient.Object) bool {
+	for _, ref := range obj.GetOwnerReferences() {
+		if ref.UID == vm.UID {
+			return true
+		}
+	}
+	return false
+}
+
 // applyInterfaceSpecToResult applies the InterfaceSpec to results. Much of the InterfaceSpec - like DHCP -
 // cannot be specified to the underlying network provider so apply those overrides to the results.
 func applyInterfaceSpecToResult(
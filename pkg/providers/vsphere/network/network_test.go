@@ -759,3 +759,174 @@ var _ = Describe("CreateAndWaitForNetworkInterfaces", Label(testlabels.VCSim), f
 		})
 	})
 })
+
+var _ = Describe("DeleteOrphanedNetworkInterfaces", Label(testlabels.VCSim), func() {
+
+	var (
+		testConfig builder.VCSimTestConfig
+		ctx        *builder.TestContextForVCSim
+
+		vmCtx       pkgctx.VirtualMachineContext
+		vm          *vmopv1.VirtualMachine
+		initObjects []client.Object
+		err         error
+	)
+
+	BeforeEach(func() {
+		testConfig = builder.VCSimTestConfig{}
+
+		vm = &vmopv1.VirtualMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "network-test-vm",
+				Namespace: "network-test-ns",
+				UID:       "network-test-vm-uid",
+			},
+			Spec: vmopv1.VirtualMachineSpec{
+				Network: &vmopv1.VirtualMachineNetworkSpec{
+					Interfaces: []vmopv1.VirtualMachineNetworkInterfaceSpec{
+						{
+							Name:    "eth0",
+							Network: &common.PartialObjectRef{Name: "my-network"},
+						},
+					},
+				},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		ctx = suite.NewTestContextForVCSim(testConfig, initObjects...)
+
+		vmCtx = pkgctx.VirtualMachineContext{
+			Context: ctx,
+			Logger:  suite.GetLogger().WithName("network_test"),
+			VM:      vm,
+		}
+
+		err = network.DeleteOrphanedNetworkInterfaces(vmCtx, ctx.Client)
+	})
+
+	AfterEach(func() {
+		ctx.AfterEach()
+		ctx = nil
+		initObjects = nil
+	})
+
+	objectMeta := func(name string, owned bool) metav1.ObjectMeta {
+		objMeta := metav1.ObjectMeta{
+			Name:      name,
+			Namespace: vm.Namespace,
+			Labels:    map[string]string{network.VMNameLabel: vm.Name},
+		}
+		if owned {
+			objMeta.OwnerReferences = []metav1.OwnerReference{
+				{
+					APIVersion: vmopv1.GroupVersion.String(),
+					Kind:       "VirtualMachine",
+					Name:       vm.Name,
+					UID:        vm.UID,
+				},
+			}
+		}
+		return objMeta
+	}
+
+	exists := func(obj client.Object) bool {
+		return ctx.Client.Get(ctx, client.ObjectKeyFromObject(obj), obj) == nil
+	}
+
+	Context("VDS", func() {
+		var expected, orphaned, notOwned *netopv1alpha1.NetworkInterface
+
+		BeforeEach(func() {
+			testConfig.WithNetworkEnv = builder.NetworkEnvVDS
+
+			expected = &netopv1alpha1.NetworkInterface{
+				ObjectMeta: objectMeta(network.NetOPCRName(vm.Name, "my-network", "eth0", false), true),
+			}
+			orphaned = &netopv1alpha1.NetworkInterface{
+				ObjectMeta: objectMeta(network.NetOPCRName(vm.Name, "my-network", "eth1", false), true),
+			}
+			notOwned = &netopv1alpha1.NetworkInterface{
+				ObjectMeta: objectMeta(network.NetOPCRName(vm.Name, "my-network", "eth2", false), false),
+			}
+			initObjects = append(initObjects, expected, orphaned, notOwned)
+		})
+
+		It("deletes the network interface of the removed interface", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(exists(expected)).To(BeTrue())
+			Expect(exists(orphaned)).To(BeFalse())
+			Expect(exists(notOwned)).To(BeTrue())
+		})
+
+		When("v1a1 network interface exists", func() {
+			BeforeEach(func() {
+				expected.Name = network.NetOPCRName(vm.Name, "my-network", "eth0", true)
+			})
+
+			It("does not delete it", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(exists(expected)).To(BeTrue())
+				Expect(exists(orphaned)).To(BeFalse())
+			})
+		})
+
+		When("network is disabled", func() {
+			BeforeEach(func() {
+				vm.Spec.Network.Disabled = true
+			})
+
+			It("deletes all of the VM's network interfaces", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(exists(expected)).To(BeFalse())
+				Expect(exists(orphaned)).To(BeFalse())
+				Expect(exists(notOwned)).To(BeTrue())
+			})
+		})
+	})
+
+	Context("NCP", func() {
+		var expected, orphaned *ncpv1alpha1.VirtualNetworkInterface
+
+		BeforeEach(func() {
+			testConfig.WithNetworkEnv = builder.NetworkEnvNSXT
+
+			expected = &ncpv1alpha1.VirtualNetworkInterface{
+				ObjectMeta: objectMeta(network.NCPCRName(vm.Name, "my-network", "eth0", false), true),
+			}
+			orphaned = &ncpv1alpha1.VirtualNetworkInterface{
+				ObjectMeta: objectMeta(network.NCPCRName(vm.Name, "my-network", "eth1", false), true),
+			}
+			initObjects = append(initObjects, expected, orphaned)
+		})
+
+		It("deletes the network interface of the removed interface", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(exists(expected)).To(BeTrue())
+			Expect(exists(orphaned)).To(BeFalse())
+		})
+	})
+
+	Context("VPC", func() {
+		var expected, orphaned *vpcv1alpha1.SubnetPort
+
+		BeforeEach(func() {
+			testConfig.WithNetworkEnv = builder.NetworkEnvVPC
+
+			expected = &vpcv1alpha1.SubnetPort{
+				ObjectMeta: objectMeta(network.VPCCRName(vm.Name, "my-network", "eth0"), true),
+			}
+			orphaned = &vpcv1alpha1.SubnetPort{
+				ObjectMeta: objectMeta(network.VPCCRName(vm.Name, "my-network", "eth1"), true),
+			}
+			initObjects = append(initObjects, expected, orphaned)
+		})
+
+		It("deletes the subnet port of the removed interface", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(exists(expected)).To(BeTrue())
+			Expect(exists(orphaned)).To(BeFalse())
+		})
+	})
+})
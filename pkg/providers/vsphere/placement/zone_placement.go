@@ -8,6 +8,7 @@ import (
 	"context"
 	"fmt"
 	"math/rand"
+	"sort"
 	"strings"
 
 	"github.com/vmware/govmomi/find"
@@ -20,6 +21,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/sets"
 	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
 
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
 	topologyv1 "github.com/vmware-tanzu/vm-operator/external/tanzu-topology/api/v1alpha1"
 	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
 	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
@@ -351,19 +353,46 @@ func getZonalPlacementRecommendations(
 	return recommendations
 }
 
-// MakePlacementDecision selects one of the recommendations for placement.
-func MakePlacementDecision(recommendations map[string][]Recommendation) (string, Recommendation) {
-	// Use an explicit rand.Intn() instead of first entry returned by map iterator.
+// MakePlacementDecision selects one of the recommendations for placement. When
+// the policy is non-nil, the zone is selected from the zones that best satisfy
+// the policy.
+func MakePlacementDecision(
+	recommendations map[string][]Recommendation,
+	policy *ZonePlacementPolicy) (string, Recommendation) {
+
 	zoneNames := make([]string, 0, len(recommendations))
 	for zoneName := range recommendations {
 		zoneNames = append(zoneNames, zoneName)
 	}
+	sort.Strings(zoneNames)
+	zoneNames = policy.BestZones(zoneNames)
+
+	// Use an explicit rand.Intn() instead of first entry returned by map iterator.
 	zoneName := zoneNames[rand.Intn(len(zoneNames))] //nolint:gosec
 
 	recs := recommendations[zoneName]
 	return zoneName, recs[rand.Intn(len(recs))] //nolint:gosec
 }
 
+// getZonePlacementPolicy returns the zone placement policy for the VM, or nil
+// if the VM does not have one.
+func getZonePlacementPolicy(
+	vmCtx pkgctx.VirtualMachineContext,
+	client ctrlclient.Client) (*ZonePlacementPolicy, error) {
+
+	spec := GetZonePlacementSpec(vmCtx.VM)
+	if spec == nil {
+		return nil, nil
+	}
+
+	vmList := &vmopv1.VirtualMachineList{}
+	if err := client.List(vmCtx, vmList, ctrlclient.InNamespace(vmCtx.VM.Namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list VirtualMachines for zone placement policy: %w", err)
+	}
+
+	return NewZonePlacementPolicy(vmCtx.VM, *spec, vmList.Items)
+}
+
 // Placement determines if the VM needs placement, and if so, determines where to place the VM
 // and updates the Labels and Annotations with the placement decision.
 func Placement(
@@ -424,6 +453,27 @@ func Placement(
 		candidates = allowedCandidates
 	}
 
+	var policy *ZonePlacementPolicy
+	if curResult.needZonePlacement {
+		policy, err = getZonePlacementPolicy(vmCtx, client)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if policy != nil {
+		allowedZones := sets.New(policy.Filter(sets.List(sets.KeySet(candidates)))...)
+		for zoneName := range candidates {
+			if !allowedZones.Has(zoneName) {
+				delete(candidates, zoneName)
+			}
+		}
+
+		if len(candidates) == 0 {
+			return nil, fmt.Errorf("no placement candidates available after applying zone placement policy")
+		}
+	}
+
 	// TBD: May want to get the host for vGPU and other passthru devices too.
 	var recommendations map[string][]Recommendation
 	if curResult.needZonePlacement {
@@ -442,7 +492,7 @@ func Placement(
 		return nil, fmt.Errorf("no placement recommendations available")
 	}
 
-	zoneName, rec := MakePlacementDecision(recommendations)
+	zoneName, rec := MakePlacementDecision(recommendations, policy)
 	vmCtx.Logger.V(5).Info("Placement recommendation", "zone", zoneName, "recommendation", rec)
 
 	if pkgcfg.FromContext(vmCtx).Features.FastDeploy {
@@ -0,0 +1,238 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package placement
+
+import (
+	"fmt"
+	"slices"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	"github.com/vmware-tanzu/vm-operator/pkg/topology"
+)
+
+// zoneConstraint is a constraint on the number of VMs matching a selector in
+// each zone.
+type zoneConstraint struct {
+	selector labels.Selector
+	maxSkew  int
+	required bool
+
+	// counts is the number of existing VMs that match the selector in each
+	// zone.
+	counts map[string]int
+}
+
+// ZonePlacementPolicy selects the zones a VM may be placed in, and the order
+// of preference among those zones, based upon the zones of the existing VMs.
+type ZonePlacementPolicy struct {
+	spread         []zoneConstraint
+	antiAffinity   *zoneConstraint
+	preferredZones []string
+}
+
+// GetZonePlacementSpec returns the placement spec for the VM. When the VM does
+// not specify one, but is owned by a VirtualMachineReplicaSet, a best effort
+// spread of the replicas across zones is returned. Otherwise nil is returned.
+func GetZonePlacementSpec(vm *vmopv1.VirtualMachine) *vmopv1.VirtualMachinePlacementSpec {
+	if vm.Spec.Placement != nil {
+		return vm.Spec.Placement
+	}
+
+	if rsName := vm.Labels[vmopv1.VirtualMachineReplicaSetNameLabel]; rsName != "" {
+		return &vmopv1.VirtualMachinePlacementSpec{
+			TopologySpreadConstraints: []vmopv1.VirtualMachineTopologySpreadConstraint{
+				{
+					MaxSkew: 1,
+					LabelSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{
+							vmopv1.VirtualMachineReplicaSetNameLabel: rsName,
+						},
+					},
+					WhenUnsatisfiable: vmopv1.ScheduleAnywayUnsatisfiableConstraintAction,
+				},
+			},
+		}
+	}
+
+	return nil
+}
+
+// NewZonePlacementPolicy returns the policy for placing the VM given the
+// placement spec and the existing VMs in the VM's namespace.
+func NewZonePlacementPolicy(
+	vm *vmopv1.VirtualMachine,
+	spec vmopv1.VirtualMachinePlacementSpec,
+	existingVMs []vmopv1.VirtualMachine) (*ZonePlacementPolicy, error) {
+
+	p := &ZonePlacementPolicy{
+		preferredZones: spec.PreferredZones,
+	}
+
+	for i, c := range spec.TopologySpreadConstraints {
+		zc, err := newZoneConstraint(c.LabelSelector, c.WhenUnsatisfiable)
+		if err != nil {
+			return nil, fmt.Errorf("invalid topology spread constraint %d: %w", i, err)
+		}
+		zc.maxSkew = max(1, int(c.MaxSkew))
+		p.spread = append(p.spread, zc)
+	}
+
+	if aa := spec.ZoneAntiAffinity; aa != nil {
+		zc, err := newZoneConstraint(aa.LabelSelector, aa.WhenUnsatisfiable)
+		if err != nil {
+			return nil, fmt.Errorf("invalid zone anti-affinity: %w", err)
+		}
+		p.antiAffinity = &zc
+	}
+
+	for i := range existingVMs {
+		existingVM := &existingVMs[i]
+		if existingVM.Name == vm.Name || !existingVM.DeletionTimestamp.IsZero() {
+			continue
+		}
+
+		zoneName := existingVM.Status.Zone
+		if zoneName == "" {
+			// The zone label is set when the VM is placed, which may be
+			// before the status has been updated.
+			zoneName = existingVM.Labels[topology.KubernetesTopologyZoneLabelKey]
+		}
+		if zoneName == "" {
+			continue
+		}
+
+		vmLabels := labels.Set(existingVM.Labels)
+		for j := range p.spread {
+			if p.spread[j].selector.Matches(vmLabels) {
+				p.spread[j].counts[zoneName]++
+			}
+		}
+		if p.antiAffinity != nil && p.antiAffinity.selector.Matches(vmLabels) {
+			p.antiAffinity.counts[zoneName]++
+		}
+	}
+
+	return p, nil
+}
+
+func newZoneConstraint(
+	labelSelector *metav1.LabelSelector,
+	action vmopv1.VirtualMachineUnsatisfiableConstraintAction) (zoneConstraint, error) {
+
+	if labelSelector == nil {
+		return zoneConstraint{}, fmt.Errorf("labelSelector is required")
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(labelSelector)
+	if err != nil {
+		return zoneConstraint{}, err
+	}
+
+	return zoneConstraint{
+		selector: selector,
+		maxSkew:  1,
+		required: action != vmopv1.ScheduleAnywayUnsatisfiableConstraintAction,
+		counts:   map[string]int{},
+	}, nil
+}
+
+// Filter returns the zones that satisfy the policy's required constraints.
+// The skew of a zone is relative to the zone with the fewest matching VMs
+// among all of the provided zones, not only the zones that satisfy the other
+// constraints.
+func (p *ZonePlacementPolicy) Filter(zoneNames []string) []string {
+	if p == nil || len(zoneNames) == 0 {
+		return zoneNames
+	}
+
+	candidateZoneNames := zoneNames
+
+	if aa := p.antiAffinity; aa != nil && aa.required {
+		zoneNames = slices.DeleteFunc(slices.Clone(zoneNames), func(z string) bool {
+			return aa.counts[z] > 0
+		})
+	}
+
+	for _, c := range p.spread {
+		if !c.required || len(zoneNames) == 0 {
+			continue
+		}
+
+		minCount := c.counts[candidateZoneNames[0]]
+		for _, z := range candidateZoneNames[1:] {
+			minCount = min(minCount, c.counts[z])
+		}
+
+		zoneNames = slices.DeleteFunc(slices.Clone(zoneNames), func(z string) bool {
+			return c.counts[z]+1-minCount > c.maxSkew
+		})
+	}
+
+	return zoneNames
+}
+
+// zoneScore describes how well a zone satisfies the policy. Lower is better,
+// and the fields are compared in order.
+type zoneScore struct {
+	antiAffinity int
+	spread       int
+	preference   int
+}
+
+func (s zoneScore) compare(o zoneScore) int {
+	switch {
+	case s.antiAffinity != o.antiAffinity:
+		return s.antiAffinity - o.antiAffinity
+	case s.spread != o.spread:
+		return s.spread - o.spread
+	default:
+		return s.preference - o.preference
+	}
+}
+
+func (p *ZonePlacementPolicy) score(zoneName string) zoneScore {
+	var s zoneScore
+
+	if p.antiAffinity != nil {
+		s.antiAffinity = p.antiAffinity.counts[zoneName]
+	}
+	for _, c := range p.spread {
+		s.spread += c.counts[zoneName]
+	}
+
+	s.preference = slices.Index(p.preferredZones, zoneName)
+	if s.preference < 0 {
+		s.preference = len(p.preferredZones)
+	}
+
+	return s
+}
+
+// BestZones returns the zones that best satisfy the policy.
+func (p *ZonePlacementPolicy) BestZones(zoneNames []string) []string {
+	if p == nil || len(zoneNames) == 0 {
+		return zoneNames
+	}
+
+	var (
+		best      []string
+		bestScore zoneScore
+	)
+
+	for _, z := range zoneNames {
+		s := p.score(z)
+		switch c := s.compare(bestScore); {
+		case len(best) == 0 || c < 0:
+			best, bestScore = []string{z}, s
+		case c == 0:
+			best = append(best, z)
+		}
+	}
+
+	return best
+}
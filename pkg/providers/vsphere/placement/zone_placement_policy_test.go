@@ -0,0 +1,301 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package placement_test
+
+import (
+	"maps"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	vimtypes "github.com/vmware/govmomi/vim25/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/placement"
+	"github.com/vmware-tanzu/vm-operator/pkg/topology"
+)
+
+var _ = Describe("ZonePlacementPolicy", func() {
+
+	var (
+		vm          *vmopv1.VirtualMachine
+		spec        vmopv1.VirtualMachinePlacementSpec
+		existingVMs []vmopv1.VirtualMachine
+		policy      *placement.ZonePlacementPolicy
+		zoneNames   = []string{"zone-a", "zone-b", "zone-c"}
+	)
+
+	newVM := func(name, zoneName string, vmLabels map[string]string) vmopv1.VirtualMachine {
+		return vmopv1.VirtualMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   name,
+				Labels: vmLabels,
+			},
+			Status: vmopv1.VirtualMachineStatus{
+				Zone: zoneName,
+			},
+		}
+	}
+
+	appLabels := map[string]string{"app": "db"}
+	appSelector := &metav1.LabelSelector{MatchLabels: appLabels}
+
+	BeforeEach(func() {
+		vm = &vmopv1.VirtualMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "my-vm",
+				Labels: maps.Clone(appLabels),
+			},
+		}
+		spec = vmopv1.VirtualMachinePlacementSpec{}
+		existingVMs = nil
+	})
+
+	JustBeforeEach(func() {
+		var err error
+		policy, err = placement.NewZonePlacementPolicy(vm, spec, existingVMs)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	Context("GetZonePlacementSpec", func() {
+		It("returns nil when the VM has no policy and no ReplicaSet", func() {
+			Expect(placement.GetZonePlacementSpec(vm)).To(BeNil())
+		})
+
+		It("returns the VM's policy", func() {
+			vm.Spec.Placement = &vmopv1.VirtualMachinePlacementSpec{PreferredZones: []string{"zone-a"}}
+			Expect(placement.GetZonePlacementSpec(vm)).To(Equal(vm.Spec.Placement))
+		})
+
+		It("returns a best effort spread for ReplicaSet VMs", func() {
+			vm.Labels[vmopv1.VirtualMachineReplicaSetNameLabel] = "my-rs"
+			s := placement.GetZonePlacementSpec(vm)
+			Expect(s).ToNot(BeNil())
+			Expect(s.TopologySpreadConstraints).To(HaveLen(1))
+			Expect(s.TopologySpreadConstraints[0].WhenUnsatisfiable).To(Equal(vmopv1.ScheduleAnywayUnsatisfiableConstraintAction))
+			Expect(s.TopologySpreadConstraints[0].LabelSelector.MatchLabels).To(
+				HaveKeyWithValue(vmopv1.VirtualMachineReplicaSetNameLabel, "my-rs"))
+		})
+	})
+
+	It("returns an error for an invalid label selector", func() {
+		spec.TopologySpreadConstraints = []vmopv1.VirtualMachineTopologySpreadConstraint{
+			{
+				LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"-invalid": "x"}},
+			},
+		}
+		_, err := placement.NewZonePlacementPolicy(vm, spec, existingVMs)
+		Expect(err).To(HaveOccurred())
+
+		spec.TopologySpreadConstraints = nil
+		spec.ZoneAntiAffinity = &vmopv1.VirtualMachineZoneAntiAffinity{}
+		_, err = placement.NewZonePlacementPolicy(vm, spec, existingVMs)
+		Expect(err).To(HaveOccurred())
+	})
+
+	Context("nil policy", func() {
+		It("does not filter or rank zones", func() {
+			var p *placement.ZonePlacementPolicy
+			Expect(p.Filter(zoneNames)).To(Equal(zoneNames))
+			Expect(p.BestZones(zoneNames)).To(Equal(zoneNames))
+		})
+	})
+
+	Context("topology spread constraints", func() {
+		BeforeEach(func() {
+			existingVMs = []vmopv1.VirtualMachine{
+				newVM("vm-1", "zone-a", appLabels),
+				newVM("vm-2", "zone-a", appLabels),
+				newVM("vm-3", "zone-b", appLabels),
+				newVM("vm-4", "zone-c", nil),
+				newVM("vm-5", "zone-c", nil),
+			}
+		})
+
+		When("DoNotSchedule", func() {
+			BeforeEach(func() {
+				spec.TopologySpreadConstraints = []vmopv1.VirtualMachineTopologySpreadConstraint{
+					{
+						MaxSkew:           1,
+						LabelSelector:     appSelector,
+						WhenUnsatisfiable: vmopv1.DoNotScheduleUnsatisfiableConstraintAction,
+					},
+				}
+			})
+
+			It("filters out the zones that would exceed the max skew", func() {
+				Expect(policy.Filter(zoneNames)).To(Equal([]string{"zone-c"}))
+				Expect(policy.BestZones(zoneNames)).To(Equal([]string{"zone-c"}))
+			})
+
+			It("only considers the candidate zones", func() {
+				Expect(policy.Filter([]string{"zone-a", "zone-b"})).To(Equal([]string{"zone-b"}))
+			})
+
+			When("the max skew is larger", func() {
+				BeforeEach(func() {
+					spec.TopologySpreadConstraints[0].MaxSkew = 2
+				})
+
+				It("allows the zones within the max skew", func() {
+					Expect(policy.Filter(zoneNames)).To(Equal([]string{"zone-b", "zone-c"}))
+				})
+			})
+
+			When("a required zone anti-affinity filters out the least used zone", func() {
+				BeforeEach(func() {
+					existingVMs[3].Labels = map[string]string{"tier": "web"}
+					spec.ZoneAntiAffinity = &vmopv1.VirtualMachineZoneAntiAffinity{
+						LabelSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{"tier": "web"},
+						},
+					}
+				})
+
+				It("computes the skew relative to all of the candidate zones", func() {
+					Expect(policy.Filter(zoneNames)).To(BeEmpty())
+				})
+			})
+
+			When("an existing VM is being deleted", func() {
+				BeforeEach(func() {
+					existingVMs[0].DeletionTimestamp = &metav1.Time{}
+					existingVMs[0].DeletionTimestamp.Time = metav1.Now().Time
+				})
+
+				It("does not count the VM", func() {
+					Expect(policy.Filter(zoneNames)).To(Equal([]string{"zone-c"}))
+					Expect(policy.Filter([]string{"zone-a", "zone-b"})).To(Equal([]string{"zone-a", "zone-b"}))
+				})
+			})
+		})
+
+		When("ScheduleAnyway", func() {
+			BeforeEach(func() {
+				spec.TopologySpreadConstraints = []vmopv1.VirtualMachineTopologySpreadConstraint{
+					{
+						MaxSkew:           1,
+						LabelSelector:     appSelector,
+						WhenUnsatisfiable: vmopv1.ScheduleAnywayUnsatisfiableConstraintAction,
+					},
+				}
+			})
+
+			It("does not filter zones but prefers the least used zones", func() {
+				Expect(policy.Filter(zoneNames)).To(Equal(zoneNames))
+				Expect(policy.BestZones(zoneNames)).To(Equal([]string{"zone-c"}))
+				Expect(policy.BestZones([]string{"zone-a", "zone-b"})).To(Equal([]string{"zone-b"}))
+			})
+		})
+
+		It("uses the zone label when status.zone is not yet set", func() {
+			spec.TopologySpreadConstraints = []vmopv1.VirtualMachineTopologySpreadConstraint{
+				{LabelSelector: appSelector},
+			}
+			existingVMs = []vmopv1.VirtualMachine{
+				newVM("vm-1", "", map[string]string{
+					"app":                                   "db",
+					topology.KubernetesTopologyZoneLabelKey: "zone-a",
+				}),
+			}
+			p, err := placement.NewZonePlacementPolicy(vm, spec, existingVMs)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(p.Filter(zoneNames)).To(Equal([]string{"zone-b", "zone-c"}))
+		})
+	})
+
+	Context("zone anti-affinity", func() {
+		BeforeEach(func() {
+			existingVMs = []vmopv1.VirtualMachine{
+				newVM("vm-1", "zone-a", appLabels),
+				newVM("vm-2", "zone-b", appLabels),
+				newVM("vm-3", "zone-b", appLabels),
+			}
+			spec.ZoneAntiAffinity = &vmopv1.VirtualMachineZoneAntiAffinity{
+				LabelSelector: appSelector,
+			}
+		})
+
+		It("filters out the zones with matching VMs", func() {
+			Expect(policy.Filter(zoneNames)).To(Equal([]string{"zone-c"}))
+			Expect(policy.Filter([]string{"zone-a", "zone-b"})).To(BeEmpty())
+		})
+
+		When("ScheduleAnyway", func() {
+			BeforeEach(func() {
+				spec.ZoneAntiAffinity.WhenUnsatisfiable = vmopv1.ScheduleAnywayUnsatisfiableConstraintAction
+			})
+
+			It("prefers the zones with the fewest matching VMs", func() {
+				Expect(policy.Filter([]string{"zone-a", "zone-b"})).To(Equal([]string{"zone-a", "zone-b"}))
+				Expect(policy.BestZones([]string{"zone-a", "zone-b"})).To(Equal([]string{"zone-a"}))
+			})
+		})
+
+		It("does not count the VM itself", func() {
+			existingVMs = []vmopv1.VirtualMachine{
+				newVM(vm.Name, "zone-a", appLabels),
+			}
+			p, err := placement.NewZonePlacementPolicy(vm, spec, existingVMs)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(p.Filter(zoneNames)).To(Equal(zoneNames))
+		})
+	})
+
+	Context("preferred zones", func() {
+		BeforeEach(func() {
+			spec.PreferredZones = []string{"zone-c", "zone-b"}
+		})
+
+		It("prefers the zones in order", func() {
+			Expect(policy.Filter(zoneNames)).To(Equal(zoneNames))
+			Expect(policy.BestZones(zoneNames)).To(Equal([]string{"zone-c"}))
+			Expect(policy.BestZones([]string{"zone-a", "zone-b"})).To(Equal([]string{"zone-b"}))
+		})
+
+		When("combined with a topology spread constraint", func() {
+			BeforeEach(func() {
+				existingVMs = []vmopv1.VirtualMachine{
+					newVM("vm-1", "zone-c", appLabels),
+				}
+				spec.TopologySpreadConstraints = []vmopv1.VirtualMachineTopologySpreadConstraint{
+					{
+						MaxSkew:           1,
+						LabelSelector:     appSelector,
+						WhenUnsatisfiable: vmopv1.ScheduleAnywayUnsatisfiableConstraintAction,
+					},
+				}
+			})
+
+			It("prefers the spread over the preferred zones", func() {
+				Expect(policy.BestZones(zoneNames)).To(Equal([]string{"zone-b"}))
+			})
+		})
+	})
+
+	Context("MakePlacementDecision", func() {
+		BeforeEach(func() {
+			spec.PreferredZones = []string{"zone-b"}
+		})
+
+		It("selects a recommendation from the best zone", func() {
+			recommendations := map[string][]placement.Recommendation{}
+			for _, zoneName := range zoneNames {
+				recommendations[zoneName] = []placement.Recommendation{
+					{
+						PoolMoRef: vimtypes.ManagedObjectReference{Type: "ResourcePool", Value: zoneName + "-rp"},
+					},
+				}
+			}
+
+			for range 10 {
+				zoneName, rec := placement.MakePlacementDecision(recommendations, policy)
+				Expect(zoneName).To(Equal("zone-b"))
+				Expect(rec.PoolMoRef.Value).To(Equal("zone-b-rp"))
+			}
+		})
+	})
+})
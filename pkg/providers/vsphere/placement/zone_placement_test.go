@@ -6,6 +6,7 @@ package placement_test
 
 import (
 	"context"
+	"fmt"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -40,7 +41,7 @@ var _ = Describe("MakePlacementDecision", func() {
 				},
 			}
 
-			zoneName, rec := placement.MakePlacementDecision(recommendations)
+			zoneName, rec := placement.MakePlacementDecision(recommendations, nil)
 			Expect(zoneName).To(Equal("zone1"))
 			Expect(rec).To(BeElementOf(recommendations[zoneName]))
 		})
@@ -64,7 +65,7 @@ var _ = Describe("MakePlacementDecision", func() {
 				}
 			}
 
-			zoneName, rec := placement.MakePlacementDecision(recommendations)
+			zoneName, rec := placement.MakePlacementDecision(recommendations, nil)
 			Expect(zones).To(HaveKey(zoneName))
 			Expect(rec).To(BeElementOf(recommendations[zoneName]))
 		})
@@ -444,6 +445,46 @@ func vcSimPlacement() {
 				})
 			})
 
+			Context("Zone placement policy", func() {
+				JustBeforeEach(func() {
+					Expect(len(ctx.ZoneNames)).To(BeNumerically(">", 1))
+
+					// Existing VMs with the same labels in every zone but the last.
+					for i, zoneName := range ctx.ZoneNames[:len(ctx.ZoneNames)-1] {
+						existingVM := builder.DummyVirtualMachine()
+						existingVM.Name = fmt.Sprintf("existing-vm-%d", i)
+						existingVM.Namespace = vm.Namespace
+						existingVM.Labels = map[string]string{"app": "db"}
+						Expect(ctx.Client.Create(ctx, existingVM)).To(Succeed())
+						existingVM.Status.Zone = zoneName
+						Expect(ctx.Client.Status().Update(ctx, existingVM)).To(Succeed())
+					}
+
+					vm.Spec.Placement = &vmopv1.VirtualMachinePlacementSpec{
+						ZoneAntiAffinity: &vmopv1.VirtualMachineZoneAntiAffinity{
+							LabelSelector: &metav1.LabelSelector{
+								MatchLabels: map[string]string{"app": "db"},
+							},
+						},
+					}
+				})
+
+				It("returns success with the only zone allowed by the policy", func() {
+					result, err := placement.Placement(vmCtx, ctx.Client, ctx.VCClient.Client, ctx.Finder, configSpec, constraints)
+					Expect(err).ToNot(HaveOccurred())
+
+					Expect(result.ZonePlacement).To(BeTrue())
+					Expect(result.ZoneName).To(Equal(ctx.ZoneNames[len(ctx.ZoneNames)-1]))
+				})
+
+				It("returns an error when the policy allows no zones", func() {
+					constraints.Zones = sets.New(ctx.ZoneNames[0])
+					result, err := placement.Placement(vmCtx, ctx.Client, ctx.VCClient.Client, ctx.Finder, configSpec, constraints)
+					Expect(err).To(MatchError("no placement candidates available after applying zone placement policy"))
+					Expect(result).To(BeNil())
+				})
+			})
+
 			Context("VM is in child RP via ResourcePolicy", func() {
 				It("returns success", func() {
 					resourcePolicy, _ := ctx.CreateVirtualMachineSetResourcePolicy("my-child-rp", nsInfo)
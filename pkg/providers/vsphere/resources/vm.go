@@ -151,11 +151,11 @@ func (vm *VirtualMachine) SetPowerState(
 	ctx context.Context,
 	currentPowerState,
 	desiredPowerState vmopv1.VirtualMachinePowerState,
-	desiredPowerOpMode vmopv1.VirtualMachinePowerOpMode) error {
+	desiredPowerOpMode vmopv1.VirtualMachinePowerOpMode) (vmutil.PowerOpResult, error) {
 
 	ctxop.MarkUpdate(ctx)
 
-	_, err := vmutil.SetAndWaitOnPowerState(
+	return vmutil.SetAndWaitOnPowerState(
 		ctx,
 		vm.VcVM().Client(),
 		mo.VirtualMachine{
@@ -173,8 +173,6 @@ func (vm *VirtualMachine) SetPowerState(
 		false,
 		vmutil.ParsePowerState(string(desiredPowerState)),
 		vmutil.ParsePowerOpMode(string(desiredPowerOpMode)))
-
-	return err
 }
 
 // GetVirtualDevices returns the VMs VirtualDeviceList.
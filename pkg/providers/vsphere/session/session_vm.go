@@ -11,6 +11,7 @@ import (
 	vimtypes "github.com/vmware/govmomi/vim25/types"
 
 	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	vmopv1util "github.com/vmware-tanzu/vm-operator/pkg/util/vmopv1"
 )
 
 func updateVirtualDiskDeviceChanges(
@@ -29,7 +30,7 @@ func updateVirtualDiskDeviceChanges(
 
 	// Skip resizing ISO VMs with attached CD-ROMs as their boot disks are FCDs
 	// and should be managed by PVCs.
-	if len(vmCtx.VM.Spec.Cdrom) > 0 {
+	if vmopv1util.IsISOVM(*vmCtx.VM) {
 		return nil, nil
 	}
 
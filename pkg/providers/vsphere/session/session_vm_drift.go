@@ -0,0 +1,234 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package session
+
+import (
+	"fmt"
+
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/mo"
+	vimtypes "github.com/vmware/govmomi/vim25/types"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	byokv1 "github.com/vmware-tanzu/vm-operator/external/byok/api/v1alpha1"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	res "github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/resources"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/virtualmachine"
+	vmopv1util "github.com/vmware-tanzu/vm-operator/pkg/util/vmopv1"
+)
+
+// driftPropertiesSelector is the list of properties compared when detecting
+// the drift of a VM's configuration. The properties are fetched separately
+// since the properties refetched after updating the VM do not include the
+// VM's number of CPUs or memory.
+var driftPropertiesSelector = []string{
+	"config.extraConfig",
+	"config.hardware",
+	"config.keyId",
+}
+
+// reconcileDrift detects the drift between the VM's configuration in vSphere
+// and the configuration implied by the VM's spec and class, and handles it
+// according to the VM's drift policy.
+func (s *Session) reconcileDrift(
+	vmCtx pkgctx.VirtualMachineContext,
+	vcVM *object.VirtualMachine,
+	getResizeArgsFn func() (*VMResizeArgs, error)) error {
+
+	policy := vmCtx.VM.Spec.DriftPolicy
+	if policy == "" {
+		vmCtx.VM.Status.Drift = nil
+		conditions.Delete(vmCtx.VM, vmopv1.VirtualMachineConfigurationDriftCondition)
+		return nil
+	}
+
+	args, err := s.getDriftArgs(vmCtx, getResizeArgsFn)
+	if err != nil {
+		return err
+	}
+
+	var moVM mo.VirtualMachine
+	if err := vcVM.Properties(vmCtx, vcVM.Reference(), driftPropertiesSelector, &moVM); err != nil {
+		return fmt.Errorf("failed to get VM properties for drift detection: %w", err)
+	}
+	if moVM.Config == nil {
+		return nil
+	}
+
+	fields := virtualmachine.DetectDrift(*vmCtx.VM, *moVM.Config, args)
+
+	var adopted []vmopv1.VirtualMachineDriftedField
+	reason := vmopv1.VirtualMachineConfigurationNoDriftReason
+
+	switch policy {
+	case vmopv1.VirtualMachineDriftPolicyRevert:
+		if len(fields) == 0 || isVMPaused(vmCtx) {
+			break
+		}
+
+		reverted, err := s.revertDrift(vmCtx, vcVM, moVM, fields, args)
+		if err != nil {
+			setDriftStatus(vmCtx.VM, fields, nil, "")
+			return err
+		}
+		if reverted {
+			moVM = mo.VirtualMachine{}
+			if err := vcVM.Properties(vmCtx, vcVM.Reference(), driftPropertiesSelector, &moVM); err != nil {
+				return fmt.Errorf("failed to get VM properties for drift detection: %w", err)
+			}
+			if moVM.Config != nil {
+				fields = virtualmachine.DetectDrift(*vmCtx.VM, *moVM.Config, args)
+			}
+			reason = vmopv1.VirtualMachineConfigurationDriftRevertedReason
+		}
+
+	case vmopv1.VirtualMachineDriftPolicyAdopt:
+		var prevAdopted []vmopv1.VirtualMachineDriftedField
+		if vmCtx.VM.Status.Drift != nil {
+			prevAdopted = vmCtx.VM.Status.Drift.Adopted
+		}
+
+		fields, adopted = virtualmachine.FilterAdoptedDrift(fields, prevAdopted)
+
+		var notAdoptable []vmopv1.VirtualMachineDriftedField
+		for _, f := range fields {
+			if virtualmachine.IsAdoptableDriftField(f.Field) {
+				vmCtx.Logger.Info("Adopting drifted field",
+					"field", f.Field, "current", f.Current, "desired", f.Desired)
+				adopted = append(adopted, f)
+			} else {
+				notAdoptable = append(notAdoptable, f)
+			}
+		}
+		fields = notAdoptable
+
+		if len(adopted) > 0 {
+			reason = vmopv1.VirtualMachineConfigurationDriftAdoptedReason
+		}
+	}
+
+	setDriftStatus(vmCtx.VM, fields, adopted, reason)
+
+	return nil
+}
+
+// getDriftArgs returns the configuration implied by the VM's spec and class.
+// The VM's number of CPUs, memory, and ExtraConfig are only compared to its
+// class when the VM was last resized to the current generation of its class,
+// otherwise changes to the class the VM has not been resized to would be
+// reported as drift.
+func (s *Session) getDriftArgs(
+	vmCtx pkgctx.VirtualMachineContext,
+	getResizeArgsFn func() (*VMResizeArgs, error)) (virtualmachine.DriftArgs, error) {
+
+	var args virtualmachine.DriftArgs
+
+	resizeArgs, err := getResizeArgsFn()
+	if err != nil {
+		return args, err
+	}
+	if resizeArgs.VMClass != nil && vmopv1util.ResizedToClass(*vmCtx.VM, *resizeArgs.VMClass) {
+		args.ClassConfigSpec = &resizeArgs.ConfigSpec
+	}
+
+	if pkgcfg.FromContext(vmCtx).Features.BringYourOwnEncryptionKey {
+		if c := vmCtx.VM.Spec.Crypto; c != nil && c.EncryptionClassName != "" {
+			var encClass byokv1.EncryptionClass
+			if err := s.K8sClient.Get(
+				vmCtx,
+				ctrlclient.ObjectKey{Namespace: vmCtx.VM.Namespace, Name: c.EncryptionClassName},
+				&encClass); err != nil {
+
+				if !apierrors.IsNotFound(err) {
+					return args, err
+				}
+			} else if encClass.Spec.KeyProvider != "" {
+				args.CryptoKey = &vimtypes.CryptoKeyId{
+					KeyId: encClass.Spec.KeyID,
+					ProviderId: &vimtypes.KeyProviderId{
+						Id: encClass.Spec.KeyProvider,
+					},
+				}
+			}
+		}
+	}
+
+	return args, nil
+}
+
+// revertDrift reconfigures the VM to revert its number of CPUs, memory, and
+// ExtraConfig to the values implied by its class. Changes that cannot be
+// applied while the VM is powered on are not reverted and remain reported as
+// drift.
+func (s *Session) revertDrift(
+	vmCtx pkgctx.VirtualMachineContext,
+	vcVM *object.VirtualMachine,
+	moVM mo.VirtualMachine,
+	fields []vmopv1.VirtualMachineDriftedField,
+	args virtualmachine.DriftArgs) (bool, error) {
+
+	configSpec := virtualmachine.DriftRevertConfigSpec(fields, args.ClassConfigSpec)
+
+	if vmCtx.MoVM.Runtime.PowerState == vimtypes.VirtualMachinePowerStatePoweredOn {
+		hotConfigSpec := vimtypes.VirtualMachineConfigSpec{
+			NumCPUs:  configSpec.NumCPUs,
+			MemoryMB: configSpec.MemoryMB,
+		}
+		_ = vmopv1util.HotResizeConfigSpec(*vmCtx.VM, *moVM.Config, &hotConfigSpec)
+		configSpec.NumCPUs = hotConfigSpec.NumCPUs
+		configSpec.MemoryMB = hotConfigSpec.MemoryMB
+	}
+
+	if configSpec.NumCPUs == 0 && configSpec.MemoryMB == 0 && len(configSpec.ExtraConfig) == 0 {
+		return false, nil
+	}
+
+	vmCtx.Logger.Info("Reverting drifted fields", "configSpec", configSpec)
+
+	if _, err := res.NewVMFromObject(vcVM).Reconfigure(vmCtx, &configSpec); err != nil {
+		return false, fmt.Errorf("failed to revert drift: %w", err)
+	}
+
+	return true, nil
+}
+
+// setDriftStatus sets the VM's drift status and ConfigurationDrift condition.
+// The condition is true when drift is reported, otherwise the given reason
+// describes why it is false. An empty reason leaves the condition unchanged.
+func setDriftStatus(
+	vm *vmopv1.VirtualMachine,
+	fields, adopted []vmopv1.VirtualMachineDriftedField,
+	reason string) {
+
+	if len(fields) == 0 && len(adopted) == 0 {
+		vm.Status.Drift = nil
+	} else {
+		vm.Status.Drift = &vmopv1.VirtualMachineDriftStatus{
+			Fields:  fields,
+			Adopted: adopted,
+		}
+	}
+
+	switch {
+	case len(fields) > 0:
+		conditions.Set(vm, &metav1.Condition{
+			Type:    vmopv1.VirtualMachineConfigurationDriftCondition,
+			Status:  metav1.ConditionTrue,
+			Reason:  vmopv1.VirtualMachineConfigurationDriftDetectedReason,
+			Message: fmt.Sprintf("%d field(s) have drifted", len(fields)),
+		})
+	case reason != "":
+		conditions.MarkFalse(
+			vm,
+			vmopv1.VirtualMachineConfigurationDriftCondition,
+			reason,
+			"")
+	}
+}
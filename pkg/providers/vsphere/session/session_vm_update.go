@@ -6,9 +6,12 @@ package session
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"maps"
 	"reflect"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -167,6 +170,171 @@ func UpdateEthCardDeviceChanges(
 	return append(removeDeviceChanges, deviceChanges...), nil
 }
 
+// NetworkInterfacesChanged returns true if network interfaces have been added
+// to or removed from the VM's spec since the VM's network config was last
+// applied, i.e. since status.network.config was updated. Please note the VM's
+// interfaces are not managed when spec.network is nil or disabled, or when the
+// network config has not been applied yet.
+func NetworkInterfacesChanged(vm *vmopv1.VirtualMachine) bool {
+	networkSpec := vm.Spec.Network
+	if networkSpec == nil || networkSpec.Disabled {
+		return false
+	}
+
+	status := vm.Status.Network
+	if status == nil || status.Config == nil {
+		return false
+	}
+
+	if len(networkSpec.Interfaces) != len(status.Config.Interfaces) {
+		return true
+	}
+
+	// An interface may have been removed and another one added.
+	for _, ifc := range status.Config.Interfaces {
+		if !slices.ContainsFunc(networkSpec.Interfaces, func(i vmopv1.VirtualMachineNetworkInterfaceSpec) bool {
+			return i.Name == ifc.Name
+		}) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// NetworkInterfaceDeviceChanges returns the device changes that add an
+// Ethernet card for each network interface added to the VM's spec, and remove
+// the Ethernet card of each network interface removed from the VM's spec,
+// since the VM's network config was last applied.
+//
+// The Ethernet card of a removed interface is the card with the device key or
+// MAC address reported for the interface in status.network.interfaces. A card
+// is not removed if it cannot be matched with a removed interface, or if its
+// MAC address or external ID is that of an interface that remains in the spec,
+// so cards that were not created by VM Operator are never removed.
+func NetworkInterfaceDeviceChanges(
+	vm *vmopv1.VirtualMachine,
+	networkResults network2.NetworkInterfaceResults,
+	currentEthCards object.VirtualDeviceList) []vimtypes.BaseVirtualDeviceConfigSpec {
+
+	if !NetworkInterfacesChanged(vm) {
+		return nil
+	}
+
+	appliedInterfaces := vm.Status.Network.Config.Interfaces
+
+	var removeDeviceChanges []vimtypes.BaseVirtualDeviceConfigSpec
+	for _, ifc := range appliedInterfaces {
+		if slices.ContainsFunc(vm.Spec.Network.Interfaces, func(i vmopv1.VirtualMachineNetworkInterfaceSpec) bool {
+			return i.Name == ifc.Name
+		}) {
+			continue
+		}
+
+		idx := slices.IndexFunc(vm.Status.Network.Interfaces, func(i vmopv1.VirtualMachineNetworkInterfaceStatus) bool {
+			return i.Name == ifc.Name
+		})
+		if idx < 0 {
+			continue
+		}
+
+		dev := getEthCardByInterfaceStatus(currentEthCards, vm.Status.Network.Interfaces[idx])
+		if dev == nil || isEthCardOfNetworkResult(dev, vm, networkResults) {
+			continue
+		}
+		if slices.ContainsFunc(removeDeviceChanges, func(c vimtypes.BaseVirtualDeviceConfigSpec) bool {
+			return c.GetVirtualDeviceConfigSpec().Device.GetVirtualDevice().Key == dev.GetVirtualDevice().Key
+		}) {
+			continue
+		}
+
+		removeDeviceChanges = append(removeDeviceChanges, &vimtypes.VirtualDeviceConfigSpec{
+			Device:    dev,
+			Operation: vimtypes.VirtualDeviceConfigSpecOperationRemove,
+		})
+	}
+
+	var addDeviceChanges []vimtypes.BaseVirtualDeviceConfigSpec
+	for _, r := range networkResults.Results {
+		if r.Device == nil {
+			continue
+		}
+		if slices.ContainsFunc(appliedInterfaces, func(i vmopv1.VirtualMachineNetworkConfigInterfaceStatus) bool {
+			return i.Name == r.Name
+		}) {
+			continue
+		}
+		addDeviceChanges = append(addDeviceChanges, &vimtypes.VirtualDeviceConfigSpec{
+			Device:    r.Device,
+			Operation: vimtypes.VirtualDeviceConfigSpecOperationAdd,
+		})
+	}
+
+	// Process any removes first.
+	return append(removeDeviceChanges, addDeviceChanges...)
+}
+
+// getEthCardByInterfaceStatus returns the Ethernet card with the device key,
+// or if no device key was reported, the MAC address of the given interface
+// status. Nil is returned if there is no such card.
+func getEthCardByInterfaceStatus(
+	ethCards object.VirtualDeviceList,
+	ifcStatus vmopv1.VirtualMachineNetworkInterfaceStatus) vimtypes.BaseVirtualDevice {
+
+	var macAddr string
+	if ifcStatus.IP != nil {
+		macAddr = ifcStatus.IP.MACAddr
+	}
+
+	for _, dev := range ethCards {
+		nic, ok := dev.(vimtypes.BaseVirtualEthernetCard)
+		if !ok {
+			continue
+		}
+		card := nic.GetVirtualEthernetCard()
+
+		if ifcStatus.DeviceKey != 0 {
+			if card.Key == ifcStatus.DeviceKey {
+				return dev
+			}
+		} else if macAddr != "" && strings.EqualFold(card.MacAddress, macAddr) {
+			return dev
+		}
+	}
+
+	return nil
+}
+
+// isEthCardOfNetworkResult returns true if the Ethernet card has the MAC
+// address or external ID of a network interface that is in the VM's spec.
+func isEthCardOfNetworkResult(
+	dev vimtypes.BaseVirtualDevice,
+	vm *vmopv1.VirtualMachine,
+	networkResults network2.NetworkInterfaceResults) bool {
+
+	nic, ok := dev.(vimtypes.BaseVirtualEthernetCard)
+	if !ok {
+		return false
+	}
+	card := nic.GetVirtualEthernetCard()
+
+	for _, r := range networkResults.Results {
+		if !slices.ContainsFunc(vm.Spec.Network.Interfaces, func(i vmopv1.VirtualMachineNetworkInterfaceSpec) bool {
+			return i.Name == r.Name
+		}) {
+			continue
+		}
+		if r.MacAddress != "" && strings.EqualFold(card.MacAddress, r.MacAddress) {
+			return true
+		}
+		if r.ExternalID != "" && card.ExternalId == r.ExternalID {
+			return true
+		}
+	}
+
+	return false
+}
+
 // UpdatePCIDeviceChanges returns devices changes for PCI devices attached to a VM. There are 2 types of PCI devices
 // processed here and in case of cloning a VM, devices listed in VMClass are considered as source of truth.
 func UpdatePCIDeviceChanges(
@@ -273,6 +441,12 @@ func UpdateConfigSpecExtraConfig(
 			StringMap()
 	}
 
+	// Publish the volumes whose file systems need to be grown to the guest
+	// if requested.
+	if v := guestFileSystemResizeExtraConfigValue(vm); v != "" {
+		extraConfig[constants.GuestFileSystemResizeExtraConfigKey] = v
+	}
+
 	// Note if the VM uses both LinuxPrep and vAppConfig. This is used in the
 	// loop below.
 	linuxPrepAndVAppConfig := isLinuxPrepAndVAppConfig(vm)
@@ -289,6 +463,13 @@ func UpdateConfigSpecExtraConfig(
 					extraConfig[o.Key] = ""
 				}
 
+			// Remove GuestFileSystemResizeExtraConfigKey once there are no
+			// longer any volumes whose file systems need to be grown.
+			case constants.GuestFileSystemResizeExtraConfigKey:
+				if _, ok := extraConfig[o.Key]; !ok && o.Value != "" {
+					extraConfig[o.Key] = ""
+				}
+
 			// For the special V1Alpha1Compatible images, set the
 			// VMOperatorV1Alpha1ExtraConfigKey to "Ready" to fix configuration
 			// races between cloud-init, vApp, and GOSC. This is addressed by
@@ -314,6 +495,52 @@ func UpdateConfigSpecExtraConfig(
 		Diff(pkgutil.OptionValuesFromMap(extraConfig)...)
 }
 
+// guestFileSystemResizeVolume describes a volume whose file system needs to
+// be grown by the guest.
+type guestFileSystemResizeVolume struct {
+	Name     string `json:"name"`
+	DiskUUID string `json:"diskUUID"`
+	Limit    string `json:"limit,omitempty"`
+}
+
+// guestFileSystemResizeExtraConfigValue returns the JSON encoded list of the
+// VM's volumes whose file systems need to be grown by the guest, or an empty
+// string if there are none or the VM has not opted into being notified.
+func guestFileSystemResizeExtraConfigValue(vm *vmopv1.VirtualMachine) string {
+	if vm == nil || vm.Annotations[vmopv1.GuestFileSystemResizeAnnotation] != "true" {
+		return ""
+	}
+
+	var volumes []guestFileSystemResizeVolume
+	for _, v := range vm.Status.Volumes {
+		if v.ResizeStatus != vmopv1.VirtualMachineVolumeResizeStatusFileSystemResizePending {
+			continue
+		}
+		gv := guestFileSystemResizeVolume{
+			Name:     v.Name,
+			DiskUUID: v.DiskUUID,
+		}
+		if v.Limit != nil {
+			gv.Limit = v.Limit.String()
+		}
+		volumes = append(volumes, gv)
+	}
+
+	if len(volumes) == 0 {
+		return ""
+	}
+
+	slices.SortFunc(volumes, func(a, b guestFileSystemResizeVolume) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	data, err := json.Marshal(volumes)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
 func isLinuxPrepAndVAppConfig(vm *vmopv1.VirtualMachine) bool {
 	if vm == nil {
 		return false
@@ -406,6 +633,36 @@ func UpdateConfigSpecChangeBlockTracking(
 	}
 }
 
+// UpdateConfigSpecCPUMemoryHotAdd sets whether CPU and memory hot-add are
+// enabled in the ConfigSpec. The values from the VM spec take precedence over
+// the ones from the class ConfigSpec.
+func UpdateConfigSpecCPUMemoryHotAdd(
+	config *vimtypes.VirtualMachineConfigInfo,
+	configSpec, classConfigSpec *vimtypes.VirtualMachineConfigSpec,
+	vmSpec vmopv1.VirtualMachineSpec) {
+
+	var cpuHotAdd, memoryHotAdd *bool
+	if classConfigSpec != nil {
+		cpuHotAdd = classConfigSpec.CpuHotAddEnabled
+		memoryHotAdd = classConfigSpec.MemoryHotAddEnabled
+	}
+	if adv := vmSpec.Advanced; adv != nil {
+		if adv.CPUHotAddEnabled != nil {
+			cpuHotAdd = adv.CPUHotAddEnabled
+		}
+		if adv.MemoryHotAddEnabled != nil {
+			memoryHotAdd = adv.MemoryHotAddEnabled
+		}
+	}
+
+	if cpuHotAdd != nil && !apiEquality.Semantic.DeepEqual(config.CpuHotAddEnabled, cpuHotAdd) {
+		configSpec.CpuHotAddEnabled = cpuHotAdd
+	}
+	if memoryHotAdd != nil && !apiEquality.Semantic.DeepEqual(config.MemoryHotAddEnabled, memoryHotAdd) {
+		configSpec.MemoryHotAddEnabled = memoryHotAdd
+	}
+}
+
 func UpdateHardwareConfigSpec(
 	config *vimtypes.VirtualMachineConfigInfo,
 	configSpec *vimtypes.VirtualMachineConfigSpec,
@@ -475,6 +732,8 @@ func updateConfigSpec(
 	UpdateConfigSpecAnnotation(config, configSpec)
 	UpdateConfigSpecChangeBlockTracking(
 		vmCtx, config, configSpec, &updateArgs.ConfigSpec, vmCtx.VM.Spec)
+	UpdateConfigSpecCPUMemoryHotAdd(
+		config, configSpec, &updateArgs.ConfigSpec, vmCtx.VM.Spec)
 	UpdateConfigSpecFirmware(config, configSpec, vmCtx.VM)
 	UpdateConfigSpecGuestID(config, configSpec, vmCtx.VM.Spec.GuestID)
 
@@ -510,12 +769,7 @@ func (s *Session) prePowerOnVMConfigSpec(
 	}
 	configSpec.DeviceChange = append(configSpec.DeviceChange, diskDeviceChanges...)
 
-	var expectedEthCards object.VirtualDeviceList
-	for idx := range updateArgs.NetworkResults.Results {
-		expectedEthCards = append(expectedEthCards, updateArgs.NetworkResults.Results[idx].Device)
-	}
-
-	ethCardDeviceChanges, err := UpdateEthCardDeviceChanges(vmCtx, expectedEthCards, currentEthCards)
+	ethCardDeviceChanges, err := ethCardDeviceChangesFromResults(vmCtx, updateArgs.NetworkResults, currentEthCards)
 	if err != nil {
 		return nil, false, err
 	}
@@ -534,7 +788,7 @@ func (s *Session) prePowerOnVMConfigSpec(
 	configSpec.DeviceChange = append(configSpec.DeviceChange, pciDeviceChanges...)
 
 	if pkgcfg.FromContext(vmCtx).Features.IsoSupport {
-		cdromDeviceChanges, err := virtualmachine.UpdateCdromDeviceChanges(vmCtx, s.Client.RestClient(), s.K8sClient, virtualDevices)
+		cdromDeviceChanges, err := virtualmachine.UpdateCdromDeviceChanges(vmCtx, s.Client.RestClient(), s.Client.VimClient(), s.K8sClient, virtualDevices)
 		if err != nil {
 			return nil, false, fmt.Errorf("update CD-ROM device changes error: %w", err)
 		}
@@ -544,6 +798,19 @@ func (s *Session) prePowerOnVMConfigSpec(
 	return configSpec, needsResize, nil
 }
 
+func ethCardDeviceChangesFromResults(
+	ctx context.Context,
+	networkResults network2.NetworkInterfaceResults,
+	currentEthCards object.VirtualDeviceList) ([]vimtypes.BaseVirtualDeviceConfigSpec, error) {
+
+	var expectedEthCards object.VirtualDeviceList
+	for idx := range networkResults.Results {
+		expectedEthCards = append(expectedEthCards, networkResults.Results[idx].Device)
+	}
+
+	return UpdateEthCardDeviceChanges(ctx, expectedEthCards, currentEthCards)
+}
+
 func (s *Session) prePowerOnVMReconfigure(
 	vmCtx pkgctx.VirtualMachineContext,
 	resVM *res.VirtualMachine,
@@ -564,6 +831,20 @@ func (s *Session) prePowerOnVMReconfigure(
 		return err
 	}
 
+	if features.VMEphemeralVolumes {
+		volumeDeviceChanges, volumeExtraConfig, err := virtualmachine.UpdateVolumeDeviceChanges(
+			vmCtx, s.K8sClient, s.Client.VimClient(), s.Client.Datacenter(), object.VirtualDeviceList(config.Hardware.Device))
+		if err != nil {
+			return fmt.Errorf("update volume device changes error: %w", err)
+		}
+		configSpec.DeviceChange = append(configSpec.DeviceChange, volumeDeviceChanges...)
+		configSpec.ExtraConfig = append(configSpec.ExtraConfig, volumeExtraConfig...)
+	}
+
+	serialPortDeviceChanges := virtualmachine.UpdateSerialPortDeviceChanges(
+		vmCtx, pkgcfg.FromContext(vmCtx).SerialConsoleProxyURI, object.VirtualDeviceList(config.Hardware.Device))
+	configSpec.DeviceChange = append(configSpec.DeviceChange, serialPortDeviceChanges...)
+
 	if _, err := doReconfigure(
 		logr.NewContext(
 			vmCtx,
@@ -578,6 +859,10 @@ func (s *Session) prePowerOnVMReconfigure(
 		return err
 	}
 
+	// Any changes that were pending while the VM was powered on have now
+	// been applied.
+	vmCtx.VM.Status.Resize = nil
+
 	if needsResize {
 		vmopv1util.MustSetLastResizedAnnotation(vmCtx.VM, updateArgs.VMClass)
 
@@ -755,6 +1040,12 @@ func (s *Session) prepareVMForPowerOn(
 		return err
 	}
 
+	if pkgcfg.FromContext(vmCtx).Features.VMNetworkHotPlug {
+		if err := network2.DeleteOrphanedNetworkInterfaces(vmCtx, s.K8sClient); err != nil {
+			return err
+		}
+	}
+
 	// Get the information required to bootstrap/customize the VM. This is
 	// retrieved outside of the customize/DoBootstrap call path in order to use
 	// the information to update the VM object's status with the resolved,
@@ -786,10 +1077,28 @@ func (s *Session) prepareVMForPowerOn(
 func (s *Session) poweredOnVMReconfigure(
 	vmCtx pkgctx.VirtualMachineContext,
 	resVM *res.VirtualMachine,
-	config *vimtypes.VirtualMachineConfigInfo) (bool, error) {
+	config *vimtypes.VirtualMachineConfigInfo,
+	getResizeArgsFn func() (*VMResizeArgs, error)) (bool, error) {
 
 	configSpec := &vimtypes.VirtualMachineConfigSpec{}
 
+	var (
+		resizeArgs     *VMResizeArgs
+		needsResize    bool
+		pendingChanges []vmopv1.VirtualMachineResizePendingChange
+	)
+
+	if f := pkgcfg.FromContext(vmCtx).Features; f.VMResize || f.VMResizeCPUMemory {
+		var err error
+		if resizeArgs, err = getResizeArgsFn(); err != nil {
+			return false, err
+		}
+		needsResize, pendingChanges, err = poweredOnVMResizeConfigSpec(vmCtx, config, resizeArgs, configSpec)
+		if err != nil {
+			return false, err
+		}
+	}
+
 	if err := vmopv1util.OverwriteAlwaysResizeConfigSpec(
 		vmCtx,
 		*vmCtx.VM,
@@ -803,11 +1112,16 @@ func (s *Session) poweredOnVMReconfigure(
 	UpdateConfigSpecChangeBlockTracking(vmCtx, config, configSpec, nil, vmCtx.VM.Spec)
 
 	if pkgcfg.FromContext(vmCtx).Features.IsoSupport {
-		if err := virtualmachine.UpdateConfigSpecCdromDeviceConnection(vmCtx, s.Client.RestClient(), s.K8sClient, config, configSpec); err != nil {
+		if err := virtualmachine.UpdateConfigSpecCdromDeviceConnection(vmCtx, s.Client.RestClient(), s.Client.VimClient(), s.K8sClient, config, configSpec); err != nil {
 			return false, fmt.Errorf("update CD-ROM device connection error: %w", err)
 		}
 	}
 
+	networkResults, networkChanged, err := s.updateConfigSpecNetworkInterfaces(vmCtx, config, configSpec)
+	if err != nil {
+		return false, err
+	}
+
 	refetchProps, err := doReconfigure(
 		logr.NewContext(
 			vmCtx,
@@ -823,6 +1137,17 @@ func (s *Session) poweredOnVMReconfigure(
 		return false, err
 	}
 
+	if resizeArgs != nil {
+		updatePoweredOnVMResizeStatus(vmCtx, resizeArgs, needsResize, pendingChanges)
+	}
+
+	if networkChanged {
+		if err := s.updateHotPluggedNetworkInterfaces(vmCtx, resVM, networkResults); err != nil {
+			return true, err
+		}
+		refetchProps = true
+	}
+
 	// Special case for CBT: in order for CBT change take effect for a powered
 	// on VM, a checkpoint save/restore is needed. The FSR call allows CBT to
 	// take effect for powered-on VMs.
@@ -835,6 +1160,131 @@ func (s *Session) poweredOnVMReconfigure(
 	return refetchProps, nil
 }
 
+// poweredOnVMResizeConfigSpec sets the changes from resizing the VM that may
+// be applied while the VM is powered on in the ConfigSpec, ex. adding CPUs when
+// CPU hot-add is enabled. The changes that require the VM to be powered off are
+// returned as pending changes.
+func poweredOnVMResizeConfigSpec(
+	vmCtx pkgctx.VirtualMachineContext,
+	config *vimtypes.VirtualMachineConfigInfo,
+	resizeArgs *VMResizeArgs,
+	configSpec *vimtypes.VirtualMachineConfigSpec) (bool, []vmopv1.VirtualMachineResizePendingChange, error) {
+
+	var needsResize bool
+
+	if resizeArgs.VMClass != nil {
+		needsResize = vmopv1util.ResizeNeeded(*vmCtx.VM, *resizeArgs.VMClass)
+		if needsResize {
+			var (
+				resizeConfigSpec vimtypes.VirtualMachineConfigSpec
+				err              error
+			)
+			if pkgcfg.FromContext(vmCtx).Features.VMResize {
+				resizeConfigSpec, err = resize.CreateResizeConfigSpec(vmCtx, *config, resizeArgs.ConfigSpec)
+			} else {
+				resizeConfigSpec, err = resize.CreateResizeCPUMemoryConfigSpec(vmCtx, *config, resizeArgs.ConfigSpec)
+			}
+			if err != nil {
+				return false, nil, err
+			}
+			*configSpec = resizeConfigSpec
+		}
+	}
+
+	pendingChanges := vmopv1util.HotResizeConfigSpec(*vmCtx.VM, *config, configSpec)
+
+	return needsResize, pendingChanges, nil
+}
+
+// updatePoweredOnVMResizeStatus reports the changes from resizing the VM that
+// could not be applied while the VM is powered on. The VM is only considered
+// resized once there are no pending changes, which are applied the next time
+// the VM is powered off or powered on.
+func updatePoweredOnVMResizeStatus(
+	vmCtx pkgctx.VirtualMachineContext,
+	resizeArgs *VMResizeArgs,
+	needsResize bool,
+	pendingChanges []vmopv1.VirtualMachineResizePendingChange) {
+
+	if len(pendingChanges) > 0 {
+		vmCtx.VM.Status.Resize = &vmopv1.VirtualMachineResizeStatus{
+			PendingChanges: pendingChanges,
+		}
+		return
+	}
+
+	vmCtx.VM.Status.Resize = nil
+
+	if needsResize {
+		vmopv1util.MustSetLastResizedAnnotation(vmCtx.VM, *resizeArgs.VMClass)
+
+		vmCtx.VM.Status.Class = &vmopv1common.LocalObjectRef{
+			APIVersion: vmopv1.GroupVersion.String(),
+			Kind:       "VirtualMachineClass",
+			Name:       resizeArgs.VMClass.Name,
+		}
+	}
+}
+
+// updateConfigSpecNetworkInterfaces adds the device changes to hot-add or
+// hot-remove the Ethernet cards of a powered on VM to the ConfigSpec when the
+// network interfaces in the VM's spec have changed.
+func (s *Session) updateConfigSpecNetworkInterfaces(
+	vmCtx pkgctx.VirtualMachineContext,
+	config *vimtypes.VirtualMachineConfigInfo,
+	configSpec *vimtypes.VirtualMachineConfigSpec) (network2.NetworkInterfaceResults, bool, error) {
+
+	if !pkgcfg.FromContext(vmCtx).Features.VMNetworkHotPlug || !NetworkInterfacesChanged(vmCtx.VM) {
+		return network2.NetworkInterfaceResults{}, false, nil
+	}
+
+	// The VM class is not consulted for a powered on VM, so any added
+	// interface is given a default Ethernet card.
+	results, err := s.ensureNetworkInterfaces(vmCtx, nil)
+	if err != nil {
+		return network2.NetworkInterfaceResults{}, false, err
+	}
+
+	currentEthCards := object.VirtualDeviceList(config.Hardware.Device).SelectByType((*vimtypes.VirtualEthernetCard)(nil))
+	configSpec.DeviceChange = append(configSpec.DeviceChange,
+		NetworkInterfaceDeviceChanges(vmCtx.VM, results, currentEthCards)...)
+
+	return results, true, nil
+}
+
+// updateHotPluggedNetworkInterfaces updates the VM's network config status and
+// deletes the network interface CRs of removed interfaces after the Ethernet
+// cards of a powered on VM were hot-added or hot-removed.
+//
+// Please note the guest is not customized again, so the guest is responsible
+// for configuring an added interface, ex. with DHCP.
+func (s *Session) updateHotPluggedNetworkInterfaces(
+	vmCtx pkgctx.VirtualMachineContext,
+	resVM *res.VirtualMachine,
+	networkResults network2.NetworkInterfaceResults) error {
+
+	updateArgs := &VMUpdateArgs{
+		NetworkResults: networkResults,
+	}
+
+	if err := s.fixupMacAddresses(vmCtx, resVM, updateArgs); err != nil {
+		return err
+	}
+
+	bootstrapArgs, err := vmlifecycle.GetBootstrapArgs(
+		vmCtx,
+		s.K8sClient,
+		updateArgs.NetworkResults,
+		vmlifecycle.BootstrapData{})
+	if err != nil {
+		return err
+	}
+
+	vmlifecycle.UpdateNetworkStatusConfig(vmCtx.VM, bootstrapArgs)
+
+	return network2.DeleteOrphanedNetworkInterfaces(vmCtx, s.K8sClient)
+}
+
 func (s *Session) attachClusterModule(
 	vmCtx pkgctx.VirtualMachineContext,
 	resVM *res.VirtualMachine,
@@ -917,6 +1367,10 @@ func (s *Session) resizeVMWhenPoweredStateOff(
 		return false, err
 	}
 
+	// Any changes that were pending while the VM was powered on have now
+	// been applied.
+	vmCtx.VM.Status.Resize = nil
+
 	if needsResize {
 		vmopv1util.MustSetLastResizedAnnotation(vmCtx.VM, *resizeArgs.VMClass)
 	}
@@ -953,6 +1407,14 @@ func (s *Session) prePowerOnVMResizeConfigSpec(
 		return nil, false, err
 	}
 
+	// Network interfaces may have been added to or removed from the VM while it
+	// was powered off.
+	if pkgcfg.FromContext(vmCtx).Features.VMNetworkHotPlug {
+		currentEthCards := object.VirtualDeviceList(config.Hardware.Device).SelectByType((*vimtypes.VirtualEthernetCard)(nil))
+		configSpec.DeviceChange = append(configSpec.DeviceChange,
+			NetworkInterfaceDeviceChanges(vmCtx.VM, updateArgs.NetworkResults, currentEthCards)...)
+	}
+
 	return &configSpec, needsResize, nil
 }
 
@@ -974,16 +1436,29 @@ func (s *Session) updateVMDesiredPowerStateOff(
 			vmCtx.VM.Spec.PowerOffMode == vmopv1.VirtualMachinePowerOpModeTrySoft
 	}
 	if powerOff {
-		if err := res.NewVMFromObject(vcVM).SetPowerState(
-			logr.NewContext(vmCtx, vmCtx.Logger),
+		result, err := res.NewVMFromObject(vcVM).SetPowerState(
+			virtualmachine.WithPowerOffOptions(
+				logr.NewContext(vmCtx, vmCtx.Logger),
+				vmCtx.VM),
 			existingPowerState,
 			vmCtx.VM.Spec.PowerState,
-			vmCtx.VM.Spec.PowerOffMode); err != nil {
-
-			return false, err
+			vmCtx.VM.Spec.PowerOffMode)
+		virtualmachine.UpdateGuestShutdownCondition(vmCtx.VM, result, err)
+		if err != nil {
+			return false, virtualmachine.RequeueOnPreStopHook(err)
 		}
 
 		refetchProps = true
+	} else if existingPowerState == vmopv1.VirtualMachinePowerStateOff {
+		// The guest may have powered itself off while running the pre-stop
+		// hook.
+		cleared, err := virtualmachine.ClearPreStopHook(vmCtx, vcVM)
+		if err != nil {
+			return false, err
+		}
+		if cleared {
+			refetchProps = true
+		}
 	}
 
 	// A VM's hardware can only be upgraded if the VM is powered off.
@@ -1035,7 +1510,7 @@ func (s *Session) updateVMDesiredPowerStateSuspended(
 	)
 
 	if existingPowerState == vmopv1.VirtualMachinePowerStateOn {
-		if err := res.NewVMFromObject(vcVM).SetPowerState(
+		if _, err := res.NewVMFromObject(vcVM).SetPowerState(
 			logr.NewContext(vmCtx, vmCtx.Logger),
 			existingPowerState,
 			vmCtx.VM.Spec.PowerState,
@@ -1061,6 +1536,7 @@ func (s *Session) updateVMDesiredPowerStateOn(
 	vmCtx pkgctx.VirtualMachineContext,
 	vcVM *object.VirtualMachine,
 	getUpdateArgsFn func() (*VMUpdateArgs, error),
+	getResizeArgsFn func() (*VMResizeArgs, error),
 	existingPowerState vmopv1.VirtualMachinePowerState) (refetchProps bool, err error) {
 
 	config := vmCtx.MoVM.Config
@@ -1074,6 +1550,15 @@ func (s *Session) updateVMDesiredPowerStateOn(
 
 	resVM := res.NewVMFromObject(vcVM)
 
+	// A soft power off may have been cancelled while the guest was running
+	// the pre-stop hook, in which case the hook must be signaled again the
+	// next time the VM is powered off.
+	cleared, err := virtualmachine.CancelPreStopHook(vmCtx, vcVM)
+	if err != nil {
+		return refetchProps, err
+	}
+	refetchProps = cleared
+
 	if existingPowerState == vmopv1.VirtualMachinePowerStateOn {
 		// Check to see if a possible restart is required.
 		// Please note a VM may only be restarted if it is powered on.
@@ -1106,9 +1591,10 @@ func (s *Session) updateVMDesiredPowerStateOn(
 		}
 
 		// Do not pass classConfigSpec to poweredOnVMReconfigure when VM is already powered
-		// on since we do not have to get VM class at this point.
+		// on since we do not have to get VM class at this point. The class is only used to
+		// resize the VM while it is powered on.
 		var reconfigured bool
-		reconfigured, err = s.poweredOnVMReconfigure(vmCtx, resVM, config)
+		reconfigured, err = s.poweredOnVMReconfigure(vmCtx, resVM, config, getResizeArgsFn)
 		if err != nil {
 			return refetchProps, err
 		}
@@ -1119,12 +1605,12 @@ func (s *Session) updateVMDesiredPowerStateOn(
 
 	if existingPowerState == vmopv1.VirtualMachinePowerStateSuspended {
 		// A suspended VM cannot be reconfigured.
-		err = resVM.SetPowerState(
+		_, err = resVM.SetPowerState(
 			logr.NewContext(vmCtx, vmCtx.Logger),
 			existingPowerState,
 			vmCtx.VM.Spec.PowerState,
 			vmopv1.VirtualMachinePowerOpModeHard)
-		return err == nil, err
+		return refetchProps || err == nil, err
 	}
 
 	updateArgs, err := getUpdateArgsFn()
@@ -1158,7 +1644,7 @@ func (s *Session) updateVMDesiredPowerStateOn(
 		return refetchProps, err
 	}
 
-	err = resVM.SetPowerState(
+	_, err = resVM.SetPowerState(
 		logr.NewContext(vmCtx, vmCtx.Logger),
 		existingPowerState,
 		vmCtx.VM.Spec.PowerState,
@@ -1218,6 +1704,7 @@ func (s *Session) UpdateVirtualMachine(
 				vmCtx,
 				vcVM,
 				getUpdateArgsFn,
+				getResizeArgsFn,
 				existingPowerState)
 		}
 	} else {
@@ -1270,6 +1757,14 @@ func (s *Session) UpdateVirtualMachine(
 		}
 	}
 
+	// Drift is only detected once the VM has been updated to match its spec,
+	// otherwise the changes VM Operator has yet to apply would be reported.
+	if updateErr == nil {
+		if err := s.reconcileDrift(vmCtx, vcVM, getResizeArgsFn); err != nil {
+			updateErr = fmt.Errorf("reconciling drift failed with %w", err)
+		}
+	}
+
 	if err := vmlifecycle.UpdateStatus(vmCtx, s.K8sClient, vcVM); err != nil {
 		err = fmt.Errorf("updating status failed with %w", err)
 		if updateErr == nil {
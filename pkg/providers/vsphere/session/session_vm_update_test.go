@@ -6,7 +6,9 @@ package session_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -24,13 +26,17 @@ import (
 	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
 	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
 	ctxop "github.com/vmware-tanzu/vm-operator/pkg/context/operation"
+	pkgerr "github.com/vmware-tanzu/vm-operator/pkg/errors"
 	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere"
 	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/constants"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/network"
 	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/session"
 	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/virtualmachine"
 	pkgutil "github.com/vmware-tanzu/vm-operator/pkg/util"
 	"github.com/vmware-tanzu/vm-operator/pkg/util/ptr"
+	vmopv1util "github.com/vmware-tanzu/vm-operator/pkg/util/vmopv1"
 	pkgclient "github.com/vmware-tanzu/vm-operator/pkg/util/vsphere/client"
+	vmutil "github.com/vmware-tanzu/vm-operator/pkg/util/vsphere/vm"
 	"github.com/vmware-tanzu/vm-operator/test/builder"
 	"github.com/vmware-tanzu/vm-operator/test/testutil"
 )
@@ -220,6 +226,61 @@ var _ = Describe("Update ConfigSpec", func() {
 			})
 		})
 
+		Context("Guest file system resize", func() {
+			BeforeEach(func() {
+				vm.Status.Volumes = []vmopv1.VirtualMachineVolumeStatus{
+					{
+						Name:         "vol-2",
+						DiskUUID:     "uuid-2",
+						Limit:        ptr.To(resource.MustParse("20Gi")),
+						ResizeStatus: vmopv1.VirtualMachineVolumeResizeStatusFileSystemResizePending,
+					},
+					{
+						Name:         "vol-1",
+						DiskUUID:     "uuid-1",
+						ResizeStatus: vmopv1.VirtualMachineVolumeResizeStatusFileSystemResizePending,
+					},
+					{
+						Name:         "vol-3",
+						DiskUUID:     "uuid-3",
+						ResizeStatus: vmopv1.VirtualMachineVolumeResizeStatusControllerResizeInProgress,
+					},
+				}
+			})
+
+			When("the VM has not opted into guest file system resize", func() {
+				It("Should not publish the volumes", func() {
+					Expect(ecMap).ToNot(HaveKey(constants.GuestFileSystemResizeExtraConfigKey))
+				})
+			})
+
+			When("the VM has opted into guest file system resize", func() {
+				BeforeEach(func() {
+					vm.Annotations[vmopv1.GuestFileSystemResizeAnnotation] = "true"
+				})
+
+				It("Should publish the volumes pending a file system resize", func() {
+					Expect(ecMap).To(HaveKeyWithValue(
+						constants.GuestFileSystemResizeExtraConfigKey,
+						`[{"name":"vol-1","diskUUID":"uuid-1"},{"name":"vol-2","diskUUID":"uuid-2","limit":"20Gi"}]`))
+				})
+
+				When("no volumes are pending a file system resize", func() {
+					BeforeEach(func() {
+						vm.Status.Volumes = nil
+						config.ExtraConfig = append(config.ExtraConfig, &vimtypes.OptionValue{
+							Key:   constants.GuestFileSystemResizeExtraConfigKey,
+							Value: `[{"name":"vol-1","diskUUID":"uuid-1"}]`,
+						})
+					})
+
+					It("Should be set to an empty value", func() {
+						Expect(ecMap).To(HaveKeyWithValue(constants.GuestFileSystemResizeExtraConfigKey, ""))
+					})
+				})
+			})
+		})
+
 		Context("ExtraConfig value already exists", func() {
 			BeforeEach(func() {
 				config.ExtraConfig = append(config.ExtraConfig, &vimtypes.OptionValue{Key: "foo", Value: "bar"})
@@ -483,6 +544,67 @@ var _ = Describe("Update ConfigSpec", func() {
 		})
 	})
 
+	Context("CPUMemoryHotAdd", func() {
+		var vmSpec vmopv1.VirtualMachineSpec
+		var classConfigSpec *vimtypes.VirtualMachineConfigSpec
+
+		BeforeEach(func() {
+			config.CpuHotAddEnabled = nil
+			config.MemoryHotAddEnabled = nil
+			classConfigSpec = nil
+			vmSpec = vmopv1.VirtualMachineSpec{}
+		})
+
+		AfterEach(func() {
+			configSpec.CpuHotAddEnabled = nil
+			configSpec.MemoryHotAddEnabled = nil
+		})
+
+		It("hot-add unset", func() {
+			session.UpdateConfigSpecCPUMemoryHotAdd(config, configSpec, classConfigSpec, vmSpec)
+			Expect(configSpec.CpuHotAddEnabled).To(BeNil())
+			Expect(configSpec.MemoryHotAddEnabled).To(BeNil())
+		})
+
+		It("hot-add set in class", func() {
+			classConfigSpec = &vimtypes.VirtualMachineConfigSpec{
+				CpuHotAddEnabled:    ptr.To(true),
+				MemoryHotAddEnabled: ptr.To(true),
+			}
+
+			session.UpdateConfigSpecCPUMemoryHotAdd(config, configSpec, classConfigSpec, vmSpec)
+			Expect(configSpec.CpuHotAddEnabled).To(HaveValue(BeTrue()))
+			Expect(configSpec.MemoryHotAddEnabled).To(HaveValue(BeTrue()))
+		})
+
+		It("hot-add set in VM spec takes precedence over class", func() {
+			classConfigSpec = &vimtypes.VirtualMachineConfigSpec{
+				CpuHotAddEnabled:    ptr.To(true),
+				MemoryHotAddEnabled: ptr.To(true),
+			}
+			vmSpec.Advanced = &vmopv1.VirtualMachineAdvancedSpec{
+				CPUHotAddEnabled: ptr.To(false),
+			}
+
+			session.UpdateConfigSpecCPUMemoryHotAdd(config, configSpec, classConfigSpec, vmSpec)
+			Expect(configSpec.CpuHotAddEnabled).To(HaveValue(BeFalse()))
+			Expect(configSpec.MemoryHotAddEnabled).To(HaveValue(BeTrue()))
+		})
+
+		It("hot-add matches", func() {
+			config.CpuHotAddEnabled = ptr.To(true)
+			config.MemoryHotAddEnabled = ptr.To(false)
+			vmSpec.Advanced = &vmopv1.VirtualMachineAdvancedSpec{
+				CPUHotAddEnabled:    ptr.To(true),
+				MemoryHotAddEnabled: ptr.To(false),
+			}
+
+			session.UpdateConfigSpecCPUMemoryHotAdd(config, configSpec, classConfigSpec, vmSpec)
+			Expect(configSpec.CpuHotAddEnabled).To(BeNil())
+			Expect(configSpec.MemoryHotAddEnabled).To(BeNil())
+		})
+	})
+
 	Context("Firmware", func() {
 		var vm *vmopv1.VirtualMachine
 
@@ -1097,6 +1219,229 @@ var _ = Describe("Update ConfigSpec", func() {
 	})
 })
 
+var _ = Describe("NetworkInterfacesChanged", func() {
+	var (
+		vm *vmopv1.VirtualMachine
+	)
+
+	BeforeEach(func() {
+		vm = builder.DummyBasicVirtualMachine("my-vm", "my-namespace")
+		vm.Spec.Network = &vmopv1.VirtualMachineNetworkSpec{
+			Interfaces: []vmopv1.VirtualMachineNetworkInterfaceSpec{
+				{Name: "eth0"},
+				{Name: "eth1"},
+			},
+		}
+		vm.Status.Network = &vmopv1.VirtualMachineNetworkStatus{
+			Config: &vmopv1.VirtualMachineNetworkConfigStatus{
+				Interfaces: []vmopv1.VirtualMachineNetworkConfigInterfaceStatus{
+					{Name: "eth0"},
+					{Name: "eth1"},
+				},
+			},
+		}
+	})
+
+	It("returns false when the interfaces match the network config status", func() {
+		Expect(session.NetworkInterfacesChanged(vm)).To(BeFalse())
+	})
+
+	It("returns true when an interface was added", func() {
+		vm.Spec.Network.Interfaces = append(vm.Spec.Network.Interfaces,
+			vmopv1.VirtualMachineNetworkInterfaceSpec{Name: "eth2"})
+		Expect(session.NetworkInterfacesChanged(vm)).To(BeTrue())
+	})
+
+	It("returns true when an interface was removed", func() {
+		vm.Spec.Network.Interfaces = vm.Spec.Network.Interfaces[:1]
+		Expect(session.NetworkInterfacesChanged(vm)).To(BeTrue())
+	})
+
+	It("returns true when an interface was replaced", func() {
+		vm.Spec.Network.Interfaces[1].Name = "eth2"
+		Expect(session.NetworkInterfacesChanged(vm)).To(BeTrue())
+	})
+
+	It("returns false when the network config has not been applied", func() {
+		vm.Status.Network = nil
+		vm.Spec.Network.Interfaces = nil
+		Expect(session.NetworkInterfacesChanged(vm)).To(BeFalse())
+	})
+
+	It("returns false when the network is disabled", func() {
+		vm.Spec.Network.Disabled = true
+		Expect(session.NetworkInterfacesChanged(vm)).To(BeFalse())
+	})
+
+	It("returns false when the network is nil", func() {
+		vm.Spec.Network = nil
+		Expect(session.NetworkInterfacesChanged(vm)).To(BeFalse())
+	})
+})
+
+var _ = Describe("NetworkInterfaceDeviceChanges", func() {
+	const (
+		mac0 = "00:50:56:00:00:00"
+		mac1 = "00:50:56:00:00:01"
+	)
+
+	var (
+		vm       *vmopv1.VirtualMachine
+		results  network.NetworkInterfaceResults
+		ethCards object.VirtualDeviceList
+	)
+
+	newEthCard := func(key int32, macAddress string) vimtypes.BaseVirtualDevice {
+		return &vimtypes.VirtualVmxnet3{
+			VirtualVmxnet: vimtypes.VirtualVmxnet{
+				VirtualEthernetCard: vimtypes.VirtualEthernetCard{
+					VirtualDevice: vimtypes.VirtualDevice{Key: key},
+					MacAddress:    macAddress,
+				},
+			},
+		}
+	}
+
+	removedKeys := func(changes []vimtypes.BaseVirtualDeviceConfigSpec) []int32 {
+		var keys []int32
+		for _, c := range changes {
+			spec := c.GetVirtualDeviceConfigSpec()
+			if spec.Operation == vimtypes.VirtualDeviceConfigSpecOperationRemove {
+				keys = append(keys, spec.Device.GetVirtualDevice().Key)
+			}
+		}
+		return keys
+	}
+
+	BeforeEach(func() {
+		vm = builder.DummyBasicVirtualMachine("my-vm", "my-namespace")
+		vm.Spec.Network = &vmopv1.VirtualMachineNetworkSpec{
+			Interfaces: []vmopv1.VirtualMachineNetworkInterfaceSpec{
+				{Name: "eth0"},
+				{Name: "eth1"},
+			},
+		}
+		vm.Status.Network = &vmopv1.VirtualMachineNetworkStatus{
+			Config: &vmopv1.VirtualMachineNetworkConfigStatus{
+				Interfaces: []vmopv1.VirtualMachineNetworkConfigInterfaceStatus{
+					{Name: "eth0"},
+					{Name: "eth1"},
+				},
+			},
+			Interfaces: []vmopv1.VirtualMachineNetworkInterfaceStatus{
+				{
+					Name:      "eth0",
+					DeviceKey: 4000,
+					IP:        &vmopv1.VirtualMachineNetworkInterfaceIPStatus{MACAddr: mac0},
+				},
+				{
+					Name:      "eth1",
+					DeviceKey: 4001,
+					IP:        &vmopv1.VirtualMachineNetworkInterfaceIPStatus{MACAddr: mac1},
+				},
+			},
+		}
+		results = network.NetworkInterfaceResults{
+			Results: []network.NetworkInterfaceResult{
+				{Name: "eth0", MacAddress: mac0, Device: newEthCard(-100, mac0)},
+				{Name: "eth1", MacAddress: mac1, Device: newEthCard(-101, mac1)},
+			},
+		}
+		ethCards = object.VirtualDeviceList{
+			newEthCard(4001, mac1),
+			newEthCard(4000, mac0),
+		}
+	})
+
+	It("returns nothing when the interfaces have not changed", func() {
+		Expect(session.NetworkInterfaceDeviceChanges(vm, results, ethCards)).To(BeEmpty())
+	})
+
+	It("adds the Ethernet card of an added interface", func() {
+		vm.Spec.Network.Interfaces = append(vm.Spec.Network.Interfaces,
+			vmopv1.VirtualMachineNetworkInterfaceSpec{Name: "eth2"})
+		results.Results = append(results.Results,
+			network.NetworkInterfaceResult{Name: "eth2", Device: newEthCard(-102, "")})
+
+		changes := session.NetworkInterfaceDeviceChanges(vm, results, ethCards)
+		Expect(changes).To(HaveLen(1))
+		Expect(changes[0].GetVirtualDeviceConfigSpec().Operation).To(Equal(vimtypes.VirtualDeviceConfigSpecOperationAdd))
+		Expect(changes[0].GetVirtualDeviceConfigSpec().Device.GetVirtualDevice().Key).To(Equal(int32(-102)))
+	})
+
+	It("removes the Ethernet card of a removed interface", func() {
+		vm.Spec.Network.Interfaces = vm.Spec.Network.Interfaces[1:]
+		results.Results = results.Results[1:]
+
+		changes := session.NetworkInterfaceDeviceChanges(vm, results, ethCards)
+		Expect(changes).To(HaveLen(1))
+		Expect(removedKeys(changes)).To(Equal([]int32{4000}))
+	})
+
+	When("the order of the device keys differs from the order of the interfaces", func() {
+		BeforeEach(func() {
+			vm.Status.Network.Interfaces[0].DeviceKey = 4001
+			vm.Status.Network.Interfaces[0].IP.MACAddr = mac1
+			vm.Status.Network.Interfaces[1].DeviceKey = 4000
+			vm.Status.Network.Interfaces[1].IP.MACAddr = mac0
+			results.Results[0].MacAddress = mac1
+			results.Results[1].MacAddress = mac0
+		})
+
+		It("removes the Ethernet card of the removed interface", func() {
+			vm.Spec.Network.Interfaces = vm.Spec.Network.Interfaces[1:]
+			results.Results = results.Results[1:]
+
+			Expect(removedKeys(session.NetworkInterfaceDeviceChanges(vm, results, ethCards))).To(Equal([]int32{4001}))
+		})
+	})
+
+	It("matches the Ethernet card by MAC address when the device key is not reported", func() {
+		vm.Status.Network.Interfaces[0].DeviceKey = 0
+		vm.Status.Network.Interfaces[0].IP.MACAddr = strings.ToUpper(mac1)
+		vm.Spec.Network.Interfaces = vm.Spec.Network.Interfaces[1:]
+		results.Results = []network.NetworkInterfaceResult{
+			{Name: "eth1", ExternalID: "eth1-external-id", Device: newEthCard(-101, "")},
+		}
+
+		Expect(removedKeys(session.NetworkInterfaceDeviceChanges(vm, results, ethCards))).To(Equal([]int32{4001}))
+	})
+
+	It("does not remove the Ethernet card of an interface that remains in the spec", func() {
+		vm.Spec.Network.Interfaces = vm.Spec.Network.Interfaces[1:]
+		results.Results = results.Results[1:]
+		results.Results[0].MacAddress = ""
+		results.Results[0].ExternalID = "eth1-external-id"
+		ethCards[1].(vimtypes.BaseVirtualEthernetCard).GetVirtualEthernetCard().ExternalId = "eth1-external-id"
+
+		Expect(session.NetworkInterfaceDeviceChanges(vm, results, ethCards)).To(BeEmpty())
+	})
+
+	It("does not remove Ethernet cards that do not correspond to an applied interface", func() {
+		vm.Spec.Network.Interfaces = nil
+		vm.Status.Network.Config.Interfaces = nil
+		results.Results = nil
+
+		Expect(session.NetworkInterfaceDeviceChanges(vm, results, ethCards)).To(BeEmpty())
+
+		By("only removing the Ethernet cards of the applied interfaces", func() {
+			vm.Status.Network.Config.Interfaces = []vmopv1.VirtualMachineNetworkConfigInterfaceStatus{
+				{Name: "eth0"},
+			}
+
+			Expect(removedKeys(session.NetworkInterfaceDeviceChanges(vm, results, ethCards))).To(Equal([]int32{4000}))
+		})
+	})
+
+	It("does not remove an Ethernet card that cannot be matched with the removed interface", func() {
+		vm.Spec.Network.Interfaces = vm.Spec.Network.Interfaces[1:]
+		results.Results = results.Results[1:]
+		vm.Status.Network.Interfaces = vm.Status.Network.Interfaces[1:]
+
+		Expect(session.NetworkInterfaceDeviceChanges(vm, results, ethCards)).To(BeEmpty())
+	})
+})
+
 var _ = Describe("UpdateVirtualMachine", func() {
 
 	var (
@@ -1216,6 +1561,116 @@ var _ = Describe("UpdateVirtualMachine", func() {
 				Expect(sess.UpdateVirtualMachine(vmCtx, vcVM, nil, nil)).To(Succeed())
 				assertNoUpdate()
 			})
+
+			When("the VM is resized", func() {
+				var (
+					vmClass       *vmopv1.VirtualMachineClass
+					cpuHotAdd     bool
+					numCPUs       int32
+					memoryMB      int32
+					getResizeArgs func() (*session.VMResizeArgs, error)
+				)
+
+				BeforeEach(func() {
+					cpuHotAdd = true
+					vmClass = builder.DummyVirtualMachineClass("my-new-class")
+					vm.Spec.ClassName = vmClass.Name
+					Expect(vmopv1util.SetLastResizedAnnotationClassName(vm, "my-old-class")).To(Succeed())
+				})
+
+				JustBeforeEach(func() {
+					pkgcfg.UpdateContext(vmCtx, func(config *pkgcfg.Config) {
+						config.Features.VMResizeCPUMemory = true
+					})
+
+					t, err := vcVM.Reconfigure(ctx, vimtypes.VirtualMachineConfigSpec{
+						CpuHotAddEnabled: &cpuHotAdd,
+					})
+					Expect(err).ToNot(HaveOccurred())
+					Expect(t.Wait(ctx)).To(Succeed())
+					Expect(vcVM.Properties(ctx, vcVM.Reference(), vmProps, &vmCtx.MoVM)).To(Succeed())
+
+					numCPUs = vmCtx.MoVM.Config.Hardware.NumCPU
+					memoryMB = vmCtx.MoVM.Config.Hardware.MemoryMB
+				})
+
+				When("the class increases the number of CPUs", func() {
+					JustBeforeEach(func() {
+						getResizeArgs = func() (*session.VMResizeArgs, error) {
+							return &session.VMResizeArgs{
+								VMClass: vmClass,
+								ConfigSpec: vimtypes.VirtualMachineConfigSpec{
+									NumCPUs:  numCPUs + 1,
+									MemoryMB: int64(memoryMB),
+								},
+							}, nil
+						}
+					})
+
+					It("should add the CPUs to the powered on VM", func() {
+						Expect(sess.UpdateVirtualMachine(vmCtx, vcVM, nil, getResizeArgs)).To(Succeed())
+						Expect(vcVM.Properties(ctx, vcVM.Reference(), vmProps, &vmCtx.MoVM)).To(Succeed())
+						Expect(vmCtx.MoVM.Summary.Runtime.PowerState).To(Equal(vimtypes.VirtualMachinePowerStatePoweredOn))
+						Expect(vmCtx.MoVM.Config.Hardware.NumCPU).To(Equal(numCPUs + 1))
+						Expect(vm.Status.Resize).To(BeNil())
+						Expect(vm.Status.Class).ToNot(BeNil())
+						Expect(vm.Status.Class.Name).To(Equal(vmClass.Name))
+						Expect(vmopv1util.ResizeNeeded(*vm, *vmClass)).To(BeFalse())
+						assertUpdate()
+					})
+
+					When("CPU hot-add is not enabled", func() {
+						BeforeEach(func() {
+							cpuHotAdd = false
+						})
+
+						It("should report the change as pending", func() {
+							Expect(sess.UpdateVirtualMachine(vmCtx, vcVM, nil, getResizeArgs)).To(Succeed())
+							Expect(vcVM.Properties(ctx, vcVM.Reference(), vmProps, &vmCtx.MoVM)).To(Succeed())
+							Expect(vmCtx.MoVM.Config.Hardware.NumCPU).To(Equal(numCPUs))
+							Expect(vm.Status.Resize).ToNot(BeNil())
+							Expect(vm.Status.Resize.PendingChanges).To(ConsistOf(vmopv1.VirtualMachineResizePendingChange{
+								Field:   "numCPUs",
+								Current: fmt.Sprintf("%d", numCPUs),
+								Desired: fmt.Sprintf("%d", numCPUs+1),
+								Reason:  vmopv1.VirtualMachineResizePendingReasonHotAddDisabled,
+							}))
+							Expect(vmopv1util.ResizeNeeded(*vm, *vmClass)).To(BeTrue())
+							assertNoUpdate()
+						})
+					})
+				})
+
+				When("the class increases the number of CPUs and decreases the memory", func() {
+					JustBeforeEach(func() {
+						getResizeArgs = func() (*session.VMResizeArgs, error) {
+							return &session.VMResizeArgs{
+								VMClass: vmClass,
+								ConfigSpec: vimtypes.VirtualMachineConfigSpec{
+									NumCPUs:  numCPUs + 1,
+									MemoryMB: int64(memoryMB / 2),
+								},
+							}, nil
+						}
+					})
+
+					It("should add the CPUs and report the memory decrease as pending", func() {
+						Expect(sess.UpdateVirtualMachine(vmCtx, vcVM, nil, getResizeArgs)).To(Succeed())
+						Expect(vcVM.Properties(ctx, vcVM.Reference(), vmProps, &vmCtx.MoVM)).To(Succeed())
+						Expect(vmCtx.MoVM.Config.Hardware.NumCPU).To(Equal(numCPUs + 1))
+						Expect(vmCtx.MoVM.Config.Hardware.MemoryMB).To(Equal(memoryMB))
+						Expect(vm.Status.Resize).ToNot(BeNil())
+						Expect(vm.Status.Resize.PendingChanges).To(ConsistOf(vmopv1.VirtualMachineResizePendingChange{
+							Field:   "memoryMB",
+							Current: fmt.Sprintf("%d", memoryMB),
+							Desired: fmt.Sprintf("%d", memoryMB/2),
+							Reason:  vmopv1.VirtualMachineResizePendingReasonDecrease,
+						}))
+						Expect(vmopv1util.ResizeNeeded(*vm, *vmClass)).To(BeTrue())
+						assertUpdate()
+					})
+				})
+			})
 		})
 
 		When("powering off the VM", func() {
@@ -1255,6 +1710,65 @@ var _ = Describe("UpdateVirtualMachine", func() {
 					assertUpdate()
 				})
 			})
+			When("there is a pre-stop hook and the power off is cancelled", func() {
+				getPreStopTime := func() string {
+					Expect(vcVM.Properties(ctx, vcVM.Reference(), vmProps, &vmCtx.MoVM)).To(Succeed())
+					v, _ := object.OptionValueList(vmCtx.MoVM.Config.ExtraConfig).GetString(vmutil.ExtraConfigKeyPreStopTime)
+					return v
+				}
+
+				BeforeEach(func() {
+					vm.Spec.PowerOffMode = vmopv1.VirtualMachinePowerOpModeSoft
+					vm.Spec.PreStop = &vmopv1.VirtualMachinePreStopHookSpec{
+						Command:        "flush",
+						TimeoutSeconds: ptr.To[int32](3600),
+					}
+				})
+
+				It("should signal the guest again the next time the VM is powered off", func() {
+					var requeueErr pkgerr.RequeueError
+
+					By("signaling the guest", func() {
+						err := sess.UpdateVirtualMachine(vmCtx, vcVM, nil, nil)
+						Expect(errors.As(err, &requeueErr)).To(BeTrue())
+						Expect(getPreStopTime()).ToNot(BeEmpty())
+						Expect(conditions.GetReason(vm, vmopv1.GuestShutdownCondition)).To(
+							Equal(vmopv1.GuestShutdownPreStopHookRunningReason))
+					})
+
+					// Make the signal older than when the VM is powered off
+					// again.
+					staleTime := time.Now().UTC().Add(-10 * time.Minute).Format(time.RFC3339)
+					t, err := vcVM.Reconfigure(ctx, vimtypes.VirtualMachineConfigSpec{
+						ExtraConfig: []vimtypes.BaseOptionValue{
+							&vimtypes.OptionValue{Key: vmutil.ExtraConfigKeyPreStopTime, Value: staleTime},
+						},
+					})
+					Expect(err).ToNot(HaveOccurred())
+					Expect(t.Wait(ctx)).To(Succeed())
+					Expect(vcVM.Properties(ctx, vcVM.Reference(), vmProps, &vmCtx.MoVM)).To(Succeed())
+
+					By("cancelling the power off", func() {
+						vm.Spec.PowerState = vmopv1.VirtualMachinePowerStateOn
+						Expect(sess.UpdateVirtualMachine(vmCtx, vcVM, nil, nil)).To(Succeed())
+						Expect(getPreStopTime()).To(BeEmpty())
+						Expect(conditions.Get(vm, vmopv1.GuestShutdownCondition)).To(BeNil())
+						Expect(vmCtx.MoVM.Summary.Runtime.PowerState).To(Equal(vimtypes.VirtualMachinePowerStatePoweredOn))
+					})
+
+					By("powering off the VM again", func() {
+						vm.Spec.PowerState = vmopv1.VirtualMachinePowerStateOff
+						err := sess.UpdateVirtualMachine(vmCtx, vcVM, nil, nil)
+						Expect(errors.As(err, &requeueErr)).To(BeTrue())
+						v := getPreStopTime()
+						Expect(v).ToNot(BeEmpty())
+						Expect(v).ToNot(Equal(staleTime))
+						Expect(conditions.GetReason(vm, vmopv1.GuestShutdownCondition)).To(
+							Equal(vmopv1.GuestShutdownPreStopHookRunningReason))
+						Expect(vmCtx.MoVM.Summary.Runtime.PowerState).To(Equal(vimtypes.VirtualMachinePowerStatePoweredOn))
+					})
+				})
+			})
 		})
 
 		When("restarting the VM", func() {
@@ -1451,7 +1965,7 @@ var _ = Describe("UpdateVirtualMachine", func() {
 					vm.Spec.Cdrom = []vmopv1.VirtualMachineCdromSpec{
 						{
 							Name: "cdrom",
-							Image: vmopv1.VirtualMachineImageRef{
+							Image: &vmopv1.VirtualMachineImageRef{
 								Name: "fake-iso-image",
 							},
 						},
@@ -1638,7 +2152,7 @@ var _ = Describe("UpdateVirtualMachine", func() {
 						vm.Spec.Cdrom = []vmopv1.VirtualMachineCdromSpec{
 							{
 								Name: "cdrom-1",
-								Image: vmopv1.VirtualMachineImageRef{
+								Image: &vmopv1.VirtualMachineImageRef{
 									Name: vmiName,
 									Kind: vmiKind,
 								},
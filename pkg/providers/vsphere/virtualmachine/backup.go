@@ -16,6 +16,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/serializer/yaml"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	k8syaml "sigs.k8s.io/yaml"
@@ -38,6 +39,8 @@ type BackupVirtualMachineOptions struct {
 	AdditionalResources []client.Object
 	BackupVersion       string
 	ClassicDiskUUIDs    map[string]struct{}
+	ExcludedFieldPaths  []string
+	BackupPolicyHash    string
 }
 
 // BackupVirtualMachine backs up the required data of a VM into its ExtraConfig.
@@ -45,6 +48,9 @@ type BackupVirtualMachineOptions struct {
 // - VM Kubernetes resource in YAMl.
 // - Additional VM-relevant Kubernetes resources in YAML, separated by "---".
 // - PVC disk data in JSON format (if DiskUUIDToPVC is not empty).
+// The fields in ExcludedFieldPaths are removed from the VM and additional
+// resources before they are backed up. All of the data is backed up again if
+// BackupPolicyHash differs from the hash stored with the existing backup.
 func BackupVirtualMachine(opts BackupVirtualMachineOptions) (result error) {
 	defer func() {
 		if result != nil {
@@ -100,16 +106,29 @@ func BackupVirtualMachine(opts BackupVirtualMachineOptions) (result error) {
 		}
 	}
 
-	additionalYAML, err := getDesiredAdditionalResourceYAMLForBackup(
+	// A change to the backup policy may change the backed up resources or
+	// fields without changing the resources themselves.
+	curPolicyHash, _ := curExCfg.GetString(backupapi.BackupPolicyHashExtraConfigKey)
+	policyChanged := curPolicyHash != opts.BackupPolicyHash
+	if policyChanged {
+		ecToUpdate = append(ecToUpdate, &vimtypes.OptionValue{
+			Key:   backupapi.BackupPolicyHashExtraConfigKey,
+			Value: opts.BackupPolicyHash,
+		})
+	}
+
+	additionalYAML, updateAdditionalYAML, err := getDesiredAdditionalResourceYAMLForBackup(
 		opts.AdditionalResources,
 		curExCfg,
+		opts.ExcludedFieldPaths,
+		policyChanged,
 	)
 	if err != nil {
 		opts.VMCtx.Logger.Error(err, "failed to get additional resources yaml for backup")
 		return err
 	}
 
-	if additionalYAML == "" {
+	if !updateAdditionalYAML {
 		opts.VMCtx.Logger.V(4).Info("Skipping additional resources yaml backup as unchanged")
 	} else {
 		ecToUpdate = append(ecToUpdate, &vimtypes.OptionValue{
@@ -148,7 +167,12 @@ func BackupVirtualMachine(opts BackupVirtualMachineOptions) (result error) {
 
 	if pkgcfg.FromContext(opts.VMCtx).Features.VMIncrementalRestore {
 		curBackup, _ := curExCfg.GetString(backupapi.VMResourceYAMLExtraConfigKey)
-		vmBackupInSync, err := isVMBackupUpToDate(opts.VMCtx.VM, curBackup)
+		backupVM, err := getBackupVM(opts.VMCtx.VM, opts.ExcludedFieldPaths)
+		if err != nil {
+			opts.VMCtx.Logger.Error(err, "failed to get VM resource for backup")
+			return err
+		}
+		vmBackupInSync, err := isVMBackupUpToDate(backupVM, curBackup, policyChanged)
 		if err != nil {
 			opts.VMCtx.Logger.Error(err, "failed to check if VM resource is in sync with backup")
 			return err
@@ -172,8 +196,12 @@ func BackupVirtualMachine(opts BackupVirtualMachineOptions) (result error) {
 			// Remove the BackupUpToDateCondition from previous VM backup to remove ambiguity with newer backup version annotation.
 			conditions.Delete(copyVM, vmopv1.VirtualMachineBackupUpToDateCondition)
 			// Backup the updated VM's YAML with encoding and compression.
-			trimBackupFields(copyVM)
-			encodedVMYaml, err := getEncodedVMYaml(copyVM)
+			backupVM, err = getBackupVM(copyVM, opts.ExcludedFieldPaths)
+			if err != nil {
+				opts.VMCtx.Logger.Error(err, "failed to get VM resource for backup")
+				return err
+			}
+			encodedVMYaml, err := getEncodedVMYaml(backupVM)
 			if err != nil {
 				opts.VMCtx.Logger.Error(err, "failed to get VM resource yaml for backup")
 				return err
@@ -186,7 +214,11 @@ func BackupVirtualMachine(opts BackupVirtualMachineOptions) (result error) {
 		}
 
 	} else {
-		vmYAML, err := getDesiredVMResourceYAMLForBackup(opts.VMCtx.VM, curExCfg)
+		vmYAML, err := getDesiredVMResourceYAMLForBackup(
+			opts.VMCtx.VM,
+			curExCfg,
+			opts.ExcludedFieldPaths,
+			policyChanged)
 		if err != nil {
 			opts.VMCtx.Logger.Error(err, "failed to get VM resource yaml for backup")
 			return err
@@ -267,20 +299,35 @@ func canPerformBackup(opts BackupVirtualMachineOptions, backupVersionEcVal, back
 // given VM, or an empty string if the existing backup is already up-to-date.
 func getDesiredVMResourceYAMLForBackup(
 	vm *vmopv1.VirtualMachine,
-	extraConfig pkgutil.OptionValues) (string, error) {
+	extraConfig pkgutil.OptionValues,
+	excludedFieldPaths []string,
+	policyChanged bool) (string, error) {
+
+	backupVM, err := getBackupVM(vm, excludedFieldPaths)
+	if err != nil {
+		return "", err
+	}
 
 	curBackup, _ := extraConfig.GetString(backupapi.VMResourceYAMLExtraConfigKey)
-	isUpToDate, err := isVMBackupUpToDate(vm, curBackup)
+	isUpToDate, err := isVMBackupUpToDate(backupVM, curBackup, policyChanged)
 	if err != nil || isUpToDate {
 		return "", err
 	}
 
-	// Use a copy to avoid modifying the VM object at the end of reconciliation.
+	// Backup the updated VM's YAML with encoding and compression.
+	return getEncodedVMYaml(backupVM)
+}
+
+// getBackupVM returns a copy of the VM without the fields that are not backed
+// up. A copy is used to avoid modifying the VM object at the end of
+// reconciliation.
+func getBackupVM(
+	vm *vmopv1.VirtualMachine,
+	excludedFieldPaths []string) (client.Object, error) {
+
 	copyVM := vm.DeepCopy()
 	trimBackupFields(copyVM)
-
-	// Backup the updated VM's YAML with encoding and compression.
-	return getEncodedVMYaml(copyVM)
+	return excludeBackupFields(copyVM, excludedFieldPaths)
 }
 
 // trimBackupFields removes the object fields that are not necessary for backup.
@@ -293,8 +340,37 @@ func trimBackupFields(obj client.Object) {
 	obj.SetManagedFields(nil)
 }
 
+// excludeBackupFields returns the object without the fields at the given JSON
+// pointer paths. The object is returned as-is if there are no paths, otherwise
+// an unstructured copy of the object is returned.
+func excludeBackupFields(
+	obj client.Object,
+	excludedFieldPaths []string) (client.Object, error) {
+
+	if len(excludedFieldPaths) == 0 {
+		return obj, nil
+	}
+
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert object %q to unstructured: %w", obj.GetName(), err)
+	}
+
+	for _, p := range excludedFieldPaths {
+		fields, err := pkgutil.ParseJSONPointer(p)
+		if err != nil {
+			return nil, err
+		}
+		if len(fields) > 0 {
+			unstructured.RemoveNestedField(content, fields...)
+		}
+	}
+
+	return &unstructured.Unstructured{Object: content}, nil
+}
+
 // getEncodedVMYaml returns the encoded and gzipped YAML of the given VM object.
-func getEncodedVMYaml(vm *vmopv1.VirtualMachine) (string, error) {
+func getEncodedVMYaml(vm client.Object) (string, error) {
 	vmYAML, err := k8syaml.Marshal(vm)
 	if err != nil {
 		return "", fmt.Errorf("failed to marshal VM into YAML %+v: %v", vm, err)
@@ -303,11 +379,11 @@ func getEncodedVMYaml(vm *vmopv1.VirtualMachine) (string, error) {
 	return pkgutil.EncodeGzipBase64(string(vmYAML))
 }
 
-// isVMBackupUpToDate returns true if none of the following fields of the VM are
-// changed compared to the existing backup:
+// isVMBackupUpToDate returns true if the backup policy is unchanged and none of
+// the following fields of the VM are changed compared to the existing backup:
 // - generation (spec changes); annotations; labels.
-func isVMBackupUpToDate(vm *vmopv1.VirtualMachine, backup string) (bool, error) {
-	if backup == "" {
+func isVMBackupUpToDate(vm client.Object, backup string, policyChanged bool) (bool, error) {
+	if backup == "" || policyChanged {
 		return false, nil
 	}
 
@@ -323,38 +399,48 @@ func isVMBackupUpToDate(vm *vmopv1.VirtualMachine, backup string) (bool, error)
 	}
 
 	// Do not compare LastAppliedConfigAnnotation as it's not in the backup.
-	curAnnotations := maps.Clone(vm.Annotations)
+	curAnnotations := maps.Clone(vm.GetAnnotations())
 	delete(curAnnotations, corev1.LastAppliedConfigAnnotation)
 
-	return vm.Generation == backupVM.Generation &&
-		maps.Equal(vm.Labels, backupVM.Labels) &&
+	return vm.GetGeneration() == backupVM.Generation &&
+		maps.Equal(vm.GetLabels(), backupVM.Labels) &&
 		maps.Equal(curAnnotations, backupVM.Annotations), nil
 }
 
 // getDesiredAdditionalResourceYAMLForBackup returns the encoded and gzipped
-// YAML of the given resources, or an empty string if the existing backup is
-// already up-to-date (checked by comparing the resource versions).
+// YAML of the given resources, and whether the existing backup needs to be
+// updated. The existing backup is up-to-date if the backup policy is unchanged
+// and the backup contains exactly the given resources at their current
+// resource versions. An empty string is returned when there are no resources
+// to back up, which removes the existing backup.
 func getDesiredAdditionalResourceYAMLForBackup(
 	resources []client.Object,
-	extraConfig pkgutil.OptionValues) (string, error) {
+	extraConfig pkgutil.OptionValues,
+	excludedFieldPaths []string,
+	policyChanged bool) (string, bool, error) {
 
 	curBackup, _ := extraConfig.GetString(backupapi.AdditionalResourcesYAMLExtraConfigKey)
 	backupVers, err := getBackupResourceVersions(curBackup)
 	if err != nil {
-		return "", err
+		return "", false, err
 	}
 
-	isLatestBackup := true
+	// A resource that was deleted or is no longer selected for the backup
+	// makes the existing backup out of date.
+	isLatestBackup := !policyChanged && len(backupVers) == len(resources)
 	for _, curRes := range resources {
-		if backupVers[string(curRes.GetUID())] != curRes.GetResourceVersion() {
+		if v, ok := backupVers[string(curRes.GetUID())]; !ok || v != curRes.GetResourceVersion() {
 			isLatestBackup = false
 			break
 		}
 	}
 
-	// All resources are up-to-date, return an empty string to skip the backup.
 	if isLatestBackup {
-		return "", nil
+		return "", false, nil
+	}
+
+	if len(resources) == 0 {
+		return "", true, nil
 	}
 
 	// Backup the given resources YAML with encoding and compression.
@@ -362,9 +448,13 @@ func getDesiredAdditionalResourceYAMLForBackup(
 	var sb strings.Builder
 	for i, resource := range resources {
 		trimBackupFields(resource)
-		marshaledYaml, err := k8syaml.Marshal(resource)
+		backupResource, err := excludeBackupFields(resource, excludedFieldPaths)
 		if err != nil {
-			return "", fmt.Errorf("failed to marshal object %q: %v", resource.GetName(), err)
+			return "", false, err
+		}
+		marshaledYaml, err := k8syaml.Marshal(backupResource)
+		if err != nil {
+			return "", false, fmt.Errorf("failed to marshal object %q: %v", resource.GetName(), err)
 		}
 		sb.Write(marshaledYaml)
 		if i != len(resources)-1 {
@@ -372,7 +462,12 @@ func getDesiredAdditionalResourceYAMLForBackup(
 		}
 	}
 
-	return pkgutil.EncodeGzipBase64(sb.String())
+	encoded, err := pkgutil.EncodeGzipBase64(sb.String())
+	if err != nil {
+		return "", false, err
+	}
+
+	return encoded, true, nil
 }
 
 // getBackupResourceVersions gets the resource version of each object in
@@ -139,6 +139,39 @@ func backupTests() {
 					})
 				})
 
+				When("Field paths are excluded from the backup", func() {
+					It("Should backup the VM resource YAML without the excluded fields in ExtraConfig", func() {
+						vmCtx.VM.Labels = map[string]string{"foo": "bar"}
+						vmCtx.VM.Spec.MinHardwareVersion = 21
+
+						backupOpts := virtualmachine.BackupVirtualMachineOptions{
+							VMCtx:              vmCtx,
+							VcVM:               vcVM,
+							ExcludedFieldPaths: []string{"/metadata/labels", "/spec/minHardwareVersion", "/spec/not-found"},
+						}
+
+						if IncrementalRestore {
+							backupOpts.BackupVersion = vT1
+						}
+
+						Expect(virtualmachine.BackupVirtualMachine(backupOpts)).To(Succeed())
+
+						var moVM mo.VirtualMachine
+						Expect(vcVM.Properties(ctx, vcVM.Reference(), []string{"config.extraConfig"}, &moVM)).To(Succeed())
+						backupVM, err := virtualmachine.GetBackupVirtualMachine(moVM.Config.ExtraConfig)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(backupVM).NotTo(BeNil())
+						Expect(backupVM.Name).To(Equal(vmCtx.VM.Name))
+						Expect(backupVM.Labels).To(BeEmpty())
+						Expect(backupVM.Spec.MinHardwareVersion).To(BeZero())
+						Expect(backupVM.Spec.ImageName).To(Equal(vmCtx.VM.Spec.ImageName))
+
+						// The excluded fields are not removed from the VM itself.
+						Expect(vmCtx.VM.Labels).To(HaveKeyWithValue("foo", "bar"))
+						Expect(vmCtx.VM.Spec.MinHardwareVersion).To(Equal(int32(21)))
+					})
+				})
+
 				When("VM resource exists in ExtraConfig and gets a spec change", func() {
 
 					JustBeforeEach(func() {
@@ -583,6 +616,119 @@ func backupTests() {
 							Expect(vmCtx.VM.Annotations[vmopv1.VirtualMachineBackupVersionAnnotation]).To(Equal(vT2))
 						}
 					})
+
+					It("Should backup the additional resource YAML again when the backup policy changed", func() {
+						// Update the resource without changing its resourceVersion to verify the backup is not skipped.
+						secretRes.Labels = map[string]string{"foo": "bar"}
+						backupOpts := virtualmachine.BackupVirtualMachineOptions{
+							VMCtx:               vmCtx,
+							VcVM:                vcVM,
+							AdditionalResources: []client.Object{secretRes},
+							BackupPolicyHash:    "new-policy-hash",
+						}
+
+						if IncrementalRestore {
+							backupOpts.BackupVersion = vT3
+						}
+
+						Expect(virtualmachine.BackupVirtualMachine(backupOpts)).To(Succeed())
+						verifyBackupDataInExtraConfig(ctx, vcVM, backupapi.AdditionalResourcesYAMLExtraConfigKey, getExpectedBackupObjectYAML(secretRes.DeepCopy()), true)
+						verifyBackupDataInExtraConfig(ctx, vcVM, backupapi.BackupPolicyHashExtraConfigKey, "new-policy-hash", false)
+
+						if IncrementalRestore {
+							verifyBackupDataInExtraConfig(ctx, vcVM, backupapi.BackupVersionExtraConfigKey, vT3, false)
+							Expect(vmCtx.VM.Annotations[vmopv1.VirtualMachineBackupVersionAnnotation]).To(Equal(vT3))
+						}
+					})
+				})
+
+				When("Additional resources exist in ExtraConfig and a resource is no longer included", func() {
+					var (
+						cmRes *corev1.ConfigMap
+					)
+
+					JustBeforeEach(func() {
+						if IncrementalRestore {
+							vmCtx.VM.Annotations[vmopv1.VirtualMachineBackupVersionAnnotation] = vT2
+						}
+
+						cmRes = &corev1.ConfigMap{
+							TypeMeta: metav1.TypeMeta{
+								Kind: "ConfigMap",
+							},
+							ObjectMeta: metav1.ObjectMeta{
+								UID:             "cm-uid",
+								ResourceVersion: "0",
+								Name:            "vm-configMap",
+							},
+						}
+
+						vmYAML := getExpectedBackupObjectYAML(vmCtx.VM.DeepCopy())
+						vmYAMLEncoded, err := pkgutil.EncodeGzipBase64(vmYAML)
+						Expect(err).NotTo(HaveOccurred())
+
+						backupStr := getExpectedBackupObjectYAML(secretRes.DeepCopy()) +
+							"\n---\n" + getExpectedBackupObjectYAML(cmRes.DeepCopy())
+						yamlEncoded, err := pkgutil.EncodeGzipBase64(backupStr)
+						Expect(err).NotTo(HaveOccurred())
+
+						extraConfig := []vimtypes.BaseOptionValue{
+							&vimtypes.OptionValue{
+								Key:   backupapi.VMResourceYAMLExtraConfigKey,
+								Value: vmYAMLEncoded,
+							},
+							&vimtypes.OptionValue{
+								Key:   backupapi.AdditionalResourcesYAMLExtraConfigKey,
+								Value: yamlEncoded,
+							},
+						}
+
+						if IncrementalRestore {
+							extraConfig = append(extraConfig, &vimtypes.OptionValue{
+								Key:   backupapi.BackupVersionExtraConfigKey,
+								Value: vT2,
+							})
+						}
+
+						_, err = vcVM.Reconfigure(vmCtx, vimtypes.VirtualMachineConfigSpec{
+							ExtraConfig: extraConfig,
+						})
+						Expect(err).NotTo(HaveOccurred())
+					})
+
+					It("Should backup the remaining additional resources YAML in ExtraConfig", func() {
+						backupOpts := virtualmachine.BackupVirtualMachineOptions{
+							VMCtx:               vmCtx,
+							VcVM:                vcVM,
+							AdditionalResources: []client.Object{secretRes},
+						}
+
+						if IncrementalRestore {
+							backupOpts.BackupVersion = vT3
+						}
+
+						Expect(virtualmachine.BackupVirtualMachine(backupOpts)).To(Succeed())
+						verifyBackupDataInExtraConfig(ctx, vcVM, backupapi.AdditionalResourcesYAMLExtraConfigKey, getExpectedBackupObjectYAML(secretRes.DeepCopy()), true)
+
+						if IncrementalRestore {
+							verifyBackupDataInExtraConfig(ctx, vcVM, backupapi.BackupVersionExtraConfigKey, vT3, false)
+							Expect(vmCtx.VM.Annotations[vmopv1.VirtualMachineBackupVersionAnnotation]).To(Equal(vT3))
+						}
+					})
+
+					It("Should clear the additional resources YAML in ExtraConfig when no resources remain", func() {
+						backupOpts := virtualmachine.BackupVirtualMachineOptions{
+							VMCtx: vmCtx,
+							VcVM:  vcVM,
+						}
+
+						if IncrementalRestore {
+							backupOpts.BackupVersion = vT3
+						}
+
+						Expect(virtualmachine.BackupVirtualMachine(backupOpts)).To(Succeed())
+						verifyBackupDataInExtraConfig(ctx, vcVM, backupapi.AdditionalResourcesYAMLExtraConfigKey, "", true)
+					})
 				})
 
 				When("Multiple additional resources are given", func() {
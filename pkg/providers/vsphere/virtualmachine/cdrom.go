@@ -5,14 +5,28 @@
 package virtualmachine
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"maps"
+	"path"
+	"slices"
+	"strings"
 
+	"github.com/vmware/govmomi/fault"
+	"github.com/vmware/govmomi/find"
 	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/property"
 	"github.com/vmware/govmomi/vapi/library"
 	"github.com/vmware/govmomi/vapi/rest"
+	"github.com/vmware/govmomi/vim25"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/soap"
 	vimtypes "github.com/vmware/govmomi/vim25/types"
+	"github.com/vmware/govmomi/vslm"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
 
 	imgregv1a1 "github.com/vmware-tanzu/image-registry-operator-api/api/v1alpha1"
@@ -20,12 +34,21 @@ import (
 	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
 	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
 	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/iso9660"
 	"github.com/vmware-tanzu/vm-operator/pkg/util/ptr"
 )
 
 const (
 	vmiKind  = "VirtualMachineImage"
 	cvmiKind = "Cluster" + vmiKind
+
+	// defaultConfigDriveVolumeLabel is the volume label of a config drive
+	// when one is not specified.
+	defaultConfigDriveVolumeLabel = "config-2"
+
+	// configDriveFileNameSuffix is appended to the name of a CD-ROM to get
+	// the name of its config drive's ISO file in the VM's directory.
+	configDriveFileNameSuffix = "-config-drive.iso"
 )
 
 // UpdateCdromDeviceChanges reconciles the desired CD-ROM devices specified in
@@ -34,6 +57,7 @@ const (
 func UpdateCdromDeviceChanges(
 	vmCtx pkgctx.VirtualMachineContext,
 	restClient *rest.Client,
+	vimClient *vim25.Client,
 	k8sClient ctrlclient.Client,
 	curDevices object.VirtualDeviceList) ([]vimtypes.BaseVirtualDeviceConfigSpec, error) {
 
@@ -45,12 +69,13 @@ func UpdateCdromDeviceChanges(
 	)
 
 	for _, specCdrom := range vmCtx.VM.Spec.Cdrom {
-		imageRef := specCdrom.Image
 		// Sync the content library file if needed to connect the CD-ROM device.
+		// The VM is about to be powered on, so a config drive is regenerated
+		// from the latest data of its sources.
 		syncFile := ptr.Deref(specCdrom.Connected)
-		bFileName, err := getBackingFileNameByImageRef(vmCtx, libManager, k8sClient, syncFile, imageRef)
+		bFileName, err := getBackingFileName(vmCtx, libManager, vimClient, k8sClient, specCdrom, syncFile, true)
 		if err != nil {
-			return nil, fmt.Errorf("error getting backing file name by image ref %s: %w", imageRef, err)
+			return nil, err
 		}
 		cdrom, err := getCdromByBackingFileName(bFileName, curDevices)
 		if err != nil {
@@ -79,6 +104,7 @@ func UpdateCdromDeviceChanges(
 	// Also, update the current device list by excluding the removed CD-ROMs to
 	// assign the new CD-ROMs to the correct controller unit numbers later.
 	newCurDevices := object.VirtualDeviceList{}
+	removedCdroms := object.VirtualDeviceList{}
 	for _, d := range curDevices {
 		cdrom, ok := d.(*vimtypes.VirtualCdrom)
 		if !ok {
@@ -96,6 +122,13 @@ func UpdateCdromDeviceChanges(
 			Device:    cdrom,
 			Operation: vimtypes.VirtualDeviceConfigSpecOperationRemove,
 		})
+		removedCdroms = append(removedCdroms, cdrom)
+	}
+
+	// Delete the ISO files of the config drives of the removed CD-ROMs. The VM
+	// is powered off, so the files are not in use.
+	if err := deleteConfigDriveFiles(vmCtx, vimClient, removedCdroms); err != nil {
+		return nil, err
 	}
 
 	// Ensure all CD-ROM devices are assigned to controllers with proper slots.
@@ -122,6 +155,7 @@ func UpdateCdromDeviceChanges(
 func UpdateConfigSpecCdromDeviceConnection(
 	vmCtx pkgctx.VirtualMachineContext,
 	restClient *rest.Client,
+	vimClient *vim25.Client,
 	k8sClient ctrlclient.Client,
 	config *vimtypes.VirtualMachineConfigInfo,
 	configSpec *vimtypes.VirtualMachineConfigSpec) error {
@@ -135,12 +169,13 @@ func UpdateConfigSpecCdromDeviceConnection(
 	)
 
 	for _, specCdrom := range cdromSpec {
-		imageRef := specCdrom.Image
 		// Sync the content library file if needed to connect the CD-ROM device.
+		// The VM is powered on, so a config drive that is in use by the VM is
+		// not regenerated.
 		syncFile := ptr.Deref(specCdrom.Connected)
-		bFileName, err := getBackingFileNameByImageRef(vmCtx, libManager, k8sClient, syncFile, imageRef)
+		bFileName, err := getBackingFileName(vmCtx, libManager, vimClient, k8sClient, specCdrom, syncFile, false)
 		if err != nil {
-			return fmt.Errorf("error getting backing file name by image ref %s: %w", imageRef, err)
+			return err
 		}
 		cdrom, err := getCdromByBackingFileName(bFileName, curDevices)
 		if err != nil {
@@ -151,7 +186,7 @@ func UpdateConfigSpecCdromDeviceConnection(
 			// This could happen if the VM spec has a new CD-ROM device, or the
 			// existing CD-ROM device's backing has been changed. The former
 			// situation should be denied by the VM validating webhook.
-			return fmt.Errorf("no CD-ROM is found for image ref %s", imageRef)
+			return fmt.Errorf("no CD-ROM is found for backing file name %s", bFileName)
 		}
 
 		backingFileNameToCdromSpec[bFileName] = specCdrom
@@ -167,6 +202,45 @@ func UpdateConfigSpecCdromDeviceConnection(
 	return nil
 }
 
+// getBackingFileName returns the name of the file used as the backing for the
+// given CD-ROM. When overwriteConfigDrive is false, a config drive is only
+// generated if its file does not already exist.
+func getBackingFileName(
+	vmCtx pkgctx.VirtualMachineContext,
+	libManager *library.Manager,
+	vimClient *vim25.Client,
+	k8sClient ctrlclient.Client,
+	specCdrom vmopv1.VirtualMachineCdromSpec,
+	syncFile bool,
+	overwriteConfigDrive bool) (string, error) {
+
+	switch {
+	case specCdrom.Image != nil:
+		imageRef := *specCdrom.Image
+		bFileName, err := getBackingFileNameByImageRef(vmCtx, libManager, k8sClient, syncFile, imageRef)
+		if err != nil {
+			return "", fmt.Errorf("error getting backing file name by image ref %s: %w", imageRef, err)
+		}
+		return bFileName, nil
+	case specCdrom.PersistentVolumeClaim != nil:
+		claimName := specCdrom.PersistentVolumeClaim.ClaimName
+		bFileName, err := getBackingFileNameByPVC(vmCtx, vimClient, k8sClient, claimName)
+		if err != nil {
+			return "", fmt.Errorf("error getting backing file name by PVC %s: %w", claimName, err)
+		}
+		return bFileName, nil
+	case specCdrom.ConfigDrive != nil:
+		bFileName, err := getBackingFileNameByConfigDrive(
+			vmCtx, vimClient, k8sClient, specCdrom.Name, *specCdrom.ConfigDrive, overwriteConfigDrive)
+		if err != nil {
+			return "", fmt.Errorf("error getting backing file name by config drive %s: %w", specCdrom.Name, err)
+		}
+		return bFileName, nil
+	default:
+		return "", fmt.Errorf("no backing specified for CD-ROM %s", specCdrom.Name)
+	}
+}
+
 // getBackingFileNameByImageRef returns the ISO type content library file name
 // based on the given VirtualMachineImageRef. It also syncs the content library
 // if needed to ensure the file is available for CD-ROM connection.
@@ -275,6 +349,387 @@ func processImage(
 	return string(clitem.Spec.UUID), clitem.Status, nil
 }
 
+// getBackingFileNameByPVC returns the name of the file that contains the data
+// of the given PVC's volume. The volume is a First Class Disk (FCD) that was
+// populated by writing an ISO image to it as a raw block device, and the ISO
+// image is read from the disk's flat extent. The flat extent is found with the
+// datastore browser, as it only exists on datastores that store disks as files,
+// ex. VMFS or NFS, but not vSAN or vVols.
+func getBackingFileNameByPVC(
+	vmCtx pkgctx.VirtualMachineContext,
+	vimClient *vim25.Client,
+	k8sClient ctrlclient.Client,
+	claimName string) (string, error) {
+
+	var pvc corev1.PersistentVolumeClaim
+	if err := k8sClient.Get(vmCtx, ctrlclient.ObjectKey{Name: claimName, Namespace: vmCtx.VM.Namespace}, &pvc); err != nil {
+		return "", err
+	}
+	if pvc.Status.Phase != corev1.ClaimBound || pvc.Spec.VolumeName == "" {
+		return "", fmt.Errorf("claim is not bound: %s", pvc.Status.Phase)
+	}
+	if pvc.Spec.VolumeMode == nil || *pvc.Spec.VolumeMode != corev1.PersistentVolumeBlock {
+		return "", fmt.Errorf("claim does not have volume mode %s", corev1.PersistentVolumeBlock)
+	}
+
+	var pv corev1.PersistentVolume
+	if err := k8sClient.Get(vmCtx, ctrlclient.ObjectKey{Name: pvc.Spec.VolumeName}, &pv); err != nil {
+		return "", err
+	}
+	if pv.Spec.CSI == nil || pv.Spec.CSI.VolumeHandle == "" {
+		return "", fmt.Errorf("volume %s is not a CSI volume", pv.Name)
+	}
+	volumeHandle := pv.Spec.CSI.VolumeHandle
+
+	datastores, err := getHostDatastores(vmCtx, vimClient)
+	if err != nil {
+		return "", err
+	}
+
+	m := vslm.NewObjectManager(vimClient)
+	for _, ds := range datastores {
+		obj, err := m.Retrieve(vmCtx, ds.Self, volumeHandle)
+		if err != nil {
+			if fault.Is(err, &vimtypes.NotFound{}) {
+				continue
+			}
+			return "", fmt.Errorf("error retrieving volume %s: %w", volumeHandle, err)
+		}
+
+		switch t := vimtypes.HostFileSystemVolumeFileSystemType(ds.Summary.Type); t {
+		case vimtypes.HostFileSystemVolumeFileSystemTypeVsan, vimtypes.HostFileSystemVolumeFileSystemTypeVVOL:
+			return "", fmt.Errorf("volume %s is on a %s datastore, "+
+				"only volumes on datastores that store disks as files may be used as the backing of a CD-ROM", volumeHandle, t)
+		}
+
+		backing, ok := obj.Config.Backing.(*vimtypes.BaseConfigInfoDiskFileBackingInfo)
+		if !ok || backing.FilePath == "" {
+			return "", fmt.Errorf("volume %s does not have a disk file backing", volumeHandle)
+		}
+
+		return getFlatExtentFileName(vmCtx, object.NewDatastore(vimClient, ds.Self), volumeHandle, backing.FilePath)
+	}
+
+	return "", fmt.Errorf("volume %s is not found on the datastores of the VM's host", volumeHandle)
+}
+
+// getFlatExtentFileName returns the name of the flat extent of the given disk
+// file. An error is returned if the disk does not have a flat extent.
+func getFlatExtentFileName(
+	vmCtx pkgctx.VirtualMachineContext,
+	ds *object.Datastore,
+	volumeHandle string,
+	diskFileName string) (string, error) {
+
+	var diskPath object.DatastorePath
+	if !diskPath.FromString(diskFileName) {
+		return "", fmt.Errorf("invalid disk path %q of volume %s", diskFileName, volumeHandle)
+	}
+	dirPath := object.DatastorePath{
+		Datastore: diskPath.Datastore,
+		Path:      path.Dir(diskPath.Path),
+	}
+	flatExtentName := strings.TrimSuffix(path.Base(diskPath.Path), ".vmdk") + "-flat.vmdk"
+
+	browser, err := ds.Browser(vmCtx)
+	if err != nil {
+		return "", err
+	}
+
+	task, err := browser.SearchDatastore(vmCtx, dirPath.String(), &vimtypes.HostDatastoreBrowserSearchSpec{
+		MatchPattern: []string{flatExtentName},
+	})
+	if err != nil {
+		return "", err
+	}
+	info, err := task.WaitForResult(vmCtx)
+	if err != nil {
+		return "", fmt.Errorf("error searching directory %s: %w", dirPath, err)
+	}
+
+	if res, ok := info.Result.(vimtypes.HostDatastoreBrowserSearchResults); ok {
+		for _, f := range res.File {
+			if f.GetFileInfo().Path == flatExtentName {
+				p := object.DatastorePath{
+					Datastore: dirPath.Datastore,
+					Path:      path.Join(dirPath.Path, flatExtentName),
+				}
+				return p.String(), nil
+			}
+		}
+	}
+
+	return "", fmt.Errorf("volume %s does not have a flat extent, "+
+		"only volumes on datastores that store disks as files may be used as the backing of a CD-ROM", volumeHandle)
+}
+
+// getBackingFileNameByConfigDrive returns the name of the config drive's ISO
+// file in the VM's directory. The ISO image is generated from the config
+// drive's sources and uploaded if overwrite is true or the file does not
+// already exist.
+func getBackingFileNameByConfigDrive(
+	vmCtx pkgctx.VirtualMachineContext,
+	vimClient *vim25.Client,
+	k8sClient ctrlclient.Client,
+	cdromName string,
+	configDrive vmopv1.VirtualMachineCdromConfigDriveSource,
+	overwrite bool) (string, error) {
+
+	vmDir, err := getVMDirectory(vmCtx)
+	if err != nil {
+		return "", err
+	}
+
+	isoPath := object.DatastorePath{
+		Datastore: vmDir.Datastore,
+		Path:      path.Join(vmDir.Path, cdromName+configDriveFileNameSuffix),
+	}
+
+	ds, err := getHostDatastoreByName(vmCtx, vimClient, isoPath.Datastore)
+	if err != nil {
+		return "", err
+	}
+
+	if !overwrite {
+		_, err := ds.Stat(vmCtx, isoPath.Path)
+		if err == nil {
+			return isoPath.String(), nil
+		}
+		if !errors.As(err, &object.DatastoreNoSuchFileError{}) {
+			return "", fmt.Errorf("error getting file %s: %w", isoPath, err)
+		}
+	}
+
+	files, err := getConfigDriveFiles(vmCtx, k8sClient, vmCtx.VM.Namespace, configDrive)
+	if err != nil {
+		return "", err
+	}
+
+	volumeLabel := configDrive.VolumeLabel
+	if volumeLabel == "" {
+		volumeLabel = defaultConfigDriveVolumeLabel
+	}
+
+	img, err := iso9660.Build(volumeLabel, files)
+	if err != nil {
+		return "", fmt.Errorf("error generating ISO image: %w", err)
+	}
+
+	upload := soap.DefaultUpload
+	upload.ContentLength = int64(len(img))
+
+	vmCtx.Logger.V(4).Info("Uploading config drive", "path", isoPath.String(), "size", len(img))
+	if err := ds.Upload(vmCtx, bytes.NewReader(img), isoPath.Path, &upload); err != nil {
+		return "", fmt.Errorf("error uploading file %s: %w", isoPath, err)
+	}
+
+	return isoPath.String(), nil
+}
+
+// deleteConfigDriveFiles deletes the ISO files of the config drives that back
+// the given CD-ROM devices. The files contain the data of the config drives'
+// sources, so they are not left on the datastore once they are no longer
+// used.
+func deleteConfigDriveFiles(
+	vmCtx pkgctx.VirtualMachineContext,
+	vimClient *vim25.Client,
+	devices object.VirtualDeviceList) error {
+
+	var isoPaths []object.DatastorePath
+	for _, d := range devices.SelectByType((*vimtypes.VirtualCdrom)(nil)) {
+		b, ok := d.(*vimtypes.VirtualCdrom).Backing.(*vimtypes.VirtualCdromIsoBackingInfo)
+		if !ok {
+			continue
+		}
+
+		var isoPath object.DatastorePath
+		if isoPath.FromString(b.FileName) && strings.HasSuffix(isoPath.Path, configDriveFileNameSuffix) {
+			isoPaths = append(isoPaths, isoPath)
+		}
+	}
+	if len(isoPaths) == 0 {
+		return nil
+	}
+
+	vmDir, err := getVMDirectory(vmCtx)
+	if err != nil {
+		return err
+	}
+
+	for _, isoPath := range isoPaths {
+		if isoPath.Datastore != vmDir.Datastore || path.Dir(isoPath.Path) != vmDir.Path {
+			continue
+		}
+
+		ds, err := getHostDatastoreByName(vmCtx, vimClient, isoPath.Datastore)
+		if err != nil {
+			return err
+		}
+		dc, err := find.NewFinder(vimClient).Datacenter(vmCtx, ds.DatacenterPath)
+		if err != nil {
+			return fmt.Errorf("error getting datacenter of datastore %s: %w", ds.Name(), err)
+		}
+
+		vmCtx.Logger.V(4).Info("Deleting config drive", "path", isoPath.String())
+		if err := ds.NewFileManager(dc, false).DeleteFile(vmCtx, isoPath.Path); err != nil &&
+			!fault.Is(err, &vimtypes.FileNotFound{}) {
+
+			return fmt.Errorf("error deleting file %s: %w", isoPath, err)
+		}
+	}
+
+	return nil
+}
+
+// getConfigDriveFiles returns the files written to a config drive from the
+// data of its ConfigMap and Secret sources. Like a projected volume, each key
+// is written to a file of the same name unless the source's items map the key
+// to another path.
+func getConfigDriveFiles(
+	ctx context.Context,
+	client ctrlclient.Client,
+	namespace string,
+	configDrive vmopv1.VirtualMachineCdromConfigDriveSource) ([]iso9660.File, error) {
+
+	var files []iso9660.File
+
+	for _, src := range configDrive.Sources {
+		var (
+			kind     string
+			key      ctrlclient.ObjectKey
+			items    []corev1.KeyToPath
+			optional bool
+			data     = map[string][]byte{}
+		)
+
+		switch {
+		case src.ConfigMap != nil:
+			kind = "ConfigMap"
+			key = ctrlclient.ObjectKey{Name: src.ConfigMap.Name, Namespace: namespace}
+			items, optional = src.ConfigMap.Items, ptr.Deref(src.ConfigMap.Optional)
+
+			var obj corev1.ConfigMap
+			if err := client.Get(ctx, key, &obj); err != nil {
+				if apierrors.IsNotFound(err) && optional {
+					continue
+				}
+				return nil, err
+			}
+			for k, v := range obj.Data {
+				data[k] = []byte(v)
+			}
+			for k, v := range obj.BinaryData {
+				data[k] = v
+			}
+		case src.Secret != nil:
+			kind = "Secret"
+			key = ctrlclient.ObjectKey{Name: src.Secret.Name, Namespace: namespace}
+			items, optional = src.Secret.Items, ptr.Deref(src.Secret.Optional)
+
+			var obj corev1.Secret
+			if err := client.Get(ctx, key, &obj); err != nil {
+				if apierrors.IsNotFound(err) && optional {
+					continue
+				}
+				return nil, err
+			}
+			data = obj.Data
+		default:
+			continue
+		}
+
+		if len(items) == 0 {
+			for _, k := range slices.Sorted(maps.Keys(data)) {
+				files = append(files, iso9660.File{Path: k, Data: data[k]})
+			}
+			continue
+		}
+
+		for _, item := range items {
+			v, ok := data[item.Key]
+			if !ok {
+				if optional {
+					continue
+				}
+				return nil, fmt.Errorf("key %s is not found in %s %s", item.Key, kind, key.Name)
+			}
+			files = append(files, iso9660.File{Path: item.Path, Data: v})
+		}
+	}
+
+	return files, nil
+}
+
+// getVMDirectory returns the path of the VM's directory.
+func getVMDirectory(vmCtx pkgctx.VirtualMachineContext) (object.DatastorePath, error) {
+	if vmCtx.MoVM.Config == nil {
+		return object.DatastorePath{}, errors.New("VM config is not available")
+	}
+
+	var vmPathName object.DatastorePath
+	if !vmPathName.FromString(vmCtx.MoVM.Config.Files.VmPathName) {
+		return object.DatastorePath{}, fmt.Errorf("invalid VM path name %q", vmCtx.MoVM.Config.Files.VmPathName)
+	}
+
+	return object.DatastorePath{
+		Datastore: vmPathName.Datastore,
+		Path:      path.Dir(vmPathName.Path),
+	}, nil
+}
+
+// getHostDatastoreByName returns the datastore with the given name that is
+// mounted on the VM's host.
+func getHostDatastoreByName(
+	vmCtx pkgctx.VirtualMachineContext,
+	vimClient *vim25.Client,
+	name string) (*object.Datastore, error) {
+
+	datastores, err := getHostDatastores(vmCtx, vimClient)
+	if err != nil {
+		return nil, err
+	}
+	i := slices.IndexFunc(datastores, func(ds mo.Datastore) bool {
+		return ds.Name == name
+	})
+	if i < 0 {
+		return nil, fmt.Errorf("datastore %s is not found on the VM's host", name)
+	}
+	ds := object.NewDatastore(vimClient, datastores[i].Self)
+	if err := ds.FindInventoryPath(vmCtx); err != nil {
+		return nil, fmt.Errorf("error getting inventory path of datastore %s: %w", name, err)
+	}
+
+	return ds, nil
+}
+
+// getHostDatastores returns the datastores mounted on the VM's host.
+func getHostDatastores(
+	vmCtx pkgctx.VirtualMachineContext,
+	vimClient *vim25.Client) ([]mo.Datastore, error) {
+
+	if vmCtx.MoVM.Runtime.Host == nil {
+		return nil, errors.New("VM host is not available")
+	}
+
+	var (
+		moHost mo.HostSystem
+		pc     = property.DefaultCollector(vimClient)
+	)
+
+	if err := pc.RetrieveOne(vmCtx, *vmCtx.MoVM.Runtime.Host, []string{"datastore"}, &moHost); err != nil {
+		return nil, fmt.Errorf("error getting datastores of host %s: %w", vmCtx.MoVM.Runtime.Host.Value, err)
+	}
+
+	var datastores []mo.Datastore
+	if len(moHost.Datastore) > 0 {
+		if err := pc.Retrieve(vmCtx, moHost.Datastore, []string{"name", "summary.type"}, &datastores); err != nil {
+			return nil, fmt.Errorf("error getting datastore names: %w", err)
+		}
+	}
+
+	return datastores, nil
+}
+
 // getCdromByBackingFileName returns the CD-ROM device from the current devices
 // by matching the given backing file name.
 func getCdromByBackingFileName(
@@ -5,16 +5,23 @@
 package virtualmachine_test
 
 import (
+	"io"
 	"path"
+	"strings"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
 	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/simulator"
 	"github.com/vmware/govmomi/vapi/library"
 	"github.com/vmware/govmomi/vapi/rest"
+	"github.com/vmware/govmomi/vim25"
 	"github.com/vmware/govmomi/vim25/mo"
 	vimtypes "github.com/vmware/govmomi/vim25/types"
+	"github.com/vmware/govmomi/vslm"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
 
 	imgregv1a1 "github.com/vmware-tanzu/image-registry-operator-api/api/v1alpha1"
@@ -58,6 +65,7 @@ func cdromTests() {
 			ctx        *builder.TestContextForVCSim
 			vmCtx      pkgctx.VirtualMachineContext
 			restClient *rest.Client
+			vimClient  *vim25.Client
 			k8sClient  ctrlclient.Client
 			curDevices object.VirtualDeviceList
 		)
@@ -69,6 +77,7 @@ func cdromTests() {
 			vcClient, err := pkgclient.NewClient(ctx, ctx.VCClientConfig)
 			Expect(err).ToNot(HaveOccurred())
 			restClient = vcClient.RestClient()
+			vimClient = vcClient.VimClient()
 
 			vmCtx = pkgctx.VirtualMachineContext{
 				Context: ctx,
@@ -89,7 +98,7 @@ func cdromTests() {
 			})
 
 			JustBeforeEach(func() {
-				result, resultErr = virtualmachine.UpdateCdromDeviceChanges(vmCtx, restClient, k8sClient, curDevices)
+				result, resultErr = virtualmachine.UpdateCdromDeviceChanges(vmCtx, restClient, vimClient, k8sClient, curDevices)
 				Expect(resultErr).ToNot(HaveOccurred())
 			})
 
@@ -110,7 +119,7 @@ func cdromTests() {
 					vmCtx.VM.Spec.Cdrom = []vmopv1.VirtualMachineCdromSpec{
 						{
 							Name: cdromName1,
-							Image: vmopv1.VirtualMachineImageRef{
+							Image: &vmopv1.VirtualMachineImageRef{
 								Name: vmiName,
 								Kind: vmiKind,
 							},
@@ -242,7 +251,7 @@ func cdromTests() {
 					vmCtx.VM.Spec.Cdrom = []vmopv1.VirtualMachineCdromSpec{
 						{
 							Name: cdromName1,
-							Image: vmopv1.VirtualMachineImageRef{
+							Image: &vmopv1.VirtualMachineImageRef{
 								Name: vmiName,
 								Kind: vmiKind,
 							},
@@ -251,7 +260,7 @@ func cdromTests() {
 						},
 						{
 							Name: cdromName2,
-							Image: vmopv1.VirtualMachineImageRef{
+							Image: &vmopv1.VirtualMachineImageRef{
 								Name: cvmiName,
 								Kind: cvmiKind,
 							},
@@ -306,14 +315,14 @@ func cdromTests() {
 					vmCtx.VM.Spec.Cdrom = []vmopv1.VirtualMachineCdromSpec{
 						{
 							Name: cdromName1,
-							Image: vmopv1.VirtualMachineImageRef{
+							Image: &vmopv1.VirtualMachineImageRef{
 								Name: vmiName,
 								Kind: vmiKind,
 							},
 						},
 						{
 							Name: cdromName2,
-							Image: vmopv1.VirtualMachineImageRef{
+							Image: &vmopv1.VirtualMachineImageRef{
 								Name: cvmiName,
 								Kind: cvmiKind,
 							},
@@ -386,7 +395,7 @@ func cdromTests() {
 					vmCtx.VM.Spec.Cdrom = []vmopv1.VirtualMachineCdromSpec{
 						{
 							Name: cdromName1,
-							Image: vmopv1.VirtualMachineImageRef{
+							Image: &vmopv1.VirtualMachineImageRef{
 								Name: vmiName,
 								Kind: vmiKind,
 							},
@@ -433,7 +442,7 @@ func cdromTests() {
 					vmCtx.VM.Spec.Cdrom = []vmopv1.VirtualMachineCdromSpec{
 						{
 							Name: cdromName2,
-							Image: vmopv1.VirtualMachineImageRef{
+							Image: &vmopv1.VirtualMachineImageRef{
 								Name: vmiName,
 								Kind: vmiKind,
 							},
@@ -477,7 +486,7 @@ func cdromTests() {
 						{
 							// CD-ROM to be added with a new backing image.
 							Name: cdromName2,
-							Image: vmopv1.VirtualMachineImageRef{
+							Image: &vmopv1.VirtualMachineImageRef{
 								Name: cvmiName,
 								Kind: cvmiKind,
 							},
@@ -540,7 +549,7 @@ func cdromTests() {
 			JustBeforeEach(func() {
 				k8sClient = builder.NewFakeClient(k8sInitObjs...)
 
-				result, resultErr = virtualmachine.UpdateCdromDeviceChanges(vmCtx, restClient, k8sClient, curDevices)
+				result, resultErr = virtualmachine.UpdateCdromDeviceChanges(vmCtx, restClient, vimClient, k8sClient, curDevices)
 				Expect(resultErr).To(HaveOccurred())
 			})
 
@@ -550,7 +559,7 @@ func cdromTests() {
 					vmCtx.VM.Spec.Cdrom = []vmopv1.VirtualMachineCdromSpec{
 						{
 							Name: cdromName1,
-							Image: vmopv1.VirtualMachineImageRef{
+							Image: &vmopv1.VirtualMachineImageRef{
 								Name: "non-existent-vmi",
 								Kind: vmiKind,
 							},
@@ -573,7 +582,7 @@ func cdromTests() {
 					vmCtx.VM.Spec.Cdrom = []vmopv1.VirtualMachineCdromSpec{
 						{
 							Name: cdromName1,
-							Image: vmopv1.VirtualMachineImageRef{
+							Image: &vmopv1.VirtualMachineImageRef{
 								Name: vmiName,
 								Kind: vmiKind,
 							},
@@ -596,7 +605,7 @@ func cdromTests() {
 					vmCtx.VM.Spec.Cdrom = []vmopv1.VirtualMachineCdromSpec{
 						{
 							Name: cdromName1,
-							Image: vmopv1.VirtualMachineImageRef{
+							Image: &vmopv1.VirtualMachineImageRef{
 								Name: vmiName,
 								Kind: vmiKind,
 							},
@@ -619,7 +628,7 @@ func cdromTests() {
 					vmCtx.VM.Spec.Cdrom = []vmopv1.VirtualMachineCdromSpec{
 						{
 							Name: cdromName1,
-							Image: vmopv1.VirtualMachineImageRef{
+							Image: &vmopv1.VirtualMachineImageRef{
 								Name: vmiName,
 								Kind: vmiKind,
 							},
@@ -642,7 +651,7 @@ func cdromTests() {
 					vmCtx.VM.Spec.Cdrom = []vmopv1.VirtualMachineCdromSpec{
 						{
 							Name: cdromName1,
-							Image: vmopv1.VirtualMachineImageRef{
+							Image: &vmopv1.VirtualMachineImageRef{
 								Name: vmiName,
 								Kind: vmiKind,
 							},
@@ -663,7 +672,7 @@ func cdromTests() {
 					vmCtx.VM.Spec.Cdrom = []vmopv1.VirtualMachineCdromSpec{
 						{
 							Name: cdromName1,
-							Image: vmopv1.VirtualMachineImageRef{
+							Image: &vmopv1.VirtualMachineImageRef{
 								Name: "non-existent-cvmi",
 								Kind: cvmiKind,
 							},
@@ -686,7 +695,7 @@ func cdromTests() {
 					vmCtx.VM.Spec.Cdrom = []vmopv1.VirtualMachineCdromSpec{
 						{
 							Name: cdromName1,
-							Image: vmopv1.VirtualMachineImageRef{
+							Image: &vmopv1.VirtualMachineImageRef{
 								Name: cvmiName,
 								Kind: cvmiKind,
 							},
@@ -709,7 +718,7 @@ func cdromTests() {
 					vmCtx.VM.Spec.Cdrom = []vmopv1.VirtualMachineCdromSpec{
 						{
 							Name: cdromName1,
-							Image: vmopv1.VirtualMachineImageRef{
+							Image: &vmopv1.VirtualMachineImageRef{
 								Name: cvmiName,
 								Kind: cvmiKind,
 							},
@@ -732,7 +741,7 @@ func cdromTests() {
 					vmCtx.VM.Spec.Cdrom = []vmopv1.VirtualMachineCdromSpec{
 						{
 							Name: cdromName1,
-							Image: vmopv1.VirtualMachineImageRef{
+							Image: &vmopv1.VirtualMachineImageRef{
 								Name: cvmiName,
 								Kind: cvmiKind,
 							},
@@ -755,7 +764,7 @@ func cdromTests() {
 					vmCtx.VM.Spec.Cdrom = []vmopv1.VirtualMachineCdromSpec{
 						{
 							Name: cdromName1,
-							Image: vmopv1.VirtualMachineImageRef{
+							Image: &vmopv1.VirtualMachineImageRef{
 								Name: cvmiName,
 								Kind: cvmiKind,
 							},
@@ -776,7 +785,7 @@ func cdromTests() {
 					vmCtx.VM.Spec.Cdrom = []vmopv1.VirtualMachineCdromSpec{
 						{
 							Name: cdromName1,
-							Image: vmopv1.VirtualMachineImageRef{
+							Image: &vmopv1.VirtualMachineImageRef{
 								Name: vmiName,
 								Kind: "invalid-kind",
 							},
@@ -799,7 +808,7 @@ func cdromTests() {
 					vmCtx.VM.Spec.Cdrom = []vmopv1.VirtualMachineCdromSpec{
 						{
 							Name: cdromName1,
-							Image: vmopv1.VirtualMachineImageRef{
+							Image: &vmopv1.VirtualMachineImageRef{
 								Name: vmiName,
 								Kind: vmiKind,
 							},
@@ -822,7 +831,7 @@ func cdromTests() {
 					vmCtx.VM.Spec.Cdrom = []vmopv1.VirtualMachineCdromSpec{
 						{
 							Name: cdromName1,
-							Image: vmopv1.VirtualMachineImageRef{
+							Image: &vmopv1.VirtualMachineImageRef{
 								Name: vmiName,
 								Kind: vmiKind,
 							},
@@ -885,7 +894,7 @@ func cdromTests() {
 					vmCtx.VM.Spec.Cdrom = []vmopv1.VirtualMachineCdromSpec{
 						{
 							Name: cdromName1,
-							Image: vmopv1.VirtualMachineImageRef{
+							Image: &vmopv1.VirtualMachineImageRef{
 								Name: vmiName,
 								Kind: vmiKind,
 							},
@@ -914,7 +923,7 @@ func cdromTests() {
 					vmCtx.VM.Spec.Cdrom = []vmopv1.VirtualMachineCdromSpec{
 						{
 							Name: cdromName1,
-							Image: vmopv1.VirtualMachineImageRef{
+							Image: &vmopv1.VirtualMachineImageRef{
 								Name: vmiName,
 								Kind: vmiKind,
 							},
@@ -960,7 +969,7 @@ func cdromTests() {
 					vmCtx.VM.Spec.Cdrom = []vmopv1.VirtualMachineCdromSpec{
 						{
 							Name: cdromName1,
-							Image: vmopv1.VirtualMachineImageRef{
+							Image: &vmopv1.VirtualMachineImageRef{
 								Name: vmiName,
 								Kind: vmiKind,
 							},
@@ -1042,6 +1051,7 @@ func cdromTests() {
 			ctx        *builder.TestContextForVCSim
 			vmCtx      pkgctx.VirtualMachineContext
 			restClient *rest.Client
+			vimClient  *vim25.Client
 			k8sClient  ctrlclient.Client
 			configInfo *vimtypes.VirtualMachineConfigInfo
 			configSpec *vimtypes.VirtualMachineConfigSpec
@@ -1056,6 +1066,7 @@ func cdromTests() {
 			vcClient, err := pkgclient.NewClient(ctx, ctx.VCClientConfig)
 			Expect(err).ToNot(HaveOccurred())
 			restClient = vcClient.RestClient()
+			vimClient = vcClient.VimClient()
 
 			vmCtx = pkgctx.VirtualMachineContext{
 				Context: pkgcfg.NewContext(),
@@ -1068,7 +1079,7 @@ func cdromTests() {
 		})
 
 		JustBeforeEach(func() {
-			updateErr = virtualmachine.UpdateConfigSpecCdromDeviceConnection(vmCtx, restClient, k8sClient, configInfo, configSpec)
+			updateErr = virtualmachine.UpdateConfigSpecCdromDeviceConnection(vmCtx, restClient, vimClient, k8sClient, configInfo, configSpec)
 		})
 
 		Context("Happy Path (no error occurs)", func() {
@@ -1109,7 +1120,7 @@ func cdromTests() {
 					vmCtx.VM.Spec.Cdrom = []vmopv1.VirtualMachineCdromSpec{
 						{
 							Name: cdromName1,
-							Image: vmopv1.VirtualMachineImageRef{
+							Image: &vmopv1.VirtualMachineImageRef{
 								Name: vmiName,
 								Kind: vmiKind,
 							},
@@ -1157,7 +1168,7 @@ func cdromTests() {
 					vmCtx.VM.Spec.Cdrom = []vmopv1.VirtualMachineCdromSpec{
 						{
 							Name: cdromName1,
-							Image: vmopv1.VirtualMachineImageRef{
+							Image: &vmopv1.VirtualMachineImageRef{
 								Name: vmiName,
 								Kind: vmiKind,
 							},
@@ -1183,7 +1194,7 @@ func cdromTests() {
 			JustBeforeEach(func() {
 				k8sClient = builder.NewFakeClient(k8sInitObjs...)
 
-				updateErr = virtualmachine.UpdateConfigSpecCdromDeviceConnection(vmCtx, restClient, k8sClient, configInfo, configSpec)
+				updateErr = virtualmachine.UpdateConfigSpecCdromDeviceConnection(vmCtx, restClient, vimClient, k8sClient, configInfo, configSpec)
 			})
 
 			// These test cases are similar to those in UpdateCdromDeviceChanges.
@@ -1194,7 +1205,7 @@ func cdromTests() {
 					vmCtx.VM.Spec.Cdrom = []vmopv1.VirtualMachineCdromSpec{
 						{
 							Name: cdromName1,
-							Image: vmopv1.VirtualMachineImageRef{
+							Image: &vmopv1.VirtualMachineImageRef{
 								Name: "non-existent-vmi",
 								Kind: vmiKind,
 							},
@@ -1245,7 +1256,7 @@ func cdromTests() {
 					vmCtx.VM.Spec.Cdrom = []vmopv1.VirtualMachineCdromSpec{
 						{
 							Name: cdromName1,
-							Image: vmopv1.VirtualMachineImageRef{
+							Image: &vmopv1.VirtualMachineImageRef{
 								Name: vmiName,
 								Kind: vmiKind,
 							},
@@ -1268,7 +1279,7 @@ func cdromTests() {
 					vmCtx.VM.Spec.Cdrom = []vmopv1.VirtualMachineCdromSpec{
 						{
 							Name: cdromName1,
-							Image: vmopv1.VirtualMachineImageRef{
+							Image: &vmopv1.VirtualMachineImageRef{
 								Name: vmiName,
 								Kind: vmiKind,
 							},
@@ -1279,11 +1290,349 @@ func cdromTests() {
 				})
 
 				It("should return an error", func() {
-					Expect(updateErr.Error()).To(ContainSubstring("no CD-ROM is found for image ref"))
+					Expect(updateErr.Error()).To(ContainSubstring("no CD-ROM is found for backing file name"))
 				})
 			})
 		})
 	})
+
+	Context("PVC and config drive backings", func() {
+
+		const (
+			claimName  = "iso-pvc"
+			volumeName = "iso-pv"
+			configName = "my-config"
+			secretName = "my-secret"
+		)
+
+		var (
+			ctx         *builder.TestContextForVCSim
+			vmCtx       pkgctx.VirtualMachineContext
+			restClient  *rest.Client
+			vimClient   *vim25.Client
+			k8sClient   ctrlclient.Client
+			k8sInitObjs []ctrlclient.Object
+			datastore   *object.Datastore
+			isoPath     object.DatastorePath
+		)
+
+		BeforeEach(func() {
+			ctx = suite.NewTestContextForVCSim(builder.VCSimTestConfig{})
+			restClient = ctx.RestClient
+			vimClient = ctx.VCClient.Client
+
+			vcVM, err := ctx.Finder.VirtualMachine(ctx, "DC0_C0_RP0_VM0")
+			Expect(err).ToNot(HaveOccurred())
+
+			datastore, err = ctx.Finder.Datastore(ctx, "LocalDS_0")
+			Expect(err).ToNot(HaveOccurred())
+
+			vmCtx = pkgctx.VirtualMachineContext{
+				Context: ctx,
+				Logger:  suite.GetLogger(),
+				VM:      builder.DummyBasicVirtualMachine(vmName, ns),
+			}
+			Expect(vcVM.Properties(ctx, vcVM.Reference(), []string{"config", "runtime"}, &vmCtx.MoVM)).To(Succeed())
+
+			var vmPathName object.DatastorePath
+			Expect(vmPathName.FromString(vmCtx.MoVM.Config.Files.VmPathName)).To(BeTrue())
+			isoPath = object.DatastorePath{
+				Datastore: vmPathName.Datastore,
+				Path:      path.Join(path.Dir(vmPathName.Path), cdromName1+"-config-drive.iso"),
+			}
+
+			k8sInitObjs = []ctrlclient.Object{
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Name: configName, Namespace: ns},
+					Data: map[string]string{
+						"user-data": "#cloud-config\n",
+						"meta-data": "instance-id: test-vm\n",
+					},
+				},
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: secretName, Namespace: ns},
+					Data: map[string][]byte{
+						"password": []byte("secret"),
+					},
+				},
+			}
+		})
+
+		JustBeforeEach(func() {
+			k8sClient = builder.NewFakeClient(k8sInitObjs...)
+		})
+
+		AfterEach(func() {
+			ctx.AfterEach()
+			ctx = nil
+		})
+
+		downloadISO := func() []byte {
+			r, _, err := datastore.Download(ctx, isoPath.Path, nil)
+			Expect(err).ToNot(HaveOccurred())
+			defer r.Close()
+			b, err := io.ReadAll(r)
+			Expect(err).ToNot(HaveOccurred())
+			return b
+		}
+
+		When("CD-ROM is backed by a PVC", func() {
+			var volumeID string
+
+			BeforeEach(func() {
+				task, err := vslm.NewObjectManager(ctx.VCClient.Client).CreateDisk(ctx, vimtypes.VslmCreateSpec{
+					Name:         "iso-disk",
+					CapacityInMB: 10,
+					BackingSpec: &vimtypes.VslmCreateSpecDiskFileBackingSpec{
+						VslmCreateSpecBackingSpec: vimtypes.VslmCreateSpecBackingSpec{
+							Datastore: datastore.Reference(),
+						},
+					},
+				})
+				Expect(err).ToNot(HaveOccurred())
+				res, err := task.WaitForResult(ctx)
+				Expect(err).ToNot(HaveOccurred())
+				volumeID = res.Result.(vimtypes.VStorageObject).Config.Id.Id
+
+				vmCtx.VM.Spec.Cdrom = []vmopv1.VirtualMachineCdromSpec{
+					{
+						Name: cdromName1,
+						PersistentVolumeClaim: &vmopv1.VirtualMachineCdromPersistentVolumeClaimSource{
+							ClaimName: claimName,
+						},
+						AllowGuestControl: ptr.To(true),
+						Connected:         ptr.To(true),
+					},
+				}
+			})
+
+			When("PVC is bound", func() {
+				BeforeEach(func() {
+					k8sInitObjs = append(k8sInitObjs,
+						&corev1.PersistentVolumeClaim{
+							ObjectMeta: metav1.ObjectMeta{Name: claimName, Namespace: ns},
+							Spec: corev1.PersistentVolumeClaimSpec{
+								VolumeName: volumeName,
+								VolumeMode: ptr.To(corev1.PersistentVolumeBlock),
+							},
+							Status: corev1.PersistentVolumeClaimStatus{Phase: corev1.ClaimBound},
+						},
+						&corev1.PersistentVolume{
+							ObjectMeta: metav1.ObjectMeta{Name: volumeName},
+							Spec: corev1.PersistentVolumeSpec{
+								PersistentVolumeSource: corev1.PersistentVolumeSource{
+									CSI: &corev1.CSIPersistentVolumeSource{
+										Driver:       "csi.vsphere.vmware.com",
+										VolumeHandle: volumeID,
+									},
+								},
+							},
+						},
+					)
+				})
+
+				It("should add a CD-ROM backed by the flat extent of the volume's disk", func() {
+					result, err := virtualmachine.UpdateCdromDeviceChanges(vmCtx, restClient, vimClient, k8sClient, object.VirtualDeviceList{})
+					Expect(err).ToNot(HaveOccurred())
+					Expect(result).To(HaveLen(3))
+
+					cdrom := findCdromDevice(result)
+					Expect(cdrom).ToNot(BeNil())
+					fileName := cdrom.Backing.(*vimtypes.VirtualCdromIsoBackingInfo).FileName
+					Expect(fileName).To(HavePrefix("[LocalDS_0] "))
+					Expect(fileName).To(HaveSuffix("-flat.vmdk"))
+				})
+
+				When("PVC has volume mode Filesystem", func() {
+					JustBeforeEach(func() {
+						pvc := &corev1.PersistentVolumeClaim{}
+						Expect(k8sClient.Get(ctx, ctrlclient.ObjectKey{Name: claimName, Namespace: ns}, pvc)).To(Succeed())
+						pvc.Spec.VolumeMode = ptr.To(corev1.PersistentVolumeFilesystem)
+						Expect(k8sClient.Update(ctx, pvc)).To(Succeed())
+					})
+
+					It("should return an error", func() {
+						_, err := virtualmachine.UpdateCdromDeviceChanges(vmCtx, restClient, vimClient, k8sClient, object.VirtualDeviceList{})
+						Expect(err).To(MatchError(ContainSubstring("claim does not have volume mode Block")))
+					})
+				})
+
+				When("the volume is on a vSAN datastore", func() {
+					JustBeforeEach(func() {
+						simulator.Map.WithLock(
+							simulator.SpoofContext(),
+							datastore.Reference(),
+							func() {
+								ds := simulator.Map.Get(datastore.Reference()).(*simulator.Datastore)
+								ds.Summary.Type = string(vimtypes.HostFileSystemVolumeFileSystemTypeVsan)
+							})
+					})
+
+					It("should return an error", func() {
+						_, err := virtualmachine.UpdateCdromDeviceChanges(vmCtx, restClient, vimClient, k8sClient, object.VirtualDeviceList{})
+						Expect(err).To(MatchError(ContainSubstring("is on a vsan datastore")))
+					})
+				})
+
+				When("the volume's disk does not have a flat extent", func() {
+					JustBeforeEach(func() {
+						obj, err := vslm.NewObjectManager(vimClient).Retrieve(ctx, datastore.Reference(), volumeID)
+						Expect(err).ToNot(HaveOccurred())
+						backing := obj.Config.Backing.(*vimtypes.BaseConfigInfoDiskFileBackingInfo)
+						var flatPath object.DatastorePath
+						Expect(flatPath.FromString(backing.FilePath)).To(BeTrue())
+						flatPath.Path = strings.TrimSuffix(flatPath.Path, ".vmdk") + "-flat.vmdk"
+						Expect(datastore.NewFileManager(ctx.Datacenter, false).DeleteFile(ctx, flatPath.Path)).To(Succeed())
+					})
+
+					It("should return an error", func() {
+						_, err := virtualmachine.UpdateCdromDeviceChanges(vmCtx, restClient, vimClient, k8sClient, object.VirtualDeviceList{})
+						Expect(err).To(MatchError(ContainSubstring("does not have a flat extent")))
+					})
+				})
+			})
+
+			When("PVC is not bound", func() {
+				BeforeEach(func() {
+					k8sInitObjs = append(k8sInitObjs,
+						&corev1.PersistentVolumeClaim{
+							ObjectMeta: metav1.ObjectMeta{Name: claimName, Namespace: ns},
+							Status:     corev1.PersistentVolumeClaimStatus{Phase: corev1.ClaimPending},
+						},
+					)
+				})
+
+				It("should return an error", func() {
+					_, err := virtualmachine.UpdateCdromDeviceChanges(vmCtx, restClient, vimClient, k8sClient, object.VirtualDeviceList{})
+					Expect(err).To(MatchError(ContainSubstring("error getting backing file name by PVC iso-pvc: claim is not bound")))
+				})
+			})
+		})
+
+		When("CD-ROM is backed by a config drive", func() {
+			BeforeEach(func() {
+				vmCtx.VM.Spec.Cdrom = []vmopv1.VirtualMachineCdromSpec{
+					{
+						Name: cdromName1,
+						ConfigDrive: &vmopv1.VirtualMachineCdromConfigDriveSource{
+							VolumeLabel: "cidata",
+							Sources: []vmopv1.VirtualMachineCdromConfigDriveProjection{
+								{
+									ConfigMap: &corev1.ConfigMapProjection{
+										LocalObjectReference: corev1.LocalObjectReference{Name: configName},
+									},
+								},
+								{
+									Secret: &corev1.SecretProjection{
+										LocalObjectReference: corev1.LocalObjectReference{Name: secretName},
+										Items: []corev1.KeyToPath{
+											{Key: "password", Path: "secrets/password"},
+										},
+									},
+								},
+							},
+						},
+						AllowGuestControl: ptr.To(true),
+						Connected:         ptr.To(true),
+					},
+				}
+			})
+
+			It("should upload the ISO image and add a CD-ROM backed by it", func() {
+				result, err := virtualmachine.UpdateCdromDeviceChanges(vmCtx, restClient, vimClient, k8sClient, object.VirtualDeviceList{})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result).To(HaveLen(3))
+
+				cdrom := findCdromDevice(result)
+				Expect(cdrom).ToNot(BeNil())
+				Expect(cdrom.Connectable.Connected).To(BeTrue())
+				Expect(cdrom.Backing).To(BeAssignableToTypeOf(&vimtypes.VirtualCdromIsoBackingInfo{}))
+				Expect(cdrom.Backing.(*vimtypes.VirtualCdromIsoBackingInfo).FileName).To(Equal(isoPath.String()))
+
+				img := downloadISO()
+				Expect(string(img[16*2048+1 : 16*2048+6])).To(Equal("CD001"))
+				Expect(img).To(ContainSubstring("#cloud-config"))
+				Expect(img).To(ContainSubstring("instance-id: test-vm"))
+				Expect(img).To(ContainSubstring("secret"))
+			})
+
+			When("the VM is powered on", func() {
+				It("should not regenerate an existing ISO image", func() {
+					result, err := virtualmachine.UpdateCdromDeviceChanges(vmCtx, restClient, vimClient, k8sClient, object.VirtualDeviceList{})
+					Expect(err).ToNot(HaveOccurred())
+					img := downloadISO()
+
+					cm := &corev1.ConfigMap{}
+					Expect(k8sClient.Get(ctx, ctrlclient.ObjectKey{Name: configName, Namespace: ns}, cm)).To(Succeed())
+					cm.Data["user-data"] = "#cloud-config\nhostname: updated\n"
+					Expect(k8sClient.Update(ctx, cm)).To(Succeed())
+
+					configInfo := &vimtypes.VirtualMachineConfigInfo{}
+					configInfo.Hardware.Device = []vimtypes.BaseVirtualDevice{
+						findCdromDevice(result),
+					}
+					configSpec := &vimtypes.VirtualMachineConfigSpec{}
+					Expect(virtualmachine.UpdateConfigSpecCdromDeviceConnection(vmCtx, restClient, vimClient, k8sClient, configInfo, configSpec)).To(Succeed())
+					Expect(configSpec.DeviceChange).To(BeEmpty())
+
+					Expect(downloadISO()).To(Equal(img))
+				})
+			})
+
+			When("the CD-ROM is removed", func() {
+				It("should delete the ISO image", func() {
+					result, err := virtualmachine.UpdateCdromDeviceChanges(vmCtx, restClient, vimClient, k8sClient, object.VirtualDeviceList{})
+					Expect(err).ToNot(HaveOccurred())
+					_, err = datastore.Stat(ctx, isoPath.Path)
+					Expect(err).ToNot(HaveOccurred())
+
+					vmCtx.VM.Spec.Cdrom = nil
+					curDevices := object.VirtualDeviceList{findCdromDevice(result)}
+					result, err = virtualmachine.UpdateCdromDeviceChanges(vmCtx, restClient, vimClient, k8sClient, curDevices)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(result).To(HaveLen(1))
+					Expect(result[0].GetVirtualDeviceConfigSpec().Operation).To(Equal(vimtypes.VirtualDeviceConfigSpecOperationRemove))
+
+					_, err = datastore.Stat(ctx, isoPath.Path)
+					Expect(err).To(BeAssignableToTypeOf(object.DatastoreNoSuchFileError{}))
+				})
+			})
+
+			When("a key is missing from a source", func() {
+				BeforeEach(func() {
+					vmCtx.VM.Spec.Cdrom[0].ConfigDrive.Sources[1].Secret.Items[0].Key = "missing"
+				})
+
+				It("should return an error", func() {
+					_, err := virtualmachine.UpdateCdromDeviceChanges(vmCtx, restClient, vimClient, k8sClient, object.VirtualDeviceList{})
+					Expect(err).To(MatchError(ContainSubstring("key missing is not found in Secret my-secret")))
+				})
+			})
+
+			When("an optional source does not exist", func() {
+				BeforeEach(func() {
+					vmCtx.VM.Spec.Cdrom[0].ConfigDrive.Sources[1].Secret.Name = "missing"
+					vmCtx.VM.Spec.Cdrom[0].ConfigDrive.Sources[1].Secret.Optional = ptr.To(true)
+				})
+
+				It("should skip the source", func() {
+					_, err := virtualmachine.UpdateCdromDeviceChanges(vmCtx, restClient, vimClient, k8sClient, object.VirtualDeviceList{})
+					Expect(err).ToNot(HaveOccurred())
+					Expect(downloadISO()).ToNot(ContainSubstring("secret"))
+				})
+			})
+		})
+	})
+}
+
+// findCdromDevice returns the CD-ROM device from the given device changes.
+func findCdromDevice(deviceChanges []vimtypes.BaseVirtualDeviceConfigSpec) *vimtypes.VirtualCdrom {
+	for _, dc := range deviceChanges {
+		if cdrom, ok := dc.GetVirtualDeviceConfigSpec().Device.(*vimtypes.VirtualCdrom); ok {
+			return cdrom
+		}
+	}
+	return nil
 }
 
 // verifyCdromDeviceConfigSpec is a helper function to verify the given device
@@ -89,8 +89,16 @@ func CreateConfigSpec(
 		configSpec.Firmware = vmImageStatus.Firmware
 	}
 
-	if advanced := vmCtx.VM.Spec.Advanced; advanced != nil && advanced.ChangeBlockTracking != nil {
-		configSpec.ChangeTrackingEnabled = advanced.ChangeBlockTracking
+	if advanced := vmCtx.VM.Spec.Advanced; advanced != nil {
+		if advanced.ChangeBlockTracking != nil {
+			configSpec.ChangeTrackingEnabled = advanced.ChangeBlockTracking
+		}
+		if advanced.CPUHotAddEnabled != nil {
+			configSpec.CpuHotAddEnabled = advanced.CPUHotAddEnabled
+		}
+		if advanced.MemoryHotAddEnabled != nil {
+			configSpec.MemoryHotAddEnabled = advanced.MemoryHotAddEnabled
+		}
 	}
 
 	// Populate the CPU reservation and limits in the ConfigSpec if VAPI fields specify any.
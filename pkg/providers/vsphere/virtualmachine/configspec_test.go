@@ -400,6 +400,21 @@ var _ = Describe("CreateConfigSpec", func() {
 			})
 		})
 
+		When("VM spec has CPU and memory hot-add set", func() {
+			BeforeEach(func() {
+				classConfigSpec.CpuHotAddEnabled = ptr.To(false)
+				classConfigSpec.MemoryHotAddEnabled = ptr.To(true)
+				vm.Spec.Advanced = &vmopv1.VirtualMachineAdvancedSpec{
+					CPUHotAddEnabled: ptr.To(true),
+				}
+			})
+
+			It("config spec has the VM spec values take precedence over the class", func() {
+				Expect(configSpec.CpuHotAddEnabled).To(HaveValue(BeTrue()))
+				Expect(configSpec.MemoryHotAddEnabled).To(HaveValue(BeTrue()))
+			})
+		})
+
 		When("VM Class has reserved profile ID", func() {
 			BeforeEach(func() {
 				vmClassSpec.ReservedProfileID = "my-profile-id"
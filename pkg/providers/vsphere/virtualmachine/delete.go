@@ -34,9 +34,14 @@ func DeleteVirtualMachine(
 	if err := vcVM.Properties(
 		vmCtx,
 		vcVM.Reference(),
-		[]string{"config.extraConfig"}, &vmCtx.MoVM); err != nil {
+		[]string{
+			"config.extraConfig",
+			"config.files",
+			"config.hardware.device",
+			"runtime.host",
+		}, &vmCtx.MoVM); err != nil {
 
-		vmCtx.Logger.Error(err, "failed to fetch config.extraConfig properties of VM for DeleteVirtualMachine")
+		vmCtx.Logger.Error(err, "failed to fetch config properties of VM for DeleteVirtualMachine")
 		return err
 	}
 	// Throw an error to distinguish from successful deletion.
@@ -48,14 +53,26 @@ func DeleteVirtualMachine(
 		return ErrorVMPausedByAdmin()
 	}
 	if _, err := vmutil.SetAndWaitOnPowerState(
-		logr.NewContext(vmCtx, vmCtx.Logger),
+		WithPowerOffOptions(logr.NewContext(vmCtx, vmCtx.Logger), vmCtx.VM),
 		vcVM.Client(),
 		vmutil.ManagedObjectFromObject(vcVM),
 		false,
 		vimtypes.VirtualMachinePowerStatePoweredOff,
 		vmutil.ParsePowerOpMode(string(vmCtx.VM.Spec.PowerOffMode))); err != nil {
 
-		return err
+		return RequeueOnPreStopHook(err)
+	}
+
+	// The ISO files of config drives are not deleted along with the VM, so
+	// delete them once the VM is powered off.
+	if vmCtx.MoVM.Config != nil {
+		if err := deleteConfigDriveFiles(
+			vmCtx,
+			vcVM.Client(),
+			vmCtx.MoVM.Config.Hardware.Device); err != nil {
+
+			return err
+		}
 	}
 
 	t, err := vcVM.Destroy(vmCtx)
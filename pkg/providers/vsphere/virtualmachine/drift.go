@@ -0,0 +1,304 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachine
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	vimtypes "github.com/vmware/govmomi/vim25/types"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	pkgutil "github.com/vmware-tanzu/vm-operator/pkg/util"
+)
+
+const (
+	// DriftFieldNumCPUs is the name of the drifted field for the VM's number
+	// of CPUs.
+	DriftFieldNumCPUs = "numCPUs"
+
+	// DriftFieldMemoryMB is the name of the drifted field for the VM's
+	// memory.
+	DriftFieldMemoryMB = "memoryMB"
+
+	// DriftFieldNetworkInterfaces is the name of the drifted field for the
+	// VM's network interfaces.
+	DriftFieldNetworkInterfaces = "network.interfaces"
+
+	// DriftFieldCrypto is the name of the drifted field for the VM's
+	// encryption key.
+	DriftFieldCrypto = "crypto"
+
+	driftFieldExtraConfigPrefix       = "extraConfig["
+	driftFieldVolumesPrefix           = "volumes["
+	driftFieldNetworkInterfacesPrefix = "network.interfaces["
+)
+
+// DriftArgs describes the configuration implied by the VM's spec and class
+// against which the VM's configuration in vSphere is compared.
+type DriftArgs struct {
+	// ClassConfigSpec is the ConfigSpec implied by the VM's class. The number
+	// of CPUs, memory, and ExtraConfig are only compared when this is not nil.
+	ClassConfigSpec *vimtypes.VirtualMachineConfigSpec
+
+	// CryptoKey is the encryption key implied by the VM's encryption class.
+	// The VM's encryption key is only compared when this is not nil. When the
+	// key ID is empty, only the key provider is compared.
+	CryptoKey *vimtypes.CryptoKeyId
+}
+
+// DetectDrift returns the fields of the VM's configuration in vSphere whose
+// values differ from the ones implied by the VM's spec and class. Changes
+// that are pending because they cannot be applied while the VM is powered on
+// are not considered drift.
+func DetectDrift(
+	vm vmopv1.VirtualMachine,
+	config vimtypes.VirtualMachineConfigInfo,
+	args DriftArgs) []vmopv1.VirtualMachineDriftedField {
+
+	var fields []vmopv1.VirtualMachineDriftedField
+
+	add := func(field, current, desired string) {
+		if current == desired {
+			return
+		}
+		if vm.Status.Resize != nil &&
+			slices.ContainsFunc(vm.Status.Resize.PendingChanges, func(c vmopv1.VirtualMachineResizePendingChange) bool {
+				return c.Field == field
+			}) {
+			return
+		}
+		fields = append(fields, vmopv1.VirtualMachineDriftedField{
+			Field:   field,
+			Current: current,
+			Desired: desired,
+		})
+	}
+
+	if cs := args.ClassConfigSpec; cs != nil {
+		if cs.NumCPUs != 0 {
+			add(DriftFieldNumCPUs,
+				strconv.FormatInt(int64(config.Hardware.NumCPU), 10),
+				strconv.FormatInt(int64(cs.NumCPUs), 10))
+		}
+		if cs.MemoryMB != 0 {
+			add(DriftFieldMemoryMB,
+				strconv.FormatInt(int64(config.Hardware.MemoryMB), 10),
+				strconv.FormatInt(cs.MemoryMB, 10))
+		}
+
+		curExtraConfig := pkgutil.OptionValues(config.ExtraConfig).StringMap()
+		desiredExtraConfig := pkgutil.OptionValues(cs.ExtraConfig).StringMap()
+		keys := make([]string, 0, len(desiredExtraConfig))
+		for k := range desiredExtraConfig {
+			keys = append(keys, k)
+		}
+		slices.Sort(keys)
+		for _, k := range keys {
+			add(ExtraConfigDriftField(k), curExtraConfig[k], desiredExtraConfig[k])
+		}
+	}
+
+	var (
+		ethCards  []*vimtypes.VirtualEthernetCard
+		diskUUIDs = map[string]struct{}{}
+	)
+	for _, bd := range config.Hardware.Device {
+		switch d := bd.(type) {
+		case vimtypes.BaseVirtualEthernetCard:
+			ethCards = append(ethCards, d.GetVirtualEthernetCard())
+		case *vimtypes.VirtualDisk:
+			if b, ok := d.Backing.(vimtypes.BaseVirtualDeviceFileBackingInfo); ok {
+				if uuid := diskBackingUUID(b); uuid != "" {
+					diskUUIDs[uuid] = struct{}{}
+				}
+			}
+		}
+	}
+
+	if n := vm.Spec.Network; n != nil && !n.Disabled {
+		add(DriftFieldNetworkInterfaces,
+			strconv.Itoa(len(ethCards)),
+			strconv.Itoa(len(n.Interfaces)))
+
+		// Compare the network adapter of each interface to the one reported
+		// in the status. The device key of the adapter changes when it is
+		// replaced, ex. to change its backing, while the MAC address is only
+		// compared when it is reported.
+		var ifaces []vmopv1.VirtualMachineNetworkInterfaceStatus
+		if vm.Status.Network != nil {
+			ifaces = vm.Status.Network.Interfaces
+		}
+		for _, iface := range ifaces {
+			if iface.Name == "" || iface.DeviceKey == 0 {
+				continue
+			}
+			var desiredMAC string
+			if iface.IP != nil {
+				desiredMAC = iface.IP.MACAddr
+			}
+			var current string
+			if card := getEthCard(ethCards, iface.DeviceKey, desiredMAC); card != nil {
+				current = ethCardString(card.Key, card.MacAddress, desiredMAC != "")
+			}
+			add(NetworkInterfaceDriftField(iface.Name),
+				current,
+				ethCardString(iface.DeviceKey, desiredMAC, desiredMAC != ""))
+		}
+	}
+
+	for _, v := range vm.Status.Volumes {
+		if v.Type != vmopv1.VirtualMachineStorageDiskTypeManaged || !v.Attached || v.DiskUUID == "" {
+			continue
+		}
+		if _, ok := diskUUIDs[v.DiskUUID]; !ok {
+			add(VolumeDriftField(v.Name), "", v.DiskUUID)
+		}
+	}
+
+	if key := args.CryptoKey; key != nil {
+		var current string
+		if config.KeyId != nil {
+			current = cryptoKeyString(*config.KeyId, key.KeyId != "")
+		}
+		add(DriftFieldCrypto, current, cryptoKeyString(*key, key.KeyId != ""))
+	}
+
+	return fields
+}
+
+// ExtraConfigDriftField returns the name of the drifted field for the given
+// ExtraConfig key.
+func ExtraConfigDriftField(key string) string {
+	return driftFieldExtraConfigPrefix + key + "]"
+}
+
+// VolumeDriftField returns the name of the drifted field for the given
+// volume.
+func VolumeDriftField(name string) string {
+	return driftFieldVolumesPrefix + name + "]"
+}
+
+// NetworkInterfaceDriftField returns the name of the drifted field for the
+// given network interface.
+func NetworkInterfaceDriftField(name string) string {
+	return driftFieldNetworkInterfacesPrefix + name + "]"
+}
+
+// IsAdoptableDriftField returns true if the current value of the given
+// drifted field may be adopted. Only the fields derived from the VM's class,
+// i.e. the number of CPUs, memory, and ExtraConfig, may be adopted.
+func IsAdoptableDriftField(field string) bool {
+	return field == DriftFieldNumCPUs ||
+		field == DriftFieldMemoryMB ||
+		strings.HasPrefix(field, driftFieldExtraConfigPrefix)
+}
+
+// FilterAdoptedDrift returns the drifted fields that have not been adopted,
+// and the adopted fields that still apply. An adopted field no longer applies
+// when either its current or desired value has changed since it was adopted.
+func FilterAdoptedDrift(
+	fields, adopted []vmopv1.VirtualMachineDriftedField) (
+	[]vmopv1.VirtualMachineDriftedField, []vmopv1.VirtualMachineDriftedField) {
+
+	var notAdopted, stillAdopted []vmopv1.VirtualMachineDriftedField
+	for _, f := range fields {
+		if slices.Contains(adopted, f) {
+			stillAdopted = append(stillAdopted, f)
+		} else {
+			notAdopted = append(notAdopted, f)
+		}
+	}
+	return notAdopted, stillAdopted
+}
+
+// DriftRevertConfigSpec returns the ConfigSpec that reverts the given drifted
+// fields to the values implied by the VM's class. The network interfaces,
+// volumes, and encryption key are not included as they are reconciled along
+// with the rest of the VM's spec.
+func DriftRevertConfigSpec(
+	fields []vmopv1.VirtualMachineDriftedField,
+	classConfigSpec *vimtypes.VirtualMachineConfigSpec) vimtypes.VirtualMachineConfigSpec {
+
+	var configSpec vimtypes.VirtualMachineConfigSpec
+	if classConfigSpec == nil {
+		return configSpec
+	}
+
+	desiredExtraConfig := pkgutil.OptionValues(classConfigSpec.ExtraConfig)
+
+	for _, f := range fields {
+		switch {
+		case f.Field == DriftFieldNumCPUs:
+			configSpec.NumCPUs = classConfigSpec.NumCPUs
+		case f.Field == DriftFieldMemoryMB:
+			configSpec.MemoryMB = classConfigSpec.MemoryMB
+		case strings.HasPrefix(f.Field, driftFieldExtraConfigPrefix):
+			key := strings.TrimSuffix(strings.TrimPrefix(f.Field, driftFieldExtraConfigPrefix), "]")
+			if v, ok := desiredExtraConfig.Get(key); ok {
+				configSpec.ExtraConfig = append(configSpec.ExtraConfig,
+					&vimtypes.OptionValue{Key: key, Value: v})
+			}
+		}
+	}
+
+	return configSpec
+}
+
+func diskBackingUUID(b vimtypes.BaseVirtualDeviceFileBackingInfo) string {
+	switch tb := b.(type) {
+	case *vimtypes.VirtualDiskFlatVer2BackingInfo:
+		return tb.Uuid
+	case *vimtypes.VirtualDiskSeSparseBackingInfo:
+		return tb.Uuid
+	case *vimtypes.VirtualDiskRawDiskMappingVer1BackingInfo:
+		return tb.Uuid
+	case *vimtypes.VirtualDiskSparseVer2BackingInfo:
+		return tb.Uuid
+	}
+	return ""
+}
+
+func cryptoKeyString(key vimtypes.CryptoKeyId, withKeyID bool) string {
+	var providerID string
+	if key.ProviderId != nil {
+		providerID = key.ProviderId.Id
+	}
+	if !withKeyID {
+		return providerID
+	}
+	return fmt.Sprintf("%s/%s", providerID, key.KeyId)
+}
+
+// getEthCard returns the Ethernet card with the given device key, or when
+// there is no such card, the one with the given MAC address.
+func getEthCard(
+	ethCards []*vimtypes.VirtualEthernetCard,
+	deviceKey int32,
+	macAddr string) *vimtypes.VirtualEthernetCard {
+
+	for _, c := range ethCards {
+		if c.Key == deviceKey {
+			return c
+		}
+	}
+	if macAddr != "" {
+		for _, c := range ethCards {
+			if strings.EqualFold(c.MacAddress, macAddr) {
+				return c
+			}
+		}
+	}
+	return nil
+}
+
+func ethCardString(deviceKey int32, macAddr string, withMACAddr bool) string {
+	if !withMACAddr {
+		return strconv.Itoa(int(deviceKey))
+	}
+	return fmt.Sprintf("%d/%s", deviceKey, strings.ToLower(macAddr))
+}
@@ -0,0 +1,275 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachine_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	vimtypes "github.com/vmware/govmomi/vim25/types"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/virtualmachine"
+)
+
+var _ = Describe("Drift", func() {
+
+	var (
+		vm     vmopv1.VirtualMachine
+		config vimtypes.VirtualMachineConfigInfo
+		args   virtualmachine.DriftArgs
+	)
+
+	BeforeEach(func() {
+		vm = vmopv1.VirtualMachine{}
+		config = vimtypes.VirtualMachineConfigInfo{
+			Hardware: vimtypes.VirtualHardware{
+				NumCPU:   2,
+				MemoryMB: 4096,
+				Device: []vimtypes.BaseVirtualDevice{
+					&vimtypes.VirtualVmxnet3{},
+					&vimtypes.VirtualDisk{
+						VirtualDevice: vimtypes.VirtualDevice{
+							Backing: &vimtypes.VirtualDiskFlatVer2BackingInfo{
+								Uuid: "my-disk-uuid",
+							},
+						},
+					},
+				},
+			},
+			ExtraConfig: []vimtypes.BaseOptionValue{
+				&vimtypes.OptionValue{Key: "key1", Value: "val1"},
+			},
+		}
+		args = virtualmachine.DriftArgs{
+			ClassConfigSpec: &vimtypes.VirtualMachineConfigSpec{
+				NumCPUs:  2,
+				MemoryMB: 4096,
+				ExtraConfig: []vimtypes.BaseOptionValue{
+					&vimtypes.OptionValue{Key: "key1", Value: "val1"},
+				},
+			},
+		}
+	})
+
+	Context("DetectDrift", func() {
+
+		It("returns nothing when the VM has not drifted", func() {
+			Expect(virtualmachine.DetectDrift(vm, config, args)).To(BeEmpty())
+		})
+
+		It("returns the drifted CPU, memory, and ExtraConfig", func() {
+			config.Hardware.NumCPU = 4
+			config.Hardware.MemoryMB = 8192
+			config.ExtraConfig = nil
+
+			Expect(virtualmachine.DetectDrift(vm, config, args)).To(Equal([]vmopv1.VirtualMachineDriftedField{
+				{Field: "numCPUs", Current: "4", Desired: "2"},
+				{Field: "memoryMB", Current: "8192", Desired: "4096"},
+				{Field: "extraConfig[key1]", Current: "", Desired: "val1"},
+			}))
+		})
+
+		It("does not compare the class when there is no class ConfigSpec", func() {
+			config.Hardware.NumCPU = 4
+			args.ClassConfigSpec = nil
+			Expect(virtualmachine.DetectDrift(vm, config, args)).To(BeEmpty())
+		})
+
+		It("does not return pending resize changes", func() {
+			config.Hardware.NumCPU = 4
+			vm.Status.Resize = &vmopv1.VirtualMachineResizeStatus{
+				PendingChanges: []vmopv1.VirtualMachineResizePendingChange{
+					{Field: "numCPUs", Reason: vmopv1.VirtualMachineResizePendingReasonDecrease},
+				},
+			}
+			Expect(virtualmachine.DetectDrift(vm, config, args)).To(BeEmpty())
+		})
+
+		It("returns drifted network interfaces", func() {
+			vm.Spec.Network = &vmopv1.VirtualMachineNetworkSpec{
+				Interfaces: []vmopv1.VirtualMachineNetworkInterfaceSpec{
+					{Name: "eth0"},
+					{Name: "eth1"},
+				},
+			}
+			Expect(virtualmachine.DetectDrift(vm, config, args)).To(Equal([]vmopv1.VirtualMachineDriftedField{
+				{Field: "network.interfaces", Current: "1", Desired: "2"},
+			}))
+
+			vm.Spec.Network.Disabled = true
+			Expect(virtualmachine.DetectDrift(vm, config, args)).To(BeEmpty())
+		})
+
+		It("returns network interfaces whose network adapters differ from the status", func() {
+			config.Hardware.Device = append(config.Hardware.Device,
+				&vimtypes.VirtualE1000{
+					VirtualEthernetCard: vimtypes.VirtualEthernetCard{
+						VirtualDevice: vimtypes.VirtualDevice{Key: 4001},
+						MacAddress:    "00:50:56:00:00:02",
+					},
+				})
+			config.Hardware.Device[0].(*vimtypes.VirtualVmxnet3).Key = 4000
+			config.Hardware.Device[0].(*vimtypes.VirtualVmxnet3).MacAddress = "00:50:56:00:00:01"
+
+			vm.Spec.Network = &vmopv1.VirtualMachineNetworkSpec{
+				Interfaces: []vmopv1.VirtualMachineNetworkInterfaceSpec{
+					{Name: "eth0"},
+					{Name: "eth1"},
+				},
+			}
+			vm.Status.Network = &vmopv1.VirtualMachineNetworkStatus{
+				Interfaces: []vmopv1.VirtualMachineNetworkInterfaceStatus{
+					{
+						Name:      "eth0",
+						DeviceKey: 4000,
+						IP: &vmopv1.VirtualMachineNetworkInterfaceIPStatus{
+							MACAddr: "00:50:56:00:00:01",
+						},
+					},
+					{
+						Name:      "eth1",
+						DeviceKey: 4001,
+					},
+					{
+						DeviceKey: 4002,
+					},
+				},
+			}
+			Expect(virtualmachine.DetectDrift(vm, config, args)).To(BeEmpty())
+
+			By("returning an interface whose MAC address changed", func() {
+				config.Hardware.Device[0].(*vimtypes.VirtualVmxnet3).MacAddress = "00:50:56:00:00:03"
+				Expect(virtualmachine.DetectDrift(vm, config, args)).To(Equal([]vmopv1.VirtualMachineDriftedField{
+					{Field: "network.interfaces[eth0]", Current: "4000/00:50:56:00:00:03", Desired: "4000/00:50:56:00:00:01"},
+				}))
+			})
+
+			By("returning an interface whose network adapter was replaced", func() {
+				config.Hardware.Device[0].(*vimtypes.VirtualVmxnet3).MacAddress = "00:50:56:00:00:01"
+				config.Hardware.Device[2].(*vimtypes.VirtualE1000).Key = 4003
+				Expect(virtualmachine.DetectDrift(vm, config, args)).To(Equal([]vmopv1.VirtualMachineDriftedField{
+					{Field: "network.interfaces[eth1]", Current: "", Desired: "4001"},
+				}))
+			})
+
+			By("matching the network adapter by MAC address when its device key changed", func() {
+				config.Hardware.Device[2].(*vimtypes.VirtualE1000).Key = 4001
+				config.Hardware.Device[0].(*vimtypes.VirtualVmxnet3).Key = 4004
+				Expect(virtualmachine.DetectDrift(vm, config, args)).To(Equal([]vmopv1.VirtualMachineDriftedField{
+					{Field: "network.interfaces[eth0]", Current: "4004/00:50:56:00:00:01", Desired: "4000/00:50:56:00:00:01"},
+				}))
+			})
+		})
+
+		It("returns volumes whose disks were removed", func() {
+			vm.Status.Volumes = []vmopv1.VirtualMachineVolumeStatus{
+				{
+					Name:     "my-vol",
+					Type:     vmopv1.VirtualMachineStorageDiskTypeManaged,
+					Attached: true,
+					DiskUUID: "my-disk-uuid",
+				},
+				{
+					Name:     "my-removed-vol",
+					Type:     vmopv1.VirtualMachineStorageDiskTypeManaged,
+					Attached: true,
+					DiskUUID: "my-removed-disk-uuid",
+				},
+				{
+					Name:     "my-detached-vol",
+					Type:     vmopv1.VirtualMachineStorageDiskTypeManaged,
+					DiskUUID: "my-detached-disk-uuid",
+				},
+			}
+			Expect(virtualmachine.DetectDrift(vm, config, args)).To(Equal([]vmopv1.VirtualMachineDriftedField{
+				{Field: "volumes[my-removed-vol]", Current: "", Desired: "my-removed-disk-uuid"},
+			}))
+		})
+
+		It("returns the drifted encryption key", func() {
+			args.CryptoKey = &vimtypes.CryptoKeyId{
+				KeyId:      "my-key",
+				ProviderId: &vimtypes.KeyProviderId{Id: "my-provider"},
+			}
+			Expect(virtualmachine.DetectDrift(vm, config, args)).To(Equal([]vmopv1.VirtualMachineDriftedField{
+				{Field: "crypto", Current: "", Desired: "my-provider/my-key"},
+			}))
+
+			config.KeyId = &vimtypes.CryptoKeyId{
+				KeyId:      "my-other-key",
+				ProviderId: &vimtypes.KeyProviderId{Id: "my-provider"},
+			}
+			Expect(virtualmachine.DetectDrift(vm, config, args)).To(Equal([]vmopv1.VirtualMachineDriftedField{
+				{Field: "crypto", Current: "my-provider/my-other-key", Desired: "my-provider/my-key"},
+			}))
+
+			By("only comparing the provider when the desired key ID is empty", func() {
+				args.CryptoKey.KeyId = ""
+				Expect(virtualmachine.DetectDrift(vm, config, args)).To(BeEmpty())
+			})
+		})
+	})
+
+	Context("FilterAdoptedDrift", func() {
+
+		It("returns the fields that have not been adopted", func() {
+			fields := []vmopv1.VirtualMachineDriftedField{
+				{Field: "numCPUs", Current: "4", Desired: "2"},
+				{Field: "memoryMB", Current: "8192", Desired: "4096"},
+			}
+			adopted := []vmopv1.VirtualMachineDriftedField{
+				{Field: "numCPUs", Current: "4", Desired: "2"},
+				{Field: "memoryMB", Current: "2048", Desired: "4096"},
+				{Field: "extraConfig[key1]", Current: "", Desired: "val1"},
+			}
+
+			notAdopted, stillAdopted := virtualmachine.FilterAdoptedDrift(fields, adopted)
+			Expect(notAdopted).To(Equal([]vmopv1.VirtualMachineDriftedField{
+				{Field: "memoryMB", Current: "8192", Desired: "4096"},
+			}))
+			Expect(stillAdopted).To(Equal([]vmopv1.VirtualMachineDriftedField{
+				{Field: "numCPUs", Current: "4", Desired: "2"},
+			}))
+		})
+	})
+
+	Context("IsAdoptableDriftField", func() {
+
+		It("returns true only for the fields from the VM's class", func() {
+			Expect(virtualmachine.IsAdoptableDriftField("numCPUs")).To(BeTrue())
+			Expect(virtualmachine.IsAdoptableDriftField("memoryMB")).To(BeTrue())
+			Expect(virtualmachine.IsAdoptableDriftField("extraConfig[key1]")).To(BeTrue())
+			Expect(virtualmachine.IsAdoptableDriftField("network.interfaces")).To(BeFalse())
+			Expect(virtualmachine.IsAdoptableDriftField("network.interfaces[eth0]")).To(BeFalse())
+			Expect(virtualmachine.IsAdoptableDriftField("volumes[my-vol]")).To(BeFalse())
+			Expect(virtualmachine.IsAdoptableDriftField("crypto")).To(BeFalse())
+		})
+	})
+
+	Context("DriftRevertConfigSpec", func() {
+
+		It("returns the ConfigSpec that reverts the drifted fields", func() {
+			fields := []vmopv1.VirtualMachineDriftedField{
+				{Field: "numCPUs", Current: "4", Desired: "2"},
+				{Field: "extraConfig[key1]", Current: "", Desired: "val1"},
+				{Field: "network.interfaces", Current: "1", Desired: "2"},
+			}
+			Expect(virtualmachine.DriftRevertConfigSpec(fields, args.ClassConfigSpec)).To(Equal(vimtypes.VirtualMachineConfigSpec{
+				NumCPUs: 2,
+				ExtraConfig: []vimtypes.BaseOptionValue{
+					&vimtypes.OptionValue{Key: "key1", Value: "val1"},
+				},
+			}))
+		})
+
+		It("returns an empty ConfigSpec when there is no class ConfigSpec", func() {
+			fields := []vmopv1.VirtualMachineDriftedField{
+				{Field: "numCPUs", Current: "4", Desired: "2"},
+			}
+			Expect(virtualmachine.DriftRevertConfigSpec(fields, nil)).To(BeZero())
+		})
+	})
+})
@@ -0,0 +1,135 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachine
+
+import (
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/vmware/govmomi/nfc"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/ovf"
+	"github.com/vmware/govmomi/vim25"
+	"github.com/vmware/govmomi/vim25/progress"
+	"github.com/vmware/govmomi/vim25/soap"
+	vimtypes "github.com/vmware/govmomi/vim25/types"
+
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+)
+
+// ExportOVF exports the VM as an OVF with the provided name. Each disk is
+// streamed from the export lease to writeFileFn as it is downloaded, followed
+// by the OVF descriptor that references the disks.
+func ExportOVF(
+	vmCtx pkgctx.VirtualMachineContext,
+	vcVM *object.VirtualMachine,
+	name string,
+	writeFileFn func(fileName string, r io.Reader) error) (retErr error) {
+
+	lease, err := vcVM.Export(vmCtx)
+	if err != nil {
+		return fmt.Errorf("failed to export VM: %w", err)
+	}
+
+	info, err := lease.Wait(vmCtx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to wait for export lease: %w", err)
+	}
+
+	completed := false
+	defer func() {
+		if retErr != nil && !completed {
+			if err := lease.Abort(vmCtx, nil); err != nil {
+				vmCtx.Logger.Error(err, "Failed to abort export lease")
+			}
+		}
+	}()
+
+	updater := lease.StartUpdater(vmCtx, info)
+	defer updater.Done()
+
+	cdp := vimtypes.OvfCreateDescriptorParams{
+		Name: name,
+	}
+
+	for _, item := range info.Items {
+		// Only the disks are exported, not images like ISOs that are
+		// attached to the VM.
+		if path.Ext(item.Path) != ".vmdk" {
+			continue
+		}
+
+		if !strings.HasPrefix(item.Path, name) {
+			item.Path = name + "-" + item.Path
+		}
+
+		size, err := exportFile(vmCtx, vcVM.Client(), item, writeFileFn)
+		if err != nil {
+			return fmt.Errorf("failed to export %s: %w", item.Path, err)
+		}
+
+		// The size of the exported stream optimized disk is not known
+		// until it has been downloaded.
+		item.Size = size
+		cdp.OvfFiles = append(cdp.OvfFiles, item.File())
+	}
+
+	if err := lease.Complete(vmCtx); err != nil {
+		return fmt.Errorf("failed to complete export lease: %w", err)
+	}
+	completed = true
+
+	desc, err := ovf.NewManager(vcVM.Client()).CreateDescriptor(vmCtx, vcVM, cdp)
+	if err != nil {
+		return fmt.Errorf("failed to create OVF descriptor: %w", err)
+	}
+	if len(desc.Error) > 0 {
+		return fmt.Errorf("failed to create OVF descriptor: %s", desc.Error[0].LocalizedMessage)
+	}
+
+	return writeFileFn(name+".ovf", strings.NewReader(desc.OvfDescriptor))
+}
+
+// exportFile downloads the file from the export lease and returns the number
+// of bytes that were read by writeFileFn.
+func exportFile(
+	vmCtx pkgctx.VirtualMachineContext,
+	client *vim25.Client,
+	item nfc.FileItem,
+	writeFileFn func(fileName string, r io.Reader) error) (_ int64, retErr error) {
+
+	body, _, err := client.Download(vmCtx, item.URL, &soap.DefaultDownload)
+	if err != nil {
+		return 0, err
+	}
+	defer body.Close()
+
+	// Report the progress of the download to the lease updater so the
+	// lease does not time out while the file is written.
+	pr := progress.NewReader(vmCtx, item, body, item.Size)
+	defer func() {
+		pr.Done(retErr)
+	}()
+
+	counter := &countingReader{r: pr}
+	if err := writeFileFn(item.Path, counter); err != nil {
+		return 0, err
+	}
+
+	return counter.n, nil
+}
+
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.n += int64(n)
+	return n, err
+}
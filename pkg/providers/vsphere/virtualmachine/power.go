@@ -0,0 +1,158 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachine
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/vmware/govmomi/object"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	pkgerr "github.com/vmware-tanzu/vm-operator/pkg/errors"
+	vmutil "github.com/vmware-tanzu/vm-operator/pkg/util/vsphere/vm"
+)
+
+// DefaultPreStopTimeout is how long the guest is given to run the pre-stop
+// hook's command if the hook does not specify a timeout.
+const DefaultPreStopTimeout = 60 * time.Second
+
+// WithPowerOffOptions returns a context that causes the VM to be powered off
+// in accordance with its power off timeout and pre-stop hook.
+func WithPowerOffOptions(
+	ctx context.Context,
+	vm *vmopv1.VirtualMachine) context.Context {
+
+	if s := vm.Spec.PowerOffTimeoutSeconds; s != nil {
+		ctx = vmutil.WithSoftTimeout(ctx, time.Duration(*s)*time.Second)
+	}
+
+	if hook := vm.Spec.PreStop; hook != nil && hook.Command != "" {
+		timeout := DefaultPreStopTimeout
+		if s := hook.TimeoutSeconds; s != nil {
+			timeout = time.Duration(*s) * time.Second
+		}
+		ctx = vmutil.WithPreStopHook(ctx, vmutil.PreStopHook{
+			Command: hook.Command,
+			Timeout: timeout,
+		})
+	}
+
+	return ctx
+}
+
+// UpdateGuestShutdownCondition updates the VM's GuestShutdown condition with
+// the result of powering off the VM. The condition is removed if the VM's
+// power off mode does not attempt to shut down the guest.
+func UpdateGuestShutdownCondition(
+	vm *vmopv1.VirtualMachine,
+	result vmutil.PowerOpResult,
+	err error) {
+
+	switch vmutil.ParsePowerOpMode(string(vm.Spec.PowerOffMode)) {
+	case vmutil.PowerOpBehaviorSoft, vmutil.PowerOpBehaviorTrySoft:
+	default:
+		conditions.Delete(vm, vmopv1.GuestShutdownCondition)
+		return
+	}
+
+	var hookErr vmutil.ErrPreStopHookInProgress
+
+	switch {
+	case errors.As(err, &hookErr):
+		conditions.MarkFalse(
+			vm,
+			vmopv1.GuestShutdownCondition,
+			vmopv1.GuestShutdownPreStopHookRunningReason,
+			"The guest was signaled with the pre-stop hook")
+	case err != nil:
+		conditions.MarkFalse(
+			vm,
+			vmopv1.GuestShutdownCondition,
+			vmopv1.GuestShutdownFailedReason,
+			err.Error())
+	case result == vmutil.PowerOpResultChangedSoft:
+		conditions.MarkTrue(vm, vmopv1.GuestShutdownCondition)
+	case result == vmutil.PowerOpResultChangedHard:
+		conditions.MarkFalse(
+			vm,
+			vmopv1.GuestShutdownCondition,
+			vmopv1.GuestShutdownHardPowerOffReason,
+			"The guest was not shut down gracefully, and the VM was halted")
+	}
+}
+
+// RequeueOnPreStopHook returns a RequeueError if the provided error indicates
+// the VM was not powered off because the guest is running the pre-stop hook,
+// so the VM is powered off once the hook's timeout elapses. Otherwise the
+// provided error is returned.
+func RequeueOnPreStopHook(err error) error {
+	var hookErr vmutil.ErrPreStopHookInProgress
+	if errors.As(err, &hookErr) {
+		return pkgerr.RequeueError{After: hookErr.Remaining}
+	}
+	return err
+}
+
+// ClearPreStopHook removes the signal of the pre-stop hook from a powered off
+// VM. If the VM was waiting on the pre-stop hook, then the guest powered
+// itself off, and the VM's GuestShutdown condition is marked true.
+func ClearPreStopHook(
+	vmCtx pkgctx.VirtualMachineContext,
+	vcVM *object.VirtualMachine) (bool, error) {
+
+	if vmCtx.MoVM.Config == nil {
+		return false, nil
+	}
+
+	cleared, err := vmutil.ClearPreStopHook(
+		vmCtx,
+		vcVM,
+		vmCtx.MoVM.Config.ExtraConfig)
+	if err != nil {
+		return false, err
+	}
+
+	if cleared && conditions.GetReason(vmCtx.VM, vmopv1.GuestShutdownCondition) ==
+		vmopv1.GuestShutdownPreStopHookRunningReason {
+
+		conditions.MarkTrue(vmCtx.VM, vmopv1.GuestShutdownCondition)
+	}
+
+	return cleared, nil
+}
+
+// CancelPreStopHook removes the signal of the pre-stop hook from a VM that
+// should be powered on, ex. a soft power off was cancelled while the guest
+// was running the pre-stop hook. Otherwise the stale signal would cause the
+// next power off to skip the hook. The VM's GuestShutdown condition is removed
+// if it reported the hook was running.
+func CancelPreStopHook(
+	vmCtx pkgctx.VirtualMachineContext,
+	vcVM *object.VirtualMachine) (bool, error) {
+
+	if vmCtx.MoVM.Config == nil {
+		return false, nil
+	}
+
+	cleared, err := vmutil.ClearPreStopHook(
+		vmCtx,
+		vcVM,
+		vmCtx.MoVM.Config.ExtraConfig)
+	if err != nil {
+		return false, err
+	}
+
+	if cleared && conditions.GetReason(vmCtx.VM, vmopv1.GuestShutdownCondition) ==
+		vmopv1.GuestShutdownPreStopHookRunningReason {
+
+		conditions.Delete(vmCtx.VM, vmopv1.GuestShutdownCondition)
+	}
+
+	return cleared, nil
+}
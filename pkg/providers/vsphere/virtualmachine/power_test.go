@@ -0,0 +1,126 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachine_test
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	pkgerr "github.com/vmware-tanzu/vm-operator/pkg/errors"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/virtualmachine"
+	vmutil "github.com/vmware-tanzu/vm-operator/pkg/util/vsphere/vm"
+)
+
+var _ = Describe("UpdateGuestShutdownCondition", func() {
+
+	DescribeTable("Results",
+		func(
+			powerOffMode vmopv1.VirtualMachinePowerOpMode,
+			result vmutil.PowerOpResult,
+			err error,
+			expectedCondition *metav1.Condition) {
+
+			vm := &vmopv1.VirtualMachine{
+				Spec: vmopv1.VirtualMachineSpec{
+					PowerOffMode: powerOffMode,
+				},
+			}
+			// Ensure an existing condition is replaced or removed.
+			conditions.MarkFalse(vm, vmopv1.GuestShutdownCondition, "Old", "old")
+
+			virtualmachine.UpdateGuestShutdownCondition(vm, result, err)
+
+			c := conditions.Get(vm, vmopv1.GuestShutdownCondition)
+			if expectedCondition == nil {
+				Expect(c).To(BeNil())
+				return
+			}
+			Expect(c).ToNot(BeNil())
+			Expect(c.Status).To(Equal(expectedCondition.Status))
+			Expect(c.Reason).To(Equal(expectedCondition.Reason))
+			Expect(c.Message).To(Equal(expectedCondition.Message))
+		},
+		Entry("hard power off",
+			vmopv1.VirtualMachinePowerOpModeHard,
+			vmutil.PowerOpResultChangedHard,
+			nil,
+			nil,
+		),
+		Entry("soft power off succeeded",
+			vmopv1.VirtualMachinePowerOpModeSoft,
+			vmutil.PowerOpResultChangedSoft,
+			nil,
+			&metav1.Condition{
+				Status: metav1.ConditionTrue,
+				Reason: string(metav1.ConditionTrue),
+			},
+		),
+		Entry("soft power off failed",
+			vmopv1.VirtualMachinePowerOpModeSoft,
+			vmutil.PowerOpResult(0),
+			errors.New("timed out waiting for power state poweredOff"),
+			&metav1.Condition{
+				Status:  metav1.ConditionFalse,
+				Reason:  vmopv1.GuestShutdownFailedReason,
+				Message: "timed out waiting for power state poweredOff",
+			},
+		),
+		Entry("soft power off waiting on pre-stop hook",
+			vmopv1.VirtualMachinePowerOpModeSoft,
+			vmutil.PowerOpResult(0),
+			fmt.Errorf("updating state failed with %w", vmutil.ErrPreStopHookInProgress{Remaining: time.Minute}),
+			&metav1.Condition{
+				Status:  metav1.ConditionFalse,
+				Reason:  vmopv1.GuestShutdownPreStopHookRunningReason,
+				Message: "The guest was signaled with the pre-stop hook",
+			},
+		),
+		Entry("try soft power off succeeded",
+			vmopv1.VirtualMachinePowerOpModeTrySoft,
+			vmutil.PowerOpResultChangedSoft,
+			nil,
+			&metav1.Condition{
+				Status: metav1.ConditionTrue,
+				Reason: string(metav1.ConditionTrue),
+			},
+		),
+		Entry("try soft power off fell back to hard",
+			vmopv1.VirtualMachinePowerOpModeTrySoft,
+			vmutil.PowerOpResultChangedHard,
+			nil,
+			&metav1.Condition{
+				Status:  metav1.ConditionFalse,
+				Reason:  vmopv1.GuestShutdownHardPowerOffReason,
+				Message: "The guest was not shut down gracefully, and the VM was halted",
+			},
+		),
+	)
+})
+
+var _ = Describe("RequeueOnPreStopHook", func() {
+
+	It("should return a RequeueError when waiting on the pre-stop hook", func() {
+		err := virtualmachine.RequeueOnPreStopHook(
+			vmutil.ErrPreStopHookInProgress{Remaining: time.Minute})
+		Expect(err).To(Equal(pkgerr.RequeueError{After: time.Minute}))
+	})
+
+	It("should return other errors as is", func() {
+		err := errors.New("timed out waiting for power state poweredOff")
+		Expect(virtualmachine.RequeueOnPreStopHook(err)).To(Equal(err))
+	})
+
+	It("should return nil when there is no error", func() {
+		Expect(virtualmachine.RequeueOnPreStopHook(nil)).To(Succeed())
+	})
+})
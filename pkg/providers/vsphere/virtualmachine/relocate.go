@@ -0,0 +1,146 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachine
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/pbm"
+	pbmtypes "github.com/vmware/govmomi/pbm/types"
+	"github.com/vmware/govmomi/vim25/mo"
+	vimtypes "github.com/vmware/govmomi/vim25/types"
+
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+)
+
+// GetCompatibleDatastore returns a datastore in the cluster that is compatible
+// with the storage profile. The VM's current datastore is preferred so the
+// VM's files are only moved when the current datastore is not compatible.
+func GetCompatibleDatastore(
+	vmCtx pkgctx.VirtualMachineContext,
+	vcVM *object.VirtualMachine,
+	clusterMoRef vimtypes.ManagedObjectReference,
+	storageProfileID string) (vimtypes.ManagedObjectReference, error) {
+
+	vimClient := vcVM.Client()
+
+	pc, err := pbm.NewClient(vmCtx, vimClient)
+	if err != nil {
+		return vimtypes.ManagedObjectReference{}, err
+	}
+
+	ds, err := pc.DatastoreMap(vmCtx, vimClient, clusterMoRef)
+	if err != nil {
+		return vimtypes.ManagedObjectReference{}, err
+	}
+
+	req := []pbmtypes.BasePbmPlacementRequirement{
+		&pbmtypes.PbmPlacementCapabilityProfileRequirement{
+			ProfileId: pbmtypes.PbmProfileId{UniqueId: storageProfileID},
+		},
+	}
+
+	res, err := pc.CheckRequirements(vmCtx, ds.PlacementHub, nil, req)
+	if err != nil {
+		return vimtypes.ManagedObjectReference{}, err
+	}
+
+	hubs := res.CompatibleDatastores()
+	if len(hubs) == 0 {
+		return vimtypes.ManagedObjectReference{}, fmt.Errorf(
+			"no datastore is compatible with storage policy %s", storageProfileID)
+	}
+
+	var vmPathName object.DatastorePath
+	if config := vmCtx.MoVM.Config; config != nil {
+		vmPathName.FromString(config.Files.VmPathName)
+	}
+
+	hub := hubs[0]
+	if i := slices.IndexFunc(hubs, func(h pbmtypes.PbmPlacementHub) bool {
+		return ds.Name[h.HubId] == vmPathName.Datastore
+	}); i >= 0 {
+		hub = hubs[i]
+	}
+
+	return vimtypes.ManagedObjectReference{
+		Type:  hub.HubType,
+		Value: hub.HubId,
+	}, nil
+}
+
+// GetRelocateSpecForStorage returns the spec to relocate the VM's home and
+// classic disks to the datastore and apply the storage profile to them. Disks
+// that are First Class Disks, ex. PVCs, are managed outside of the VM and are
+// not relocated.
+func GetRelocateSpecForStorage(
+	moVM mo.VirtualMachine,
+	datastore vimtypes.ManagedObjectReference,
+	storageProfileID string) vimtypes.VirtualMachineRelocateSpec {
+
+	profile := func() []vimtypes.BaseVirtualMachineProfileSpec {
+		return []vimtypes.BaseVirtualMachineProfileSpec{
+			&vimtypes.VirtualMachineDefinedProfileSpec{
+				ProfileId: storageProfileID,
+			},
+		}
+	}
+
+	spec := vimtypes.VirtualMachineRelocateSpec{
+		Datastore: &datastore,
+		Profile:   profile(),
+	}
+
+	if moVM.Config == nil {
+		return spec
+	}
+
+	for _, d := range moVM.Config.Hardware.Device {
+		disk, ok := d.(*vimtypes.VirtualDisk)
+		if !ok {
+			continue
+		}
+		if disk.VDiskId != nil && disk.VDiskId.Id != "" {
+			continue
+		}
+
+		spec.Disk = append(spec.Disk, vimtypes.VirtualMachineRelocateSpecDiskLocator{
+			DiskId:    disk.Key,
+			Datastore: datastore,
+			Profile:   profile(),
+		})
+	}
+
+	return spec
+}
+
+// RelocateStorage starts relocating the VM's home and classic disks to a
+// datastore compatible with the storage profile and applies the profile to
+// them. The relocate task is returned without waiting for it to complete.
+func RelocateStorage(
+	vmCtx pkgctx.VirtualMachineContext,
+	vcVM *object.VirtualMachine,
+	clusterMoRef vimtypes.ManagedObjectReference,
+	storageProfileID string) (*object.Task, error) {
+
+	datastore, err := GetCompatibleDatastore(vmCtx, vcVM, clusterMoRef, storageProfileID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get compatible datastore: %w", err)
+	}
+
+	spec := GetRelocateSpecForStorage(vmCtx.MoVM, datastore, storageProfileID)
+
+	vmCtx.Logger.Info("Relocating VM storage",
+		"datastore", datastore.Value, "storageProfileID", storageProfileID)
+
+	task, err := vcVM.Relocate(vmCtx, spec, vimtypes.VirtualMachineMovePriorityDefaultPriority)
+	if err != nil {
+		return nil, fmt.Errorf("failed to relocate VM: %w", err)
+	}
+
+	return task, nil
+}
@@ -0,0 +1,116 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachine_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/mo"
+	vimtypes "github.com/vmware/govmomi/vim25/types"
+
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/virtualmachine"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func relocateTests() {
+
+	const profileID = "my-profile-id"
+
+	var (
+		ctx          *builder.TestContextForVCSim
+		vcVM         *object.VirtualMachine
+		vmCtx        pkgctx.VirtualMachineContext
+		clusterMoRef vimtypes.ManagedObjectReference
+	)
+
+	BeforeEach(func() {
+		ctx = suite.NewTestContextForVCSim(builder.VCSimTestConfig{})
+
+		var err error
+		vcVM, err = ctx.Finder.VirtualMachine(ctx, "DC0_C0_RP0_VM0")
+		Expect(err).ToNot(HaveOccurred())
+
+		cluster, err := ctx.Finder.ClusterComputeResource(ctx, "DC0_C0")
+		Expect(err).ToNot(HaveOccurred())
+		clusterMoRef = cluster.Reference()
+
+		vmCtx = pkgctx.VirtualMachineContext{
+			Context: ctx,
+			Logger:  suite.GetLogger().WithValues("vmName", vcVM.Name()),
+			VM:      builder.DummyVirtualMachine(),
+		}
+		Expect(vcVM.Properties(ctx, vcVM.Reference(), []string{"config"}, &vmCtx.MoVM)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		ctx.AfterEach()
+		ctx = nil
+	})
+
+	Context("GetRelocateSpecForStorage", func() {
+		var datastore vimtypes.ManagedObjectReference
+
+		BeforeEach(func() {
+			datastore = vimtypes.ManagedObjectReference{Type: "Datastore", Value: "datastore-1"}
+		})
+
+		It("relocates the VM home and classic disks", func() {
+			spec := virtualmachine.GetRelocateSpecForStorage(vmCtx.MoVM, datastore, profileID)
+			Expect(spec.Datastore).To(HaveValue(Equal(datastore)))
+			Expect(spec.Profile).To(ConsistOf(&vimtypes.VirtualMachineDefinedProfileSpec{ProfileId: profileID}))
+
+			disks := object.VirtualDeviceList(vmCtx.MoVM.Config.Hardware.Device).SelectByType(&vimtypes.VirtualDisk{})
+			Expect(disks).ToNot(BeEmpty())
+			Expect(spec.Disk).To(HaveLen(len(disks)))
+			for i := range spec.Disk {
+				Expect(spec.Disk[i].DiskId).To(Equal(disks[i].GetVirtualDevice().Key))
+				Expect(spec.Disk[i].Datastore).To(Equal(datastore))
+				Expect(spec.Disk[i].Profile).To(ConsistOf(&vimtypes.VirtualMachineDefinedProfileSpec{ProfileId: profileID}))
+			}
+		})
+
+		It("does not relocate First Class Disks", func() {
+			for _, d := range vmCtx.MoVM.Config.Hardware.Device {
+				if disk, ok := d.(*vimtypes.VirtualDisk); ok {
+					disk.VDiskId = &vimtypes.ID{Id: "fcd-id"}
+				}
+			}
+
+			spec := virtualmachine.GetRelocateSpecForStorage(vmCtx.MoVM, datastore, profileID)
+			Expect(spec.Datastore).To(HaveValue(Equal(datastore)))
+			Expect(spec.Disk).To(BeEmpty())
+		})
+
+		It("only relocates the VM home when there is no config", func() {
+			spec := virtualmachine.GetRelocateSpecForStorage(mo.VirtualMachine{}, datastore, profileID)
+			Expect(spec.Datastore).To(HaveValue(Equal(datastore)))
+			Expect(spec.Disk).To(BeEmpty())
+		})
+	})
+
+	Context("GetCompatibleDatastore", func() {
+		It("prefers the current datastore of the VM", func() {
+			ds, err := virtualmachine.GetCompatibleDatastore(vmCtx, vcVM, clusterMoRef, profileID)
+			Expect(err).ToNot(HaveOccurred())
+
+			var moDS mo.Datastore
+			Expect(vcVM.Properties(ctx, ds, []string{"name"}, &moDS)).To(Succeed())
+			var vmPathName object.DatastorePath
+			Expect(vmPathName.FromString(vmCtx.MoVM.Config.Files.VmPathName)).To(BeTrue())
+			Expect(moDS.Name).To(Equal(vmPathName.Datastore))
+		})
+	})
+
+	Context("RelocateStorage", func() {
+		It("relocates the VM", func() {
+			task, err := virtualmachine.RelocateStorage(vmCtx, vcVM, clusterMoRef, profileID)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(task).ToNot(BeNil())
+			Expect(task.Wait(ctx)).To(Succeed())
+		})
+	})
+}
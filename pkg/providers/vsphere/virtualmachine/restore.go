@@ -0,0 +1,120 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachine
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/vmware/govmomi/object"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/serializer/yaml"
+	k8syaml "sigs.k8s.io/yaml"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	backupapi "github.com/vmware-tanzu/vm-operator/pkg/backup/api"
+	pkgutil "github.com/vmware-tanzu/vm-operator/pkg/util"
+)
+
+// GetBackupVirtualMachine returns the VM resource decoded from the backup
+// data in the given ExtraConfig, or nil if the VM has not been backed up.
+func GetBackupVirtualMachine(extraConfig pkgutil.OptionValues) (*vmopv1.VirtualMachine, error) {
+	data, _ := extraConfig.GetString(backupapi.VMResourceYAMLExtraConfigKey)
+	if data == "" {
+		return nil, nil
+	}
+
+	vmYAML, err := pkgutil.TryToDecodeBase64Gzip([]byte(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode VM resource backup: %w", err)
+	}
+
+	vm := &vmopv1.VirtualMachine{}
+	if err := k8syaml.Unmarshal([]byte(vmYAML), vm); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal VM resource backup: %w", err)
+	}
+
+	return vm, nil
+}
+
+// GetBackupAdditionalResources returns the additional resources, ex. the
+// bootstrap Secrets, decoded from the backup data in the given ExtraConfig.
+func GetBackupAdditionalResources(extraConfig pkgutil.OptionValues) ([]*unstructured.Unstructured, error) {
+	data, _ := extraConfig.GetString(backupapi.AdditionalResourcesYAMLExtraConfigKey)
+	if data == "" {
+		return nil, nil
+	}
+
+	decoded, err := pkgutil.TryToDecodeBase64Gzip([]byte(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode additional resources backup: %w", err)
+	}
+
+	var resources []*unstructured.Unstructured
+	decUnstructured := yaml.NewDecodingSerializer(unstructured.UnstructuredJSONScheme)
+	for _, resYAML := range strings.Split(decoded, "\n---\n") {
+		resYAML = strings.TrimSpace(resYAML)
+		if resYAML == "" {
+			continue
+		}
+		res := &unstructured.Unstructured{}
+		if _, _, err := decUnstructured.Decode([]byte(resYAML), nil, res); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal additional resources backup: %w", err)
+		}
+		resources = append(resources, res)
+	}
+
+	return resources, nil
+}
+
+// GetBackupPVCDiskData returns the PVC disk data decoded from the backup data
+// in the given ExtraConfig.
+func GetBackupPVCDiskData(extraConfig pkgutil.OptionValues) ([]backupapi.PVCDiskData, error) {
+	data, _ := extraConfig.GetString(backupapi.PVCDiskDataExtraConfigKey)
+	if data == "" {
+		return nil, nil
+	}
+
+	decoded, err := pkgutil.TryToDecodeBase64Gzip([]byte(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode PVC disk data backup: %w", err)
+	}
+
+	var diskData []backupapi.PVCDiskData
+	if err := json.Unmarshal([]byte(decoded), &diskData); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal PVC disk data backup: %w", err)
+	}
+
+	return diskData, nil
+}
+
+// GetDiskURLPath returns the URL of the disk with the given datastore path,
+// ex. "[datastore1] my-vm/my-disk.vmdk", in the format expected by the
+// CnsRegisterVolume API to statically register the disk as a volume.
+func GetDiskURLPath(
+	serverURL *url.URL,
+	datacenterPath, fileName string) (string, error) {
+
+	var dsPath object.DatastorePath
+	if !dsPath.FromString(fileName) || dsPath.Datastore == "" || dsPath.Path == "" {
+		return "", fmt.Errorf("invalid disk file name %q", fileName)
+	}
+
+	query := url.Values{}
+	query.Set("dcPath", strings.TrimPrefix(datacenterPath, "/"))
+	query.Set("dsName", dsPath.Datastore)
+
+	diskURL := url.URL{
+		Scheme:   "https",
+		Host:     serverURL.Host,
+		Path:     "/folder/" + dsPath.Path,
+		RawQuery: query.Encode(),
+	}
+
+	return diskURL.String(), nil
+}
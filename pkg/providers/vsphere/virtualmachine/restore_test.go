@@ -0,0 +1,124 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachine_test
+
+import (
+	"encoding/json"
+	"net/url"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	vimtypes "github.com/vmware/govmomi/vim25/types"
+	"sigs.k8s.io/yaml"
+
+	backupapi "github.com/vmware-tanzu/vm-operator/pkg/backup/api"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/virtualmachine"
+	pkgutil "github.com/vmware-tanzu/vm-operator/pkg/util"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+var _ = Describe("Restore", func() {
+
+	var (
+		extraConfig pkgutil.OptionValues
+	)
+
+	BeforeEach(func() {
+		extraConfig = nil
+	})
+
+	setExtraConfig := func(key, plainText string) {
+		encoded, err := pkgutil.EncodeGzipBase64(plainText)
+		ExpectWithOffset(1, err).ToNot(HaveOccurred())
+		extraConfig = append(extraConfig, &vimtypes.OptionValue{Key: key, Value: encoded})
+	}
+
+	Context("GetBackupVirtualMachine", func() {
+
+		It("returns nil if the VM has not been backed up", func() {
+			vm, err := virtualmachine.GetBackupVirtualMachine(extraConfig)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(vm).To(BeNil())
+		})
+
+		It("returns the backed up VM", func() {
+			backupVM := builder.DummyBasicVirtualMachine("my-vm", "my-ns")
+			backupVM.Spec.BiosUUID = "my-bios-uuid"
+			vmYAML, err := yaml.Marshal(backupVM)
+			Expect(err).ToNot(HaveOccurred())
+			setExtraConfig(backupapi.VMResourceYAMLExtraConfigKey, string(vmYAML))
+
+			vm, err := virtualmachine.GetBackupVirtualMachine(extraConfig)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(vm).ToNot(BeNil())
+			Expect(vm.Name).To(Equal("my-vm"))
+			Expect(vm.Namespace).To(Equal("my-ns"))
+			Expect(vm.Spec.BiosUUID).To(Equal("my-bios-uuid"))
+		})
+
+		It("returns an error if the backup is not valid YAML", func() {
+			setExtraConfig(backupapi.VMResourceYAMLExtraConfigKey, "metadata: [")
+
+			_, err := virtualmachine.GetBackupVirtualMachine(extraConfig)
+			Expect(err).To(MatchError(ContainSubstring("failed to unmarshal VM resource backup")))
+		})
+	})
+
+	Context("GetBackupAdditionalResources", func() {
+
+		It("returns the backed up resources", func() {
+			setExtraConfig(backupapi.AdditionalResourcesYAMLExtraConfigKey,
+				"apiVersion: v1\nkind: Secret\nmetadata:\n  name: my-secret\n"+
+					"\n---\n"+
+					"apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: my-cm\n")
+
+			resources, err := virtualmachine.GetBackupAdditionalResources(extraConfig)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resources).To(HaveLen(2))
+			Expect(resources[0].GetKind()).To(Equal("Secret"))
+			Expect(resources[0].GetName()).To(Equal("my-secret"))
+			Expect(resources[1].GetKind()).To(Equal("ConfigMap"))
+			Expect(resources[1].GetName()).To(Equal("my-cm"))
+		})
+	})
+
+	Context("GetBackupPVCDiskData", func() {
+
+		It("returns the backed up disk data", func() {
+			diskData := []backupapi.PVCDiskData{
+				{
+					FileName:    "[datastore1] my-vm/my-disk.vmdk",
+					PVCName:     "my-pvc",
+					AccessModes: []string{"ReadWriteOnce"},
+				},
+			}
+			data, err := json.Marshal(diskData)
+			Expect(err).ToNot(HaveOccurred())
+			setExtraConfig(backupapi.PVCDiskDataExtraConfigKey, string(data))
+
+			Expect(virtualmachine.GetBackupPVCDiskData(extraConfig)).To(Equal(diskData))
+		})
+	})
+
+	Context("GetDiskURLPath", func() {
+
+		var serverURL *url.URL
+
+		BeforeEach(func() {
+			serverURL = &url.URL{Scheme: "https", Host: "my-vc.local", Path: "/sdk"}
+		})
+
+		It("returns the URL of the disk", func() {
+			Expect(virtualmachine.GetDiskURLPath(serverURL, "/my-dc", "[datastore1] my-vm/my-disk.vmdk")).To(
+				Equal("https://my-vc.local/folder/my-vm/my-disk.vmdk?dcPath=my-dc&dsName=datastore1"))
+		})
+
+		It("returns an error if the file name is not a datastore path", func() {
+			_, err := virtualmachine.GetDiskURLPath(serverURL, "/my-dc", "my-vm/my-disk.vmdk")
+			Expect(err).To(MatchError(`invalid disk file name "my-vm/my-disk.vmdk"`))
+		})
+	})
+})
@@ -0,0 +1,287 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachine
+
+import (
+	"fmt"
+
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/methods"
+	"github.com/vmware/govmomi/vim25/mo"
+	vimtypes "github.com/vmware/govmomi/vim25/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	vmopv1common "github.com/vmware-tanzu/vm-operator/api/v1alpha3/common"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/paused"
+)
+
+// SnapshotArgs contains the options used to take a snapshot of a VM.
+type SnapshotArgs struct {
+	Name        string
+	Description string
+	Memory      bool
+	Quiesce     bool
+}
+
+// FindSnapshot returns the node from the VM's snapshot tree with the provided
+// name, or nil if no such snapshot exists.
+func FindSnapshot(
+	moVM mo.VirtualMachine,
+	name string) *vimtypes.VirtualMachineSnapshotTree {
+
+	if moVM.Snapshot == nil {
+		return nil
+	}
+	return findSnapshotInTree(
+		moVM.Snapshot.RootSnapshotList,
+		func(t vimtypes.VirtualMachineSnapshotTree) bool {
+			return t.Name == name
+		})
+}
+
+// FindSnapshotByID returns the node from the VM's snapshot tree with the
+// provided managed object ID, or nil if no such snapshot exists.
+func FindSnapshotByID(
+	moVM mo.VirtualMachine,
+	id string) *vimtypes.VirtualMachineSnapshotTree {
+
+	if moVM.Snapshot == nil {
+		return nil
+	}
+	return findSnapshotInTree(
+		moVM.Snapshot.RootSnapshotList,
+		func(t vimtypes.VirtualMachineSnapshotTree) bool {
+			return t.Snapshot.Value == id
+		})
+}
+
+// FindVirtualMachineSnapshot returns the node from the VM's snapshot tree for
+// the provided VirtualMachineSnapshot, or nil if no such snapshot exists. The
+// snapshot is found by the managed object ID recorded in status.uniqueID, and
+// only by name if the ID has not been recorded, ex. because the status was not
+// updated after the snapshot was taken. Otherwise a snapshot with the same
+// name that was taken outside of VM Operator could be mistaken for it.
+func FindVirtualMachineSnapshot(
+	moVM mo.VirtualMachine,
+	vmSnapshot *vmopv1.VirtualMachineSnapshot) *vimtypes.VirtualMachineSnapshotTree {
+
+	if id := vmSnapshot.Status.UniqueID; id != "" {
+		return FindSnapshotByID(moVM, id)
+	}
+	return FindSnapshot(moVM, vmSnapshot.Name)
+}
+
+// CurrentSnapshotName returns the name of the VM's current snapshot, or an
+// empty string if the VM does not have a current snapshot.
+func CurrentSnapshotName(moVM mo.VirtualMachine) string {
+	if moVM.Snapshot == nil || moVM.Snapshot.CurrentSnapshot == nil {
+		return ""
+	}
+	ref := *moVM.Snapshot.CurrentSnapshot
+	t := findSnapshotInTree(
+		moVM.Snapshot.RootSnapshotList,
+		func(t vimtypes.VirtualMachineSnapshotTree) bool {
+			return t.Snapshot == ref
+		})
+	if t == nil {
+		return ""
+	}
+	return t.Name
+}
+
+func findSnapshotInTree(
+	tree []vimtypes.VirtualMachineSnapshotTree,
+	match func(vimtypes.VirtualMachineSnapshotTree) bool) *vimtypes.VirtualMachineSnapshotTree {
+
+	for i := range tree {
+		if match(tree[i]) {
+			return &tree[i]
+		}
+		if t := findSnapshotInTree(tree[i].ChildSnapshotList, match); t != nil {
+			return t
+		}
+	}
+	return nil
+}
+
+// CreateSnapshot takes a snapshot of the VM. The memory and quiesce options
+// are only honored when the VM is powered on.
+func CreateSnapshot(
+	vmCtx pkgctx.VirtualMachineContext,
+	vcVM *object.VirtualMachine,
+	moVM mo.VirtualMachine,
+	args SnapshotArgs) (*vimtypes.ManagedObjectReference, error) {
+
+	poweredOn := moVM.Summary.Runtime.PowerState == vimtypes.VirtualMachinePowerStatePoweredOn
+
+	t, err := vcVM.CreateSnapshot(
+		vmCtx,
+		args.Name,
+		args.Description,
+		args.Memory && poweredOn,
+		args.Quiesce && poweredOn)
+	if err != nil {
+		return nil, err
+	}
+
+	taskInfo, err := t.WaitForResult(vmCtx)
+	if err != nil {
+		if taskInfo != nil {
+			vmCtx.Logger.V(5).Error(err, "create snapshot task failed", "taskInfo", taskInfo)
+		}
+		return nil, fmt.Errorf("create snapshot task failed: %w", err)
+	}
+
+	ref, ok := taskInfo.Result.(vimtypes.ManagedObjectReference)
+	if !ok {
+		return nil, fmt.Errorf("create snapshot task returned unexpected result %T", taskInfo.Result)
+	}
+
+	return &ref, nil
+}
+
+// DeleteSnapshot removes the snapshot for the provided VirtualMachineSnapshot
+// from the VM. The children of the snapshot, if any, are re-parented to the
+// snapshot's parent. No error is returned if the snapshot does not exist.
+func DeleteSnapshot(
+	vmCtx pkgctx.VirtualMachineContext,
+	vcVM *object.VirtualMachine,
+	moVM mo.VirtualMachine,
+	vmSnapshot *vmopv1.VirtualMachineSnapshot) error {
+
+	ss := FindVirtualMachineSnapshot(moVM, vmSnapshot)
+	if ss == nil {
+		return nil
+	}
+
+	consolidate := true
+	req := vimtypes.RemoveSnapshot_Task{
+		This:           ss.Snapshot,
+		RemoveChildren: false,
+		Consolidate:    &consolidate,
+	}
+
+	res, err := methods.RemoveSnapshot_Task(vmCtx, vcVM.Client(), &req)
+	if err != nil {
+		return err
+	}
+
+	t := object.NewTask(vcVM.Client(), res.Returnval)
+
+	if taskInfo, err := t.WaitForResult(vmCtx); err != nil {
+		if taskInfo != nil {
+			vmCtx.Logger.V(5).Error(err, "remove snapshot task failed", "taskInfo", taskInfo)
+		}
+		return fmt.Errorf("remove snapshot task failed: %w", err)
+	}
+
+	return nil
+}
+
+// RevertToDesiredSnapshot reverts the VM to the snapshot specified by
+// spec.currentSnapshot if it is not already the VM's current snapshot. True
+// is returned if the VM was reverted.
+//
+// The VM is not reverted if it is paused or if the desired snapshot does not
+// yet exist.
+func RevertToDesiredSnapshot(
+	vmCtx pkgctx.VirtualMachineContext,
+	vcVM *object.VirtualMachine) (bool, error) {
+
+	desired := vmCtx.VM.Spec.CurrentSnapshot
+	if desired == nil || desired.Name == "" {
+		return false, nil
+	}
+
+	if paused.ByAdmin(vmCtx.MoVM) || paused.ByDevOps(vmCtx.VM) {
+		return false, nil
+	}
+
+	if CurrentSnapshotName(vmCtx.MoVM) == desired.Name {
+		return false, nil
+	}
+
+	ss := FindSnapshot(vmCtx.MoVM, desired.Name)
+	if ss == nil {
+		vmCtx.Logger.Info(
+			"Skipping revert since snapshot does not exist",
+			"snapshotName", desired.Name)
+		return false, nil
+	}
+
+	vmCtx.Logger.Info("Reverting VM to snapshot", "snapshotName", desired.Name)
+
+	req := vimtypes.RevertToSnapshot_Task{
+		This: ss.Snapshot,
+	}
+
+	res, err := methods.RevertToSnapshot_Task(vmCtx, vcVM.Client(), &req)
+	if err != nil {
+		return false, err
+	}
+
+	t := object.NewTask(vcVM.Client(), res.Returnval)
+
+	if taskInfo, err := t.WaitForResult(vmCtx); err != nil {
+		if taskInfo != nil {
+			vmCtx.Logger.V(5).Error(err, "revert to snapshot task failed", "taskInfo", taskInfo)
+		}
+		return false, fmt.Errorf("revert to snapshot task failed: %w", err)
+	}
+
+	return true, nil
+}
+
+// UpdateSnapshotStatus updates the status of the VirtualMachineSnapshot from
+// the provided node of the VM's snapshot tree.
+func UpdateSnapshotStatus(
+	vmSnapshot *vmopv1.VirtualMachineSnapshot,
+	ss vimtypes.VirtualMachineSnapshotTree) {
+
+	vmSnapshot.Status.UniqueID = ss.Snapshot.Value
+	vmSnapshot.Status.Quiesced = ss.Quiesced
+	vmSnapshot.Status.PowerState = convertPowerState(ss.State)
+	vmSnapshot.Status.CreationTime = &metav1.Time{Time: ss.CreateTime}
+	vmSnapshot.Status.Children = SnapshotRefs(ss.ChildSnapshotList)
+}
+
+// SnapshotRefs returns references to the VirtualMachineSnapshot resources
+// that correspond to the provided nodes of a VM's snapshot tree.
+func SnapshotRefs(
+	tree []vimtypes.VirtualMachineSnapshotTree) []vmopv1common.LocalObjectRef {
+
+	if len(tree) == 0 {
+		return nil
+	}
+	refs := make([]vmopv1common.LocalObjectRef, len(tree))
+	for i := range tree {
+		refs[i] = SnapshotRef(tree[i].Name)
+	}
+	return refs
+}
+
+// SnapshotRef returns a reference to the VirtualMachineSnapshot resource with
+// the provided name.
+func SnapshotRef(name string) vmopv1common.LocalObjectRef {
+	return vmopv1common.LocalObjectRef{
+		APIVersion: vmopv1.GroupVersion.String(),
+		Kind:       "VirtualMachineSnapshot",
+		Name:       name,
+	}
+}
+
+func convertPowerState(state vimtypes.VirtualMachinePowerState) vmopv1.VirtualMachinePowerState {
+	switch state {
+	case vimtypes.VirtualMachinePowerStatePoweredOff:
+		return vmopv1.VirtualMachinePowerStateOff
+	case vimtypes.VirtualMachinePowerStatePoweredOn:
+		return vmopv1.VirtualMachinePowerStateOn
+	case vimtypes.VirtualMachinePowerStateSuspended:
+		return vmopv1.VirtualMachinePowerStateSuspended
+	}
+	return ""
+}
@@ -0,0 +1,147 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachine_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/mo"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	vmopv1common "github.com/vmware-tanzu/vm-operator/api/v1alpha3/common"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/virtualmachine"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func snapshotTests() {
+
+	var (
+		ctx   *builder.TestContextForVCSim
+		vcVM  *object.VirtualMachine
+		vmCtx pkgctx.VirtualMachineContext
+	)
+
+	BeforeEach(func() {
+		ctx = suite.NewTestContextForVCSim(builder.VCSimTestConfig{})
+
+		var err error
+		vcVM, err = ctx.Finder.VirtualMachine(ctx, "DC0_C0_RP0_VM0")
+		Expect(err).ToNot(HaveOccurred())
+
+		vmCtx = pkgctx.VirtualMachineContext{
+			Context: ctx,
+			Logger:  suite.GetLogger().WithValues("vmName", vcVM.Name()),
+			VM:      builder.DummyVirtualMachine(),
+		}
+	})
+
+	AfterEach(func() {
+		ctx.AfterEach()
+		ctx = nil
+	})
+
+	refreshMoVM := func() {
+		vmCtx.MoVM = mo.VirtualMachine{}
+		Expect(vcVM.Properties(ctx, vcVM.Reference(), []string{"snapshot", "summary.runtime.powerState"}, &vmCtx.MoVM)).To(Succeed())
+	}
+
+	createSnapshot := func(name string) string {
+		refreshMoVM()
+		ref, err := virtualmachine.CreateSnapshot(vmCtx, vcVM, vmCtx.MoVM, virtualmachine.SnapshotArgs{Name: name})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ref).ToNot(BeNil())
+		refreshMoVM()
+		return ref.Value
+	}
+
+	It("Creates and finds a snapshot", func() {
+		createSnapshot("snap-1")
+
+		ss := virtualmachine.FindSnapshot(vmCtx.MoVM, "snap-1")
+		Expect(ss).ToNot(BeNil())
+		Expect(virtualmachine.CurrentSnapshotName(vmCtx.MoVM)).To(Equal("snap-1"))
+		Expect(virtualmachine.FindSnapshot(vmCtx.MoVM, "does-not-exist")).To(BeNil())
+
+		vmSnapshot := &vmopv1.VirtualMachineSnapshot{}
+		virtualmachine.UpdateSnapshotStatus(vmSnapshot, *ss)
+		Expect(vmSnapshot.Status.UniqueID).To(Equal(ss.Snapshot.Value))
+		Expect(vmSnapshot.Status.CreationTime).ToNot(BeNil())
+		Expect(vmSnapshot.Status.Children).To(BeEmpty())
+	})
+
+	It("Finds the snapshot of a VirtualMachineSnapshot by its unique ID", func() {
+		id1 := createSnapshot("snap-1")
+		id2 := createSnapshot("snap-1")
+		Expect(id1).ToNot(Equal(id2))
+
+		vmSnapshot := &vmopv1.VirtualMachineSnapshot{}
+		vmSnapshot.Name = "snap-1"
+
+		By("Finds the snapshot by name when the unique ID is not recorded", func() {
+			ss := virtualmachine.FindVirtualMachineSnapshot(vmCtx.MoVM, vmSnapshot)
+			Expect(ss).ToNot(BeNil())
+			Expect(ss.Snapshot.Value).To(Equal(id1))
+		})
+
+		By("Finds the snapshot by the recorded unique ID", func() {
+			vmSnapshot.Status.UniqueID = id2
+			ss := virtualmachine.FindVirtualMachineSnapshot(vmCtx.MoVM, vmSnapshot)
+			Expect(ss).ToNot(BeNil())
+			Expect(ss.Snapshot.Value).To(Equal(id2))
+		})
+
+		By("Does not find a snapshot with the same name when the recorded unique ID does not exist", func() {
+			vmSnapshot.Status.UniqueID = "does-not-exist"
+			Expect(virtualmachine.FindVirtualMachineSnapshot(vmCtx.MoVM, vmSnapshot)).To(BeNil())
+		})
+	})
+
+	It("Reverts to the desired snapshot", func() {
+		createSnapshot("snap-1")
+		createSnapshot("snap-2")
+		Expect(virtualmachine.CurrentSnapshotName(vmCtx.MoVM)).To(Equal("snap-2"))
+
+		ss := virtualmachine.FindSnapshot(vmCtx.MoVM, "snap-1")
+		Expect(ss).ToNot(BeNil())
+		Expect(virtualmachine.SnapshotRefs(ss.ChildSnapshotList)).To(ConsistOf(virtualmachine.SnapshotRef("snap-2")))
+
+		vmCtx.VM.Spec.CurrentSnapshot = &vmopv1common.LocalObjectRef{Name: "snap-1"}
+		reverted, err := virtualmachine.RevertToDesiredSnapshot(vmCtx, vcVM)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(reverted).To(BeTrue())
+
+		refreshMoVM()
+		Expect(virtualmachine.CurrentSnapshotName(vmCtx.MoVM)).To(Equal("snap-1"))
+
+		By("Does not revert again when already at the desired snapshot", func() {
+			reverted, err := virtualmachine.RevertToDesiredSnapshot(vmCtx, vcVM)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(reverted).To(BeFalse())
+		})
+
+		By("Does not revert when the desired snapshot does not exist", func() {
+			vmCtx.VM.Spec.CurrentSnapshot.Name = "does-not-exist"
+			reverted, err := virtualmachine.RevertToDesiredSnapshot(vmCtx, vcVM)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(reverted).To(BeFalse())
+		})
+	})
+
+	It("Deletes a snapshot", func() {
+		vmSnapshot := &vmopv1.VirtualMachineSnapshot{}
+		vmSnapshot.Name = "snap-1"
+		vmSnapshot.Status.UniqueID = createSnapshot("snap-1")
+		Expect(virtualmachine.DeleteSnapshot(vmCtx, vcVM, vmCtx.MoVM, vmSnapshot)).To(Succeed())
+
+		refreshMoVM()
+		Expect(virtualmachine.FindSnapshot(vmCtx.MoVM, "snap-1")).To(BeNil())
+
+		By("Does not return an error when the snapshot does not exist", func() {
+			Expect(virtualmachine.DeleteSnapshot(vmCtx, vcVM, vmCtx.MoVM, vmSnapshot)).To(Succeed())
+		})
+	})
+}
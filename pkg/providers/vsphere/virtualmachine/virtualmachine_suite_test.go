@@ -20,6 +20,9 @@ func vcSimTests() {
 	Describe("Backup", Label(testlabels.VCSim), backupTests)
 	Describe("GuestInfo", Label(testlabels.VCSim), guestInfoTests)
 	Describe("CD-ROM", Label(testlabels.VCSim), cdromTests)
+	Describe("Snapshot", Label(testlabels.VCSim), snapshotTests)
+	Describe("Relocate", Label(testlabels.VCSim), relocateTests)
+	Describe("Volumes", Label(testlabels.VCSim), volumesTests)
 }
 
 var suite = builder.NewTestSuite()
@@ -0,0 +1,513 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachine
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"path"
+	"strings"
+
+	"github.com/vmware/govmomi/fault"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25"
+	"github.com/vmware/govmomi/vim25/soap"
+	vimtypes "github.com/vmware/govmomi/vim25/types"
+	corev1 "k8s.io/api/core/v1"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/constants"
+	pkgutil "github.com/vmware-tanzu/vm-operator/pkg/util"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/iso9660"
+)
+
+const (
+	// dataVolumeAlignment is the size, in bytes, the image of a volume backed
+	// by a ConfigMap or Secret is padded to a multiple of.
+	dataVolumeAlignment = 1024 * 1024
+
+	// diskSectorSize is the size, in bytes, of a sector in a disk descriptor.
+	diskSectorSize = 512
+)
+
+// UpdateVolumeDeviceChanges reconciles the disks of the volumes in
+// VM.Spec.Volumes that are not backed by a PVC with the current disks in the
+// VM. It returns a list of device changes required to update the disks, and
+// the ExtraConfig that records the disks of these volumes if it changed.
+//
+// An ephemeral volume is a disk created in the VM's directory that is deleted
+// along with the VM. A volume backed by a ConfigMap or Secret is a read-only
+// disk in the VM's directory with an ISO 9660 file system that contains the
+// latest data, and is replaced with a new disk when the data changes. The
+// disks are created with the Virtual Disk Manager before they are attached to
+// the VM, and are identified by their UUID, which is recorded in the VM's
+// ExtraConfig. Only the disks recorded this way are removed from the VM when
+// their volume is removed from the spec.
+func UpdateVolumeDeviceChanges(
+	vmCtx pkgctx.VirtualMachineContext,
+	k8sClient ctrlclient.Client,
+	vimClient *vim25.Client,
+	datacenter *object.Datacenter,
+	curDevices object.VirtualDeviceList) (
+	[]vimtypes.BaseVirtualDeviceConfigSpec, []vimtypes.BaseOptionValue, error) {
+
+	vmDir, err := getVMDirectory(vmCtx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	curVolumeDisks, err := GetEphemeralVolumeDiskUUIDs(
+		pkgutil.OptionValues(vmCtx.MoVM.Config.ExtraConfig))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Get the current disks by their UUID.
+	curDisks := map[string]*vimtypes.VirtualDisk{}
+	curFileNames := map[string]struct{}{}
+	for _, d := range curDevices.SelectByType((*vimtypes.VirtualDisk)(nil)) {
+		disk := d.(*vimtypes.VirtualDisk)
+		if b, ok := disk.Backing.(*vimtypes.VirtualDiskFlatVer2BackingInfo); ok {
+			curFileNames[b.FileName] = struct{}{}
+			if b.Uuid != "" {
+				curDisks[NormalizeDiskUUID(b.Uuid)] = disk
+			}
+		}
+	}
+
+	var (
+		deviceChanges  []vimtypes.BaseVirtualDeviceConfigSpec
+		newDisks       []*vimtypes.VirtualDisk
+		newVolumeDisks = map[string]string{}
+		ds             *object.Datastore
+	)
+
+	for _, vol := range vmCtx.VM.Spec.Volumes {
+		var curDisk *vimtypes.VirtualDisk
+		if diskUUID, ok := curVolumeDisks[vol.Name]; ok {
+			// When the disk is not found, it was removed from the VM
+			// out-of-band, so a new one is created.
+			curDisk = curDisks[diskUUID]
+		}
+
+		var (
+			diskPath object.DatastorePath
+			diskUUID string
+			disk     *vimtypes.VirtualDisk
+		)
+
+		switch {
+		case vol.Ephemeral != nil:
+			capacity := vol.Ephemeral.Size.Value()
+			diskPath = object.DatastorePath{
+				Datastore: vmDir.Datastore,
+				Path:      path.Join(vmDir.Path, vol.Name+constants.EphemeralVolumeFileNameSuffix),
+			}
+
+			if curDisk != nil && diskFileName(curDisk) == diskPath.String() {
+				newVolumeDisks[vol.Name] = curVolumeDisks[vol.Name]
+
+				if capacity < curDisk.CapacityInBytes {
+					return nil, nil, fmt.Errorf("cannot shrink ephemeral volume %s from %d bytes to %d bytes",
+						vol.Name, curDisk.CapacityInBytes, capacity)
+				}
+				if capacity > curDisk.CapacityInBytes {
+					curDisk.CapacityInBytes = capacity
+					deviceChanges = append(deviceChanges, &vimtypes.VirtualDeviceConfigSpec{
+						Operation: vimtypes.VirtualDeviceConfigSpecOperationEdit,
+						Device:    curDisk,
+					})
+				}
+				continue
+			}
+			if _, ok := curFileNames[diskPath.String()]; ok {
+				return nil, nil, fmt.Errorf("cannot create disk for ephemeral volume %s: disk %s is already attached to the VM",
+					vol.Name, diskPath.String())
+			}
+
+			diskUUID, err = createEphemeralDisk(vmCtx, vimClient, datacenter, diskPath.String(), capacity,
+				ephemeralDiskType(vmCtx.VM, vol.Ephemeral.ProvisioningMode))
+			if err != nil {
+				return nil, nil, fmt.Errorf("error creating disk for ephemeral volume %s: %w", vol.Name, err)
+			}
+			disk = newVolumeDisk(diskPath.String(), capacity, vimtypes.VirtualDiskModePersistent)
+
+		case vol.ConfigMap != nil, vol.Secret != nil:
+			img, err := getDataVolumeImage(vmCtx, k8sClient, vol)
+			if err != nil {
+				return nil, nil, fmt.Errorf("error generating image for volume %s: %w", vol.Name, err)
+			}
+
+			// The disk is named after the digest of its data, so the disk is
+			// only replaced when the data changes.
+			diskPath = object.DatastorePath{
+				Datastore: vmDir.Datastore,
+				Path: path.Join(vmDir.Path,
+					fmt.Sprintf("%s-%x%s", vol.Name, sha256.Sum256(img), constants.DataVolumeFileNameSuffix)),
+			}
+
+			if curDisk != nil && diskFileName(curDisk) == diskPath.String() {
+				newVolumeDisks[vol.Name] = curVolumeDisks[vol.Name]
+				continue
+			}
+			if _, ok := curFileNames[diskPath.String()]; ok {
+				return nil, nil, fmt.Errorf("cannot create disk for volume %s: disk %s is already attached to the VM",
+					vol.Name, diskPath.String())
+			}
+
+			if ds == nil {
+				if ds, err = getHostDatastoreByName(vmCtx, vimClient, vmDir.Datastore); err != nil {
+					return nil, nil, err
+				}
+			}
+			diskUUID, err = createDataVolumeDisk(vmCtx, vimClient, datacenter, ds, diskPath, img)
+			if err != nil {
+				return nil, nil, fmt.Errorf("error creating disk for volume %s: %w", vol.Name, err)
+			}
+			disk = newVolumeDisk(diskPath.String(), int64(len(img)), vimtypes.VirtualDiskModeIndependent_nonpersistent)
+
+		default:
+			continue
+		}
+
+		// Replace the volume's current disk, ex. the data of its ConfigMap
+		// changed, or the volume's source changed.
+		if curDisk != nil {
+			deviceChanges = append(deviceChanges, &vimtypes.VirtualDeviceConfigSpec{
+				Operation:     vimtypes.VirtualDeviceConfigSpecOperationRemove,
+				FileOperation: vimtypes.VirtualDeviceConfigSpecFileOperationDestroy,
+				Device:        curDisk,
+			})
+		}
+
+		newVolumeDisks[vol.Name] = diskUUID
+		newDisks = append(newDisks, disk)
+		deviceChanges = append(deviceChanges, &vimtypes.VirtualDeviceConfigSpec{
+			Operation: vimtypes.VirtualDeviceConfigSpecOperationAdd,
+			Device:    disk,
+		})
+	}
+
+	// Remove and delete the disks of the volumes that are no longer specified.
+	for name, diskUUID := range curVolumeDisks {
+		if _, ok := newVolumeDisks[name]; ok {
+			continue
+		}
+		if disk, ok := curDisks[diskUUID]; ok {
+			deviceChanges = append(deviceChanges, &vimtypes.VirtualDeviceConfigSpec{
+				Operation:     vimtypes.VirtualDeviceConfigSpecOperationRemove,
+				FileOperation: vimtypes.VirtualDeviceConfigSpecFileOperationDestroy,
+				Device:        disk,
+			})
+		}
+	}
+
+	// Assign the new disks to the VM's disk controller. Update curDevices with
+	// each assigned disk for correct slot (unit number) allocation in the next
+	// assignment.
+	for _, disk := range newDisks {
+		controller, err := curDevices.FindDiskController("")
+		if err != nil {
+			return nil, nil, fmt.Errorf("error finding disk controller: %w", err)
+		}
+		curDevices.AssignController(disk, controller)
+		curDevices = append(curDevices, disk)
+	}
+
+	var extraConfig []vimtypes.BaseOptionValue
+	if !maps.Equal(curVolumeDisks, newVolumeDisks) {
+		var value string
+		if len(newVolumeDisks) > 0 {
+			data, err := json.Marshal(newVolumeDisks)
+			if err != nil {
+				return nil, nil, err
+			}
+			value = string(data)
+		}
+		extraConfig = append(extraConfig, &vimtypes.OptionValue{
+			Key:   constants.EphemeralVolumesExtraConfigKey,
+			Value: value,
+		})
+	}
+
+	return deviceChanges, extraConfig, nil
+}
+
+// GetEphemeralVolumeDiskUUIDs returns the UUIDs of the disks of the volumes
+// that are not backed by a PVC recorded in the given ExtraConfig, keyed by the
+// name of the volume. The UUIDs are normalized with NormalizeDiskUUID.
+func GetEphemeralVolumeDiskUUIDs(extraConfig pkgutil.OptionValues) (map[string]string, error) {
+	diskUUIDs := map[string]string{}
+
+	data, _ := extraConfig.GetString(constants.EphemeralVolumesExtraConfigKey)
+	if data == "" {
+		return diskUUIDs, nil
+	}
+
+	if err := json.Unmarshal([]byte(data), &diskUUIDs); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal ephemeral volume disks: %w", err)
+	}
+	for name, diskUUID := range diskUUIDs {
+		diskUUIDs[name] = NormalizeDiskUUID(diskUUID)
+	}
+
+	return diskUUIDs, nil
+}
+
+// NormalizeDiskUUID returns the given disk UUID in the format used to record
+// the disks of the volumes that are not backed by a PVC. The Virtual Disk Manager and the backing of
+// a disk may format the same UUID differently.
+func NormalizeDiskUUID(diskUUID string) string {
+	return strings.ToLower(strings.NewReplacer(" ", "", "-", "").Replace(diskUUID))
+}
+
+// ephemeralDiskType returns the type of the disk for an ephemeral volume with
+// the given provisioning mode. When the mode is not specified, the VM's
+// default volume provisioning mode is used.
+func ephemeralDiskType(
+	vm *vmopv1.VirtualMachine,
+	mode vmopv1.VirtualMachineVolumeProvisioningMode) vimtypes.VirtualDiskType {
+
+	if mode == "" {
+		if adv := vm.Spec.Advanced; adv != nil {
+			mode = adv.DefaultVolumeProvisioningMode
+		}
+	}
+
+	switch mode {
+	case vmopv1.VirtualMachineVolumeProvisioningModeThick:
+		return vimtypes.VirtualDiskTypeThick
+	case vmopv1.VirtualMachineVolumeProvisioningModeThickEagerZero:
+		return vimtypes.VirtualDiskTypeEagerZeroedThick
+	default:
+		return vimtypes.VirtualDiskTypeThin
+	}
+}
+
+// createEphemeralDisk creates the disk for an ephemeral volume with the
+// Virtual Disk Manager and returns its UUID. A disk that was created by a
+// previous attempt, but was never attached to the VM, is reused.
+func createEphemeralDisk(
+	vmCtx pkgctx.VirtualMachineContext,
+	vimClient *vim25.Client,
+	datacenter *object.Datacenter,
+	name string,
+	capacityInBytes int64,
+	diskType vimtypes.VirtualDiskType) (string, error) {
+
+	m := object.NewVirtualDiskManager(vimClient)
+
+	spec := &vimtypes.FileBackedVirtualDiskSpec{
+		VirtualDiskSpec: vimtypes.VirtualDiskSpec{
+			AdapterType: string(vimtypes.VirtualDiskAdapterTypeLsiLogic),
+			DiskType:    string(diskType),
+		},
+		CapacityKb: capacityInBytes / 1024,
+	}
+
+	vmCtx.Logger.Info("Creating ephemeral volume disk", "name", name, "capacityInBytes", capacityInBytes)
+	task, err := m.CreateVirtualDisk(vmCtx, name, datacenter, spec)
+	if err == nil {
+		err = task.Wait(vmCtx)
+	}
+	if err != nil && !fault.Is(err, &vimtypes.FileAlreadyExists{}) {
+		return "", err
+	}
+
+	diskUUID, err := m.QueryVirtualDiskUuid(vmCtx, name, datacenter)
+	if err != nil {
+		return "", fmt.Errorf("error getting UUID of disk %s: %w", name, err)
+	}
+
+	return NormalizeDiskUUID(diskUUID), nil
+}
+
+// newVolumeDisk returns a new disk with the given backing file name, capacity,
+// and mode.
+func newVolumeDisk(
+	fileName string,
+	capacityInBytes int64,
+	mode vimtypes.VirtualDiskMode) *vimtypes.VirtualDisk {
+
+	return &vimtypes.VirtualDisk{
+		VirtualDevice: vimtypes.VirtualDevice{
+			Backing: &vimtypes.VirtualDiskFlatVer2BackingInfo{
+				VirtualDeviceFileBackingInfo: vimtypes.VirtualDeviceFileBackingInfo{
+					FileName: fileName,
+				},
+				DiskMode: string(mode),
+			},
+		},
+		CapacityInBytes: capacityInBytes,
+	}
+}
+
+// diskFileName returns the backing file name of the given disk.
+func diskFileName(disk *vimtypes.VirtualDisk) string {
+	if b, ok := disk.Backing.(*vimtypes.VirtualDiskFlatVer2BackingInfo); ok {
+		return b.FileName
+	}
+	return ""
+}
+
+// getDataVolumeImage returns an ISO image with the data of the volume's
+// ConfigMap or Secret, padded to a multiple of dataVolumeAlignment. The name of
+// the volume is used as the label of the file system.
+func getDataVolumeImage(
+	vmCtx pkgctx.VirtualMachineContext,
+	k8sClient ctrlclient.Client,
+	vol vmopv1.VirtualMachineVolume) ([]byte, error) {
+
+	var src vmopv1.VirtualMachineCdromConfigDriveProjection
+	switch {
+	case vol.ConfigMap != nil:
+		src.ConfigMap = &corev1.ConfigMapProjection{
+			LocalObjectReference: vol.ConfigMap.LocalObjectReference,
+			Items:                vol.ConfigMap.Items,
+			Optional:             vol.ConfigMap.Optional,
+		}
+	case vol.Secret != nil:
+		src.Secret = &corev1.SecretProjection{
+			LocalObjectReference: corev1.LocalObjectReference{Name: vol.Secret.SecretName},
+			Items:                vol.Secret.Items,
+			Optional:             vol.Secret.Optional,
+		}
+	}
+
+	files, err := getConfigDriveFiles(
+		vmCtx, k8sClient, vmCtx.VM.Namespace,
+		vmopv1.VirtualMachineCdromConfigDriveSource{
+			Sources: []vmopv1.VirtualMachineCdromConfigDriveProjection{src},
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	img, err := iso9660.Build(vol.Name, files)
+	if err != nil {
+		return nil, err
+	}
+	if n := len(img) % dataVolumeAlignment; n != 0 {
+		img = append(img, make([]byte, dataVolumeAlignment-n)...)
+	}
+
+	return img, nil
+}
+
+// createDataVolumeDisk creates the disk for a volume backed by a ConfigMap or
+// Secret with the given image and returns its UUID.
+//
+// The image is uploaded to the VM's directory as a hosted disk, which the
+// Virtual Disk Manager copies to a disk in the datastore's native format, as
+// a hosted disk cannot be attached to a VM on a vSAN or vVol datastore. The
+// hosted disk is deleted after it is copied. Since the disk is named after
+// the digest of the image, a disk that was created by a previous attempt, but
+// was never attached to the VM, is reused.
+func createDataVolumeDisk(
+	vmCtx pkgctx.VirtualMachineContext,
+	vimClient *vim25.Client,
+	datacenter *object.Datacenter,
+	ds *object.Datastore,
+	name object.DatastorePath,
+	img []byte) (string, error) {
+
+	srcPath := object.DatastorePath{
+		Datastore: name.Datastore,
+		Path:      strings.TrimSuffix(name.Path, ".vmdk") + constants.DataVolumeSourceFileNameSuffix,
+	}
+	extentPath := strings.TrimSuffix(srcPath.Path, ".vmdk") + "-flat.vmdk"
+	descriptor := dataVolumeDiskDescriptor(path.Base(extentPath), int64(len(img)))
+
+	for _, f := range []struct {
+		path string
+		data []byte
+	}{
+		{path: extentPath, data: img},
+		{path: srcPath.Path, data: descriptor},
+	} {
+		upload := soap.DefaultUpload
+		upload.ContentLength = int64(len(f.data))
+
+		vmCtx.Logger.V(4).Info("Uploading volume disk", "path", f.path, "size", len(f.data))
+		if err := ds.Upload(vmCtx, bytes.NewReader(f.data), f.path, &upload); err != nil {
+			return "", fmt.Errorf("error uploading file %s: %w", f.path, err)
+		}
+	}
+
+	m := object.NewVirtualDiskManager(vimClient)
+
+	defer func() {
+		task, err := m.DeleteVirtualDisk(vmCtx, srcPath.String(), datacenter)
+		if err == nil {
+			err = task.Wait(vmCtx)
+		}
+		if err != nil {
+			vmCtx.Logger.Error(err, "Failed to delete hosted volume disk", "name", srcPath.String())
+		}
+	}()
+
+	spec := &vimtypes.FileBackedVirtualDiskSpec{
+		VirtualDiskSpec: vimtypes.VirtualDiskSpec{
+			AdapterType: string(vimtypes.VirtualDiskAdapterTypeLsiLogic),
+			DiskType:    string(vimtypes.VirtualDiskTypeThin),
+		},
+		CapacityKb: int64(len(img)) / 1024,
+	}
+
+	vmCtx.Logger.Info("Creating volume disk", "name", name.String(), "capacityInBytes", len(img))
+	task, err := m.CopyVirtualDisk(vmCtx, srcPath.String(), datacenter, name.String(), datacenter, spec, false)
+	if err == nil {
+		err = task.Wait(vmCtx)
+	}
+	if err != nil && !fault.Is(err, &vimtypes.FileAlreadyExists{}) {
+		return "", err
+	}
+
+	diskUUID, err := m.QueryVirtualDiskUuid(vmCtx, name.String(), datacenter)
+	if err != nil {
+		return "", fmt.Errorf("error getting UUID of disk %s: %w", name.String(), err)
+	}
+
+	return NormalizeDiskUUID(diskUUID), nil
+}
+
+// dataVolumeDiskDescriptor returns the descriptor of a hosted, monolithic flat
+// disk with the given extent file name and size in bytes.
+func dataVolumeDiskDescriptor(extentFileName string, sizeInBytes int64) []byte {
+	const (
+		heads   = 255
+		sectors = 63
+	)
+
+	numSectors := sizeInBytes / diskSectorSize
+	cylinders := max(numSectors/(heads*sectors), 1)
+
+	var b strings.Builder
+	fmt.Fprintln(&b, "# Disk DescriptorFile")
+	fmt.Fprintln(&b, "version=1")
+	fmt.Fprintln(&b, `encoding="UTF-8"`)
+	fmt.Fprintln(&b, "CID=fffffffe")
+	fmt.Fprintln(&b, "parentCID=ffffffff")
+	fmt.Fprintln(&b, `createType="monolithicFlat"`)
+	fmt.Fprintln(&b)
+	fmt.Fprintln(&b, "# Extent description")
+	fmt.Fprintf(&b, "RW %d FLAT %q 0\n", numSectors, extentFileName)
+	fmt.Fprintln(&b)
+	fmt.Fprintln(&b, "# The Disk Data Base")
+	fmt.Fprintln(&b, "#DDB")
+	fmt.Fprintln(&b)
+	fmt.Fprintln(&b, `ddb.adapterType = "lsilogic"`)
+	fmt.Fprintf(&b, "ddb.geometry.cylinders = \"%d\"\n", cylinders)
+	fmt.Fprintf(&b, "ddb.geometry.heads = \"%d\"\n", heads)
+	fmt.Fprintf(&b, "ddb.geometry.sectors = \"%d\"\n", sectors)
+	fmt.Fprintln(&b, `ddb.virtualHWVersion = "4"`)
+
+	return []byte(b.String())
+}
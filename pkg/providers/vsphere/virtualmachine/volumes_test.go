@@ -0,0 +1,395 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachine_test
+
+import (
+	"path"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/mo"
+	vimtypes "github.com/vmware/govmomi/vim25/types"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/constants"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/virtualmachine"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func volumesTests() {
+
+	const (
+		ns     = "test-ns"
+		vmName = "test-vm"
+	)
+
+	var (
+		ctx       *builder.TestContextForVCSim
+		vmCtx     pkgctx.VirtualMachineContext
+		vcVM      *object.VirtualMachine
+		datastore *object.Datastore
+		vmDir     object.DatastorePath
+		k8sClient ctrlclient.Client
+	)
+
+	BeforeEach(func() {
+		ctx = suite.NewTestContextForVCSim(builder.VCSimTestConfig{})
+
+		var err error
+		vcVM, err = ctx.Finder.VirtualMachine(ctx, "DC0_C0_RP0_VM0")
+		Expect(err).ToNot(HaveOccurred())
+
+		datastore, err = ctx.Finder.Datastore(ctx, "LocalDS_0")
+		Expect(err).ToNot(HaveOccurred())
+
+		vmCtx = pkgctx.VirtualMachineContext{
+			Context: ctx,
+			Logger:  suite.GetLogger(),
+			VM:      builder.DummyBasicVirtualMachine(vmName, ns),
+		}
+		Expect(vcVM.Properties(ctx, vcVM.Reference(), []string{"config", "runtime"}, &vmCtx.MoVM)).To(Succeed())
+
+		Expect(vmDir.FromString(vmCtx.MoVM.Config.Files.VmPathName)).To(BeTrue())
+		vmDir.Path = path.Dir(vmDir.Path)
+
+		k8sClient = builder.NewFakeClient(
+			&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-config", Namespace: ns},
+				Data:       map[string]string{"app.conf": "debug=true"},
+			},
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-secret", Namespace: ns},
+				Data:       map[string][]byte{"password": []byte("hunter2")},
+			},
+		)
+
+		vmCtx.VM.Spec.Volumes = []vmopv1.VirtualMachineVolume{
+			{
+				Name: "scratch",
+				VirtualMachineVolumeSource: vmopv1.VirtualMachineVolumeSource{
+					Ephemeral: &vmopv1.EphemeralVolumeSource{
+						Size: resource.MustParse("10Mi"),
+					},
+				},
+			},
+			{
+				Name: "cache",
+				VirtualMachineVolumeSource: vmopv1.VirtualMachineVolumeSource{
+					Ephemeral: &vmopv1.EphemeralVolumeSource{
+						Size:             resource.MustParse("20Mi"),
+						ProvisioningMode: vmopv1.VirtualMachineVolumeProvisioningModeThick,
+					},
+				},
+			},
+		}
+	})
+
+	AfterEach(func() {
+		ctx.AfterEach()
+		ctx = nil
+	})
+
+	updateVolumeDeviceChanges := func() ([]vimtypes.BaseVirtualDeviceConfigSpec, []vimtypes.BaseOptionValue, error) {
+		return virtualmachine.UpdateVolumeDeviceChanges(
+			vmCtx, k8sClient, ctx.VCClient.Client, ctx.Datacenter,
+			object.VirtualDeviceList(vmCtx.MoVM.Config.Hardware.Device))
+	}
+
+	reconfigure := func(
+		deviceChanges []vimtypes.BaseVirtualDeviceConfigSpec,
+		extraConfig []vimtypes.BaseOptionValue) {
+
+		t, err := vcVM.Reconfigure(ctx, vimtypes.VirtualMachineConfigSpec{
+			DeviceChange: deviceChanges,
+			ExtraConfig:  extraConfig,
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(t.Wait(ctx)).To(Succeed())
+		vmCtx.MoVM = mo.VirtualMachine{}
+		Expect(vcVM.Properties(ctx, vcVM.Reference(), []string{"config", "runtime"}, &vmCtx.MoVM)).To(Succeed())
+	}
+
+	diskPath := func(name string) string {
+		p := object.DatastorePath{Datastore: vmDir.Datastore, Path: path.Join(vmDir.Path, name)}
+		return p.String()
+	}
+
+	diskByFileName := func(
+		deviceChanges []vimtypes.BaseVirtualDeviceConfigSpec,
+		name string) (*vimtypes.VirtualDeviceConfigSpec, *vimtypes.VirtualDisk) {
+
+		fileName := diskPath(name)
+		for _, dc := range deviceChanges {
+			spec := dc.GetVirtualDeviceConfigSpec()
+			if disk, ok := spec.Device.(*vimtypes.VirtualDisk); ok {
+				if disk.Backing.(*vimtypes.VirtualDiskFlatVer2BackingInfo).FileName == fileName {
+					return spec, disk
+				}
+			}
+		}
+		return nil, nil
+	}
+
+	diskExists := func(name string) bool {
+		_, err := datastore.Stat(ctx, path.Join(vmDir.Path, name))
+		return err == nil
+	}
+
+	It("should create and add the disks of the volumes", func() {
+		deviceChanges, extraConfig, err := updateVolumeDeviceChanges()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(deviceChanges).To(HaveLen(2))
+
+		var unitNumbers []int32
+		for _, name := range []string{"scratch-ephemeral.vmdk", "cache-ephemeral.vmdk"} {
+			spec, disk := diskByFileName(deviceChanges, name)
+			Expect(disk).ToNot(BeNil())
+			Expect(spec.Operation).To(Equal(vimtypes.VirtualDeviceConfigSpecOperationAdd))
+			Expect(spec.FileOperation).To(BeEmpty())
+			Expect(disk.Backing.(*vimtypes.VirtualDiskFlatVer2BackingInfo).DiskMode).To(
+				Equal(string(vimtypes.VirtualDiskModePersistent)))
+			Expect(unitNumbers).ToNot(ContainElement(*disk.UnitNumber))
+			unitNumbers = append(unitNumbers, *disk.UnitNumber)
+			Expect(diskExists(name)).To(BeTrue())
+		}
+
+		Expect(extraConfig).To(HaveLen(1))
+		ov := extraConfig[0].GetOptionValue()
+		Expect(ov.Key).To(Equal(constants.EphemeralVolumesExtraConfigKey))
+		Expect(ov.Value).To(And(ContainSubstring(`"scratch":`), ContainSubstring(`"cache":`)))
+	})
+
+	When("the disks of the volumes exist", func() {
+		JustBeforeEach(func() {
+			deviceChanges, extraConfig, err := updateVolumeDeviceChanges()
+			Expect(err).ToNot(HaveOccurred())
+			reconfigure(deviceChanges, extraConfig)
+		})
+
+		It("should not change the disks", func() {
+			deviceChanges, extraConfig, err := updateVolumeDeviceChanges()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(deviceChanges).To(BeEmpty())
+			Expect(extraConfig).To(BeEmpty())
+		})
+
+		It("should grow an ephemeral volume", func() {
+			vmCtx.VM.Spec.Volumes[0].Ephemeral.Size = resource.MustParse("30Mi")
+
+			deviceChanges, extraConfig, err := updateVolumeDeviceChanges()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(extraConfig).To(BeEmpty())
+			Expect(deviceChanges).To(HaveLen(1))
+			spec, disk := diskByFileName(deviceChanges, "scratch-ephemeral.vmdk")
+			Expect(disk).ToNot(BeNil())
+			Expect(spec.Operation).To(Equal(vimtypes.VirtualDeviceConfigSpecOperationEdit))
+			Expect(disk.CapacityInBytes).To(Equal(int64(30 * 1024 * 1024)))
+		})
+
+		It("should not shrink an ephemeral volume", func() {
+			vmCtx.VM.Spec.Volumes[0].Ephemeral.Size = resource.MustParse("5Mi")
+
+			_, _, err := updateVolumeDeviceChanges()
+			Expect(err).To(MatchError(ContainSubstring("cannot shrink ephemeral volume scratch")))
+		})
+
+		It("should remove and delete only the disks of removed volumes", func() {
+			vmCtx.VM.Spec.Volumes = vmCtx.VM.Spec.Volumes[1:]
+
+			deviceChanges, extraConfig, err := updateVolumeDeviceChanges()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(deviceChanges).To(HaveLen(1))
+			spec, disk := diskByFileName(deviceChanges, "scratch-ephemeral.vmdk")
+			Expect(disk).ToNot(BeNil())
+			Expect(spec.Operation).To(Equal(vimtypes.VirtualDeviceConfigSpecOperationRemove))
+			Expect(spec.FileOperation).To(Equal(vimtypes.VirtualDeviceConfigSpecFileOperationDestroy))
+			Expect(extraConfig).To(HaveLen(1))
+			Expect(extraConfig[0].GetOptionValue().Value).ToNot(ContainSubstring(`"scratch":`))
+
+			reconfigure(deviceChanges, extraConfig)
+			disks := object.VirtualDeviceList(vmCtx.MoVM.Config.Hardware.Device).SelectByType((*vimtypes.VirtualDisk)(nil))
+			Expect(disks).To(HaveLen(2))
+		})
+
+		It("should clear the ExtraConfig when all volumes are removed", func() {
+			vmCtx.VM.Spec.Volumes = nil
+
+			deviceChanges, extraConfig, err := updateVolumeDeviceChanges()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(deviceChanges).To(HaveLen(2))
+			Expect(extraConfig).To(HaveLen(1))
+			Expect(extraConfig[0].GetOptionValue().Value).To(BeEmpty())
+		})
+	})
+
+	When("a disk that is not recorded for a volume has the name of its disk", func() {
+		BeforeEach(func() {
+			vmCtx.VM.Spec.Volumes = vmCtx.VM.Spec.Volumes[:1]
+
+			disk := &vimtypes.VirtualDisk{
+				VirtualDevice: vimtypes.VirtualDevice{
+					Backing: &vimtypes.VirtualDiskFlatVer2BackingInfo{
+						VirtualDeviceFileBackingInfo: vimtypes.VirtualDeviceFileBackingInfo{
+							FileName: diskPath("scratch-ephemeral.vmdk"),
+						},
+						DiskMode: string(vimtypes.VirtualDiskModePersistent),
+					},
+				},
+				CapacityInBytes: 1024 * 1024,
+			}
+			devices := object.VirtualDeviceList(vmCtx.MoVM.Config.Hardware.Device)
+			controller, err := devices.FindDiskController("")
+			Expect(err).ToNot(HaveOccurred())
+			devices.AssignController(disk, controller)
+			reconfigure([]vimtypes.BaseVirtualDeviceConfigSpec{
+				&vimtypes.VirtualDeviceConfigSpec{
+					Operation:     vimtypes.VirtualDeviceConfigSpecOperationAdd,
+					FileOperation: vimtypes.VirtualDeviceConfigSpecFileOperationCreate,
+					Device:        disk,
+				},
+			}, nil)
+		})
+
+		It("should return an error instead of adding or removing the disk", func() {
+			_, _, err := updateVolumeDeviceChanges()
+			Expect(err).To(MatchError(ContainSubstring("is already attached to the VM")))
+		})
+
+		It("should not remove the disk when there are no volumes", func() {
+			vmCtx.VM.Spec.Volumes = nil
+
+			deviceChanges, extraConfig, err := updateVolumeDeviceChanges()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(deviceChanges).To(BeEmpty())
+			Expect(extraConfig).To(BeEmpty())
+		})
+	})
+
+	Context("volumes backed by a ConfigMap or Secret", func() {
+		BeforeEach(func() {
+			vmCtx.VM.Spec.Volumes = []vmopv1.VirtualMachineVolume{
+				{
+					Name: "config",
+					VirtualMachineVolumeSource: vmopv1.VirtualMachineVolumeSource{
+						ConfigMap: &corev1.ConfigMapVolumeSource{
+							LocalObjectReference: corev1.LocalObjectReference{Name: "my-config"},
+						},
+					},
+				},
+				{
+					Name: "creds",
+					VirtualMachineVolumeSource: vmopv1.VirtualMachineVolumeSource{
+						Secret: &corev1.SecretVolumeSource{
+							SecretName: "my-secret",
+							Items:      []corev1.KeyToPath{{Key: "password", Path: "db/password"}},
+						},
+					},
+				},
+			}
+		})
+
+		dataDiskName := func(deviceChanges []vimtypes.BaseVirtualDeviceConfigSpec, volName string) string {
+			for _, dc := range deviceChanges {
+				spec := dc.GetVirtualDeviceConfigSpec()
+				if spec.Operation != vimtypes.VirtualDeviceConfigSpecOperationAdd {
+					continue
+				}
+				if disk, ok := spec.Device.(*vimtypes.VirtualDisk); ok {
+					var p object.DatastorePath
+					Expect(p.FromString(disk.Backing.(*vimtypes.VirtualDiskFlatVer2BackingInfo).FileName)).To(BeTrue())
+					if name := path.Base(p.Path); strings.HasPrefix(name, volName+"-") {
+						return name
+					}
+				}
+			}
+			return ""
+		}
+
+		It("should create and add read-only disks with the data of the volumes", func() {
+			deviceChanges, extraConfig, err := updateVolumeDeviceChanges()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(deviceChanges).To(HaveLen(2))
+
+			for _, volName := range []string{"config", "creds"} {
+				name := dataDiskName(deviceChanges, volName)
+				Expect(name).To(HaveSuffix(constants.DataVolumeFileNameSuffix))
+				spec, disk := diskByFileName(deviceChanges, name)
+				Expect(disk).ToNot(BeNil())
+				Expect(spec.FileOperation).To(BeEmpty())
+				Expect(disk.Backing.(*vimtypes.VirtualDiskFlatVer2BackingInfo).DiskMode).To(
+					Equal(string(vimtypes.VirtualDiskModeIndependent_nonpersistent)))
+				Expect(disk.CapacityInBytes).To(Equal(int64(1024 * 1024)))
+				Expect(diskExists(name)).To(BeTrue())
+
+				// The hosted disk the disk is copied from is deleted.
+				srcName := strings.TrimSuffix(name, ".vmdk") + constants.DataVolumeSourceFileNameSuffix
+				Expect(diskExists(srcName)).To(BeFalse())
+				Expect(diskExists(strings.TrimSuffix(srcName, ".vmdk") + "-flat.vmdk")).To(BeFalse())
+			}
+
+			Expect(extraConfig).To(HaveLen(1))
+			Expect(extraConfig[0].GetOptionValue().Value).To(
+				And(ContainSubstring(`"config":`), ContainSubstring(`"creds":`)))
+		})
+
+		It("should return an error when a key is missing from the Secret", func() {
+			vmCtx.VM.Spec.Volumes[1].Secret.Items[0].Key = "missing"
+
+			_, _, err := updateVolumeDeviceChanges()
+			Expect(err).To(MatchError(ContainSubstring("error generating image for volume creds: key missing is not found in Secret my-secret")))
+		})
+
+		When("the disks of the volumes exist", func() {
+			var oldName string
+
+			JustBeforeEach(func() {
+				deviceChanges, extraConfig, err := updateVolumeDeviceChanges()
+				Expect(err).ToNot(HaveOccurred())
+				oldName = dataDiskName(deviceChanges, "config")
+				reconfigure(deviceChanges, extraConfig)
+			})
+
+			It("should not change the disks when the data is unchanged", func() {
+				deviceChanges, extraConfig, err := updateVolumeDeviceChanges()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(deviceChanges).To(BeEmpty())
+				Expect(extraConfig).To(BeEmpty())
+			})
+
+			It("should replace the disk of a volume when its data changes", func() {
+				cm := &corev1.ConfigMap{}
+				Expect(k8sClient.Get(ctx, ctrlclient.ObjectKey{Name: "my-config", Namespace: ns}, cm)).To(Succeed())
+				cm.Data["app.conf"] = "debug=false"
+				Expect(k8sClient.Update(ctx, cm)).To(Succeed())
+
+				deviceChanges, extraConfig, err := updateVolumeDeviceChanges()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(deviceChanges).To(HaveLen(2))
+
+				spec, disk := diskByFileName(deviceChanges, oldName)
+				Expect(disk).ToNot(BeNil())
+				Expect(spec.Operation).To(Equal(vimtypes.VirtualDeviceConfigSpecOperationRemove))
+				Expect(spec.FileOperation).To(Equal(vimtypes.VirtualDeviceConfigSpecFileOperationDestroy))
+
+				newName := dataDiskName(deviceChanges, "config")
+				Expect(newName).ToNot(BeEmpty())
+				Expect(newName).ToNot(Equal(oldName))
+				Expect(extraConfig).To(HaveLen(1))
+
+				reconfigure(deviceChanges, extraConfig)
+				Expect(diskExists(oldName)).To(BeFalse())
+				Expect(diskExists(newName)).To(BeTrue())
+			})
+		})
+	})
+}
@@ -15,6 +15,7 @@ import (
 	"fmt"
 
 	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/mo"
 	vimtypes "github.com/vmware/govmomi/vim25/types"
 
 	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
@@ -36,6 +37,115 @@ func GetWebConsoleTicket(
 	return EncryptWebMKS(pubKey, url)
 }
 
+// GetSerialConsoleTicket returns the encrypted URI used to connect to the VM's
+// serial console via the virtual serial port concentrator at proxyURI. The VM
+// must have the serial port that is added when the VM's
+// spec.advanced.serialConsoleEnabled is true.
+func GetSerialConsoleTicket(
+	vmCtx pkgctx.VirtualMachineContext,
+	vm *object.VirtualMachine,
+	proxyURI, pubKey, token string) (string, error) {
+
+	vmCtx.Logger.V(5).Info("GetSerialConsoleTicket")
+
+	var moVM mo.VirtualMachine
+	if err := vm.Properties(vmCtx, vm.Reference(), []string{"config.hardware.device"}, &moVM); err != nil {
+		return "", err
+	}
+
+	serviceURI := string(vmCtx.VM.UID)
+
+	var found bool
+	if moVM.Config != nil {
+		for _, dev := range moVM.Config.Hardware.Device {
+			if isSerialConsolePort(dev, serviceURI, proxyURI) {
+				found = true
+				break
+			}
+		}
+	}
+	if !found {
+		return "", errors.New("the VM does not have a serial console port")
+	}
+
+	url := fmt.Sprintf("serial://%s/ticket/%s", serviceURI, token)
+	return EncryptWebMKS(pubKey, url)
+}
+
+// UpdateSerialPortDeviceChanges returns the device changes that add the serial
+// console port to the VM when spec.advanced.serialConsoleEnabled is true and
+// the virtual serial port concentrator is configured, and remove it otherwise.
+// Only serial ports whose service URI is the VM's UID are managed.
+func UpdateSerialPortDeviceChanges(
+	vmCtx pkgctx.VirtualMachineContext,
+	proxyURI string,
+	currentDevices object.VirtualDeviceList) []vimtypes.BaseVirtualDeviceConfigSpec {
+
+	serviceURI := string(vmCtx.VM.UID)
+
+	var enabled bool
+	if adv := vmCtx.VM.Spec.Advanced; adv != nil && adv.SerialConsoleEnabled != nil {
+		enabled = *adv.SerialConsoleEnabled && proxyURI != ""
+	}
+
+	var (
+		deviceChanges []vimtypes.BaseVirtualDeviceConfigSpec
+		found         bool
+	)
+
+	for _, dev := range currentDevices.SelectByType((*vimtypes.VirtualSerialPort)(nil)) {
+		if enabled && !found && isSerialConsolePort(dev, serviceURI, proxyURI) {
+			found = true
+			continue
+		}
+		if isSerialConsolePort(dev, serviceURI, "") {
+			deviceChanges = append(deviceChanges, &vimtypes.VirtualDeviceConfigSpec{
+				Operation: vimtypes.VirtualDeviceConfigSpecOperationRemove,
+				Device:    dev,
+			})
+		}
+	}
+
+	if enabled && !found {
+		deviceChanges = append(deviceChanges, &vimtypes.VirtualDeviceConfigSpec{
+			Operation: vimtypes.VirtualDeviceConfigSpecOperationAdd,
+			Device: &vimtypes.VirtualSerialPort{
+				VirtualDevice: vimtypes.VirtualDevice{
+					Key: -1,
+					Backing: &vimtypes.VirtualSerialPortURIBackingInfo{
+						VirtualDeviceURIBackingInfo: vimtypes.VirtualDeviceURIBackingInfo{
+							ServiceURI: serviceURI,
+							Direction:  string(vimtypes.VirtualDeviceURIBackingOptionDirectionServer),
+							ProxyURI:   proxyURI,
+						},
+					},
+					Connectable: &vimtypes.VirtualDeviceConnectInfo{
+						StartConnected: true,
+						Connected:      true,
+					},
+				},
+				YieldOnPoll: true,
+			},
+		})
+	}
+
+	return deviceChanges
+}
+
+// isSerialConsolePort returns true if the device is a serial port backed by
+// the given service URI and, if not empty, proxy URI.
+func isSerialConsolePort(dev vimtypes.BaseVirtualDevice, serviceURI, proxyURI string) bool {
+	sp, ok := dev.(*vimtypes.VirtualSerialPort)
+	if !ok {
+		return false
+	}
+	b, ok := sp.Backing.(*vimtypes.VirtualSerialPortURIBackingInfo)
+	if !ok || b.ServiceURI != serviceURI {
+		return false
+	}
+	return proxyURI == "" || b.ProxyURI == proxyURI
+}
+
 func EncryptWebMKS(pubKey string, plaintext string) (string, error) {
 	block, _ := pem.Decode([]byte(pubKey))
 	if block == nil || block.Type != "PUBLIC KEY" {
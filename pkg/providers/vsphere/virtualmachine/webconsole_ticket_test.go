@@ -35,6 +35,18 @@ var _ = Describe("Webconsole Ticket", func() {
 			Expect(decrypted).To(Equal(plaintext))
 		})
 
+		It("Encrypts a serial console URI", func() {
+			// A serial console URI with a VM UID and 32 byte, base64 encoded
+			// token must fit in the plaintext limit of the key.
+			plaintext := "serial://7a2ef1b2-3c4d-4e5f-8a9b-0c1d2e3f4a5b/ticket/" +
+				"ZGVhZGJlZWZkZWFkYmVlZmRlYWRiZWVmZGVhZGJlZWY"
+			ciphertext, err := virtualmachine.EncryptWebMKS(publicKeyPem, plaintext)
+			Expect(err).ShouldNot(HaveOccurred())
+			decrypted, err := virtualmachine.DecryptWebMKS(privateKey, ciphertext)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(decrypted).To(Equal(plaintext))
+		})
+
 		It("Error on invalid public key", func() {
 			plaintext := "HelloWorld3"
 			_, err := virtualmachine.EncryptWebMKS("invalid-pub-key", plaintext)
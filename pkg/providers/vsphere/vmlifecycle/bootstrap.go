@@ -27,6 +27,7 @@ import (
 	pkgutil "github.com/vmware-tanzu/vm-operator/pkg/util"
 	"github.com/vmware-tanzu/vm-operator/pkg/util/cloudinit"
 	kubeutil "github.com/vmware-tanzu/vm-operator/pkg/util/kube"
+	vmopv1util "github.com/vmware-tanzu/vm-operator/pkg/util/vmopv1"
 )
 
 const (
@@ -69,9 +70,9 @@ func DoBootstrap(
 	if bootstrap == nil {
 		// V1ALPHA1: We had always defaulted to LinuxPrep w/ HwClockUTC=true.
 		// Now, try to just do that on Linux VMs.
-		// Skip if the VM has a CD-ROM as the Linux ISO-type image may not have
+		// Skip if the VM is deployed from an ISO-type image as it may not have
 		// the necessary tools to do the default LinuxPrep bootstrap.
-		if !isLinuxGuest(config.GuestId) || len(vmCtx.VM.Spec.Cdrom) > 0 {
+		if !isLinuxGuest(config.GuestId) || vmopv1util.IsISOVM(*vmCtx.VM) {
 			vmCtx.Logger.V(6).Info("no bootstrap provider specified")
 			return nil
 		}
@@ -87,6 +88,7 @@ func DoBootstrap(
 	linuxPrep := bootstrap.LinuxPrep
 	sysPrep := bootstrap.Sysprep
 	vAppConfig := bootstrap.VAppConfig
+	ignition := bootstrap.Ignition
 
 	if sysPrep != nil || vAppConfig != nil {
 		bootstrapArgs.TemplateRenderFn = GetTemplateRenderFunc(vmCtx, &bootstrapArgs)
@@ -105,6 +107,8 @@ func DoBootstrap(
 		configSpec, customSpec, err = BootstrapSysPrep(vmCtx, config, sysPrep, vAppConfig, &bootstrapArgs)
 	case vAppConfig != nil:
 		configSpec, customSpec, err = BootstrapVAppConfig(vmCtx, config, vAppConfig, &bootstrapArgs)
+	case ignition != nil:
+		configSpec, customSpec, err = BootstrapIgnition(vmCtx, config, ignition, &bootstrapArgs)
 	}
 
 	if err != nil {
@@ -142,6 +146,7 @@ func GetBootstrapArgs(
 	}
 
 	isCloudInit := bootstrap.CloudInit != nil
+	isIgnition := bootstrap.Ignition != nil
 	isGOSC := bootstrap.LinuxPrep != nil || bootstrap.Sysprep != nil
 
 	bsa := BootstrapArgs{
@@ -206,7 +211,7 @@ func GetBootstrapArgs(
 			bsa.SearchSuffixes = ss
 		}
 
-		if isCloudInit {
+		if isCloudInit || isIgnition {
 			// Previously we would apply the global DNS config to every
 			// interface so do that here too.
 			for i := range networkResults.Results {
@@ -356,7 +361,8 @@ func SanitizeConfigSpec(cs vimtypes.VirtualMachineConfigSpec) vimtypes.VirtualMa
 
 		// This is what is likely to contain any sensitive. We can expand this to vendor
 		// and metadata later if needed.
-		if optVal.Key == constants.CloudInitGuestInfoUserdata {
+		if optVal.Key == constants.CloudInitGuestInfoUserdata ||
+			optVal.Key == constants.IgnitionGuestInfoConfigData {
 			optValCopy := *optVal
 			optValCopy.Value = redacted
 			cs.ExtraConfig[i] = &optValCopy
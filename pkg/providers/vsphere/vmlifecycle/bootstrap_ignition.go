@@ -0,0 +1,342 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package vmlifecycle
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	vimtypes "github.com/vmware/govmomi/vim25/types"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/constants"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/network"
+	pkgutil "github.com/vmware-tanzu/vm-operator/pkg/util"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/netplan"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/ptr"
+)
+
+const (
+	// IgnitionConfigVersion is the version of the Ignition config spec that is
+	// rendered. It is supported by Flatcar Container Linux, Fedora CoreOS, and
+	// Red Hat Enterprise Linux CoreOS.
+	IgnitionConfigVersion = "3.2.0"
+
+	// ignitionDefaultFileMode is 0644.
+	ignitionDefaultFileMode = int32(420)
+
+	networkdDir = "/etc/systemd/network"
+)
+
+type ignitionConfig struct {
+	Ignition ignitionMetadata `json:"ignition"`
+	Passwd   *ignitionPasswd  `json:"passwd,omitempty"`
+	Storage  *ignitionStorage `json:"storage,omitempty"`
+	Systemd  *ignitionSystemd `json:"systemd,omitempty"`
+}
+
+type ignitionMetadata struct {
+	Version string                `json:"version"`
+	Config  *ignitionConfigMerges `json:"config,omitempty"`
+}
+
+type ignitionConfigMerges struct {
+	Merge []ignitionResource `json:"merge,omitempty"`
+}
+
+type ignitionResource struct {
+	Source string `json:"source"`
+}
+
+type ignitionPasswd struct {
+	Users []ignitionUser `json:"users,omitempty"`
+}
+
+type ignitionUser struct {
+	Name              string   `json:"name"`
+	Groups            []string `json:"groups,omitempty"`
+	SSHAuthorizedKeys []string `json:"sshAuthorizedKeys,omitempty"`
+}
+
+type ignitionStorage struct {
+	Files []ignitionFile `json:"files,omitempty"`
+}
+
+type ignitionFile struct {
+	Path      string           `json:"path"`
+	Overwrite *bool            `json:"overwrite,omitempty"`
+	Mode      *int32           `json:"mode,omitempty"`
+	Contents  ignitionResource `json:"contents"`
+}
+
+type ignitionSystemd struct {
+	Units []ignitionUnit `json:"units,omitempty"`
+}
+
+type ignitionUnit struct {
+	Name     string `json:"name"`
+	Enabled  *bool  `json:"enabled,omitempty"`
+	Contents string `json:"contents,omitempty"`
+}
+
+func BootstrapIgnition(
+	vmCtx pkgctx.VirtualMachineContext,
+	config *vimtypes.VirtualMachineConfigInfo,
+	ignitionSpec *vmopv1.VirtualMachineBootstrapIgnitionSpec,
+	bsArgs *BootstrapArgs) (*vimtypes.VirtualMachineConfigSpec, *vimtypes.CustomizationSpec, error) {
+
+	netPlan, err := network.NetPlanCustomization(bsArgs.NetworkResults)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create NetPlan customization: %w", err)
+	}
+
+	var rawConfig string
+	if raw := ignitionSpec.RawConfig; raw != nil {
+		rawConfig = bsArgs.BootstrapData.Data[raw.Key]
+		if rawConfig == "" {
+			return nil, nil, fmt.Errorf("ignition config key %q is empty", raw.Key)
+		}
+	}
+
+	data, err := GetIgnitionConfig(
+		bsArgs.HostName, bsArgs.DomainName, netPlan, ignitionSpec.Config, rawConfig)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	vmCtx.Logger.V(4).Info("Rendered Ignition config")
+
+	return GetIgnitionGuestInfoConfigSpec(config, data), nil, nil
+}
+
+// GetIgnitionConfig returns the Ignition config used to bootstrap the guest.
+// The config writes the guest's hostname and the systemd-networkd units that
+// are translated from the provided netplan, and then either includes the
+// typed config or merges the raw config.
+func GetIgnitionConfig(
+	hostName, domainName string,
+	netPlan *netplan.Network,
+	typedConfig *vmopv1.VirtualMachineBootstrapIgnitionConfig,
+	rawConfig string) (string, error) {
+
+	out := ignitionConfig{
+		Ignition: ignitionMetadata{
+			Version: IgnitionConfigVersion,
+		},
+	}
+
+	fqdn := hostName
+	if domainName != "" {
+		fqdn = hostName + "." + domainName
+	}
+
+	var files []ignitionFile
+	if fqdn != "" {
+		files = append(files, newIgnitionFile("/etc/hostname", fqdn+"\n", nil, ptr.To(true)))
+	}
+	files = append(files, netPlanToNetworkdFiles(netPlan)...)
+
+	if rawConfig != "" {
+		// Ensure the data is normalized first to plain-text.
+		plainText, err := pkgutil.TryToDecodeBase64Gzip([]byte(rawConfig))
+		if err != nil {
+			return "", fmt.Errorf("decoding ignition config failed: %w", err)
+		}
+		if !json.Valid([]byte(plainText)) {
+			return "", fmt.Errorf("ignition config is not valid JSON")
+		}
+
+		out.Ignition.Config = &ignitionConfigMerges{
+			Merge: []ignitionResource{
+				{
+					Source: ignitionDataURL(plainText),
+				},
+			},
+		}
+	} else if typedConfig != nil {
+		for _, u := range typedConfig.Users {
+			if out.Passwd == nil {
+				out.Passwd = &ignitionPasswd{}
+			}
+			out.Passwd.Users = append(out.Passwd.Users, ignitionUser{
+				Name:              u.Name,
+				Groups:            u.Groups,
+				SSHAuthorizedKeys: u.SSHAuthorizedKeys,
+			})
+		}
+
+		for _, f := range typedConfig.Files {
+			files = append(files, newIgnitionFile(f.Path, f.Contents, f.Mode, f.Overwrite))
+		}
+
+		for _, u := range typedConfig.SystemdUnits {
+			if out.Systemd == nil {
+				out.Systemd = &ignitionSystemd{}
+			}
+			out.Systemd.Units = append(out.Systemd.Units, ignitionUnit{
+				Name:     u.Name,
+				Enabled:  u.Enabled,
+				Contents: u.Contents,
+			})
+		}
+	}
+
+	if len(files) > 0 {
+		out.Storage = &ignitionStorage{
+			Files: files,
+		}
+	}
+
+	data, err := json.Marshal(out)
+	if err != nil {
+		return "", fmt.Errorf("json marshalling of ignition config failed: %w", err)
+	}
+
+	return string(data), nil
+}
+
+// netPlanToNetworkdFiles translates the netplan into the systemd-networkd
+// .link and .network files that configure the guest's interfaces.
+func netPlanToNetworkdFiles(netPlan *netplan.Network) []ignitionFile {
+	if netPlan == nil {
+		return nil
+	}
+
+	names := make([]string, 0, len(netPlan.Ethernets))
+	for name := range netPlan.Ethernets {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	var files []ignitionFile
+	for i, name := range names {
+		eth := netPlan.Ethernets[name]
+		prefix := fmt.Sprintf("%s/%02d-%s", networkdDir, 10+i, name)
+
+		var mac string
+		if eth.Match != nil {
+			mac = ptr.Deref(eth.Match.Macaddress)
+		}
+
+		ifName := ptr.Deref(eth.SetName)
+		if ifName != "" && mac != "" {
+			link := &strings.Builder{}
+			fmt.Fprintf(link, "[Match]\nMACAddress=%s\n\n[Link]\nName=%s\n", mac, ifName)
+			files = append(files, newIgnitionFile(prefix+".link", link.String(), nil, ptr.To(true)))
+		}
+
+		files = append(files, newIgnitionFile(
+			prefix+".network", networkdNetworkUnit(eth, mac, ifName), nil, ptr.To(true)))
+	}
+
+	return files
+}
+
+func networkdNetworkUnit(eth netplan.Ethernet, mac, ifName string) string {
+	sb := &strings.Builder{}
+
+	sb.WriteString("[Match]\n")
+	if mac != "" {
+		fmt.Fprintf(sb, "MACAddress=%s\n", mac)
+	} else if ifName != "" {
+		fmt.Fprintf(sb, "Name=%s\n", ifName)
+	}
+
+	if mtu := ptr.Deref(eth.MTU); mtu > 0 {
+		fmt.Fprintf(sb, "\n[Link]\nMTUBytes=%d\n", mtu)
+	}
+
+	dhcp4, dhcp6 := ptr.Deref(eth.Dhcp4), ptr.Deref(eth.Dhcp6)
+
+	sb.WriteString("\n[Network]\n")
+	switch {
+	case dhcp4 && dhcp6:
+		sb.WriteString("DHCP=yes\n")
+	case dhcp4:
+		sb.WriteString("DHCP=ipv4\n")
+	case dhcp6:
+		sb.WriteString("DHCP=ipv6\n")
+	default:
+		sb.WriteString("DHCP=no\n")
+	}
+
+	for _, a := range eth.Addresses {
+		if a.String != nil {
+			fmt.Fprintf(sb, "Address=%s\n", *a.String)
+		}
+	}
+	if gw := ptr.Deref(eth.Gateway4); gw != "" {
+		fmt.Fprintf(sb, "Gateway=%s\n", gw)
+	}
+	if gw := ptr.Deref(eth.Gateway6); gw != "" {
+		fmt.Fprintf(sb, "Gateway=%s\n", gw)
+	}
+	if ns := eth.Nameservers; ns != nil {
+		for _, a := range ns.Addresses {
+			fmt.Fprintf(sb, "DNS=%s\n", a)
+		}
+		if len(ns.Search) > 0 {
+			fmt.Fprintf(sb, "Domains=%s\n", strings.Join(ns.Search, " "))
+		}
+	}
+
+	for _, r := range eth.Routes {
+		sb.WriteString("\n[Route]\n")
+		if to := ptr.Deref(r.To); to != "" {
+			fmt.Fprintf(sb, "Destination=%s\n", to)
+		}
+		if via := ptr.Deref(r.Via); via != "" {
+			fmt.Fprintf(sb, "Gateway=%s\n", via)
+		}
+		if r.Metric != nil {
+			fmt.Fprintf(sb, "Metric=%s\n", strconv.FormatInt(*r.Metric, 10))
+		}
+	}
+
+	return sb.String()
+}
+
+func GetIgnitionGuestInfoConfigSpec(
+	config *vimtypes.VirtualMachineConfigInfo,
+	data string) *vimtypes.VirtualMachineConfigSpec {
+
+	extraConfig := pkgutil.OptionValues{
+		&vimtypes.OptionValue{
+			Key:   constants.IgnitionGuestInfoConfigData,
+			Value: base64.StdEncoding.EncodeToString([]byte(data)),
+		},
+		&vimtypes.OptionValue{
+			Key:   constants.IgnitionGuestInfoConfigDataEncoding,
+			Value: "base64",
+		},
+	}
+
+	return &vimtypes.VirtualMachineConfigSpec{
+		ExtraConfig: pkgutil.OptionValues(config.ExtraConfig).Diff(extraConfig...),
+	}
+}
+
+func newIgnitionFile(path, contents string, mode *int32, overwrite *bool) ignitionFile {
+	if mode == nil {
+		mode = ptr.To(ignitionDefaultFileMode)
+	}
+	return ignitionFile{
+		Path:      path,
+		Overwrite: overwrite,
+		Mode:      mode,
+		Contents: ignitionResource{
+			Source: ignitionDataURL(contents),
+		},
+	}
+}
+
+func ignitionDataURL(s string) string {
+	return "data:;base64," + base64.StdEncoding.EncodeToString([]byte(s))
+}
@@ -0,0 +1,286 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package vmlifecycle_test
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	vimtypes "github.com/vmware/govmomi/vim25/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	"github.com/vmware-tanzu/vm-operator/api/v1alpha3/common"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/constants"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/network"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/vmlifecycle"
+	pkgutil "github.com/vmware-tanzu/vm-operator/pkg/util"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/ptr"
+)
+
+// testIgnitionConfig is the subset of an Ignition config inspected by the
+// tests.
+type testIgnitionConfig struct {
+	Ignition struct {
+		Version string `json:"version"`
+		Config  *struct {
+			Merge []struct {
+				Source string `json:"source"`
+			} `json:"merge"`
+		} `json:"config"`
+	} `json:"ignition"`
+	Passwd *struct {
+		Users []struct {
+			Name              string   `json:"name"`
+			SSHAuthorizedKeys []string `json:"sshAuthorizedKeys"`
+		} `json:"users"`
+	} `json:"passwd"`
+	Storage *struct {
+		Files []struct {
+			Path     string `json:"path"`
+			Mode     int32  `json:"mode"`
+			Contents struct {
+				Source string `json:"source"`
+			} `json:"contents"`
+		} `json:"files"`
+	} `json:"storage"`
+	Systemd *struct {
+		Units []struct {
+			Name    string `json:"name"`
+			Enabled *bool  `json:"enabled"`
+		} `json:"units"`
+	} `json:"systemd"`
+}
+
+func decodeIgnitionDataURL(s string) string {
+	ExpectWithOffset(1, s).To(HavePrefix("data:;base64,"))
+	data, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(s, "data:;base64,"))
+	ExpectWithOffset(1, err).ToNot(HaveOccurred())
+	return string(data)
+}
+
+var _ = Describe("Ignition Bootstrap", func() {
+
+	var (
+		bsArgs       vmlifecycle.BootstrapArgs
+		configInfo   *vimtypes.VirtualMachineConfigInfo
+		ignitionSpec *vmopv1.VirtualMachineBootstrapIgnitionSpec
+		vmCtx        pkgctx.VirtualMachineContext
+
+		configSpec *vimtypes.VirtualMachineConfigSpec
+		custSpec   *vimtypes.CustomizationSpec
+		err        error
+
+		ignConfig testIgnitionConfig
+		files     map[string]string
+	)
+
+	BeforeEach(func() {
+		configInfo = &vimtypes.VirtualMachineConfigInfo{}
+		ignitionSpec = &vmopv1.VirtualMachineBootstrapIgnitionSpec{}
+
+		bsArgs = vmlifecycle.BootstrapArgs{
+			HostName:   "my-vm",
+			DomainName: "example.com",
+		}
+		bsArgs.Data = map[string]string{}
+		bsArgs.NetworkResults.Results = []network.NetworkInterfaceResult{
+			{
+				Name:            "eth0",
+				GuestDeviceName: "eth0",
+				MacAddress:      "00-50-56-AA-BB-CC",
+				MTU:             1500,
+				IPConfigs: []network.NetworkInterfaceIPConfig{
+					{
+						IPCIDR:  "192.168.1.10/24",
+						IsIPv4:  true,
+						Gateway: "192.168.1.1",
+					},
+				},
+				Nameservers:   []string{"8.8.8.8"},
+				SearchDomains: []string{"example.com"},
+			},
+		}
+
+		vmCtx = pkgctx.VirtualMachineContext{
+			Context: context.Background(),
+			Logger:  suite.GetLogger(),
+			VM: &vmopv1.VirtualMachine{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "ignition-bootstrap-test",
+					Namespace: "test-ns",
+				},
+			},
+		}
+
+		ignConfig = testIgnitionConfig{}
+		files = map[string]string{}
+	})
+
+	JustBeforeEach(func() {
+		configSpec, custSpec, err = vmlifecycle.BootstrapIgnition(
+			vmCtx,
+			configInfo,
+			ignitionSpec,
+			&bsArgs,
+		)
+
+		if err != nil {
+			return
+		}
+
+		extraConfig := pkgutil.OptionValues(configSpec.ExtraConfig)
+		encoding, _ := extraConfig.GetString(constants.IgnitionGuestInfoConfigDataEncoding)
+		Expect(encoding).To(Equal("base64"))
+
+		encoded, _ := extraConfig.GetString(constants.IgnitionGuestInfoConfigData)
+		data, err := base64.StdEncoding.DecodeString(encoded)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(json.Unmarshal(data, &ignConfig)).To(Succeed())
+
+		if ignConfig.Storage != nil {
+			for _, f := range ignConfig.Storage.Files {
+				files[f.Path] = decodeIgnitionDataURL(f.Contents.Source)
+			}
+		}
+	})
+
+	It("writes the hostname and network units", func() {
+		Expect(err).ToNot(HaveOccurred())
+		Expect(custSpec).To(BeNil())
+		Expect(ignConfig.Ignition.Version).To(Equal(vmlifecycle.IgnitionConfigVersion))
+
+		Expect(files).To(HaveKeyWithValue("/etc/hostname", "my-vm.example.com\n"))
+		Expect(files).To(HaveKeyWithValue("/etc/systemd/network/10-eth0.link",
+			"[Match]\nMACAddress=00:50:56:aa:bb:cc\n\n[Link]\nName=eth0\n"))
+		Expect(files).To(HaveKeyWithValue("/etc/systemd/network/10-eth0.network",
+			"[Match]\nMACAddress=00:50:56:aa:bb:cc\n\n"+
+				"[Link]\nMTUBytes=1500\n\n"+
+				"[Network]\nDHCP=no\nAddress=192.168.1.10/24\nGateway=192.168.1.1\n"+
+				"DNS=8.8.8.8\nDomains=example.com\n"))
+	})
+
+	When("the interface uses DHCP", func() {
+		BeforeEach(func() {
+			bsArgs.NetworkResults.Results[0].DHCP4 = true
+			bsArgs.NetworkResults.Results[0].DHCP6 = true
+			bsArgs.NetworkResults.Results[0].IPConfigs = nil
+		})
+
+		It("enables DHCP in the network unit", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(files["/etc/systemd/network/10-eth0.network"]).To(ContainSubstring("DHCP=yes\n"))
+			Expect(files["/etc/systemd/network/10-eth0.network"]).ToNot(ContainSubstring("\nAddress="))
+		})
+	})
+
+	When("inlined config", func() {
+		BeforeEach(func() {
+			ignitionSpec.Config = &vmopv1.VirtualMachineBootstrapIgnitionConfig{
+				Users: []vmopv1.VirtualMachineBootstrapIgnitionUser{
+					{
+						Name:              "core",
+						SSHAuthorizedKeys: []string{"ssh-rsa AAAA"},
+					},
+				},
+				Files: []vmopv1.VirtualMachineBootstrapIgnitionFile{
+					{
+						Path:     "/etc/motd",
+						Contents: "hello",
+						Mode:     ptr.To[int32](384),
+					},
+				},
+				SystemdUnits: []vmopv1.VirtualMachineBootstrapIgnitionSystemdUnit{
+					{
+						Name:    "hello.service",
+						Enabled: ptr.To(true),
+					},
+				},
+			}
+		})
+
+		It("includes the config", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ignConfig.Ignition.Config).To(BeNil())
+
+			Expect(ignConfig.Passwd).ToNot(BeNil())
+			Expect(ignConfig.Passwd.Users).To(HaveLen(1))
+			Expect(ignConfig.Passwd.Users[0].Name).To(Equal("core"))
+			Expect(ignConfig.Passwd.Users[0].SSHAuthorizedKeys).To(ConsistOf("ssh-rsa AAAA"))
+
+			Expect(files).To(HaveKeyWithValue("/etc/motd", "hello"))
+			for _, f := range ignConfig.Storage.Files {
+				if f.Path == "/etc/motd" {
+					Expect(f.Mode).To(Equal(int32(384)))
+				}
+			}
+
+			Expect(ignConfig.Systemd).ToNot(BeNil())
+			Expect(ignConfig.Systemd.Units).To(HaveLen(1))
+			Expect(ignConfig.Systemd.Units[0].Name).To(Equal("hello.service"))
+			Expect(ignConfig.Systemd.Units[0].Enabled).To(HaveValue(BeTrue()))
+		})
+	})
+
+	When("raw config", func() {
+		const rawConfig = `{"ignition":{"version":"3.3.0"},"passwd":{"users":[{"name":"core"}]}}`
+
+		BeforeEach(func() {
+			ignitionSpec.RawConfig = &common.SecretKeySelector{
+				Name: "my-secret",
+				Key:  "config.ign",
+			}
+			bsArgs.Data["config.ign"] = rawConfig
+		})
+
+		It("merges the raw config", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ignConfig.Passwd).To(BeNil())
+			Expect(ignConfig.Ignition.Config).ToNot(BeNil())
+			Expect(ignConfig.Ignition.Config.Merge).To(HaveLen(1))
+			Expect(decodeIgnitionDataURL(ignConfig.Ignition.Config.Merge[0].Source)).To(Equal(rawConfig))
+			Expect(files).To(HaveKey("/etc/systemd/network/10-eth0.network"))
+		})
+
+		When("raw config is gzipped and base64-encoded", func() {
+			BeforeEach(func() {
+				data, err := pkgutil.EncodeGzipBase64(rawConfig)
+				Expect(err).ToNot(HaveOccurred())
+				bsArgs.Data["config.ign"] = data
+			})
+
+			It("merges the decoded raw config", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(decodeIgnitionDataURL(ignConfig.Ignition.Config.Merge[0].Source)).To(Equal(rawConfig))
+			})
+		})
+
+		When("raw config is not JSON", func() {
+			BeforeEach(func() {
+				bsArgs.Data["config.ign"] = "variant: fcos"
+			})
+
+			It("returns an error", func() {
+				Expect(err).To(MatchError("ignition config is not valid JSON"))
+			})
+		})
+
+		When("raw config key is missing", func() {
+			BeforeEach(func() {
+				delete(bsArgs.Data, "config.ign")
+			})
+
+			It("returns an error", func() {
+				Expect(err).To(MatchError(`ignition config key "config.ign" is empty`))
+			})
+		})
+	})
+})
@@ -81,6 +81,23 @@ var _ = Describe("SanitizeConfigSpec", func() {
 		})
 	})
 
+	When("EC IgnitionGuestInfoConfigData", func() {
+		BeforeEach(func() {
+			inConfigSpec.ExtraConfig = append(inConfigSpec.ExtraConfig, &vimtypes.OptionValue{
+				Key:   constants.IgnitionGuestInfoConfigData,
+				Value: "value",
+			})
+		})
+
+		It("redacts value", func() {
+			Expect(inConfigSpec.ExtraConfig[0].GetOptionValue().Value).To(Equal("value"))
+
+			Expect(outConfigSpec.ExtraConfig).To(HaveLen(1))
+			Expect(outConfigSpec.ExtraConfig[0].GetOptionValue().Key).To(Equal(constants.IgnitionGuestInfoConfigData))
+			Expect(outConfigSpec.ExtraConfig[0].GetOptionValue().Value).To(Equal("***"))
+		})
+	})
+
 	When("vAppConfig user property", func() {
 		BeforeEach(func() {
 			inConfigSpec.VAppConfig = &vimtypes.VmConfigSpec{
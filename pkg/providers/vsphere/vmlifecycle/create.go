@@ -19,6 +19,11 @@ type CreateArgs struct {
 	UseContentLibrary bool
 	ProviderItemID    string
 
+	// CloneSourceMoID is the managed object ID of the vSphere VM that is
+	// cloned when the VM is created from another VirtualMachine.
+	CloneSourceMoID string
+	LinkedClone     bool
+
 	ConfigSpec          vimtypes.VirtualMachineConfigSpec
 	StorageProvisioning string
 	DatacenterMoID      string
@@ -52,6 +57,9 @@ func CreateVirtualMachine(
 	finder *find.Finder,
 	createArgs *CreateArgs) (*vimtypes.ManagedObjectReference, error) {
 
+	if createArgs.CloneSourceMoID != "" {
+		return cloneVMFromVM(vmCtx, vimClient, createArgs)
+	}
 	if createArgs.UseContentLibrary {
 		return deployFromContentLibrary(vmCtx, restClient, vimClient, createArgs)
 	}
@@ -9,11 +9,15 @@ import (
 
 	"github.com/vmware/govmomi/find"
 	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25"
+	"github.com/vmware/govmomi/vim25/mo"
 	vimtypes "github.com/vmware/govmomi/vim25/types"
 
 	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
 	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/placement"
+	pkgutil "github.com/vmware-tanzu/vm-operator/pkg/util"
 	"github.com/vmware-tanzu/vm-operator/pkg/util/ptr"
+	vmopv1util "github.com/vmware-tanzu/vm-operator/pkg/util/vmopv1"
 )
 
 // CloneVMFromInventory creates a new VM by cloning the source VM. This is not reachable/used
@@ -36,6 +40,105 @@ func cloneVMFromInventory(
 		return nil, fmt.Errorf("failed to create CloneSpec: %w", err)
 	}
 
+	return cloneVM(vmCtx, srcVM, cloneSpec)
+}
+
+// cloneVMFromVM creates a new VM by cloning the vSphere VM that backs another
+// VirtualMachine. The clone keeps the source VM's virtual hardware, except for
+// its network interfaces, which are replaced with the ones in the ConfigSpec,
+// and its FCDs, which are not cloned.
+func cloneVMFromVM(
+	vmCtx pkgctx.VirtualMachineContext,
+	vimClient *vim25.Client,
+	createArgs *CreateArgs) (*vimtypes.ManagedObjectReference, error) {
+
+	srcVM := object.NewVirtualMachine(vimClient, vimtypes.ManagedObjectReference{
+		Type:  "VirtualMachine",
+		Value: createArgs.CloneSourceMoID,
+	})
+
+	var srcMoVM mo.VirtualMachine
+	if err := srcVM.Properties(
+		vmCtx,
+		srcVM.Reference(),
+		[]string{"config.hardware.device", "snapshot"},
+		&srcMoVM); err != nil {
+
+		return nil, fmt.Errorf("failed to get clone source VM properties: %w", err)
+	}
+
+	var srcDevices object.VirtualDeviceList
+	if srcMoVM.Config != nil {
+		srcDevices = srcMoVM.Config.Hardware.Device
+	}
+	updateConfigSpecForVMClone(&createArgs.ConfigSpec, srcDevices)
+
+	cloneSpec, err := createCloneSpec(vmCtx, createArgs, srcVM)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create CloneSpec: %w", err)
+	}
+
+	if createArgs.LinkedClone {
+		if srcMoVM.Snapshot == nil || srcMoVM.Snapshot.CurrentSnapshot == nil {
+			return nil, fmt.Errorf(
+				"linked clone source VM %s does not have a current snapshot",
+				createArgs.CloneSourceMoID)
+		}
+
+		cloneSpec.Snapshot = srcMoVM.Snapshot.CurrentSnapshot
+		for i := range cloneSpec.Location.Disk {
+			cloneSpec.Location.Disk[i].DiskMoveType =
+				string(vimtypes.VirtualMachineRelocateDiskMoveOptionsCreateNewChildDiskBacking)
+			// The backing of a delta disk is inherited from its parent.
+			cloneSpec.Location.Disk[i].DiskBackingInfo = nil
+		}
+	}
+
+	return cloneVM(vmCtx, srcVM, cloneSpec)
+}
+
+// updateConfigSpecForVMClone updates the ConfigSpec used to clone another VM so
+// that the source VM's network interfaces and FCDs are removed, and only the
+// network interfaces are added from the ConfigSpec. The source VM already has
+// the remaining devices from its class.
+func updateConfigSpecForVMClone(
+	configSpec *vimtypes.VirtualMachineConfigSpec,
+	srcDevices object.VirtualDeviceList) {
+
+	// The clone keeps the firmware and hardware version of the source VM.
+	configSpec.Firmware = ""
+	configSpec.Version = ""
+
+	var deviceChanges []vimtypes.BaseVirtualDeviceConfigSpec
+
+	for _, dev := range srcDevices {
+		if pkgutil.IsEthernetCard(dev) || isFCD(dev) {
+			deviceChanges = append(deviceChanges, &vimtypes.VirtualDeviceConfigSpec{
+				Operation: vimtypes.VirtualDeviceConfigSpecOperationRemove,
+				Device:    dev,
+			})
+		}
+	}
+
+	for _, dc := range configSpec.DeviceChange {
+		if spec := dc.GetVirtualDeviceConfigSpec(); spec != nil && pkgutil.IsEthernetCard(spec.Device) {
+			deviceChanges = append(deviceChanges, dc)
+		}
+	}
+
+	configSpec.DeviceChange = deviceChanges
+}
+
+func isFCD(dev vimtypes.BaseVirtualDevice) bool {
+	disk, ok := dev.(*vimtypes.VirtualDisk)
+	return ok && disk.VDiskId != nil && disk.VDiskId.Id != ""
+}
+
+func cloneVM(
+	vmCtx pkgctx.VirtualMachineContext,
+	srcVM *object.VirtualMachine,
+	cloneSpec *vimtypes.VirtualMachineCloneSpec) (*vimtypes.ManagedObjectReference, error) {
+
 	// We always set cloneSpec.Location.Folder so use that to get the parent folder object.
 	folder := object.NewFolder(srcVM.Client(), *cloneSpec.Location.Folder)
 
@@ -68,7 +171,9 @@ func createCloneSpec(
 		return nil, fmt.Errorf("failed to get clone source VM devices: %w", err)
 	}
 
-	virtualDisks := virtualDevices.SelectByType((*vimtypes.VirtualDisk)(nil))
+	// FCDs are managed by PVCs and are never cloned.
+	virtualDisks := virtualDevices.SelectByType((*vimtypes.VirtualDisk)(nil)).Select(
+		func(dev vimtypes.BaseVirtualDevice) bool { return !isFCD(dev) })
 
 	for _, deviceChange := range resizeBootDiskDeviceChange(vmCtx, virtualDisks) {
 		if deviceChange.GetVirtualDeviceConfigSpec().Operation == vimtypes.VirtualDeviceConfigSpecOperationEdit {
@@ -171,7 +276,7 @@ func resizeBootDiskDeviceChange(
 
 	// Skip resizing ISO VMs with attached CD-ROMs as their boot disks are FCDs
 	// and should be managed by PVCs.
-	if len(vmCtx.VM.Spec.Cdrom) > 0 {
+	if vmopv1util.IsISOVM(*vmCtx.VM) {
 		return nil
 	}
 
@@ -0,0 +1,106 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package vmlifecycle
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/vmware/govmomi/object"
+	vimtypes "github.com/vmware/govmomi/vim25/types"
+)
+
+var _ = Describe("updateConfigSpecForVMClone", func() {
+
+	var (
+		configSpec vimtypes.VirtualMachineConfigSpec
+		srcDevices object.VirtualDeviceList
+
+		srcNIC      *vimtypes.VirtualVmxnet3
+		srcBootDisk *vimtypes.VirtualDisk
+		srcFCD      *vimtypes.VirtualDisk
+		newNIC      *vimtypes.VirtualVmxnet3
+	)
+
+	BeforeEach(func() {
+		srcNIC = &vimtypes.VirtualVmxnet3{
+			VirtualVmxnet: vimtypes.VirtualVmxnet{
+				VirtualEthernetCard: vimtypes.VirtualEthernetCard{
+					VirtualDevice: vimtypes.VirtualDevice{Key: 4000},
+					MacAddress:    "00:50:56:00:00:01",
+				},
+			},
+		}
+		srcBootDisk = &vimtypes.VirtualDisk{
+			VirtualDevice: vimtypes.VirtualDevice{Key: 2000},
+		}
+		srcFCD = &vimtypes.VirtualDisk{
+			VirtualDevice: vimtypes.VirtualDevice{Key: 2001},
+			VDiskId:       &vimtypes.ID{Id: "fcd-1"},
+		}
+		srcDevices = object.VirtualDeviceList{srcNIC, srcBootDisk, srcFCD}
+
+		newNIC = &vimtypes.VirtualVmxnet3{
+			VirtualVmxnet: vimtypes.VirtualVmxnet{
+				VirtualEthernetCard: vimtypes.VirtualEthernetCard{
+					VirtualDevice: vimtypes.VirtualDevice{Key: -100},
+				},
+			},
+		}
+		configSpec = vimtypes.VirtualMachineConfigSpec{
+			Firmware: "efi",
+			Version:  "vmx-21",
+			DeviceChange: []vimtypes.BaseVirtualDeviceConfigSpec{
+				&vimtypes.VirtualDeviceConfigSpec{
+					Operation: vimtypes.VirtualDeviceConfigSpecOperationAdd,
+					Device:    &vimtypes.VirtualPCIPassthrough{},
+				},
+				&vimtypes.VirtualDeviceConfigSpec{
+					Operation: vimtypes.VirtualDeviceConfigSpecOperationAdd,
+					Device:    newNIC,
+				},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		updateConfigSpecForVMClone(&configSpec, srcDevices)
+	})
+
+	It("keeps the source VM's firmware and hardware version", func() {
+		Expect(configSpec.Firmware).To(BeEmpty())
+		Expect(configSpec.Version).To(BeEmpty())
+	})
+
+	It("replaces the source VM's NICs and removes its FCDs", func() {
+		Expect(configSpec.DeviceChange).To(HaveLen(3))
+
+		dc0 := configSpec.DeviceChange[0].GetVirtualDeviceConfigSpec()
+		Expect(dc0.Operation).To(Equal(vimtypes.VirtualDeviceConfigSpecOperationRemove))
+		Expect(dc0.Device).To(BeIdenticalTo(srcNIC))
+
+		dc1 := configSpec.DeviceChange[1].GetVirtualDeviceConfigSpec()
+		Expect(dc1.Operation).To(Equal(vimtypes.VirtualDeviceConfigSpecOperationRemove))
+		Expect(dc1.Device).To(BeIdenticalTo(srcFCD))
+
+		dc2 := configSpec.DeviceChange[2].GetVirtualDeviceConfigSpec()
+		Expect(dc2.Operation).To(Equal(vimtypes.VirtualDeviceConfigSpecOperationAdd))
+		Expect(dc2.Device).To(BeIdenticalTo(newNIC))
+	})
+
+	When("the VM has no network interfaces", func() {
+		BeforeEach(func() {
+			configSpec.DeviceChange = nil
+		})
+
+		It("removes the source VM's NICs", func() {
+			Expect(configSpec.DeviceChange).To(HaveLen(2))
+			for _, dc := range configSpec.DeviceChange {
+				Expect(dc.GetVirtualDeviceConfigSpec().Operation).To(
+					Equal(vimtypes.VirtualDeviceConfigSpecOperationRemove))
+			}
+		})
+	})
+})
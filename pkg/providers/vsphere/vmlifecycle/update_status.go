@@ -32,6 +32,7 @@ import (
 	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
 	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/network"
 	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/vcenter"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/virtualmachine"
 	vmoprecord "github.com/vmware-tanzu/vm-operator/pkg/record"
 	"github.com/vmware-tanzu/vm-operator/pkg/topology"
 	"github.com/vmware-tanzu/vm-operator/pkg/util"
@@ -52,6 +53,7 @@ var (
 		"guest",
 		"resourcePool",
 		"runtime",
+		"snapshot",
 		"summary",
 	}
 )
@@ -99,6 +101,10 @@ func UpdateStatus(
 	updateGuestNetworkStatus(vmCtx.VM, vmCtx.MoVM.Guest)
 	updateStorageStatus(vmCtx.VM, vmCtx.MoVM)
 
+	if pkgcfg.FromContext(vmCtx).Features.VMSnapshots {
+		updateSnapshotStatus(vmCtx.VM, vmCtx.MoVM)
+	}
+
 	if pkgcfg.FromContext(vmCtx).AsyncSignalEnabled {
 		updateProbeStatus(vmCtx, vm, vmCtx.MoVM)
 	}
@@ -739,6 +745,24 @@ func updateGuestNetworkStatus(vm *vmopv1.VirtualMachine, gi *vimtypes.GuestInfo)
 	}
 }
 
+// updateSnapshotStatus updates the status for all snapshot-related fields.
+func updateSnapshotStatus(vm *vmopv1.VirtualMachine, moVM mo.VirtualMachine) {
+	if moVM.Snapshot == nil {
+		vm.Status.CurrentSnapshot = nil
+		vm.Status.RootSnapshots = nil
+		return
+	}
+
+	if name := virtualmachine.CurrentSnapshotName(moVM); name != "" {
+		ref := virtualmachine.SnapshotRef(name)
+		vm.Status.CurrentSnapshot = &ref
+	} else {
+		vm.Status.CurrentSnapshot = nil
+	}
+
+	vm.Status.RootSnapshots = virtualmachine.SnapshotRefs(moVM.Snapshot.RootSnapshotList)
+}
+
 // updateStorageStatus updates the status for all storage-related fields.
 func updateStorageStatus(vm *vmopv1.VirtualMachine, moVM mo.VirtualMachine) {
 	updateChangeBlockTracking(vm, moVM)
@@ -834,6 +858,17 @@ func updateVolumeStatus(vm *vmopv1.VirtualMachine, moVM mo.VirtualMachine) {
 
 	existingDisksInConfig := map[string]struct{}{}
 
+	// The classic disks of the volumes that are not backed by a PVC are named
+	// after their volume.
+	ephemeralVolumeNames := map[string]string{}
+	if diskUUIDs, err := virtualmachine.GetEphemeralVolumeDiskUUIDs(
+		util.OptionValues(moVM.Config.ExtraConfig)); err == nil {
+
+		for name, diskUUID := range diskUUIDs {
+			ephemeralVolumeNames[diskUUID] = name
+		}
+	}
+
 	for i := range moVM.Config.Hardware.Device {
 		vd, ok := moVM.Config.Hardware.Device[i].(*vimtypes.VirtualDisk)
 		if !ok {
@@ -888,9 +923,13 @@ func updateVolumeStatus(vm *vmopv1.VirtualMachine, moVM mo.VirtualMachine) {
 			// The disk is a classic, non-FCD that must be added to the list of
 			// volume statuses.
 			di, _ := vmdk.GetVirtualDiskInfoByUUID(ctx, nil, moVM, false, diskUUID)
-			dp := diskPath.Path
+			name, ok := ephemeralVolumeNames[virtualmachine.NormalizeDiskUUID(diskUUID)]
+			if !ok {
+				dp := diskPath.Path
+				name = strings.TrimSuffix(path.Base(dp), path.Ext(dp))
+			}
 			volStatus := vmopv1.VirtualMachineVolumeStatus{
-				Name:     strings.TrimSuffix(path.Base(dp), path.Ext(dp)),
+				Name:     name,
 				Type:     vmopv1.VirtualMachineStorageDiskTypeClassic,
 				Attached: true,
 				DiskUUID: diskUUID,
@@ -963,14 +1002,16 @@ func heartbeatValue(value string) int {
 
 // updateProbeStatus updates a VM's status with the results of the configured
 // readiness probes.
-// Please note, this function returns early if the configured probe is TCP.
+// Please note, this function returns early if the configured probe is TCP or
+// HTTP GET.
 func updateProbeStatus(
 	ctx context.Context,
 	vm *vmopv1.VirtualMachine,
 	moVM mo.VirtualMachine) {
 
 	p := vm.Spec.ReadinessProbe
-	if p == nil || p.TCPSocket != nil {
+	if p == nil || vmopv1util.IsReadinessProbePeriodic(*vm) {
+		// Periodic probes are run by the probe manager.
 		return
 	}
 
@@ -23,6 +23,7 @@ import (
 	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
 	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
 	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/constants"
 	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/network"
 	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/vmlifecycle"
 	"github.com/vmware-tanzu/vm-operator/pkg/record"
@@ -1098,6 +1099,21 @@ var _ = Describe("UpdateStatus", func() {
 				})
 			})
 
+			When("there is a classic disk of an ephemeral volume", func() {
+				BeforeEach(func() {
+					vmCtx.MoVM.Config.ExtraConfig = append(vmCtx.MoVM.Config.ExtraConfig,
+						&vimtypes.OptionValue{
+							Key:   constants.EphemeralVolumesExtraConfigKey,
+							Value: `{"scratch":"100"}`,
+						})
+				})
+				Specify("status.volumes uses the name of the volume", func() {
+					Expect(vmCtx.VM.Status.Volumes).To(HaveLen(5))
+					Expect(vmCtx.VM.Status.Volumes[0].Name).To(Equal("scratch"))
+					Expect(vmCtx.VM.Status.Volumes[1].Name).To(Equal("my-disk-101"))
+				})
+			})
+
 			When("there is a classic disk w an invalid path", func() {
 				BeforeEach(func() {
 					vmCtx.MoVM.Config.Hardware.
@@ -1188,6 +1204,18 @@ var _ = Describe("UpdateStatus", func() {
 			})
 		})
 
+		When("there is a GuestHeartbeat probe with a success threshold", func() {
+			BeforeEach(func() {
+				vmCtx.VM.Spec.ReadinessProbe = &vmopv1.VirtualMachineReadinessProbeSpec{
+					GuestHeartbeat:   &vmopv1.GuestHeartbeatAction{},
+					SuccessThreshold: 2,
+				}
+			})
+			It("should not update status", func() {
+				Expect(conditions.Has(vmCtx.VM, vmopv1.ReadyConditionType)).To(BeFalse())
+			})
+		})
+
 		When("there is a GuestHeartbeat probe", func() {
 			BeforeEach(func() {
 				vmCtx.VM.Spec.ReadinessProbe = &vmopv1.VirtualMachineReadinessProbeSpec{
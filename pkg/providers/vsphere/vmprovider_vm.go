@@ -10,11 +10,13 @@ import (
 	"fmt"
 	"maps"
 	"math/rand"
+	"slices"
 	"strings"
 	"sync"
 	"text/template"
 	"time"
 
+	"github.com/vmware/govmomi/fault"
 	"github.com/vmware/govmomi/object"
 	"github.com/vmware/govmomi/ovf"
 	"github.com/vmware/govmomi/pbm"
@@ -23,6 +25,7 @@ import (
 	"github.com/vmware/govmomi/vim25/mo"
 	vimtypes "github.com/vmware/govmomi/vim25/types"
 	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	apierrorsutil "k8s.io/apimachinery/pkg/util/errors"
@@ -37,6 +40,7 @@ import (
 	"github.com/vmware-tanzu/vm-operator/api/v1alpha3/common"
 	pkgcnd "github.com/vmware-tanzu/vm-operator/pkg/conditions"
 	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
+	pkgconst "github.com/vmware-tanzu/vm-operator/pkg/constants"
 	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
 	ctxop "github.com/vmware-tanzu/vm-operator/pkg/context/operation"
 	pkgerr "github.com/vmware-tanzu/vm-operator/pkg/errors"
@@ -315,6 +319,31 @@ func (vs *vSphereVMProvider) PublishVirtualMachine(
 	return itemID, nil
 }
 
+func (vs *vSphereVMProvider) ExportVirtualMachine(
+	ctx context.Context,
+	vm *vmopv1.VirtualMachine,
+	name string,
+	writeFileFn providers.ExportFileFn) error {
+
+	vmCtx := pkgctx.VirtualMachineContext{
+		Context: context.WithValue(ctx, vimtypes.ID{}, vs.getOpID(vm, "exportVM")),
+		Logger:  log.WithValues("vmName", vm.NamespacedName()),
+		VM:      vm,
+	}
+
+	client, err := vs.getVcClient(vmCtx)
+	if err != nil {
+		return fmt.Errorf("failed to get vCenter client: %w", err)
+	}
+
+	vcVM, err := vs.getVM(vmCtx, client, true)
+	if err != nil {
+		return err
+	}
+
+	return virtualmachine.ExportOVF(vmCtx, vcVM, name, writeFileFn)
+}
+
 func (vs *vSphereVMProvider) GetVirtualMachineGuestHeartbeat(
 	ctx context.Context,
 	vm *vmopv1.VirtualMachine) (vmopv1.GuestHeartbeatStatus, error) {
@@ -432,6 +461,35 @@ func (vs *vSphereVMProvider) GetVirtualMachineWebMKSTicket(
 	return ticket, nil
 }
 
+func (vs *vSphereVMProvider) GetVirtualMachineSerialConsoleTicket(
+	ctx context.Context,
+	vm *vmopv1.VirtualMachine,
+	pubKey, token string) (string, error) {
+
+	vmCtx := pkgctx.VirtualMachineContext{
+		Context: context.WithValue(ctx, vimtypes.ID{}, vs.getOpID(vm, "serialconsole")),
+		Logger:  log.WithValues("vmName", vm.NamespacedName()),
+		VM:      vm,
+	}
+
+	proxyURI := pkgcfg.FromContext(vmCtx).SerialConsoleProxyURI
+	if proxyURI == "" {
+		return "", errors.New("serial console proxy is not configured")
+	}
+
+	client, err := vs.getVcClient(vmCtx)
+	if err != nil {
+		return "", err
+	}
+
+	vcVM, err := vs.getVM(vmCtx, client, true)
+	if err != nil {
+		return "", err
+	}
+
+	return virtualmachine.GetSerialConsoleTicket(vmCtx, vcVM, proxyURI, pubKey, token)
+}
+
 func (vs *vSphereVMProvider) GetVirtualMachineHardwareVersion(
 	ctx context.Context,
 	vm *vmopv1.VirtualMachine) (vimtypes.HardwareVersion, error) {
@@ -461,12 +519,186 @@ func (vs *vSphereVMProvider) GetVirtualMachineHardwareVersion(
 	return vimtypes.ParseHardwareVersion(o.Config.Version)
 }
 
+func (vs *vSphereVMProvider) CreateOrUpdateVirtualMachineSnapshot(
+	ctx context.Context,
+	vm *vmopv1.VirtualMachine,
+	vmSnapshot *vmopv1.VirtualMachineSnapshot) error {
+
+	vmCtx := pkgctx.VirtualMachineContext{
+		Context: context.WithValue(ctx, vimtypes.ID{}, vs.getOpID(vm, "snapshot")),
+		Logger:  log.WithValues("vmName", vm.NamespacedName(), "snapshotName", vmSnapshot.Name),
+		VM:      vm,
+	}
+
+	client, err := vs.getVcClient(vmCtx)
+	if err != nil {
+		return err
+	}
+
+	vcVM, err := vs.getVM(vmCtx, client, true)
+	if err != nil {
+		return err
+	}
+
+	if err := vcVM.Properties(
+		vmCtx,
+		vcVM.Reference(),
+		[]string{"snapshot", "summary.runtime.powerState"},
+		&vmCtx.MoVM); err != nil {
+
+		return err
+	}
+
+	ss := virtualmachine.FindVirtualMachineSnapshot(vmCtx.MoVM, vmSnapshot)
+	if ss == nil {
+		vmCtx.Logger.Info("Creating snapshot")
+
+		ref, err := virtualmachine.CreateSnapshot(
+			vmCtx,
+			vcVM,
+			vmCtx.MoVM,
+			virtualmachine.SnapshotArgs{
+				Name:        vmSnapshot.Name,
+				Description: vmSnapshot.Spec.Description,
+				Memory:      vmSnapshot.Spec.Memory,
+				Quiesce:     vmSnapshot.Spec.Quiesce,
+			})
+		if err != nil {
+			return err
+		}
+
+		vmCtx.MoVM = mo.VirtualMachine{}
+		if err := vcVM.Properties(
+			vmCtx,
+			vcVM.Reference(),
+			[]string{"snapshot"},
+			&vmCtx.MoVM); err != nil {
+
+			return err
+		}
+
+		if ss = virtualmachine.FindSnapshotByID(vmCtx.MoVM, ref.Value); ss == nil {
+			return fmt.Errorf("snapshot %q was not found after creation", ref.Value)
+		}
+	}
+
+	virtualmachine.UpdateSnapshotStatus(vmSnapshot, *ss)
+
+	return nil
+}
+
+func (vs *vSphereVMProvider) DeleteVirtualMachineSnapshot(
+	ctx context.Context,
+	vm *vmopv1.VirtualMachine,
+	vmSnapshot *vmopv1.VirtualMachineSnapshot) error {
+
+	vmCtx := pkgctx.VirtualMachineContext{
+		Context: context.WithValue(ctx, vimtypes.ID{}, vs.getOpID(vm, "deleteSnapshot")),
+		Logger:  log.WithValues("vmName", vm.NamespacedName(), "snapshotName", vmSnapshot.Name),
+		VM:      vm,
+	}
+
+	client, err := vs.getVcClient(vmCtx)
+	if err != nil {
+		return err
+	}
+
+	vcVM, err := vs.getVM(vmCtx, client, false)
+	if err != nil {
+		return err
+	}
+
+	if vcVM == nil {
+		// The snapshot was removed with the VM.
+		return nil
+	}
+
+	if err := vcVM.Properties(
+		vmCtx,
+		vcVM.Reference(),
+		[]string{"snapshot"},
+		&vmCtx.MoVM); err != nil {
+
+		return err
+	}
+
+	return virtualmachine.DeleteSnapshot(vmCtx, vcVM, vmCtx.MoVM, vmSnapshot)
+}
+
+func (vs *vSphereVMProvider) GetVirtualMachineBackup(
+	ctx context.Context,
+	moID string) (*providers.VirtualMachineBackup, error) {
+
+	client, err := vs.getVcClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	moRef := vimtypes.ManagedObjectReference{
+		Type:  "VirtualMachine",
+		Value: moID,
+	}
+
+	var moVM mo.VirtualMachine
+	if err := property.DefaultCollector(client.VimClient()).RetrieveOne(
+		ctx,
+		moRef,
+		[]string{"config.extraConfig"},
+		&moVM); err != nil {
+
+		var f *vimtypes.ManagedObjectNotFound
+		if _, ok := fault.As(err, &f); ok {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get VM %s properties: %w", moID, err)
+	}
+
+	if moVM.Config == nil {
+		return nil, nil
+	}
+	extraConfig := pkgutil.OptionValues(moVM.Config.ExtraConfig)
+
+	vm, err := virtualmachine.GetBackupVirtualMachine(extraConfig)
+	if err != nil || vm == nil {
+		return nil, err
+	}
+
+	additionalResources, err := virtualmachine.GetBackupAdditionalResources(extraConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	pvcDiskData, err := virtualmachine.GetBackupPVCDiskData(extraConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	pvcDiskURLPaths := make(map[string]string, len(pvcDiskData))
+	for _, diskData := range pvcDiskData {
+		urlPath, err := virtualmachine.GetDiskURLPath(
+			client.VimClient().URL(),
+			client.Datacenter().InventoryPath,
+			diskData.FileName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get disk URL of PVC %s: %w", diskData.PVCName, err)
+		}
+		pvcDiskURLPaths[diskData.PVCName] = urlPath
+	}
+
+	return &providers.VirtualMachineBackup{
+		VM:                  vm,
+		AdditionalResources: additionalResources,
+		PVCDiskData:         pvcDiskData,
+		PVCDiskURLPaths:     pvcDiskURLPaths,
+	}, nil
+}
+
 func (vs *vSphereVMProvider) vmCreatePathName(
 	vmCtx pkgctx.VirtualMachineContext,
 	vcClient *vcclient.Client,
 	createArgs *VMCreateArgs) error {
 
-	if len(vmCtx.VM.Spec.Cdrom) == 0 {
+	if !vmopv1util.IsISOVM(*vmCtx.VM) {
 		return nil // only needed when deploying ISO library items
 	}
 
@@ -563,7 +795,7 @@ func (vs *vSphereVMProvider) getCreateArgs(
 		return nil, err
 	}
 
-	if pkgcfg.FromContext(vmCtx).Features.FastDeploy {
+	if pkgcfg.FromContext(vmCtx).Features.FastDeploy && createArgs.CloneSourceMoID == "" {
 		if err := vs.vmCreateGetSourceDiskPaths(vmCtx, vcClient, createArgs); err != nil {
 			return nil, err
 		}
@@ -728,6 +960,7 @@ var VMUpdatePropertiesSelector = []string{
 	"layoutEx",
 	"resourcePool",
 	"runtime",
+	"snapshot",
 	"summary",
 }
 
@@ -751,6 +984,26 @@ func (vs *vSphereVMProvider) updateVirtualMachine(
 			return err
 		}
 
+		if pkgcfg.FromContext(vmCtx).Features.VMSnapshots {
+			reverted, err := virtualmachine.RevertToDesiredSnapshot(vmCtx, vcVM)
+			if err != nil {
+				return err
+			}
+
+			if reverted {
+				// The VM's configuration and power state may have changed.
+				vmCtx.MoVM = mo.VirtualMachine{}
+				if err := vcVM.Properties(
+					vmCtx,
+					vcVM.Reference(),
+					VMUpdatePropertiesSelector,
+					&vmCtx.MoVM); err != nil {
+
+					return err
+				}
+			}
+		}
+
 		if vmCtx.MoVM.ResourcePool == nil {
 			// Same error as govmomi VirtualMachine::ResourcePool().
 			return fmt.Errorf("VM doesn't have a resourcePool")
@@ -764,6 +1017,10 @@ func (vs *vSphereVMProvider) updateVirtualMachine(
 			return err
 		}
 
+		if err := vs.vmUpdateStorageClass(vmCtx, vcVM, clusterMoRef); err != nil {
+			return err
+		}
+
 		ses := &session.Session{
 			K8sClient:    vs.k8sClient,
 			Client:       vcClient.Client,
@@ -807,6 +1064,40 @@ func (vs *vSphereVMProvider) updateVirtualMachine(
 			return err
 		}
 
+		var (
+			backupPolicy     vmopv1.VirtualMachineBackupPolicySpec
+			backupPolicyHash string
+		)
+		if pkgcfg.FromContext(vmCtx).Features.VMBackupPolicy {
+			backupPolicy, err = GetBackupPolicySpec(vmCtx, vs.k8sClient)
+			if err != nil {
+				vmCtx.Logger.Error(err, "failed to get backup policy")
+				return err
+			}
+
+			backupPolicyHash, err = GetBackupPolicyHash(backupPolicy)
+			if err != nil {
+				vmCtx.Logger.Error(err, "failed to get backup policy hash")
+				return err
+			}
+
+			policyResources, err := GetBackupPolicyResources(vmCtx, vs.k8sClient, backupPolicy.Resources)
+			if err != nil {
+				vmCtx.Logger.Error(err, "failed to get backup policy resources")
+				return err
+			}
+			for _, obj := range policyResources {
+				// Skip the resources that are already backed up, ex. a
+				// bootstrap ConfigMap that is also selected by the policy.
+				if !slices.ContainsFunc(additionalResources, func(o ctrlclient.Object) bool {
+					return o.GetObjectKind().GroupVersionKind().Kind == obj.GetObjectKind().GroupVersionKind().Kind &&
+						o.GetName() == obj.GetName()
+				}) {
+					additionalResources = append(additionalResources, obj)
+				}
+			}
+		}
+
 		backupOpts := virtualmachine.BackupVirtualMachineOptions{
 			VMCtx:               vmCtx,
 			VcVM:                vcVM,
@@ -814,6 +1105,8 @@ func (vs *vSphereVMProvider) updateVirtualMachine(
 			AdditionalResources: additionalResources,
 			BackupVersion:       fmt.Sprint(time.Now().UnixMilli()),
 			ClassicDiskUUIDs:    GetAttachedClassicDiskUUIDs(vmCtx),
+			ExcludedFieldPaths:  backupPolicy.ExcludedFieldPaths,
+			BackupPolicyHash:    backupPolicyHash,
 		}
 		if err := virtualmachine.BackupVirtualMachine(backupOpts); err != nil {
 			vmCtx.Logger.Error(err, "failed to backup VM")
@@ -824,6 +1117,195 @@ func (vs *vSphereVMProvider) updateVirtualMachine(
 	return nil
 }
 
+// storageClassMigrationRequeueDelay is how long to wait before checking again
+// whether the relocate task that migrates the VM to a new storage class is
+// complete.
+const storageClassMigrationRequeueDelay = 10 * time.Second
+
+// vmUpdateStorageClass migrates the VM's home and classic disks to a datastore
+// compatible with the storage policy of spec.storageClass when it differs from
+// the storage class the VM was last placed on. The relocate task is started
+// without waiting for it, and its ID is recorded on the VM so the migration is
+// tracked by subsequent reconciles. A failed migration is retried on the next
+// reconcile.
+func (vs *vSphereVMProvider) vmUpdateStorageClass(
+	vmCtx pkgctx.VirtualMachineContext,
+	vcVM *object.VirtualMachine,
+	clusterMoRef vimtypes.ManagedObjectReference) error {
+
+	vm := vmCtx.VM
+
+	if vm.Spec.StorageClass == "" {
+		return nil
+	}
+
+	if vm.Status.Storage == nil {
+		vm.Status.Storage = &vmopv1.VirtualMachineStorageStatus{}
+	}
+
+	if v := vm.Annotations[pkgconst.StorageClassMigrationAnnotationKey]; v != "" {
+		if err := vs.vmCheckStorageClassMigration(vmCtx, vcVM, v); err != nil {
+			return err
+		}
+	}
+
+	fromStorageClass := vm.Status.Storage.StorageClass
+
+	if fromStorageClass == "" {
+		// The VM was created with, or existed before the status reported, the
+		// current storage class.
+		vm.Status.Storage.StorageClass = vm.Spec.StorageClass
+		pkgcnd.MarkTrue(vm, vmopv1.VirtualMachineStorageClassSynced)
+		return nil
+	}
+
+	if fromStorageClass == vm.Spec.StorageClass {
+		pkgcnd.MarkTrue(vm, vmopv1.VirtualMachineStorageClassSynced)
+		return nil
+	}
+
+	pkgcnd.MarkFalse(
+		vm,
+		vmopv1.VirtualMachineStorageClassSynced,
+		vmopv1.VirtualMachineStorageClassMigratingReason,
+		"Migrating from storage class %s to %s",
+		fromStorageClass,
+		vm.Spec.StorageClass)
+
+	var sc storagev1.StorageClass
+	if err := vs.k8sClient.Get(
+		vmCtx,
+		ctrlclient.ObjectKey{Name: vm.Spec.StorageClass},
+		&sc); err != nil {
+
+		pkgcnd.MarkFalse(
+			vm,
+			vmopv1.VirtualMachineStorageClassSynced,
+			vmopv1.VirtualMachineStorageClassMigrationFailedReason,
+			"Failed to get storage class %s: %v",
+			vm.Spec.StorageClass,
+			err)
+		return err
+	}
+
+	storageProfileID, err := kubeutil.GetStoragePolicyID(sc)
+	if err != nil {
+		pkgcnd.MarkFalse(
+			vm,
+			vmopv1.VirtualMachineStorageClassSynced,
+			vmopv1.VirtualMachineStorageClassMigrationFailedReason,
+			err.Error())
+		return err
+	}
+
+	task, err := virtualmachine.RelocateStorage(
+		vmCtx,
+		vcVM,
+		clusterMoRef,
+		storageProfileID)
+	if err != nil {
+		pkgcnd.MarkFalse(
+			vm,
+			vmopv1.VirtualMachineStorageClassSynced,
+			vmopv1.VirtualMachineStorageClassMigrationFailedReason,
+			err.Error())
+		return err
+	}
+
+	vmCtx.Logger.Info("Migrating VM to storage class",
+		"fromStorageClass", fromStorageClass,
+		"toStorageClass", vm.Spec.StorageClass,
+		"task", task.Reference().Value)
+
+	if vm.Annotations == nil {
+		vm.Annotations = map[string]string{}
+	}
+	vm.Annotations[pkgconst.StorageClassMigrationAnnotationKey] =
+		vm.Spec.StorageClass + "," + task.Reference().Value
+
+	// Do not update the VM while it is being migrated.
+	return pkgerr.RequeueError{After: storageClassMigrationRequeueDelay}
+}
+
+// vmCheckStorageClassMigration checks the relocate task recorded by
+// vmUpdateStorageClass. While the task is running a RequeueError is returned.
+// When the task succeeded, the VM's status is updated with the storage class
+// the VM was migrated to. When the task failed, the error is returned and the
+// migration is retried on the next reconcile. When the task no longer exists,
+// the migration is started again by vmUpdateStorageClass.
+func (vs *vSphereVMProvider) vmCheckStorageClassMigration(
+	vmCtx pkgctx.VirtualMachineContext,
+	vcVM *object.VirtualMachine,
+	annotationValue string) error {
+
+	vm := vmCtx.VM
+
+	toStorageClass, taskID, _ := strings.Cut(annotationValue, ",")
+
+	var moTask mo.Task
+	if err := vcVM.Properties(
+		vmCtx,
+		vimtypes.ManagedObjectReference{Type: "Task", Value: taskID},
+		[]string{"info"},
+		&moTask); err != nil {
+
+		if !fault.Is(err, &vimtypes.ManagedObjectNotFound{}) {
+			return fmt.Errorf("failed to get storage class migration task: %w", err)
+		}
+
+		vmCtx.Logger.Info("Storage class migration task no longer exists",
+			"toStorageClass", toStorageClass,
+			"task", taskID)
+		delete(vm.Annotations, pkgconst.StorageClassMigrationAnnotationKey)
+		return nil
+	}
+
+	switch moTask.Info.State {
+	case vimtypes.TaskInfoStateQueued, vimtypes.TaskInfoStateRunning:
+		pkgcnd.MarkFalse(
+			vm,
+			vmopv1.VirtualMachineStorageClassSynced,
+			vmopv1.VirtualMachineStorageClassMigratingReason,
+			"Migrating from storage class %s to %s",
+			vm.Status.Storage.StorageClass,
+			toStorageClass)
+		return pkgerr.RequeueError{After: storageClassMigrationRequeueDelay}
+
+	case vimtypes.TaskInfoStateError:
+		delete(vm.Annotations, pkgconst.StorageClassMigrationAnnotationKey)
+
+		msg := "unknown error"
+		if moTask.Info.Error != nil {
+			msg = moTask.Info.Error.LocalizedMessage
+		}
+		pkgcnd.MarkFalse(
+			vm,
+			vmopv1.VirtualMachineStorageClassSynced,
+			vmopv1.VirtualMachineStorageClassMigrationFailedReason,
+			"Failed to migrate from storage class %s to %s: %s",
+			vm.Status.Storage.StorageClass,
+			toStorageClass,
+			msg)
+		return fmt.Errorf("failed to relocate VM: %s", msg)
+	}
+
+	delete(vm.Annotations, pkgconst.StorageClassMigrationAnnotationKey)
+
+	vmCtx.Logger.Info("Migrated VM to storage class",
+		"fromStorageClass", vm.Status.Storage.StorageClass,
+		"toStorageClass", toStorageClass)
+
+	vm.Status.Storage.StorageClass = toStorageClass
+
+	// The VM's files and disks have moved.
+	vmCtx.MoVM = mo.VirtualMachine{}
+	return vcVM.Properties(
+		vmCtx,
+		vcVM.Reference(),
+		VMUpdatePropertiesSelector,
+		&vmCtx.MoVM)
+}
+
 // vmCreateDoPlacement determines placement of the VM prior to creating the VM on VC.
 func (vs *vSphereVMProvider) vmCreateDoPlacement(
 	vmCtx pkgctx.VirtualMachineContext,
@@ -1249,6 +1731,10 @@ func (vs *vSphereVMProvider) vmCreateGetPrereqs(
 		return nil, apierrorsutil.NewAggregate(prereqErrs)
 	}
 
+	if err := vs.vmCreateCloneVolumes(vmCtx, createArgs); err != nil {
+		return nil, err
+	}
+
 	if !vmopv1util.IsClasslessVM(*vmCtx.VM) {
 		// Only set VM Class field for non-synthesized classes.
 		if f := pkgcfg.FromContext(vmCtx).Features; f.VMResize || f.VMResizeCPUMemory {
@@ -1291,6 +1777,15 @@ func (vs *vSphereVMProvider) vmCreateGetVirtualMachineImage(
 	createArgs.ImageSpec = imageSpec
 	createArgs.ImageStatus = imageStatus
 
+	if _, ok := imageObj.(*vmopv1.VirtualMachine); ok {
+		// The VM is cloned from the vSphere VM of another VirtualMachine.
+		createArgs.CloneSourceMoID = imageStatus.ProviderItemID
+		if c := vmCtx.VM.Spec.Clone; c != nil {
+			createArgs.LinkedClone = c.Mode == vmopv1.VirtualMachineCloneModeLinked
+		}
+		return nil
+	}
+
 	var providerRef common.LocalObjectRef
 	if imageSpec.ProviderRef != nil {
 		providerRef = *imageSpec.ProviderRef
@@ -1316,6 +1811,102 @@ func (vs *vSphereVMProvider) vmCreateGetVirtualMachineImage(
 	return nil
 }
 
+// vmCreateCloneVolumes creates a PVC for each of the source VM's PVC volumes
+// when a VM is cloned along with its volumes. Each PVC uses the source VM's
+// PVC as its data source, and is added to the VM's volumes.
+func (vs *vSphereVMProvider) vmCreateCloneVolumes(
+	vmCtx pkgctx.VirtualMachineContext,
+	createArgs *VMCreateArgs) error {
+
+	srcVM, ok := createArgs.ImageObj.(*vmopv1.VirtualMachine)
+	if !ok || vmCtx.VM.Spec.Clone == nil || !vmCtx.VM.Spec.Clone.CloneVolumes {
+		return nil
+	}
+
+	for _, srcVol := range srcVM.Spec.Volumes {
+		srcClaim := srcVol.PersistentVolumeClaim
+		if srcClaim == nil || srcClaim.InstanceVolumeClaim != nil {
+			continue
+		}
+
+		if slices.ContainsFunc(
+			vmCtx.VM.Spec.Volumes,
+			func(v vmopv1.VirtualMachineVolume) bool { return v.Name == srcVol.Name }) {
+
+			// The volume was already cloned by a previous create attempt, or
+			// a volume with the same name was specified by the user.
+			continue
+		}
+
+		var srcPVC corev1.PersistentVolumeClaim
+		if err := vs.k8sClient.Get(
+			vmCtx,
+			ctrlclient.ObjectKey{Namespace: srcVM.Namespace, Name: srcClaim.ClaimName},
+			&srcPVC); err != nil {
+
+			return fmt.Errorf("failed to get source PVC %s: %w", srcClaim.ClaimName, err)
+		}
+
+		pvc := corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      vmopv1util.CloneVolumeClaimName(vmCtx.VM.Name, srcVol.Name),
+				Namespace: vmCtx.VM.Namespace,
+				Labels: map[string]string{
+					constants.ClonedVolumeVMNameLabelKey: vmCtx.VM.Name,
+				},
+			},
+			Spec: corev1.PersistentVolumeClaimSpec{
+				AccessModes:      srcPVC.Spec.AccessModes,
+				Resources:        srcPVC.Spec.Resources,
+				StorageClassName: srcPVC.Spec.StorageClassName,
+				VolumeMode:       srcPVC.Spec.VolumeMode,
+				DataSource: &corev1.TypedLocalObjectReference{
+					Kind: "PersistentVolumeClaim",
+					Name: srcPVC.Name,
+				},
+			},
+		}
+
+		// The VM owns the cloned PVC so it is deleted along with the VM.
+		if err := controllerutil.SetOwnerReference(vmCtx.VM, &pvc, vs.k8sClient.Scheme()); err != nil {
+			return fmt.Errorf("failed to set owner of PVC %s: %w", pvc.Name, err)
+		}
+
+		if err := vs.k8sClient.Create(vmCtx, &pvc); err != nil {
+			if !apierrors.IsAlreadyExists(err) {
+				return fmt.Errorf("failed to create PVC %s: %w", pvc.Name, err)
+			}
+
+			// The PVC may have been created by a previous create attempt, but
+			// a PVC with the same name that is not owned by this VM must not
+			// be used in place of the clone.
+			var existingPVC corev1.PersistentVolumeClaim
+			if err := vs.k8sClient.Get(vmCtx, ctrlclient.ObjectKeyFromObject(&pvc), &existingPVC); err != nil {
+				return fmt.Errorf("failed to get PVC %s: %w", pvc.Name, err)
+			}
+			if !slices.ContainsFunc(
+				existingPVC.OwnerReferences,
+				func(r metav1.OwnerReference) bool { return r.UID == vmCtx.VM.UID }) {
+
+				return fmt.Errorf("PVC %s already exists and is not owned by the VM", pvc.Name)
+			}
+		}
+
+		vmCtx.VM.Spec.Volumes = append(vmCtx.VM.Spec.Volumes, vmopv1.VirtualMachineVolume{
+			Name: srcVol.Name,
+			VirtualMachineVolumeSource: vmopv1.VirtualMachineVolumeSource{
+				PersistentVolumeClaim: &vmopv1.PersistentVolumeClaimVolumeSource{
+					PersistentVolumeClaimVolumeSource: corev1.PersistentVolumeClaimVolumeSource{
+						ClaimName: pvc.Name,
+					},
+				},
+			},
+		})
+	}
+
+	return nil
+}
+
 func (vs *vSphereVMProvider) vmCreateGetSetResourcePolicy(
 	vmCtx pkgctx.VirtualMachineContext,
 	createArgs *VMCreateArgs) error {
@@ -212,6 +212,68 @@ func vmResizeTests() {
 					})
 				})
 
+				Context("Drift", func() {
+
+					reconfigureNumCPUs := func(numCPUs int32) {
+						vcVM := ctx.GetVMFromMoID(vm.Status.UniqueID)
+						ExpectWithOffset(1, vcVM).ToNot(BeNil())
+						t, err := vcVM.Reconfigure(ctx, vimtypes.VirtualMachineConfigSpec{NumCPUs: numCPUs})
+						ExpectWithOffset(1, err).ToNot(HaveOccurred())
+						ExpectWithOffset(1, t.Wait(ctx)).To(Succeed())
+					}
+
+					It("Reports drift", func() {
+						vm.Spec.DriftPolicy = vmopv1.VirtualMachineDriftPolicyReport
+						Expect(createOrUpdateVM(ctx, vmProvider, vm)).To(Succeed())
+						Expect(vm.Status.Drift).To(BeNil())
+						Expect(conditions.IsFalse(vm, vmopv1.VirtualMachineConfigurationDriftCondition)).To(BeTrue())
+
+						reconfigureNumCPUs(configSpec.NumCPUs + 1)
+
+						Expect(createOrUpdateVM(ctx, vmProvider, vm)).To(Succeed())
+						Expect(vm.Status.Drift).ToNot(BeNil())
+						Expect(vm.Status.Drift.Fields).To(ConsistOf(vmopv1.VirtualMachineDriftedField{
+							Field:   "numCPUs",
+							Current: fmt.Sprintf("%d", configSpec.NumCPUs+1),
+							Desired: fmt.Sprintf("%d", configSpec.NumCPUs),
+						}))
+						Expect(conditions.IsTrue(vm, vmopv1.VirtualMachineConfigurationDriftCondition)).To(BeTrue())
+					})
+
+					It("Reverts drift", func() {
+						vm.Spec.DriftPolicy = vmopv1.VirtualMachineDriftPolicyRevert
+						reconfigureNumCPUs(configSpec.NumCPUs + 1)
+
+						vcVM, err := createOrUpdateAndGetVcVM(ctx, vmProvider, vm)
+						Expect(err).ToNot(HaveOccurred())
+						Expect(vm.Status.Drift).To(BeNil())
+						Expect(conditions.GetReason(vm, vmopv1.VirtualMachineConfigurationDriftCondition)).To(
+							Equal(vmopv1.VirtualMachineConfigurationDriftRevertedReason))
+
+						var o mo.VirtualMachine
+						Expect(vcVM.Properties(ctx, vcVM.Reference(), nil, &o)).To(Succeed())
+						Expect(o.Config.Hardware.NumCPU).To(BeEquivalentTo(configSpec.NumCPUs))
+					})
+
+					It("Adopts drift", func() {
+						vm.Spec.DriftPolicy = vmopv1.VirtualMachineDriftPolicyAdopt
+						reconfigureNumCPUs(configSpec.NumCPUs + 1)
+
+						vcVM, err := createOrUpdateAndGetVcVM(ctx, vmProvider, vm)
+						Expect(err).ToNot(HaveOccurred())
+						Expect(vm.Status.Drift).ToNot(BeNil())
+						Expect(vm.Status.Drift.Fields).To(BeEmpty())
+						Expect(vm.Status.Drift.Adopted).To(HaveLen(1))
+						Expect(vm.Status.Drift.Adopted[0].Field).To(Equal("numCPUs"))
+						Expect(conditions.GetReason(vm, vmopv1.VirtualMachineConfigurationDriftCondition)).To(
+							Equal(vmopv1.VirtualMachineConfigurationDriftAdoptedReason))
+
+						var o mo.VirtualMachine
+						Expect(vcVM.Properties(ctx, vcVM.Reference(), nil, &o)).To(Succeed())
+						Expect(o.Config.Hardware.NumCPU).To(BeEquivalentTo(configSpec.NumCPUs + 1))
+					})
+				})
+
 				Context("CPU/Memory Reservations", func() {
 
 					Context("No reservations", func() {
@@ -11,7 +11,9 @@ import (
 	"errors"
 	"fmt"
 	"math/rand"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/google/uuid"
 	. "github.com/onsi/ginkgo/v2"
@@ -2163,6 +2165,95 @@ func vmTests() {
 				})
 			})
 
+			Context("Storage Class is changed", func() {
+				assertStorageClassMigrationStarted := func() {
+					err := vmProvider.CreateOrUpdateVirtualMachine(ctx, vm)
+					Expect(err).To(Equal(pkgerr.RequeueError{After: 10 * time.Second}))
+					Expect(vm.Annotations).To(HaveKeyWithValue(
+						pkgconst.StorageClassMigrationAnnotationKey,
+						HavePrefix(ctx.EncryptedStorageClassName+",task-")))
+					Expect(vm.Status.Storage.StorageClass).To(Equal(ctx.StorageClassName))
+					c := conditions.Get(vm, vmopv1.VirtualMachineStorageClassSynced)
+					Expect(c).ToNot(BeNil())
+					Expect(c.Status).To(Equal(metav1.ConditionFalse))
+					Expect(c.Reason).To(Equal(vmopv1.VirtualMachineStorageClassMigratingReason))
+				}
+
+				waitForStorageClassMigrationTask := func() {
+					v := vm.Annotations[pkgconst.StorageClassMigrationAnnotationKey]
+					_, taskID, _ := strings.Cut(v, ",")
+					task := object.NewTask(ctx.VCClient.Client, vimtypes.ManagedObjectReference{
+						Type:  "Task",
+						Value: taskID,
+					})
+					Expect(task.Wait(ctx)).To(Succeed())
+				}
+
+				It("Migrates the VM to the new storage class", func() {
+					_, err := createOrUpdateAndGetVcVM(ctx, vmProvider, vm)
+					Expect(err).ToNot(HaveOccurred())
+
+					Expect(vm.Status.Storage).ToNot(BeNil())
+					Expect(vm.Status.Storage.StorageClass).To(Equal(ctx.StorageClassName))
+					Expect(conditions.IsTrue(vm, vmopv1.VirtualMachineStorageClassSynced)).To(BeTrue())
+
+					vm.Spec.StorageClass = ctx.EncryptedStorageClassName
+
+					By("starts the migration", func() {
+						assertStorageClassMigrationStarted()
+					})
+
+					By("completes the migration", func() {
+						waitForStorageClassMigrationTask()
+						Expect(vmProvider.CreateOrUpdateVirtualMachine(ctx, vm)).To(Succeed())
+						Expect(vm.Annotations).ToNot(HaveKey(pkgconst.StorageClassMigrationAnnotationKey))
+						Expect(vm.Status.Storage.StorageClass).To(Equal(ctx.EncryptedStorageClassName))
+						Expect(conditions.IsTrue(vm, vmopv1.VirtualMachineStorageClassSynced)).To(BeTrue())
+					})
+				})
+
+				It("Restarts the migration when the task no longer exists", func() {
+					_, err := createOrUpdateAndGetVcVM(ctx, vmProvider, vm)
+					Expect(err).ToNot(HaveOccurred())
+
+					vm.Spec.StorageClass = ctx.EncryptedStorageClassName
+					if vm.Annotations == nil {
+						vm.Annotations = map[string]string{}
+					}
+					vm.Annotations[pkgconst.StorageClassMigrationAnnotationKey] =
+						ctx.EncryptedStorageClassName + ",task-does-not-exist"
+
+					assertStorageClassMigrationStarted()
+					Expect(vm.Annotations[pkgconst.StorageClassMigrationAnnotationKey]).ToNot(HaveSuffix("task-does-not-exist"))
+				})
+
+				It("Retries the migration after it failed", func() {
+					_, err := createOrUpdateAndGetVcVM(ctx, vmProvider, vm)
+					Expect(err).ToNot(HaveOccurred())
+
+					vm.Spec.StorageClass = "does-not-exist"
+
+					By("reports the migration failed", func() {
+						Expect(vmProvider.CreateOrUpdateVirtualMachine(ctx, vm)).ToNot(Succeed())
+						Expect(vm.Status.Storage.StorageClass).To(Equal(ctx.StorageClassName))
+						c := conditions.Get(vm, vmopv1.VirtualMachineStorageClassSynced)
+						Expect(c).ToNot(BeNil())
+						Expect(c.Status).To(Equal(metav1.ConditionFalse))
+						Expect(c.Reason).To(Equal(vmopv1.VirtualMachineStorageClassMigrationFailedReason))
+					})
+
+					vm.Spec.StorageClass = ctx.EncryptedStorageClassName
+
+					By("migrates the VM", func() {
+						assertStorageClassMigrationStarted()
+						waitForStorageClassMigrationTask()
+						Expect(vmProvider.CreateOrUpdateVirtualMachine(ctx, vm)).To(Succeed())
+						Expect(vm.Status.Storage.StorageClass).To(Equal(ctx.EncryptedStorageClassName))
+						Expect(conditions.IsTrue(vm, vmopv1.VirtualMachineStorageClassSynced)).To(BeTrue())
+					})
+				})
+			})
+
 			Context("Without Content Library", func() {
 				BeforeEach(func() {
 					testConfig.WithContentLibrary = false
@@ -2610,6 +2701,62 @@ func vmTests() {
 						_, dvpg := getDVPG(ctx, dvpgName)
 						Expect(backing2.Port.PortgroupKey).To(Equal(dvpg.Reference().Value))
 					})
+
+					It("Hot-removes and hot-adds NICs when the VM is powered on", func() {
+						pkgcfg.SetContext(ctx, func(config *pkgcfg.Config) {
+							config.Features.VMNetworkHotPlug = true
+						})
+
+						_, err := createOrUpdateAndGetVcVM(ctx, vmProvider, vm)
+						Expect(err).ToNot(HaveOccurred())
+						Expect(vm.Status.PowerState).To(Equal(vmopv1.VirtualMachinePowerStateOn))
+
+						getEthCards := func(vcVM *object.VirtualMachine) object.VirtualDeviceList {
+							var o mo.VirtualMachine
+							ExpectWithOffset(1, vcVM.Properties(ctx, vcVM.Reference(), nil, &o)).To(Succeed())
+							return object.VirtualDeviceList(o.Config.Hardware.Device).SelectByType(&vimtypes.VirtualEthernetCard{})
+						}
+
+						By("removing eth0", func() {
+							vm.Spec.Network.Interfaces = vm.Spec.Network.Interfaces[1:]
+
+							vcVM, err := createOrUpdateAndGetVcVM(ctx, vmProvider, vm)
+							Expect(err).ToNot(HaveOccurred())
+
+							l := getEthCards(vcVM)
+							Expect(l).To(HaveLen(1))
+							backing, ok := l[0].GetVirtualDevice().Backing.(*vimtypes.VirtualEthernetCardDistributedVirtualPortBackingInfo)
+							Expect(ok).Should(BeTrue())
+							_, dvpg := getDVPG(ctx, dvpgName)
+							Expect(backing.Port.PortgroupKey).To(Equal(dvpg.Reference().Value))
+
+							Expect(vm.Status.Network).ToNot(BeNil())
+							Expect(vm.Status.Network.Config).ToNot(BeNil())
+							Expect(vm.Status.Network.Config.Interfaces).To(HaveLen(1))
+							Expect(vm.Status.Network.Config.Interfaces[0].Name).To(Equal("eth1"))
+						})
+
+						By("adding eth2", func() {
+							vm.Spec.Network.Interfaces = append(vm.Spec.Network.Interfaces,
+								vmopv1.VirtualMachineNetworkInterfaceSpec{
+									Name:    "eth2",
+									Network: &common.PartialObjectRef{Name: "VM Network"},
+								})
+
+							vcVM, err := createOrUpdateAndGetVcVM(ctx, vmProvider, vm)
+							Expect(err).ToNot(HaveOccurred())
+							Expect(vm.Status.PowerState).To(Equal(vmopv1.VirtualMachinePowerStateOn))
+
+							l := getEthCards(vcVM)
+							Expect(l).To(HaveLen(2))
+							backing, ok := l[1].GetVirtualDevice().Backing.(*vimtypes.VirtualEthernetCardNetworkBackingInfo)
+							Expect(ok).Should(BeTrue())
+							Expect(backing.DeviceName).To(Equal("VM Network"))
+
+							Expect(vm.Status.Network.Config.Interfaces).To(HaveLen(2))
+							Expect(vm.Status.Network.Config.Interfaces[1].Name).To(Equal("eth2"))
+						})
+					})
 				})
 			})
 
@@ -2928,6 +3075,81 @@ func vmTests() {
 			})
 		})
 
+		Context("Serial console", func() {
+			const proxyURI = "telnets://vspc.local:13370"
+
+			getSerialPorts := func() []*vimtypes.VirtualSerialPort {
+				var o mo.VirtualMachine
+				ExpectWithOffset(1, ctx.GetVMFromMoID(vm.Status.UniqueID).Properties(ctx, vimtypes.ManagedObjectReference{
+					Type: "VirtualMachine", Value: vm.Status.UniqueID}, []string{"config.hardware.device"}, &o)).To(Succeed())
+
+				var serialPorts []*vimtypes.VirtualSerialPort
+				for _, bd := range o.Config.Hardware.Device {
+					if sp, ok := bd.(*vimtypes.VirtualSerialPort); ok {
+						if b, ok := sp.Backing.(*vimtypes.VirtualSerialPortURIBackingInfo); ok && b.ServiceURI == "my-vm-uid" {
+							serialPorts = append(serialPorts, sp)
+						}
+					}
+				}
+				return serialPorts
+			}
+
+			BeforeEach(func() {
+				vm.UID = "my-vm-uid"
+				vm.Spec.Advanced = &vmopv1.VirtualMachineAdvancedSpec{
+					SerialConsoleEnabled: ptr.To(true),
+				}
+			})
+
+			It("returns an error when the proxy is not configured", func() {
+				Expect(createOrUpdateVM(ctx, vmProvider, vm)).To(Succeed())
+				Expect(getSerialPorts()).To(BeEmpty())
+
+				_, err := vmProvider.GetVirtualMachineSerialConsoleTicket(ctx, vm, "foo", "my-token")
+				Expect(err).To(MatchError("serial console proxy is not configured"))
+			})
+
+			When("the proxy is configured", func() {
+				JustBeforeEach(func() {
+					pkgcfg.SetContext(ctx, func(config *pkgcfg.Config) {
+						config.SerialConsoleProxyURI = proxyURI
+					})
+				})
+
+				It("adds the serial port before power on and returns the ticket", func() {
+					Expect(createOrUpdateVM(ctx, vmProvider, vm)).To(Succeed())
+					Expect(createOrUpdateVM(ctx, vmProvider, vm)).To(Succeed())
+
+					serialPorts := getSerialPorts()
+					Expect(serialPorts).To(HaveLen(1), "serial port is only added once")
+					backing := serialPorts[0].Backing.(*vimtypes.VirtualSerialPortURIBackingInfo)
+					Expect(backing.ProxyURI).To(Equal(proxyURI))
+
+					privateKey, publicKeyPem := builder.WebConsoleRequestKeyPair()
+					ticket, err := vmProvider.GetVirtualMachineSerialConsoleTicket(ctx, vm, publicKeyPem, "my-token")
+					Expect(err).ToNot(HaveOccurred())
+					Expect(virtualmachine.DecryptWebMKS(privateKey, ticket)).To(Equal("serial://my-vm-uid/ticket/my-token"))
+				})
+
+				It("removes the serial port when disabled", func() {
+					Expect(createOrUpdateVM(ctx, vmProvider, vm)).To(Succeed())
+					Expect(getSerialPorts()).To(HaveLen(1))
+
+					vm.Spec.PowerState = vmopv1.VirtualMachinePowerStateOff
+					Expect(createOrUpdateVM(ctx, vmProvider, vm)).To(Succeed())
+
+					vm.Spec.Advanced.SerialConsoleEnabled = ptr.To(false)
+					vm.Spec.PowerState = vmopv1.VirtualMachinePowerStateOn
+					Expect(createOrUpdateVM(ctx, vmProvider, vm)).To(Succeed())
+					Expect(getSerialPorts()).To(BeEmpty())
+
+					_, publicKeyPem := builder.WebConsoleRequestKeyPair()
+					_, err := vmProvider.GetVirtualMachineSerialConsoleTicket(ctx, vm, publicKeyPem, "my-token")
+					Expect(err).To(MatchError("the VM does not have a serial console port"))
+				})
+			})
+		})
+
 		Context("VM hardware version", func() {
 			JustBeforeEach(func() {
 				Expect(createOrUpdateVM(ctx, vmProvider, vm)).To(Succeed())
@@ -2972,7 +3194,7 @@ func vmTests() {
 				vm.Spec.StorageClass = ctx.StorageClassName
 				vm.Spec.Cdrom = []vmopv1.VirtualMachineCdromSpec{{
 					Name: "cdrom0",
-					Image: vmopv1.VirtualMachineImageRef{
+					Image: &vmopv1.VirtualMachineImageRef{
 						Name: cvmiKind,
 						Kind: clusterVMImage.Name,
 					},
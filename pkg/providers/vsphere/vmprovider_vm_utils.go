@@ -6,15 +6,23 @@ package vsphere
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/google/uuid"
 	vimtypes "github.com/vmware/govmomi/vim25/types"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
 
 	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
@@ -127,6 +135,10 @@ func getVirtualMachineClassFromClassName(
 // or ClusterVirtualMachineImage resource, as well as its spec and status, for
 // the resource used to deploy a VM.
 //
+// If the VM is cloned from another VirtualMachine, the source VirtualMachine
+// is returned along with an empty spec and an image status that describes the
+// source VM.
+//
 // Please note, this function is *not* designed to be invoked in the "update"
 // VM workflow. This function assumes it is only ever invoked as part of the
 // "create" workflow. For example, spec.image *can* be nil during the update
@@ -173,6 +185,15 @@ func GetVirtualMachineImageSpecAndStatus(
 		if objErr = k8sClient.Get(vmCtx, key, &img); objErr == nil {
 			obj, spec, status = &img, img.Spec, img.Status
 		}
+	case "VirtualMachine":
+		var srcVM vmopv1.VirtualMachine
+		if objErr = k8sClient.Get(vmCtx, key, &srcVM); objErr == nil {
+			obj = &srcVM
+			status.ProviderItemID = srcVM.Status.UniqueID
+			if v := srcVM.Status.HardwareVersion; v != 0 {
+				status.HardwareVersion = &v
+			}
+		}
 	case "":
 		// This is only possible IFF VirtualMachine API resources created at a
 		// schema version prior to spec.image were not yet deployed when VM Op
@@ -242,6 +263,20 @@ func GetVirtualMachineImageSpecAndStatus(
 			err
 	}
 
+	if srcVM, ok := obj.(*vmopv1.VirtualMachine); ok {
+		// A VM may only be cloned once the source VM has been created.
+		if srcVM.Status.UniqueID == "" {
+			msg := "source VirtualMachine is not created"
+			conditions.MarkFalse(vmCtx.VM, vmopv1.VirtualMachineConditionImageReady, "NotReady", msg)
+			return nil,
+				vmopv1.VirtualMachineImageSpec{},
+				vmopv1.VirtualMachineImageStatus{},
+				errors.New(msg)
+		}
+		conditions.MarkTrue(vmCtx.VM, vmopv1.VirtualMachineConditionImageReady)
+		return obj, spec, status, nil
+	}
+
 	vmiNotReadyMessage := "VirtualMachineImage is not ready"
 
 	// Mirror the image's ReadyConditionType into the VM's
@@ -398,6 +433,16 @@ func GetVirtualMachineBootstrap(
 				return vmlifecycle.BootstrapData{}, err
 			}
 		}
+	} else if v := bootstrapSpec.Ignition; v != nil {
+		if raw := v.RawConfig; raw != nil {
+			var err error
+			data, err = getSecretData(vmCtx, k8sClient, raw.Name, raw.Key, false)
+			if err != nil {
+				reason, msg := errToConditionReasonAndMessage(err)
+				conditions.MarkFalse(vmCtx.VM, vmopv1.VirtualMachineConditionBootstrapReady, reason, msg)
+				return vmlifecycle.BootstrapData{}, err
+			}
+		}
 	}
 
 	// vApp bootstrap can be used alongside LinuxPrep/Sysprep.
@@ -611,7 +656,7 @@ func GetAdditionalResourcesForBackup(
 	vmCtx pkgctx.VirtualMachineContext,
 	k8sClient ctrlclient.Client) ([]ctrlclient.Object, error) {
 	var objects []ctrlclient.Object
-	// Get bootstrap related objects from CloudInit or Sysprep (mutually exclusive).
+	// Get bootstrap related objects from CloudInit, Sysprep, or Ignition (mutually exclusive).
 	if bootstrapSpec := vmCtx.VM.Spec.Bootstrap; bootstrapSpec != nil {
 		if v := bootstrapSpec.CloudInit; v != nil {
 			if cooked := v.CloudConfig; cooked != nil {
@@ -651,6 +696,15 @@ func GetAdditionalResourcesForBackup(
 				}
 				objects = append(objects, obj)
 			}
+		} else if v := bootstrapSpec.Ignition; v != nil {
+			if raw := v.RawConfig; raw != nil {
+				// Ignition data is only read from a Secret, see GetVirtualMachineBootstrap.
+				obj, err := getSecretOrConfigMapObject(vmCtx, k8sClient, raw.Name, false)
+				if err != nil {
+					return nil, err
+				}
+				objects = append(objects, obj)
+			}
 		}
 
 		// Get bootstrap related objects from vAppConfig (can be used alongside LinuxPrep/Sysprep).
@@ -685,6 +739,153 @@ func GetAdditionalResourcesForBackup(
 	return objects, nil
 }
 
+// GetBackupPolicySpec returns the union of the VirtualMachineBackupPolicies in
+// the VM's namespace that apply to the VM.
+func GetBackupPolicySpec(
+	vmCtx pkgctx.VirtualMachineContext,
+	k8sClient ctrlclient.Client) (vmopv1.VirtualMachineBackupPolicySpec, error) {
+
+	var spec vmopv1.VirtualMachineBackupPolicySpec
+
+	policyList := &vmopv1.VirtualMachineBackupPolicyList{}
+	if err := k8sClient.List(vmCtx, policyList, ctrlclient.InNamespace(vmCtx.VM.Namespace)); err != nil {
+		return spec, fmt.Errorf("failed to list VirtualMachineBackupPolicies: %w", err)
+	}
+
+	slices.SortFunc(policyList.Items, func(a, b vmopv1.VirtualMachineBackupPolicy) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	for _, policy := range policyList.Items {
+		if policy.Spec.VMSelector != nil {
+			selector, err := metav1.LabelSelectorAsSelector(policy.Spec.VMSelector)
+			if err != nil {
+				return spec, fmt.Errorf("invalid vmSelector in VirtualMachineBackupPolicy %s: %w", policy.Name, err)
+			}
+			if !selector.Matches(labels.Set(vmCtx.VM.Labels)) {
+				continue
+			}
+		}
+
+		spec.Resources = append(spec.Resources, policy.Spec.Resources...)
+		for _, p := range policy.Spec.ExcludedFieldPaths {
+			if !slices.Contains(spec.ExcludedFieldPaths, p) {
+				spec.ExcludedFieldPaths = append(spec.ExcludedFieldPaths, p)
+			}
+		}
+	}
+
+	return spec, nil
+}
+
+// GetBackupPolicyHash returns a hash of the given backup policy, or an empty
+// string if the policy is empty. The hash is stored with the VM's backup so a
+// change to the policy causes the VM to be backed up again.
+func GetBackupPolicyHash(spec vmopv1.VirtualMachineBackupPolicySpec) (string, error) {
+	if len(spec.Resources) == 0 && len(spec.ExcludedFieldPaths) == 0 {
+		return "", nil
+	}
+
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal backup policy: %w", err)
+	}
+
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// GetBackupPolicyResources returns the resources, in the VM's namespace, that
+// are selected by the given backup policy resources.
+func GetBackupPolicyResources(
+	vmCtx pkgctx.VirtualMachineContext,
+	k8sClient ctrlclient.Client,
+	policyResources []vmopv1.VirtualMachineBackupPolicyResource) ([]ctrlclient.Object, error) {
+
+	var (
+		objects []ctrlclient.Object
+		seen    = map[string]struct{}{}
+		vmLabel = labels.Set(vmCtx.VM.Labels)
+	)
+
+	appendObject := func(obj ctrlclient.Object, gvk schema.GroupVersionKind) {
+		key := gvk.Kind + "/" + obj.GetName()
+		if _, ok := seen[key]; ok {
+			return
+		}
+		seen[key] = struct{}{}
+		// GVK is dropped when getting a resource from client.
+		// Add it in backup so that the resource can be applied successfully during restore.
+		obj.GetObjectKind().SetGroupVersionKind(gvk)
+		objects = append(objects, obj)
+	}
+
+	for _, res := range policyResources {
+		listOpts := []ctrlclient.ListOption{ctrlclient.InNamespace(vmCtx.VM.Namespace)}
+		if res.Selector != nil {
+			selector, err := metav1.LabelSelectorAsSelector(res.Selector)
+			if err != nil {
+				return nil, fmt.Errorf("invalid selector for %s: %w", res.Kind, err)
+			}
+			listOpts = append(listOpts, ctrlclient.MatchingLabelsSelector{Selector: selector})
+		}
+
+		switch res.Kind {
+		case vmopv1.VirtualMachineBackupPolicyResourceKindConfigMap:
+			if res.Selector == nil {
+				continue
+			}
+			list := &corev1.ConfigMapList{}
+			if err := k8sClient.List(vmCtx, list, listOpts...); err != nil {
+				return nil, err
+			}
+			for i := range list.Items {
+				appendObject(&list.Items[i], corev1.SchemeGroupVersion.WithKind("ConfigMap"))
+			}
+
+		case vmopv1.VirtualMachineBackupPolicyResourceKindNetworkPolicy:
+			list := &networkingv1.NetworkPolicyList{}
+			if err := k8sClient.List(vmCtx, list, listOpts...); err != nil {
+				return nil, err
+			}
+			for i := range list.Items {
+				if res.Selector == nil {
+					selector, err := metav1.LabelSelectorAsSelector(&list.Items[i].Spec.PodSelector)
+					if err != nil || !selector.Matches(vmLabel) {
+						continue
+					}
+				}
+				appendObject(&list.Items[i], networkingv1.SchemeGroupVersion.WithKind("NetworkPolicy"))
+			}
+
+		case vmopv1.VirtualMachineBackupPolicyResourceKindVirtualMachineService:
+			list := &vmopv1.VirtualMachineServiceList{}
+			if err := k8sClient.List(vmCtx, list, listOpts...); err != nil {
+				return nil, err
+			}
+			for i := range list.Items {
+				if res.Selector == nil {
+					sel := list.Items[i].Spec.Selector
+					if len(sel) == 0 || !labels.SelectorFromSet(sel).Matches(vmLabel) {
+						continue
+					}
+				}
+				appendObject(&list.Items[i], vmopv1.GroupVersion.WithKind("VirtualMachineService"))
+			}
+		}
+	}
+
+	// Sort the resources so the backup is stable.
+	slices.SortStableFunc(objects, func(a, b ctrlclient.Object) int {
+		if c := strings.Compare(a.GetObjectKind().GroupVersionKind().Kind, b.GetObjectKind().GroupVersionKind().Kind); c != 0 {
+			return c
+		}
+		return strings.Compare(a.GetName(), b.GetName())
+	})
+
+	return objects, nil
+}
+
 func getSecretOrConfigMapObject(
 	vmCtx pkgctx.VirtualMachineContext,
 	k8sClient ctrlclient.Client,
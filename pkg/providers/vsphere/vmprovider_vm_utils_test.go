@@ -12,6 +12,8 @@ import (
 
 	vimtypes "github.com/vmware/govmomi/vim25/types"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -414,6 +416,61 @@ func vmUtilTests() {
 				Expect(conditions.IsTrue(vmCtx.VM, vmopv1.VirtualMachineConditionImageReady)).To(BeTrue())
 			})
 		})
+
+		When("spec.image.kind is VirtualMachine", func() {
+			var srcVM *vmopv1.VirtualMachine
+
+			BeforeEach(func() {
+				srcVM = builder.DummyVirtualMachine()
+				srcVM.Name = "source-vm"
+				srcVM.Namespace = vmCtx.VM.Namespace
+				vmCtx.VM.Spec.Image.Kind = "VirtualMachine"
+				vmCtx.VM.Spec.Image.Name = srcVM.Name
+			})
+
+			When("source VM does not exist", func() {
+				It("returns error and sets condition", func() {
+					_, _, _, err := vsphere.GetVirtualMachineImageSpecAndStatus(vmCtx, k8sClient)
+					Expect(err).To(HaveOccurred())
+					Expect(conditions.IsFalse(vmCtx.VM, vmopv1.VirtualMachineConditionImageReady)).To(BeTrue())
+					Expect(conditions.GetReason(vmCtx.VM, vmopv1.VirtualMachineConditionImageReady)).To(Equal("NotFound"))
+				})
+			})
+
+			When("source VM is not created", func() {
+				BeforeEach(func() {
+					initObjects = append(initObjects, srcVM)
+				})
+
+				It("returns error and sets condition", func() {
+					_, _, _, err := vsphere.GetVirtualMachineImageSpecAndStatus(vmCtx, k8sClient)
+					Expect(err).To(MatchError("source VirtualMachine is not created"))
+
+					expectedCondition := []metav1.Condition{
+						*conditions.FalseCondition(vmopv1.VirtualMachineConditionImageReady, "NotReady", "source VirtualMachine is not created"),
+					}
+					Expect(vmCtx.VM.Status.Conditions).To(conditions.MatchConditions(expectedCondition))
+				})
+			})
+
+			When("source VM is created", func() {
+				BeforeEach(func() {
+					srcVM.Status.UniqueID = "vm-42"
+					srcVM.Status.HardwareVersion = 21
+					initObjects = append(initObjects, srcVM)
+				})
+
+				It("returns success", func() {
+					obj, _, status, err := vsphere.GetVirtualMachineImageSpecAndStatus(vmCtx, k8sClient)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(obj).ToNot(BeNil())
+					Expect(obj.GetObjectKind().GroupVersionKind().Kind).To(Equal("VirtualMachine"))
+					Expect(status.ProviderItemID).To(Equal("vm-42"))
+					Expect(status.HardwareVersion).To(HaveValue(BeEquivalentTo(21)))
+					Expect(conditions.IsTrue(vmCtx.VM, vmopv1.VirtualMachineConditionImageReady)).To(BeTrue())
+				})
+			})
+		})
 	})
 
 	Context("GetVirtualMachineBootstrap", func() {
@@ -594,6 +651,50 @@ func vmUtilTests() {
 			})
 		})
 
+		When("Bootstrap via Ignition RawConfig", func() {
+			BeforeEach(func() {
+				vmCtx.VM.Spec.Bootstrap = &vmopv1.VirtualMachineBootstrapSpec{
+					Ignition: &vmopv1.VirtualMachineBootstrapIgnitionSpec{
+						RawConfig: &common.SecretKeySelector{
+							Name: dataName,
+							Key:  "foo1",
+						},
+					},
+				}
+			})
+
+			It("return an error when resource does not exist", func() {
+				_, err := vsphere.GetVirtualMachineBootstrap(vmCtx, k8sClient)
+				Expect(err).To(HaveOccurred())
+				Expect(conditions.IsTrue(vmCtx.VM, vmopv1.VirtualMachineConditionBootstrapReady)).To(BeFalse())
+			})
+
+			When("Secret exists", func() {
+				BeforeEach(func() {
+					initObjects = append(initObjects, bootstrapSecret)
+				})
+
+				It("returns success", func() {
+					bsData, err := vsphere.GetVirtualMachineBootstrap(vmCtx, k8sClient)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(bsData.Data).To(HaveKeyWithValue("foo1", "bar1"))
+					Expect(conditions.IsTrue(vmCtx.VM, vmopv1.VirtualMachineConditionBootstrapReady)).To(BeTrue())
+				})
+
+				When("the key does not exist", func() {
+					BeforeEach(func() {
+						vmCtx.VM.Spec.Bootstrap.Ignition.RawConfig.Key = "missing"
+					})
+
+					It("returns an error", func() {
+						_, err := vsphere.GetVirtualMachineBootstrap(vmCtx, k8sClient)
+						Expect(err).To(MatchError(`required key "missing" not found in Secret ` + dataName))
+						Expect(conditions.IsTrue(vmCtx.VM, vmopv1.VirtualMachineConditionBootstrapReady)).To(BeFalse())
+					})
+				})
+			})
+		})
+
 		Context("Bootstrap via inline Sysprep", func() {
 			anotherKey := "some_other_key"
 
@@ -1266,6 +1367,57 @@ func vmUtilTests() {
 			})
 		})
 
+		When("VM spec has bootstrap in Ignition RawConfig referencing a Secret object", func() {
+
+			BeforeEach(func() {
+				vmCtx.VM.Spec.Bootstrap = &vmopv1.VirtualMachineBootstrapSpec{
+					Ignition: &vmopv1.VirtualMachineBootstrapIgnitionSpec{
+						RawConfig: &common.SecretKeySelector{
+							Name: "dummy-raw-ignition-secret",
+						},
+					},
+				}
+				initObjects = append(initObjects, &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: vmCtx.VM.Namespace,
+						Name:      "dummy-raw-ignition-secret",
+					},
+				})
+			})
+
+			It("Should return the Secret object as additional resource for backup", func() {
+				objects, err := vsphere.GetAdditionalResourcesForBackup(vmCtx, k8sClient)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(objects).To(HaveLen(1))
+				Expect(objects[0].GetName()).To(Equal("dummy-raw-ignition-secret"))
+				Expect(objects[0].GetObjectKind().GroupVersionKind()).To(Equal(corev1.SchemeGroupVersion.WithKind("Secret")))
+			})
+		})
+
+		When("VM spec has bootstrap in Ignition RawConfig referencing a ConfigMap object", func() {
+
+			BeforeEach(func() {
+				vmCtx.VM.Spec.Bootstrap = &vmopv1.VirtualMachineBootstrapSpec{
+					Ignition: &vmopv1.VirtualMachineBootstrapIgnitionSpec{
+						RawConfig: &common.SecretKeySelector{
+							Name: "dummy-raw-ignition-config-map",
+						},
+					},
+				}
+				initObjects = append(initObjects, &corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: vmCtx.VM.Namespace,
+						Name:      "dummy-raw-ignition-config-map",
+					},
+				})
+			})
+
+			It("Should not fall back to the ConfigMap object", func() {
+				_, err := vsphere.GetAdditionalResourcesForBackup(vmCtx, k8sClient)
+				Expect(apierrors.IsNotFound(err)).To(BeTrue())
+			})
+		})
+
 		When("VM spec has bootstrap in VAppConfig Properties referencing a Secret object", func() {
 
 			BeforeEach(func() {
@@ -1358,4 +1510,226 @@ func vmUtilTests() {
 			})
 		})
 	})
+
+	Context("GetBackupPolicySpec", func() {
+
+		BeforeEach(func() {
+			vmCtx.VM.Labels = map[string]string{"app": "db"}
+		})
+
+		When("there are no backup policies", func() {
+
+			It("Should return an empty spec", func() {
+				spec, err := vsphere.GetBackupPolicySpec(vmCtx, k8sClient)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(spec.Resources).To(BeEmpty())
+				Expect(spec.ExcludedFieldPaths).To(BeEmpty())
+			})
+		})
+
+		When("there are backup policies", func() {
+
+			BeforeEach(func() {
+				policy1 := builder.DummyVirtualMachineBackupPolicy(vmCtx.VM.Namespace, "policy-1")
+				policy1.Spec.ExcludedFieldPaths = []string{"/metadata/labels", "/spec/minHardwareVersion"}
+
+				policy2 := builder.DummyVirtualMachineBackupPolicy(vmCtx.VM.Namespace, "policy-2")
+				policy2.Spec.VMSelector = &metav1.LabelSelector{
+					MatchLabels: map[string]string{"app": "db"},
+				}
+				policy2.Spec.Resources = []vmopv1.VirtualMachineBackupPolicyResource{
+					{
+						Kind: vmopv1.VirtualMachineBackupPolicyResourceKindNetworkPolicy,
+					},
+				}
+
+				policy3 := builder.DummyVirtualMachineBackupPolicy(vmCtx.VM.Namespace, "policy-3")
+				policy3.Spec.VMSelector = &metav1.LabelSelector{
+					MatchLabels: map[string]string{"app": "web"},
+				}
+				policy3.Spec.ExcludedFieldPaths = []string{"/spec/biosUUID"}
+
+				policy4 := builder.DummyVirtualMachineBackupPolicy("other-ns", "policy-4")
+				policy4.Spec.ExcludedFieldPaths = []string{"/spec/instanceUUID"}
+
+				initObjects = append(initObjects, policy1, policy2, policy3, policy4)
+			})
+
+			It("Should return the union of the policies that apply to the VM", func() {
+				spec, err := vsphere.GetBackupPolicySpec(vmCtx, k8sClient)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(spec.Resources).To(Equal([]vmopv1.VirtualMachineBackupPolicyResource{
+					{
+						Kind: vmopv1.VirtualMachineBackupPolicyResourceKindVirtualMachineService,
+					},
+					{
+						Kind: vmopv1.VirtualMachineBackupPolicyResourceKindNetworkPolicy,
+					},
+				}))
+				Expect(spec.ExcludedFieldPaths).To(Equal([]string{"/metadata/labels", "/spec/minHardwareVersion"}))
+			})
+		})
+	})
+
+	Context("GetBackupPolicyHash", func() {
+
+		It("Should return an empty hash for an empty policy", func() {
+			hash, err := vsphere.GetBackupPolicyHash(vmopv1.VirtualMachineBackupPolicySpec{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(hash).To(BeEmpty())
+		})
+
+		It("Should return a hash that changes with the policy", func() {
+			spec := vmopv1.VirtualMachineBackupPolicySpec{
+				ExcludedFieldPaths: []string{"/metadata/labels"},
+			}
+			hash1, err := vsphere.GetBackupPolicyHash(spec)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(hash1).ToNot(BeEmpty())
+
+			hash2, err := vsphere.GetBackupPolicyHash(spec)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(hash2).To(Equal(hash1))
+
+			spec.Resources = []vmopv1.VirtualMachineBackupPolicyResource{
+				{
+					Kind: vmopv1.VirtualMachineBackupPolicyResourceKindNetworkPolicy,
+				},
+			}
+			hash3, err := vsphere.GetBackupPolicyHash(spec)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(hash3).ToNot(Equal(hash1))
+		})
+	})
+
+	Context("GetBackupPolicyResources", func() {
+
+		var (
+			policyResources []vmopv1.VirtualMachineBackupPolicyResource
+		)
+
+		BeforeEach(func() {
+			vmCtx.VM.Labels = map[string]string{"app": "db"}
+
+			initObjects = append(initObjects,
+				&vmopv1.VirtualMachineService{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: vmCtx.VM.Namespace,
+						Name:      "db-svc",
+						Labels:    map[string]string{"tier": "backend"},
+					},
+					Spec: vmopv1.VirtualMachineServiceSpec{
+						Selector: map[string]string{"app": "db"},
+					},
+				},
+				&vmopv1.VirtualMachineService{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: vmCtx.VM.Namespace,
+						Name:      "web-svc",
+					},
+					Spec: vmopv1.VirtualMachineServiceSpec{
+						Selector: map[string]string{"app": "web"},
+					},
+				},
+				&networkingv1.NetworkPolicy{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: vmCtx.VM.Namespace,
+						Name:      "db-netpol",
+					},
+					Spec: networkingv1.NetworkPolicySpec{
+						PodSelector: metav1.LabelSelector{
+							MatchLabels: map[string]string{"app": "db"},
+						},
+					},
+				},
+				&networkingv1.NetworkPolicy{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: vmCtx.VM.Namespace,
+						Name:      "web-netpol",
+					},
+					Spec: networkingv1.NetworkPolicySpec{
+						PodSelector: metav1.LabelSelector{
+							MatchLabels: map[string]string{"app": "web"},
+						},
+					},
+				},
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: vmCtx.VM.Namespace,
+						Name:      "db-config",
+						Labels:    map[string]string{"tier": "backend"},
+					},
+				},
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "other-ns",
+						Name:      "other-config",
+						Labels:    map[string]string{"tier": "backend"},
+					},
+				},
+			)
+		})
+
+		AfterEach(func() {
+			policyResources = nil
+		})
+
+		When("no selectors are specified", func() {
+
+			BeforeEach(func() {
+				policyResources = []vmopv1.VirtualMachineBackupPolicyResource{
+					{
+						Kind: vmopv1.VirtualMachineBackupPolicyResourceKindVirtualMachineService,
+					},
+					{
+						Kind: vmopv1.VirtualMachineBackupPolicyResourceKindNetworkPolicy,
+					},
+					{
+						Kind: vmopv1.VirtualMachineBackupPolicyResourceKindConfigMap,
+					},
+				}
+			})
+
+			It("Should return the resources that select the VM", func() {
+				objects, err := vsphere.GetBackupPolicyResources(vmCtx, k8sClient, policyResources)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(objects).To(HaveLen(2))
+				Expect(objects[0].GetName()).To(Equal("db-netpol"))
+				Expect(objects[0].GetObjectKind().GroupVersionKind()).To(Equal(networkingv1.SchemeGroupVersion.WithKind("NetworkPolicy")))
+				Expect(objects[1].GetName()).To(Equal("db-svc"))
+				Expect(objects[1].GetObjectKind().GroupVersionKind()).To(Equal(vmopv1.GroupVersion.WithKind("VirtualMachineService")))
+			})
+		})
+
+		When("selectors are specified", func() {
+
+			BeforeEach(func() {
+				selector := &metav1.LabelSelector{
+					MatchLabels: map[string]string{"tier": "backend"},
+				}
+				policyResources = []vmopv1.VirtualMachineBackupPolicyResource{
+					{
+						Kind:     vmopv1.VirtualMachineBackupPolicyResourceKindConfigMap,
+						Selector: selector,
+					},
+					{
+						Kind:     vmopv1.VirtualMachineBackupPolicyResourceKindVirtualMachineService,
+						Selector: selector,
+					},
+					{
+						Kind: vmopv1.VirtualMachineBackupPolicyResourceKindVirtualMachineService,
+					},
+				}
+			})
+
+			It("Should return the resources in the VM's namespace that match the selectors once", func() {
+				objects, err := vsphere.GetBackupPolicyResources(vmCtx, k8sClient, policyResources)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(objects).To(HaveLen(2))
+				Expect(objects[0].GetName()).To(Equal("db-config"))
+				Expect(objects[0].GetObjectKind().GroupVersionKind()).To(Equal(corev1.SchemeGroupVersion.WithKind("ConfigMap")))
+				Expect(objects[1].GetName()).To(Equal("db-svc"))
+			})
+		})
+	})
 }
@@ -0,0 +1,244 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package cron parses standard, five field cron expressions and computes the
+// times at which they are scheduled.
+//
+// The fields are, in order, the minute (0-59), hour (0-23), day of the month
+// (1-31), month (1-12 or JAN-DEC), and day of the week (0-7 or SUN-SAT, where
+// both 0 and 7 are Sunday). Each field is a comma separated list of values,
+// ranges (1-5), and steps (*/15 or 1-30/5), or an asterisk for any value. The
+// descriptors @yearly, @annually, @monthly, @weekly, @daily, @midnight, and
+// @hourly may be used instead of the five fields.
+//
+// Like other cron implementations, if both the day of the month and the day
+// of the week are restricted, i.e. do not start with an asterisk, then a time
+// is scheduled when either matches.
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxYears is how far into the future to search for a scheduled time before
+// concluding an expression is never scheduled, ex. February 30th.
+const maxYears = 5
+
+// Schedule is a parsed cron expression.
+type Schedule struct {
+	minute uint64
+	hour   uint64
+	dom    uint64
+	month  uint64
+	dow    uint64
+
+	// domOrDow is true when both the day of the month and the day of the week
+	// are restricted, and a day matches if either one does.
+	domOrDow bool
+}
+
+type bounds struct {
+	name  string
+	min   uint
+	max   uint
+	names map[string]uint
+}
+
+var (
+	minuteBounds = bounds{name: "minute", min: 0, max: 59}
+	hourBounds   = bounds{name: "hour", min: 0, max: 23}
+	domBounds    = bounds{name: "day of month", min: 1, max: 31}
+	monthBounds  = bounds{name: "month", min: 1, max: 12, names: map[string]uint{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	dowBounds = bounds{name: "day of week", min: 0, max: 7, names: map[string]uint{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+)
+
+var descriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// Parse returns the Schedule for the cron expression.
+func Parse(expr string) (Schedule, error) {
+	expr = strings.TrimSpace(expr)
+	if strings.HasPrefix(expr, "@") {
+		d, ok := descriptors[strings.ToLower(expr)]
+		if !ok {
+			return Schedule{}, fmt.Errorf("unknown descriptor %q", expr)
+		}
+		expr = d
+	}
+
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return Schedule{}, fmt.Errorf(
+			"expected 5 fields but found %d in %q", len(fields), expr)
+	}
+
+	var (
+		s   Schedule
+		err error
+	)
+	if s.minute, err = parseField(fields[0], minuteBounds); err != nil {
+		return Schedule{}, err
+	}
+	if s.hour, err = parseField(fields[1], hourBounds); err != nil {
+		return Schedule{}, err
+	}
+	if s.dom, err = parseField(fields[2], domBounds); err != nil {
+		return Schedule{}, err
+	}
+	if s.month, err = parseField(fields[3], monthBounds); err != nil {
+		return Schedule{}, err
+	}
+	if s.dow, err = parseField(fields[4], dowBounds); err != nil {
+		return Schedule{}, err
+	}
+
+	// Sunday may be specified as either 0 or 7.
+	if s.dow&(1<<7) != 0 {
+		s.dow = (s.dow | 1) &^ (1 << 7)
+	}
+
+	s.domOrDow = !strings.HasPrefix(fields[2], "*") && !strings.HasPrefix(fields[4], "*")
+
+	return s, nil
+}
+
+func parseField(field string, b bounds) (uint64, error) {
+	var set uint64
+	for _, part := range strings.Split(field, ",") {
+		v, err := parsePart(part, b)
+		if err != nil {
+			return 0, err
+		}
+		set |= v
+	}
+	return set, nil
+}
+
+func parsePart(part string, b bounds) (uint64, error) {
+	rangePart, stepPart, hasStep := strings.Cut(part, "/")
+
+	var start, end uint
+	switch {
+	case rangePart == "*":
+		start, end = b.min, b.max
+	case strings.Contains(rangePart, "-"):
+		startPart, endPart, _ := strings.Cut(rangePart, "-")
+		var err error
+		if start, err = parseValue(startPart, b); err != nil {
+			return 0, err
+		}
+		if end, err = parseValue(endPart, b); err != nil {
+			return 0, err
+		}
+		if start > end {
+			return 0, fmt.Errorf("invalid %s range %q", b.name, rangePart)
+		}
+	default:
+		v, err := parseValue(rangePart, b)
+		if err != nil {
+			return 0, err
+		}
+		start, end = v, v
+		if hasStep {
+			// A step after a single value, ex. 5/15, is from the value to
+			// the end of the field's range.
+			end = b.max
+		}
+	}
+
+	step := uint(1)
+	if hasStep {
+		v, err := strconv.ParseUint(stepPart, 10, 8)
+		if err != nil || v == 0 {
+			return 0, fmt.Errorf("invalid %s step %q", b.name, stepPart)
+		}
+		step = uint(v)
+	}
+
+	var set uint64
+	for i := start; i <= end; i += step {
+		set |= 1 << i
+	}
+	return set, nil
+}
+
+func parseValue(s string, b bounds) (uint, error) {
+	if v, ok := b.names[strings.ToLower(s)]; ok {
+		return v, nil
+	}
+	v, err := strconv.ParseUint(s, 10, 8)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s value %q", b.name, s)
+	}
+	if uint(v) < b.min || uint(v) > b.max {
+		return 0, fmt.Errorf(
+			"%s value %d is not between %d and %d", b.name, v, b.min, b.max)
+	}
+	return uint(v), nil
+}
+
+// Next returns the first time after t at which the schedule is scheduled, in
+// the location of t. The zero time is returned if the schedule is never
+// scheduled, ex. for February 30th.
+func (s Schedule) Next(t time.Time) time.Time {
+	// Start at the next whole minute.
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	loc := t.Location()
+	yearLimit := t.Year() + maxYears
+
+	for t.Year() <= yearLimit {
+		if !has(s.month, uint(t.Month())) {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !s.matchDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !has(s.hour, uint(t.Hour())) {
+			next := time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+			if !next.After(t) {
+				// The hour was repeated when leaving daylight saving time.
+				next = t.Add(time.Duration(60-t.Minute()) * time.Minute)
+			}
+			t = next
+			continue
+		}
+		if !has(s.minute, uint(t.Minute())) {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+
+	return time.Time{}
+}
+
+func (s Schedule) matchDay(t time.Time) bool {
+	domMatch := has(s.dom, uint(t.Day()))
+	dowMatch := has(s.dow, uint(t.Weekday()))
+	if s.domOrDow {
+		return domMatch || dowMatch
+	}
+	return domMatch && dowMatch
+}
+
+func has(set uint64, v uint) bool {
+	return set&(1<<v) != 0
+}
@@ -0,0 +1,77 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package cron_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/vmware-tanzu/vm-operator/pkg/util/cron"
+)
+
+var _ = Describe("Parse", func() {
+
+	DescribeTable("invalid expressions",
+		func(expr, expectedErr string) {
+			_, err := cron.Parse(expr)
+			Expect(err).To(MatchError(expectedErr))
+		},
+		Entry("empty", "", `expected 5 fields but found 0 in ""`),
+		Entry("too few fields", "0 0 * *", `expected 5 fields but found 4 in "0 0 * *"`),
+		Entry("too many fields", "0 0 * * * *", `expected 5 fields but found 6 in "0 0 * * * *"`),
+		Entry("unknown descriptor", "@often", `unknown descriptor "@often"`),
+		Entry("minute out of range", "60 0 * * *", "minute value 60 is not between 0 and 59"),
+		Entry("hour out of range", "0 24 * * *", "hour value 24 is not between 0 and 23"),
+		Entry("day of month out of range", "0 0 0 * *", "day of month value 0 is not between 1 and 31"),
+		Entry("invalid month name", "0 0 * FOO *", `invalid month value "FOO"`),
+		Entry("invalid range", "0 0 * * 5-1", `invalid day of week range "5-1"`),
+		Entry("invalid step", "*/0 0 * * *", `invalid minute step "0"`),
+	)
+})
+
+var _ = Describe("Next", func() {
+
+	var loc *time.Location
+
+	BeforeEach(func() {
+		var err error
+		loc, err = time.LoadLocation("America/New_York")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	DescribeTable("scheduled times",
+		func(expr, from, expected string) {
+			s, err := cron.Parse(expr)
+			Expect(err).ToNot(HaveOccurred())
+
+			t, err := time.ParseInLocation(time.DateTime, from, loc)
+			Expect(err).ToNot(HaveOccurred())
+
+			next := s.Next(t)
+			if expected == "" {
+				Expect(next.IsZero()).To(BeTrue())
+				return
+			}
+			Expect(next.Location()).To(Equal(loc))
+			Expect(next.Format(time.DateTime)).To(Equal(expected))
+		},
+		Entry("every minute", "* * * * *", "2024-01-01 10:00:30", "2024-01-01 10:01:00"),
+		Entry("after the scheduled time", "0 19 * * *", "2024-01-01 19:00:00", "2024-01-02 19:00:00"),
+		Entry("step", "*/15 * * * *", "2024-01-01 10:16:00", "2024-01-01 10:30:00"),
+		Entry("range with step", "0 8-18/4 * * *", "2024-01-01 13:00:00", "2024-01-01 16:00:00"),
+		Entry("list", "0 7,19 * * *", "2024-01-01 08:00:00", "2024-01-01 19:00:00"),
+		Entry("weekdays", "0 7 * * MON-FRI", "2024-01-05 08:00:00", "2024-01-08 07:00:00"),
+		Entry("Sunday as 7", "0 7 * * 7", "2024-01-01 08:00:00", "2024-01-07 07:00:00"),
+		Entry("month name", "0 0 1 jun *", "2024-01-01 00:00:00", "2024-06-01 00:00:00"),
+		Entry("day of month or day of week", "0 0 15 * MON", "2024-01-09 00:00:00", "2024-01-15 00:00:00"),
+		Entry("leap day", "0 0 29 2 *", "2024-03-01 00:00:00", "2028-02-29 00:00:00"),
+		Entry("never", "0 0 30 2 *", "2024-01-01 00:00:00", ""),
+		Entry("descriptor", "@daily", "2024-01-01 10:00:00", "2024-01-02 00:00:00"),
+		Entry("skipped hour entering daylight saving time", "30 2 * * *", "2024-03-10 00:00:00", "2024-03-11 02:30:00"),
+		Entry("after repeated hour leaving daylight saving time", "0 2 * * *", "2024-11-03 00:00:00", "2024-11-03 02:00:00"),
+	)
+})
@@ -0,0 +1,510 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package iso9660 writes small, read-only ISO 9660 images with Joliet
+// extensions. Joliet preserves the case and characters of file names, which
+// guest tools like cloud-init and Ignition rely upon when reading data from a
+// config drive.
+package iso9660
+
+import (
+	"encoding/binary"
+	"fmt"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+const (
+	// SectorSize is the size of a logical sector in the image.
+	SectorSize = 2048
+
+	// MaxVolumeIDLength is the maximum length of a volume ID.
+	MaxVolumeIDLength = 16
+
+	// systemAreaSectors is the number of sectors reserved at the start of the
+	// image for the system area.
+	systemAreaSectors = 16
+
+	maxJolietNameLength = 64
+	maxISONameLength    = 30
+)
+
+// File is a file written to the image.
+type File struct {
+	// Path is the slash separated path of the file relative to the root of
+	// the image. Parent directories are created as needed.
+	Path string
+
+	// Data is the content of the file.
+	Data []byte
+}
+
+type tree int
+
+const (
+	primaryTree tree = iota
+	jolietTree
+)
+
+var trees = []tree{primaryTree, jolietTree}
+
+type entry struct {
+	name     string
+	data     []byte
+	isDir    bool
+	parent   *entry
+	children []*entry
+
+	// ident is the file identifier of the entry in each directory tree.
+	ident [2][]byte
+
+	// dirNum, dirLBA and dirSize are the directory number in the path table,
+	// location and size of a directory's extent in each directory tree.
+	dirNum  [2]uint16
+	dirLBA  [2]uint32
+	dirSize [2]uint32
+
+	// fileLBA is the location of a file's extent. It is shared by both trees.
+	fileLBA uint32
+}
+
+// Build returns an ISO 9660 image with Joliet extensions that contains the
+// given files. The output is deterministic, i.e. the same inputs always result
+// in the same image.
+func Build(volumeID string, files []File) ([]byte, error) {
+	if volumeID == "" {
+		return nil, fmt.Errorf("volume ID is required")
+	}
+	if len(volumeID) > MaxVolumeIDLength {
+		return nil, fmt.Errorf(
+			"volume ID %q exceeds %d characters", volumeID, MaxVolumeIDLength)
+	}
+
+	root := &entry{isDir: true}
+	root.parent = root
+	root.ident = [2][]byte{{0}, {0}}
+
+	for i := range files {
+		if err := root.add(files[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := root.setIdentifiers(); err != nil {
+		return nil, err
+	}
+
+	var (
+		dirs = [2][]*entry{
+			root.directories(primaryTree),
+			root.directories(jolietTree),
+		}
+		ptSize [2]uint32
+		ptLBA  [2][2]uint32
+		lba    = uint32(systemAreaSectors + 3)
+	)
+
+	// The little and big endian path tables for each tree.
+	for _, t := range trees {
+		ptSize[t] = pathTableSize(dirs[t], t)
+		for i := range ptLBA[t] {
+			ptLBA[t][i] = lba
+			lba += sectors(ptSize[t])
+		}
+	}
+
+	// The directory extents for each tree.
+	for _, t := range trees {
+		for _, d := range dirs[t] {
+			d.dirLBA[t] = lba
+			d.dirSize[t] = directorySize(d, t)
+			lba += sectors(d.dirSize[t])
+		}
+	}
+
+	// The file extents, which are shared by both trees.
+	for _, d := range dirs[primaryTree] {
+		for _, c := range d.sortedChildren(primaryTree) {
+			if !c.isDir && len(c.data) > 0 {
+				c.fileLBA = lba
+				lba += sectors(uint32(len(c.data)))
+			}
+		}
+	}
+
+	img := make([]byte, int(lba)*SectorSize)
+
+	for _, t := range trees {
+		writeVolumeDescriptor(
+			sector(img, systemAreaSectors+uint32(t)),
+			t, volumeID, lba, ptSize[t], ptLBA[t], root)
+	}
+	writeTerminator(sector(img, systemAreaSectors+2))
+
+	for _, t := range trees {
+		writePathTable(img[ptLBA[t][0]*SectorSize:], dirs[t], t, binary.LittleEndian)
+		writePathTable(img[ptLBA[t][1]*SectorSize:], dirs[t], t, binary.BigEndian)
+		for _, d := range dirs[t] {
+			writeDirectory(img[d.dirLBA[t]*SectorSize:], d, t)
+		}
+	}
+
+	for _, d := range dirs[primaryTree] {
+		for _, c := range d.children {
+			if !c.isDir {
+				copy(img[c.fileLBA*SectorSize:], c.data)
+			}
+		}
+	}
+
+	return img, nil
+}
+
+func (e *entry) add(f File) error {
+	p := path.Clean("/" + f.Path)
+	if p == "/" || p != "/"+strings.Trim(f.Path, "/") {
+		return fmt.Errorf("invalid file path %q", f.Path)
+	}
+
+	parts := strings.Split(p[1:], "/")
+	dir := e
+	for i, name := range parts {
+		var child *entry
+		for _, c := range dir.children {
+			if c.name == name {
+				child = c
+				break
+			}
+		}
+
+		isDir := i < len(parts)-1
+		switch {
+		case child == nil:
+			child = &entry{name: name, isDir: isDir, parent: dir}
+			dir.children = append(dir.children, child)
+		case !isDir || !child.isDir:
+			return fmt.Errorf("duplicate file path %q", f.Path)
+		}
+
+		if !isDir {
+			child.data = f.Data
+		}
+		dir = child
+	}
+
+	return nil
+}
+
+// setIdentifiers sets the file identifiers of the entry's descendants.
+func (e *entry) setIdentifiers() error {
+	used := map[string]struct{}{}
+	for _, c := range e.children {
+		jolietIdent, err := jolietIdentifier(c.name)
+		if err != nil {
+			return err
+		}
+		c.ident[jolietTree] = jolietIdent
+		c.ident[primaryTree] = []byte(isoIdentifier(c.name, c.isDir, used))
+
+		if c.isDir {
+			if err := c.setIdentifiers(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// sortedChildren returns the entry's children ordered by their identifiers
+// in the given tree.
+func (e *entry) sortedChildren(t tree) []*entry {
+	children := slices.Clone(e.children)
+	slices.SortFunc(children, func(a, b *entry) int {
+		return slices.Compare(a.ident[t], b.ident[t])
+	})
+	return children
+}
+
+// directories returns the entry and its descendant directories in the order
+// of the path table for the given tree and sets their directory numbers.
+func (e *entry) directories(t tree) []*entry {
+	dirs := []*entry{e}
+	for i := 0; i < len(dirs); i++ {
+		dirs[i].dirNum[t] = uint16(i + 1) //nolint:gosec
+		for _, c := range dirs[i].sortedChildren(t) {
+			if c.isDir {
+				dirs = append(dirs, c)
+			}
+		}
+	}
+	return dirs
+}
+
+// isoIdentifier returns an identifier for the name that only consists of
+// d-characters. The identifier is made unique among the used identifiers.
+func isoIdentifier(name string, isDir bool, used map[string]struct{}) string {
+	sanitize := func(s string) string {
+		return strings.Map(func(r rune) rune {
+			switch {
+			case r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_':
+				return r
+			case r >= 'a' && r <= 'z':
+				return r - 'a' + 'A'
+			default:
+				return '_'
+			}
+		}, s)
+	}
+
+	base, ext := name, ""
+	if !isDir {
+		if i := strings.LastIndex(name, "."); i >= 0 {
+			base, ext = name[:i], name[i+1:]
+		}
+		ext = sanitize(ext)
+		if len(ext) > 8 {
+			ext = ext[:8]
+		}
+	}
+	base = sanitize(base)
+
+	maxBase := maxISONameLength
+	if !isDir {
+		maxBase -= len(ext) + 1
+	}
+
+	format := func(base string) string {
+		if isDir {
+			return base
+		}
+		return base + "." + ext + ";1"
+	}
+
+	ident := format(base[:min(len(base), maxBase)])
+	for i := 1; ; i++ {
+		if _, ok := used[ident]; !ok {
+			break
+		}
+		suffix := "_" + strconv.Itoa(i)
+		ident = format(base[:min(len(base), maxBase-len(suffix))] + suffix)
+	}
+	used[ident] = struct{}{}
+
+	return ident
+}
+
+// jolietIdentifier returns the UCS-2 encoded identifier for the name.
+func jolietIdentifier(name string) ([]byte, error) {
+	if strings.ContainsAny(name, `*/:;?\`) {
+		return nil, fmt.Errorf("invalid character in file name %q", name)
+	}
+	u := utf16.Encode([]rune(name))
+	if len(u) > maxJolietNameLength {
+		return nil, fmt.Errorf(
+			"file name %q exceeds %d characters", name, maxJolietNameLength)
+	}
+	return ucs2(name), nil
+}
+
+func ucs2(s string) []byte {
+	u := utf16.Encode([]rune(s))
+	b := make([]byte, 2*len(u))
+	for i := range u {
+		binary.BigEndian.PutUint16(b[2*i:], u[i])
+	}
+	return b
+}
+
+func sector(img []byte, lba uint32) []byte {
+	return img[lba*SectorSize : (lba+1)*SectorSize]
+}
+
+func sectors(size uint32) uint32 {
+	return (size + SectorSize - 1) / SectorSize
+}
+
+func pathTableSize(dirs []*entry, t tree) uint32 {
+	var size uint32
+	for _, d := range dirs {
+		size += uint32(pathTableRecordLength(d.ident[t]))
+	}
+	return size
+}
+
+func pathTableRecordLength(ident []byte) int {
+	return 8 + len(ident) + len(ident)%2
+}
+
+func writePathTable(b []byte, dirs []*entry, t tree, order binary.ByteOrder) {
+	off := 0
+	for _, d := range dirs {
+		ident := d.ident[t]
+		b[off] = byte(len(ident))
+		order.PutUint32(b[off+2:], d.dirLBA[t])
+		order.PutUint16(b[off+6:], d.parent.dirNum[t])
+		copy(b[off+8:], ident)
+		off += pathTableRecordLength(ident)
+	}
+}
+
+func directoryRecordLength(ident []byte) int {
+	n := 33 + len(ident)
+	return n + n%2
+}
+
+// directoryRecords returns the records of the directory in order. The first
+// two records are the directory itself and its parent.
+func directoryRecords(d *entry, t tree) []func(b []byte) int {
+	records := []func(b []byte) int{
+		func(b []byte) int {
+			return writeDirectoryRecord(b, []byte{0}, d.dirLBA[t], d.dirSize[t], true)
+		},
+		func(b []byte) int {
+			p := d.parent
+			return writeDirectoryRecord(b, []byte{1}, p.dirLBA[t], p.dirSize[t], true)
+		},
+	}
+	for _, c := range d.sortedChildren(t) {
+		records = append(records, func(b []byte) int {
+			if c.isDir {
+				return writeDirectoryRecord(b, c.ident[t], c.dirLBA[t], c.dirSize[t], true)
+			}
+			return writeDirectoryRecord(b, c.ident[t], c.fileLBA, uint32(len(c.data)), false) //nolint:gosec
+		})
+	}
+	return records
+}
+
+// directorySize returns the size of the directory's extent. Directory records
+// may not span sectors, so a record that does not fit in the remainder of a
+// sector is placed at the start of the next one.
+func directorySize(d *entry, t tree) uint32 {
+	lengths := []int{directoryRecordLength([]byte{0}), directoryRecordLength([]byte{1})}
+	for _, c := range d.children {
+		lengths = append(lengths, directoryRecordLength(c.ident[t]))
+	}
+
+	off := 0
+	for _, n := range lengths {
+		if off%SectorSize+n > SectorSize {
+			off += SectorSize - off%SectorSize
+		}
+		off += n
+	}
+
+	return sectors(uint32(off)) * SectorSize //nolint:gosec
+}
+
+func writeDirectory(b []byte, d *entry, t tree) {
+	var (
+		off     = 0
+		scratch = make([]byte, 255)
+	)
+	for _, write := range directoryRecords(d, t) {
+		clear(scratch)
+		n := write(scratch)
+		if off%SectorSize+n > SectorSize {
+			off += SectorSize - off%SectorSize
+		}
+		copy(b[off:], scratch[:n])
+		off += n
+	}
+}
+
+func writeDirectoryRecord(b, ident []byte, lba, size uint32, isDir bool) int {
+	n := directoryRecordLength(ident)
+	b[0] = byte(n)
+	putBothUint32(b[2:], lba)
+	putBothUint32(b[10:], size)
+	if isDir {
+		b[25] = 2
+	}
+	putBothUint16(b[28:], 1)
+	b[32] = byte(len(ident))
+	copy(b[33:], ident)
+	return n
+}
+
+func writeVolumeDescriptor(
+	b []byte,
+	t tree,
+	volumeID string,
+	volumeSpaceSize uint32,
+	pathTableSize uint32,
+	pathTableLBA [2]uint32,
+	root *entry) {
+
+	writeString := func(b []byte, s string) {
+		if t == jolietTree {
+			for i := range len(b) / 2 {
+				binary.BigEndian.PutUint16(b[2*i:], ' ')
+			}
+			copy(b, ucs2(s))
+			return
+		}
+		for i := range b {
+			b[i] = ' '
+		}
+		copy(b, s)
+	}
+
+	b[0] = 1
+	if t == jolietTree {
+		b[0] = 2
+	}
+	copy(b[1:], "CD001")
+	b[6] = 1
+
+	writeString(b[8:40], "")
+	writeString(b[40:72], volumeID)
+	putBothUint32(b[80:], volumeSpaceSize)
+	if t == jolietTree {
+		// UCS-2 Level 3.
+		copy(b[88:], "%/E")
+	}
+	putBothUint16(b[120:], 1)
+	putBothUint16(b[124:], 1)
+	putBothUint16(b[128:], SectorSize)
+	putBothUint32(b[132:], pathTableSize)
+	binary.LittleEndian.PutUint32(b[140:], pathTableLBA[0])
+	binary.BigEndian.PutUint32(b[148:], pathTableLBA[1])
+	writeDirectoryRecord(b[156:], []byte{0}, root.dirLBA[t], root.dirSize[t], true)
+
+	// Volume set, publisher, data preparer, application, copyright,
+	// abstract and bibliographic identifiers.
+	writeString(b[190:318], "")
+	writeString(b[318:446], "")
+	writeString(b[446:574], "")
+	writeString(b[574:702], "")
+	writeString(b[702:739], "")
+	writeString(b[739:776], "")
+	writeString(b[776:813], "")
+
+	// The creation, modification, expiration and effective dates are not
+	// specified so the image is deterministic.
+	for off := 813; off < 881; off += 17 {
+		copy(b[off:], "0000000000000000")
+	}
+
+	b[881] = 1
+}
+
+func writeTerminator(b []byte) {
+	b[0] = 255
+	copy(b[1:], "CD001")
+	b[6] = 1
+}
+
+func putBothUint16(b []byte, v uint16) {
+	binary.LittleEndian.PutUint16(b, v)
+	binary.BigEndian.PutUint16(b[2:], v)
+}
+
+func putBothUint32(b []byte, v uint32) {
+	binary.LittleEndian.PutUint32(b, v)
+	binary.BigEndian.PutUint32(b[4:], v)
+}
@@ -0,0 +1,193 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package iso9660_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"strings"
+	"unicode/utf16"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/vmware-tanzu/vm-operator/pkg/util/iso9660"
+)
+
+// readTree returns the files in the directory tree described by the volume
+// descriptor in the given sector, keyed by their path.
+func readTree(img []byte, vdSector int) map[string][]byte {
+	vd := img[vdSector*iso9660.SectorSize:]
+	ExpectWithOffset(1, string(vd[1:6])).To(Equal("CD001"))
+
+	joliet := vd[0] == 2
+	name := func(ident []byte) string {
+		if !joliet {
+			return string(ident)
+		}
+		u := make([]uint16, len(ident)/2)
+		for i := range u {
+			u[i] = binary.BigEndian.Uint16(ident[2*i:])
+		}
+		return string(utf16.Decode(u))
+	}
+
+	files := map[string][]byte{}
+
+	var walk func(prefix string, lba, size uint32)
+	walk = func(prefix string, lba, size uint32) {
+		dir := img[lba*iso9660.SectorSize : lba*iso9660.SectorSize+size]
+		for off := 0; off < len(dir); {
+			n := int(dir[off])
+			if n == 0 {
+				off += iso9660.SectorSize - off%iso9660.SectorSize
+				continue
+			}
+			rec := dir[off : off+n]
+			off += n
+
+			ident := rec[33 : 33+int(rec[32])]
+			if len(ident) == 1 && ident[0] <= 1 {
+				continue
+			}
+
+			childLBA := binary.LittleEndian.Uint32(rec[2:])
+			childSize := binary.LittleEndian.Uint32(rec[10:])
+			p := prefix + name(ident)
+			if rec[25]&2 != 0 {
+				walk(p+"/", childLBA, childSize)
+				continue
+			}
+			start := childLBA * iso9660.SectorSize
+			files[p] = img[start : start+childSize]
+		}
+	}
+
+	root := vd[156:]
+	walk("", binary.LittleEndian.Uint32(root[2:]), binary.LittleEndian.Uint32(root[10:]))
+
+	return files
+}
+
+var _ = Describe("Build", func() {
+	var (
+		volumeID string
+		files    []iso9660.File
+		img      []byte
+		err      error
+	)
+
+	BeforeEach(func() {
+		volumeID = "config-2"
+		files = []iso9660.File{
+			{Path: "user-data", Data: []byte("#cloud-config\n")},
+			{Path: "meta-data", Data: []byte("instance-id: my-vm\n")},
+			{Path: "openstack/latest/meta_data.json", Data: []byte("{}")},
+			{Path: "empty"},
+		}
+	})
+
+	JustBeforeEach(func() {
+		img, err = iso9660.Build(volumeID, files)
+	})
+
+	It("returns an image with the files", func() {
+		Expect(err).ToNot(HaveOccurred())
+		Expect(len(img) % iso9660.SectorSize).To(BeZero())
+
+		By("primary volume descriptor", func() {
+			pvd := img[16*iso9660.SectorSize:]
+			Expect(pvd[0]).To(Equal(byte(1)))
+			Expect(strings.TrimRight(string(pvd[40:72]), " ")).To(Equal(volumeID))
+			Expect(binary.LittleEndian.Uint32(pvd[80:])).To(BeEquivalentTo(len(img) / iso9660.SectorSize))
+
+			Expect(readTree(img, 16)).To(Equal(map[string][]byte{
+				"USER_DATA.;1":                      []byte("#cloud-config\n"),
+				"META_DATA.;1":                      []byte("instance-id: my-vm\n"),
+				"OPENSTACK/LATEST/META_DATA.JSON;1": []byte("{}"),
+				"EMPTY.;1":                          {},
+			}))
+		})
+
+		By("Joliet supplementary volume descriptor", func() {
+			svd := img[17*iso9660.SectorSize:]
+			Expect(svd[0]).To(Equal(byte(2)))
+			Expect(string(svd[88:91])).To(Equal("%/E"))
+
+			Expect(readTree(img, 17)).To(Equal(map[string][]byte{
+				"user-data":                       []byte("#cloud-config\n"),
+				"meta-data":                       []byte("instance-id: my-vm\n"),
+				"openstack/latest/meta_data.json": []byte("{}"),
+				"empty":                           {},
+			}))
+		})
+
+		By("volume descriptor set terminator", func() {
+			Expect(img[18*iso9660.SectorSize]).To(Equal(byte(255)))
+		})
+	})
+
+	It("is deterministic", func() {
+		Expect(err).ToNot(HaveOccurred())
+		img2, err := iso9660.Build(volumeID, files)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(bytes.Equal(img, img2)).To(BeTrue())
+	})
+
+	When("a directory has more records than fit in a sector", func() {
+		BeforeEach(func() {
+			files = nil
+			for i := range 100 {
+				files = append(files, iso9660.File{
+					Path: fmt.Sprintf("dir/a-long-file-name-%03d.txt", i),
+					Data: bytes.Repeat([]byte{byte(i)}, 3000),
+				})
+			}
+		})
+
+		It("returns an image with all of the files", func() {
+			Expect(err).ToNot(HaveOccurred())
+			tree := readTree(img, 17)
+			Expect(tree).To(HaveLen(100))
+			Expect(tree).To(HaveKeyWithValue("dir/a-long-file-name-042.txt", bytes.Repeat([]byte{42}, 3000)))
+
+			Expect(readTree(img, 16)).To(HaveLen(100))
+		})
+	})
+
+	When("file names are the same after mapping to d-characters", func() {
+		BeforeEach(func() {
+			files = []iso9660.File{
+				{Path: "a-b", Data: []byte("1")},
+				{Path: "a_b", Data: []byte("2")},
+			}
+		})
+
+		It("returns an image with unique identifiers", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(readTree(img, 16)).To(HaveLen(2))
+			Expect(readTree(img, 17)).To(Equal(map[string][]byte{
+				"a-b": []byte("1"),
+				"a_b": []byte("2"),
+			}))
+		})
+	})
+
+	DescribeTable("invalid input",
+		func(volumeID string, files []iso9660.File, expectedErr string) {
+			_, err := iso9660.Build(volumeID, files)
+			Expect(err).To(MatchError(ContainSubstring(expectedErr)))
+		},
+		Entry("no volume ID", "", nil, "volume ID is required"),
+		Entry("long volume ID", strings.Repeat("a", 17), nil, "exceeds 16 characters"),
+		Entry("empty path", "cidata", []iso9660.File{{Path: ""}}, `invalid file path ""`),
+		Entry("relative path", "cidata", []iso9660.File{{Path: "a/../b"}}, `invalid file path "a/../b"`),
+		Entry("duplicate path", "cidata", []iso9660.File{{Path: "a"}, {Path: "a"}}, `duplicate file path "a"`),
+		Entry("file and directory", "cidata", []iso9660.File{{Path: "a"}, {Path: "a/b"}}, `duplicate file path "a/b"`),
+		Entry("invalid character", "cidata", []iso9660.File{{Path: "a:b"}}, `invalid character in file name "a:b"`),
+		Entry("long name", "cidata", []iso9660.File{{Path: strings.Repeat("a", 65)}}, "exceeds 64 characters"),
+	)
+})
@@ -0,0 +1,32 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package util_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/vmware-tanzu/vm-operator/pkg/util"
+)
+
+var _ = DescribeTable("ParseJSONPointer",
+	func(in string, out []string, expectedErr string) {
+		tokens, err := util.ParseJSONPointer(in)
+		if expectedErr != "" {
+			Expect(err).To(MatchError(expectedErr))
+			return
+		}
+		Expect(err).ToNot(HaveOccurred())
+		Expect(tokens).To(Equal(out))
+	},
+	Entry("empty string", "", nil, ""),
+	Entry("root", "/", []string{""}, ""),
+	Entry("nested", "/metadata/labels", []string{"metadata", "labels"}, ""),
+	Entry("escaped", "/metadata/annotations/example.com~1a~0b", []string{"metadata", "annotations", "example.com/a~b"}, ""),
+	Entry("escaped tilde followed by one", "/a~01", []string{"a~1"}, ""),
+	Entry("missing leading slash", "metadata", nil, `json pointer "metadata" must start with /`),
+	Entry("invalid escape", "/a~2", nil, `json pointer "/a~2" has invalid escape sequence`),
+	Entry("trailing tilde", "/a~", nil, `json pointer "/a~" has invalid escape sequence`),
+)
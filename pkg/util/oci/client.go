@@ -0,0 +1,406 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package oci
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// ErrNotFound is returned when a manifest does not exist in the registry.
+var ErrNotFound = errors.New("not found")
+
+// maxErrorBodySize is the number of bytes of a response body included in
+// the error returned for an unexpected response.
+const maxErrorBodySize = 1024
+
+// ClientOptions are the options used to create a Client.
+type ClientOptions struct {
+	// Username and Password are the credentials used to authenticate to the
+	// registry. The registry is accessed anonymously if they are empty.
+	Username string
+	Password string
+
+	// Insecure indicates the registry is accessed over plain HTTP.
+	Insecure bool
+
+	// HTTPClient is the client used to send requests to the registry. If
+	// nil, http.DefaultClient is used.
+	HTTPClient *http.Client
+}
+
+// Client pushes and pulls manifests and blobs to and from a registry.
+type Client struct {
+	baseURL    *url.URL
+	username   string
+	password   string
+	httpClient *http.Client
+
+	mu sync.Mutex
+	// authorization is the Authorization header for each repository,
+	// obtained from the registry's authentication challenge.
+	authorization map[string]string
+}
+
+// NewClient returns a new client for the registry host.
+func NewClient(host string, opts ClientOptions) *Client {
+	scheme := "https"
+	if opts.Insecure {
+		scheme = "http"
+	}
+
+	httpClient := opts.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	return &Client{
+		baseURL:       &url.URL{Scheme: scheme, Host: host, Path: "/"},
+		username:      opts.Username,
+		password:      opts.Password,
+		httpClient:    httpClient,
+		authorization: map[string]string{},
+	}
+}
+
+// PushBlob streams the content of the reader to a blob in the repository
+// and returns the blob's digest and size.
+func (c *Client) PushBlob(ctx context.Context, name string, r io.Reader) (string, int64, error) {
+	// Start the upload session. This also authenticates with the registry
+	// so the content, which cannot be replayed, is not sent to a request
+	// that is rejected as unauthorized.
+	resp, err := c.do(ctx, name, func() (*http.Request, error) {
+		return http.NewRequestWithContext(ctx, http.MethodPost,
+			c.baseURL.JoinPath("v2", name, "blobs", "uploads").String()+"/", nil)
+	})
+	if err != nil {
+		return "", 0, err
+	}
+	location, err := c.uploadLocation(resp)
+	if err != nil {
+		return "", 0, err
+	}
+
+	hash := sha256.New()
+	counter := &countingReader{r: io.TeeReader(r, hash)}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPatch, location.String(), counter)
+	if err != nil {
+		return "", 0, err
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	c.authorize(req, name)
+
+	resp, err = c.httpClient.Do(req)
+	if err != nil {
+		return "", 0, err
+	}
+	if location, err = c.uploadLocation(resp); err != nil {
+		return "", 0, err
+	}
+
+	digest := "sha256:" + hex.EncodeToString(hash.Sum(nil))
+	query := location.Query()
+	query.Set("digest", digest)
+	location.RawQuery = query.Encode()
+
+	resp, err = c.do(ctx, name, func() (*http.Request, error) {
+		return http.NewRequestWithContext(ctx, http.MethodPut, location.String(), nil)
+	})
+	if err != nil {
+		return "", 0, err
+	}
+	if err := checkResponse(resp, http.StatusCreated); err != nil {
+		return "", 0, err
+	}
+
+	return digest, counter.n, nil
+}
+
+// PushManifest pushes the manifest to the repository with the provided tag
+// and returns the manifest's digest.
+func (c *Client) PushManifest(ctx context.Context, name, tag string, manifest *Manifest) (string, error) {
+	data, err := json.Marshal(manifest)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := c.do(ctx, name, func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPut,
+			c.baseURL.JoinPath("v2", name, "manifests", tag).String(), bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", manifest.MediaType)
+		return req, nil
+	})
+	if err != nil {
+		return "", err
+	}
+	if err := checkResponse(resp, http.StatusCreated); err != nil {
+		return "", err
+	}
+
+	return Digest(data), nil
+}
+
+// GetManifest returns the manifest in the repository with the provided tag
+// or digest, and the manifest's digest. ErrNotFound is returned if the
+// manifest does not exist.
+func (c *Client) GetManifest(ctx context.Context, name, reference string) (*Manifest, string, error) {
+	resp, err := c.do(ctx, name, func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet,
+			c.baseURL.JoinPath("v2", name, "manifests", reference).String(), nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Accept", MediaTypeImageManifest)
+		return req, nil
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		_ = resp.Body.Close()
+		return nil, "", fmt.Errorf("manifest %s:%s: %w", name, reference, ErrNotFound)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", checkResponse(resp, http.StatusOK)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", err
+	}
+
+	manifest := &Manifest{}
+	if err := json.Unmarshal(data, manifest); err != nil {
+		return nil, "", fmt.Errorf("failed to decode manifest %s:%s: %w", name, reference, err)
+	}
+
+	digest := resp.Header.Get("Docker-Content-Digest")
+	if digest == "" {
+		digest = Digest(data)
+	}
+
+	return manifest, digest, nil
+}
+
+// do sends the request returned by newRequest. If the registry responds that
+// the request is unauthorized, the client authenticates as directed by the
+// registry's challenge and sends a new request.
+func (c *Client) do(ctx context.Context, name string, newRequest func() (*http.Request, error)) (*http.Response, error) {
+	req, err := newRequest()
+	if err != nil {
+		return nil, err
+	}
+	c.authorize(req, name)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusUnauthorized {
+		return resp, nil
+	}
+
+	challenge := resp.Header.Get("WWW-Authenticate")
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+
+	if err := c.login(ctx, name, challenge); err != nil {
+		return nil, err
+	}
+
+	if req, err = newRequest(); err != nil {
+		return nil, err
+	}
+	c.authorize(req, name)
+
+	return c.httpClient.Do(req)
+}
+
+func (c *Client) authorize(req *http.Request, name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if authorization, ok := c.authorization[name]; ok {
+		req.Header.Set("Authorization", authorization)
+	}
+}
+
+// login obtains the Authorization header for the repository as directed by
+// the registry's authentication challenge.
+func (c *Client) login(ctx context.Context, name, challenge string) error {
+	scheme, params := parseChallenge(challenge)
+
+	var authorization string
+	switch strings.ToLower(scheme) {
+	case "basic":
+		if c.username == "" {
+			return fmt.Errorf("registry %s requires credentials", c.baseURL.Host)
+		}
+		authorization = "Basic " + basicAuth(c.username, c.password)
+	case "bearer":
+		token, err := c.fetchToken(ctx, name, params)
+		if err != nil {
+			return err
+		}
+		authorization = "Bearer " + token
+	default:
+		return fmt.Errorf("registry %s returned unsupported authentication challenge %q", c.baseURL.Host, challenge)
+	}
+
+	c.mu.Lock()
+	c.authorization[name] = authorization
+	c.mu.Unlock()
+
+	return nil
+}
+
+// fetchToken returns a token from the registry's token server that grants
+// pull and push access to the repository.
+func (c *Client) fetchToken(ctx context.Context, name string, params map[string]string) (string, error) {
+	realm, err := url.Parse(params["realm"])
+	if err != nil || realm.Host == "" {
+		return "", fmt.Errorf("registry %s returned invalid token realm %q", c.baseURL.Host, params["realm"])
+	}
+
+	query := realm.Query()
+	if service := params["service"]; service != "" {
+		query.Set("service", service)
+	}
+	query.Set("scope", fmt.Sprintf("repository:%s:pull,push", name))
+	realm.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, realm.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	if c.username != "" {
+		req.SetBasicAuth(c.username, c.password)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", checkResponse(resp, http.StatusOK)
+	}
+	defer resp.Body.Close()
+
+	var tokenResponse struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&tokenResponse); err != nil {
+		return "", fmt.Errorf("failed to decode token response: %w", err)
+	}
+
+	if tokenResponse.Token != "" {
+		return tokenResponse.Token, nil
+	}
+	if tokenResponse.AccessToken != "" {
+		return tokenResponse.AccessToken, nil
+	}
+	return "", fmt.Errorf("token server %s returned no token", realm.Host)
+}
+
+// uploadLocation returns the URL of the blob upload session from the
+// registry's response to a request that started or continued the upload.
+func (c *Client) uploadLocation(resp *http.Response) (*url.URL, error) {
+	if err := checkResponse(resp, http.StatusAccepted); err != nil {
+		return nil, err
+	}
+
+	location := resp.Header.Get("Location")
+	if location == "" {
+		return nil, fmt.Errorf("registry %s returned no upload location", c.baseURL.Host)
+	}
+
+	return c.baseURL.Parse(location)
+}
+
+// checkResponse closes the response body and returns an error if the
+// response does not have the expected status code.
+func checkResponse(resp *http.Response, expected int) error {
+	defer resp.Body.Close()
+
+	if resp.StatusCode == expected {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil
+	}
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+	return fmt.Errorf("%s %s: unexpected status %s: %s",
+		resp.Request.Method, resp.Request.URL.Redacted(), resp.Status, strings.TrimSpace(string(body)))
+}
+
+// parseChallenge returns the scheme and parameters of a WWW-Authenticate
+// header, ex. Bearer realm="https://auth.example.com/token",service="example".
+func parseChallenge(challenge string) (string, map[string]string) {
+	scheme, rest, _ := strings.Cut(strings.TrimSpace(challenge), " ")
+	params := map[string]string{}
+
+	for rest = strings.TrimSpace(rest); rest != ""; {
+		key, value, ok := strings.Cut(rest, "=")
+		if !ok {
+			break
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+
+		if strings.HasPrefix(value, `"`) {
+			// Quoted values, ex. the scope, may contain commas.
+			end := strings.Index(value[1:], `"`)
+			if end < 0 {
+				params[key] = value[1:]
+				break
+			}
+			params[key] = value[1 : end+1]
+			value = value[end+2:]
+		} else {
+			end := strings.Index(value, ",")
+			if end < 0 {
+				end = len(value)
+			}
+			params[key] = strings.TrimSpace(value[:end])
+			value = value[end:]
+		}
+
+		rest = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(value), ","))
+	}
+
+	return scheme, params
+}
+
+func basicAuth(username, password string) string {
+	return base64.StdEncoding.EncodeToString([]byte(username + ":" + password))
+}
+
+// countingReader counts the number of bytes read from the underlying reader.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,204 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package oci_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/vmware-tanzu/vm-operator/pkg/util/oci"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/oci/fake"
+)
+
+var _ = Describe("Client", func() {
+	const (
+		name = "golden/ubuntu"
+		tag  = "v1"
+	)
+
+	var (
+		ctx      context.Context
+		registry *fake.Registry
+		opts     oci.ClientOptions
+		client   *oci.Client
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		registry = fake.NewRegistry()
+		opts = oci.ClientOptions{Insecure: true}
+	})
+
+	JustBeforeEach(func() {
+		client = oci.NewClient(registry.Host(), opts)
+	})
+
+	AfterEach(func() {
+		registry.Close()
+	})
+
+	pushArtifact := func() (string, string) {
+		content := []byte("disk content")
+		blobDigest, size, err := client.PushBlob(ctx, name, bytes.NewReader(content))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(blobDigest).To(Equal(oci.Digest(content)))
+		Expect(size).To(BeEquivalentTo(len(content)))
+
+		_, _, err = client.PushBlob(ctx, name, bytes.NewReader(oci.EmptyJSON))
+		Expect(err).ToNot(HaveOccurred())
+
+		manifest := &oci.Manifest{
+			SchemaVersion: 2,
+			MediaType:     oci.MediaTypeImageManifest,
+			ArtifactType:  "application/vnd.example",
+			Config:        oci.EmptyJSONDescriptor,
+			Layers: []oci.Descriptor{
+				{
+					MediaType:   "application/octet-stream",
+					Digest:      blobDigest,
+					Size:        size,
+					Annotations: map[string]string{oci.AnnotationTitle: "disk.vmdk"},
+				},
+			},
+		}
+		manifestDigest, err := client.PushManifest(ctx, name, tag, manifest)
+		Expect(err).ToNot(HaveOccurred())
+
+		return blobDigest, manifestDigest
+	}
+
+	It("pushes blobs and manifests", func() {
+		blobDigest, manifestDigest := pushArtifact()
+
+		data, ok := registry.Blob(blobDigest)
+		Expect(ok).To(BeTrue())
+		Expect(string(data)).To(Equal("disk content"))
+
+		_, ok = registry.Blob(oci.EmptyJSONDescriptor.Digest)
+		Expect(ok).To(BeTrue())
+
+		data, ok = registry.Manifest(name, tag)
+		Expect(ok).To(BeTrue())
+		Expect(oci.Digest(data)).To(Equal(manifestDigest))
+	})
+
+	It("gets a manifest by tag and digest", func() {
+		blobDigest, manifestDigest := pushArtifact()
+
+		for _, reference := range []string{tag, manifestDigest} {
+			manifest, digest, err := client.GetManifest(ctx, name, reference)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(digest).To(Equal(manifestDigest))
+			Expect(manifest.ArtifactType).To(Equal("application/vnd.example"))
+			Expect(manifest.Layers).To(HaveLen(1))
+			Expect(manifest.Layers[0].Digest).To(Equal(blobDigest))
+			Expect(manifest.Layers[0].Annotations).To(HaveKeyWithValue(oci.AnnotationTitle, "disk.vmdk"))
+		}
+	})
+
+	It("returns ErrNotFound for a missing manifest", func() {
+		_, _, err := client.GetManifest(ctx, name, tag)
+		Expect(err).To(MatchError(oci.ErrNotFound))
+	})
+
+	Context("registry requires basic auth", func() {
+		BeforeEach(func() {
+			registry.Close()
+			registry = fake.NewRegistryWithBasicAuth("user", "pass")
+		})
+
+		When("credentials are provided", func() {
+			BeforeEach(func() {
+				opts.Username = "user"
+				opts.Password = "pass"
+			})
+
+			It("pushes the artifact", func() {
+				_, manifestDigest := pushArtifact()
+
+				_, digest, err := client.GetManifest(ctx, name, tag)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(digest).To(Equal(manifestDigest))
+			})
+		})
+
+		When("credentials are not provided", func() {
+			It("returns an error", func() {
+				_, _, err := client.PushBlob(ctx, name, bytes.NewReader(oci.EmptyJSON))
+				Expect(err).To(MatchError(ContainSubstring("requires credentials")))
+			})
+		})
+
+		When("credentials are wrong", func() {
+			BeforeEach(func() {
+				opts.Username = "user"
+				opts.Password = "wrong"
+			})
+
+			It("returns an error", func() {
+				_, _, err := client.GetManifest(ctx, name, tag)
+				Expect(err).To(MatchError(ContainSubstring("401 Unauthorized")))
+			})
+		})
+	})
+
+	Context("registry requires a bearer token", func() {
+		var (
+			server     *httptest.Server
+			tokenQuery string
+		)
+
+		BeforeEach(func() {
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				if req.URL.Path == "/token" {
+					username, password, ok := req.BasicAuth()
+					if !ok || username != "user" || password != "pass" {
+						w.WriteHeader(http.StatusUnauthorized)
+						return
+					}
+					tokenQuery = req.URL.RawQuery
+					_ = json.NewEncoder(w).Encode(map[string]string{"token": "abc"})
+					return
+				}
+
+				if req.Header.Get("Authorization") != "Bearer abc" {
+					w.Header().Set("WWW-Authenticate", fmt.Sprintf(
+						`Bearer realm="http://%s/token",service="test",scope="repository:%s:pull,push"`,
+						req.Host, name))
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+
+				http.NotFound(w, req)
+			}))
+
+			opts.Username = "user"
+			opts.Password = "pass"
+		})
+
+		JustBeforeEach(func() {
+			client = oci.NewClient(strings.TrimPrefix(server.URL, "http://"), opts)
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		It("fetches a token for the repository", func() {
+			_, _, err := client.GetManifest(ctx, name, tag)
+			Expect(err).To(MatchError(oci.ErrNotFound))
+			Expect(tokenQuery).To(ContainSubstring("service=test"))
+			Expect(tokenQuery).To(ContainSubstring("scope=repository%3Agolden%2Fubuntu%3Apull%2Cpush"))
+		})
+	})
+})
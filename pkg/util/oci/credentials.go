@@ -0,0 +1,72 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package oci
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// dockerConfigJSON is the content of a Secret of type
+// kubernetes.io/dockerconfigjson.
+type dockerConfigJSON struct {
+	Auths map[string]dockerConfigEntry `json:"auths"`
+}
+
+type dockerConfigEntry struct {
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+	Auth     string `json:"auth,omitempty"`
+}
+
+// CredentialsFromSecret returns the username and password for the registry
+// host from a Secret of type kubernetes.io/dockerconfigjson or
+// kubernetes.io/basic-auth.
+func CredentialsFromSecret(secret *corev1.Secret, host string) (username, password string, err error) {
+	switch secret.Type {
+	case corev1.SecretTypeBasicAuth:
+		return string(secret.Data[corev1.BasicAuthUsernameKey]), string(secret.Data[corev1.BasicAuthPasswordKey]), nil
+
+	case corev1.SecretTypeDockerConfigJson:
+		var config dockerConfigJSON
+		if err := json.Unmarshal(secret.Data[corev1.DockerConfigJsonKey], &config); err != nil {
+			return "", "", fmt.Errorf("failed to decode %s of secret %s: %w", corev1.DockerConfigJsonKey, secret.Name, err)
+		}
+
+		for server, entry := range config.Auths {
+			if registryHost(server) != host {
+				continue
+			}
+
+			if entry.Username != "" || entry.Auth == "" {
+				return entry.Username, entry.Password, nil
+			}
+
+			auth, err := base64.StdEncoding.DecodeString(entry.Auth)
+			if err != nil {
+				return "", "", fmt.Errorf("failed to decode auth for %s in secret %s: %w", server, secret.Name, err)
+			}
+			username, password, _ = strings.Cut(string(auth), ":")
+			return username, password, nil
+		}
+
+		return "", "", fmt.Errorf("secret %s has no credentials for registry %s", secret.Name, host)
+
+	default:
+		return "", "", fmt.Errorf("secret %s has unsupported type %q", secret.Name, secret.Type)
+	}
+}
+
+// registryHost returns the host of a server in a Docker config, which may be
+// a host or a URL, ex. https://registry.example.com/v1/.
+func registryHost(server string) string {
+	server = strings.TrimPrefix(strings.TrimPrefix(server, "https://"), "http://")
+	host, _, _ := strings.Cut(server, "/")
+	return host
+}
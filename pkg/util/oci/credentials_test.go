@@ -0,0 +1,96 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package oci_test
+
+import (
+	"encoding/base64"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/vmware-tanzu/vm-operator/pkg/util/oci"
+)
+
+var _ = Describe("CredentialsFromSecret", func() {
+	const host = "registry.example.com"
+
+	var secret *corev1.Secret
+
+	BeforeEach(func() {
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "registry-creds"},
+		}
+	})
+
+	When("secret is basic-auth", func() {
+		BeforeEach(func() {
+			secret.Type = corev1.SecretTypeBasicAuth
+			secret.Data = map[string][]byte{
+				corev1.BasicAuthUsernameKey: []byte("user"),
+				corev1.BasicAuthPasswordKey: []byte("pass"),
+			}
+		})
+
+		It("returns the credentials", func() {
+			username, password, err := oci.CredentialsFromSecret(secret, host)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(username).To(Equal("user"))
+			Expect(password).To(Equal("pass"))
+		})
+	})
+
+	When("secret is dockerconfigjson", func() {
+		BeforeEach(func() {
+			secret.Type = corev1.SecretTypeDockerConfigJson
+		})
+
+		DescribeTable("returns the credentials for the host",
+			func(config string) {
+				secret.Data = map[string][]byte{corev1.DockerConfigJsonKey: []byte(config)}
+
+				username, password, err := oci.CredentialsFromSecret(secret, host)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(username).To(Equal("user"))
+				Expect(password).To(Equal("pass"))
+			},
+			Entry("username and password",
+				`{"auths":{"registry.example.com":{"username":"user","password":"pass"}}}`),
+			Entry("auth",
+				`{"auths":{"registry.example.com":{"auth":"`+base64.StdEncoding.EncodeToString([]byte("user:pass"))+`"}}}`),
+			Entry("server URL",
+				`{"auths":{"other.example.com":{"username":"other"},"https://registry.example.com/v1/":{"username":"user","password":"pass"}}}`),
+		)
+
+		It("returns an error when there are no credentials for the host", func() {
+			secret.Data = map[string][]byte{
+				corev1.DockerConfigJsonKey: []byte(`{"auths":{"other.example.com":{"username":"user"}}}`),
+			}
+
+			_, _, err := oci.CredentialsFromSecret(secret, host)
+			Expect(err).To(MatchError(ContainSubstring("has no credentials for registry registry.example.com")))
+		})
+
+		It("returns an error when the config is invalid", func() {
+			secret.Data = map[string][]byte{corev1.DockerConfigJsonKey: []byte("{")}
+
+			_, _, err := oci.CredentialsFromSecret(secret, host)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	When("secret has an unsupported type", func() {
+		BeforeEach(func() {
+			secret.Type = corev1.SecretTypeOpaque
+		})
+
+		It("returns an error", func() {
+			_, _, err := oci.CredentialsFromSecret(secret, host)
+			Expect(err).To(MatchError(ContainSubstring("unsupported type")))
+		})
+	})
+})
@@ -0,0 +1,202 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package fake provides an in-memory OCI registry for tests.
+package fake
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+
+	"github.com/vmware-tanzu/vm-operator/pkg/util/oci"
+)
+
+// Registry is an in-memory OCI registry that supports the blob upload and
+// manifest endpoints of the OCI distribution specification over plain HTTP.
+type Registry struct {
+	server *httptest.Server
+
+	username string
+	password string
+
+	mu        sync.Mutex
+	nextID    int
+	uploads   map[string]*bytes.Buffer
+	blobs     map[string][]byte
+	manifests map[string][]byte
+}
+
+// NewRegistry returns a new registry that allows anonymous access.
+func NewRegistry() *Registry {
+	return NewRegistryWithBasicAuth("", "")
+}
+
+// NewRegistryWithBasicAuth returns a new registry that requires the provided
+// credentials. Anonymous access is allowed if the username is empty.
+func NewRegistryWithBasicAuth(username, password string) *Registry {
+	r := &Registry{
+		username:  username,
+		password:  password,
+		uploads:   map[string]*bytes.Buffer{},
+		blobs:     map[string][]byte{},
+		manifests: map[string][]byte{},
+	}
+	r.server = httptest.NewServer(http.HandlerFunc(r.serveHTTP))
+	return r
+}
+
+// Host returns the host of the registry, ex. 127.0.0.1:12345.
+func (r *Registry) Host() string {
+	return r.server.Listener.Addr().String()
+}
+
+// Close shuts down the registry.
+func (r *Registry) Close() {
+	r.server.Close()
+}
+
+// Blob returns the content of the blob with the provided digest.
+func (r *Registry) Blob(digest string) ([]byte, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	data, ok := r.blobs[digest]
+	return data, ok
+}
+
+// Manifest returns the content of the manifest in the repository with the
+// provided tag or digest.
+func (r *Registry) Manifest(name, reference string) ([]byte, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	data, ok := r.manifests[name+":"+reference]
+	return data, ok
+}
+
+func (r *Registry) serveHTTP(w http.ResponseWriter, req *http.Request) {
+	if r.username != "" {
+		if username, password, ok := req.BasicAuth(); !ok || username != r.username || password != r.password {
+			w.Header().Set("WWW-Authenticate", `Basic realm="fake"`)
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+	}
+
+	path, ok := strings.CutPrefix(req.URL.Path, "/v2/")
+	if !ok {
+		http.NotFound(w, req)
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if i := strings.LastIndex(path, "/manifests/"); i > 0 {
+		r.serveManifest(w, req, path[:i], path[i+len("/manifests/"):])
+		return
+	}
+
+	if i := strings.LastIndex(path, "/blobs/uploads/"); i > 0 {
+		r.serveUpload(w, req, path[:i], path[i+len("/blobs/uploads/"):])
+		return
+	}
+
+	if i := strings.LastIndex(path, "/blobs/"); i > 0 {
+		r.serveBlob(w, req, path[i+len("/blobs/"):])
+		return
+	}
+
+	http.NotFound(w, req)
+}
+
+func (r *Registry) serveManifest(w http.ResponseWriter, req *http.Request, name, reference string) {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead:
+		data, ok := r.manifests[name+":"+reference]
+		if !ok {
+			http.Error(w, "manifest unknown", http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", oci.MediaTypeImageManifest)
+		w.Header().Set("Docker-Content-Digest", oci.Digest(data))
+		if req.Method == http.MethodGet {
+			_, _ = w.Write(data)
+		}
+
+	case http.MethodPut:
+		data, err := io.ReadAll(req.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		digest := oci.Digest(data)
+		r.manifests[name+":"+reference] = data
+		r.manifests[name+":"+digest] = data
+		w.Header().Set("Docker-Content-Digest", digest)
+		w.WriteHeader(http.StatusCreated)
+
+	default:
+		http.Error(w, "unsupported", http.StatusMethodNotAllowed)
+	}
+}
+
+func (r *Registry) serveUpload(w http.ResponseWriter, req *http.Request, name, id string) {
+	if req.Method == http.MethodPost && id == "" {
+		r.nextID++
+		id = fmt.Sprint(r.nextID)
+		r.uploads[id] = &bytes.Buffer{}
+		w.Header().Set("Location", fmt.Sprintf("/v2/%s/blobs/uploads/%s", name, id))
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+
+	upload, ok := r.uploads[id]
+	if !ok {
+		http.Error(w, "blob upload unknown", http.StatusNotFound)
+		return
+	}
+
+	if _, err := io.Copy(upload, req.Body); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	switch req.Method {
+	case http.MethodPatch:
+		w.Header().Set("Location", fmt.Sprintf("/v2/%s/blobs/uploads/%s", name, id))
+		w.WriteHeader(http.StatusAccepted)
+
+	case http.MethodPut:
+		digest := req.URL.Query().Get("digest")
+		if digest != oci.Digest(upload.Bytes()) {
+			http.Error(w, "digest invalid", http.StatusBadRequest)
+			return
+		}
+		delete(r.uploads, id)
+		r.blobs[digest] = upload.Bytes()
+		w.WriteHeader(http.StatusCreated)
+
+	default:
+		http.Error(w, "unsupported", http.StatusMethodNotAllowed)
+	}
+}
+
+func (r *Registry) serveBlob(w http.ResponseWriter, req *http.Request, digest string) {
+	data, ok := r.blobs[digest]
+	if !ok {
+		http.Error(w, "blob unknown", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Docker-Content-Digest", digest)
+	if req.Method == http.MethodGet {
+		_, _ = w.Write(data)
+	}
+}
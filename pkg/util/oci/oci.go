@@ -0,0 +1,95 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package oci implements the subset of the OCI distribution specification
+// used to push VM images as OCI artifacts to a registry.
+package oci
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const (
+	// MediaTypeImageManifest is the media type of an OCI image manifest.
+	MediaTypeImageManifest = "application/vnd.oci.image.manifest.v1+json"
+
+	// MediaTypeEmptyJSON is the media type of the empty config blob of an
+	// OCI artifact.
+	MediaTypeEmptyJSON = "application/vnd.oci.empty.v1+json"
+
+	// AnnotationTitle is the annotation for the file name of a layer.
+	AnnotationTitle = "org.opencontainers.image.title"
+
+	// AnnotationDescription is the annotation for the description of an
+	// artifact.
+	AnnotationDescription = "org.opencontainers.image.description"
+
+	// AnnotationCreated is the annotation for the time an artifact was
+	// created, in RFC3339 form.
+	AnnotationCreated = "org.opencontainers.image.created"
+)
+
+// tagRegexp matches a valid tag of a manifest.
+var tagRegexp = regexp.MustCompile(`^[a-zA-Z0-9_][a-zA-Z0-9._-]{0,127}$`)
+
+// EmptyJSON is the content of the empty config blob of an OCI artifact.
+var EmptyJSON = []byte("{}")
+
+// EmptyJSONDescriptor is the descriptor of the empty config blob of an OCI
+// artifact.
+var EmptyJSONDescriptor = Descriptor{
+	MediaType: MediaTypeEmptyJSON,
+	Digest:    Digest(EmptyJSON),
+	Size:      int64(len(EmptyJSON)),
+}
+
+// Descriptor describes the content of a blob.
+type Descriptor struct {
+	MediaType   string            `json:"mediaType"`
+	Digest      string            `json:"digest"`
+	Size        int64             `json:"size"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// Manifest is an OCI image manifest.
+type Manifest struct {
+	SchemaVersion int               `json:"schemaVersion"`
+	MediaType     string            `json:"mediaType"`
+	ArtifactType  string            `json:"artifactType,omitempty"`
+	Config        Descriptor        `json:"config"`
+	Layers        []Descriptor      `json:"layers"`
+	Annotations   map[string]string `json:"annotations,omitempty"`
+}
+
+// Digest returns the sha256 digest of the provided data.
+func Digest(data []byte) string {
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// ParseRepository splits a repository, ex. registry.example.com/images/ubuntu,
+// into the registry host and the repository name. The registry host is
+// required.
+func ParseRepository(repository string) (host, name string, err error) {
+	host, name, ok := strings.Cut(repository, "/")
+	if !ok || name == "" || !strings.ContainsAny(host, ".:") && host != "localhost" {
+		return "", "", fmt.Errorf("repository %q must include the registry host", repository)
+	}
+
+	if name != strings.ToLower(name) || strings.Contains(name, "//") ||
+		strings.HasSuffix(name, "/") || strings.ContainsAny(name, ":@") {
+		return "", "", fmt.Errorf("repository %q has an invalid name", repository)
+	}
+
+	return host, name, nil
+}
+
+// IsValidTag returns true if the tag is a valid tag of a manifest.
+func IsValidTag(tag string) bool {
+	return tagRegexp.MatchString(tag)
+}
@@ -0,0 +1,61 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package oci_test
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/vmware-tanzu/vm-operator/pkg/util/oci"
+)
+
+var _ = Describe("ParseRepository", func() {
+
+	DescribeTable("valid repositories",
+		func(repository, expectedHost, expectedName string) {
+			host, name, err := oci.ParseRepository(repository)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(host).To(Equal(expectedHost))
+			Expect(name).To(Equal(expectedName))
+		},
+		Entry("host with domain", "registry.example.com/images/ubuntu", "registry.example.com", "images/ubuntu"),
+		Entry("host with port", "10.0.0.1:5000/ubuntu", "10.0.0.1:5000", "ubuntu"),
+		Entry("localhost", "localhost/ubuntu", "localhost", "ubuntu"),
+	)
+
+	DescribeTable("invalid repositories",
+		func(repository string) {
+			_, _, err := oci.ParseRepository(repository)
+			Expect(err).To(HaveOccurred())
+		},
+		Entry("no host", "images/ubuntu"),
+		Entry("no name", "registry.example.com/"),
+		Entry("only host", "registry.example.com"),
+		Entry("upper case name", "registry.example.com/Ubuntu"),
+		Entry("tag", "registry.example.com/ubuntu:22.04"),
+		Entry("empty path component", "registry.example.com/images//ubuntu"),
+	)
+})
+
+var _ = DescribeTable("IsValidTag",
+	func(tag string, expected bool) {
+		Expect(oci.IsValidTag(tag)).To(Equal(expected))
+	},
+	Entry("simple", "v1", true),
+	Entry("with dots and dashes", "ubuntu-22.04_lts", true),
+	Entry("empty", "", false),
+	Entry("leading dash", "-v1", false),
+	Entry("slash", "v1/latest", false),
+	Entry("too long", strings.Repeat("a", 129), false),
+)
+
+var _ = Describe("Digest", func() {
+	It("returns the sha256 digest", func() {
+		Expect(oci.Digest(oci.EmptyJSON)).To(Equal(
+			"sha256:44136fa355b3678a1146ad16f7e8649e94fb4fc21fe77e8310c060f61caaff8a"))
+	})
+})
@@ -22,9 +22,12 @@ package util
 
 import (
 	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
 	"fmt"
 	"hash/fnv"
 
+	"k8s.io/apimachinery/pkg/util/rand"
 	"k8s.io/apimachinery/pkg/util/validation"
 )
 
@@ -56,3 +59,31 @@ func MustFormatValue(str string) string {
 
 	return val
 }
+
+// ComputeHash returns a hash value calculated from the provided object and
+// collision count that is safe to use as a Kubernetes label value or as part
+// of a resource name. It is used to identify the revision of a
+// VirtualMachineDeployment's template. The collision count, if any, is used to
+// avoid hash collisions between different templates.
+func ComputeHash(obj any, collisionCount *int32) (string, error) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return "", err
+	}
+
+	hasher := fnv.New32a()
+	if _, err := hasher.Write(data); err != nil {
+		return "", err
+	}
+
+	// Add the collision count to the hash if it exists.
+	if collisionCount != nil {
+		collisionCountBytes := make([]byte, 8)
+		binary.LittleEndian.PutUint32(collisionCountBytes, uint32(*collisionCount)) //nolint:gosec
+		if _, err := hasher.Write(collisionCountBytes); err != nil {
+			return "", err
+		}
+	}
+
+	return rand.SafeEncodeString(fmt.Sprint(hasher.Sum32())), nil
+}
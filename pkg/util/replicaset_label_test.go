@@ -14,6 +14,7 @@ import (
 	. "github.com/onsi/gomega"
 
 	"github.com/vmware-tanzu/vm-operator/pkg/util"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/ptr"
 )
 
 var _ = Describe("FormatValue", func() {
@@ -59,3 +60,38 @@ var _ = Describe("MustFormatValue", func() {
 		})
 	})
 })
+
+var _ = Describe("ComputeHash", func() {
+	type obj struct {
+		Name  string
+		Value int
+	}
+
+	It("returns the same hash for equal objects", func() {
+		h1, err := util.ComputeHash(obj{Name: "a", Value: 1}, nil)
+		Expect(err).ToNot(HaveOccurred())
+		h2, err := util.ComputeHash(obj{Name: "a", Value: 1}, nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(h1).To(Equal(h2))
+		Expect(util.FormatValue(h1)).To(Equal(h1))
+	})
+
+	It("returns a different hash for different objects", func() {
+		h1, err := util.ComputeHash(obj{Name: "a", Value: 1}, nil)
+		Expect(err).ToNot(HaveOccurred())
+		h2, err := util.ComputeHash(obj{Name: "a", Value: 2}, nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(h1).ToNot(Equal(h2))
+	})
+
+	It("returns a different hash for different collision counts", func() {
+		h1, err := util.ComputeHash(obj{Name: "a", Value: 1}, nil)
+		Expect(err).ToNot(HaveOccurred())
+		h2, err := util.ComputeHash(obj{Name: "a", Value: 1}, ptr.To(int32(1)))
+		Expect(err).ToNot(HaveOccurred())
+		h3, err := util.ComputeHash(obj{Name: "a", Value: 1}, ptr.To(int32(2)))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(h1).ToNot(Equal(h2))
+		Expect(h2).ToNot(Equal(h3))
+	})
+})
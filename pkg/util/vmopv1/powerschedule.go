@@ -0,0 +1,72 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package vmopv1
+
+import (
+	"fmt"
+	"time"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/cron"
+)
+
+// GetPowerScheduleLocation returns the location in which the power schedule is
+// evaluated. UTC is returned if the schedule does not specify a time zone.
+func GetPowerScheduleLocation(
+	schedule vmopv1.VirtualMachinePowerScheduleSpec) (*time.Location, error) {
+
+	switch schedule.TimeZone {
+	case "":
+		return time.UTC, nil
+	case "Local":
+		// The local time zone of the controller is not meaningful to users.
+		return nil, fmt.Errorf("unknown time zone %s", schedule.TimeZone)
+	}
+	return time.LoadLocation(schedule.TimeZone)
+}
+
+// NextScheduledPowerState returns the power state to which the power schedule
+// next changes the VM after t, and the time at which it does so. An empty
+// power state is returned if the schedule never changes the VM's power state.
+func NextScheduledPowerState(
+	schedule vmopv1.VirtualMachinePowerScheduleSpec,
+	t time.Time) (vmopv1.VirtualMachinePowerState, time.Time, error) {
+
+	loc, err := GetPowerScheduleLocation(schedule)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	t = t.In(loc)
+
+	var (
+		nextPowerState vmopv1.VirtualMachinePowerState
+		nextTime       time.Time
+	)
+
+	for _, e := range []struct {
+		expr       string
+		powerState vmopv1.VirtualMachinePowerState
+	}{
+		{schedule.PowerOn, vmopv1.VirtualMachinePowerStateOn},
+		{schedule.PowerOff, vmopv1.VirtualMachinePowerStateOff},
+		{schedule.Suspend, vmopv1.VirtualMachinePowerStateSuspended},
+	} {
+		if e.expr == "" {
+			continue
+		}
+		s, err := cron.Parse(e.expr)
+		if err != nil {
+			return "", time.Time{}, fmt.Errorf(
+				"invalid %s schedule: %w", e.powerState, err)
+		}
+		if next := s.Next(t); !next.IsZero() &&
+			(nextTime.IsZero() || next.Before(nextTime)) {
+
+			nextPowerState, nextTime = e.powerState, next
+		}
+	}
+
+	return nextPowerState, nextTime, nil
+}
@@ -0,0 +1,126 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package vmopv1_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	vmopv1util "github.com/vmware-tanzu/vm-operator/pkg/util/vmopv1"
+)
+
+var _ = Describe("GetPowerScheduleLocation", func() {
+
+	DescribeTable("Time zones",
+		func(timeZone, expectedLocation string, expectErr bool) {
+			loc, err := vmopv1util.GetPowerScheduleLocation(
+				vmopv1.VirtualMachinePowerScheduleSpec{TimeZone: timeZone})
+			if expectErr {
+				Expect(err).To(HaveOccurred())
+				return
+			}
+			Expect(err).ToNot(HaveOccurred())
+			Expect(loc.String()).To(Equal(expectedLocation))
+		},
+		Entry("empty", "", "UTC", false),
+		Entry("UTC", "UTC", "UTC", false),
+		Entry("IANA name", "America/New_York", "America/New_York", false),
+		Entry("Local", "Local", "", true),
+		Entry("unknown", "Nowhere/Special", "", true),
+	)
+})
+
+var _ = Describe("NextScheduledPowerState", func() {
+
+	// Monday, October 19th, 2026 at 12:00 UTC.
+	now := time.Date(2026, time.October, 19, 12, 0, 0, 0, time.UTC)
+
+	DescribeTable("Schedules",
+		func(schedule vmopv1.VirtualMachinePowerScheduleSpec,
+			expectedPowerState vmopv1.VirtualMachinePowerState,
+			expectedTime time.Time,
+			expectedErr string) {
+
+			powerState, t, err := vmopv1util.NextScheduledPowerState(schedule, now)
+			if expectedErr != "" {
+				Expect(err).To(MatchError(ContainSubstring(expectedErr)))
+				return
+			}
+			Expect(err).ToNot(HaveOccurred())
+			Expect(powerState).To(Equal(expectedPowerState))
+			Expect(t.Equal(expectedTime)).To(BeTrue(), "expected %s, got %s", expectedTime, t)
+		},
+		Entry("empty schedule",
+			vmopv1.VirtualMachinePowerScheduleSpec{},
+			vmopv1.VirtualMachinePowerState(""),
+			time.Time{},
+			"",
+		),
+		Entry("power off is next",
+			vmopv1.VirtualMachinePowerScheduleSpec{
+				PowerOn:  "0 8 * * MON-FRI",
+				PowerOff: "0 18 * * MON-FRI",
+			},
+			vmopv1.VirtualMachinePowerStateOff,
+			time.Date(2026, time.October, 19, 18, 0, 0, 0, time.UTC),
+			"",
+		),
+		Entry("power on is next",
+			vmopv1.VirtualMachinePowerScheduleSpec{
+				PowerOn:  "0 8 * * MON-FRI",
+				PowerOff: "0 11 * * MON-FRI",
+			},
+			vmopv1.VirtualMachinePowerStateOn,
+			time.Date(2026, time.October, 20, 8, 0, 0, 0, time.UTC),
+			"",
+		),
+		Entry("suspend is next",
+			vmopv1.VirtualMachinePowerScheduleSpec{
+				PowerOn: "@daily",
+				Suspend: "30 12 * * *",
+			},
+			vmopv1.VirtualMachinePowerStateSuspended,
+			time.Date(2026, time.October, 19, 12, 30, 0, 0, time.UTC),
+			"",
+		),
+		Entry("evaluated in time zone",
+			vmopv1.VirtualMachinePowerScheduleSpec{
+				TimeZone: "America/New_York",
+				PowerOff: "0 18 * * *",
+			},
+			vmopv1.VirtualMachinePowerStateOff,
+			time.Date(2026, time.October, 19, 22, 0, 0, 0, time.UTC),
+			"",
+		),
+		Entry("never scheduled",
+			vmopv1.VirtualMachinePowerScheduleSpec{
+				PowerOn: "0 0 30 2 *",
+			},
+			vmopv1.VirtualMachinePowerState(""),
+			time.Time{},
+			"",
+		),
+		Entry("invalid time zone",
+			vmopv1.VirtualMachinePowerScheduleSpec{
+				TimeZone: "Local",
+				PowerOn:  "@daily",
+			},
+			vmopv1.VirtualMachinePowerState(""),
+			time.Time{},
+			"unknown time zone Local",
+		),
+		Entry("invalid expression",
+			vmopv1.VirtualMachinePowerScheduleSpec{
+				PowerOff: "0 18 * *",
+			},
+			vmopv1.VirtualMachinePowerState(""),
+			time.Time{},
+			"invalid PoweredOff schedule: expected 5 fields",
+		),
+	)
+})
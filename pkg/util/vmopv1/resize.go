@@ -130,3 +130,21 @@ func ResizeNeeded(
 
 	return false
 }
+
+// ResizedToClass returns true if the VM was last resized to the given class,
+// i.e. the VM's configuration was last synced to the current generation of its
+// class.
+func ResizedToClass(
+	vm vmopv1.VirtualMachine,
+	vmClass vmopv1.VirtualMachineClass) bool {
+
+	lra, exists := getLastResizeAnnotation(vm)
+	if !exists {
+		return false
+	}
+
+	return vm.Spec.ClassName == vmClass.Name &&
+		lra.Name == vmClass.Name &&
+		lra.UID == vmClass.UID &&
+		lra.Generation == vmClass.Generation
+}
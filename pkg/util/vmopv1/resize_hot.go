@@ -0,0 +1,108 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package vmopv1
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+
+	vimtypes "github.com/vmware/govmomi/vim25/types"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/ptr"
+)
+
+// HotResizeConfigSpec removes the changes from the resize ConfigSpec that
+// cannot be applied while the VM is powered on, and returns them as pending
+// changes. The number of CPUs and the memory may only be increased while the
+// VM is powered on, and only if hot-add is enabled for them. The CPU and
+// memory allocations may always be changed. Any other change, including
+// enabling or disabling hot-add itself, requires the VM to be powered off.
+func HotResizeConfigSpec(
+	vm vmopv1.VirtualMachine,
+	ci vimtypes.VirtualMachineConfigInfo,
+	cs *vimtypes.VirtualMachineConfigSpec) []vmopv1.VirtualMachineResizePendingChange {
+
+	overwriteHotAdd(vm, ci, cs)
+
+	var pending []vmopv1.VirtualMachineResizePendingChange
+
+	if cs.NumCPUs != 0 {
+		if reason := hotAddPendingReason(
+			int64(ci.Hardware.NumCPU),
+			int64(cs.NumCPUs),
+			ci.CpuHotAddEnabled); reason != "" {
+
+			pending = append(pending, vmopv1.VirtualMachineResizePendingChange{
+				Field:   "numCPUs",
+				Current: strconv.FormatInt(int64(ci.Hardware.NumCPU), 10),
+				Desired: strconv.FormatInt(int64(cs.NumCPUs), 10),
+				Reason:  reason,
+			})
+			cs.NumCPUs = 0
+		}
+	}
+
+	if cs.MemoryMB != 0 {
+		if reason := hotAddPendingReason(
+			int64(ci.Hardware.MemoryMB),
+			cs.MemoryMB,
+			ci.MemoryHotAddEnabled); reason != "" {
+
+			pending = append(pending, vmopv1.VirtualMachineResizePendingChange{
+				Field:   "memoryMB",
+				Current: strconv.FormatInt(int64(ci.Hardware.MemoryMB), 10),
+				Desired: strconv.FormatInt(cs.MemoryMB, 10),
+				Reason:  reason,
+			})
+			cs.MemoryMB = 0
+		}
+	}
+
+	hotCS := vimtypes.VirtualMachineConfigSpec{
+		NumCPUs:          cs.NumCPUs,
+		MemoryMB:         cs.MemoryMB,
+		CpuAllocation:    cs.CpuAllocation,
+		MemoryAllocation: cs.MemoryAllocation,
+	}
+
+	remainingCS := *cs
+	remainingCS.NumCPUs = 0
+	remainingCS.MemoryMB = 0
+	remainingCS.CpuAllocation = nil
+	remainingCS.MemoryAllocation = nil
+
+	v := reflect.ValueOf(remainingCS)
+	for i := 0; i < v.NumField(); i++ {
+		if v.Field(i).IsZero() {
+			continue
+		}
+		field := v.Type().Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("xml"), ",")
+		if name == "" {
+			name = field.Name
+		}
+		pending = append(pending, vmopv1.VirtualMachineResizePendingChange{
+			Field:  name,
+			Reason: vmopv1.VirtualMachineResizePendingReasonPowerOffRequired,
+		})
+	}
+
+	*cs = hotCS
+
+	return pending
+}
+
+func hotAddPendingReason(current, desired int64, hotAddEnabled *bool) string {
+	switch {
+	case desired < current:
+		return vmopv1.VirtualMachineResizePendingReasonDecrease
+	case !ptr.Deref(hotAddEnabled):
+		return vmopv1.VirtualMachineResizePendingReasonHotAddDisabled
+	default:
+		return ""
+	}
+}
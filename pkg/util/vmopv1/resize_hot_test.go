@@ -0,0 +1,141 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package vmopv1_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	vimtypes "github.com/vmware/govmomi/vim25/types"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/ptr"
+	vmopv1util "github.com/vmware-tanzu/vm-operator/pkg/util/vmopv1"
+)
+
+var _ = Describe("HotResizeConfigSpec", func() {
+
+	type ConfigSpec = vimtypes.VirtualMachineConfigSpec
+	type ConfigInfo = vimtypes.VirtualMachineConfigInfo
+	type PendingChange = vmopv1.VirtualMachineResizePendingChange
+
+	configInfo := func(numCPUs, memoryMB int32, cpuHotAdd, memoryHotAdd bool) ConfigInfo {
+		return ConfigInfo{
+			Hardware: vimtypes.VirtualHardware{
+				NumCPU:   numCPUs,
+				MemoryMB: memoryMB,
+			},
+			CpuHotAddEnabled:    ptr.To(cpuHotAdd),
+			MemoryHotAddEnabled: ptr.To(memoryHotAdd),
+		}
+	}
+
+	DescribeTable("Hot resize",
+		func(vm vmopv1.VirtualMachine,
+			ci ConfigInfo,
+			cs, expectedCS ConfigSpec,
+			expectedPending []PendingChange) {
+
+			pending := vmopv1util.HotResizeConfigSpec(vm, ci, &cs)
+			Expect(cs).To(Equal(expectedCS))
+			Expect(pending).To(Equal(expectedPending))
+		},
+
+		Entry("Empty ConfigSpec",
+			vmopv1.VirtualMachine{},
+			configInfo(2, 1024, true, true),
+			ConfigSpec{},
+			ConfigSpec{},
+			nil),
+		Entry("Increases with hot-add enabled",
+			vmopv1.VirtualMachine{},
+			configInfo(2, 1024, true, true),
+			ConfigSpec{NumCPUs: 4, MemoryMB: 2048},
+			ConfigSpec{NumCPUs: 4, MemoryMB: 2048},
+			nil),
+		Entry("Increases with hot-add disabled",
+			vmopv1.VirtualMachine{},
+			configInfo(2, 1024, false, false),
+			ConfigSpec{NumCPUs: 4, MemoryMB: 2048},
+			ConfigSpec{},
+			[]PendingChange{
+				{
+					Field:   "numCPUs",
+					Current: "2",
+					Desired: "4",
+					Reason:  vmopv1.VirtualMachineResizePendingReasonHotAddDisabled,
+				},
+				{
+					Field:   "memoryMB",
+					Current: "1024",
+					Desired: "2048",
+					Reason:  vmopv1.VirtualMachineResizePendingReasonHotAddDisabled,
+				},
+			}),
+		Entry("Decreases with hot-add enabled",
+			vmopv1.VirtualMachine{},
+			configInfo(2, 1024, true, true),
+			ConfigSpec{NumCPUs: 1, MemoryMB: 4096},
+			ConfigSpec{MemoryMB: 4096},
+			[]PendingChange{
+				{
+					Field:   "numCPUs",
+					Current: "2",
+					Desired: "1",
+					Reason:  vmopv1.VirtualMachineResizePendingReasonDecrease,
+				},
+			}),
+		Entry("Allocations are always applied",
+			vmopv1.VirtualMachine{},
+			configInfo(2, 1024, false, false),
+			ConfigSpec{
+				CpuAllocation:    &vimtypes.ResourceAllocationInfo{Reservation: ptr.To[int64](100)},
+				MemoryAllocation: &vimtypes.ResourceAllocationInfo{Reservation: ptr.To[int64](512)},
+			},
+			ConfigSpec{
+				CpuAllocation:    &vimtypes.ResourceAllocationInfo{Reservation: ptr.To[int64](100)},
+				MemoryAllocation: &vimtypes.ResourceAllocationInfo{Reservation: ptr.To[int64](512)},
+			},
+			nil),
+		Entry("Other changes require power off",
+			vmopv1.VirtualMachine{},
+			configInfo(2, 1024, true, true),
+			ConfigSpec{NumCPUs: 4, NumCoresPerSocket: 2, NestedHVEnabled: ptr.To(true)},
+			ConfigSpec{NumCPUs: 4},
+			[]PendingChange{
+				{
+					Field:  "numCoresPerSocket",
+					Reason: vmopv1.VirtualMachineResizePendingReasonPowerOffRequired,
+				},
+				{
+					Field:  "nestedHVEnabled",
+					Reason: vmopv1.VirtualMachineResizePendingReasonPowerOffRequired,
+				},
+			}),
+		Entry("Hot-add set in VM Spec requires power off",
+			vmopv1.VirtualMachine{
+				Spec: vmopv1.VirtualMachineSpec{
+					Advanced: &vmopv1.VirtualMachineAdvancedSpec{
+						CPUHotAddEnabled: ptr.To(true),
+					},
+				},
+			},
+			configInfo(2, 1024, false, false),
+			ConfigSpec{NumCPUs: 4},
+			ConfigSpec{},
+			[]PendingChange{
+				{
+					Field:   "numCPUs",
+					Current: "2",
+					Desired: "4",
+					Reason:  vmopv1.VirtualMachineResizePendingReasonHotAddDisabled,
+				},
+				{
+					Field:  "cpuHotAddEnabled",
+					Reason: vmopv1.VirtualMachineResizePendingReasonPowerOffRequired,
+				},
+			}),
+	)
+})
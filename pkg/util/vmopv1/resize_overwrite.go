@@ -32,6 +32,8 @@ func OverwriteResizeConfigSpec(
 		ptr.OverwriteWithUser(&cs.ChangeTrackingEnabled, adv.ChangeBlockTracking, ci.ChangeTrackingEnabled)
 	}
 
+	overwriteHotAdd(vm, ci, cs)
+
 	overwriteGuestID(vm, ci, cs)
 	overwriteExtraConfig(vm, ci, cs)
 
@@ -53,6 +55,17 @@ func OverwriteAlwaysResizeConfigSpec(
 	return nil
 }
 
+func overwriteHotAdd(
+	vm vmopv1.VirtualMachine,
+	ci vimtypes.VirtualMachineConfigInfo,
+	cs *vimtypes.VirtualMachineConfigSpec) {
+
+	if adv := vm.Spec.Advanced; adv != nil {
+		ptr.OverwriteWithUser(&cs.CpuHotAddEnabled, adv.CPUHotAddEnabled, ci.CpuHotAddEnabled)
+		ptr.OverwriteWithUser(&cs.MemoryHotAddEnabled, adv.MemoryHotAddEnabled, ci.MemoryHotAddEnabled)
+	}
+}
+
 func overwriteGuestID(
 	vm vmopv1.VirtualMachine,
 	ci vimtypes.VirtualMachineConfigInfo,
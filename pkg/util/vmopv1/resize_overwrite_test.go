@@ -149,6 +149,22 @@ var _ = Describe("OverwriteResizeConfigSpec", func() {
 			ConfigSpec{ChangeTrackingEnabled: falsePtr},
 			ConfigSpec{}),
 
+		Entry("Hot-add not set in VM Spec but in ConfigSpec",
+			vmAdvSpec(vmopv1.VirtualMachineAdvancedSpec{}),
+			configInfoWithManagedByAndNamespaceName(),
+			ConfigSpec{CpuHotAddEnabled: truePtr, MemoryHotAddEnabled: truePtr},
+			ConfigSpec{CpuHotAddEnabled: truePtr, MemoryHotAddEnabled: truePtr}),
+		Entry("Hot-add set in VM Spec takes precedence over ConfigSpec",
+			vmAdvSpec(vmopv1.VirtualMachineAdvancedSpec{CPUHotAddEnabled: falsePtr, MemoryHotAddEnabled: truePtr}),
+			configInfoWithManagedByAndNamespaceName(),
+			ConfigSpec{CpuHotAddEnabled: truePtr, MemoryHotAddEnabled: falsePtr},
+			ConfigSpec{CpuHotAddEnabled: falsePtr, MemoryHotAddEnabled: truePtr}),
+		Entry("Hot-add set in VM Spec with same value in ConfigInfo",
+			vmAdvSpec(vmopv1.VirtualMachineAdvancedSpec{CPUHotAddEnabled: truePtr, MemoryHotAddEnabled: truePtr}),
+			configInfoManagedBy(configInfoNamespaceName(ConfigInfo{CpuHotAddEnabled: truePtr, MemoryHotAddEnabled: truePtr})),
+			ConfigSpec{},
+			ConfigSpec{}),
+
 		Entry("Guest not set in VM Spec but in ConfigSpec is ignored",
 			vmGuestID(""),
 			configInfoWithManagedByAndNamespaceName(),
@@ -188,3 +188,46 @@ var _ = Describe("ResizeNeeded", func() {
 		})
 	})
 })
+
+var _ = Describe("ResizedToClass", func() {
+
+	var (
+		vm      vmopv1.VirtualMachine
+		vmClass vmopv1.VirtualMachineClass
+	)
+
+	BeforeEach(func() {
+		vmClass = *builder.DummyVirtualMachineClass("my-class")
+		vmClass.UID = vmClassUID
+		vmClass.Generation = 42
+
+		vm = *builder.DummyVirtualMachine()
+		vm.Spec.ClassName = vmClass.Name
+	})
+
+	It("Returns false when the resize annotation is not present", func() {
+		Expect(vmopv1util.ResizedToClass(vm, vmClass)).To(BeFalse())
+	})
+
+	It("Returns false when the resize annotation only has the class name", func() {
+		Expect(vmopv1util.SetLastResizedAnnotationClassName(&vm, vmClass.Name)).To(Succeed())
+		Expect(vmopv1util.ResizedToClass(vm, vmClass)).To(BeFalse())
+	})
+
+	It("Returns true when the VM was resized to the class", func() {
+		vmopv1util.MustSetLastResizedAnnotation(&vm, vmClass)
+		Expect(vmopv1util.ResizedToClass(vm, vmClass)).To(BeTrue())
+	})
+
+	It("Returns false when the class has changed since the VM was resized", func() {
+		vmopv1util.MustSetLastResizedAnnotation(&vm, vmClass)
+		vmClass.Generation++
+		Expect(vmopv1util.ResizedToClass(vm, vmClass)).To(BeFalse())
+	})
+
+	It("Returns false when the VM's class name has changed", func() {
+		vmopv1util.MustSetLastResizedAnnotation(&vm, vmClass)
+		vm.Spec.ClassName = "my-new-class"
+		Expect(vmopv1util.ResizedToClass(vm, vmClass)).To(BeFalse())
+	})
+})
@@ -22,6 +22,7 @@ import (
 
 	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
 	byokv1 "github.com/vmware-tanzu/vm-operator/external/byok/api/v1alpha1"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
 	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
 	"github.com/vmware-tanzu/vm-operator/pkg/constants"
 	pkgutil "github.com/vmware-tanzu/vm-operator/pkg/util"
@@ -235,6 +236,103 @@ func IsImagelessVM(vm vmopv1.VirtualMachine) bool {
 	return vm.Spec.Image == nil && vm.Spec.ImageName == ""
 }
 
+// IsISOVM returns true if any of the provided VM's CD-ROMs is backed by an
+// image, which indicates the VM is deployed from an ISO type image. CD-ROMs
+// backed by a PVC or config drive may also be attached to VMs deployed from
+// OVF type images.
+func IsISOVM(vm vmopv1.VirtualMachine) bool {
+	for i := range vm.Spec.Cdrom {
+		if vm.Spec.Cdrom[i].Image != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// IsVirtualMachineReady returns true if the provided VM is ready. A VM with a
+// readiness probe is ready when its Ready condition is true. Otherwise a VM is
+// ready once it has been created and is in its desired power state.
+func IsVirtualMachineReady(vm vmopv1.VirtualMachine) bool {
+	if !vm.DeletionTimestamp.IsZero() {
+		return false
+	}
+	if vm.Spec.ReadinessProbe != nil {
+		return conditions.IsTrue(&vm, vmopv1.ReadyConditionType)
+	}
+	return conditions.IsTrue(&vm, vmopv1.VirtualMachineConditionCreated) &&
+		vm.Status.PowerState == vm.Spec.PowerState
+}
+
+// IsReadinessProbePeriodic returns true if the provided VM's readiness probe
+// must be run periodically by the prober rather than evaluated only when the
+// VM's guest signals change. TCP and HTTP GET probes require connecting to the
+// VM, and probes with thresholds or an initial delay require tracking the
+// results of consecutive probes over time.
+func IsReadinessProbePeriodic(vm vmopv1.VirtualMachine) bool {
+	p := vm.Spec.ReadinessProbe
+	if p == nil {
+		return false
+	}
+	return p.TCPSocket != nil || p.HTTPGet != nil ||
+		p.InitialDelaySeconds > 0 || p.SuccessThreshold > 1 || p.FailureThreshold > 1
+}
+
+// LinkedCloneSourceIndexField is the name of the field index used to look up
+// the linked clones of a VM by the name of their source VM.
+const LinkedCloneSourceIndexField = "spec.linkedCloneSource"
+
+// LinkedCloneSourceIndexFunc indexes a VM by the name of its source VM when the
+// VM is a linked clone.
+func LinkedCloneSourceIndexFunc(rawObj client.Object) []string {
+	vm, ok := rawObj.(*vmopv1.VirtualMachine)
+	if !ok || vm.Spec.Image == nil || vm.Spec.Clone == nil {
+		return nil
+	}
+	if vm.Spec.Image.Kind != "VirtualMachine" ||
+		vm.Spec.Clone.Mode != vmopv1.VirtualMachineCloneModeLinked {
+
+		return nil
+	}
+	return []string{vm.Spec.Image.Name}
+}
+
+// GetLinkedClones returns the names of the VMs in the given namespace that are
+// linked clones of the VM with the given name. The disks of a linked clone are
+// backed by the source VM's current snapshot, so the source VM and its current
+// snapshot may not be removed while the linked clone exists.
+//
+// The client must have the LinkedCloneSourceIndexField index.
+func GetLinkedClones(
+	ctx context.Context,
+	k8sClient client.Client,
+	namespace, name string) ([]string, error) {
+
+	var list vmopv1.VirtualMachineList
+	if err := k8sClient.List(
+		ctx,
+		&list,
+		client.InNamespace(namespace),
+		client.MatchingFields{LinkedCloneSourceIndexField: name}); err != nil {
+
+		return nil, err
+	}
+
+	var names []string
+	for i := range list.Items {
+		if vm := &list.Items[i]; vm.Name != name {
+			names = append(names, vm.Name)
+		}
+	}
+
+	return names, nil
+}
+
+// CloneVolumeClaimName returns the name of the PVC created for the volume with
+// the given name when a VM is cloned along with its volumes.
+func CloneVolumeClaimName(vmName, volumeName string) string {
+	return vmName + "-" + volumeName
+}
+
 // ImageRefsEqual returns true if the two image refs match.
 func ImageRefsEqual(ref1, ref2 *vmopv1.VirtualMachineImageRef) bool {
 	if ref1 == nil && ref2 == nil {
@@ -524,6 +524,159 @@ var _ = DescribeTable("IsImageLessVM",
 	),
 )
 
+var _ = DescribeTable("IsReadinessProbePeriodic",
+	func(
+		probe *vmopv1.VirtualMachineReadinessProbeSpec,
+		expected bool,
+	) {
+		vm := vmopv1.VirtualMachine{
+			Spec: vmopv1.VirtualMachineSpec{
+				ReadinessProbe: probe,
+			},
+		}
+		Ω(vmopv1util.IsReadinessProbePeriodic(vm)).Should(Equal(expected))
+	},
+	Entry("no probe", nil, false),
+	Entry("tcp probe",
+		&vmopv1.VirtualMachineReadinessProbeSpec{
+			TCPSocket: &vmopv1.TCPSocketAction{},
+		},
+		true,
+	),
+	Entry("http get probe",
+		&vmopv1.VirtualMachineReadinessProbeSpec{
+			HTTPGet: &vmopv1.HTTPGetAction{},
+		},
+		true,
+	),
+	Entry("guest heartbeat probe",
+		&vmopv1.VirtualMachineReadinessProbeSpec{
+			GuestHeartbeat: &vmopv1.GuestHeartbeatAction{},
+		},
+		false,
+	),
+	Entry("guest heartbeat probe with default thresholds",
+		&vmopv1.VirtualMachineReadinessProbeSpec{
+			GuestHeartbeat:   &vmopv1.GuestHeartbeatAction{},
+			SuccessThreshold: 1,
+			FailureThreshold: 1,
+		},
+		false,
+	),
+	Entry("guest heartbeat probe with success threshold",
+		&vmopv1.VirtualMachineReadinessProbeSpec{
+			GuestHeartbeat:   &vmopv1.GuestHeartbeatAction{},
+			SuccessThreshold: 2,
+		},
+		true,
+	),
+	Entry("guest info probe with failure threshold",
+		&vmopv1.VirtualMachineReadinessProbeSpec{
+			GuestInfo:        []vmopv1.GuestInfoAction{{Key: "ready"}},
+			FailureThreshold: 3,
+		},
+		true,
+	),
+	Entry("guest info probe with initial delay",
+		&vmopv1.VirtualMachineReadinessProbeSpec{
+			GuestInfo:           []vmopv1.GuestInfoAction{{Key: "ready"}},
+			InitialDelaySeconds: 30,
+		},
+		true,
+	),
+)
+
+var _ = DescribeTable("IsVirtualMachineReady",
+	func(
+		vm vmopv1.VirtualMachine,
+		expected bool,
+	) {
+		Ω(vmopv1util.IsVirtualMachineReady(vm)).Should(Equal(expected))
+	},
+	Entry(
+		"not created",
+		vmopv1.VirtualMachine{},
+		false,
+	),
+	Entry(
+		"created and in desired power state",
+		vmopv1.VirtualMachine{
+			Spec: vmopv1.VirtualMachineSpec{
+				PowerState: vmopv1.VirtualMachinePowerStateOn,
+			},
+			Status: vmopv1.VirtualMachineStatus{
+				PowerState: vmopv1.VirtualMachinePowerStateOn,
+				Conditions: []metav1.Condition{
+					{
+						Type:   vmopv1.VirtualMachineConditionCreated,
+						Status: metav1.ConditionTrue,
+					},
+				},
+			},
+		},
+		true,
+	),
+	Entry(
+		"created but not in desired power state",
+		vmopv1.VirtualMachine{
+			Spec: vmopv1.VirtualMachineSpec{
+				PowerState: vmopv1.VirtualMachinePowerStateOn,
+			},
+			Status: vmopv1.VirtualMachineStatus{
+				PowerState: vmopv1.VirtualMachinePowerStateOff,
+				Conditions: []metav1.Condition{
+					{
+						Type:   vmopv1.VirtualMachineConditionCreated,
+						Status: metav1.ConditionTrue,
+					},
+				},
+			},
+		},
+		false,
+	),
+	Entry(
+		"has readiness probe and is not ready",
+		vmopv1.VirtualMachine{
+			Spec: vmopv1.VirtualMachineSpec{
+				PowerState:     vmopv1.VirtualMachinePowerStateOn,
+				ReadinessProbe: &vmopv1.VirtualMachineReadinessProbeSpec{},
+			},
+			Status: vmopv1.VirtualMachineStatus{
+				PowerState: vmopv1.VirtualMachinePowerStateOn,
+				Conditions: []metav1.Condition{
+					{
+						Type:   vmopv1.VirtualMachineConditionCreated,
+						Status: metav1.ConditionTrue,
+					},
+					{
+						Type:   vmopv1.ReadyConditionType,
+						Status: metav1.ConditionFalse,
+					},
+				},
+			},
+		},
+		false,
+	),
+	Entry(
+		"has readiness probe and is ready",
+		vmopv1.VirtualMachine{
+			Spec: vmopv1.VirtualMachineSpec{
+				PowerState:     vmopv1.VirtualMachinePowerStateOn,
+				ReadinessProbe: &vmopv1.VirtualMachineReadinessProbeSpec{},
+			},
+			Status: vmopv1.VirtualMachineStatus{
+				Conditions: []metav1.Condition{
+					{
+						Type:   vmopv1.ReadyConditionType,
+						Status: metav1.ConditionTrue,
+					},
+				},
+			},
+		},
+		true,
+	),
+)
+
 var _ = DescribeTable("ImageRefsEqual",
 	func(
 		ref1 *vmopv1.VirtualMachineImageRef,
@@ -577,6 +730,92 @@ var _ = DescribeTable("ImageRefsEqual",
 	),
 )
 
+var _ = DescribeTable("IsISOVM",
+	func(cdrom []vmopv1.VirtualMachineCdromSpec, expected bool) {
+		vm := vmopv1.VirtualMachine{
+			Spec: vmopv1.VirtualMachineSpec{
+				Cdrom: cdrom,
+			},
+		}
+		Expect(vmopv1util.IsISOVM(vm)).To(Equal(expected))
+	},
+	Entry("no CD-ROMs", nil, false),
+	Entry(
+		"CD-ROM backed by an image",
+		[]vmopv1.VirtualMachineCdromSpec{
+			{
+				Name:        "cdrom1",
+				ConfigDrive: &vmopv1.VirtualMachineCdromConfigDriveSource{},
+			},
+			{
+				Name:  "cdrom2",
+				Image: &vmopv1.VirtualMachineImageRef{Name: "vmi-iso"},
+			},
+		},
+		true,
+	),
+	Entry(
+		"CD-ROMs backed by a PVC and config drive",
+		[]vmopv1.VirtualMachineCdromSpec{
+			{
+				Name: "cdrom1",
+				PersistentVolumeClaim: &vmopv1.VirtualMachineCdromPersistentVolumeClaimSource{
+					ClaimName: "my-iso-pvc",
+				},
+			},
+			{
+				Name:        "cdrom2",
+				ConfigDrive: &vmopv1.VirtualMachineCdromConfigDriveSource{},
+			},
+		},
+		false,
+	),
+)
+
+var _ = Describe("GetLinkedClones", func() {
+	const (
+		namespace = "my-namespace"
+		srcVMName = "my-src-vm"
+	)
+
+	newClone := func(name, namespace, srcName string, mode vmopv1.VirtualMachineCloneMode) *vmopv1.VirtualMachine {
+		return &vmopv1.VirtualMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+			},
+			Spec: vmopv1.VirtualMachineSpec{
+				Image: &vmopv1.VirtualMachineImageRef{
+					Kind: "VirtualMachine",
+					Name: srcName,
+				},
+				Clone: &vmopv1.VirtualMachineCloneSpec{
+					Mode: mode,
+				},
+			},
+		}
+	}
+
+	It("should return only the linked clones of the VM", func() {
+		k8sClient := builder.NewFakeClient(
+			&vmopv1.VirtualMachine{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      srcVMName,
+					Namespace: namespace,
+				},
+			},
+			newClone("linked-clone", namespace, srcVMName, vmopv1.VirtualMachineCloneModeLinked),
+			newClone("full-clone", namespace, srcVMName, vmopv1.VirtualMachineCloneModeFull),
+			newClone("other-linked-clone", namespace, "my-other-vm", vmopv1.VirtualMachineCloneModeLinked),
+			newClone("other-ns-linked-clone", "my-other-namespace", srcVMName, vmopv1.VirtualMachineCloneModeLinked),
+		)
+
+		names, err := vmopv1util.GetLinkedClones(context.Background(), k8sClient, namespace, srcVMName)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(names).To(ConsistOf("linked-clone"))
+	})
+})
+
 var _ = Describe("SyncStorageUsageForNamespace", func() {
 	var (
 		ctx          context.Context
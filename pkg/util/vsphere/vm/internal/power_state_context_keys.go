@@ -10,6 +10,9 @@ const (
 	// SoftTimeoutKey is the context key for the time.Duration value that may
 	// be stored in the context. If this value is not present, then a default
 	// timeout of five minutes is used.
-	// Used for testing.
 	SoftTimeoutKey powerStateContextKey = iota
+
+	// PreStopHookKey is the context key for the PreStopHook value that may be
+	// stored in the context.
+	PreStopHookKey
 )
@@ -29,6 +29,33 @@ import (
 // operation.
 const DefaultTrySoftTimeout = 5 * time.Minute
 
+// WithSoftTimeout returns a context that causes soft power ops to wait up to
+// the provided timeout for the desired power state to be realized instead of
+// DefaultTrySoftTimeout.
+func WithSoftTimeout(ctx context.Context, timeout time.Duration) context.Context {
+	return context.WithValue(ctx, internal.SoftTimeoutKey, timeout)
+}
+
+// PreStopHook describes the signal sent to a VM's guest before the VM is
+// powered off with a soft operation.
+type PreStopHook struct {
+	// Command is the value of the guestinfo key
+	// guestinfo.vmservice.prestop.command, and is meant to be run by an agent
+	// in the guest.
+	Command string
+
+	// Timeout is how long to wait after signaling the guest before the guest
+	// is shut down. The guest is not shut down if it powers itself off in the
+	// meantime.
+	Timeout time.Duration
+}
+
+// WithPreStopHook returns a context that causes the guest to be signaled
+// with the provided hook before a VM is powered off with a soft operation.
+func WithPreStopHook(ctx context.Context, hook PreStopHook) context.Context {
+	return context.WithValue(ctx, internal.PreStopHookKey, hook)
+}
+
 // PowerOpBehavior indicates the three behaviors for powering off or suspending
 // a VM.
 type PowerOpBehavior uint8
@@ -43,8 +70,8 @@ const (
 	PowerOpBehaviorSoft
 
 	// PowerOpBehaviorTrySoft causes an attempt to use the soft behavior, and
-	// if the VM is not in the desired power state after 10 minutes, the next
-	// attempt falls back to the hard behavior.
+	// if the VM is not in the desired power state after the soft timeout, the
+	// next attempt falls back to the hard behavior.
 	PowerOpBehaviorTrySoft
 )
 
@@ -85,6 +112,19 @@ func (e ErrInvalidPowerState) Error() string {
 	return fmt.Sprintf("invalid power state: %q", e.PowerState)
 }
 
+// ErrPreStopHookInProgress is returned when a VM is not powered off because
+// the guest was signaled with the pre-stop hook, and the hook's timeout has
+// not yet elapsed.
+type ErrPreStopHookInProgress struct {
+	// Remaining is how long until the hook's timeout elapses.
+	Remaining time.Duration
+}
+
+// Error enables this type to be returned as a Golang error object.
+func (e ErrPreStopHookInProgress) Error() string {
+	return "waiting for the guest to run the pre-stop hook"
+}
+
 // ErrInvalidPowerOpBehavior is returned if a power op behavior is not one of
 // the pre-defined constants that map to PowerOpBehavior in this package.
 type ErrInvalidPowerOpBehavior struct {
@@ -225,13 +265,27 @@ func setPowerState(
 		obj               = object.NewVirtualMachine(client, mo.Self)
 	)
 
+	hook, hasHook := ctx.Value(internal.PreStopHookKey).(PreStopHook)
+	hasHook = hasHook &&
+		desiredPowerState == vimtypes.VirtualMachinePowerStatePoweredOff
+
 	// Fetch the VM's current power state if it is not already known or if
-	// explicitly requested.
+	// explicitly requested. The extra config is also required to know whether
+	// the guest was already signaled with the pre-stop hook.
+	var propsToFetch []string
 	if mo.Summary.Runtime.PowerState == "" || fetchProperties {
+		propsToFetch = append(propsToFetch, object.PropRuntimePowerState)
+	}
+	if hasHook && (mo.Config == nil || fetchProperties) {
+		// Retrieving the properties replaces the managed object, so the
+		// power state is fetched as well.
+		propsToFetch = []string{object.PropRuntimePowerState, propConfigExtraConfig}
+	}
+	if len(propsToFetch) > 0 {
 		if err := obj.Properties(
 			ctx,
 			mo.Self,
-			[]string{object.PropRuntimePowerState},
+			propsToFetch,
 			&mo); err != nil {
 			return 0, fmt.Errorf("failed to retrieve properties %w", err)
 		}
@@ -291,16 +345,31 @@ func setPowerState(
 		return 0, ErrInvalidPowerState{PowerState: desiredPowerState}
 	}
 
+	// The guest can only run the pre-stop hook if it is running.
+	if hasHook && powerOpSoftFn != nil &&
+		currentPowerState == vimtypes.VirtualMachinePowerStatePoweredOn {
+
+		log.Info("Pre-stop hook", "preStopTimeout", hook.Timeout.String())
+		if err := signalPreStopHook(ctx, obj, hook, mo); err != nil {
+			return 0, err
+		}
+	}
+
+	var (
+		result PowerOpResult
+		err    error
+	)
+
 	switch {
 	case powerOpHardFn != nil && powerOpSoftFn == nil: // hard
 		log.Info("Hard power op")
-		return doAndWaitOnHardPowerOp(ctx, desiredPowerState, powerOpHardFn)
+		result, err = doAndWaitOnHardPowerOp(ctx, desiredPowerState, powerOpHardFn)
 	case powerOpHardFn == nil && powerOpSoftFn != nil: // soft
 		log.Info("Soft power op")
-		return doAndWaitOnSoftPowerOp(ctx, desiredPowerState, powerOpSoftFn, waitForPowerStateFn)
+		result, err = doAndWaitOnSoftPowerOp(ctx, desiredPowerState, powerOpSoftFn, waitForPowerStateFn)
 	case powerOpHardFn != nil && powerOpSoftFn != nil: // trySoft + hard
 		log.Info("Try soft power op")
-		result, err := doAndWaitOnSoftPowerOp(
+		result, err = doAndWaitOnSoftPowerOp(
 			ctx,
 			desiredPowerState,
 			powerOpSoftFn,
@@ -312,10 +381,20 @@ func setPowerState(
 				desiredPowerState,
 				powerOpHardFn)
 		}
-		return result, err
 	default:
 		return 0, errors.New("missing hard and soft power op functions")
 	}
+
+	if err == nil && hasHook && mo.Config != nil {
+		if _, err := ClearPreStopHook(ctx, obj, mo.Config.ExtraConfig); err != nil {
+			// Do not let a failure to clear the signal fail the power op,
+			// the signal is cleared again the next time the VM is
+			// reconciled while powered off.
+			log.Error(err, "Failed to clear pre-stop hook")
+		}
+	}
+
+	return result, err
 }
 
 func doAndWaitOnHardPowerOp(
@@ -391,6 +470,84 @@ func doAndWaitOnSoftPowerOp(
 	return PowerOpResultChangedSoft, nil
 }
 
+// signalPreStopHook signals the guest with the pre-stop hook if it was not
+// already signaled, and returns ErrPreStopHookInProgress until the hook's
+// timeout has elapsed since the guest was signaled. The guest is signaled
+// only once, so the hook is not re-run when a soft power op is retried.
+func signalPreStopHook(
+	ctx context.Context,
+	obj *object.VirtualMachine,
+	hook PreStopHook,
+	mo mo.VirtualMachine) error {
+
+	if mo.Config != nil {
+		ec := object.OptionValueList(mo.Config.ExtraConfig)
+		if v, _ := ec.GetString(ExtraConfigKeyPreStopTime); v != "" {
+			if signaledAt, err := time.Parse(time.RFC3339, v); err == nil {
+				if remaining := hook.Timeout - time.Since(signaledAt); remaining > 0 {
+					return ErrPreStopHookInProgress{Remaining: remaining}
+				}
+				return nil
+			}
+		}
+	}
+
+	t, err := obj.Reconfigure(ctx, vimtypes.VirtualMachineConfigSpec{
+		ExtraConfig: []vimtypes.BaseOptionValue{
+			&vimtypes.OptionValue{
+				Key:   ExtraConfigKeyPreStopCommand,
+				Value: hook.Command,
+			},
+			&vimtypes.OptionValue{
+				Key:   ExtraConfigKeyPreStopTime,
+				Value: time.Now().UTC().Format(time.RFC3339),
+			},
+		}})
+	if err != nil {
+		return fmt.Errorf("failed to invoke reconfigure that signals pre-stop hook %w", err)
+	}
+	if err := t.Wait(ctx); err != nil {
+		return fmt.Errorf("failed to signal pre-stop hook %w", err)
+	}
+
+	return ErrPreStopHookInProgress{Remaining: hook.Timeout}
+}
+
+// ClearPreStopHook removes the signal of the pre-stop hook from the VM's
+// extra config, returning true if the guest had been signaled.
+func ClearPreStopHook(
+	ctx context.Context,
+	obj *object.VirtualMachine,
+	extraConfig []vimtypes.BaseOptionValue) (bool, error) {
+
+	ec := object.OptionValueList(extraConfig)
+	command, _ := ec.GetString(ExtraConfigKeyPreStopCommand)
+	signaledAt, _ := ec.GetString(ExtraConfigKeyPreStopTime)
+	if command == "" && signaledAt == "" {
+		return false, nil
+	}
+
+	t, err := obj.Reconfigure(ctx, vimtypes.VirtualMachineConfigSpec{
+		ExtraConfig: []vimtypes.BaseOptionValue{
+			&vimtypes.OptionValue{
+				Key:   ExtraConfigKeyPreStopCommand,
+				Value: "",
+			},
+			&vimtypes.OptionValue{
+				Key:   ExtraConfigKeyPreStopTime,
+				Value: "",
+			},
+		}})
+	if err != nil {
+		return false, fmt.Errorf("failed to invoke reconfigure that clears pre-stop hook %w", err)
+	}
+	if err := t.Wait(ctx); err != nil {
+		return false, fmt.Errorf("failed to clear pre-stop hook %w", err)
+	}
+
+	return true, nil
+}
+
 // Restart restarts a VM if the provided lastRestart timestamp occurs after
 // the last time the VM was restarted.
 // If fetchProperties is true, then even if already known, the required
@@ -452,6 +609,16 @@ const (
 	// ExtraConfig array that contains the epoch of the last time the VM was
 	// restarted.
 	ExtraConfigKeyLastRestartTime = "vmservice.lastRestartTime"
+
+	// ExtraConfigKeyPreStopCommand is the name of the key in a VM's
+	// ExtraConfig array that contains the command of the pre-stop hook the
+	// guest was last signaled with.
+	ExtraConfigKeyPreStopCommand = "guestinfo.vmservice.prestop.command"
+
+	// ExtraConfigKeyPreStopTime is the name of the key in a VM's ExtraConfig
+	// array that contains the RFC3339 formatted time the guest was last
+	// signaled with the pre-stop hook.
+	ExtraConfigKeyPreStopTime = "guestinfo.vmservice.prestop.time"
 )
 
 func restart(
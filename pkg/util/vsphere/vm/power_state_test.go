@@ -450,6 +450,115 @@ func powerStateTests() {
 						})
 						It("should power off the VM using a hard op", func() {})
 					})
+					Context("and there is a pre-stop hook", func() {
+						var (
+							preStopTime    time.Time
+							preStopTimeout time.Duration
+						)
+
+						setPreStopSignal := func(t time.Time) {
+							task, err := obj.Reconfigure(ctx, vimtypes.VirtualMachineConfigSpec{
+								ExtraConfig: []vimtypes.BaseOptionValue{
+									&vimtypes.OptionValue{
+										Key:   vmutil.ExtraConfigKeyPreStopCommand,
+										Value: "flush",
+									},
+									&vimtypes.OptionValue{
+										Key:   vmutil.ExtraConfigKeyPreStopTime,
+										Value: t.Format(time.RFC3339),
+									},
+								}})
+							Expect(err).ToNot(HaveOccurred())
+							Expect(task.Wait(ctx)).To(Succeed())
+						}
+
+						getPreStopSignal := func() (string, string) {
+							var moVM mo.VirtualMachine
+							Expect(obj.Properties(
+								ctx,
+								obj.Reference(),
+								[]string{"config.extraConfig"},
+								&moVM)).To(Succeed())
+							ec := object.OptionValueList(moVM.Config.ExtraConfig)
+							command, _ := ec.GetString(vmutil.ExtraConfigKeyPreStopCommand)
+							signaledAt, _ := ec.GetString(vmutil.ExtraConfigKeyPreStopTime)
+							return command, signaledAt
+						}
+
+						BeforeEach(func() {
+							preStopTime = time.Now().UTC().Truncate(time.Second)
+							preStopTimeout = time.Hour
+							expectedErr = vmutil.ErrPreStopHookInProgress{}
+							mutateContextFn = func(ctx context.Context) context.Context {
+								return vmutil.WithPreStopHook(ctx, vmutil.PreStopHook{
+									Command: "flush",
+									Timeout: preStopTimeout,
+								})
+							}
+						})
+
+						It("should signal the guest and not power off the VM", func() {
+							command, signaledAt := getPreStopSignal()
+							Expect(command).To(Equal("flush"))
+							t, err := time.Parse(time.RFC3339, signaledAt)
+							Expect(err).ToNot(HaveOccurred())
+							Expect(t).To(BeTemporally(">=", preStopTime))
+
+							powerState, err := obj.PowerState(ctx)
+							Expect(err).ToNot(HaveOccurred())
+							Expect(powerState).To(Equal(vimtypes.VirtualMachinePowerStatePoweredOn))
+						})
+
+						Context("and the guest was already signaled", func() {
+							var signaledAt time.Time
+
+							BeforeEach(func() {
+								signaledAt = preStopTime.Add(-time.Minute)
+								setPreStopSignal(signaledAt)
+							})
+
+							Context("and the timeout has not elapsed", func() {
+								It("should not signal the guest again", func() {
+									_, v := getPreStopSignal()
+									Expect(v).To(Equal(signaledAt.Format(time.RFC3339)))
+								})
+							})
+
+							Context("and the timeout has elapsed", func() {
+								BeforeEach(func() {
+									preStopTimeout = time.Second
+									expectedErr = nil
+								})
+
+								It("should power off the VM and clear the signal", func() {
+									command, v := getPreStopSignal()
+									Expect(command).To(BeEmpty())
+									Expect(v).To(BeEmpty())
+								})
+
+								Context("and waiting for the power state times out", func() {
+									BeforeEach(func() {
+										expectedResult = vmutil.PowerOpResultChangedHard
+										mutateContextFn = func(ctx context.Context) context.Context {
+											return vmutil.WithPreStopHook(
+												vmutil.WithSoftTimeout(
+													delayTask(ctx, "ShutdownGuest", 1*time.Second, 0),
+													500*time.Millisecond),
+												vmutil.PreStopHook{
+													Command: "flush",
+													Timeout: preStopTimeout,
+												})
+										}
+									})
+									It("should power off the VM using a hard op and clear the signal", func() {
+										command, v := getPreStopSignal()
+										Expect(command).To(BeEmpty())
+										Expect(v).To(BeEmpty())
+									})
+								})
+							})
+						})
+					})
 				})
 			})
 			Context("that is suspended", func() {
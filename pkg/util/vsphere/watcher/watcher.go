@@ -40,6 +40,15 @@ func DefaultWatchedPropertyPaths() []string {
 	}
 }
 
+// requiredWatchedPropertyPaths returns the property paths the watcher always
+// watches, even when other property paths are provided, since changes to a VM's
+// extraConfig must always be signaled, less the ignored extraConfig keys.
+func requiredWatchedPropertyPaths() []string {
+	return []string{
+		extraConfigPropPath,
+	}
+}
+
 const extraConfigNamespacedNameKey = "vmservice.namespacedName"
 
 // defaultIgnoredExtraConfigKeys returns the default set of extra config keys to
@@ -164,6 +173,13 @@ func newWatcher(
 
 	if watchedPropertyPaths == nil {
 		watchedPropertyPaths = DefaultWatchedPropertyPaths()
+	} else {
+		watchedPropertyPaths = slices.Clone(watchedPropertyPaths)
+		for _, p := range requiredWatchedPropertyPaths() {
+			if !slices.Contains(watchedPropertyPaths, p) {
+				watchedPropertyPaths = append(watchedPropertyPaths, p)
+			}
+		}
 	}
 	ignoredExtraConfigKeys := slices.Concat(
 		defaultIgnoredExtraConfigKeys,
@@ -229,6 +245,8 @@ func (w *Watcher) close() {
 
 // Start begins watching a vSphere server for updates to VM Service managed VMs.
 // If watchedPropertyPaths is nil, DefaultWatchedPropertyPaths will be used.
+// Otherwise the property paths the watcher requires, ex. config.extraConfig,
+// are always watched in addition to watchedPropertyPaths.
 // The containerRefsWithIDs parameter may be used to start the watcher with an
 // initial list of entities to watch.
 func Start(
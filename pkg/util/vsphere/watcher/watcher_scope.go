@@ -0,0 +1,85 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package watcher
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
+)
+
+// NamespaceSelector returns the selector for the namespaces whose VMs are
+// watched, as configured by AsyncSignalNamespaceSelector.
+func NamespaceSelector(ctx context.Context) (labels.Selector, error) {
+	s := pkgcfg.FromContext(ctx).AsyncSignalNamespaceSelector
+	if s == "" {
+		return labels.Everything(), nil
+	}
+	selector, err := labels.Parse(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid async signal namespace selector %q: %w", s, err)
+	}
+	return selector, nil
+}
+
+// IsNamespaceWatched returns true if the VMs in the given namespace are
+// watched, as configured by AsyncSignalNamespaceSelector.
+func IsNamespaceWatched(
+	ctx context.Context,
+	k8sClient ctrlclient.Reader,
+	namespace string) (bool, error) {
+
+	selector, err := NamespaceSelector(ctx)
+	if err != nil {
+		return false, err
+	}
+	if selector.Empty() {
+		return true, nil
+	}
+
+	var obj corev1.Namespace
+	if err := k8sClient.Get(ctx, ctrlclient.ObjectKey{Name: namespace}, &obj); err != nil {
+		return false, err
+	}
+
+	return selector.Matches(labels.Set(obj.Labels)), nil
+}
+
+// WatchedNamespaces returns the names of the namespaces whose VMs are watched,
+// as configured by AsyncSignalNamespaceSelector. A nil map is returned if all
+// namespaces are watched.
+func WatchedNamespaces(
+	ctx context.Context,
+	k8sClient ctrlclient.Reader) (map[string]struct{}, error) {
+
+	selector, err := NamespaceSelector(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if selector.Empty() {
+		return nil, nil
+	}
+
+	var list corev1.NamespaceList
+	if err := k8sClient.List(
+		ctx,
+		&list,
+		ctrlclient.MatchingLabelsSelector{Selector: selector}); err != nil {
+
+		return nil, err
+	}
+
+	namespaces := make(map[string]struct{}, len(list.Items))
+	for i := range list.Items {
+		namespaces[list.Items[i].Name] = struct{}{}
+	}
+
+	return namespaces, nil
+}
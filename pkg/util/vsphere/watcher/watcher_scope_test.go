@@ -0,0 +1,88 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package watcher_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/vsphere/watcher"
+)
+
+var _ = Describe("Scope", func() {
+	var (
+		ctx       context.Context
+		k8sClient ctrlclient.Client
+		selector  string
+	)
+
+	BeforeEach(func() {
+		selector = ""
+	})
+
+	JustBeforeEach(func() {
+		ctx = pkgcfg.UpdateContext(
+			pkgcfg.NewContext(),
+			func(config *pkgcfg.Config) {
+				config.AsyncSignalNamespaceSelector = selector
+			})
+		k8sClient = fake.NewClientBuilder().WithObjects(
+			&corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   "ns-a",
+					Labels: map[string]string{"shard": "a"},
+				},
+			},
+			&corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   "ns-b",
+					Labels: map[string]string{"shard": "b"},
+				},
+			},
+		).Build()
+	})
+
+	When("the namespace selector is empty", func() {
+		It("should watch all namespaces", func() {
+			Expect(watcher.IsNamespaceWatched(ctx, k8sClient, "ns-a")).To(BeTrue())
+			Expect(watcher.IsNamespaceWatched(ctx, k8sClient, "ns-b")).To(BeTrue())
+			Expect(watcher.WatchedNamespaces(ctx, k8sClient)).To(BeNil())
+		})
+	})
+
+	When("the namespace selector is valid", func() {
+		BeforeEach(func() {
+			selector = "shard=a"
+		})
+		It("should watch the selected namespaces", func() {
+			Expect(watcher.IsNamespaceWatched(ctx, k8sClient, "ns-a")).To(BeTrue())
+			Expect(watcher.IsNamespaceWatched(ctx, k8sClient, "ns-b")).To(BeFalse())
+			Expect(watcher.WatchedNamespaces(ctx, k8sClient)).To(Equal(map[string]struct{}{"ns-a": {}}))
+		})
+		It("should return an error if the namespace does not exist", func() {
+			_, err := watcher.IsNamespaceWatched(ctx, k8sClient, "ns-c")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	When("the namespace selector is invalid", func() {
+		BeforeEach(func() {
+			selector = "shard in (a"
+		})
+		It("should return an error", func() {
+			_, err := watcher.IsNamespaceWatched(ctx, k8sClient, "ns-a")
+			Expect(err).To(MatchError(ContainSubstring("invalid async signal namespace selector")))
+			_, err = watcher.WatchedNamespaces(ctx, k8sClient)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -57,6 +57,8 @@ var _ = Describe("Start", func() {
 
 		lookupFnVerified bool
 		lookupFnDeleted  bool
+
+		watchedPropertyPaths []string
 	)
 
 	addNamespaceName := func(
@@ -87,6 +89,7 @@ var _ = Describe("Start", func() {
 		closeServerOnce = sync.Once{}
 
 		lookupFnVerified = false
+		watchedPropertyPaths = nil
 
 		model = simulator.VPX()
 		model.Datacenter = 1
@@ -157,7 +160,7 @@ var _ = Describe("Start", func() {
 		w, err = watcher.Start(
 			ctx,
 			client.Client,
-			nil,
+			watchedPropertyPaths,
 			[]string{
 				"ignoredKey1",
 				"ignoredKey1",
@@ -418,6 +421,47 @@ var _ = Describe("Start", func() {
 			})
 		})
 
+		When("the watched property paths do not include config.extraConfig", func() {
+			BeforeEach(func() {
+				watchedPropertyPaths = []string{
+					"summary.runtime.powerState",
+				}
+			})
+			Specify("a change to a non-ignored extraConfig key should be received", func() {
+				// Assert that a result is signaled due to the VM entering the
+				// scope of the watcher.
+				assertResult(cluster1vm1, "my-namespace-1", "my-name-1")
+
+				// Assert no more results are signaled.
+				assertNoResult()
+
+				// Add a non-ignored key.
+				t, err := cluster1vm1.Reconfigure(
+					ctx,
+					vimtypes.VirtualMachineConfigSpec{
+						ExtraConfig: []vimtypes.BaseOptionValue{
+							&vimtypes.OptionValue{
+								Key:   "guestinfo.fromTheGuest",
+								Value: "1.2.3.4",
+							},
+						},
+					},
+				)
+				ExpectWithOffset(1, err).ShouldNot(HaveOccurred())
+				ExpectWithOffset(1, t.WaitEx(ctx)).To(Succeed())
+
+				// Assert that a result is signaled due to the extraConfig
+				// change, even though config.extraConfig was not configured.
+				assertResult(cluster1vm1, "my-namespace-1", "my-name-1")
+
+				// Assert no more results are signaled.
+				assertNoResult()
+
+				// Assert no error either.
+				assertNoError()
+			})
+		})
+
 		When("the container is removed from the watcher", func() {
 			Specify("no result should be received", func() {
 				// Assert that a result is signaled due to the VM entering the
@@ -6,6 +6,8 @@ package webconsolevalidation
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"net/http"
 	"time"
@@ -17,7 +19,21 @@ import (
 	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
 )
 
-const UUIDLabelKey = "vmoperator.vmware.com/webconsolerequest-uuid"
+const (
+	UUIDLabelKey = "vmoperator.vmware.com/webconsolerequest-uuid"
+
+	// SerialTokenHashAnnotationKey is the annotation on a serial console
+	// request that contains the hash of the token used to connect to the VM's
+	// serial console.
+	SerialTokenHashAnnotationKey = "vmoperator.vmware.com/webconsolerequest-serial-token-hash"
+)
+
+// HashSerialToken returns the hash of a serial console token, as stored in
+// the SerialTokenHashAnnotationKey annotation.
+func HashSerialToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
 
 // Server represents a web console validation server.
 type Server struct {
@@ -54,6 +70,8 @@ func (s *Server) Run() error {
 
 // HandleWebConsoleValidation verifies a web console validation request by
 // checking if a WebConsoleRequest resource exists with the given UUID in query.
+// A serial console request is only valid if the query also contains the
+// request's token.
 func (s *Server) HandleWebConsoleValidation(w http.ResponseWriter, r *http.Request) {
 	uuid := r.URL.Query().Get("uuid")
 	if uuid == "" {
@@ -69,7 +87,9 @@ func (s *Server) HandleWebConsoleValidation(w http.ResponseWriter, r *http.Reque
 
 	logger := ctrllog.Log.WithName(r.URL.Path).WithValues("uuid", uuid).WithValues("namespace", namespace)
 
-	found, err := isResourceFound(r.Context(), uuid, namespace, s.KubeClient)
+	token := r.URL.Query().Get("token")
+
+	found, err := isResourceFound(r.Context(), uuid, namespace, token, s.KubeClient)
 	if err != nil {
 		logger.Error(err, "Error occurred in finding a webconsolerequest resource with the given params.")
 		http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -87,7 +107,7 @@ func (s *Server) HandleWebConsoleValidation(w http.ResponseWriter, r *http.Reque
 
 func isResourceFound(
 	ctx context.Context,
-	uuid, namespace string,
+	uuid, namespace, token string,
 	kubeClient ctrlclient.Client) (bool, error) {
 	labelSelector := ctrlclient.MatchingLabels{
 		UUIDLabelKey: uuid,
@@ -104,8 +124,13 @@ func isResourceFound(
 		return false, err
 	}
 
-	if len(vmwcrObjectList.Items) > 0 {
-		return true, nil
+	for _, wcr := range vmwcrObjectList.Items {
+		if wcr.Spec.ConsoleType != vmopv1.VirtualMachineWebConsoleTypeSerial {
+			return true, nil
+		}
+		if token != "" && wcr.Annotations[SerialTokenHashAnnotationKey] == HashSerialToken(token) {
+			return true, nil
+		}
 	}
 
 	// NOTE: In v1a1 this CRD has a different name - WebConsoleRequest - so this
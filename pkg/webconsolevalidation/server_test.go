@@ -215,6 +215,47 @@ func serverUnitTests() {
 
 			})
 
+			When("UUID matches an existing serial console VirtualMachineWebConsoleRequest resource", func() {
+
+				const (
+					serialUUID = "test-uuid-serial"
+					token      = "test-token"
+				)
+
+				BeforeEach(func() {
+					vmwcr := &vmopv1.VirtualMachineWebConsoleRequest{}
+					vmwcr.Name = "serial"
+					vmwcr.Namespace = namespace
+					vmwcr.Labels = map[string]string{
+						webconsolevalidation.UUIDLabelKey: serialUUID,
+					}
+					vmwcr.Annotations = map[string]string{
+						webconsolevalidation.SerialTokenHashAnnotationKey: webconsolevalidation.HashSerialToken(token),
+					}
+					vmwcr.Spec.ConsoleType = vmopv1.VirtualMachineWebConsoleTypeSerial
+					initObjects = append(initObjects, vmwcr)
+				})
+
+				It("should return http.StatusOK (200) when the token matches", func() {
+					url := fmt.Sprintf("/?uuid=%s&namespace=%s&token=%s", serialUUID, namespace, token)
+					responseCode := fakeValidationRequest(url, server)
+					Expect(responseCode).To(Equal(http.StatusOK))
+				})
+
+				It("should return http.StatusForbidden (403) when the token does not match", func() {
+					url := fmt.Sprintf("/?uuid=%s&namespace=%s&token=%s", serialUUID, namespace, "wrong-token")
+					responseCode := fakeValidationRequest(url, server)
+					Expect(responseCode).To(Equal(http.StatusForbidden))
+				})
+
+				It("should return http.StatusForbidden (403) when the token is missing", func() {
+					url := fmt.Sprintf("/?uuid=%s&namespace=%s", serialUUID, namespace)
+					responseCode := fakeValidationRequest(url, server)
+					Expect(responseCode).To(Equal(http.StatusForbidden))
+				})
+
+			})
+
 			When("UUID doesn't match any WebConsoleRequest or VirtualMachineWebConsoleRequest resource", func() {
 
 				It("should return http.StatusForbidden (403)", func() {
@@ -8,12 +8,15 @@ import (
 	"context"
 	"fmt"
 	"maps"
+	"math"
 	"slices"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/vmware/govmomi/property"
 	vimtypes "github.com/vmware/govmomi/vim25/types"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
 	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/event"
@@ -71,6 +74,11 @@ func New(
 
 var _ manager.LeaderElectionRunnable = Service{}
 
+// NeedLeaderElection returns true since the watcher enqueues its changes to
+// the VirtualMachine controller, which only runs on the leader. When the
+// namespaces are sharded with AsyncSignalNamespaceSelector, each shard is a
+// separate deployment with its own LeaderElectionID, so each shard's leader
+// runs a watcher for the shard's namespaces.
 func (s Service) NeedLeaderElection() bool {
 	return true
 }
@@ -85,8 +93,11 @@ func (s Service) Start(ctx context.Context) error {
 
 	logger.Info("Starting VM watcher service")
 
+	backoff := newRetryBackoff()
+
 	for ctx.Err() == nil {
-		if err := s.waitForChanges(ctx); err != nil {
+		started, err := s.waitForChanges(ctx)
+		if err != nil {
 			// If waitForChanges failed because of an invalid login or auth
 			// error, then do not treat the error as fatal. This allows the
 			// loop to run again, kicking off another watcher with what should
@@ -104,14 +115,41 @@ func (s Service) Start(ctx context.Context) error {
 					err,
 					"Unexpected error trying to start vm watcher service")
 			}
+		}
+
+		if started {
+			// The watcher was started, so the next attempt to start it is not
+			// delayed, ex. when the watcher stopped because of an expired
+			// session.
+			backoff = newRetryBackoff()
+			continue
+		}
+
+		// Wait before trying to start the watcher again so the service does
+		// not spin when, for example, it cannot get a vSphere client.
+		delay := backoff.Step()
+		logger.V(4).Info("Retrying vm watcher service", "delay", delay)
 
-			// TODO: We might want to sleep a bit here so we don't spin if like we
-			// cannot get a VC client.
+		select {
+		case <-ctx.Done():
+		case <-time.After(delay):
 		}
 	}
 	return ctx.Err()
 }
 
+// newRetryBackoff returns the backoff used to delay the attempts to start the
+// watcher after it failed to start.
+func newRetryBackoff() wait.Backoff {
+	return wait.Backoff{
+		Duration: 1 * time.Second,
+		Factor:   2,
+		Jitter:   0.5,
+		Steps:    math.MaxInt32,
+		Cap:      1 * time.Minute,
+	}
+}
+
 func (s Service) vmFolderMoRefWithIDs(
 	ctx context.Context,
 	vcClient *vsphereclient.Client) (map[vimtypes.ManagedObjectReference][]string, error) {
@@ -128,9 +166,22 @@ func (s Service) vmFolderMoRefWithIDs(
 		return nil, err
 	}
 
+	// Get the namespaces that are in the scope of this watcher. A nil map
+	// indicates all namespaces are in scope.
+	watchedNamespaces, err := watcher.WatchedNamespaces(ctx, s.Client)
+	if err != nil {
+		return nil, err
+	}
+
 	for i := range zones.Items {
 		z := zones.Items[i]
 
+		if watchedNamespaces != nil {
+			if _, ok := watchedNamespaces[z.Namespace]; !ok {
+				continue
+			}
+		}
+
 		if v := z.Spec.ManagedVMs.FolderMoID; v != "" {
 			if _, ok := moids[v]; !ok {
 				moids[v] = make([]string, 0, 1)
@@ -202,34 +253,39 @@ func (s Service) vmFolderMoRefWithIDs(
 
 var emptyResult watcher.Result
 
-func (s Service) waitForChanges(ctx context.Context) error {
+// waitForChanges starts the watcher and waits for changes until the watcher
+// is closed. The returned boolean indicates whether the watcher was started.
+func (s Service) waitForChanges(ctx context.Context) (bool, error) {
 	var (
 		logger     = logr.FromContextOrDiscard(ctx)
 		chanSource = cource.FromContextWithBuffer(ctx, "VirtualMachine", 100)
+		config     = pkgcfg.FromContext(ctx)
 	)
 
 	vcClient, err := s.provider.VSphereClient(ctx)
 	if err != nil {
-		return err
+		return false, err
 	}
 	logger.Info("Got vsphere client")
 
 	moRefWithIDs, err := s.vmFolderMoRefWithIDs(ctx, vcClient)
 	if err != nil {
-		return err
+		return false, err
 	}
 	logger.Info("Got vm service folders", "refs", slices.Collect(maps.Keys(moRefWithIDs)))
 
-	// Start the watcher.
+	// Start the watcher. If no property paths are configured, the watcher's
+	// default property paths are used. Otherwise the watcher merges in the
+	// property paths it requires.
 	w, err := watcher.Start(
 		ctx,
 		vcClient.VimClient(),
-		nil,
-		nil,
+		pkgcfg.StringToSlice(config.AsyncSignalWatchedPropertyPaths),
+		pkgcfg.StringToSlice(config.AsyncSignalIgnoredExtraConfigKeys),
 		s.lookupNamespacedName,
 		moRefWithIDs)
 	if err != nil {
-		return err
+		return false, err
 	}
 
 	for {
@@ -237,7 +293,7 @@ func (s Service) waitForChanges(ctx context.Context) error {
 		case result := <-w.Result():
 			if result == emptyResult {
 				logger.Info("Received empty result, watcher is closed")
-				return w.Err()
+				return true, w.Err()
 			}
 
 			if !result.Verified {
@@ -270,7 +326,7 @@ func (s Service) waitForChanges(ctx context.Context) error {
 			}
 
 		case <-w.Done():
-			return w.Err()
+			return true, w.Err()
 		}
 	}
 }
@@ -53,10 +53,12 @@ var _ = Describe(
 			vsClientMu        sync.RWMutex
 			vsClient          *vsclient.Client
 			numNewClientCalls int32
+			namespaceSelector string
 		)
 
 		BeforeEach(func() {
 			numNewClientCalls = 0
+			namespaceSelector = ""
 			vsClient = nil
 			vsClientMu = sync.RWMutex{}
 			ctx = logr.NewContext(
@@ -69,6 +71,12 @@ var _ = Describe(
 
 		JustBeforeEach(func() {
 			ctx = pkgcfg.WithContext(ctx, pkgcfg.Default())
+			ctx = pkgcfg.UpdateContext(
+				ctx,
+				func(config *pkgcfg.Config) {
+					config.AsyncSignalNamespaceSelector = namespaceSelector
+				},
+			)
 			ctx = cource.WithContext(ctx)
 			ctx = watcher.WithContext(ctx)
 
@@ -356,6 +364,17 @@ var _ = Describe(
 					})))
 				})
 
+				When("the vm's namespace is not in the scope of the watcher", func() {
+					BeforeEach(func() {
+						namespaceSelector = "vmoperator.vmware.com/watcher-shard=does-not-exist"
+					})
+
+					Specify("a reconcile request should not be received", func() {
+						chanSource := cource.FromContext(ctx, "VirtualMachine")
+						Consistently(chanSource, time.Second*5).ShouldNot(Receive())
+					})
+				})
+
 				When("a bogus Zone Folder MoID", func() {
 					BeforeEach(func() {
 						outerInitEnvFn := initEnvFn
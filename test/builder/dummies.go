@@ -359,7 +359,7 @@ func DummyVirtualMachine() *vmopv1.VirtualMachine {
 			Cdrom: []vmopv1.VirtualMachineCdromSpec{
 				{
 					Name: "cdrom1",
-					Image: vmopv1.VirtualMachineImageRef{
+					Image: &vmopv1.VirtualMachineImageRef{
 						Kind: vmiKind,
 						Name: DummyVMIName,
 					},
@@ -368,7 +368,7 @@ func DummyVirtualMachine() *vmopv1.VirtualMachine {
 				},
 				{
 					Name: "cdrom2",
-					Image: vmopv1.VirtualMachineImageRef{
+					Image: &vmopv1.VirtualMachineImageRef{
 						Kind: cvmiKind,
 						Name: DummyCVMIName,
 					},
@@ -422,6 +422,28 @@ func DummyVirtualMachineReplicaSet() *vmopv1.VirtualMachineReplicaSet {
 	}
 }
 
+func DummyVirtualMachineDeployment() *vmopv1.VirtualMachineDeployment {
+	rs := DummyVirtualMachineReplicaSet()
+	rs.Spec.Template.Labels["app"] = "dummy"
+	return &vmopv1.VirtualMachineDeployment{
+		TypeMeta: metav1.TypeMeta{
+			Kind: "VirtualMachineDeployment",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: "test-",
+			Labels:       map[string]string{},
+			Annotations:  map[string]string{},
+		},
+		Spec: vmopv1.VirtualMachineDeploymentSpec{
+			Replicas: ptr.To(int32(1)),
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"app": "dummy"},
+			},
+			Template: rs.Spec.Template,
+		},
+	}
+}
+
 func AddDummyInstanceStorageVolume(vm *vmopv1.VirtualMachine) {
 	vm.Spec.Volumes = append(vm.Spec.Volumes, DummyInstanceStorageVirtualMachineVolumes()...)
 }
@@ -561,6 +583,55 @@ func DummyVirtualMachineWebConsoleRequest(namespace, wcrName, vmName, pubKey str
 	}
 }
 
+func DummyVirtualMachineSnapshot(namespace, name, vmName string) *vmopv1.VirtualMachineSnapshot {
+	return &vmopv1.VirtualMachineSnapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: vmopv1.VirtualMachineSnapshotSpec{
+			VMRef: &vmopv1common.LocalObjectRef{
+				APIVersion: vmopv1.GroupVersion.String(),
+				Kind:       "VirtualMachine",
+				Name:       vmName,
+			},
+		},
+	}
+}
+
+func DummyVirtualMachineRestoreRequest(namespace, name, moID string) *vmopv1.VirtualMachineRestoreRequest {
+	return &vmopv1.VirtualMachineRestoreRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: vmopv1.VirtualMachineRestoreRequestSpec{
+			Source: vmopv1.VirtualMachineRestoreRequestSource{
+				ManagedObjectID: moID,
+			},
+		},
+	}
+}
+
+func DummyVirtualMachineBackupPolicy(namespace, name string) *vmopv1.VirtualMachineBackupPolicy {
+	return &vmopv1.VirtualMachineBackupPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: vmopv1.VirtualMachineBackupPolicySpec{
+			Resources: []vmopv1.VirtualMachineBackupPolicyResource{
+				{
+					Kind: vmopv1.VirtualMachineBackupPolicyResourceKindVirtualMachineService,
+				},
+			},
+			ExcludedFieldPaths: []string{
+				"/metadata/labels",
+			},
+		},
+	}
+}
+
 func DummyImageAndItemObjectsForCdromBacking(
 	name, ns, kind, storageURI, libItemUUID string,
 	imgReady, imgHasProviderRef, itemObjExists bool,
@@ -29,6 +29,7 @@ import (
 	vmopv1a2 "github.com/vmware-tanzu/vm-operator/api/v1alpha2"
 	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
 	"github.com/vmware-tanzu/vm-operator/pkg/record"
+	vmopv1util "github.com/vmware-tanzu/vm-operator/pkg/util/vmopv1"
 )
 
 func NewFakeClient(objs ...client.Object) client.Client {
@@ -45,6 +46,10 @@ func NewFakeClientWithInterceptors(
 		WithInterceptorFuncs(funcs).
 		WithObjects(objs...).
 		WithStatusSubresource(KnownObjectTypes()...).
+		WithIndex(
+			&vmopv1.VirtualMachine{},
+			vmopv1util.LinkedCloneSourceIndexField,
+			vmopv1util.LinkedCloneSourceIndexFunc).
 		Build()
 }
 
@@ -62,6 +67,11 @@ func KnownObjectTypes() []client.Object {
 		&vmopv1.VirtualMachineImage{},
 		&vmopv1.VirtualMachineImageCache{},
 		&vmopv1.VirtualMachineWebConsoleRequest{},
+		&vmopv1.VirtualMachineSnapshot{},
+		&vmopv1.VirtualMachineRestoreRequest{},
+		&vmopv1.VirtualMachineBackupPolicy{},
+		&vmopv1.VirtualMachineReplicaSet{},
+		&vmopv1.VirtualMachineDeployment{},
 		&vmopv1a1.WebConsoleRequest{},
 		&cnsv1alpha1.CnsNodeVmAttachment{},
 		&spqv1.StoragePolicyQuota{},
@@ -133,7 +133,8 @@ func (h *RequestedCapacityHandler) Handle(req admission.Request) CapacityRespons
 	return handleRequest(webhookRequestContext)
 }
 
-// HandleCreate returns the Boot Disk capacity from the corresponding VMI/CVMI for the VM object in the AdmissionRequest.
+// HandleCreate returns the Boot Disk capacity from the corresponding VMI/CVMI, or the source VM of a clone,
+// for the VM object in the AdmissionRequest.
 func (h *RequestedCapacityHandler) HandleCreate(ctx *pkgctx.WebhookRequestContext) CapacityResponse {
 	vm := &vmopv1.VirtualMachine{}
 	if err := h.Converter.FromUnstructured(ctx.Obj.UnstructuredContent(), vm); err != nil {
@@ -175,6 +176,29 @@ func (h *RequestedCapacityHandler) HandleCreate(ctx *pkgctx.WebhookRequestContex
 			return CapacityResponse{Response: webhook.Errored(http.StatusInternalServerError, err)}
 		}
 		imageStatus = cvmi.Status
+	case "VirtualMachine":
+		srcVM := &vmopv1.VirtualMachine{}
+		if err := h.Client.Get(ctx, client.ObjectKey{Namespace: vm.Namespace, Name: vmiName}, srcVM); err != nil {
+			if apierrors.IsNotFound(err) {
+				return CapacityResponse{Response: webhook.Errored(http.StatusNotFound, err)}
+			}
+			return CapacityResponse{Response: webhook.Errored(http.StatusInternalServerError, err)}
+		}
+		// A cloned VM receives a copy of all of the source VM's classic
+		// disks, so the requested capacity is the sum of their sizes.
+		var (
+			capacity resource.Quantity
+			found    bool
+		)
+		for _, volume := range srcVM.Status.Volumes {
+			if volume.Type == vmopv1.VirtualMachineStorageDiskTypeClassic && volume.Limit != nil {
+				capacity.Add(*volume.Limit)
+				found = true
+			}
+		}
+		if found {
+			imageStatus.Disks = []vmopv1.VirtualMachineImageDiskInfo{{Capacity: &capacity}}
+		}
 	default:
 		return CapacityResponse{Response: webhook.Errored(http.StatusBadRequest, fmt.Errorf("unsupported image kind %s", vm.Spec.Image.Kind))}
 	}
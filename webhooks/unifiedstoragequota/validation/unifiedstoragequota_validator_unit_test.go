@@ -873,6 +873,126 @@ func testRequestedCapacityHandlerHandleCreate() {
 				})
 			})
 
+			Context("vm is specified as vm image kind", func() {
+				var srcVM *vmopv1.VirtualMachine
+
+				BeforeEach(func() {
+					srcVM = builder.DummyVirtualMachine()
+					srcVM.Name = "source-vm"
+					srcVM.Namespace = dummyNamespaceName
+
+					vm.Spec.Image = &vmopv1.VirtualMachineImageRef{
+						Kind: "VirtualMachine",
+						Name: srcVM.Name,
+					}
+				})
+
+				AfterEach(func() {
+					srcVM = nil
+				})
+
+				When("source VM is not found", func() {
+					It("should write StatusNotFound and an empty RequestedCapacity to the response", func() {
+						Expect(resp.Allowed).To(BeFalse())
+						Expect(int(resp.Result.Code)).To(Equal(http.StatusNotFound))
+
+						Expect(resp.Capacity.String()).To(Equal(expected.Capacity.String()))
+						Expect(resp.StoragePolicyID).To(Equal(expected.StoragePolicyID))
+						Expect(resp.StorageClassName).To(Equal(expected.StorageClassName))
+					})
+				})
+
+				When("source VM does not have a classic disk", func() {
+					BeforeEach(func() {
+						withObjects = append(withObjects, srcVM)
+					})
+
+					It("should write StatusNotFound code and an empty RequestedCapacity to the response", func() {
+						Expect(resp.Allowed).To(BeFalse())
+						Expect(int(resp.Result.Code)).To(Equal(http.StatusNotFound))
+
+						Expect(resp.Capacity.String()).To(Equal(expected.Capacity.String()))
+						Expect(resp.StoragePolicyID).To(Equal(expected.StoragePolicyID))
+						Expect(resp.StorageClassName).To(Equal(expected.StorageClassName))
+					})
+				})
+
+				When("source VM has a classic disk", func() {
+					BeforeEach(func() {
+						srcVM.Status.Volumes = []vmopv1.VirtualMachineVolumeStatus{
+							{
+								Name:  "pvc-disk",
+								Type:  vmopv1.VirtualMachineStorageDiskTypeManaged,
+								Limit: resource.NewQuantity(1024*1024*1024, resource.BinarySI),
+							},
+							{
+								Name:  "boot-disk",
+								Type:  vmopv1.VirtualMachineStorageDiskTypeClassic,
+								Limit: resource.NewQuantity(20*1024*1024*1024, resource.BinarySI),
+							},
+						}
+						withObjects = append(withObjects, srcVM)
+
+						expected = validation.CapacityResponse{
+							RequestedCapacity: validation.RequestedCapacity{
+								Capacity:         *resource.NewQuantity(20*1024*1024*1024, resource.BinarySI),
+								StoragePolicyID:  "id42",
+								StorageClassName: "dummy-storage-class",
+							},
+						}
+					})
+
+					It("should write StatusOK code and the source VM's boot disk capacity to the response", func() {
+						Expect(resp.Allowed).To(BeTrue())
+						Expect(int(resp.Result.Code)).To(Equal(http.StatusOK))
+
+						Expect(resp.Capacity.String()).To(Equal(expected.Capacity.String()))
+						Expect(resp.StoragePolicyID).To(Equal(expected.StoragePolicyID))
+						Expect(resp.StorageClassName).To(Equal(expected.StorageClassName))
+					})
+				})
+
+				When("source VM has multiple classic disks", func() {
+					BeforeEach(func() {
+						srcVM.Status.Volumes = []vmopv1.VirtualMachineVolumeStatus{
+							{
+								Name:  "boot-disk",
+								Type:  vmopv1.VirtualMachineStorageDiskTypeClassic,
+								Limit: resource.NewQuantity(20*1024*1024*1024, resource.BinarySI),
+							},
+							{
+								Name:  "pvc-disk",
+								Type:  vmopv1.VirtualMachineStorageDiskTypeManaged,
+								Limit: resource.NewQuantity(1024*1024*1024, resource.BinarySI),
+							},
+							{
+								Name:  "data-disk",
+								Type:  vmopv1.VirtualMachineStorageDiskTypeClassic,
+								Limit: resource.NewQuantity(10*1024*1024*1024, resource.BinarySI),
+							},
+						}
+						withObjects = append(withObjects, srcVM)
+
+						expected = validation.CapacityResponse{
+							RequestedCapacity: validation.RequestedCapacity{
+								Capacity:         *resource.NewQuantity(30*1024*1024*1024, resource.BinarySI),
+								StoragePolicyID:  "id42",
+								StorageClassName: "dummy-storage-class",
+							},
+						}
+					})
+
+					It("should write StatusOK code and the sum of the source VM's classic disk capacities to the response", func() {
+						Expect(resp.Allowed).To(BeTrue())
+						Expect(int(resp.Result.Code)).To(Equal(http.StatusOK))
+
+						Expect(resp.Capacity.String()).To(Equal(expected.Capacity.String()))
+						Expect(resp.StoragePolicyID).To(Equal(expected.StoragePolicyID))
+						Expect(resp.StorageClassName).To(Equal(expected.StorageClassName))
+					})
+				})
+			})
+
 			When("vm uses invalid image kind", func() {
 				BeforeEach(func() {
 					vm = &vmopv1.VirtualMachine{
@@ -502,7 +502,7 @@ func SetDefaultCdromImgKindOnCreate(
 	vm *vmopv1.VirtualMachine) {
 
 	for i, c := range vm.Spec.Cdrom {
-		if c.Image.Kind == "" {
+		if c.Image != nil && c.Image.Kind == "" {
 			vm.Spec.Cdrom[i].Image.Kind = vmiKind
 		}
 	}
@@ -518,14 +518,16 @@ func SetDefaultCdromImgKindOnUpdate(
 	)
 
 	for _, c := range oldVM.Spec.Cdrom {
-		imgNameToOldKind[c.Image.Name] = c.Image.Kind
+		if c.Image != nil {
+			imgNameToOldKind[c.Image.Name] = c.Image.Kind
+		}
 	}
 
 	for i, c := range vm.Spec.Cdrom {
 		// Repopulate the image kind only if it was previously set to default.
 		// This ensures an error is returned if the image kind was reset from
 		// a different value other than the default VirtualMachineImage kind.
-		if c.Image.Kind == "" && imgNameToOldKind[c.Image.Name] == vmiKind {
+		if c.Image != nil && c.Image.Kind == "" && imgNameToOldKind[c.Image.Name] == vmiKind {
 			vm.Spec.Cdrom[i].Image.Kind = vmiKind
 			mutated = true
 		}
@@ -546,15 +548,21 @@ func SetImageNameFromCdrom(
 	var cdromImageName string
 	for _, cdrom := range vm.Spec.Cdrom {
 		// Set the image name to the first connected CD-ROM image name.
-		if cdrom.Connected != nil && *cdrom.Connected {
+		if cdrom.Image != nil && cdrom.Connected != nil && *cdrom.Connected {
 			cdromImageName = cdrom.Image.Name
 			break
 		}
 	}
 
 	// If no connected CD-ROM is found, set it to the first CD-ROM image name.
+	// CD-ROMs backed by a PVC or config drive do not have an image name.
 	if cdromImageName == "" {
-		cdromImageName = vm.Spec.Cdrom[0].Image.Name
+		for _, cdrom := range vm.Spec.Cdrom {
+			if cdrom.Image != nil {
+				cdromImageName = cdrom.Image.Name
+				break
+			}
+		}
 	}
 
 	vm.Spec.ImageName = cdromImageName
@@ -1175,13 +1175,13 @@ func unitTestsMutating() {
 			ctx.vm.Spec.Cdrom = []vmopv1.VirtualMachineCdromSpec{
 				{
 					Name: "cdrom1",
-					Image: vmopv1.VirtualMachineImageRef{
+					Image: &vmopv1.VirtualMachineImageRef{
 						Name: "vmi-1",
 					},
 				},
 				{
 					Name: "cdrom2",
-					Image: vmopv1.VirtualMachineImageRef{
+					Image: &vmopv1.VirtualMachineImageRef{
 						Name: "vmi-2",
 					},
 				},
@@ -1203,14 +1203,14 @@ func unitTestsMutating() {
 			ctx.vm.Spec.Cdrom = []vmopv1.VirtualMachineCdromSpec{
 				{
 					Name: "cdrom1",
-					Image: vmopv1.VirtualMachineImageRef{
+					Image: &vmopv1.VirtualMachineImageRef{
 						Name: "vmi-1",
 						Kind: "VirtualMachineImage",
 					},
 				},
 				{
 					Name: "cdrom2",
-					Image: vmopv1.VirtualMachineImageRef{
+					Image: &vmopv1.VirtualMachineImageRef{
 						Name: "vmi-2",
 						Kind: "ClusterVirtualMachineImage",
 					},
@@ -1233,7 +1233,7 @@ func unitTestsMutating() {
 		BeforeEach(func() {
 			ctx.vm.Spec.Cdrom = []vmopv1.VirtualMachineCdromSpec{
 				{
-					Image: vmopv1.VirtualMachineImageRef{
+					Image: &vmopv1.VirtualMachineImageRef{
 						Name: "vmi-cdrom",
 					},
 				},
@@ -1259,7 +1259,7 @@ func unitTestsMutating() {
 				ctx.vm.Spec.Cdrom[i].Connected = ptr.To(false)
 			}
 			ctx.vm.Spec.Cdrom = append(ctx.vm.Spec.Cdrom, vmopv1.VirtualMachineCdromSpec{
-				Image: vmopv1.VirtualMachineImageRef{
+				Image: &vmopv1.VirtualMachineImageRef{
 					Name: "vmi-new",
 				},
 				Connected: ptr.To(true),
@@ -1268,5 +1268,31 @@ func unitTestsMutating() {
 			mutation.SetImageNameFromCdrom(&ctx.WebhookRequestContext, ctx.vm)
 			Expect(ctx.vm.Spec.ImageName).To(Equal("vmi-new"))
 		})
+
+		It("should skip CD-ROMs that are not backed by an image", func() {
+			ctx.vm.Spec.Cdrom[0].Connected = ptr.To(false)
+			ctx.vm.Spec.Cdrom = append([]vmopv1.VirtualMachineCdromSpec{
+				{
+					ConfigDrive: &vmopv1.VirtualMachineCdromConfigDriveSource{},
+					Connected:   ptr.To(true),
+				},
+			}, ctx.vm.Spec.Cdrom...)
+			ctx.vm.Spec.ImageName = ""
+			mutation.SetImageNameFromCdrom(&ctx.WebhookRequestContext, ctx.vm)
+			Expect(ctx.vm.Spec.ImageName).To(Equal("vmi-cdrom"))
+		})
+
+		It("should not set the image name if no CD-ROM is backed by an image", func() {
+			ctx.vm.Spec.Cdrom = []vmopv1.VirtualMachineCdromSpec{
+				{
+					PersistentVolumeClaim: &vmopv1.VirtualMachineCdromPersistentVolumeClaimSource{
+						ClaimName: "iso-pvc",
+					},
+				},
+			}
+			ctx.vm.Spec.ImageName = ""
+			mutation.SetImageNameFromCdrom(&ctx.WebhookRequestContext, ctx.vm)
+			Expect(ctx.vm.Spec.ImageName).To(BeEmpty())
+		})
 	})
 }
@@ -9,8 +9,10 @@ import (
 	"fmt"
 	"net"
 	"net/http"
+	"path"
 	"reflect"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -23,8 +25,11 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/api/validation"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	utilvalidation "k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
 	ctrlmgr "sigs.k8s.io/controller-runtime/pkg/manager"
@@ -43,6 +48,8 @@ import (
 	"github.com/vmware-tanzu/vm-operator/pkg/topology"
 	"github.com/vmware-tanzu/vm-operator/pkg/util"
 	cloudinitvalidate "github.com/vmware-tanzu/vm-operator/pkg/util/cloudinit/validate"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/cron"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/iso9660"
 	kubeutil "github.com/vmware-tanzu/vm-operator/pkg/util/kube"
 	"github.com/vmware-tanzu/vm-operator/pkg/util/ptr"
 	vmopv1util "github.com/vmware-tanzu/vm-operator/pkg/util/vmopv1"
@@ -57,9 +64,13 @@ const (
 
 	vmiKind  = "VirtualMachineImage"
 	cvmiKind = "ClusterVirtualMachineImage"
+	vmKind   = "VirtualMachine"
 
 	readinessProbeOnlyOneAction              = "only one action can be specified"
 	tcpReadinessProbeNotAllowedVPC           = "VPC networking doesn't allow TCP readiness probe to be specified"
+	httpGetReadinessProbeNotAllowedVPC       = "VPC networking doesn't allow HTTP GET readiness probe to be specified"
+	tcpLivenessProbeNotAllowedVPC            = "VPC networking doesn't allow TCP liveness probe to be specified"
+	httpGetLivenessProbeNotAllowedVPC        = "VPC networking doesn't allow HTTP GET liveness probe to be specified"
 	updatesNotAllowedWhenPowerOn             = "updates to this field is not allowed when VM power is on"
 	storageClassNotFoundFmt                  = "Storage policy %s does not exist"
 	storageClassNotAssignedFmt               = "Storage policy is not associated with the namespace %s"
@@ -77,18 +88,42 @@ const (
 	invalidMinHardwareVersionNotSupported    = "should be less than or equal to %d"
 	invalidMinHardwareVersionDowngrade       = "cannot downgrade hardware version"
 	invalidMinHardwareVersionPowerState      = "cannot upgrade hardware version unless powered off"
-	invalidImageKind                         = "supported: " + vmiKind + "; " + cvmiKind
+	invalidImageKind                         = "supported: " + vmiKind + "; " + cvmiKind + "; " + vmKind
+	cloneSourceIsSelf                        = "cannot clone a VM from itself"
+	cloneRequiresVMImageKind                 = "may only be set when spec.image.kind is " + vmKind
+	cloneVolumeClaimNameTooLongFmt           = "name %q of the PVC cloned from volume %q must be no more than %d characters"
 	invalidZone                              = "cannot use zone that is being deleted"
 	restrictedToPrivUsers                    = "restricted to privileged users"
 	invalidPVCBYOKFmt                        = "cannot attach volume to vm with spec.crypto.encryptionClassName=%q"
+	cdromOnlyOneBacking                      = "only one of image, persistentVolumeClaim, or configDrive can be specified"
+	cdromClaimNotBlock                       = "claim must have volumeMode Block"
+	configDriveOnlyOneSource                 = "only one of configMap or secret can be specified"
+	invalidConfigDrivePath                   = "must be a relative path that does not contain '..'"
+	configDriveSecretItemsRequired           = "the keys of a Secret written to a config drive must be specified"
+	volumeOnlyOneSource                      = "only one of persistentVolumeClaim, ephemeral, configMap, or secret can be specified"
+	serialConsoleProxyNotConfigured          = "the serial console proxy is not configured"
+	invalidEphemeralVolumeSize               = "must be greater than 0"
+	ephemeralVolumeSizeDecreased             = "cannot be decreased"
+	linkedClonesExistFmt                     = "cannot delete VM while linked clones depend on it: %s"
 )
 
-// +kubebuilder:webhook:verbs=create;update,path=/default-validate-vmoperator-vmware-com-v1alpha3-virtualmachine,mutating=false,failurePolicy=fail,groups=vmoperator.vmware.com,resources=virtualmachines,versions=v1alpha3,name=default.validating.virtualmachine.v1alpha3.vmoperator.vmware.com,sideEffects=None,admissionReviewVersions=v1;v1beta1
+// +kubebuilder:webhook:verbs=create;update;delete,path=/default-validate-vmoperator-vmware-com-v1alpha3-virtualmachine,mutating=false,failurePolicy=fail,groups=vmoperator.vmware.com,resources=virtualmachines,versions=v1alpha3,name=default.validating.virtualmachine.v1alpha3.vmoperator.vmware.com,sideEffects=None,admissionReviewVersions=v1;v1beta1
 // +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachines,verbs=get;list
 // +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachines/status,verbs=get
 
 // AddToManager adds the webhook to the provided manager.
 func AddToManager(ctx *pkgctx.ControllerManagerContext, mgr ctrlmgr.Manager) error {
+	// Index the VirtualMachine objects by the name of their linked clone source
+	// VM to allow efficient querying in GetLinkedClones(). This index is also
+	// used by the VirtualMachineSnapshot validation webhook.
+	if err := mgr.GetFieldIndexer().IndexField(
+		ctx,
+		&vmopv1.VirtualMachine{},
+		vmopv1util.LinkedCloneSourceIndexField,
+		vmopv1util.LinkedCloneSourceIndexFunc); err != nil {
+		return err
+	}
+
 	hook, err := builder.NewValidatingWebhook(ctx, mgr, webHookName, NewValidator(mgr.GetClient()))
 	if err != nil {
 		return fmt.Errorf("failed to create VirtualMachine validation webhook: %w", err)
@@ -136,17 +171,21 @@ func (v validator) ValidateCreate(ctx *pkgctx.WebhookRequestContext) admission.R
 	fieldErrs = append(fieldErrs, v.validateCrypto(ctx, vm)...)
 	fieldErrs = append(fieldErrs, v.validateBootstrap(ctx, vm)...)
 	fieldErrs = append(fieldErrs, v.validateNetwork(ctx, vm)...)
-	fieldErrs = append(fieldErrs, v.validateVolumes(ctx, vm)...)
+	fieldErrs = append(fieldErrs, v.validateVolumes(ctx, vm, nil)...)
 	fieldErrs = append(fieldErrs, v.validateInstanceStorageVolumes(ctx, vm, nil)...)
 	fieldErrs = append(fieldErrs, v.validateReadinessProbe(ctx, vm)...)
+	fieldErrs = append(fieldErrs, v.validateLivenessProbe(ctx, vm)...)
 	fieldErrs = append(fieldErrs, v.validateAdvanced(ctx, vm)...)
+	fieldErrs = append(fieldErrs, v.validateSerialConsole(ctx, vm, nil)...)
 	fieldErrs = append(fieldErrs, v.validatePowerStateOnCreate(ctx, vm)...)
 	fieldErrs = append(fieldErrs, v.validateNextRestartTimeOnCreate(ctx, vm)...)
+	fieldErrs = append(fieldErrs, v.validatePowerSchedule(ctx, vm)...)
 	fieldErrs = append(fieldErrs, v.validateAnnotation(ctx, vm, nil)...)
 	fieldErrs = append(fieldErrs, v.validateLabel(ctx, vm, nil)...)
 	fieldErrs = append(fieldErrs, v.validateNetworkHostAndDomainName(ctx, vm, nil)...)
 	fieldErrs = append(fieldErrs, v.validateMinHardwareVersion(ctx, vm, nil)...)
 	fieldErrs = append(fieldErrs, v.validateCdrom(ctx, vm)...)
+	fieldErrs = append(fieldErrs, v.validatePlacement(ctx, vm)...)
 
 	validationErrs := make([]string, 0, len(fieldErrs))
 	for _, fieldErr := range fieldErrs {
@@ -156,8 +195,22 @@ func (v validator) ValidateCreate(ctx *pkgctx.WebhookRequestContext) admission.R
 	return common.BuildValidationResponse(ctx, nil, validationErrs, nil)
 }
 
-func (v validator) ValidateDelete(*pkgctx.WebhookRequestContext) admission.Response {
-	return admission.Allowed("")
+// ValidateDelete denies deleting a VM that is the source of linked clones, as
+// the disks of the clones are backed by the VM's current snapshot.
+func (v validator) ValidateDelete(ctx *pkgctx.WebhookRequestContext) admission.Response {
+	clones, err := vmopv1util.GetLinkedClones(
+		ctx, v.client, ctx.Obj.GetNamespace(), ctx.Obj.GetName())
+	if err != nil {
+		return webhook.Errored(http.StatusInternalServerError, err)
+	}
+
+	var validationErrs []string
+	if len(clones) > 0 {
+		validationErrs = append(validationErrs,
+			fmt.Sprintf(linkedClonesExistFmt, strings.Join(clones, ", ")))
+	}
+
+	return common.BuildValidationResponse(ctx, nil, validationErrs, nil)
 }
 
 // Updates to VM's image are only allowed if it is a failed over VM.
@@ -188,6 +241,8 @@ func (v validator) validateImageOnUpdate(ctx *pkgctx.WebhookRequestContext, vm,
 		validation.ValidateImmutableField(vm.Spec.ImageName, oldVM.Spec.ImageName, field.NewPath("spec", "imageName"))...)
 	allErrs = append(allErrs,
 		validation.ValidateImmutableField(vm.Spec.Image, oldVM.Spec.Image, field.NewPath("spec", "image"))...)
+	allErrs = append(allErrs,
+		validation.ValidateImmutableField(vm.Spec.Clone, oldVM.Spec.Clone, field.NewPath("spec", "clone"))...)
 
 	return allErrs
 }
@@ -196,7 +251,6 @@ func (v validator) validateImageOnUpdate(ctx *pkgctx.WebhookRequestContext, vm,
 // Changes to following fields are not allowed:
 //   - Image
 //   - ImageName
-//   - StorageClass
 //   - ResourcePolicyName
 //   - Minimum VM Hardware Version
 //
@@ -233,18 +287,23 @@ func (v validator) ValidateUpdate(ctx *pkgctx.WebhookRequestContext) admission.R
 	// of whether the update is allowed or not.
 	fieldErrs = append(fieldErrs, v.validateCrypto(ctx, vm)...)
 	fieldErrs = append(fieldErrs, v.validateAvailabilityZone(ctx, vm, oldVM)...)
+	fieldErrs = append(fieldErrs, v.validateStorageClassOnUpdate(ctx, vm, oldVM)...)
 	fieldErrs = append(fieldErrs, v.validateBootstrap(ctx, vm)...)
 	fieldErrs = append(fieldErrs, v.validateNetwork(ctx, vm)...)
-	fieldErrs = append(fieldErrs, v.validateVolumes(ctx, vm)...)
+	fieldErrs = append(fieldErrs, v.validateVolumes(ctx, vm, oldVM)...)
 	fieldErrs = append(fieldErrs, v.validateInstanceStorageVolumes(ctx, vm, oldVM)...)
 	fieldErrs = append(fieldErrs, v.validateReadinessProbe(ctx, vm)...)
+	fieldErrs = append(fieldErrs, v.validateLivenessProbe(ctx, vm)...)
 	fieldErrs = append(fieldErrs, v.validateAdvanced(ctx, vm)...)
+	fieldErrs = append(fieldErrs, v.validateSerialConsole(ctx, vm, oldVM)...)
 	fieldErrs = append(fieldErrs, v.validateNextRestartTimeOnUpdate(ctx, vm, oldVM)...)
+	fieldErrs = append(fieldErrs, v.validatePowerSchedule(ctx, vm)...)
 	fieldErrs = append(fieldErrs, v.validateAnnotation(ctx, vm, oldVM)...)
 	fieldErrs = append(fieldErrs, v.validateMinHardwareVersion(ctx, vm, oldVM)...)
 	fieldErrs = append(fieldErrs, v.validateLabel(ctx, vm, oldVM)...)
 	fieldErrs = append(fieldErrs, v.validateNetworkHostAndDomainName(ctx, vm, oldVM)...)
 	fieldErrs = append(fieldErrs, v.validateCdrom(ctx, vm)...)
+	fieldErrs = append(fieldErrs, v.validatePlacement(ctx, vm)...)
 
 	validationErrs := make([]string, 0, len(fieldErrs))
 	for _, fieldErr := range fieldErrs {
@@ -266,6 +325,7 @@ func (v validator) validateBootstrap(
 		linuxPrep  *vmopv1.VirtualMachineBootstrapLinuxPrepSpec
 		sysPrep    *vmopv1.VirtualMachineBootstrapSysprepSpec
 		vAppConfig *vmopv1.VirtualMachineBootstrapVAppConfigSpec
+		ignition   *vmopv1.VirtualMachineBootstrapIgnitionSpec
 	)
 
 	if vm.Spec.Bootstrap != nil {
@@ -273,12 +333,13 @@ func (v validator) validateBootstrap(
 		linuxPrep = vm.Spec.Bootstrap.LinuxPrep
 		sysPrep = vm.Spec.Bootstrap.Sysprep
 		vAppConfig = vm.Spec.Bootstrap.VAppConfig
+		ignition = vm.Spec.Bootstrap.Ignition
 	}
 
 	if cloudInit != nil {
 		p := bootstrapPath.Child("cloudInit")
 
-		if linuxPrep != nil || sysPrep != nil || vAppConfig != nil {
+		if linuxPrep != nil || sysPrep != nil || vAppConfig != nil || ignition != nil {
 			allErrs = append(allErrs, field.Forbidden(p,
 				"CloudInit may not be used with any other bootstrap provider"))
 		}
@@ -349,6 +410,74 @@ func (v validator) validateBootstrap(
 
 	}
 
+	if ignition != nil {
+		p := bootstrapPath.Child("ignition")
+
+		if cloudInit != nil || linuxPrep != nil || sysPrep != nil || vAppConfig != nil {
+			allErrs = append(allErrs, field.Forbidden(p,
+				"Ignition may not be used with any other bootstrap provider"))
+		}
+
+		if ignition.Config != nil && ignition.RawConfig != nil {
+			allErrs = append(allErrs, field.Invalid(p, "ignition",
+				"config and rawConfig are mutually exclusive"))
+		} else if ignition.Config == nil && ignition.RawConfig == nil {
+			allErrs = append(allErrs, field.Invalid(p, "ignition",
+				"either config or rawConfig must be provided"))
+		}
+
+		if ignition.Config != nil {
+			allErrs = append(allErrs, v.validateInlineIgnition(p.Child("config"), ignition.Config)...)
+		}
+	}
+
+	return allErrs
+}
+
+// ignitionSystemdUnitSuffixes are the suffixes of the systemd unit types that
+// may be added with Ignition.
+var ignitionSystemdUnitSuffixes = []string{
+	".automount",
+	".device",
+	".mount",
+	".path",
+	".scope",
+	".service",
+	".slice",
+	".socket",
+	".swap",
+	".target",
+	".timer",
+}
+
+func (v validator) validateInlineIgnition(
+	p *field.Path,
+	config *vmopv1.VirtualMachineBootstrapIgnitionConfig) field.ErrorList {
+
+	var allErrs field.ErrorList
+
+	for i, u := range config.Users {
+		if u.Name == "" {
+			allErrs = append(allErrs, field.Required(p.Child("users").Index(i).Child("name"), ""))
+		}
+	}
+
+	for i, f := range config.Files {
+		if !path.IsAbs(f.Path) {
+			allErrs = append(allErrs, field.Invalid(p.Child("files").Index(i).Child("path"), f.Path,
+				"must be an absolute path"))
+		}
+	}
+
+	for i, u := range config.SystemdUnits {
+		if !slices.ContainsFunc(ignitionSystemdUnitSuffixes, func(s string) bool {
+			return strings.HasSuffix(u.Name, s) && len(u.Name) > len(s)
+		}) {
+			allErrs = append(allErrs, field.Invalid(p.Child("systemdUnits").Index(i).Child("name"), u.Name,
+				"must be a systemd unit name with a valid unit type suffix, ex. example.service"))
+		}
+	}
+
 	return allErrs
 }
 
@@ -437,10 +566,69 @@ func (v validator) validateImageOnCreate(ctx *pkgctx.WebhookRequestContext, vm *
 		allErrs = append(allErrs, field.Required(f, ""))
 	case vm.Spec.Image.Kind == "":
 		allErrs = append(allErrs, field.Required(f.Child("kind"), invalidImageKind))
+	case vm.Spec.Image.Kind == vmKind:
+		allErrs = append(allErrs, v.validateCloneSource(ctx, vm)...)
 	case vm.Spec.Image.Kind != vmiKind && vm.Spec.Image.Kind != cvmiKind:
 		allErrs = append(allErrs, field.Invalid(f.Child("kind"), vm.Spec.Image.Kind, invalidImageKind))
 	}
 
+	if vm.Spec.Clone != nil && (vm.Spec.Image == nil || vm.Spec.Image.Kind != vmKind) {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "clone"), cloneRequiresVMImageKind))
+	}
+
+	return allErrs
+}
+
+// validateCloneSource validates the VirtualMachine from which a new VM is
+// cloned exists in the same namespace.
+func (v validator) validateCloneSource(ctx *pkgctx.WebhookRequestContext, vm *vmopv1.VirtualMachine) field.ErrorList {
+	var (
+		allErrs field.ErrorList
+		f       = field.NewPath("spec", "image", "name")
+		name    = vm.Spec.Image.Name
+	)
+
+	if name == vm.Name {
+		return append(allErrs, field.Invalid(f, name, cloneSourceIsSelf))
+	}
+
+	var srcVM vmopv1.VirtualMachine
+	if err := v.client.Get(ctx, ctrlclient.ObjectKey{Namespace: vm.Namespace, Name: name}, &srcVM); err != nil {
+		if apierrors.IsNotFound(err) {
+			allErrs = append(allErrs, field.NotFound(f, name))
+		} else {
+			allErrs = append(allErrs, field.InternalError(f, err))
+		}
+		return allErrs
+	}
+
+	if vm.Spec.Clone != nil && vm.Spec.Clone.CloneVolumes {
+		allErrs = append(allErrs, validateCloneVolumeClaimNames(vm, &srcVM)...)
+	}
+
+	return allErrs
+}
+
+// validateCloneVolumeClaimNames validates the names of the PVCs created for the
+// source VM's volumes, when the VM is cloned along with its volumes, do not
+// exceed the maximum length of an object name.
+func validateCloneVolumeClaimNames(vm, srcVM *vmopv1.VirtualMachine) field.ErrorList {
+	var (
+		allErrs field.ErrorList
+		f       = field.NewPath("spec", "clone", "cloneVolumes")
+	)
+
+	for _, vol := range srcVM.Spec.Volumes {
+		if claim := vol.PersistentVolumeClaim; claim == nil || claim.InstanceVolumeClaim != nil {
+			continue
+		}
+		claimName := vmopv1util.CloneVolumeClaimName(vm.Name, vol.Name)
+		if len(claimName) > utilvalidation.DNS1123SubdomainMaxLength {
+			allErrs = append(allErrs, field.Invalid(f, vm.Spec.Clone.CloneVolumes,
+				fmt.Sprintf(cloneVolumeClaimNameTooLongFmt, claimName, vol.Name, utilvalidation.DNS1123SubdomainMaxLength)))
+		}
+	}
+
 	return allErrs
 }
 
@@ -545,6 +733,27 @@ func (v validator) validateStorageClass(ctx *pkgctx.WebhookRequestContext, vm *v
 	return append(allErrs, field.Invalid(scPath, scName, fmt.Sprintf(storageClassNotAssignedFmt, vm.Namespace)))
 }
 
+// validateStorageClassOnUpdate validates a change to the VM's storage class,
+// which migrates the VM to a datastore compatible with the new storage policy.
+func (v validator) validateStorageClassOnUpdate(
+	ctx *pkgctx.WebhookRequestContext,
+	vm, oldVM *vmopv1.VirtualMachine) field.ErrorList {
+
+	if vm.Spec.StorageClass == oldVM.Spec.StorageClass {
+		return nil
+	}
+
+	scPath := field.NewPath("spec", "storageClass")
+
+	if vm.Spec.StorageClass == "" {
+		return field.ErrorList{
+			field.Forbidden(scPath, "cannot be removed once set"),
+		}
+	}
+
+	return v.validateStorageClass(ctx, vm)
+}
+
 func (v validator) validateCrypto(
 	ctx *pkgctx.WebhookRequestContext,
 	vm *vmopv1.VirtualMachine) field.ErrorList {
@@ -886,11 +1095,23 @@ func (v validator) validateNetworkInterfaceSpecWithBootstrap(
 	return allErrs
 }
 
-func (v validator) validateVolumes(ctx *pkgctx.WebhookRequestContext, vm *vmopv1.VirtualMachine) field.ErrorList {
+func (v validator) validateVolumes(
+	ctx *pkgctx.WebhookRequestContext,
+	vm, oldVM *vmopv1.VirtualMachine) field.ErrorList {
+
 	var allErrs field.ErrorList
 	volumesPath := field.NewPath("spec", "volumes")
 	volumeNames := map[string]bool{}
 
+	oldEphemeralVolumes := map[string]*vmopv1.EphemeralVolumeSource{}
+	if oldVM != nil {
+		for _, vol := range oldVM.Spec.Volumes {
+			if vol.Ephemeral != nil {
+				oldEphemeralVolumes[vol.Name] = vol.Ephemeral
+			}
+		}
+	}
+
 	for i, vol := range vm.Spec.Volumes {
 		volPath := volumesPath.Index(i)
 
@@ -911,16 +1132,81 @@ func (v validator) validateVolumes(ctx *pkgctx.WebhookRequestContext, vm *vmopv1
 			}
 		}
 
-		if vol.PersistentVolumeClaim == nil {
+		var numSources int
+		for _, set := range []bool{
+			vol.PersistentVolumeClaim != nil,
+			vol.Ephemeral != nil,
+			vol.ConfigMap != nil,
+			vol.Secret != nil,
+		} {
+			if set {
+				numSources++
+			}
+		}
+
+		switch {
+		case numSources == 0:
 			allErrs = append(allErrs, field.Required(volPath.Child("persistentVolumeClaim"), ""))
-		} else {
+		case numSources > 1:
+			allErrs = append(allErrs, field.Forbidden(volPath, volumeOnlyOneSource))
+		case vol.PersistentVolumeClaim != nil:
 			allErrs = append(allErrs, v.validateVolumeWithPVC(ctx, vm, vol, volPath)...)
+		case vol.Ephemeral != nil:
+			srcPath := volPath.Child("ephemeral")
+			if !pkgcfg.FromContext(ctx).Features.VMEphemeralVolumes {
+				allErrs = append(allErrs, field.Forbidden(srcPath, fmt.Sprintf(featureNotEnabled, "Ephemeral Volumes")))
+				continue
+			}
+			if vol.Ephemeral.Size.Sign() <= 0 {
+				allErrs = append(allErrs, field.Invalid(srcPath.Child("size"),
+					vol.Ephemeral.Size.String(), invalidEphemeralVolumeSize))
+			}
+			// The disk of an ephemeral volume can be grown but not shrunk.
+			if oldVol, ok := oldEphemeralVolumes[vol.Name]; ok && vol.Ephemeral.Size.Cmp(oldVol.Size) < 0 {
+				allErrs = append(allErrs, field.Invalid(srcPath.Child("size"),
+					vol.Ephemeral.Size.String(), ephemeralVolumeSizeDecreased))
+			}
+		case vol.ConfigMap != nil:
+			srcPath := volPath.Child("configMap")
+			if !pkgcfg.FromContext(ctx).Features.VMEphemeralVolumes {
+				allErrs = append(allErrs, field.Forbidden(srcPath, fmt.Sprintf(featureNotEnabled, "Ephemeral Volumes")))
+				continue
+			}
+			allErrs = append(allErrs, validateDataVolumeName(vol.Name, volPath)...)
+			if vol.ConfigMap.Name == "" {
+				allErrs = append(allErrs, field.Required(srcPath.Child("name"), ""))
+			}
+			allErrs = append(allErrs, validateKeyToPathItems(srcPath, vol.ConfigMap.Items)...)
+		case vol.Secret != nil:
+			srcPath := volPath.Child("secret")
+			if !pkgcfg.FromContext(ctx).Features.VMEphemeralVolumes {
+				allErrs = append(allErrs, field.Forbidden(srcPath, fmt.Sprintf(featureNotEnabled, "Ephemeral Volumes")))
+				continue
+			}
+			allErrs = append(allErrs, validateDataVolumeName(vol.Name, volPath)...)
+			if vol.Secret.SecretName == "" {
+				allErrs = append(allErrs, field.Required(srcPath.Child("secretName"), ""))
+			}
+			allErrs = append(allErrs, validateKeyToPathItems(srcPath, vol.Secret.Items)...)
 		}
 	}
 
 	return allErrs
 }
 
+// validateDataVolumeName validates the name of a volume backed by a ConfigMap
+// or Secret is short enough to be used as the label of the file system on the
+// disk.
+func validateDataVolumeName(name string, volPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if len(name) > iso9660.MaxVolumeIDLength {
+		allErrs = append(allErrs, field.TooLong(volPath.Child("name"), name, iso9660.MaxVolumeIDLength))
+	}
+
+	return allErrs
+}
+
 func (v validator) validateVolumeWithPVC(
 	ctx *pkgctx.WebhookRequestContext,
 	vm *vmopv1.VirtualMachine,
@@ -1004,6 +1290,9 @@ func (v validator) validateReadinessProbe(ctx *pkgctx.WebhookRequestContext, vm
 	if probe.TCPSocket != nil {
 		actionsCnt++
 	}
+	if probe.HTTPGet != nil {
+		actionsCnt++
+	}
 	if probe.GuestHeartbeat != nil {
 		actionsCnt++
 	}
@@ -1015,21 +1304,123 @@ func (v validator) validateReadinessProbe(ctx *pkgctx.WebhookRequestContext, vm
 	}
 
 	if probe.TCPSocket != nil {
-		tcpSocketPath := readinessProbePath.Child("tcpSocket")
-
-		// TCP readiness probe is not allowed under VPC Networking
-		if pkgcfg.FromContext(ctx).NetworkProviderType == pkgcfg.NetworkProviderTypeVPC {
-			allErrs = append(allErrs, field.Forbidden(tcpSocketPath, tcpReadinessProbeNotAllowedVPC))
-		} else if probe.TCPSocket.Port.IntValue() != allowedRestrictedNetworkTCPProbePort {
-			// Validate port if environment is a restricted network environment between SV CP VMs and Workload VMs e.g. VMC.
-			isRestrictedEnv, err := v.isNetworkRestrictedForReadinessProbe(ctx)
-			if err != nil {
-				allErrs = append(allErrs, field.Forbidden(tcpSocketPath, err.Error()))
-			} else if isRestrictedEnv {
-				allErrs = append(allErrs,
-					field.NotSupported(tcpSocketPath.Child("port"), probe.TCPSocket.Port.IntValue(),
-						[]string{strconv.Itoa(allowedRestrictedNetworkTCPProbePort)}))
-			}
+		allErrs = append(allErrs, v.validateTCPSocketAction(ctx, probe.TCPSocket,
+			readinessProbePath.Child("tcpSocket"), tcpReadinessProbeNotAllowedVPC)...)
+	}
+
+	if probe.HTTPGet != nil {
+		allErrs = append(allErrs, v.validateHTTPGetAction(ctx, probe.HTTPGet,
+			readinessProbePath.Child("httpGet"), httpGetReadinessProbeNotAllowedVPC)...)
+	}
+
+	return allErrs
+}
+
+func (v validator) validateLivenessProbe(ctx *pkgctx.WebhookRequestContext, vm *vmopv1.VirtualMachine) field.ErrorList {
+	var allErrs field.ErrorList
+
+	probe := vm.Spec.LivenessProbe
+	if probe == nil {
+		return allErrs
+	}
+
+	livenessProbePath := field.NewPath("spec", "livenessProbe")
+
+	actionsCnt := 0
+	if probe.TCPSocket != nil {
+		actionsCnt++
+	}
+	if probe.HTTPGet != nil {
+		actionsCnt++
+	}
+	if probe.GuestHeartbeat != nil {
+		actionsCnt++
+	}
+	if len(probe.GuestInfo) != 0 {
+		actionsCnt++
+	}
+	if actionsCnt > 1 {
+		allErrs = append(allErrs, field.Forbidden(livenessProbePath, readinessProbeOnlyOneAction))
+	}
+
+	if probe.TCPSocket != nil {
+		allErrs = append(allErrs, v.validateTCPSocketAction(ctx, probe.TCPSocket,
+			livenessProbePath.Child("tcpSocket"), tcpLivenessProbeNotAllowedVPC)...)
+	}
+
+	if probe.HTTPGet != nil {
+		allErrs = append(allErrs, v.validateHTTPGetAction(ctx, probe.HTTPGet,
+			livenessProbePath.Child("httpGet"), httpGetLivenessProbeNotAllowedVPC)...)
+	}
+
+	return allErrs
+}
+
+func (v validator) validateTCPSocketAction(
+	ctx *pkgctx.WebhookRequestContext,
+	action *vmopv1.TCPSocketAction,
+	tcpSocketPath *field.Path,
+	notAllowedVPCMsg string) field.ErrorList {
+
+	var allErrs field.ErrorList
+
+	// TCP probe is not allowed under VPC Networking
+	if pkgcfg.FromContext(ctx).NetworkProviderType == pkgcfg.NetworkProviderTypeVPC {
+		allErrs = append(allErrs, field.Forbidden(tcpSocketPath, notAllowedVPCMsg))
+	} else if action.Port.IntValue() != allowedRestrictedNetworkTCPProbePort {
+		// Validate port if environment is a restricted network environment between SV CP VMs and Workload VMs e.g. VMC.
+		isRestrictedEnv, err := v.isNetworkRestrictedForReadinessProbe(ctx)
+		if err != nil {
+			allErrs = append(allErrs, field.Forbidden(tcpSocketPath, err.Error()))
+		} else if isRestrictedEnv {
+			allErrs = append(allErrs,
+				field.NotSupported(tcpSocketPath.Child("port"), action.Port.IntValue(),
+					[]string{strconv.Itoa(allowedRestrictedNetworkTCPProbePort)}))
+		}
+	}
+
+	return allErrs
+}
+
+func (v validator) validateHTTPGetAction(
+	ctx *pkgctx.WebhookRequestContext,
+	action *vmopv1.HTTPGetAction,
+	httpGetPath *field.Path,
+	notAllowedVPCMsg string) field.ErrorList {
+
+	var allErrs field.ErrorList
+
+	// Like the TCP probe, the HTTP GET probe requires network connectivity to
+	// the VM.
+	if pkgcfg.FromContext(ctx).NetworkProviderType == pkgcfg.NetworkProviderTypeVPC {
+		allErrs = append(allErrs, field.Forbidden(httpGetPath, notAllowedVPCMsg))
+	} else if action.Port.IntValue() != allowedRestrictedNetworkTCPProbePort {
+		isRestrictedEnv, err := v.isNetworkRestrictedForReadinessProbe(ctx)
+		if err != nil {
+			allErrs = append(allErrs, field.Forbidden(httpGetPath, err.Error()))
+		} else if isRestrictedEnv {
+			allErrs = append(allErrs,
+				field.NotSupported(httpGetPath.Child("port"), action.Port.IntValue(),
+					[]string{strconv.Itoa(allowedRestrictedNetworkTCPProbePort)}))
+		}
+	}
+
+	if action.Port.Type == intstr.String {
+		for _, msg := range utilvalidation.IsValidPortName(action.Port.StrVal) {
+			allErrs = append(allErrs, field.Invalid(httpGetPath.Child("port"), action.Port.StrVal, msg))
+		}
+	} else if p := action.Port.IntValue(); p < 1 || p > 65535 {
+		allErrs = append(allErrs, field.Invalid(httpGetPath.Child("port"), p, "must be between 1 and 65535, inclusive"))
+	}
+
+	if action.MinStatusCode != 0 && action.MaxStatusCode != 0 && action.MinStatusCode > action.MaxStatusCode {
+		allErrs = append(allErrs, field.Invalid(httpGetPath.Child("minStatusCode"), action.MinStatusCode,
+			"must be less than or equal to maxStatusCode"))
+	}
+
+	for i, h := range action.HTTPHeaders {
+		for _, msg := range utilvalidation.IsHTTPHeaderName(h.Name) {
+			allErrs = append(allErrs, field.Invalid(httpGetPath.Child("httpHeaders").Index(i).Child("name"), h.Name, msg))
 		}
 	}
 
@@ -1058,6 +1449,76 @@ func (v validator) validateAdvanced(ctx *pkgctx.WebhookRequestContext, vm *vmopv
 	return allErrs
 }
 
+// validateSerialConsole validates that the serial console may only be enabled
+// when the serial console proxy is configured. A VM that already has the serial
+// console enabled is not prevented from being updated if the proxy is no longer
+// configured.
+func (v validator) validateSerialConsole(
+	ctx *pkgctx.WebhookRequestContext,
+	vm, oldVM *vmopv1.VirtualMachine) field.ErrorList {
+
+	if !isSerialConsoleEnabled(vm) || (oldVM != nil && isSerialConsoleEnabled(oldVM)) {
+		return nil
+	}
+
+	var allErrs field.ErrorList
+
+	if pkgcfg.FromContext(ctx).SerialConsoleProxyURI == "" {
+		allErrs = append(allErrs, field.Forbidden(
+			field.NewPath("spec", "advanced", "serialConsoleEnabled"),
+			serialConsoleProxyNotConfigured))
+	}
+
+	return allErrs
+}
+
+func isSerialConsoleEnabled(vm *vmopv1.VirtualMachine) bool {
+	adv := vm.Spec.Advanced
+	return adv != nil && adv.SerialConsoleEnabled != nil && *adv.SerialConsoleEnabled
+}
+
+func (v validator) validatePowerSchedule(
+	ctx *pkgctx.WebhookRequestContext,
+	vm *vmopv1.VirtualMachine) field.ErrorList {
+
+	schedule := vm.Spec.PowerSchedule
+	if schedule == nil {
+		return nil
+	}
+
+	var allErrs field.ErrorList
+
+	powerSchedulePath := field.NewPath("spec", "powerSchedule")
+
+	if _, err := vmopv1util.GetPowerScheduleLocation(*schedule); err != nil {
+		allErrs = append(allErrs, field.Invalid(
+			powerSchedulePath.Child("timeZone"),
+			schedule.TimeZone,
+			err.Error()))
+	}
+
+	for _, e := range []struct {
+		name string
+		expr string
+	}{
+		{"powerOn", schedule.PowerOn},
+		{"powerOff", schedule.PowerOff},
+		{"suspend", schedule.Suspend},
+	} {
+		if e.expr == "" {
+			continue
+		}
+		if _, err := cron.Parse(e.expr); err != nil {
+			allErrs = append(allErrs, field.Invalid(
+				powerSchedulePath.Child(e.name),
+				e.expr,
+				err.Error()))
+		}
+	}
+
+	return allErrs
+}
+
 func (v validator) validateNextRestartTimeOnCreate(
 	ctx *pkgctx.WebhookRequestContext,
 	vm *vmopv1.VirtualMachine) field.ErrorList {
@@ -1208,7 +1669,12 @@ func (v validator) validateUpdatesWhenPoweredOn(ctx *pkgctx.WebhookRequestContex
 		allErrs = append(allErrs, field.Forbidden(specPath.Child("guestID"), updatesNotAllowedWhenPowerOn))
 	}
 
+	if isSerialConsoleEnabled(vm) != isSerialConsoleEnabled(oldVM) {
+		allErrs = append(allErrs, field.Forbidden(specPath.Child("advanced", "serialConsoleEnabled"), updatesNotAllowedWhenPowerOn))
+	}
+
 	allErrs = append(allErrs, validateCdromWhenPoweredOn(vm.Spec.Cdrom, oldVM.Spec.Cdrom)...)
+	allErrs = append(allErrs, validateNonPVCVolumesWhenPoweredOn(vm.Spec.Volumes, oldVM.Spec.Volumes)...)
 
 	// TODO: More checks.
 
@@ -1221,7 +1687,6 @@ func (v validator) validateImmutableFields(ctx *pkgctx.WebhookRequestContext, vm
 
 	allErrs = append(allErrs, v.validateImageOnUpdate(ctx, vm, oldVM)...)
 	allErrs = append(allErrs, v.validateClassOnUpdate(ctx, vm, oldVM)...)
-	allErrs = append(allErrs, validation.ValidateImmutableField(vm.Spec.StorageClass, oldVM.Spec.StorageClass, specPath.Child("storageClass"))...)
 	// New VMs always have non-empty biosUUID. Existing VMs being upgraded may have an empty biosUUID.
 	if oldVM.Spec.BiosUUID != "" {
 		allErrs = append(allErrs, validation.ValidateImmutableField(vm.Spec.BiosUUID, oldVM.Spec.BiosUUID, specPath.Child("biosUUID"))...)
@@ -1272,7 +1737,10 @@ func (v validator) validateImmutableNetwork(ctx *pkgctx.WebhookRequestContext, v
 	}
 
 	if len(oldInterfaces) != len(newInterfaces) {
-		return append(allErrs, field.Forbidden(p.Child("interfaces"), "network interfaces cannot be added or removed"))
+		if !pkgcfg.FromContext(ctx).Features.VMNetworkHotPlug {
+			return append(allErrs, field.Forbidden(p.Child("interfaces"), "network interfaces cannot be added or removed"))
+		}
+		return append(allErrs, validateAddedOrRemovedNetworkInterfaces(p, oldInterfaces, newInterfaces)...)
 	}
 
 	// Skip comparing interfaces if this is a fail-over to allow vendors to
@@ -1297,6 +1765,42 @@ func (v validator) validateImmutableNetwork(ctx *pkgctx.WebhookRequestContext, v
 	return allErrs
 }
 
+// validateAddedOrRemovedNetworkInterfaces validates the network interfaces
+// when interfaces are being added or removed. The interfaces that remain are
+// matched by name since their index may have changed, and their network may
+// not be changed.
+func validateAddedOrRemovedNetworkInterfaces(
+	p *field.Path,
+	oldInterfaces, newInterfaces []vmopv1.VirtualMachineNetworkInterfaceSpec) field.ErrorList {
+
+	var allErrs field.ErrorList
+
+	oldInterfacesByName := make(map[string]*vmopv1.VirtualMachineNetworkInterfaceSpec, len(oldInterfaces))
+	for i := range oldInterfaces {
+		oldInterfacesByName[oldInterfaces[i].Name] = &oldInterfaces[i]
+	}
+
+	newInterfaceNames := make(map[string]struct{}, len(newInterfaces))
+	for i := range newInterfaces {
+		newInterface := &newInterfaces[i]
+		pi := p.Child("interfaces").Index(i)
+
+		if _, ok := newInterfaceNames[newInterface.Name]; ok {
+			allErrs = append(allErrs, field.Duplicate(pi.Child("name"), newInterface.Name))
+			continue
+		}
+		newInterfaceNames[newInterface.Name] = struct{}{}
+
+		if oldInterface, ok := oldInterfacesByName[newInterface.Name]; ok {
+			if !reflect.DeepEqual(newInterface.Network, oldInterface.Network) {
+				allErrs = append(allErrs, field.Forbidden(pi.Child("network"), validation.FieldImmutableErrorMsg))
+			}
+		}
+	}
+
+	return allErrs
+}
+
 func (v validator) validateAvailabilityZone(ctx *pkgctx.WebhookRequestContext, vm, oldVM *vmopv1.VirtualMachine) field.ErrorList {
 	var allErrs field.ErrorList
 
@@ -1508,25 +2012,127 @@ func (v *validator) validateCdrom(
 	}
 
 	// GuestID must be set when deploying an ISO VM with CD-ROMs.
-	if vm.Spec.GuestID == "" {
+	if vm.Spec.GuestID == "" && vmopv1util.IsISOVM(*vm) {
 		allErrs = append(allErrs, field.Required(field.NewPath("spec", "guestID"), "when deploying a VM with CD-ROMs"))
 	}
 
-	// Validate image kind and uniqueness for each CD-ROM.
+	volumeClaimNames := make(map[string]struct{}, len(vm.Spec.Volumes))
+	for _, vol := range vm.Spec.Volumes {
+		if vol.PersistentVolumeClaim != nil {
+			volumeClaimNames[vol.PersistentVolumeClaim.ClaimName] = struct{}{}
+		}
+	}
+
+	// Validate the backing and its uniqueness for each CD-ROM.
 	// Namespace and cluster scope images with the same name are considered
 	// duplicates since they come from the same content library item file.
-	imgNames := make(map[string]struct{}, len(vm.Spec.Cdrom))
+	var (
+		imgNames   = make(map[string]struct{}, len(vm.Spec.Cdrom))
+		claimNames = make(map[string]struct{}, len(vm.Spec.Cdrom))
+	)
 	for i, c := range vm.Spec.Cdrom {
-		imgPath := f.Index(i).Child("image")
-		imgKind := c.Image.Kind
-		if imgKind != vmiKind && imgKind != cvmiKind {
-			allErrs = append(allErrs, field.NotSupported(imgPath.Child("kind"), imgKind, []string{vmiKind, cvmiKind}))
-		}
-		imgName := c.Image.Name
-		if _, ok := imgNames[imgName]; ok {
-			allErrs = append(allErrs, field.Duplicate(imgPath.Child("name"), imgName))
-		} else {
-			imgNames[imgName] = struct{}{}
+		cdromPath := f.Index(i)
+
+		var numBackings int
+		for _, b := range []bool{c.Image != nil, c.PersistentVolumeClaim != nil, c.ConfigDrive != nil} {
+			if b {
+				numBackings++
+			}
+		}
+		if numBackings == 0 {
+			allErrs = append(allErrs, field.Required(cdromPath, cdromOnlyOneBacking))
+		} else if numBackings > 1 {
+			allErrs = append(allErrs, field.Forbidden(cdromPath, cdromOnlyOneBacking))
+		}
+
+		if c.Image != nil {
+			imgPath := cdromPath.Child("image")
+			imgKind := c.Image.Kind
+			if imgKind != vmiKind && imgKind != cvmiKind {
+				allErrs = append(allErrs, field.NotSupported(imgPath.Child("kind"), imgKind, []string{vmiKind, cvmiKind}))
+			}
+			imgName := c.Image.Name
+			if _, ok := imgNames[imgName]; ok {
+				allErrs = append(allErrs, field.Duplicate(imgPath.Child("name"), imgName))
+			} else {
+				imgNames[imgName] = struct{}{}
+			}
+		}
+
+		if c.PersistentVolumeClaim != nil {
+			claimPath := cdromPath.Child("persistentVolumeClaim", "claimName")
+			claimName := c.PersistentVolumeClaim.ClaimName
+			if _, ok := claimNames[claimName]; ok {
+				allErrs = append(allErrs, field.Duplicate(claimPath, claimName))
+			} else {
+				claimNames[claimName] = struct{}{}
+			}
+			if _, ok := volumeClaimNames[claimName]; ok {
+				allErrs = append(allErrs, field.Invalid(claimPath, claimName, "claim is also specified in spec.volumes"))
+			}
+
+			// The ISO image is read from the raw contents of the volume, so
+			// the claim must be a block volume. Like the check of the
+			// volumes' claims, this check is best effort.
+			pvc := &corev1.PersistentVolumeClaim{}
+			if err := v.client.Get(ctx, ctrlclient.ObjectKey{Name: claimName, Namespace: vm.Namespace}, pvc); err == nil {
+				if mode := pvc.Spec.VolumeMode; mode == nil || *mode != corev1.PersistentVolumeBlock {
+					allErrs = append(allErrs, field.Invalid(claimPath, claimName, cdromClaimNotBlock))
+				}
+			}
+		}
+
+		if c.ConfigDrive != nil {
+			allErrs = append(allErrs, validateCdromConfigDrive(cdromPath.Child("configDrive"), c.ConfigDrive)...)
+		}
+	}
+
+	return allErrs
+}
+
+func validateCdromConfigDrive(
+	f *field.Path,
+	configDrive *vmopv1.VirtualMachineCdromConfigDriveSource) field.ErrorList {
+
+	var allErrs field.ErrorList
+
+	for i, src := range configDrive.Sources {
+		srcPath := f.Child("sources").Index(i)
+
+		var items []corev1.KeyToPath
+		switch {
+		case src.ConfigMap != nil && src.Secret != nil:
+			allErrs = append(allErrs, field.Forbidden(srcPath, configDriveOnlyOneSource))
+			continue
+		case src.ConfigMap != nil:
+			srcPath = srcPath.Child("configMap")
+			items = src.ConfigMap.Items
+		case src.Secret != nil:
+			srcPath = srcPath.Child("secret")
+			items = src.Secret.Items
+			// Only the keys of a Secret that are explicitly listed are written
+			// to a config drive.
+			if len(items) == 0 {
+				allErrs = append(allErrs, field.Required(srcPath.Child("items"), configDriveSecretItemsRequired))
+			}
+		default:
+			allErrs = append(allErrs, field.Required(srcPath, configDriveOnlyOneSource))
+			continue
+		}
+
+		allErrs = append(allErrs, validateKeyToPathItems(srcPath, items)...)
+	}
+
+	return allErrs
+}
+
+func validateKeyToPathItems(f *field.Path, items []corev1.KeyToPath) field.ErrorList {
+	var allErrs field.ErrorList
+
+	for i, item := range items {
+		p := item.Path
+		if p == "" || path.IsAbs(p) || path.Clean(p) != p || p == ".." || strings.HasPrefix(p, "../") {
+			allErrs = append(allErrs, field.Invalid(f.Child("items").Index(i).Child("path"), p, invalidConfigDrivePath))
 		}
 	}
 
@@ -1547,19 +2153,103 @@ func validateCdromWhenPoweredOn(
 		return allErrs
 	}
 
-	oldCdromNameToImage := make(map[string]vmopv1.VirtualMachineImageRef, len(oldCdrom))
+	oldCdromNameToSpec := make(map[string]vmopv1.VirtualMachineCdromSpec, len(oldCdrom))
 	for _, c := range oldCdrom {
-		oldCdromNameToImage[c.Name] = c.Image
+		oldCdromNameToSpec[c.Name] = c
 	}
 
 	for i, c := range cdrom {
-		if oldImage, ok := oldCdromNameToImage[c.Name]; !ok {
+		oldC, ok := oldCdromNameToSpec[c.Name]
+		if !ok {
 			// CD-ROM name is changed.
 			allErrs = append(allErrs, field.Forbidden(f.Index(i).Child("name"), updatesNotAllowedWhenPowerOn))
-		} else if !reflect.DeepEqual(c.Image, oldImage) {
+			continue
+		}
+		if !reflect.DeepEqual(c.Image, oldC.Image) {
 			// CD-ROM image is changed.
 			allErrs = append(allErrs, field.Forbidden(f.Index(i).Child("image"), updatesNotAllowedWhenPowerOn))
 		}
+		if !reflect.DeepEqual(c.PersistentVolumeClaim, oldC.PersistentVolumeClaim) {
+			// CD-ROM PVC is changed.
+			allErrs = append(allErrs, field.Forbidden(f.Index(i).Child("persistentVolumeClaim"), updatesNotAllowedWhenPowerOn))
+		}
+		if !reflect.DeepEqual(c.ConfigDrive, oldC.ConfigDrive) {
+			// CD-ROM config drive is changed.
+			allErrs = append(allErrs, field.Forbidden(f.Index(i).Child("configDrive"), updatesNotAllowedWhenPowerOn))
+		}
+	}
+
+	return allErrs
+}
+
+// validateNonPVCVolumesWhenPoweredOn validates the volumes that are not
+// backed by a PVC are not added, removed, or changed while the VM is powered
+// on. These disks are only reconfigured before the VM is powered on.
+func validateNonPVCVolumesWhenPoweredOn(
+	volumes, oldVolumes []vmopv1.VirtualMachineVolume) field.ErrorList {
+
+	var (
+		allErrs field.ErrorList
+		f       = field.NewPath("spec", "volumes")
+	)
+
+	oldVolumeNameToSpec := make(map[string]vmopv1.VirtualMachineVolume, len(oldVolumes))
+	for _, vol := range oldVolumes {
+		if vol.PersistentVolumeClaim == nil {
+			oldVolumeNameToSpec[vol.Name] = vol
+		}
+	}
+
+	for i, vol := range volumes {
+		if vol.PersistentVolumeClaim != nil {
+			continue
+		}
+		oldVol, ok := oldVolumeNameToSpec[vol.Name]
+		if !ok || !reflect.DeepEqual(vol.VirtualMachineVolumeSource, oldVol.VirtualMachineVolumeSource) {
+			allErrs = append(allErrs, field.Forbidden(f.Index(i), updatesNotAllowedWhenPowerOn))
+		}
+		delete(oldVolumeNameToSpec, vol.Name)
+	}
+
+	if len(oldVolumeNameToSpec) > 0 {
+		// Removing a volume not backed by a PVC is not allowed when VM is
+		// powered on.
+		allErrs = append(allErrs, field.Forbidden(f, updatesNotAllowedWhenPowerOn))
+	}
+
+	return allErrs
+}
+
+func (v validator) validatePlacement(
+	_ *pkgctx.WebhookRequestContext,
+	vm *vmopv1.VirtualMachine) field.ErrorList {
+
+	var allErrs field.ErrorList
+
+	if vm.Spec.Placement == nil {
+		return allErrs
+	}
+
+	f := field.NewPath("spec", "placement")
+
+	for i, c := range vm.Spec.Placement.TopologySpreadConstraints {
+		cPath := f.Child("topologySpreadConstraints").Index(i)
+		if c.LabelSelector == nil {
+			allErrs = append(allErrs, field.Required(cPath.Child("labelSelector"), ""))
+		} else {
+			allErrs = append(allErrs, metav1validation.ValidateLabelSelector(
+				c.LabelSelector, metav1validation.LabelSelectorValidationOptions{}, cPath.Child("labelSelector"))...)
+		}
+	}
+
+	if aa := vm.Spec.Placement.ZoneAntiAffinity; aa != nil {
+		aaPath := f.Child("zoneAntiAffinity")
+		if aa.LabelSelector == nil {
+			allErrs = append(allErrs, field.Required(aaPath.Child("labelSelector"), ""))
+		} else {
+			allErrs = append(allErrs, metav1validation.ValidateLabelSelector(
+				aa.LabelSelector, metav1validation.LabelSelectorValidationOptions{}, aaPath.Child("labelSelector"))...)
+		}
 	}
 
 	return allErrs
@@ -14,6 +14,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	apivalidation "k8s.io/apimachinery/pkg/api/validation"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -57,7 +58,7 @@ const (
 	vmiKind                        = "VirtualMachineImage"
 	cvmiKind                       = "Cluster" + vmiKind
 	invalidKind                    = "InvalidKind"
-	invalidImageKindMsg            = "supported: " + vmiKind + "; " + cvmiKind
+	invalidImageKindMsg            = "supported: " + vmiKind + "; " + cvmiKind + "; VirtualMachine"
 )
 
 type testParams struct {
@@ -1210,6 +1211,217 @@ func unitTestsValidateCreate() {
 					expectAllowed: true,
 				},
 			),
+			Entry("should fail when Readiness probe has TCP and HTTP GET actions",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.ReadinessProbe = &vmopv1.VirtualMachineReadinessProbeSpec{
+							TCPSocket: &vmopv1.TCPSocketAction{Port: intstr.FromInt(6443)},
+							HTTPGet:   &vmopv1.HTTPGetAction{Port: intstr.FromInt(6443)},
+						}
+						pkgcfg.SetContext(ctx, func(config *pkgcfg.Config) {
+							config.NetworkProviderType = pkgcfg.NetworkProviderTypeNSXT
+						})
+					},
+					validate: doValidateWithMsg(
+						`spec.readinessProbe: Forbidden: only one action can be specified`),
+				},
+			),
+			Entry("should deny when HTTP GET readiness probe is specified under VPC networking",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.ReadinessProbe = &vmopv1.VirtualMachineReadinessProbeSpec{
+							HTTPGet: &vmopv1.HTTPGetAction{Port: intstr.FromInt(80)},
+						}
+						pkgcfg.SetContext(ctx, func(config *pkgcfg.Config) {
+							config.NetworkProviderType = pkgcfg.NetworkProviderTypeVPC
+						})
+					},
+					validate: doValidateWithMsg(
+						`spec.readinessProbe.httpGet: Forbidden: VPC networking doesn't allow HTTP GET readiness probe to be specified`),
+				},
+			),
+			Entry("should deny when restricted network and HTTP GET port in readiness probe is not 6443",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						cm := &corev1.ConfigMap{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:      config.ProviderConfigMapName,
+								Namespace: ctx.Namespace,
+							},
+							Data: map[string]string{
+								"IsRestrictedNetwork": "true",
+							},
+						}
+						Expect(ctx.Client.Create(ctx, cm)).To(Succeed())
+
+						ctx.vm.Spec.ReadinessProbe = &vmopv1.VirtualMachineReadinessProbeSpec{
+							HTTPGet: &vmopv1.HTTPGetAction{Port: intstr.FromInt(80)},
+						}
+					},
+					validate: doValidateWithMsg(
+						`spec.readinessProbe.httpGet.port: Unsupported value: 80: supported values: "6443"`),
+				},
+			),
+			Entry("should allow a valid HTTP GET readiness probe",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						cm := &corev1.ConfigMap{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:      config.ProviderConfigMapName,
+								Namespace: ctx.Namespace,
+							},
+							Data: make(map[string]string),
+						}
+						Expect(ctx.Client.Create(ctx, cm)).To(Succeed())
+
+						ctx.vm.Spec.ReadinessProbe = &vmopv1.VirtualMachineReadinessProbeSpec{
+							HTTPGet: &vmopv1.HTTPGetAction{
+								Path:   "/healthz",
+								Port:   intstr.FromInt(8443),
+								Scheme: vmopv1.URISchemeHTTPS,
+								HTTPHeaders: []vmopv1.HTTPHeader{
+									{Name: "X-Probe", Value: "ready"},
+								},
+								MinStatusCode: 200,
+								MaxStatusCode: 299,
+							},
+						}
+					},
+					expectAllowed: true,
+				},
+			),
+			Entry("should allow an HTTP GET readiness probe with a named port",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						cm := &corev1.ConfigMap{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:      config.ProviderConfigMapName,
+								Namespace: ctx.Namespace,
+							},
+							Data: make(map[string]string),
+						}
+						Expect(ctx.Client.Create(ctx, cm)).To(Succeed())
+
+						ctx.vm.Spec.ReadinessProbe = &vmopv1.VirtualMachineReadinessProbeSpec{
+							HTTPGet: &vmopv1.HTTPGetAction{Port: intstr.FromString("http")},
+						}
+					},
+					expectAllowed: true,
+				},
+			),
+			Entry("should deny an invalid HTTP GET readiness probe",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						cm := &corev1.ConfigMap{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:      config.ProviderConfigMapName,
+								Namespace: ctx.Namespace,
+							},
+							Data: make(map[string]string),
+						}
+						Expect(ctx.Client.Create(ctx, cm)).To(Succeed())
+
+						ctx.vm.Spec.ReadinessProbe = &vmopv1.VirtualMachineReadinessProbeSpec{
+							HTTPGet: &vmopv1.HTTPGetAction{
+								Port: intstr.FromString("Not_A_Port_Name"),
+								HTTPHeaders: []vmopv1.HTTPHeader{
+									{Name: "Bad Header", Value: "ready"},
+								},
+								MinStatusCode: 400,
+								MaxStatusCode: 299,
+							},
+						}
+					},
+					validate: doValidateWithMsg(
+						`spec.readinessProbe.httpGet.port: Invalid value: "Not_A_Port_Name"`,
+						`spec.readinessProbe.httpGet.minStatusCode: Invalid value: 400: must be less than or equal to maxStatusCode`,
+						`spec.readinessProbe.httpGet.httpHeaders[0].name: Invalid value: "Bad Header"`),
+				},
+			),
+		)
+	})
+
+	Context("Liveness Probe", func() {
+
+		DescribeTable("create", doTest,
+			Entry("should fail when Liveness probe has multiple actions",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.LivenessProbe = &vmopv1.VirtualMachineLivenessProbeSpec{
+							GuestInfo: []vmopv1.GuestInfoAction{
+								{
+									Key: "my-key",
+								},
+							},
+							GuestHeartbeat: &vmopv1.GuestHeartbeatAction{},
+						}
+					},
+					validate: doValidateWithMsg(
+						`spec.livenessProbe: Forbidden: only one action can be specified`),
+				},
+			),
+			Entry("should deny when TCP liveness probe is specified under VPC networking",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.LivenessProbe = &vmopv1.VirtualMachineLivenessProbeSpec{
+							TCPSocket: &vmopv1.TCPSocketAction{Port: intstr.FromInt(22)},
+						}
+						pkgcfg.SetContext(ctx, func(config *pkgcfg.Config) {
+							config.NetworkProviderType = pkgcfg.NetworkProviderTypeVPC
+						})
+					},
+					validate: doValidateWithMsg(
+						`spec.livenessProbe.tcpSocket: Forbidden: VPC networking doesn't allow TCP liveness probe to be specified`),
+				},
+			),
+			Entry("should deny when HTTP GET liveness probe is specified under VPC networking",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.LivenessProbe = &vmopv1.VirtualMachineLivenessProbeSpec{
+							HTTPGet: &vmopv1.HTTPGetAction{Port: intstr.FromInt(80)},
+						}
+						pkgcfg.SetContext(ctx, func(config *pkgcfg.Config) {
+							config.NetworkProviderType = pkgcfg.NetworkProviderTypeVPC
+						})
+					},
+					validate: doValidateWithMsg(
+						`spec.livenessProbe.httpGet: Forbidden: VPC networking doesn't allow HTTP GET liveness probe to be specified`),
+				},
+			),
+			Entry("should deny when restricted network and TCP port in liveness probe is not 6443",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						cm := &corev1.ConfigMap{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:      config.ProviderConfigMapName,
+								Namespace: ctx.Namespace,
+							},
+							Data: map[string]string{
+								"IsRestrictedNetwork": "true",
+							},
+						}
+						Expect(ctx.Client.Create(ctx, cm)).To(Succeed())
+
+						ctx.vm.Spec.LivenessProbe = &vmopv1.VirtualMachineLivenessProbeSpec{
+							TCPSocket: &vmopv1.TCPSocketAction{Port: intstr.FromInt(22)},
+						}
+					},
+					validate: doValidateWithMsg(
+						`spec.livenessProbe.tcpSocket.port: Unsupported value: 22: supported values: "6443"`),
+				},
+			),
+			Entry("should allow a valid liveness probe",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.LivenessProbe = &vmopv1.VirtualMachineLivenessProbeSpec{
+							GuestHeartbeat:      &vmopv1.GuestHeartbeatAction{},
+							InitialDelaySeconds: 60,
+							FailureThreshold:    5,
+						}
+					},
+					expectAllowed: true,
+				},
+			),
 		)
 	})
 
@@ -1370,6 +1582,161 @@ func unitTestsValidateCreate() {
 				},
 			),
 		)
+
+		DescribeTable("Non-PVC volume sources",
+			doTest,
+			Entry("allow ephemeral, ConfigMap, and Secret volumes",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						pkgcfg.SetContext(ctx, func(config *pkgcfg.Config) {
+							config.Features.VMEphemeralVolumes = true
+						})
+						ctx.vm.Spec.Volumes = append(ctx.vm.Spec.Volumes,
+							vmopv1.VirtualMachineVolume{
+								Name: "scratch",
+								VirtualMachineVolumeSource: vmopv1.VirtualMachineVolumeSource{
+									Ephemeral: &vmopv1.EphemeralVolumeSource{
+										Size:             resource.MustParse("1Gi"),
+										ProvisioningMode: vmopv1.VirtualMachineVolumeProvisioningModeThin,
+									},
+								},
+							},
+							vmopv1.VirtualMachineVolume{
+								Name: "config",
+								VirtualMachineVolumeSource: vmopv1.VirtualMachineVolumeSource{
+									ConfigMap: &corev1.ConfigMapVolumeSource{
+										LocalObjectReference: corev1.LocalObjectReference{Name: "my-config"},
+										Items:                []corev1.KeyToPath{{Key: "key", Path: "dir/file"}},
+									},
+								},
+							},
+							vmopv1.VirtualMachineVolume{
+								Name: "secret",
+								VirtualMachineVolumeSource: vmopv1.VirtualMachineVolumeSource{
+									Secret: &corev1.SecretVolumeSource{
+										SecretName: "my-secret",
+									},
+								},
+							},
+						)
+					},
+					expectAllowed: true,
+				},
+			),
+
+			Entry("disallow ephemeral volumes when the feature is disabled",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.Volumes = append(ctx.vm.Spec.Volumes, vmopv1.VirtualMachineVolume{
+							Name: "scratch",
+							VirtualMachineVolumeSource: vmopv1.VirtualMachineVolumeSource{
+								Ephemeral: &vmopv1.EphemeralVolumeSource{
+									Size: resource.MustParse("1Gi"),
+								},
+							},
+						})
+					},
+					validate: doValidateWithMsg(
+						`spec.volumes[1].ephemeral: Forbidden: the Ephemeral Volumes feature is not enabled`,
+					),
+				},
+			),
+
+			Entry("disallow a volume with more than one source",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						pkgcfg.SetContext(ctx, func(config *pkgcfg.Config) {
+							config.Features.VMEphemeralVolumes = true
+						})
+						ctx.vm.Spec.Volumes[0].Ephemeral = &vmopv1.EphemeralVolumeSource{
+							Size: resource.MustParse("1Gi"),
+						}
+					},
+					validate: doValidateWithMsg(
+						`spec.volumes[0]: Forbidden: only one of persistentVolumeClaim, ephemeral, configMap, or secret can be specified`,
+					),
+				},
+			),
+
+			Entry("disallow an ephemeral volume without a size",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						pkgcfg.SetContext(ctx, func(config *pkgcfg.Config) {
+							config.Features.VMEphemeralVolumes = true
+						})
+						ctx.vm.Spec.Volumes = append(ctx.vm.Spec.Volumes, vmopv1.VirtualMachineVolume{
+							Name: "scratch",
+							VirtualMachineVolumeSource: vmopv1.VirtualMachineVolumeSource{
+								Ephemeral: &vmopv1.EphemeralVolumeSource{},
+							},
+						})
+					},
+					validate: doValidateWithMsg(
+						`spec.volumes[1].ephemeral.size: Invalid value: "0": must be greater than 0`,
+					),
+				},
+			),
+
+			Entry("disallow ConfigMap volumes when the feature is disabled",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.Volumes = append(ctx.vm.Spec.Volumes, vmopv1.VirtualMachineVolume{
+							Name: "config",
+							VirtualMachineVolumeSource: vmopv1.VirtualMachineVolumeSource{
+								ConfigMap: &corev1.ConfigMapVolumeSource{
+									LocalObjectReference: corev1.LocalObjectReference{Name: "my-config"},
+								},
+							},
+						})
+					},
+					validate: doValidateWithMsg(
+						`spec.volumes[1].configMap: Forbidden: the Ephemeral Volumes feature is not enabled`,
+					),
+				},
+			),
+
+			Entry("disallow a ConfigMap volume without a name and with an invalid item path",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						pkgcfg.SetContext(ctx, func(config *pkgcfg.Config) {
+							config.Features.VMEphemeralVolumes = true
+						})
+						ctx.vm.Spec.Volumes = append(ctx.vm.Spec.Volumes, vmopv1.VirtualMachineVolume{
+							Name: "config",
+							VirtualMachineVolumeSource: vmopv1.VirtualMachineVolumeSource{
+								ConfigMap: &corev1.ConfigMapVolumeSource{
+									Items: []corev1.KeyToPath{{Key: "key", Path: "../file"}},
+								},
+							},
+						})
+					},
+					validate: doValidateWithMsg(
+						`spec.volumes[1].configMap.name: Required value`,
+						`spec.volumes[1].configMap.items[0].path: Invalid value: "../file": must be a relative path that does not contain '..'`,
+					),
+				},
+			),
+
+			Entry("disallow a Secret volume without a secret name and with a long volume name",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						pkgcfg.SetContext(ctx, func(config *pkgcfg.Config) {
+							config.Features.VMEphemeralVolumes = true
+						})
+						ctx.vm.Spec.Volumes = append(ctx.vm.Spec.Volumes, vmopv1.VirtualMachineVolume{
+							Name: "my-very-long-secret",
+							VirtualMachineVolumeSource: vmopv1.VirtualMachineVolumeSource{
+								Secret: &corev1.SecretVolumeSource{},
+							},
+						})
+					},
+					validate: doValidateWithMsg(
+						`spec.volumes[1].name: Too long: must have at most 16 bytes`,
+						`spec.volumes[1].secret.secretName: Required value`,
+					),
+				},
+			),
+		)
 	})
 
 	Context("Bootstrap", func() {
@@ -1650,7 +2017,138 @@ func unitTestsValidateCreate() {
 				},
 			),
 
-			Entry("disallow inline sysPrep autoLogon with missing autoLogonCount and password",
+			Entry("allow Ignition bootstrap with rawConfig",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.Bootstrap = &vmopv1.VirtualMachineBootstrapSpec{
+							Ignition: &vmopv1.VirtualMachineBootstrapIgnitionSpec{
+								RawConfig: &common.SecretKeySelector{
+									Name: "ignition-secret",
+									Key:  "config.ign",
+								},
+							},
+						}
+					},
+					expectAllowed: true,
+				},
+			),
+			Entry("allow Ignition bootstrap with config",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.Bootstrap = &vmopv1.VirtualMachineBootstrapSpec{
+							Ignition: &vmopv1.VirtualMachineBootstrapIgnitionSpec{
+								Config: &vmopv1.VirtualMachineBootstrapIgnitionConfig{
+									Users: []vmopv1.VirtualMachineBootstrapIgnitionUser{
+										{
+											Name: "core",
+										},
+									},
+									Files: []vmopv1.VirtualMachineBootstrapIgnitionFile{
+										{
+											Path: "/etc/motd",
+										},
+									},
+									SystemdUnits: []vmopv1.VirtualMachineBootstrapIgnitionSystemdUnit{
+										{
+											Name: "hello.service",
+										},
+									},
+								},
+							},
+						}
+					},
+					expectAllowed: true,
+				},
+			),
+			Entry("disallow empty Ignition bootstrap",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.Bootstrap = &vmopv1.VirtualMachineBootstrapSpec{
+							Ignition: &vmopv1.VirtualMachineBootstrapIgnitionSpec{},
+						}
+					},
+					validate: doValidateWithMsg(
+						`spec.bootstrap.ignition: Invalid value: "ignition": either config or rawConfig must be provided`,
+					),
+				},
+			),
+			Entry("disallow Ignition mixing config and rawConfig",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.Bootstrap = &vmopv1.VirtualMachineBootstrapSpec{
+							Ignition: &vmopv1.VirtualMachineBootstrapIgnitionSpec{
+								Config: &vmopv1.VirtualMachineBootstrapIgnitionConfig{},
+								RawConfig: &common.SecretKeySelector{
+									Name: "ignition-secret",
+									Key:  "config.ign",
+								},
+							},
+						}
+					},
+					validate: doValidateWithMsg(
+						`spec.bootstrap.ignition: Invalid value: "ignition": config and rawConfig are mutually exclusive`,
+					),
+				},
+			),
+			Entry("disallow Ignition and LinuxPrep",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.Bootstrap = &vmopv1.VirtualMachineBootstrapSpec{
+							LinuxPrep: &vmopv1.VirtualMachineBootstrapLinuxPrepSpec{},
+							Ignition: &vmopv1.VirtualMachineBootstrapIgnitionSpec{
+								Config: &vmopv1.VirtualMachineBootstrapIgnitionConfig{},
+							},
+						}
+					},
+					validate: doValidateWithMsg(
+						`spec.bootstrap.ignition: Forbidden: Ignition may not be used with any other bootstrap provider`,
+					),
+				},
+			),
+			Entry("disallow Ignition and CloudInit",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.Bootstrap = &vmopv1.VirtualMachineBootstrapSpec{
+							CloudInit: &vmopv1.VirtualMachineBootstrapCloudInitSpec{},
+							Ignition: &vmopv1.VirtualMachineBootstrapIgnitionSpec{
+								Config: &vmopv1.VirtualMachineBootstrapIgnitionConfig{},
+							},
+						}
+					},
+					validate: doValidateWithMsg(
+						`spec.bootstrap.cloudInit: Forbidden: CloudInit may not be used with any other bootstrap provider`,
+						`spec.bootstrap.ignition: Forbidden: Ignition may not be used with any other bootstrap provider`,
+					),
+				},
+			),
+			Entry("disallow inline Ignition with relative file path and invalid unit name",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.Bootstrap = &vmopv1.VirtualMachineBootstrapSpec{
+							Ignition: &vmopv1.VirtualMachineBootstrapIgnitionSpec{
+								Config: &vmopv1.VirtualMachineBootstrapIgnitionConfig{
+									Files: []vmopv1.VirtualMachineBootstrapIgnitionFile{
+										{
+											Path: "etc/motd",
+										},
+									},
+									SystemdUnits: []vmopv1.VirtualMachineBootstrapIgnitionSystemdUnit{
+										{
+											Name: "hello",
+										},
+									},
+								},
+							},
+						}
+					},
+					validate: doValidateWithMsg(
+						`spec.bootstrap.ignition.config.files[0].path: Invalid value: "etc/motd": must be an absolute path`,
+						`spec.bootstrap.ignition.config.systemdUnits[0].name: Invalid value: "hello": must be a systemd unit name with a valid unit type suffix, ex. example.service`,
+					),
+				},
+			),
+
+			Entry("disallow inline sysPrep autoLogon with missing autoLogonCount and password",
 				testParams{
 					setup: func(ctx *unitValidatingWebhookContext) {
 						ctx.vm.Spec.Bootstrap = &vmopv1.VirtualMachineBootstrapSpec{
@@ -2484,159 +2982,691 @@ func unitTestsValidateCreate() {
 						}
 					},
 					validate: doValidateWithMsg(
-						fmt.Sprintf(`spec.network: Invalid value: "%[1]s.%[1]s.%[1]s.%[1]s.com": %s`, strings.Repeat("a", 63), vmopv1util.ErrInvalidHostAndDomainName),
+						fmt.Sprintf(`spec.network: Invalid value: "%[1]s.%[1]s.%[1]s.%[1]s.com": %s`, strings.Repeat("a", 63), vmopv1util.ErrInvalidHostAndDomainName),
+					),
+				},
+			),
+		)
+	})
+
+	Context("HardwareVersion", func() {
+
+		DescribeTable("MinHardwareVersion", doTest,
+			Entry("disallow greater than max valid hardware version",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.MinHardwareVersion = 22
+						ctx.vm.Spec.PowerState = vmopv1.VirtualMachinePowerStateOff
+					},
+					validate: doValidateWithMsg(
+						`spec.minHardwareVersion: Invalid value: 22: should be less than or equal to 21`,
+					),
+					expectAllowed: false,
+				},
+			),
+		)
+	})
+
+	Context("CD-ROM", func() {
+
+		DescribeTable("CD-ROM create", doTest,
+
+			Entry("allow creating a VM with empty CD-ROM",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.Cdrom = []vmopv1.VirtualMachineCdromSpec{}
+					},
+					expectAllowed: true,
+				},
+			),
+
+			Entry("disallow creating a VM with CD-ROM when FSS is not enabled",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						pkgcfg.SetContext(ctx, func(config *pkgcfg.Config) {
+							config.Features.IsoSupport = false
+						})
+						ctx.vm.Spec.Cdrom = []vmopv1.VirtualMachineCdromSpec{
+							{
+								Name: "cdrom",
+							},
+						}
+					},
+					validate: doValidateWithMsg(
+						`spec.cdrom: Forbidden: the CD-ROM (ISO) support feature is not enabled`,
+					),
+					expectAllowed: false,
+				},
+			),
+
+			Entry("disallow creating a VM with CD-ROM and empty guest ID",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.GuestID = ""
+					},
+					validate: doValidateWithMsg(
+						`spec.guestID: Required value: when deploying a VM with CD-ROMs`,
+					),
+					expectAllowed: false,
+				},
+			),
+
+			Entry("disallow creating a VM with invalid CD-ROM image ref kind",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.Cdrom = []vmopv1.VirtualMachineCdromSpec{
+							{
+								Name: "cdromInvalidImgKind",
+								Image: &vmopv1.VirtualMachineImageRef{
+									Name: dummyVmiName,
+									Kind: "InvalidKind",
+								},
+							},
+						}
+					},
+					validate: doValidateWithMsg(
+						`spec.cdrom[0].image.kind: Unsupported value: "InvalidKind": supported values: "VirtualMachineImage"`,
+						`"ClusterVirtualMachineImage"`,
+					),
+					expectAllowed: false,
+				},
+			),
+
+			Entry("disallow creating a VM with duplicate CD-ROM image ref from VMI kind",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.Cdrom = []vmopv1.VirtualMachineCdromSpec{
+							{
+								Name: "cdromDupVmi",
+								Image: &vmopv1.VirtualMachineImageRef{
+									Name: dummyVmiName,
+									Kind: vmiKind,
+								},
+							},
+						}
+						ctx.vm.Spec.Cdrom = append(ctx.vm.Spec.Cdrom, ctx.vm.Spec.Cdrom[0])
+					},
+					validate: doValidateWithMsg(
+						`spec.cdrom[1].image.name: Duplicate value: "vmi-dummy"`,
+					),
+					expectAllowed: false,
+				},
+			),
+
+			Entry("disallow creating a VM with duplicate CD-ROM image ref from CVMI kind",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.Cdrom = []vmopv1.VirtualMachineCdromSpec{
+							{
+								Name: "cdromDupCvmi",
+								Image: &vmopv1.VirtualMachineImageRef{
+									Name: dummyVmiName,
+									Kind: cvmiKind,
+								},
+							},
+						}
+						ctx.vm.Spec.Cdrom = append(ctx.vm.Spec.Cdrom, ctx.vm.Spec.Cdrom[0])
+					},
+					validate: doValidateWithMsg(
+						`spec.cdrom[1].image.name: Duplicate value: "vmi-dummy"`),
+					expectAllowed: false,
+				},
+			),
+
+			Entry("disallow creating a VM with duplicate CD-ROM image ref from VMI and CVMI kinds",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.Cdrom = []vmopv1.VirtualMachineCdromSpec{
+							{
+								Name: "cdromDupVmi",
+								Image: &vmopv1.VirtualMachineImageRef{
+									Name: dummyVmiName,
+									Kind: vmiKind,
+								},
+							},
+							{
+								Name: "cdromDupCvmi",
+								Image: &vmopv1.VirtualMachineImageRef{
+									Name: dummyVmiName,
+									Kind: cvmiKind,
+								},
+							},
+						}
+					},
+					validate: doValidateWithMsg(
+						`spec.cdrom[1].image.name: Duplicate value: "vmi-dummy"`),
+					expectAllowed: false,
+				},
+			),
+
+			Entry("allow creating a VM with CD-ROMs backed by a PVC and config drive",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.Cdrom = append(ctx.vm.Spec.Cdrom,
+							vmopv1.VirtualMachineCdromSpec{
+								Name: "cdrompvc",
+								PersistentVolumeClaim: &vmopv1.VirtualMachineCdromPersistentVolumeClaimSource{
+									ClaimName: "iso-pvc",
+								},
+							},
+							vmopv1.VirtualMachineCdromSpec{
+								Name: "cdromconfig",
+								ConfigDrive: &vmopv1.VirtualMachineCdromConfigDriveSource{
+									Sources: []vmopv1.VirtualMachineCdromConfigDriveProjection{
+										{
+											ConfigMap: &corev1.ConfigMapProjection{
+												LocalObjectReference: corev1.LocalObjectReference{Name: "my-config"},
+												Items: []corev1.KeyToPath{
+													{Key: "meta_data.json", Path: "openstack/latest/meta_data.json"},
+												},
+											},
+										},
+										{
+											Secret: &corev1.SecretProjection{
+												LocalObjectReference: corev1.LocalObjectReference{Name: "my-secret"},
+												Items: []corev1.KeyToPath{
+													{Key: "password", Path: "password"},
+												},
+											},
+										},
+									},
+								},
+							},
+						)
+					},
+					expectAllowed: true,
+				},
+			),
+
+			Entry("allow creating a VM with only a config drive CD-ROM and empty guest ID",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.GuestID = ""
+						ctx.vm.Spec.Cdrom = []vmopv1.VirtualMachineCdromSpec{
+							{
+								Name: "cdromconfig",
+								ConfigDrive: &vmopv1.VirtualMachineCdromConfigDriveSource{
+									Sources: []vmopv1.VirtualMachineCdromConfigDriveProjection{
+										{
+											ConfigMap: &corev1.ConfigMapProjection{
+												LocalObjectReference: corev1.LocalObjectReference{Name: "my-config"},
+											},
+										},
+									},
+								},
+							},
+						}
+					},
+					expectAllowed: true,
+				},
+			),
+
+			Entry("disallow creating a VM with CD-ROM without a backing",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.Cdrom = []vmopv1.VirtualMachineCdromSpec{
+							{
+								Name: "cdromnobacking",
+							},
+						}
+					},
+					validate: doValidateWithMsg(
+						`spec.cdrom[0]: Required value: only one of image, persistentVolumeClaim, or configDrive can be specified`,
+					),
+					expectAllowed: false,
+				},
+			),
+
+			Entry("disallow creating a VM with CD-ROM with multiple backings",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.Cdrom[0].PersistentVolumeClaim = &vmopv1.VirtualMachineCdromPersistentVolumeClaimSource{
+							ClaimName: "iso-pvc",
+						}
+					},
+					validate: doValidateWithMsg(
+						`spec.cdrom[0]: Forbidden: only one of image, persistentVolumeClaim, or configDrive can be specified`,
+					),
+					expectAllowed: false,
+				},
+			),
+
+			Entry("disallow creating a VM with duplicate CD-ROM PVC",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.Cdrom = []vmopv1.VirtualMachineCdromSpec{
+							{
+								Name: "cdrompvc1",
+								PersistentVolumeClaim: &vmopv1.VirtualMachineCdromPersistentVolumeClaimSource{
+									ClaimName: "iso-pvc",
+								},
+							},
+							{
+								Name: "cdrompvc2",
+								PersistentVolumeClaim: &vmopv1.VirtualMachineCdromPersistentVolumeClaimSource{
+									ClaimName: "iso-pvc",
+								},
+							},
+						}
+					},
+					validate: doValidateWithMsg(
+						`spec.cdrom[1].persistentVolumeClaim.claimName: Duplicate value: "iso-pvc"`,
+					),
+					expectAllowed: false,
+				},
+			),
+
+			Entry("disallow creating a VM with CD-ROM PVC that is also a volume",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.Volumes = []vmopv1.VirtualMachineVolume{
+							{
+								Name: "iso-vol",
+								VirtualMachineVolumeSource: vmopv1.VirtualMachineVolumeSource{
+									PersistentVolumeClaim: &vmopv1.PersistentVolumeClaimVolumeSource{
+										PersistentVolumeClaimVolumeSource: corev1.PersistentVolumeClaimVolumeSource{
+											ClaimName: "iso-pvc",
+										},
+									},
+								},
+							},
+						}
+						ctx.vm.Spec.Cdrom = []vmopv1.VirtualMachineCdromSpec{
+							{
+								Name: "cdrompvc",
+								PersistentVolumeClaim: &vmopv1.VirtualMachineCdromPersistentVolumeClaimSource{
+									ClaimName: "iso-pvc",
+								},
+							},
+						}
+					},
+					validate: doValidateWithMsg(
+						`spec.cdrom[0].persistentVolumeClaim.claimName: Invalid value: "iso-pvc": claim is also specified in spec.volumes`,
+					),
+					expectAllowed: false,
+				},
+			),
+
+			Entry("allow creating a VM with CD-ROM PVC that is a block volume",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						Expect(ctx.Client.Create(ctx, &corev1.PersistentVolumeClaim{
+							ObjectMeta: metav1.ObjectMeta{Name: "iso-pvc", Namespace: ctx.vm.Namespace},
+							Spec: corev1.PersistentVolumeClaimSpec{
+								VolumeMode: ptr.To(corev1.PersistentVolumeBlock),
+							},
+						})).To(Succeed())
+						ctx.vm.Spec.Cdrom = []vmopv1.VirtualMachineCdromSpec{
+							{
+								Name: "cdrompvc",
+								PersistentVolumeClaim: &vmopv1.VirtualMachineCdromPersistentVolumeClaimSource{
+									ClaimName: "iso-pvc",
+								},
+							},
+						}
+					},
+					expectAllowed: true,
+				},
+			),
+
+			Entry("disallow creating a VM with CD-ROM PVC that is a filesystem volume",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						Expect(ctx.Client.Create(ctx, &corev1.PersistentVolumeClaim{
+							ObjectMeta: metav1.ObjectMeta{Name: "iso-pvc", Namespace: ctx.vm.Namespace},
+							Spec: corev1.PersistentVolumeClaimSpec{
+								VolumeMode: ptr.To(corev1.PersistentVolumeFilesystem),
+							},
+						})).To(Succeed())
+						ctx.vm.Spec.Cdrom = []vmopv1.VirtualMachineCdromSpec{
+							{
+								Name: "cdrompvc",
+								PersistentVolumeClaim: &vmopv1.VirtualMachineCdromPersistentVolumeClaimSource{
+									ClaimName: "iso-pvc",
+								},
+							},
+						}
+					},
+					validate: doValidateWithMsg(
+						`spec.cdrom[0].persistentVolumeClaim.claimName: Invalid value: "iso-pvc": claim must have volumeMode Block`,
+					),
+					expectAllowed: false,
+				},
+			),
+
+			Entry("disallow creating a VM with config drive source without a ConfigMap or Secret",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.Cdrom = []vmopv1.VirtualMachineCdromSpec{
+							{
+								Name: "cdromconfig",
+								ConfigDrive: &vmopv1.VirtualMachineCdromConfigDriveSource{
+									Sources: []vmopv1.VirtualMachineCdromConfigDriveProjection{
+										{},
+										{
+											ConfigMap: &corev1.ConfigMapProjection{
+												LocalObjectReference: corev1.LocalObjectReference{Name: "my-config"},
+											},
+											Secret: &corev1.SecretProjection{
+												LocalObjectReference: corev1.LocalObjectReference{Name: "my-secret"},
+											},
+										},
+									},
+								},
+							},
+						}
+					},
+					validate: doValidateWithMsg(
+						`spec.cdrom[0].configDrive.sources[0]: Required value: only one of configMap or secret can be specified`,
+						`spec.cdrom[0].configDrive.sources[1]: Forbidden: only one of configMap or secret can be specified`,
+					),
+					expectAllowed: false,
+				},
+			),
+
+			Entry("disallow creating a VM with config drive Secret source without items",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.Cdrom = []vmopv1.VirtualMachineCdromSpec{
+							{
+								Name: "cdromconfig",
+								ConfigDrive: &vmopv1.VirtualMachineCdromConfigDriveSource{
+									Sources: []vmopv1.VirtualMachineCdromConfigDriveProjection{
+										{
+											Secret: &corev1.SecretProjection{
+												LocalObjectReference: corev1.LocalObjectReference{Name: "my-secret"},
+											},
+										},
+									},
+								},
+							},
+						}
+					},
+					validate: doValidateWithMsg(
+						`spec.cdrom[0].configDrive.sources[0].secret.items: Required value: the keys of a Secret written to a config drive must be specified`,
+					),
+					expectAllowed: false,
+				},
+			),
+
+			Entry("disallow creating a VM with config drive item with invalid path",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.Cdrom = []vmopv1.VirtualMachineCdromSpec{
+							{
+								Name: "cdromconfig",
+								ConfigDrive: &vmopv1.VirtualMachineCdromConfigDriveSource{
+									Sources: []vmopv1.VirtualMachineCdromConfigDriveProjection{
+										{
+											Secret: &corev1.SecretProjection{
+												LocalObjectReference: corev1.LocalObjectReference{Name: "my-secret"},
+												Items: []corev1.KeyToPath{
+													{Key: "a", Path: "/user-data"},
+													{Key: "b", Path: "../user-data"},
+												},
+											},
+										},
+									},
+								},
+							},
+						}
+					},
+					validate: doValidateWithMsg(
+						`spec.cdrom[0].configDrive.sources[0].secret.items[0].path: Invalid value: "/user-data": must be a relative path that does not contain '..'`,
+						`spec.cdrom[0].configDrive.sources[0].secret.items[1].path: Invalid value: "../user-data": must be a relative path that does not contain '..'`,
+					),
+					expectAllowed: false,
+				},
+			),
+		)
+	})
+
+	Context("Placement", func() {
+
+		DescribeTable("placement create", doTest,
+			Entry("allow creating a VM with a valid placement policy",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.Placement = &vmopv1.VirtualMachinePlacementSpec{
+							TopologySpreadConstraints: []vmopv1.VirtualMachineTopologySpreadConstraint{
+								{
+									MaxSkew: 1,
+									LabelSelector: &metav1.LabelSelector{
+										MatchLabels: map[string]string{"app": "db"},
+									},
+								},
+							},
+							ZoneAntiAffinity: &vmopv1.VirtualMachineZoneAntiAffinity{
+								LabelSelector: &metav1.LabelSelector{
+									MatchLabels: map[string]string{"app": "db"},
+								},
+							},
+							PreferredZones: []string{"zone-a"},
+						}
+					},
+					expectAllowed: true,
+				},
+			),
+
+			Entry("disallow creating a VM with a topology spread constraint without a label selector",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.Placement = &vmopv1.VirtualMachinePlacementSpec{
+							TopologySpreadConstraints: []vmopv1.VirtualMachineTopologySpreadConstraint{
+								{
+									MaxSkew: 1,
+								},
+							},
+						}
+					},
+					validate: doValidateWithMsg(
+						`spec.placement.topologySpreadConstraints[0].labelSelector: Required value`,
+					),
+					expectAllowed: false,
+				},
+			),
+
+			Entry("disallow creating a VM with an invalid zone anti-affinity label selector",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.Placement = &vmopv1.VirtualMachinePlacementSpec{
+							ZoneAntiAffinity: &vmopv1.VirtualMachineZoneAntiAffinity{
+								LabelSelector: &metav1.LabelSelector{
+									MatchLabels: map[string]string{"-invalid": "db"},
+								},
+							},
+						}
+					},
+					validate: doValidateWithMsg(
+						`spec.placement.zoneAntiAffinity.labelSelector.matchLabels: Invalid value: "-invalid"`,
 					),
+					expectAllowed: false,
 				},
 			),
 		)
 	})
 
-	Context("HardwareVersion", func() {
+	Context("PowerSchedule", func() {
 
-		DescribeTable("MinHardwareVersion", doTest,
-			Entry("disallow greater than max valid hardware version",
+		DescribeTable("power schedule create", doTest,
+			Entry("allow creating a VM with a valid power schedule",
 				testParams{
 					setup: func(ctx *unitValidatingWebhookContext) {
-						ctx.vm.Spec.MinHardwareVersion = 22
-						ctx.vm.Spec.PowerState = vmopv1.VirtualMachinePowerStateOff
+						ctx.vm.Spec.PowerSchedule = &vmopv1.VirtualMachinePowerScheduleSpec{
+							TimeZone: "America/Los_Angeles",
+							PowerOn:  "0 8 * * MON-FRI",
+							PowerOff: "0 18 * * MON-FRI",
+							Suspend:  "@daily",
+						}
 					},
-					validate: doValidateWithMsg(
-						`spec.minHardwareVersion: Invalid value: 22: should be less than or equal to 21`,
-					),
-					expectAllowed: false,
+					expectAllowed: true,
 				},
 			),
-		)
-	})
-
-	Context("CD-ROM", func() {
-
-		DescribeTable("CD-ROM create", doTest,
 
-			Entry("allow creating a VM with empty CD-ROM",
+			Entry("allow creating a VM with a power schedule without a time zone",
 				testParams{
 					setup: func(ctx *unitValidatingWebhookContext) {
-						ctx.vm.Spec.Cdrom = []vmopv1.VirtualMachineCdromSpec{}
+						ctx.vm.Spec.PowerSchedule = &vmopv1.VirtualMachinePowerScheduleSpec{
+							PowerOff: "*/30 * * * *",
+						}
 					},
 					expectAllowed: true,
 				},
 			),
 
-			Entry("disallow creating a VM with CD-ROM when FSS is not enabled",
+			Entry("disallow creating a VM with an unknown power schedule time zone",
 				testParams{
 					setup: func(ctx *unitValidatingWebhookContext) {
-						pkgcfg.SetContext(ctx, func(config *pkgcfg.Config) {
-							config.Features.IsoSupport = false
-						})
-						ctx.vm.Spec.Cdrom = []vmopv1.VirtualMachineCdromSpec{
-							{
-								Name: "cdrom",
-							},
+						ctx.vm.Spec.PowerSchedule = &vmopv1.VirtualMachinePowerScheduleSpec{
+							TimeZone: "Mars/Olympus_Mons",
+							PowerOff: "0 18 * * *",
 						}
 					},
 					validate: doValidateWithMsg(
-						`spec.cdrom: Forbidden: the CD-ROM (ISO) support feature is not enabled`,
+						`spec.powerSchedule.timeZone: Invalid value: "Mars/Olympus_Mons"`,
 					),
 					expectAllowed: false,
 				},
 			),
 
-			Entry("disallow creating a VM with CD-ROM and empty guest ID",
+			Entry("disallow creating a VM with the Local power schedule time zone",
 				testParams{
 					setup: func(ctx *unitValidatingWebhookContext) {
-						ctx.vm.Spec.GuestID = ""
+						ctx.vm.Spec.PowerSchedule = &vmopv1.VirtualMachinePowerScheduleSpec{
+							TimeZone: "Local",
+							PowerOff: "0 18 * * *",
+						}
 					},
 					validate: doValidateWithMsg(
-						`spec.guestID: Required value: when deploying a VM with CD-ROMs`,
+						`spec.powerSchedule.timeZone: Invalid value: "Local": unknown time zone Local`,
 					),
 					expectAllowed: false,
 				},
 			),
 
-			Entry("disallow creating a VM with invalid CD-ROM image ref kind",
+			Entry("disallow creating a VM with invalid power schedule expressions",
 				testParams{
 					setup: func(ctx *unitValidatingWebhookContext) {
-						ctx.vm.Spec.Cdrom = []vmopv1.VirtualMachineCdromSpec{
-							{
-								Name: "cdromInvalidImgKind",
-								Image: vmopv1.VirtualMachineImageRef{
-									Name: dummyVmiName,
-									Kind: "InvalidKind",
-								},
-							},
+						ctx.vm.Spec.PowerSchedule = &vmopv1.VirtualMachinePowerScheduleSpec{
+							PowerOn:  "0 8 * *",
+							PowerOff: "0 24 * * *",
+							Suspend:  "@sometimes",
 						}
 					},
 					validate: doValidateWithMsg(
-						`spec.cdrom[0].image.kind: Unsupported value: "InvalidKind": supported values: "VirtualMachineImage"`,
-						`"ClusterVirtualMachineImage"`,
+						`spec.powerSchedule.powerOn: Invalid value: "0 8 * *": expected 5 fields but found 4`,
+						`spec.powerSchedule.powerOff: Invalid value: "0 24 * * *": hour value 24 is not between 0 and 23`,
+						`spec.powerSchedule.suspend: Invalid value: "@sometimes": unknown descriptor`,
 					),
 					expectAllowed: false,
 				},
 			),
+		)
+	})
 
-			Entry("disallow creating a VM with duplicate CD-ROM image ref from VMI kind",
+	Context("Clone", func() {
+
+		DescribeTable("clone create", doTest,
+			Entry("allow cloning a VM from another VM",
 				testParams{
 					setup: func(ctx *unitValidatingWebhookContext) {
-						ctx.vm.Spec.Cdrom = []vmopv1.VirtualMachineCdromSpec{
+						srcVM := builder.DummyVirtualMachine()
+						srcVM.Name = "source-vm"
+						srcVM.Namespace = ctx.vm.Namespace
+						Expect(ctx.Client.Create(ctx, srcVM)).To(Succeed())
+
+						ctx.vm.Spec.ImageName = ""
+						ctx.vm.Spec.Image = &vmopv1.VirtualMachineImageRef{
+							Kind: "VirtualMachine",
+							Name: srcVM.Name,
+						}
+						ctx.vm.Spec.Clone = &vmopv1.VirtualMachineCloneSpec{
+							Mode:         vmopv1.VirtualMachineCloneModeLinked,
+							CloneVolumes: true,
+						}
+					},
+					expectAllowed: true,
+				},
+			),
+
+			Entry("disallow cloning the volumes of a VM when a cloned PVC name is too long",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						srcVM := builder.DummyVirtualMachine()
+						srcVM.Name = "source-vm"
+						srcVM.Namespace = ctx.vm.Namespace
+						srcVM.Spec.Volumes = []vmopv1.VirtualMachineVolume{
 							{
-								Name: "cdromDupVmi",
-								Image: vmopv1.VirtualMachineImageRef{
-									Name: dummyVmiName,
-									Kind: vmiKind,
+								Name: "my-disk",
+								VirtualMachineVolumeSource: vmopv1.VirtualMachineVolumeSource{
+									PersistentVolumeClaim: &vmopv1.PersistentVolumeClaimVolumeSource{
+										PersistentVolumeClaimVolumeSource: corev1.PersistentVolumeClaimVolumeSource{
+											ClaimName: "my-pvc",
+										},
+									},
 								},
 							},
 						}
-						ctx.vm.Spec.Cdrom = append(ctx.vm.Spec.Cdrom, ctx.vm.Spec.Cdrom[0])
+						Expect(ctx.Client.Create(ctx, srcVM)).To(Succeed())
+
+						ctx.vm.Name = strings.Repeat("a", 250)
+						ctx.vm.Spec.ImageName = ""
+						ctx.vm.Spec.Image = &vmopv1.VirtualMachineImageRef{
+							Kind: "VirtualMachine",
+							Name: srcVM.Name,
+						}
+						ctx.vm.Spec.Clone = &vmopv1.VirtualMachineCloneSpec{
+							Mode:         vmopv1.VirtualMachineCloneModeFull,
+							CloneVolumes: true,
+						}
 					},
 					validate: doValidateWithMsg(
-						`spec.cdrom[1].image.name: Duplicate value: "vmi-dummy"`,
+						fmt.Sprintf(`spec.clone.cloneVolumes: Invalid value: true: name "%s-my-disk" of the PVC cloned from volume "my-disk" must be no more than 253 characters`, strings.Repeat("a", 250)),
 					),
 					expectAllowed: false,
 				},
 			),
 
-			Entry("disallow creating a VM with duplicate CD-ROM image ref from CVMI kind",
+			Entry("disallow cloning a VM from itself",
 				testParams{
 					setup: func(ctx *unitValidatingWebhookContext) {
-						ctx.vm.Spec.Cdrom = []vmopv1.VirtualMachineCdromSpec{
-							{
-								Name: "cdromDupCvmi",
-								Image: vmopv1.VirtualMachineImageRef{
-									Name: dummyVmiName,
-									Kind: cvmiKind,
-								},
-							},
+						ctx.vm.Spec.ImageName = ""
+						ctx.vm.Spec.Image = &vmopv1.VirtualMachineImageRef{
+							Kind: "VirtualMachine",
+							Name: ctx.vm.Name,
 						}
-						ctx.vm.Spec.Cdrom = append(ctx.vm.Spec.Cdrom, ctx.vm.Spec.Cdrom[0])
 					},
 					validate: doValidateWithMsg(
-						`spec.cdrom[1].image.name: Duplicate value: "vmi-dummy"`),
+						`spec.image.name: Invalid value: "dummy-vm": cannot clone a VM from itself`,
+					),
 					expectAllowed: false,
 				},
 			),
 
-			Entry("disallow creating a VM with duplicate CD-ROM image ref from VMI and CVMI kinds",
+			Entry("disallow cloning a VM from a VM that does not exist",
 				testParams{
 					setup: func(ctx *unitValidatingWebhookContext) {
-						ctx.vm.Spec.Cdrom = []vmopv1.VirtualMachineCdromSpec{
-							{
-								Name: "cdromDupVmi",
-								Image: vmopv1.VirtualMachineImageRef{
-									Name: dummyVmiName,
-									Kind: vmiKind,
-								},
-							},
-							{
-								Name: "cdromDupCvmi",
-								Image: vmopv1.VirtualMachineImageRef{
-									Name: dummyVmiName,
-									Kind: cvmiKind,
-								},
-							},
+						ctx.vm.Spec.ImageName = ""
+						ctx.vm.Spec.Image = &vmopv1.VirtualMachineImageRef{
+							Kind: "VirtualMachine",
+							Name: "missing-vm",
 						}
 					},
 					validate: doValidateWithMsg(
-						`spec.cdrom[1].image.name: Duplicate value: "vmi-dummy"`),
+						`spec.image.name: Not found: "missing-vm"`,
+					),
+					expectAllowed: false,
+				},
+			),
+
+			Entry("disallow spec.clone when the image is not a VM",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.Clone = &vmopv1.VirtualMachineCloneSpec{}
+					},
+					validate: doValidateWithMsg(
+						`spec.clone: Forbidden: may only be set when spec.image.kind is VirtualMachine`,
+					),
 					expectAllowed: false,
 				},
 			),
@@ -2787,7 +3817,8 @@ func unitTestsValidateUpdate() {
 		Entry("should deny image name change", updateArgs{changeImageName: true}, false, msg, nil),
 		Entry("should deny instance uuid change", updateArgs{changeInstanceUUID: true, oldInstanceUUID: "uuid"}, false, msg, nil),
 		Entry("should deny bios uuid change", updateArgs{changeBiosUUID: true, oldBiosUUID: "uuid"}, false, msg, nil),
-		Entry("should deny storageClass change", updateArgs{changeStorageClass: true}, false, msg, nil),
+		Entry("should deny storageClass change to storage class that does not exist", updateArgs{changeStorageClass: true}, false,
+			"Storage policy "+updateSuffix+" does not exist", nil),
 		Entry("should deny resourcePolicy change", updateArgs{changeResourcePolicy: true}, false, msg, nil),
 
 		Entry("should allow empty instance uuid change", updateArgs{changeInstanceUUID: true}, true, nil, nil),
@@ -3342,6 +4373,58 @@ func unitTestsValidateUpdate() {
 		)
 	})
 
+	Context("StorageClass", func() {
+
+		DescribeTable("StorageClass update", doTest,
+			Entry("should allow changing to storage class associated with namespace",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						storageClass := builder.DummyStorageClass()
+						storageClass.Name += updateSuffix
+						Expect(ctx.Client.Create(ctx, storageClass)).To(Succeed())
+						ctx.vm.Spec.StorageClass = storageClass.Name
+
+						rlName := storageClass.Name + ".storageclass.storage.k8s.io/persistentvolumeclaims"
+						resourceQuota := builder.DummyResourceQuota(ctx.vm.Namespace, rlName)
+						Expect(ctx.Client.Create(ctx, resourceQuota)).To(Succeed())
+					},
+					expectAllowed: true,
+				},
+			),
+			Entry("should disallow changing to storage class not associated with namespace",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						storageClass := builder.DummyStorageClass()
+						storageClass.Name += updateSuffix
+						Expect(ctx.Client.Create(ctx, storageClass)).To(Succeed())
+						ctx.vm.Spec.StorageClass = storageClass.Name
+					},
+					validate: doValidateWithMsg(
+						`spec.storageClass: Invalid value: "dummy-storage-class-updated": Storage policy is not associated with the namespace dummy-vm-namespace-for-webhook-validation`),
+				},
+			),
+			Entry("should disallow removing storage class",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.oldVM.Spec.StorageClass = builder.DummyStorageClassName
+						ctx.vm.Spec.StorageClass = ""
+					},
+					validate: doValidateWithMsg(
+						`spec.storageClass: Forbidden: cannot be removed once set`),
+				},
+			),
+			Entry("should allow unchanged storage class that does not exist",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.oldVM.Spec.StorageClass = builder.DummyStorageClassName
+						ctx.vm.Spec.StorageClass = builder.DummyStorageClassName
+					},
+					expectAllowed: true,
+				},
+			),
+		)
+	})
+
 	Context("Network", func() {
 
 		DescribeTable("update network", doTest,
@@ -3365,29 +4448,151 @@ func unitTestsValidateUpdate() {
 				},
 			),
 
-			Entry("disallow changing network interface network",
+			Entry("disallow changing network interface network",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.oldVM.Spec.Network = &vmopv1.VirtualMachineNetworkSpec{
+							Interfaces: []vmopv1.VirtualMachineNetworkInterfaceSpec{
+								{
+									Name:    "eth0",
+									Network: &common.PartialObjectRef{Name: "my-network"},
+								},
+							},
+						}
+
+						ctx.vm = ctx.oldVM.DeepCopy()
+						ctx.vm.Spec.Network.Interfaces[0].Network.Name = "my-other-network"
+					},
+					validate: doValidateWithMsg(
+						`spec.network.interfaces[0].network: Forbidden: field is immutable`),
+				},
+			),
+
+			Entry("disallow changing number of network interfaces",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.oldVM.Spec.Network = &vmopv1.VirtualMachineNetworkSpec{
+							Interfaces: []vmopv1.VirtualMachineNetworkInterfaceSpec{
+								{
+									Name: "eth0",
+								},
+							},
+						}
+
+						ctx.vm = ctx.oldVM.DeepCopy()
+						ctx.vm.Spec.Network.Interfaces = append(ctx.vm.Spec.Network.Interfaces,
+							vmopv1.VirtualMachineNetworkInterfaceSpec{Name: "eth1"})
+
+					},
+					validate: doValidateWithMsg(
+						`spec.network.interfaces: Forbidden: network interfaces cannot be added or removed`),
+				},
+			),
+
+			Entry("disallow changing number of network interfaces if VM has failover label",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.oldVM.Annotations[vmopv1.FailedOverVMAnnotation] = "foo"
+
+						ctx.oldVM.Spec.Network = &vmopv1.VirtualMachineNetworkSpec{
+							Interfaces: []vmopv1.VirtualMachineNetworkInterfaceSpec{
+								{
+									Name: "eth0",
+								},
+							},
+						}
+
+						ctx.vm = ctx.oldVM.DeepCopy()
+						ctx.vm.Spec.Network.Interfaces = append(ctx.vm.Spec.Network.Interfaces,
+							vmopv1.VirtualMachineNetworkInterfaceSpec{Name: "eth1"})
+					},
+					validate: doValidateWithMsg(
+						`spec.network.interfaces: Forbidden: network interfaces cannot be added or removed`),
+				},
+			),
+
+			Entry("allow adding network interface when network hot-plug is enabled",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						pkgcfg.SetContext(ctx, func(config *pkgcfg.Config) {
+							config.Features.VMNetworkHotPlug = true
+						})
+						ctx.oldVM.Spec.Network = &vmopv1.VirtualMachineNetworkSpec{
+							Interfaces: []vmopv1.VirtualMachineNetworkInterfaceSpec{
+								{
+									Name: "eth0",
+								},
+							},
+						}
+
+						ctx.vm = ctx.oldVM.DeepCopy()
+						ctx.vm.Spec.Network.Interfaces = append(ctx.vm.Spec.Network.Interfaces,
+							vmopv1.VirtualMachineNetworkInterfaceSpec{Name: "eth1"})
+					},
+					expectAllowed: true,
+				},
+			),
+
+			Entry("allow removing network interface when network hot-plug is enabled",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						pkgcfg.SetContext(ctx, func(config *pkgcfg.Config) {
+							config.Features.VMNetworkHotPlug = true
+						})
+						ctx.oldVM.Spec.Network = &vmopv1.VirtualMachineNetworkSpec{
+							Interfaces: []vmopv1.VirtualMachineNetworkInterfaceSpec{
+								{
+									Name:    "eth0",
+									Network: &common.PartialObjectRef{Name: "my-network"},
+								},
+								{
+									Name:    "eth1",
+									Network: &common.PartialObjectRef{Name: "my-other-network"},
+								},
+							},
+						}
+
+						ctx.vm = ctx.oldVM.DeepCopy()
+						ctx.vm.Spec.Network.Interfaces = ctx.vm.Spec.Network.Interfaces[1:]
+					},
+					expectAllowed: true,
+				},
+			),
+
+			Entry("disallow changing network of remaining interface when removing network interface when network hot-plug is enabled",
 				testParams{
 					setup: func(ctx *unitValidatingWebhookContext) {
+						pkgcfg.SetContext(ctx, func(config *pkgcfg.Config) {
+							config.Features.VMNetworkHotPlug = true
+						})
 						ctx.oldVM.Spec.Network = &vmopv1.VirtualMachineNetworkSpec{
 							Interfaces: []vmopv1.VirtualMachineNetworkInterfaceSpec{
 								{
 									Name:    "eth0",
 									Network: &common.PartialObjectRef{Name: "my-network"},
 								},
+								{
+									Name:    "eth1",
+									Network: &common.PartialObjectRef{Name: "my-other-network"},
+								},
 							},
 						}
 
 						ctx.vm = ctx.oldVM.DeepCopy()
-						ctx.vm.Spec.Network.Interfaces[0].Network.Name = "my-other-network"
+						ctx.vm.Spec.Network.Interfaces = ctx.vm.Spec.Network.Interfaces[1:]
+						ctx.vm.Spec.Network.Interfaces[0].Network.Name = "my-network"
 					},
 					validate: doValidateWithMsg(
 						`spec.network.interfaces[0].network: Forbidden: field is immutable`),
 				},
 			),
 
-			Entry("disallow changing number of network interfaces",
+			Entry("disallow adding network interface with duplicate name when network hot-plug is enabled",
 				testParams{
 					setup: func(ctx *unitValidatingWebhookContext) {
+						pkgcfg.SetContext(ctx, func(config *pkgcfg.Config) {
+							config.Features.VMNetworkHotPlug = true
+						})
 						ctx.oldVM.Spec.Network = &vmopv1.VirtualMachineNetworkSpec{
 							Interfaces: []vmopv1.VirtualMachineNetworkInterfaceSpec{
 								{
@@ -3398,11 +4603,10 @@ func unitTestsValidateUpdate() {
 
 						ctx.vm = ctx.oldVM.DeepCopy()
 						ctx.vm.Spec.Network.Interfaces = append(ctx.vm.Spec.Network.Interfaces,
-							vmopv1.VirtualMachineNetworkInterfaceSpec{Name: "eth1"})
-
+							vmopv1.VirtualMachineNetworkInterfaceSpec{Name: "eth0"})
 					},
 					validate: doValidateWithMsg(
-						`spec.network.interfaces: Forbidden: network interfaces cannot be added or removed`),
+						`spec.network.interfaces[1].name: Duplicate value: "eth0"`),
 				},
 			),
 
@@ -3725,6 +4929,78 @@ func unitTestsValidateUpdate() {
 		)
 	})
 
+	Context("Serial console", func() {
+		const proxyURI = "telnets://vspc.local:13370"
+
+		DescribeTable("SerialConsoleEnabled update", doTest,
+
+			Entry("allow enabling if powered off and the proxy is configured",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						pkgcfg.SetContext(ctx, func(config *pkgcfg.Config) {
+							config.SerialConsoleProxyURI = proxyURI
+						})
+						ctx.oldVM.Spec.PowerState = vmopv1.VirtualMachinePowerStateOff
+						ctx.vm.Spec.PowerState = vmopv1.VirtualMachinePowerStateOff
+						ctx.vm.Spec.Advanced = &vmopv1.VirtualMachineAdvancedSpec{
+							SerialConsoleEnabled: ptr.To(true),
+						}
+					},
+					expectAllowed: true,
+				},
+			),
+
+			Entry("disallow enabling if the proxy is not configured",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.oldVM.Spec.PowerState = vmopv1.VirtualMachinePowerStateOff
+						ctx.vm.Spec.PowerState = vmopv1.VirtualMachinePowerStateOff
+						ctx.vm.Spec.Advanced = &vmopv1.VirtualMachineAdvancedSpec{
+							SerialConsoleEnabled: ptr.To(true),
+						}
+					},
+					validate: doValidateWithMsg(
+						`spec.advanced.serialConsoleEnabled: Forbidden: the serial console proxy is not configured`,
+					),
+					expectAllowed: false,
+				},
+			),
+
+			Entry("allow if already enabled and the proxy is no longer configured",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.oldVM.Spec.Advanced = &vmopv1.VirtualMachineAdvancedSpec{
+							SerialConsoleEnabled: ptr.To(true),
+						}
+						ctx.vm.Spec.Advanced = &vmopv1.VirtualMachineAdvancedSpec{
+							SerialConsoleEnabled: ptr.To(true),
+						}
+					},
+					expectAllowed: true,
+				},
+			),
+
+			Entry("disallow disabling if powered on",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.oldVM.Spec.PowerState = vmopv1.VirtualMachinePowerStateOn
+						ctx.oldVM.Spec.Advanced = &vmopv1.VirtualMachineAdvancedSpec{
+							SerialConsoleEnabled: ptr.To(true),
+						}
+						ctx.vm.Spec.PowerState = vmopv1.VirtualMachinePowerStateOn
+						ctx.vm.Spec.Advanced = &vmopv1.VirtualMachineAdvancedSpec{
+							SerialConsoleEnabled: ptr.To(false),
+						}
+					},
+					validate: doValidateWithMsg(
+						`spec.advanced.serialConsoleEnabled: Forbidden: updates to this field is not allowed when VM power is on`,
+					),
+					expectAllowed: false,
+				},
+			),
+		)
+	})
+
 	Context("CD-ROM", func() {
 
 		DescribeTable("CD-ROM update", doTest,
@@ -3734,7 +5010,7 @@ func unitTestsValidateUpdate() {
 					setup: func(ctx *unitValidatingWebhookContext) {
 						ctx.vm.Spec.Cdrom = append(ctx.vm.Spec.Cdrom, vmopv1.VirtualMachineCdromSpec{
 							Name: "new",
-							Image: vmopv1.VirtualMachineImageRef{
+							Image: &vmopv1.VirtualMachineImageRef{
 								Name: "vmi-new",
 								Kind: vmiKind,
 							},
@@ -3750,7 +5026,7 @@ func unitTestsValidateUpdate() {
 					setup: func(ctx *unitValidatingWebhookContext) {
 						ctx.vm.Spec.Cdrom = append(ctx.vm.Spec.Cdrom, vmopv1.VirtualMachineCdromSpec{
 							Name: "new2",
-							Image: vmopv1.VirtualMachineImageRef{
+							Image: &vmopv1.VirtualMachineImageRef{
 								Name: "vmi-new",
 								Kind: vmiKind,
 							},
@@ -3813,7 +5089,7 @@ func unitTestsValidateUpdate() {
 			Entry("allow changing CD-ROM image ref when VM is powered off",
 				testParams{
 					setup: func(ctx *unitValidatingWebhookContext) {
-						ctx.vm.Spec.Cdrom[0].Image = vmopv1.VirtualMachineImageRef{
+						ctx.vm.Spec.Cdrom[0].Image = &vmopv1.VirtualMachineImageRef{
 							Name: "cvmi-new",
 							Kind: cvmiKind,
 						}
@@ -3826,7 +5102,7 @@ func unitTestsValidateUpdate() {
 			Entry("disallow changing CD-ROM image ref when VM is powered on",
 				testParams{
 					setup: func(ctx *unitValidatingWebhookContext) {
-						ctx.vm.Spec.Cdrom[0].Image = vmopv1.VirtualMachineImageRef{
+						ctx.vm.Spec.Cdrom[0].Image = &vmopv1.VirtualMachineImageRef{
 							Name: "cvmi-new",
 							Kind: cvmiKind,
 						}
@@ -3892,6 +5168,68 @@ func unitTestsValidateUpdate() {
 					expectAllowed: false,
 				},
 			),
+
+			Entry("allow changing CD-ROM backing to config drive when VM is powered off",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.Cdrom[1].Image = nil
+						ctx.vm.Spec.Cdrom[1].ConfigDrive = &vmopv1.VirtualMachineCdromConfigDriveSource{
+							Sources: []vmopv1.VirtualMachineCdromConfigDriveProjection{
+								{
+									ConfigMap: &corev1.ConfigMapProjection{
+										LocalObjectReference: corev1.LocalObjectReference{Name: "my-config"},
+									},
+								},
+							},
+						}
+						ctx.vm.Spec.PowerState = vmopv1.VirtualMachinePowerStateOff
+					},
+					expectAllowed: true,
+				},
+			),
+
+			Entry("disallow changing CD-ROM backing to PVC when VM is powered on",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.Cdrom[1].Image = nil
+						ctx.vm.Spec.Cdrom[1].PersistentVolumeClaim = &vmopv1.VirtualMachineCdromPersistentVolumeClaimSource{
+							ClaimName: "iso-pvc",
+						}
+						ctx.vm.Spec.PowerState = vmopv1.VirtualMachinePowerStateOn
+					},
+					validate: doValidateWithMsg(
+						`spec.cdrom[1].image: Forbidden: updates to this field is not allowed when VM power is on`,
+						`spec.cdrom[1].persistentVolumeClaim: Forbidden: updates to this field is not allowed when VM power is on`,
+					),
+					expectAllowed: false,
+				},
+			),
+
+			Entry("disallow changing CD-ROM config drive when VM is powered on",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						configDrive := &vmopv1.VirtualMachineCdromConfigDriveSource{
+							Sources: []vmopv1.VirtualMachineCdromConfigDriveProjection{
+								{
+									ConfigMap: &corev1.ConfigMapProjection{
+										LocalObjectReference: corev1.LocalObjectReference{Name: "my-config"},
+									},
+								},
+							},
+						}
+						ctx.oldVM.Spec.Cdrom[1].Image = nil
+						ctx.oldVM.Spec.Cdrom[1].ConfigDrive = configDrive
+						ctx.vm.Spec.Cdrom[1].Image = nil
+						ctx.vm.Spec.Cdrom[1].ConfigDrive = configDrive.DeepCopy()
+						ctx.vm.Spec.Cdrom[1].ConfigDrive.VolumeLabel = "cidata"
+						ctx.vm.Spec.PowerState = vmopv1.VirtualMachinePowerStateOn
+					},
+					validate: doValidateWithMsg(
+						`spec.cdrom[1].configDrive: Forbidden: updates to this field is not allowed when VM power is on`,
+					),
+					expectAllowed: false,
+				},
+			),
 		)
 	})
 
@@ -4090,6 +5428,175 @@ func unitTestsValidateUpdate() {
 				},
 			),
 		)
+
+		DescribeTable("Non-PVC volume sources",
+			doTest,
+			Entry("allow adding an ephemeral volume when VM is powered off",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						pkgcfg.SetContext(ctx, func(config *pkgcfg.Config) {
+							config.Features.VMEphemeralVolumes = true
+						})
+						ctx.vm.Spec.Volumes = append(ctx.vm.Spec.Volumes, vmopv1.VirtualMachineVolume{
+							Name: "scratch",
+							VirtualMachineVolumeSource: vmopv1.VirtualMachineVolumeSource{
+								Ephemeral: &vmopv1.EphemeralVolumeSource{
+									Size: resource.MustParse("1Gi"),
+								},
+							},
+						})
+						ctx.vm.Spec.PowerState = vmopv1.VirtualMachinePowerStateOff
+					},
+					expectAllowed: true,
+				},
+			),
+
+			Entry("allow growing an ephemeral volume when VM is powered off",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						pkgcfg.SetContext(ctx, func(config *pkgcfg.Config) {
+							config.Features.VMEphemeralVolumes = true
+						})
+						vol := vmopv1.VirtualMachineVolume{
+							Name: "scratch",
+							VirtualMachineVolumeSource: vmopv1.VirtualMachineVolumeSource{
+								Ephemeral: &vmopv1.EphemeralVolumeSource{
+									Size: resource.MustParse("1Gi"),
+								},
+							},
+						}
+						ctx.oldVM.Spec.Volumes = append(ctx.oldVM.Spec.Volumes, vol)
+						vol = *vol.DeepCopy()
+						vol.Ephemeral.Size = resource.MustParse("2Gi")
+						ctx.vm.Spec.Volumes = append(ctx.vm.Spec.Volumes, vol)
+						ctx.vm.Spec.PowerState = vmopv1.VirtualMachinePowerStateOff
+					},
+					expectAllowed: true,
+				},
+			),
+
+			Entry("disallow decreasing the size of an ephemeral volume",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						pkgcfg.SetContext(ctx, func(config *pkgcfg.Config) {
+							config.Features.VMEphemeralVolumes = true
+						})
+						vol := vmopv1.VirtualMachineVolume{
+							Name: "scratch",
+							VirtualMachineVolumeSource: vmopv1.VirtualMachineVolumeSource{
+								Ephemeral: &vmopv1.EphemeralVolumeSource{
+									Size: resource.MustParse("2Gi"),
+								},
+							},
+						}
+						ctx.oldVM.Spec.Volumes = append(ctx.oldVM.Spec.Volumes, vol)
+						vol = *vol.DeepCopy()
+						vol.Ephemeral.Size = resource.MustParse("1Gi")
+						ctx.vm.Spec.Volumes = append(ctx.vm.Spec.Volumes, vol)
+						ctx.vm.Spec.PowerState = vmopv1.VirtualMachinePowerStateOff
+					},
+					validate: doValidateWithMsg(
+						`spec.volumes[1].ephemeral.size: Invalid value: "1Gi": cannot be decreased`,
+					),
+				},
+			),
+
+			Entry("disallow adding an ephemeral volume when VM is powered on",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						pkgcfg.SetContext(ctx, func(config *pkgcfg.Config) {
+							config.Features.VMEphemeralVolumes = true
+						})
+						ctx.vm.Spec.Volumes = append(ctx.vm.Spec.Volumes, vmopv1.VirtualMachineVolume{
+							Name: "scratch",
+							VirtualMachineVolumeSource: vmopv1.VirtualMachineVolumeSource{
+								Ephemeral: &vmopv1.EphemeralVolumeSource{
+									Size: resource.MustParse("1Gi"),
+								},
+							},
+						})
+						ctx.vm.Spec.PowerState = vmopv1.VirtualMachinePowerStateOn
+					},
+					validate: doValidateWithMsg(
+						`spec.volumes[1]: Forbidden: updates to this field is not allowed when VM power is on`,
+					),
+				},
+			),
+
+			Entry("disallow growing an ephemeral volume when VM is powered on",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						pkgcfg.SetContext(ctx, func(config *pkgcfg.Config) {
+							config.Features.VMEphemeralVolumes = true
+						})
+						vol := vmopv1.VirtualMachineVolume{
+							Name: "scratch",
+							VirtualMachineVolumeSource: vmopv1.VirtualMachineVolumeSource{
+								Ephemeral: &vmopv1.EphemeralVolumeSource{
+									Size: resource.MustParse("1Gi"),
+								},
+							},
+						}
+						ctx.oldVM.Spec.Volumes = append(ctx.oldVM.Spec.Volumes, vol)
+						vol = *vol.DeepCopy()
+						vol.Ephemeral.Size = resource.MustParse("2Gi")
+						ctx.vm.Spec.Volumes = append(ctx.vm.Spec.Volumes, vol)
+						ctx.vm.Spec.PowerState = vmopv1.VirtualMachinePowerStateOn
+					},
+					validate: doValidateWithMsg(
+						`spec.volumes[1]: Forbidden: updates to this field is not allowed when VM power is on`,
+					),
+				},
+			),
+
+			Entry("disallow changing a ConfigMap volume when VM is powered on",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						pkgcfg.SetContext(ctx, func(config *pkgcfg.Config) {
+							config.Features.VMEphemeralVolumes = true
+						})
+						vol := vmopv1.VirtualMachineVolume{
+							Name: "config",
+							VirtualMachineVolumeSource: vmopv1.VirtualMachineVolumeSource{
+								ConfigMap: &corev1.ConfigMapVolumeSource{
+									LocalObjectReference: corev1.LocalObjectReference{Name: "my-config"},
+								},
+							},
+						}
+						ctx.oldVM.Spec.Volumes = append(ctx.oldVM.Spec.Volumes, vol)
+						vol = *vol.DeepCopy()
+						vol.ConfigMap.Name = "my-other-config"
+						ctx.vm.Spec.Volumes = append(ctx.vm.Spec.Volumes, vol)
+						ctx.vm.Spec.PowerState = vmopv1.VirtualMachinePowerStateOn
+					},
+					validate: doValidateWithMsg(
+						`spec.volumes[1]: Forbidden: updates to this field is not allowed when VM power is on`,
+					),
+				},
+			),
+
+			Entry("disallow removing an ephemeral volume when VM is powered on",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						pkgcfg.SetContext(ctx, func(config *pkgcfg.Config) {
+							config.Features.VMEphemeralVolumes = true
+						})
+						ctx.oldVM.Spec.Volumes = append(ctx.oldVM.Spec.Volumes, vmopv1.VirtualMachineVolume{
+							Name: "scratch",
+							VirtualMachineVolumeSource: vmopv1.VirtualMachineVolumeSource{
+								Ephemeral: &vmopv1.EphemeralVolumeSource{
+									Size: resource.MustParse("1Gi"),
+								},
+							},
+						})
+						ctx.vm.Spec.PowerState = vmopv1.VirtualMachinePowerStateOn
+					},
+					validate: doValidateWithMsg(
+						`spec.volumes: Forbidden: updates to this field is not allowed when VM power is on`,
+					),
+				},
+			),
+		)
 	})
 
 	Context("Network", func() {
@@ -4183,6 +5690,59 @@ func unitTestsValidateUpdate() {
 			),
 		)
 	})
+
+	Context("PowerSchedule", func() {
+
+		DescribeTable("power schedule update", doTest,
+			Entry("allow updating a VM with a valid power schedule",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.PowerSchedule = &vmopv1.VirtualMachinePowerScheduleSpec{
+							TimeZone: "Europe/London",
+							PowerOn:  "30 7 * * 1-5",
+						}
+					},
+					expectAllowed: true,
+				},
+			),
+
+			Entry("disallow updating a VM with an invalid power schedule expression",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.PowerSchedule = &vmopv1.VirtualMachinePowerScheduleSpec{
+							PowerOff: "0 18 * * FUN",
+						}
+					},
+					validate: doValidateWithMsg(
+						`spec.powerSchedule.powerOff: Invalid value: "0 18 * * FUN": invalid day of week value "FUN"`,
+					),
+					expectAllowed: false,
+				},
+			),
+		)
+	})
+
+	Context("Clone", func() {
+
+		DescribeTable("clone update", doTest,
+			Entry("disallow updating spec.clone",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.oldVM.Spec.Clone = &vmopv1.VirtualMachineCloneSpec{
+							Mode: vmopv1.VirtualMachineCloneModeFull,
+						}
+						ctx.vm.Spec.Clone = &vmopv1.VirtualMachineCloneSpec{
+							Mode: vmopv1.VirtualMachineCloneModeLinked,
+						}
+					},
+					validate: doValidateWithMsg(
+						`spec.clone: Invalid value: `,
+					),
+					expectAllowed: false,
+				},
+			),
+		)
+	})
 }
 
 func unitTestsValidateDelete() {
@@ -4208,5 +5768,47 @@ func unitTestsValidateDelete() {
 			Expect(response.Allowed).To(BeTrue())
 			Expect(response.Result).ToNot(BeNil())
 		})
+
+		When("the VM is the source of a linked clone", func() {
+			BeforeEach(func() {
+				clone := builder.DummyVirtualMachine()
+				clone.Name = "dummy-clone"
+				clone.Namespace = ctx.vm.Namespace
+				clone.Spec.Image = &vmopv1.VirtualMachineImageRef{
+					Kind: "VirtualMachine",
+					Name: ctx.vm.Name,
+				}
+				clone.Spec.Clone = &vmopv1.VirtualMachineCloneSpec{
+					Mode: vmopv1.VirtualMachineCloneModeLinked,
+				}
+				Expect(ctx.Client.Create(ctx, clone)).To(Succeed())
+			})
+
+			It("should deny the request", func() {
+				Expect(response.Allowed).To(BeFalse())
+				Expect(string(response.Result.Reason)).To(ContainSubstring(
+					"cannot delete VM while linked clones depend on it: dummy-clone"))
+			})
+		})
+
+		When("the VM is the source of a full clone", func() {
+			BeforeEach(func() {
+				clone := builder.DummyVirtualMachine()
+				clone.Name = "dummy-clone"
+				clone.Namespace = ctx.vm.Namespace
+				clone.Spec.Image = &vmopv1.VirtualMachineImageRef{
+					Kind: "VirtualMachine",
+					Name: ctx.vm.Name,
+				}
+				clone.Spec.Clone = &vmopv1.VirtualMachineCloneSpec{
+					Mode: vmopv1.VirtualMachineCloneModeFull,
+				}
+				Expect(ctx.Client.Create(ctx, clone)).To(Succeed())
+			})
+
+			It("should allow the request", func() {
+				Expect(response.Allowed).To(BeTrue())
+			})
+		})
 	})
 }
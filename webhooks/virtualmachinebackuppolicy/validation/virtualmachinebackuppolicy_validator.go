@@ -0,0 +1,170 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package validation
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"slices"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlmgr "sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	"github.com/vmware-tanzu/vm-operator/pkg/builder"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	pkgutil "github.com/vmware-tanzu/vm-operator/pkg/util"
+	"github.com/vmware-tanzu/vm-operator/webhooks/common"
+)
+
+const (
+	webHookName = "default"
+)
+
+// +kubebuilder:webhook:verbs=create;update,path=/default-validate-vmoperator-vmware-com-v1alpha3-virtualmachinebackuppolicy,mutating=false,failurePolicy=fail,groups=vmoperator.vmware.com,resources=virtualmachinebackuppolicies,versions=v1alpha3,name=default.validating.virtualmachinebackuppolicy.v1alpha3.vmoperator.vmware.com,sideEffects=None,admissionReviewVersions=v1;v1beta1
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachinebackuppolicies,verbs=get;list
+
+// AddToManager adds the webhook to the provided manager.
+func AddToManager(ctx *pkgctx.ControllerManagerContext, mgr ctrlmgr.Manager) error {
+	hook, err := builder.NewValidatingWebhook(ctx, mgr, webHookName, NewValidator(mgr.GetClient()))
+	if err != nil {
+		return fmt.Errorf("failed to create virtualmachinebackuppolicy validation webhook: %w", err)
+	}
+	mgr.GetWebhookServer().Register(hook.Path, hook)
+	return nil
+}
+
+// NewValidator returns the package's Validator.
+func NewValidator(_ client.Client) builder.Validator {
+	return validator{
+		converter: runtime.DefaultUnstructuredConverter,
+	}
+}
+
+type validator struct {
+	converter runtime.UnstructuredConverter
+}
+
+func (v validator) For() schema.GroupVersionKind {
+	return vmopv1.GroupVersion.WithKind(reflect.TypeOf(vmopv1.VirtualMachineBackupPolicy{}).Name())
+}
+
+func (v validator) ValidateCreate(ctx *pkgctx.WebhookRequestContext) admission.Response {
+	policy, err := v.backupPolicyFromUnstructured(ctx.Obj)
+	if err != nil {
+		return webhook.Errored(http.StatusBadRequest, err)
+	}
+
+	var fieldErrs field.ErrorList
+	fieldErrs = append(fieldErrs, v.validateSpec(policy)...)
+
+	validationErrs := make([]string, 0, len(fieldErrs))
+	for _, fieldErr := range fieldErrs {
+		validationErrs = append(validationErrs, fieldErr.Error())
+	}
+
+	return common.BuildValidationResponse(ctx, nil, validationErrs, nil)
+}
+
+func (v validator) ValidateDelete(*pkgctx.WebhookRequestContext) admission.Response {
+	return admission.Allowed("")
+}
+
+func (v validator) ValidateUpdate(ctx *pkgctx.WebhookRequestContext) admission.Response {
+	policy, err := v.backupPolicyFromUnstructured(ctx.Obj)
+	if err != nil {
+		return webhook.Errored(http.StatusBadRequest, err)
+	}
+
+	var fieldErrs field.ErrorList
+	fieldErrs = append(fieldErrs, v.validateSpec(policy)...)
+
+	validationErrs := make([]string, 0, len(fieldErrs))
+	for _, fieldErr := range fieldErrs {
+		validationErrs = append(validationErrs, fieldErr.Error())
+	}
+
+	return common.BuildValidationResponse(ctx, nil, validationErrs, nil)
+}
+
+func (v validator) validateSpec(policy *vmopv1.VirtualMachineBackupPolicy) field.ErrorList {
+	var allErrs field.ErrorList
+	specPath := field.NewPath("spec")
+
+	if s := policy.Spec.VMSelector; s != nil {
+		if _, err := metav1.LabelSelectorAsSelector(s); err != nil {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("vmSelector"), s, err.Error()))
+		}
+	}
+
+	resourcesPath := specPath.Child("resources")
+	for i, r := range policy.Spec.Resources {
+		selectorPath := resourcesPath.Index(i).Child("selector")
+		if r.Selector == nil {
+			if r.Kind == vmopv1.VirtualMachineBackupPolicyResourceKindConfigMap {
+				allErrs = append(allErrs, field.Required(selectorPath, "selector is required for ConfigMap"))
+			}
+			continue
+		}
+		if _, err := metav1.LabelSelectorAsSelector(r.Selector); err != nil {
+			allErrs = append(allErrs, field.Invalid(selectorPath, r.Selector, err.Error()))
+		}
+	}
+
+	pathsPath := specPath.Child("excludedFieldPaths")
+	for i, p := range policy.Spec.ExcludedFieldPaths {
+		fields, err := pkgutil.ParseJSONPointer(p)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(pathsPath.Index(i), p, err.Error()))
+			continue
+		}
+		if isRequiredForRestore(fields) {
+			allErrs = append(allErrs, field.Forbidden(pathsPath.Index(i), fmt.Sprintf("%s is required to restore a resource", p)))
+		}
+	}
+
+	return allErrs
+}
+
+// requiredForRestoreFields are the fields that are required to restore a
+// resource, or to determine whether its backup is up-to-date.
+var requiredForRestoreFields = [][]string{
+	{"apiVersion"},
+	{"kind"},
+	{"metadata", "name"},
+	{"metadata", "namespace"},
+	{"metadata", "uid"},
+	{"metadata", "resourceVersion"},
+	{"metadata", "generation"},
+	{"metadata", "annotations", vmopv1.VirtualMachineBackupVersionAnnotation},
+}
+
+// isRequiredForRestore returns true if the fields refer to, or contain, a
+// field that is required to restore a resource.
+func isRequiredForRestore(fields []string) bool {
+	for _, required := range requiredForRestoreFields {
+		if len(fields) <= len(required) && slices.Equal(fields, required[:len(fields)]) {
+			return true
+		}
+	}
+	return false
+}
+
+// backupPolicyFromUnstructured returns the VirtualMachineBackupPolicy from the
+// unstructured object.
+func (v validator) backupPolicyFromUnstructured(obj runtime.Unstructured) (*vmopv1.VirtualMachineBackupPolicy, error) {
+	policy := &vmopv1.VirtualMachineBackupPolicy{}
+	if err := v.converter.FromUnstructured(obj.UnstructuredContent(), policy); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
@@ -0,0 +1,225 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package validation_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func unitTests() {
+	Describe(
+		"Create",
+		Label(
+			testlabels.Create,
+			testlabels.V1Alpha3,
+			testlabels.Validation,
+			testlabels.Webhook,
+		),
+		unitTestsValidateCreate,
+	)
+	Describe(
+		"Update",
+		Label(
+			testlabels.Update,
+			testlabels.V1Alpha3,
+			testlabels.Validation,
+			testlabels.Webhook,
+		),
+		unitTestsValidateUpdate,
+	)
+	Describe(
+		"Delete",
+		Label(
+			testlabels.Delete,
+			testlabels.V1Alpha3,
+			testlabels.Validation,
+			testlabels.Webhook,
+		),
+		unitTestsValidateDelete,
+	)
+}
+
+type unitValidatingWebhookContext struct {
+	builder.UnitTestContextForValidatingWebhook
+	policy    *vmopv1.VirtualMachineBackupPolicy
+	oldPolicy *vmopv1.VirtualMachineBackupPolicy
+}
+
+func newUnitTestContextForValidatingWebhook(isUpdate bool) *unitValidatingWebhookContext {
+	policy := builder.DummyVirtualMachineBackupPolicy("some-namespace", "some-name")
+	obj, err := builder.ToUnstructured(policy)
+	Expect(err).ToNot(HaveOccurred())
+
+	var oldPolicy *vmopv1.VirtualMachineBackupPolicy
+	var oldObj *unstructured.Unstructured
+
+	if isUpdate {
+		oldPolicy = policy.DeepCopy()
+		oldObj, err = builder.ToUnstructured(oldPolicy)
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	return &unitValidatingWebhookContext{
+		UnitTestContextForValidatingWebhook: *suite.NewUnitTestContextForValidatingWebhook(obj, oldObj),
+		policy:                              policy,
+		oldPolicy:                           oldPolicy,
+	}
+}
+
+type specArgs struct {
+	invalidVMSelector       bool
+	configMapWithSelector   bool
+	configMapNoSelector     bool
+	invalidResourceSelector bool
+	excludedFieldPath       string
+}
+
+func setSpec(policy *vmopv1.VirtualMachineBackupPolicy, args specArgs) {
+	invalidSelector := &metav1.LabelSelector{
+		MatchExpressions: []metav1.LabelSelectorRequirement{
+			{
+				Key:      "foo",
+				Operator: "Bogus",
+			},
+		},
+	}
+
+	if args.invalidVMSelector {
+		policy.Spec.VMSelector = invalidSelector
+	}
+	if args.configMapWithSelector {
+		policy.Spec.Resources = append(policy.Spec.Resources, vmopv1.VirtualMachineBackupPolicyResource{
+			Kind: vmopv1.VirtualMachineBackupPolicyResourceKindConfigMap,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"foo": "bar"},
+			},
+		})
+	}
+	if args.configMapNoSelector {
+		policy.Spec.Resources = append(policy.Spec.Resources, vmopv1.VirtualMachineBackupPolicyResource{
+			Kind: vmopv1.VirtualMachineBackupPolicyResourceKindConfigMap,
+		})
+	}
+	if args.invalidResourceSelector {
+		policy.Spec.Resources[0].Selector = invalidSelector
+	}
+	if args.excludedFieldPath != "" {
+		policy.Spec.ExcludedFieldPaths = append(policy.Spec.ExcludedFieldPaths, args.excludedFieldPath)
+	}
+}
+
+func unitTestsValidateCreate() {
+	var (
+		ctx *unitValidatingWebhookContext
+	)
+
+	validateCreate := func(args specArgs, expectedAllowed bool, expectedReason string) {
+		var err error
+
+		setSpec(ctx.policy, args)
+
+		ctx.WebhookRequestContext.Obj, err = builder.ToUnstructured(ctx.policy)
+		Expect(err).ToNot(HaveOccurred())
+
+		response := ctx.ValidateCreate(&ctx.WebhookRequestContext)
+		Expect(response.Allowed).To(Equal(expectedAllowed))
+		if expectedReason != "" {
+			Expect(string(response.Result.Reason)).To(ContainSubstring(expectedReason))
+		}
+	}
+
+	BeforeEach(func() {
+		ctx = newUnitTestContextForValidatingWebhook(false)
+	})
+	AfterEach(func() {
+		ctx = nil
+	})
+
+	DescribeTable("create table", validateCreate,
+		Entry("should allow valid", specArgs{}, true, ""),
+		Entry("should allow ConfigMap with selector", specArgs{configMapWithSelector: true}, true, ""),
+		Entry("should allow excluding a field in spec", specArgs{excludedFieldPath: "/spec/minHardwareVersion"}, true, ""),
+		Entry("should allow excluding an unrelated annotation", specArgs{excludedFieldPath: "/metadata/annotations/example.com~1foo"}, true, ""),
+		Entry("should deny invalid VM selector", specArgs{invalidVMSelector: true}, false, "spec.vmSelector: Invalid value"),
+		Entry("should deny ConfigMap without selector", specArgs{configMapNoSelector: true}, false, "spec.resources[1].selector: Required value"),
+		Entry("should deny invalid resource selector", specArgs{invalidResourceSelector: true}, false, "spec.resources[0].selector: Invalid value"),
+		Entry("should deny invalid JSON pointer", specArgs{excludedFieldPath: "spec"}, false, `spec.excludedFieldPaths[1]: Invalid value: "spec"`),
+		Entry("should deny excluding the generation", specArgs{excludedFieldPath: "/metadata/generation"}, false, "spec.excludedFieldPaths[1]: Forbidden"),
+		Entry("should deny excluding metadata", specArgs{excludedFieldPath: "/metadata"}, false, "spec.excludedFieldPaths[1]: Forbidden"),
+		Entry("should deny excluding the name", specArgs{excludedFieldPath: "/metadata/name"}, false, "spec.excludedFieldPaths[1]: Forbidden"),
+		Entry("should deny excluding the annotations", specArgs{excludedFieldPath: "/metadata/annotations"}, false, "spec.excludedFieldPaths[1]: Forbidden"),
+		Entry("should deny excluding the backup version annotation",
+			specArgs{excludedFieldPath: "/metadata/annotations/vmoperator.vmware.com~1backup-version"}, false, "spec.excludedFieldPaths[1]: Forbidden"),
+	)
+}
+
+func unitTestsValidateUpdate() {
+	var (
+		ctx *unitValidatingWebhookContext
+	)
+
+	validateUpdate := func(args specArgs, expectedAllowed bool, expectedReason string) {
+		var err error
+
+		setSpec(ctx.policy, args)
+
+		ctx.WebhookRequestContext.Obj, err = builder.ToUnstructured(ctx.policy)
+		Expect(err).ToNot(HaveOccurred())
+
+		response := ctx.ValidateUpdate(&ctx.WebhookRequestContext)
+		Expect(response.Allowed).To(Equal(expectedAllowed))
+		if expectedReason != "" {
+			Expect(string(response.Result.Reason)).To(ContainSubstring(expectedReason))
+		}
+	}
+
+	BeforeEach(func() {
+		ctx = newUnitTestContextForValidatingWebhook(true)
+	})
+	AfterEach(func() {
+		ctx = nil
+	})
+
+	DescribeTable("update table", validateUpdate,
+		Entry("should allow", specArgs{}, true, ""),
+		Entry("should allow ConfigMap with selector", specArgs{configMapWithSelector: true}, true, ""),
+		Entry("should deny ConfigMap without selector", specArgs{configMapNoSelector: true}, false, "spec.resources[1].selector: Required value"),
+		Entry("should deny excluding the kind", specArgs{excludedFieldPath: "/kind"}, false, "spec.excludedFieldPaths[1]: Forbidden"),
+	)
+}
+
+func unitTestsValidateDelete() {
+	var (
+		ctx      *unitValidatingWebhookContext
+		response admission.Response
+	)
+
+	BeforeEach(func() {
+		ctx = newUnitTestContextForValidatingWebhook(false)
+	})
+	AfterEach(func() {
+		ctx = nil
+	})
+
+	When("the delete is performed", func() {
+		JustBeforeEach(func() {
+			response = ctx.ValidateDelete(&ctx.WebhookRequestContext)
+		})
+
+		It("should allow the request", func() {
+			Expect(response.Allowed).To(BeTrue())
+			Expect(response.Result).ToNot(BeNil())
+		})
+	})
+}
@@ -0,0 +1,229 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package validation
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlmgr "sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+
+	"github.com/vmware-tanzu/vm-operator/pkg/builder"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/webhooks/common"
+)
+
+const (
+	webHookName = "default"
+
+	immutableFieldMsg = "field is immutable"
+)
+
+// +kubebuilder:webhook:verbs=create;update,path=/default-validate-vmoperator-vmware-com-v1alpha3-virtualmachinedeployment,mutating=false,failurePolicy=fail,groups=vmoperator.vmware.com,resources=virtualmachinedeployments,versions=v1alpha3,name=default.validating.virtualmachinedeployment.v1alpha3.vmoperator.vmware.com,sideEffects=None,admissionReviewVersions=v1;v1beta1
+
+// AddToManager adds the webhook to the provided manager.
+func AddToManager(ctx *pkgctx.ControllerManagerContext, mgr ctrlmgr.Manager) error {
+	hook, err := builder.NewValidatingWebhook(ctx, mgr, webHookName, NewValidator(mgr.GetClient()))
+	if err != nil {
+		return fmt.Errorf("failed to create VirtualMachineDeployment validation webhook: %w", err)
+	}
+	mgr.GetWebhookServer().Register(hook.Path, hook)
+
+	return nil
+}
+
+// NewValidator returns the package's Validator.
+func NewValidator(_ client.Client) builder.Validator {
+	return validator{
+		converter: runtime.DefaultUnstructuredConverter,
+	}
+}
+
+type validator struct {
+	converter runtime.UnstructuredConverter
+}
+
+func (v validator) For() schema.GroupVersionKind {
+	return vmopv1.GroupVersion.WithKind(reflect.TypeOf(vmopv1.VirtualMachineDeployment{}).Name())
+}
+
+func (v validator) ValidateCreate(ctx *pkgctx.WebhookRequestContext) admission.Response {
+	d, err := v.deploymentFromUnstructured(ctx.Obj)
+	if err != nil {
+		return webhook.Errored(http.StatusBadRequest, err)
+	}
+
+	var fieldErrs field.ErrorList
+	fieldErrs = append(fieldErrs, v.validateLabelSelectorLabelMatch(d)...)
+	fieldErrs = append(fieldErrs, v.validateStrategy(d)...)
+
+	validationErrs := make([]string, 0, len(fieldErrs))
+	for _, fieldErr := range fieldErrs {
+		validationErrs = append(validationErrs, fieldErr.Error())
+	}
+
+	return common.BuildValidationResponse(ctx, nil, validationErrs, nil)
+}
+
+func (v validator) ValidateDelete(*pkgctx.WebhookRequestContext) admission.Response {
+	return admission.Allowed("")
+}
+
+func (v validator) ValidateUpdate(ctx *pkgctx.WebhookRequestContext) admission.Response {
+	d, err := v.deploymentFromUnstructured(ctx.Obj)
+	if err != nil {
+		return webhook.Errored(http.StatusBadRequest, err)
+	}
+
+	oldD, err := v.deploymentFromUnstructured(ctx.OldObj)
+	if err != nil {
+		return webhook.Errored(http.StatusBadRequest, err)
+	}
+
+	var fieldErrs field.ErrorList
+	fieldErrs = append(fieldErrs, v.validateLabelSelectorLabelMatch(d)...)
+	fieldErrs = append(fieldErrs, v.validateStrategy(d)...)
+
+	// Changing the selector would orphan the existing VirtualMachineReplicaSets.
+	if !apiequality.Semantic.DeepEqual(d.Spec.Selector, oldD.Spec.Selector) {
+		fieldErrs = append(fieldErrs, field.Forbidden(field.NewPath("spec", "selector"), immutableFieldMsg))
+	}
+
+	validationErrs := make([]string, 0, len(fieldErrs))
+	for _, fieldErr := range fieldErrs {
+		validationErrs = append(validationErrs, fieldErr.Error())
+	}
+
+	return common.BuildValidationResponse(ctx, nil, validationErrs, nil)
+}
+
+func (v validator) validateLabelSelectorLabelMatch(
+	d *vmopv1.VirtualMachineDeployment) field.ErrorList {
+
+	var allErrs field.ErrorList
+
+	specPath := field.NewPath("spec")
+
+	if d.Spec.Selector == nil {
+		return append(allErrs, field.Required(specPath.Child("selector"), ""))
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(d.Spec.Selector)
+	switch {
+	case err != nil:
+		allErrs = append(
+			allErrs,
+			field.Invalid(
+				specPath.Child("selector"),
+				d.Spec.Selector,
+				err.Error(),
+			),
+		)
+	case selector.Empty():
+		allErrs = append(
+			allErrs,
+			field.Invalid(
+				specPath.Child("selector"),
+				d.Spec.Selector,
+				"empty selector is invalid for VirtualMachineDeployment",
+			),
+		)
+	case !selector.Matches(labels.Set(d.Spec.Template.ObjectMeta.Labels)):
+		allErrs = append(
+			allErrs,
+			field.Invalid(
+				specPath.Child("template", "metadata", "labels"),
+				d.Spec.Template.ObjectMeta.Labels,
+				fmt.Sprintf("must match spec.selector %q", selector.String()),
+			),
+		)
+	}
+
+	allErrs = append(allErrs, d.Spec.Template.ObjectMeta.Validate(specPath.Child("template", "metadata"))...)
+
+	return allErrs
+}
+
+func (v validator) validateStrategy(d *vmopv1.VirtualMachineDeployment) field.ErrorList {
+	var allErrs field.ErrorList
+
+	strategy := d.Spec.Strategy
+	if strategy == nil || strategy.RollingUpdate == nil {
+		return allErrs
+	}
+
+	strategyPath := field.NewPath("spec", "strategy")
+	rollingUpdatePath := strategyPath.Child("rollingUpdate")
+
+	if strategy.Type == vmopv1.RecreateVirtualMachineDeploymentStrategyType {
+		return append(allErrs, field.Forbidden(
+			rollingUpdatePath,
+			fmt.Sprintf("may not be specified when strategy type is %q", strategy.Type)))
+	}
+
+	maxSurge, surgeErrs := validateIntOrPercent(strategy.RollingUpdate.MaxSurge, rollingUpdatePath.Child("maxSurge"))
+	allErrs = append(allErrs, surgeErrs...)
+
+	maxUnavailable, unavailableErrs := validateIntOrPercent(strategy.RollingUpdate.MaxUnavailable, rollingUpdatePath.Child("maxUnavailable"))
+	allErrs = append(allErrs, unavailableErrs...)
+
+	if len(surgeErrs) == 0 && len(unavailableErrs) == 0 &&
+		strategy.RollingUpdate.MaxSurge != nil && strategy.RollingUpdate.MaxUnavailable != nil &&
+		maxSurge == 0 && maxUnavailable == 0 {
+
+		allErrs = append(allErrs, field.Invalid(
+			rollingUpdatePath.Child("maxUnavailable"),
+			strategy.RollingUpdate.MaxUnavailable.String(),
+			"may not be 0 when maxSurge is 0"))
+	}
+
+	return allErrs
+}
+
+// validateIntOrPercent returns errors if the value is not a non-negative
+// integer or a percentage no greater than 100%. The value is scaled against
+// 100 so that a non-zero percentage is never reported as zero.
+func validateIntOrPercent(val *intstr.IntOrString, fldPath *field.Path) (int, field.ErrorList) {
+	var allErrs field.ErrorList
+
+	if val == nil {
+		return 0, allErrs
+	}
+
+	scaled, err := intstr.GetScaledValueFromIntOrPercent(val, 100, true)
+	switch {
+	case err != nil:
+		allErrs = append(allErrs, field.Invalid(fldPath, val.String(), err.Error()))
+	case scaled < 0:
+		allErrs = append(allErrs, field.Invalid(fldPath, val.String(), "must be greater than or equal to 0"))
+	case val.Type == intstr.String && scaled > 100:
+		allErrs = append(allErrs, field.Invalid(fldPath, val.String(), "must not be greater than 100%"))
+	}
+
+	return scaled, allErrs
+}
+
+// deploymentFromUnstructured returns the VirtualMachineDeployment from the unstructured object.
+func (v validator) deploymentFromUnstructured(obj runtime.Unstructured) (*vmopv1.VirtualMachineDeployment, error) {
+	d := &vmopv1.VirtualMachineDeployment{}
+	if err := v.converter.FromUnstructured(obj.UnstructuredContent(), d); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
@@ -0,0 +1,247 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package validation_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/ptr"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func unitTests() {
+	Describe(
+		"Create",
+		Label(
+			testlabels.Create,
+			testlabels.V1Alpha3,
+			testlabels.Validation,
+			testlabels.Webhook,
+		),
+		unitTestsValidateCreate,
+	)
+	Describe(
+		"Update",
+		Label(
+			testlabels.Update,
+			testlabels.V1Alpha3,
+			testlabels.Validation,
+			testlabels.Webhook,
+		),
+		unitTestsValidateUpdate,
+	)
+	Describe(
+		"Delete",
+		Label(
+			testlabels.Delete,
+			testlabels.V1Alpha3,
+			testlabels.Validation,
+			testlabels.Webhook,
+		),
+		unitTestsValidateDelete,
+	)
+}
+
+type unitValidatingWebhookContext struct {
+	builder.UnitTestContextForValidatingWebhook
+	d, oldD *vmopv1.VirtualMachineDeployment
+}
+
+func newUnitTestContextForValidatingWebhook(isUpdate bool) *unitValidatingWebhookContext {
+	d := builder.DummyVirtualMachineDeployment()
+	d.Name = "dummy-deployment-for-webhook-validation"
+	d.Namespace = "dummy-deployment-namespace-for-webhook-validation"
+	obj, err := builder.ToUnstructured(d)
+	Expect(err).ToNot(HaveOccurred())
+
+	var (
+		oldD   *vmopv1.VirtualMachineDeployment
+		oldObj *unstructured.Unstructured
+	)
+
+	if isUpdate {
+		oldD = d.DeepCopy()
+		oldObj, err = builder.ToUnstructured(oldD)
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	return &unitValidatingWebhookContext{
+		UnitTestContextForValidatingWebhook: *suite.NewUnitTestContextForValidatingWebhook(obj, oldObj),
+		d:                                   d,
+		oldD:                                oldD,
+	}
+}
+
+func unitTestsValidateCreate() {
+	var (
+		ctx *unitValidatingWebhookContext
+	)
+
+	type createArgs struct {
+		nilSelector            bool
+		emptySelector          bool
+		mismatchedLabels       bool
+		recreateWithRolling    bool
+		zeroSurgeUnavailable   bool
+		negativeMaxSurge       bool
+		tooLargeMaxUnavailable bool
+		validPercentages       bool
+	}
+
+	validateCreate := func(args createArgs, expectedAllowed bool, expectedReason string) {
+		if args.nilSelector {
+			ctx.d.Spec.Selector = nil
+		}
+		if args.emptySelector {
+			ctx.d.Spec.Selector = &metav1.LabelSelector{}
+		}
+		if args.mismatchedLabels {
+			ctx.d.Spec.Template.Labels = map[string]string{"app": "other"}
+		}
+		if args.recreateWithRolling {
+			ctx.d.Spec.Strategy = &vmopv1.VirtualMachineDeploymentStrategy{
+				Type:          vmopv1.RecreateVirtualMachineDeploymentStrategyType,
+				RollingUpdate: &vmopv1.VirtualMachineRollingUpdateDeployment{},
+			}
+		}
+		if args.zeroSurgeUnavailable {
+			ctx.d.Spec.Strategy = &vmopv1.VirtualMachineDeploymentStrategy{
+				RollingUpdate: &vmopv1.VirtualMachineRollingUpdateDeployment{
+					MaxSurge:       ptr.To(intstr.FromInt32(0)),
+					MaxUnavailable: ptr.To(intstr.FromString("0%")),
+				},
+			}
+		}
+		if args.negativeMaxSurge {
+			ctx.d.Spec.Strategy = &vmopv1.VirtualMachineDeploymentStrategy{
+				RollingUpdate: &vmopv1.VirtualMachineRollingUpdateDeployment{
+					MaxSurge: ptr.To(intstr.FromInt32(-1)),
+				},
+			}
+		}
+		if args.tooLargeMaxUnavailable {
+			ctx.d.Spec.Strategy = &vmopv1.VirtualMachineDeploymentStrategy{
+				RollingUpdate: &vmopv1.VirtualMachineRollingUpdateDeployment{
+					MaxUnavailable: ptr.To(intstr.FromString("101%")),
+				},
+			}
+		}
+		if args.validPercentages {
+			ctx.d.Spec.Strategy = &vmopv1.VirtualMachineDeploymentStrategy{
+				Type: vmopv1.RollingUpdateVirtualMachineDeploymentStrategyType,
+				RollingUpdate: &vmopv1.VirtualMachineRollingUpdateDeployment{
+					MaxSurge:       ptr.To(intstr.FromString("25%")),
+					MaxUnavailable: ptr.To(intstr.FromString("0%")),
+				},
+			}
+		}
+
+		var err error
+		ctx.WebhookRequestContext.Obj, err = builder.ToUnstructured(ctx.d)
+		Expect(err).ToNot(HaveOccurred())
+
+		response := ctx.ValidateCreate(&ctx.WebhookRequestContext)
+		Expect(response.Allowed).To(Equal(expectedAllowed))
+		if expectedReason != "" {
+			Expect(string(response.Result.Reason)).To(ContainSubstring(expectedReason))
+		}
+	}
+
+	BeforeEach(func() {
+		ctx = newUnitTestContextForValidatingWebhook(false)
+	})
+
+	AfterEach(func() {
+		ctx = nil
+	})
+
+	DescribeTable("create table", validateCreate,
+		Entry("should allow valid", createArgs{}, true, ""),
+		Entry("should deny nil selector", createArgs{nilSelector: true}, false, "spec.selector: Required value"),
+		Entry("should deny empty selector", createArgs{emptySelector: true}, false, "empty selector is invalid"),
+		Entry("should deny template labels that do not match selector", createArgs{mismatchedLabels: true}, false,
+			"spec.template.metadata.labels: Invalid value"),
+		Entry("should deny rollingUpdate with Recreate strategy", createArgs{recreateWithRolling: true}, false,
+			"spec.strategy.rollingUpdate: Forbidden"),
+		Entry("should deny maxSurge and maxUnavailable both zero", createArgs{zeroSurgeUnavailable: true}, false,
+			"may not be 0 when maxSurge is 0"),
+		Entry("should deny negative maxSurge", createArgs{negativeMaxSurge: true}, false,
+			"spec.strategy.rollingUpdate.maxSurge: Invalid value"),
+		Entry("should deny maxUnavailable greater than 100%", createArgs{tooLargeMaxUnavailable: true}, false,
+			"must not be greater than 100%"),
+		Entry("should allow valid percentages", createArgs{validPercentages: true}, true, ""),
+	)
+}
+
+func unitTestsValidateUpdate() {
+	var (
+		ctx *unitValidatingWebhookContext
+	)
+
+	BeforeEach(func() {
+		ctx = newUnitTestContextForValidatingWebhook(true)
+	})
+
+	AfterEach(func() {
+		ctx = nil
+	})
+
+	validateUpdate := func(expectedAllowed bool, expectedReason string) {
+		var err error
+		ctx.WebhookRequestContext.Obj, err = builder.ToUnstructured(ctx.d)
+		Expect(err).ToNot(HaveOccurred())
+
+		response := ctx.ValidateUpdate(&ctx.WebhookRequestContext)
+		Expect(response.Allowed).To(Equal(expectedAllowed))
+		if expectedReason != "" {
+			Expect(string(response.Result.Reason)).To(ContainSubstring(expectedReason))
+		}
+	}
+
+	It("should allow changing the template and replicas", func() {
+		ctx.d.Spec.Replicas = ptr.To(int32(5))
+		ctx.d.Spec.Template.Spec.ImageName = "new-image"
+		ctx.d.Spec.Paused = true
+		validateUpdate(true, "")
+	})
+
+	It("should deny changing the selector", func() {
+		ctx.d.Spec.Selector.MatchLabels["tier"] = "db"
+		ctx.d.Spec.Template.Labels["tier"] = "db"
+		validateUpdate(false, "spec.selector: Forbidden: field is immutable")
+	})
+
+	It("should allow the update while the object is being deleted", func() {
+		t := metav1.Now()
+		ctx.d.DeletionTimestamp = &t
+		validateUpdate(true, "")
+	})
+}
+
+func unitTestsValidateDelete() {
+	var (
+		ctx *unitValidatingWebhookContext
+	)
+
+	BeforeEach(func() {
+		ctx = newUnitTestContextForValidatingWebhook(false)
+	})
+
+	AfterEach(func() {
+		ctx = nil
+	})
+
+	It("should allow the request", func() {
+		response := ctx.ValidateDelete(&ctx.WebhookRequestContext)
+		Expect(response.Allowed).To(BeTrue())
+	})
+}
@@ -25,6 +25,7 @@ import (
 	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
 	"github.com/vmware-tanzu/vm-operator/pkg/builder"
 	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/oci"
 	"github.com/vmware-tanzu/vm-operator/webhooks/common"
 )
 
@@ -139,6 +140,16 @@ func (v validator) validateTargetLocation(ctx *pkgctx.WebhookRequestContext, vmp
 
 	targetLocationPath := field.NewPath("spec").Child("target").
 		Child("location")
+
+	if vmpub.Spec.Target.Location.Kind == vmopv1.VirtualMachinePublishRequestTargetLocationKindOCIRegistry {
+		return v.validateOCIRegistryTargetLocation(vmpub, targetLocationPath)
+	}
+
+	if vmpub.Spec.Target.Location.OCIRegistry != nil {
+		allErrs = append(allErrs, field.Forbidden(targetLocationPath.Child("ociRegistry"),
+			fmt.Sprintf("only allowed when kind is %s", vmopv1.VirtualMachinePublishRequestTargetLocationKindOCIRegistry)))
+	}
+
 	targetLocationName := vmpub.Spec.Target.Location.Name
 	targetLocationNamePath := targetLocationPath.Child("name")
 	if targetLocationName == "" {
@@ -152,7 +163,39 @@ func (v validator) validateTargetLocation(ctx *pkgctx.WebhookRequestContext, vmp
 
 	if vmpub.Spec.Target.Location.Kind != reflect.TypeOf(imgregv1a1.ContentLibrary{}).Name() {
 		allErrs = append(allErrs, field.NotSupported(targetLocationPath.Child("kind"),
-			vmpub.Spec.Target.Location.Kind, []string{reflect.TypeOf(imgregv1a1.ContentLibrary{}).Name(), ""}))
+			vmpub.Spec.Target.Location.Kind, []string{reflect.TypeOf(imgregv1a1.ContentLibrary{}).Name(),
+				vmopv1.VirtualMachinePublishRequestTargetLocationKindOCIRegistry, ""}))
+	}
+
+	return allErrs
+}
+
+func (v validator) validateOCIRegistryTargetLocation(
+	vmpub *vmopv1.VirtualMachinePublishRequest,
+	targetLocationPath *field.Path) field.ErrorList {
+
+	var allErrs field.ErrorList
+
+	v1a1GV, v1a2GV, vmopv1GV := vmopv1a1.GroupVersion.String(), vmopv1a2.GroupVersion.String(), vmopv1.GroupVersion.String()
+	switch apiVersion := vmpub.Spec.Target.Location.APIVersion; apiVersion {
+	case v1a1GV, v1a2GV, vmopv1GV:
+	default:
+		allErrs = append(allErrs, field.NotSupported(targetLocationPath.Child("apiVersion"),
+			apiVersion, []string{v1a1GV, v1a2GV, vmopv1GV}))
+	}
+
+	registry := vmpub.Spec.Target.Location.OCIRegistry
+	registryPath := targetLocationPath.Child("ociRegistry")
+	if registry == nil {
+		allErrs = append(allErrs, field.Required(registryPath, ""))
+	} else if _, _, err := oci.ParseRepository(registry.Repository); err != nil {
+		allErrs = append(allErrs, field.Invalid(registryPath.Child("repository"), registry.Repository, err.Error()))
+	}
+
+	// The item name is the tag of the published artifact.
+	if itemName := vmpub.Spec.Target.Item.Name; itemName != "" && !oci.IsValidTag(itemName) {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "target", "item", "name"), itemName,
+			"must be a valid OCI tag"))
 	}
 
 	return allErrs
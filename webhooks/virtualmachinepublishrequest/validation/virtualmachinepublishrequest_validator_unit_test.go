@@ -109,6 +109,11 @@ func unitTestsValidateCreate() {
 		targetLocationNameEmpty         bool
 		targetLocationNotFound          bool
 		targetItemAlreadyExists         bool
+		ociRegistryTarget               bool
+		ociRegistryNil                  bool
+		ociRegistryInvalidRepository    bool
+		ociRegistryInvalidTag           bool
+		ociRegistryWithContentLibrary   bool
 	}
 
 	validateCreate := func(args createArgs, expectedAllowed bool, expectedReason string, expectedErr error) {
@@ -120,6 +125,34 @@ func unitTestsValidateCreate() {
 			ctx.vmPub.Spec.Source.Kind = "Machine"
 		}
 
+		if args.ociRegistryTarget {
+			ctx.vmPub.Spec.Target.Location = vmopv1.VirtualMachinePublishRequestTargetLocation{
+				APIVersion: vmopv1.GroupVersion.String(),
+				Kind:       vmopv1.VirtualMachinePublishRequestTargetLocationKindOCIRegistry,
+				OCIRegistry: &vmopv1.VirtualMachinePublishRequestTargetOCIRegistry{
+					Repository: "registry.example.com/golden/dummy-vm",
+				},
+			}
+		}
+
+		if args.ociRegistryNil {
+			ctx.vmPub.Spec.Target.Location.OCIRegistry = nil
+		}
+
+		if args.ociRegistryInvalidRepository {
+			ctx.vmPub.Spec.Target.Location.OCIRegistry.Repository = "golden/dummy-vm"
+		}
+
+		if args.ociRegistryInvalidTag {
+			ctx.vmPub.Spec.Target.Item.Name = "-dummy-item"
+		}
+
+		if args.ociRegistryWithContentLibrary {
+			ctx.vmPub.Spec.Target.Location.OCIRegistry = &vmopv1.VirtualMachinePublishRequestTargetOCIRegistry{
+				Repository: "registry.example.com/golden/dummy-vm",
+			}
+		}
+
 		if args.invalidTargetLocationAPIVersion {
 			ctx.vmPub.Spec.Target.Location.APIVersion = invalidAPIVersion
 		}
@@ -192,9 +225,24 @@ func unitTestsValidateCreate() {
 				[]string{"imageregistry.vmware.com/v1alpha1", ""}).Error(), nil),
 		Entry("should deny invalid target location kind", createArgs{invalidTargetLocationKind: true}, false,
 			field.NotSupported(targetLocationPath.Child("kind"), "ClusterContentLibrary",
-				[]string{"ContentLibrary", ""}).Error(), nil),
+				[]string{"ContentLibrary", "OCIRegistry", ""}).Error(), nil),
 		Entry("should deny if target location name is empty", createArgs{targetLocationNameEmpty: true}, false,
 			field.Required(targetLocationPath.Child("name"), "").Error(), nil),
+		Entry("should deny ociRegistry when target location kind is ContentLibrary", createArgs{ociRegistryWithContentLibrary: true}, false,
+			field.Forbidden(targetLocationPath.Child("ociRegistry"), "only allowed when kind is OCIRegistry").Error(), nil),
+		Entry("should allow valid OCI registry target", createArgs{ociRegistryTarget: true}, true, nil, nil),
+		Entry("should allow OCI registry target without a location name", createArgs{ociRegistryTarget: true, targetLocationNameEmpty: true}, true, nil, nil),
+		Entry("should deny OCI registry target with invalid API version", createArgs{ociRegistryTarget: true, invalidTargetLocationAPIVersion: true}, false,
+			field.NotSupported(targetLocationPath.Child("apiVersion"), invalidAPIVersion,
+				[]string{"vmoperator.vmware.com/v1alpha1", "vmoperator.vmware.com/v1alpha2", "vmoperator.vmware.com/v1alpha3"}).Error(), nil),
+		Entry("should deny OCI registry target without ociRegistry", createArgs{ociRegistryTarget: true, ociRegistryNil: true}, false,
+			field.Required(targetLocationPath.Child("ociRegistry"), "").Error(), nil),
+		Entry("should deny OCI registry target with invalid repository", createArgs{ociRegistryTarget: true, ociRegistryInvalidRepository: true}, false,
+			field.Invalid(targetLocationPath.Child("ociRegistry", "repository"), "golden/dummy-vm",
+				`repository "golden/dummy-vm" must include the registry host`).Error(), nil),
+		Entry("should deny OCI registry target with invalid tag", createArgs{ociRegistryTarget: true, ociRegistryInvalidTag: true}, false,
+			field.Invalid(field.NewPath("spec", "target", "item", "name"), "-dummy-item",
+				"must be a valid OCI tag").Error(), nil),
 	)
 }
 
@@ -57,6 +57,15 @@ func unitTests() {
 		),
 		unitTestVaildateTemplateObjectMetaAndSelectorMatching,
 	)
+	Describe(
+		"DeletePolicy",
+		Label(
+			testlabels.V1Alpha3,
+			testlabels.Validation,
+			testlabels.Webhook,
+		),
+		unitTestsValidateDeletePolicy,
+	)
 }
 
 type unitValidatingWebhookContext struct {
@@ -249,6 +258,46 @@ func unitTestVaildateTemplateObjectMetaAndSelectorMatching() {
 	})
 }
 
+func unitTestsValidateDeletePolicy() {
+	var (
+		ctx *unitValidatingWebhookContext
+	)
+
+	BeforeEach(func() {
+		ctx = newUnitTestContextForValidatingWebhook(false)
+	})
+	AfterEach(func() {
+		ctx = nil
+	})
+
+	doTest := func(deletePolicy string, expectAllowed bool) {
+		ctx.rs.Spec.DeletePolicy = deletePolicy
+
+		var err error
+		ctx.WebhookRequestContext.Obj, err = builder.ToUnstructured(ctx.rs)
+		Expect(err).ToNot(HaveOccurred())
+
+		response := ctx.ValidateCreate(&ctx.WebhookRequestContext)
+		Expect(response.Allowed).To(Equal(expectAllowed))
+		if !expectAllowed {
+			Expect(string(response.Result.Reason)).To(ContainSubstring("spec.deletePolicy: Unsupported value"))
+		}
+
+		response = ctx.ValidateUpdate(&ctx.WebhookRequestContext)
+		Expect(response.Allowed).To(Equal(expectAllowed))
+	}
+
+	DescribeTable("delete policy validations", doTest,
+		Entry("should allow empty", "", true),
+		Entry("should allow Random", vmopv1.RandomVirtualMachineReplicaSetDeletePolicy, true),
+		Entry("should allow Oldest", vmopv1.OldestVirtualMachineReplicaSetDeletePolicy, true),
+		Entry("should allow Newest", vmopv1.NewestVirtualMachineReplicaSetDeletePolicy, true),
+		Entry("should allow NotReadyFirst", vmopv1.NotReadyFirstVirtualMachineReplicaSetDeletePolicy, true),
+		Entry("should allow ZoneBalanced", vmopv1.ZoneBalancedVirtualMachineReplicaSetDeletePolicy, true),
+		Entry("should deny unknown policy", "LeastRecentlyUsed", false),
+	)
+}
+
 func unitTestsValidateUpdate() {
 	var (
 		ctx      *unitValidatingWebhookContext
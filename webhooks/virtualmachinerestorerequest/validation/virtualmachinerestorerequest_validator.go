@@ -0,0 +1,132 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package validation
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+
+	"k8s.io/apimachinery/pkg/api/validation"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlmgr "sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	"github.com/vmware-tanzu/vm-operator/pkg/builder"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/webhooks/common"
+)
+
+const (
+	webHookName = "default"
+)
+
+// +kubebuilder:webhook:verbs=create;update,path=/default-validate-vmoperator-vmware-com-v1alpha3-virtualmachinerestorerequest,mutating=false,failurePolicy=fail,groups=vmoperator.vmware.com,resources=virtualmachinerestorerequests,versions=v1alpha3,name=default.validating.virtualmachinerestorerequest.v1alpha3.vmoperator.vmware.com,sideEffects=None,admissionReviewVersions=v1;v1beta1
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachinerestorerequests,verbs=get;list
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachinerestorerequests/status,verbs=get
+
+// AddToManager adds the webhook to the provided manager.
+func AddToManager(ctx *pkgctx.ControllerManagerContext, mgr ctrlmgr.Manager) error {
+	hook, err := builder.NewValidatingWebhook(ctx, mgr, webHookName, NewValidator(mgr.GetClient()))
+	if err != nil {
+		return fmt.Errorf("failed to create virtualmachinerestorerequest validation webhook: %w", err)
+	}
+	mgr.GetWebhookServer().Register(hook.Path, hook)
+	return nil
+}
+
+// NewValidator returns the package's Validator.
+func NewValidator(_ client.Client) builder.Validator {
+	return validator{
+		converter: runtime.DefaultUnstructuredConverter,
+	}
+}
+
+type validator struct {
+	converter runtime.UnstructuredConverter
+}
+
+func (v validator) For() schema.GroupVersionKind {
+	return vmopv1.GroupVersion.WithKind(reflect.TypeOf(vmopv1.VirtualMachineRestoreRequest{}).Name())
+}
+
+func (v validator) ValidateCreate(ctx *pkgctx.WebhookRequestContext) admission.Response {
+	restoreReq, err := v.restoreRequestFromUnstructured(ctx.Obj)
+	if err != nil {
+		return webhook.Errored(http.StatusBadRequest, err)
+	}
+
+	var fieldErrs field.ErrorList
+	fieldErrs = append(fieldErrs, v.validateSpec(restoreReq)...)
+
+	validationErrs := make([]string, 0, len(fieldErrs))
+	for _, fieldErr := range fieldErrs {
+		validationErrs = append(validationErrs, fieldErr.Error())
+	}
+
+	return common.BuildValidationResponse(ctx, nil, validationErrs, nil)
+}
+
+func (v validator) ValidateDelete(*pkgctx.WebhookRequestContext) admission.Response {
+	return admission.Allowed("")
+}
+
+func (v validator) ValidateUpdate(ctx *pkgctx.WebhookRequestContext) admission.Response {
+	restoreReq, err := v.restoreRequestFromUnstructured(ctx.Obj)
+	if err != nil {
+		return webhook.Errored(http.StatusBadRequest, err)
+	}
+
+	oldRestoreReq, err := v.restoreRequestFromUnstructured(ctx.OldObj)
+	if err != nil {
+		return webhook.Errored(http.StatusBadRequest, err)
+	}
+
+	var fieldErrs field.ErrorList
+	fieldErrs = append(fieldErrs, v.validateImmutableFields(restoreReq, oldRestoreReq)...)
+
+	validationErrs := make([]string, 0, len(fieldErrs))
+	for _, fieldErr := range fieldErrs {
+		validationErrs = append(validationErrs, fieldErr.Error())
+	}
+
+	return common.BuildValidationResponse(ctx, nil, validationErrs, nil)
+}
+
+func (v validator) validateSpec(restoreReq *vmopv1.VirtualMachineRestoreRequest) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if restoreReq.Spec.Source.ManagedObjectID == "" {
+		allErrs = append(allErrs, field.Required(field.NewPath("spec", "source", "managedObjectID"), ""))
+	}
+
+	return allErrs
+}
+
+func (v validator) validateImmutableFields(restoreReq, oldRestoreReq *vmopv1.VirtualMachineRestoreRequest) field.ErrorList {
+	var allErrs field.ErrorList
+	specPath := field.NewPath("spec")
+
+	allErrs = append(allErrs, validation.ValidateImmutableField(restoreReq.Spec.Source, oldRestoreReq.Spec.Source, specPath.Child("source"))...)
+	allErrs = append(allErrs, validation.ValidateImmutableField(restoreReq.Spec.ConflictPolicy, oldRestoreReq.Spec.ConflictPolicy, specPath.Child("conflictPolicy"))...)
+	allErrs = append(allErrs, validation.ValidateImmutableField(restoreReq.Spec.DryRun, oldRestoreReq.Spec.DryRun, specPath.Child("dryRun"))...)
+
+	return allErrs
+}
+
+// restoreRequestFromUnstructured returns the VirtualMachineRestoreRequest from
+// the unstructured object.
+func (v validator) restoreRequestFromUnstructured(obj runtime.Unstructured) (*vmopv1.VirtualMachineRestoreRequest, error) {
+	restoreReq := &vmopv1.VirtualMachineRestoreRequest{}
+	if err := v.converter.FromUnstructured(obj.UnstructuredContent(), restoreReq); err != nil {
+		return nil, err
+	}
+	return restoreReq, nil
+}
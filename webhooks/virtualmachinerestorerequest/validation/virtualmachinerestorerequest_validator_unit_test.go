@@ -0,0 +1,211 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package validation_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func unitTests() {
+	Describe(
+		"Create",
+		Label(
+			testlabels.Create,
+			testlabels.V1Alpha3,
+			testlabels.Validation,
+			testlabels.Webhook,
+		),
+		unitTestsValidateCreate,
+	)
+	Describe(
+		"Update",
+		Label(
+			testlabels.Update,
+			testlabels.V1Alpha3,
+			testlabels.Validation,
+			testlabels.Webhook,
+		),
+		unitTestsValidateUpdate,
+	)
+	Describe(
+		"Delete",
+		Label(
+			testlabels.Delete,
+			testlabels.V1Alpha3,
+			testlabels.Validation,
+			testlabels.Webhook,
+		),
+		unitTestsValidateDelete,
+	)
+}
+
+type unitValidatingWebhookContext struct {
+	builder.UnitTestContextForValidatingWebhook
+	restoreReq    *vmopv1.VirtualMachineRestoreRequest
+	oldRestoreReq *vmopv1.VirtualMachineRestoreRequest
+}
+
+func newUnitTestContextForValidatingWebhook(isUpdate bool) *unitValidatingWebhookContext {
+	restoreReq := builder.DummyVirtualMachineRestoreRequest("some-namespace", "some-name", "vm-42")
+	obj, err := builder.ToUnstructured(restoreReq)
+	Expect(err).ToNot(HaveOccurred())
+
+	var oldRestoreReq *vmopv1.VirtualMachineRestoreRequest
+	var oldObj *unstructured.Unstructured
+
+	if isUpdate {
+		oldRestoreReq = restoreReq.DeepCopy()
+		oldObj, err = builder.ToUnstructured(oldRestoreReq)
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	return &unitValidatingWebhookContext{
+		UnitTestContextForValidatingWebhook: *suite.NewUnitTestContextForValidatingWebhook(obj, oldObj),
+		restoreReq:                          restoreReq,
+		oldRestoreReq:                       oldRestoreReq,
+	}
+}
+
+func unitTestsValidateCreate() {
+	var (
+		ctx *unitValidatingWebhookContext
+	)
+
+	type createArgs struct {
+		emptyMoID bool
+		dryRun    bool
+		skip      bool
+	}
+
+	validateCreate := func(args createArgs, expectedAllowed bool, expectedReason string) {
+		var err error
+
+		if args.emptyMoID {
+			ctx.restoreReq.Spec.Source.ManagedObjectID = ""
+		}
+		if args.dryRun {
+			ctx.restoreReq.Spec.DryRun = true
+		}
+		if args.skip {
+			ctx.restoreReq.Spec.ConflictPolicy = vmopv1.VirtualMachineRestoreRequestConflictPolicySkip
+		}
+
+		ctx.WebhookRequestContext.Obj, err = builder.ToUnstructured(ctx.restoreReq)
+		Expect(err).ToNot(HaveOccurred())
+
+		response := ctx.ValidateCreate(&ctx.WebhookRequestContext)
+		Expect(response.Allowed).To(Equal(expectedAllowed))
+		if expectedReason != "" {
+			Expect(string(response.Result.Reason)).To(ContainSubstring(expectedReason))
+		}
+	}
+
+	BeforeEach(func() {
+		ctx = newUnitTestContextForValidatingWebhook(false)
+	})
+	AfterEach(func() {
+		ctx = nil
+	})
+
+	DescribeTable("create table", validateCreate,
+		Entry("should allow valid", createArgs{}, true, ""),
+		Entry("should allow dry run with skip conflict policy", createArgs{dryRun: true, skip: true}, true, ""),
+		Entry("should deny empty managed object ID", createArgs{emptyMoID: true}, false, "spec.source.managedObjectID: Required value"),
+	)
+}
+
+func unitTestsValidateUpdate() {
+	var (
+		ctx *unitValidatingWebhookContext
+	)
+
+	type updateArgs struct {
+		updateMoID           bool
+		updateConflictPolicy bool
+		updateDryRun         bool
+		updateStatus         bool
+	}
+
+	validateUpdate := func(args updateArgs, expectedAllowed bool, expectedReason string) {
+		var err error
+
+		if args.updateMoID {
+			ctx.restoreReq.Spec.Source.ManagedObjectID = "vm-43"
+		}
+		if args.updateConflictPolicy {
+			ctx.restoreReq.Spec.ConflictPolicy = vmopv1.VirtualMachineRestoreRequestConflictPolicySkip
+		}
+		if args.updateDryRun {
+			ctx.restoreReq.Spec.DryRun = true
+		}
+		if args.updateStatus {
+			ctx.restoreReq.Status.Resources = []vmopv1.VirtualMachineRestoreRequestResourceStatus{
+				{
+					APIVersion: "v1",
+					Kind:       "Secret",
+					Name:       "my-secret",
+					Action:     vmopv1.VirtualMachineRestoreRequestResourceActionCreate,
+				},
+			}
+		}
+
+		ctx.WebhookRequestContext.Obj, err = builder.ToUnstructured(ctx.restoreReq)
+		Expect(err).ToNot(HaveOccurred())
+
+		response := ctx.ValidateUpdate(&ctx.WebhookRequestContext)
+		Expect(response.Allowed).To(Equal(expectedAllowed))
+		if expectedReason != "" {
+			Expect(string(response.Result.Reason)).To(ContainSubstring(expectedReason))
+		}
+	}
+
+	BeforeEach(func() {
+		ctx = newUnitTestContextForValidatingWebhook(true)
+	})
+	AfterEach(func() {
+		ctx = nil
+	})
+
+	DescribeTable("update table", validateUpdate,
+		Entry("should allow", updateArgs{}, true, ""),
+		Entry("should allow status change", updateArgs{updateStatus: true}, true, ""),
+		Entry("should deny source change", updateArgs{updateMoID: true}, false, "spec.source: Invalid value"),
+		Entry("should deny conflict policy change", updateArgs{updateConflictPolicy: true}, false, `spec.conflictPolicy: Invalid value: "Skip": field is immutable`),
+		Entry("should deny dry run change", updateArgs{updateDryRun: true}, false, "spec.dryRun: Invalid value: true: field is immutable"),
+	)
+}
+
+func unitTestsValidateDelete() {
+	var (
+		ctx      *unitValidatingWebhookContext
+		response admission.Response
+	)
+
+	BeforeEach(func() {
+		ctx = newUnitTestContextForValidatingWebhook(false)
+	})
+	AfterEach(func() {
+		ctx = nil
+	})
+
+	When("the delete is performed", func() {
+		JustBeforeEach(func() {
+			response = ctx.ValidateDelete(&ctx.WebhookRequestContext)
+		})
+
+		It("should allow the request", func() {
+			Expect(response.Allowed).To(BeTrue())
+			Expect(response.Result).ToNot(BeNil())
+		})
+	})
+}
@@ -46,6 +46,22 @@ var (
 		string(corev1.ProtocolUDP),
 		string(corev1.ProtocolSCTP),
 	)
+
+	supportedSessionAffinityType = sets.NewString(
+		string(vmopv1.VirtualMachineServiceAffinityClientIP),
+		string(vmopv1.VirtualMachineServiceAffinityNone),
+	)
+
+	supportedExternalTrafficPolicyType = sets.NewString(
+		string(vmopv1.VirtualMachineServiceExternalTrafficPolicyCluster),
+		string(vmopv1.VirtualMachineServiceExternalTrafficPolicyLocal),
+	)
+)
+
+const (
+	// maxClientIPServiceAffinitySeconds is the maximum timeout of ClientIP
+	// based session affinity, matching that of a Service.
+	maxClientIPServiceAffinitySeconds = 86400
 )
 
 // +kubebuilder:webhook:verbs=create;update,path=/default-validate-vmoperator-vmware-com-v1alpha3-virtualmachineservice,mutating=false,failurePolicy=fail,groups=vmoperator.vmware.com,resources=virtualmachineservices,versions=v1alpha3,name=default.validating.virtualmachineservice.v1alpha3.vmoperator.vmware.com,sideEffects=None,admissionReviewVersions=v1;v1beta1
@@ -164,6 +180,8 @@ func (v validator) validateSpec(ctx *pkgctx.WebhookRequestContext, vmService *vm
 	}
 
 	allErrs = append(allErrs, validatePorts(vmService, specPath)...)
+	allErrs = append(allErrs, validateSessionAffinity(vmService, specPath)...)
+	allErrs = append(allErrs, validateExternalTrafficPolicy(vmService, specPath)...)
 
 	if vmService.Spec.Selector != nil {
 		allErrs = append(allErrs, unversionedvalidation.ValidateLabels(vmService.Spec.Selector, specPath.Child("selector"))...)
@@ -263,8 +281,82 @@ func validateServicePort(sp *vmopv1.VirtualMachineServicePort, requireName bool,
 		allErrs = append(allErrs, field.NotSupported(fldPath.Child("protocol"), sp.Protocol, supportedPortProtocols.List()))
 	}
 
-	for _, msg := range validation.IsValidPortNum(int(sp.TargetPort)) {
-		allErrs = append(allErrs, field.Invalid(fldPath.Child("targetPort"), sp.TargetPort, msg))
+	if len(sp.TargetPortName) == 0 {
+		for _, msg := range validation.IsValidPortNum(int(sp.TargetPort)) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("targetPort"), sp.TargetPort, msg))
+		}
+	} else {
+		if sp.TargetPort != 0 {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("targetPort"), "may not be specified with `targetPortName`"))
+		}
+		for _, msg := range validation.IsValidPortName(sp.TargetPortName) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("targetPortName"), sp.TargetPortName, msg))
+		}
+	}
+
+	return allErrs
+}
+
+func validateSessionAffinity(vmService *vmopv1.VirtualMachineService, specPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	sessionAffinity := vmService.Spec.SessionAffinity
+	if sessionAffinity != "" && !supportedSessionAffinityType.Has(string(sessionAffinity)) {
+		allErrs = append(allErrs, field.NotSupported(specPath.Child("sessionAffinity"), sessionAffinity, supportedSessionAffinityType.List()))
+	}
+
+	if sessionAffinity == vmopv1.VirtualMachineServiceAffinityClientIP &&
+		vmService.Spec.Type == vmopv1.VirtualMachineServiceTypeExternalName {
+
+		allErrs = append(allErrs, field.Forbidden(specPath.Child("sessionAffinity"), "may not be 'ClientIP' for ExternalName services"))
+	}
+
+	if config := vmService.Spec.SessionAffinityConfig; config != nil {
+		fldPath := specPath.Child("sessionAffinityConfig")
+
+		if sessionAffinity != vmopv1.VirtualMachineServiceAffinityClientIP {
+			allErrs = append(allErrs, field.Forbidden(fldPath, "may only be used when `sessionAffinity` is 'ClientIP'"))
+		}
+
+		if config.ClientIP != nil && config.ClientIP.TimeoutSeconds != nil {
+			if timeout := *config.ClientIP.TimeoutSeconds; timeout <= 0 || timeout > maxClientIPServiceAffinitySeconds {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("clientIP", "timeoutSeconds"), timeout,
+					fmt.Sprintf("must be greater than 0 and less than or equal to %d", maxClientIPServiceAffinitySeconds)))
+			}
+		}
+	}
+
+	return allErrs
+}
+
+func validateExternalTrafficPolicy(vmService *vmopv1.VirtualMachineService, specPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if etp := vmService.Spec.ExternalTrafficPolicy; etp != "" {
+		fldPath := specPath.Child("externalTrafficPolicy")
+
+		if vmService.Spec.Type != vmopv1.VirtualMachineServiceTypeLoadBalancer {
+			allErrs = append(allErrs, field.Forbidden(fldPath, "may only be used when `type` is 'LoadBalancer'"))
+		}
+
+		if !supportedExternalTrafficPolicyType.Has(string(etp)) {
+			allErrs = append(allErrs, field.NotSupported(fldPath, etp, supportedExternalTrafficPolicyType.List()))
+		}
+	}
+
+	if port := vmService.Spec.HealthCheckNodePort; port != 0 {
+		fldPath := specPath.Child("healthCheckNodePort")
+
+		if vmService.Spec.Type != vmopv1.VirtualMachineServiceTypeLoadBalancer ||
+			vmService.Spec.ExternalTrafficPolicy != vmopv1.VirtualMachineServiceExternalTrafficPolicyLocal {
+
+			allErrs = append(allErrs, field.Forbidden(fldPath,
+				"may only be used when `type` is 'LoadBalancer' and `externalTrafficPolicy` is 'Local'"))
+		}
+
+		for _, msg := range validation.IsValidPortNum(int(port)) {
+			allErrs = append(allErrs, field.Invalid(fldPath, port, msg))
+		}
 	}
 
 	return allErrs
@@ -14,6 +14,7 @@ import (
 
 	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
 	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/ptr"
 	"github.com/vmware-tanzu/vm-operator/test/builder"
 )
 
@@ -91,6 +92,15 @@ func unitTestsValidateCreate() {
 		invalidClusterIP      bool
 		invalidLBSourceRanges bool
 		invalidExternalName   bool
+
+		validSessionAffinity            bool
+		invalidSessionAffinity          bool
+		invalidSessionAffinityConfig    bool
+		validExternalTrafficPolicy      bool
+		invalidExternalTrafficPolicy    bool
+		invalidExternalTrafficPolicyLB  bool
+		invalidHealthCheckNodePort      bool
+		invalidHealthCheckNodePortValue bool
 	}
 
 	validateCreate := func(args createArgs, expectedAllowed bool, expectedReason string, expectedErr error) {
@@ -123,6 +133,43 @@ func unitTestsValidateCreate() {
 			ctx.vmService.Spec.Type = vmopv1.VirtualMachineServiceTypeExternalName
 			ctx.vmService.Spec.ExternalName = "InValid!"
 		}
+		if args.validSessionAffinity {
+			ctx.vmService.Spec.SessionAffinity = vmopv1.VirtualMachineServiceAffinityClientIP
+			ctx.vmService.Spec.SessionAffinityConfig = &vmopv1.SessionAffinityConfig{
+				ClientIP: &vmopv1.ClientIPConfig{TimeoutSeconds: ptr.To[int32](600)},
+			}
+		}
+		if args.invalidSessionAffinity {
+			ctx.vmService.Spec.SessionAffinity = "Invalid"
+		}
+		if args.invalidSessionAffinityConfig {
+			ctx.vmService.Spec.SessionAffinity = vmopv1.VirtualMachineServiceAffinityClientIP
+			ctx.vmService.Spec.SessionAffinityConfig = &vmopv1.SessionAffinityConfig{
+				ClientIP: &vmopv1.ClientIPConfig{TimeoutSeconds: ptr.To[int32](86401)},
+			}
+		}
+		if args.validExternalTrafficPolicy {
+			ctx.vmService.Spec.Type = vmopv1.VirtualMachineServiceTypeLoadBalancer
+			ctx.vmService.Spec.ExternalTrafficPolicy = vmopv1.VirtualMachineServiceExternalTrafficPolicyLocal
+			ctx.vmService.Spec.HealthCheckNodePort = 30123
+		}
+		if args.invalidExternalTrafficPolicy {
+			ctx.vmService.Spec.ExternalTrafficPolicy = "Invalid"
+		}
+		if args.invalidExternalTrafficPolicyLB {
+			ctx.vmService.Spec.Type = vmopv1.VirtualMachineServiceTypeClusterIP
+			ctx.vmService.Spec.ExternalTrafficPolicy = vmopv1.VirtualMachineServiceExternalTrafficPolicyLocal
+		}
+		if args.invalidHealthCheckNodePort {
+			ctx.vmService.Spec.Type = vmopv1.VirtualMachineServiceTypeLoadBalancer
+			ctx.vmService.Spec.ExternalTrafficPolicy = vmopv1.VirtualMachineServiceExternalTrafficPolicyCluster
+			ctx.vmService.Spec.HealthCheckNodePort = 30123
+		}
+		if args.invalidHealthCheckNodePortValue {
+			ctx.vmService.Spec.Type = vmopv1.VirtualMachineServiceTypeLoadBalancer
+			ctx.vmService.Spec.ExternalTrafficPolicy = vmopv1.VirtualMachineServiceExternalTrafficPolicyLocal
+			ctx.vmService.Spec.HealthCheckNodePort = 100000
+		}
 
 		ctx.WebhookRequestContext.Obj, err = builder.ToUnstructured(ctx.vmService)
 		Expect(err).ToNot(HaveOccurred())
@@ -154,6 +201,14 @@ func unitTestsValidateCreate() {
 		Entry("should deny invalid ClusterIP", createArgs{invalidClusterIP: true}, false, "spec.clusterIP: Invalid value: \"100.1000.1.1\": must be a valid IP address", nil),
 		Entry("should deny invalid LoadBalancerSourceRanges", createArgs{invalidLBSourceRanges: true}, false, `spec.loadBalancerSourceRanges[0]: Invalid value: "10.1.1.1/42": must be compatible with https://pkg.go.dev/net#ParseCIDR`, nil),
 		Entry("should deny invalid ExternalName", createArgs{invalidExternalName: true}, false, "spec.externalName: Invalid value: \"InValid!\": a lowercase RFC 1123 subdomain must consist of lower case alphanumeric characters", nil),
+		Entry("should allow valid SessionAffinity", createArgs{validSessionAffinity: true}, true, nil, nil),
+		Entry("should deny invalid SessionAffinity", createArgs{invalidSessionAffinity: true}, false, `spec.sessionAffinity: Unsupported value: "Invalid"`, nil),
+		Entry("should deny invalid SessionAffinityConfig", createArgs{invalidSessionAffinityConfig: true}, false, "spec.sessionAffinityConfig.clientIP.timeoutSeconds: Invalid value: 86401: must be greater than 0 and less than or equal to 86400", nil),
+		Entry("should allow valid ExternalTrafficPolicy", createArgs{validExternalTrafficPolicy: true}, true, nil, nil),
+		Entry("should deny invalid ExternalTrafficPolicy", createArgs{invalidExternalTrafficPolicy: true}, false, `spec.externalTrafficPolicy: Unsupported value: "Invalid"`, nil),
+		Entry("should deny ExternalTrafficPolicy for non-LoadBalancer", createArgs{invalidExternalTrafficPolicyLB: true}, false, "spec.externalTrafficPolicy: Forbidden: may only be used when `type` is 'LoadBalancer'", nil),
+		Entry("should deny HealthCheckNodePort without Local ExternalTrafficPolicy", createArgs{invalidHealthCheckNodePort: true}, false, "spec.healthCheckNodePort: Forbidden: may only be used when `type` is 'LoadBalancer' and `externalTrafficPolicy` is 'Local'", nil),
+		Entry("should deny invalid HealthCheckNodePort", createArgs{invalidHealthCheckNodePortValue: true}, false, "spec.healthCheckNodePort: Invalid value: 100000:", nil),
 	)
 
 	validatePortCreate := func(expectedReason string, ports []vmopv1.VirtualMachineServicePort) {
@@ -212,6 +267,31 @@ func unitTestsValidateCreate() {
 				},
 			},
 		),
+		Entry("should allow valid named target port", "",
+			[]vmopv1.VirtualMachineServicePort{
+				{
+					Name:           "http",
+					Protocol:       "TCP",
+					Port:           80,
+					TargetPortName: "http",
+				},
+			},
+		),
+		Entry("should deny invalid target port name", "spec.ports[0].targetPortName: Invalid value: \"INVALID_NAME\"",
+			[]vmopv1.VirtualMachineServicePort{
+				{
+					TargetPortName: "INVALID_NAME",
+				},
+			},
+		),
+		Entry("should deny target port with target port name", "spec.ports[0].targetPort: Forbidden: may not be specified with `targetPortName`",
+			[]vmopv1.VirtualMachineServicePort{
+				{
+					TargetPort:     8080,
+					TargetPortName: "http",
+				},
+			},
+		),
 		Entry("should deny duplicate names", "spec.ports[1].name: Duplicate value: \"port1\"",
 			[]vmopv1.VirtualMachineServicePort{
 				{
@@ -0,0 +1,193 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package validation
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/validation"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlmgr "sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	"github.com/vmware-tanzu/vm-operator/pkg/builder"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	vmopv1util "github.com/vmware-tanzu/vm-operator/pkg/util/vmopv1"
+	"github.com/vmware-tanzu/vm-operator/webhooks/common"
+)
+
+const (
+	webHookName = "default"
+
+	linkedClonesExistFmt = "cannot delete the current snapshot of a VM while linked clones depend on it: %s"
+)
+
+// +kubebuilder:webhook:verbs=create;update;delete,path=/default-validate-vmoperator-vmware-com-v1alpha3-virtualmachinesnapshot,mutating=false,failurePolicy=fail,groups=vmoperator.vmware.com,resources=virtualmachinesnapshots,versions=v1alpha3,name=default.validating.virtualmachinesnapshot.v1alpha3.vmoperator.vmware.com,sideEffects=None,admissionReviewVersions=v1;v1beta1
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachinesnapshots,verbs=get;list
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachinesnapshots/status,verbs=get
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachines,verbs=get;list
+
+// AddToManager adds the webhook to the provided manager. The webhook relies on
+// the linked clone source index added by the VirtualMachine validation webhook.
+func AddToManager(ctx *pkgctx.ControllerManagerContext, mgr ctrlmgr.Manager) error {
+	hook, err := builder.NewValidatingWebhook(ctx, mgr, webHookName, NewValidator(mgr.GetClient()))
+	if err != nil {
+		return fmt.Errorf("failed to create virtualmachinesnapshot validation webhook: %w", err)
+	}
+	mgr.GetWebhookServer().Register(hook.Path, hook)
+	return nil
+}
+
+// NewValidator returns the package's Validator.
+func NewValidator(client client.Client) builder.Validator {
+	return validator{
+		client:    client,
+		converter: runtime.DefaultUnstructuredConverter,
+	}
+}
+
+type validator struct {
+	client    client.Client
+	converter runtime.UnstructuredConverter
+}
+
+func (v validator) For() schema.GroupVersionKind {
+	return vmopv1.GroupVersion.WithKind(reflect.TypeOf(vmopv1.VirtualMachineSnapshot{}).Name())
+}
+
+func (v validator) ValidateCreate(ctx *pkgctx.WebhookRequestContext) admission.Response {
+	vmSnapshot, err := v.vmSnapshotFromUnstructured(ctx.Obj)
+	if err != nil {
+		return webhook.Errored(http.StatusBadRequest, err)
+	}
+
+	var fieldErrs field.ErrorList
+	fieldErrs = append(fieldErrs, v.validateSpec(vmSnapshot)...)
+
+	validationErrs := make([]string, 0, len(fieldErrs))
+	for _, fieldErr := range fieldErrs {
+		validationErrs = append(validationErrs, fieldErr.Error())
+	}
+
+	return common.BuildValidationResponse(ctx, nil, validationErrs, nil)
+}
+
+// ValidateDelete denies deleting the current snapshot of a VM that is the
+// source of linked clones, as the disks of the clones are backed by it.
+func (v validator) ValidateDelete(ctx *pkgctx.WebhookRequestContext) admission.Response {
+	vmSnapshot, err := v.vmSnapshotFromUnstructured(ctx.Obj)
+	if err != nil {
+		return webhook.Errored(http.StatusBadRequest, err)
+	}
+
+	if vmSnapshot.Spec.VMRef == nil || vmSnapshot.Spec.VMRef.Name == "" {
+		return admission.Allowed("")
+	}
+
+	vm := &vmopv1.VirtualMachine{}
+	vmKey := client.ObjectKey{
+		Namespace: vmSnapshot.Namespace,
+		Name:      vmSnapshot.Spec.VMRef.Name,
+	}
+	if err := v.client.Get(ctx, vmKey, vm); err != nil {
+		if apierrors.IsNotFound(err) {
+			return admission.Allowed("")
+		}
+		return webhook.Errored(http.StatusInternalServerError, err)
+	}
+
+	if vm.Status.CurrentSnapshot == nil ||
+		vm.Status.CurrentSnapshot.Name != vmSnapshot.Name {
+
+		return admission.Allowed("")
+	}
+
+	clones, err := vmopv1util.GetLinkedClones(ctx, v.client, vm.Namespace, vm.Name)
+	if err != nil {
+		return webhook.Errored(http.StatusInternalServerError, err)
+	}
+
+	var validationErrs []string
+	if len(clones) > 0 {
+		validationErrs = append(validationErrs,
+			fmt.Sprintf(linkedClonesExistFmt, strings.Join(clones, ", ")))
+	}
+
+	return common.BuildValidationResponse(ctx, nil, validationErrs, nil)
+}
+
+func (v validator) ValidateUpdate(ctx *pkgctx.WebhookRequestContext) admission.Response {
+	vmSnapshot, err := v.vmSnapshotFromUnstructured(ctx.Obj)
+	if err != nil {
+		return webhook.Errored(http.StatusBadRequest, err)
+	}
+
+	oldVMSnapshot, err := v.vmSnapshotFromUnstructured(ctx.OldObj)
+	if err != nil {
+		return webhook.Errored(http.StatusBadRequest, err)
+	}
+
+	var fieldErrs field.ErrorList
+	fieldErrs = append(fieldErrs, v.validateImmutableFields(vmSnapshot, oldVMSnapshot)...)
+
+	validationErrs := make([]string, 0, len(fieldErrs))
+	for _, fieldErr := range fieldErrs {
+		validationErrs = append(validationErrs, fieldErr.Error())
+	}
+
+	return common.BuildValidationResponse(ctx, nil, validationErrs, nil)
+}
+
+func (v validator) validateSpec(vmSnapshot *vmopv1.VirtualMachineSnapshot) field.ErrorList {
+	var allErrs field.ErrorList
+	vmRefPath := field.NewPath("spec", "vmRef")
+
+	vmRef := vmSnapshot.Spec.VMRef
+	if vmRef == nil {
+		allErrs = append(allErrs, field.Required(vmRefPath, ""))
+		return allErrs
+	}
+
+	if vmRef.Name == "" {
+		allErrs = append(allErrs, field.Required(vmRefPath.Child("name"), ""))
+	}
+
+	if vmRef.Kind != "" && vmRef.Kind != "VirtualMachine" {
+		allErrs = append(allErrs, field.NotSupported(vmRefPath.Child("kind"), vmRef.Kind, []string{"VirtualMachine"}))
+	}
+
+	return allErrs
+}
+
+func (v validator) validateImmutableFields(vmSnapshot, oldVMSnapshot *vmopv1.VirtualMachineSnapshot) field.ErrorList {
+	var allErrs field.ErrorList
+	specPath := field.NewPath("spec")
+
+	allErrs = append(allErrs, validation.ValidateImmutableField(vmSnapshot.Spec.VMRef, oldVMSnapshot.Spec.VMRef, specPath.Child("vmRef"))...)
+	allErrs = append(allErrs, validation.ValidateImmutableField(vmSnapshot.Spec.Memory, oldVMSnapshot.Spec.Memory, specPath.Child("memory"))...)
+	allErrs = append(allErrs, validation.ValidateImmutableField(vmSnapshot.Spec.Quiesce, oldVMSnapshot.Spec.Quiesce, specPath.Child("quiesce"))...)
+	allErrs = append(allErrs, validation.ValidateImmutableField(vmSnapshot.Spec.Description, oldVMSnapshot.Spec.Description, specPath.Child("description"))...)
+
+	return allErrs
+}
+
+// vmSnapshotFromUnstructured returns the VirtualMachineSnapshot from the
+// unstructured object.
+func (v validator) vmSnapshotFromUnstructured(obj runtime.Unstructured) (*vmopv1.VirtualMachineSnapshot, error) {
+	vmSnapshot := &vmopv1.VirtualMachineSnapshot{}
+	if err := v.converter.FromUnstructured(obj.UnstructuredContent(), vmSnapshot); err != nil {
+		return nil, err
+	}
+	return vmSnapshot, nil
+}
@@ -0,0 +1,255 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package validation_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	vmopv1common "github.com/vmware-tanzu/vm-operator/api/v1alpha3/common"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func unitTests() {
+	Describe(
+		"Create",
+		Label(
+			testlabels.Create,
+			testlabels.V1Alpha3,
+			testlabels.Validation,
+			testlabels.Webhook,
+		),
+		unitTestsValidateCreate,
+	)
+	Describe(
+		"Update",
+		Label(
+			testlabels.Update,
+			testlabels.V1Alpha3,
+			testlabels.Validation,
+			testlabels.Webhook,
+		),
+		unitTestsValidateUpdate,
+	)
+	Describe(
+		"Delete",
+		Label(
+			testlabels.Delete,
+			testlabels.V1Alpha3,
+			testlabels.Validation,
+			testlabels.Webhook,
+		),
+		unitTestsValidateDelete,
+	)
+}
+
+type unitValidatingWebhookContext struct {
+	builder.UnitTestContextForValidatingWebhook
+	vmSnapshot    *vmopv1.VirtualMachineSnapshot
+	oldVMSnapshot *vmopv1.VirtualMachineSnapshot
+}
+
+func newUnitTestContextForValidatingWebhook(isUpdate bool) *unitValidatingWebhookContext {
+	vmSnapshot := builder.DummyVirtualMachineSnapshot("some-namespace", "some-name", "some-vm-name")
+	obj, err := builder.ToUnstructured(vmSnapshot)
+	Expect(err).ToNot(HaveOccurred())
+
+	var oldVMSnapshot *vmopv1.VirtualMachineSnapshot
+	var oldObj *unstructured.Unstructured
+
+	if isUpdate {
+		oldVMSnapshot = vmSnapshot.DeepCopy()
+		oldObj, err = builder.ToUnstructured(oldVMSnapshot)
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	return &unitValidatingWebhookContext{
+		UnitTestContextForValidatingWebhook: *suite.NewUnitTestContextForValidatingWebhook(obj, oldObj),
+		vmSnapshot:                          vmSnapshot,
+		oldVMSnapshot:                       oldVMSnapshot,
+	}
+}
+
+func unitTestsValidateCreate() {
+	var (
+		ctx *unitValidatingWebhookContext
+	)
+
+	type createArgs struct {
+		nilVMRef         bool
+		emptyVMName      bool
+		invalidKind      bool
+		memoryAndQuiesce bool
+	}
+
+	validateCreate := func(args createArgs, expectedAllowed bool, expectedReason string) {
+		var err error
+
+		if args.nilVMRef {
+			ctx.vmSnapshot.Spec.VMRef = nil
+		}
+		if args.emptyVMName {
+			ctx.vmSnapshot.Spec.VMRef.Name = ""
+		}
+		if args.invalidKind {
+			ctx.vmSnapshot.Spec.VMRef.Kind = "VirtualMachineClass"
+		}
+		if args.memoryAndQuiesce {
+			ctx.vmSnapshot.Spec.Memory = true
+			ctx.vmSnapshot.Spec.Quiesce = true
+		}
+
+		ctx.WebhookRequestContext.Obj, err = builder.ToUnstructured(ctx.vmSnapshot)
+		Expect(err).ToNot(HaveOccurred())
+
+		response := ctx.ValidateCreate(&ctx.WebhookRequestContext)
+		Expect(response.Allowed).To(Equal(expectedAllowed))
+		if expectedReason != "" {
+			Expect(string(response.Result.Reason)).To(ContainSubstring(expectedReason))
+		}
+	}
+
+	BeforeEach(func() {
+		ctx = newUnitTestContextForValidatingWebhook(false)
+	})
+	AfterEach(func() {
+		ctx = nil
+	})
+
+	DescribeTable("create table", validateCreate,
+		Entry("should allow valid", createArgs{}, true, ""),
+		Entry("should allow memory and quiesce", createArgs{memoryAndQuiesce: true}, true, ""),
+		Entry("should deny nil vmRef", createArgs{nilVMRef: true}, false, "spec.vmRef: Required value"),
+		Entry("should deny empty vm name", createArgs{emptyVMName: true}, false, "spec.vmRef.name: Required value"),
+		Entry("should deny invalid kind", createArgs{invalidKind: true}, false, `spec.vmRef.kind: Unsupported value: "VirtualMachineClass"`),
+	)
+}
+
+func unitTestsValidateUpdate() {
+	var (
+		ctx *unitValidatingWebhookContext
+	)
+
+	type updateArgs struct {
+		updateVMName      bool
+		updateMemory      bool
+		updateQuiesce     bool
+		updateDescription bool
+	}
+
+	validateUpdate := func(args updateArgs, expectedAllowed bool, expectedReason string) {
+		var err error
+
+		if args.updateVMName {
+			ctx.vmSnapshot.Spec.VMRef.Name = "new-vm-name"
+		}
+		if args.updateMemory {
+			ctx.vmSnapshot.Spec.Memory = true
+		}
+		if args.updateQuiesce {
+			ctx.vmSnapshot.Spec.Quiesce = true
+		}
+		if args.updateDescription {
+			ctx.vmSnapshot.Spec.Description = "new-description"
+		}
+
+		ctx.WebhookRequestContext.Obj, err = builder.ToUnstructured(ctx.vmSnapshot)
+		Expect(err).ToNot(HaveOccurred())
+
+		response := ctx.ValidateUpdate(&ctx.WebhookRequestContext)
+		Expect(response.Allowed).To(Equal(expectedAllowed))
+		if expectedReason != "" {
+			Expect(string(response.Result.Reason)).To(ContainSubstring(expectedReason))
+		}
+	}
+
+	BeforeEach(func() {
+		ctx = newUnitTestContextForValidatingWebhook(true)
+	})
+	AfterEach(func() {
+		ctx = nil
+	})
+
+	DescribeTable("update table", validateUpdate,
+		Entry("should allow", updateArgs{}, true, ""),
+		Entry("should deny vmRef change", updateArgs{updateVMName: true}, false, "spec.vmRef: Invalid value"),
+		Entry("should deny memory change", updateArgs{updateMemory: true}, false, "spec.memory: Invalid value: true: field is immutable"),
+		Entry("should deny quiesce change", updateArgs{updateQuiesce: true}, false, "spec.quiesce: Invalid value: true: field is immutable"),
+		Entry("should deny description change", updateArgs{updateDescription: true}, false, `spec.description: Invalid value: "new-description": field is immutable`),
+	)
+}
+
+func unitTestsValidateDelete() {
+	var (
+		ctx      *unitValidatingWebhookContext
+		response admission.Response
+	)
+
+	BeforeEach(func() {
+		ctx = newUnitTestContextForValidatingWebhook(false)
+	})
+	AfterEach(func() {
+		ctx = nil
+	})
+
+	When("the delete is performed", func() {
+		JustBeforeEach(func() {
+			response = ctx.ValidateDelete(&ctx.WebhookRequestContext)
+		})
+
+		It("should allow the request", func() {
+			Expect(response.Allowed).To(BeTrue())
+			Expect(response.Result).ToNot(BeNil())
+		})
+
+		When("the snapshot is the current snapshot of a VM", func() {
+			var vm *vmopv1.VirtualMachine
+
+			BeforeEach(func() {
+				vm = builder.DummyVirtualMachine()
+				vm.Name = ctx.vmSnapshot.Spec.VMRef.Name
+				vm.Namespace = ctx.vmSnapshot.Namespace
+				Expect(ctx.Client.Create(ctx, vm)).To(Succeed())
+				vm.Status.CurrentSnapshot = &vmopv1common.LocalObjectRef{
+					APIVersion: vmopv1.GroupVersion.String(),
+					Kind:       "VirtualMachineSnapshot",
+					Name:       ctx.vmSnapshot.Name,
+				}
+				Expect(ctx.Client.Status().Update(ctx, vm)).To(Succeed())
+			})
+
+			It("should allow the request", func() {
+				Expect(response.Allowed).To(BeTrue())
+			})
+
+			When("the VM is the source of a linked clone", func() {
+				BeforeEach(func() {
+					clone := builder.DummyVirtualMachine()
+					clone.Name = "some-clone"
+					clone.Namespace = vm.Namespace
+					clone.Spec.Image = &vmopv1.VirtualMachineImageRef{
+						Kind: "VirtualMachine",
+						Name: vm.Name,
+					}
+					clone.Spec.Clone = &vmopv1.VirtualMachineCloneSpec{
+						Mode: vmopv1.VirtualMachineCloneModeLinked,
+					}
+					Expect(ctx.Client.Create(ctx, clone)).To(Succeed())
+				})
+
+				It("should deny the request", func() {
+					Expect(response.Allowed).To(BeFalse())
+					Expect(string(response.Result.Reason)).To(ContainSubstring(
+						"cannot delete the current snapshot of a VM while linked clones depend on it: some-clone"))
+				})
+			})
+		})
+	})
+}
@@ -23,12 +23,15 @@ import (
 	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
 	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachinewebconsolerequest"
 	"github.com/vmware-tanzu/vm-operator/pkg/builder"
+	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
 	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
 	"github.com/vmware-tanzu/vm-operator/webhooks/common"
 )
 
 const (
 	webHookName = "default"
+
+	serialConsoleProxyNotConfigured = "the serial console proxy is not configured"
 )
 
 // +kubebuilder:webhook:verbs=create;update,path=/default-validate-vmoperator-vmware-com-v1alpha3-virtualmachinewebconsolerequest,mutating=false,failurePolicy=fail,groups=vmoperator.vmware.com,resources=virtualmachinewebconsolerequests,versions=v1alpha3,name=default.validating.virtualmachinewebconsolerequest.v1alpha3.vmoperator.vmware.com,sideEffects=None,admissionReviewVersions=v1;v1beta1
@@ -116,10 +119,25 @@ func (v validator) validateSpec(ctx *pkgctx.WebhookRequestContext, wcr *vmopv1.V
 
 	fieldErrs = append(fieldErrs, v.validateVirtualMachineName(specPath.Child("Name"), wcr)...)
 	fieldErrs = append(fieldErrs, v.validatePublicKey(specPath.Child("publicKey"), wcr.Spec.PublicKey)...)
+	fieldErrs = append(fieldErrs, v.validateConsoleType(ctx, specPath.Child("consoleType"), wcr.Spec.ConsoleType)...)
 
 	return fieldErrs
 }
 
+func (v validator) validateConsoleType(
+	ctx *pkgctx.WebhookRequestContext,
+	path *field.Path,
+	consoleType vmopv1.VirtualMachineWebConsoleType) field.ErrorList {
+
+	var allErrs field.ErrorList
+
+	if consoleType == vmopv1.VirtualMachineWebConsoleTypeSerial && pkgcfg.FromContext(ctx).SerialConsoleProxyURI == "" {
+		allErrs = append(allErrs, field.Forbidden(path, serialConsoleProxyNotConfigured))
+	}
+
+	return allErrs
+}
+
 func (v validator) validateVirtualMachineName(path *field.Path, wcr *vmopv1.VirtualMachineWebConsoleRequest) field.ErrorList {
 	var allErrs field.ErrorList
 
@@ -16,6 +16,7 @@ import (
 
 	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
 	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachinewebconsolerequest"
+	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
 	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
 	"github.com/vmware-tanzu/vm-operator/test/builder"
 )
@@ -96,6 +97,8 @@ func unitTestsValidateCreate() {
 		emptyVirtualMachineName bool
 		emptyPublicKey          bool
 		invalidPublicKey        bool
+		serialConsole           bool
+		serialConsoleProxy      bool
 	}
 
 	validateCreate := func(args createArgs, expectedAllowed bool, expectedReason string, expectedErr error) {
@@ -110,6 +113,14 @@ func unitTestsValidateCreate() {
 		if args.invalidPublicKey {
 			ctx.wcr.Spec.PublicKey = "invalid-public-key"
 		}
+		if args.serialConsole {
+			ctx.wcr.Spec.ConsoleType = vmopv1.VirtualMachineWebConsoleTypeSerial
+		}
+		if args.serialConsoleProxy {
+			pkgcfg.SetContext(ctx, func(config *pkgcfg.Config) {
+				config.SerialConsoleProxyURI = "telnets://vspc.local:13370"
+			})
+		}
 
 		ctx.WebhookRequestContext.Obj, err = builder.ToUnstructured(ctx.wcr)
 		Expect(err).ToNot(HaveOccurred())
@@ -136,6 +147,8 @@ func unitTestsValidateCreate() {
 		Entry("should deny empty virtualmachinename", createArgs{emptyVirtualMachineName: true}, false, "spec.Name: Required value", nil),
 		Entry("should deny empty publickey", createArgs{emptyPublicKey: true}, false, "spec.publicKey: Required value", nil),
 		Entry("should deny invalid publickey", createArgs{invalidPublicKey: true}, false, "spec.publicKey: Invalid value: \"\": invalid public key format", nil),
+		Entry("should allow serial console when the proxy is configured", createArgs{serialConsole: true, serialConsoleProxy: true}, true, nil, nil),
+		Entry("should deny serial console when the proxy is not configured", createArgs{serialConsole: true}, false, "spec.consoleType: Forbidden: the serial console proxy is not configured", nil),
 	)
 }
 
@@ -15,11 +15,15 @@ import (
 	"github.com/vmware-tanzu/vm-operator/webhooks/persistentvolumeclaim"
 	"github.com/vmware-tanzu/vm-operator/webhooks/unifiedstoragequota"
 	"github.com/vmware-tanzu/vm-operator/webhooks/virtualmachine"
+	"github.com/vmware-tanzu/vm-operator/webhooks/virtualmachinebackuppolicy"
 	"github.com/vmware-tanzu/vm-operator/webhooks/virtualmachineclass"
+	"github.com/vmware-tanzu/vm-operator/webhooks/virtualmachinedeployment"
 	"github.com/vmware-tanzu/vm-operator/webhooks/virtualmachinepublishrequest"
 	"github.com/vmware-tanzu/vm-operator/webhooks/virtualmachinereplicaset"
+	"github.com/vmware-tanzu/vm-operator/webhooks/virtualmachinerestorerequest"
 	"github.com/vmware-tanzu/vm-operator/webhooks/virtualmachineservice"
 	"github.com/vmware-tanzu/vm-operator/webhooks/virtualmachinesetresourcepolicy"
+	"github.com/vmware-tanzu/vm-operator/webhooks/virtualmachinesnapshot"
 	"github.com/vmware-tanzu/vm-operator/webhooks/virtualmachinewebconsolerequest"
 )
 
@@ -54,6 +58,9 @@ func AddToManager(ctx *pkgctx.ControllerManagerContext, mgr ctrlmgr.Manager) err
 		if err := virtualmachinereplicaset.AddToManager(ctx, mgr); err != nil {
 			return fmt.Errorf("failed to initialize VirtualMachineReplicaSet webhooks: %w", err)
 		}
+		if err := virtualmachinedeployment.AddToManager(ctx, mgr); err != nil {
+			return fmt.Errorf("failed to initialize VirtualMachineDeployment webhooks: %w", err)
+		}
 	}
 
 	if pkgcfg.FromContext(ctx).Features.UnifiedStorageQuota {
@@ -62,5 +69,23 @@ func AddToManager(ctx *pkgctx.ControllerManagerContext, mgr ctrlmgr.Manager) err
 		}
 	}
 
+	if pkgcfg.FromContext(ctx).Features.VMSnapshots {
+		if err := virtualmachinesnapshot.AddToManager(ctx, mgr); err != nil {
+			return fmt.Errorf("failed to initialize VirtualMachineSnapshot webhooks: %w", err)
+		}
+	}
+
+	if pkgcfg.FromContext(ctx).Features.VMRestoreRequest {
+		if err := virtualmachinerestorerequest.AddToManager(ctx, mgr); err != nil {
+			return fmt.Errorf("failed to initialize VirtualMachineRestoreRequest webhooks: %w", err)
+		}
+	}
+
+	if pkgcfg.FromContext(ctx).Features.VMBackupPolicy {
+		if err := virtualmachinebackuppolicy.AddToManager(ctx, mgr); err != nil {
+			return fmt.Errorf("failed to initialize VirtualMachineBackupPolicy webhooks: %w", err)
+		}
+	}
+
 	return nil
 }